@@ -6,17 +6,24 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/animal-ekarte/backend/internal/analyzer"
+	"github.com/animal-ekarte/backend/internal/audit"
+	"github.com/animal-ekarte/backend/internal/auth"
 	"github.com/animal-ekarte/backend/internal/config"
 	"github.com/animal-ekarte/backend/internal/handler"
+	"github.com/animal-ekarte/backend/internal/invoice"
 	"github.com/animal-ekarte/backend/internal/logger"
 	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/reminder"
 	"github.com/animal-ekarte/backend/internal/repository"
 	"github.com/animal-ekarte/backend/internal/service"
+	"github.com/animal-ekarte/backend/internal/storage"
 
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -59,6 +66,10 @@ func main() {
 
 	// 設定読み込み
 	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		logger.Error("invalid configuration", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
 	// DB接続
 	db, err := repository.NewDB(cfg)
@@ -68,19 +79,29 @@ func main() {
 	}
 	logger.Info("database connected successfully")
 
+	// 監査ログ（全エンティティの書き込みをaudit_eventsに記録）
+	if err := db.Use(audit.NewPlugin()); err != nil {
+		logger.Error("failed to register audit plugin", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
 	// マイグレーション（全モデルを依存関係順に登録）
 	if err := db.AutoMigrate(
 		// 独立テーブル
 		&model.Clinic{},
 		&model.InventoryItem{},
 		&model.Cage{},
+		&model.LabReferenceRange{},
 		// Clinic依存
 		&model.Staff{},
 		// InventoryItem依存
 		&model.MasterItem{},
+		&model.MasterItemPrice{},
 		// コアテーブル
 		&model.Owner{},
 		&model.Pet{},
+		&model.OwnerMerge{},
+		&model.PetOwnership{},
 		// Pet依存
 		&model.MedicalRecord{},
 		&model.Reservation{},
@@ -88,6 +109,9 @@ func main() {
 		&model.Vaccination{},
 		&model.Trimming{},
 		&model.Examination{},
+		// Examination依存
+		&model.AnalyzerImport{},
+		&model.Attachment{},
 		&model.Accounting{},
 		// Hospitalization依存
 		&model.CarePlanItem{},
@@ -96,20 +120,199 @@ func main() {
 		&model.Vital{},
 		&model.CareLog{},
 		&model.StaffNote{},
+		// Vital依存
+		&model.PetMeasurement{},
 		// Accounting依存
 		&model.AccountingItem{},
+		// MedicalRecord依存
+		&model.MedicalRecordAmendment{},
+		&model.MedicalRecordItem{},
+		// 監査ログ
+		&model.AuditEvent{},
+		// Staff依存
+		&model.RefreshToken{},
+		// Vaccination依存
+		&model.VaccinationReminder{},
+		// InventoryItem依存
+		&model.InventoryLot{},
+		&model.StockMovement{},
 	); err != nil {
 		logger.Error("failed to migrate database", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
-	logger.Info("database migrated successfully (20 tables)")
+	logger.Info("database migrated successfully (34 tables)")
+
+	// 類似度検索用のpg_trgm拡張を用意し、検索用カラム追加前に登録された飼い主・ペットの検索用の値を補完
+	searchRepo := repository.NewPatientSearchRepository(db)
+	if err := searchRepo.EnsureSearchExtension(context.Background()); err != nil {
+		logger.Error("failed to prepare search extension", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	if n, err := searchRepo.RefreshSearchKeys(context.Background()); err != nil {
+		logger.Error("failed to refresh search keys", slog.String("error", err.Error()))
+		os.Exit(1)
+	} else if n > 0 {
+		logger.Info("search keys refreshed", slog.Int("rows", n))
+	}
 
 	// レイヤー初期化
 	repo := repository.New(db)
 	medicalRecordRepo := repository.NewMedicalRecordRepository(db)
-	svc := service.New(repo, repo, medicalRecordRepo, repo)
+	if err := medicalRecordRepo.EnsureRecordNoSequence(context.Background()); err != nil {
+		logger.Error("failed to prepare medical record number sequence", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	// 全文検索の追加前に登録されたカルテの検索用バイグラムを補完
+	if n, err := medicalRecordRepo.RefreshSearchIndex(context.Background()); err != nil {
+		logger.Error("failed to refresh medical record search index", slog.String("error", err.Error()))
+		os.Exit(1)
+	} else if n > 0 {
+		logger.Info("medical record search index refreshed", slog.Int("rows", n))
+	}
+	reservationRepo := repository.NewReservationRepository(db)
+	auditEventRepo := repository.NewAuditEventRepository(db)
+	authRepo := repository.NewAuthRepository(db)
+	accountingRepo := repository.NewAccountingRepository(db)
+	masterItemRepo := repository.NewMasterItemRepository(db)
+	clinicRepo := repository.NewClinicRepository(db)
+	hospitalizationRepo := repository.NewHospitalizationRepository(db)
+	if err := hospitalizationRepo.EnsureHospitalizationNoSequence(context.Background()); err != nil {
+		logger.Error("failed to prepare hospitalization number sequence", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	dailyRecordRepo := repository.NewDailyRecordRepository(db)
+	vaccinationRepo := repository.NewVaccinationRepository(db)
+	inventoryRepo := repository.NewInventoryRepository(db)
+	ownerMergeRepo := repository.NewOwnerMergeRepository(db)
+	petOwnershipRepo := repository.NewPetOwnershipRepository(db)
+	trimmingRepo := repository.NewTrimmingRepository(db)
+	examinationRepo := repository.NewExaminationRepository(db)
+	if err := examinationRepo.EnsureAccessionNoSequence(context.Background()); err != nil {
+		logger.Error("failed to prepare examination accession number sequence", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	analyzerImportRepo := repository.NewAnalyzerImportRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	petMeasurementRepo := repository.NewPetMeasurementRepository(db)
+	// 飼い主の履歴の追加前に登録されたペットの履歴を補完
+	if n, err := petOwnershipRepo.BackfillPetOwnerships(context.Background()); err != nil {
+		logger.Error("failed to backfill pet ownerships", slog.String("error", err.Error()))
+		os.Exit(1)
+	} else if n > 0 {
+		logger.Info("pet ownerships backfilled", slog.Int64("rows", n))
+	}
+	// 測定値の推移の追加前に記録されたペットの体重・入院中のバイタルを補完
+	if n, err := petMeasurementRepo.BackfillPetMeasurements(context.Background()); err != nil {
+		logger.Error("failed to backfill pet measurements", slog.String("error", err.Error()))
+		os.Exit(1)
+	} else if n > 0 {
+		logger.Info("pet measurements backfilled", slog.Int64("rows", n))
+	}
+	if cfg.JWTSecret == config.DefaultJWTSecret {
+		logger.Warn("JWT_SECRET is not set; using insecure development secret (refused in release mode)")
+	}
+	tokens := auth.NewTokenManager(cfg.JWTSecret, cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	documentFont, err := invoice.LoadFont(cfg.PDFFontPath)
+	if err != nil {
+		logger.Error("failed to load document font", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	// 添付ファイルの保存先（ATTACHMENT_STORAGE=s3でMinIOなどのS3互換ストレージ）
+	var attachmentStore storage.Store = storage.NewLocalStore(cfg.AttachmentDir)
+	if cfg.AttachmentStorage == "s3" {
+		s3Store, err := storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.AttachmentS3Endpoint,
+			Region:    cfg.AttachmentS3Region,
+			Bucket:    cfg.AttachmentS3Bucket,
+			AccessKey: cfg.AttachmentS3AccessKey,
+			SecretKey: cfg.AttachmentS3SecretKey,
+		})
+		if err != nil {
+			logger.Error("failed to configure attachment storage", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+		attachmentStore = s3Store
+	}
+	svc := service.New(repo, repo, medicalRecordRepo, repo,
+		service.WithReservationRepository(reservationRepo),
+		service.WithAuditEventRepository(auditEventRepo),
+		service.WithAuthRepository(authRepo),
+		service.WithAccountingRepository(accountingRepo),
+		service.WithMasterItemRepository(masterItemRepo),
+		service.WithClinicRepository(clinicRepo),
+		service.WithHospitalizationRepository(hospitalizationRepo),
+		service.WithDailyRecordRepository(dailyRecordRepo),
+		service.WithVaccinationRepository(vaccinationRepo),
+		service.WithInventoryRepository(inventoryRepo),
+		service.WithPatientSearchRepository(searchRepo),
+		service.WithOwnerMergeRepository(ownerMergeRepo),
+		service.WithPetOwnershipRepository(petOwnershipRepo),
+		service.WithTrimmingRepository(trimmingRepo),
+		service.WithExaminationRepository(examinationRepo),
+		service.WithAnalyzerImportRepository(analyzerImportRepo),
+		service.WithAttachmentRepository(attachmentRepo),
+		service.WithPetMeasurementRepository(petMeasurementRepo),
+		service.WithAttachmentStorage(attachmentStore, cfg.AttachmentMaxSize),
+		service.WithVaccinationReminders(cfg.ReminderLead,
+			reminder.NewFileNotifier(filepath.Join(cfg.ReminderOutboxDir, "postcards")),
+			reminder.NewSMTPNotifier(reminder.SMTPConfig{
+				Host:      cfg.SMTPHost,
+				Port:      cfg.SMTPPort,
+				Username:  cfg.SMTPUsername,
+				Password:  cfg.SMTPPassword,
+				From:      cfg.SMTPFrom,
+				OutboxDir: filepath.Join(cfg.ReminderOutboxDir, "mail"),
+			}),
+		),
+		service.WithInvoiceRenderer(invoice.NewRenderer(documentFont)),
+		service.WithTokenManager(tokens),
+		service.WithTransactor(repo),
+	)
+
+	// 初期管理者（ADMIN_EMAIL/ADMIN_PASSWORD指定時、未登録なら作成）
+	if cfg.AdminEmail != "" && cfg.AdminPassword != "" {
+		if err := svc.EnsureAdminStaff(context.Background(), "管理者", cfg.AdminEmail, cfg.AdminPassword); err != nil {
+			logger.Error("failed to ensure admin staff", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	}
 	h := handler.New(svc)
 
+	// ワクチン接種案内の定期送付（REMINDER_INTERVAL=0で無効）
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	if cfg.ReminderInterval > 0 {
+		go reminder.RunEvery(jobCtx, cfg.ReminderInterval, func(ctx context.Context) error {
+			_, err := svc.SendVaccinationReminders(ctx)
+			return err
+		})
+	}
+
+	// 検査装置の結果取り込み（ANALYZER_LISTEN_ADDRでTCP受信、ANALYZER_DROP_DIRでファイル取り込み）
+	importAnalyzerMessage := func(ctx context.Context, source string, msg *analyzer.Message) error {
+		record, err := svc.ImportAnalyzerMessage(ctx, source, msg)
+		if err != nil {
+			return err
+		}
+		logger.Info("analyzer result imported",
+			slog.String("source", source),
+			slog.String("accession_no", record.AccessionNo),
+			slog.String("status", record.Status),
+			slog.String("reason", record.Reason),
+		)
+		return nil
+	}
+	if cfg.AnalyzerListenAddr != "" {
+		go func() {
+			if err := analyzer.NewServer(importAnalyzerMessage).ListenAndServe(jobCtx, cfg.AnalyzerListenAddr); err != nil {
+				logger.Error("analyzer listener stopped", slog.String("error", err.Error()))
+			}
+		}()
+	}
+	if cfg.AnalyzerDropDir != "" {
+		go analyzer.WatchDir(jobCtx, cfg.AnalyzerDropDir, cfg.AnalyzerPollInterval, importAnalyzerMessage)
+	}
+
 	// ルーター設定
 	r := gin.Default()
 	h.RegisterRoutes(r)
@@ -145,6 +348,7 @@ func main() {
 	<-quit
 
 	logger.Info("shutting down server...")
+	stopJobs()
 
 	// シャットダウン処理（30秒タイムアウト）
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
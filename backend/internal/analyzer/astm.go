@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"errors"
+	"strings"
+)
+
+// ASTM E1381の制御文字
+const (
+	astmSTX = 0x02
+	astmETX = 0x03
+	astmEOT = 0x04
+	astmENQ = 0x05
+	astmACK = 0x06
+	astmNAK = 0x15
+	astmETB = 0x17
+)
+
+// ParseASTM ASTM E1394の結果メッセージを読み取る
+// Patient（P）レコードのP-3（なければP-4）をペット番号、Order（O）レコードのO-3（なければO-4）を検体番号とし、
+// Result（R）レコードを結果とする。E1381のフレーム（STX・フレーム番号・チェックサム）が残っていれば取り除く。
+func ParseASTM(data []byte) ([]*Message, error) {
+	var records []string
+	for _, record := range splitRecords(data) {
+		if record = strings.TrimSpace(stripASTMFrame(record)); record != "" {
+			records = append(records, record)
+		}
+	}
+	if len(records) == 0 || !strings.HasPrefix(records[0], "H") || len(records[0]) < 5 {
+		return nil, errors.New("astm: message must start with a header record")
+	}
+	header := records[0]
+	fieldSep, repeat, componentSep := header[1:2], header[2:3], header[3:4]
+	machine := component(field(strings.Split(header, fieldSep), 4), repeat, componentSep, 0)
+
+	var (
+		messages  []*Message
+		current   *Message
+		patientID string
+	)
+	newMessage := func() *Message {
+		m := &Message{
+			Format:    FormatASTM,
+			Machine:   machine,
+			PatientID: patientID,
+			Raw:       strings.Join(records, "\r"),
+		}
+		messages = append(messages, m)
+		return m
+	}
+	for _, record := range records[1:] {
+		fields := strings.Split(record, fieldSep)
+		switch fields[0] {
+		case "P":
+			patientID = component(field(fields, 2), repeat, componentSep, 0)
+			if patientID == "" {
+				patientID = component(field(fields, 3), repeat, componentSep, 0)
+			}
+			current = nil
+		case "O":
+			current = newMessage()
+			current.AccessionNo = component(field(fields, 2), repeat, componentSep, 0)
+			if current.AccessionNo == "" {
+				current.AccessionNo = component(field(fields, 3), repeat, componentSep, 0)
+			}
+			current.ObservedAt = parseTimestamp(field(fields, 7))
+		case "R":
+			// 項目IDは ^^^ALT のように4番目の成分に装置の項目コードを持つ
+			testID := field(fields, 2)
+			analyte := component(testID, repeat, componentSep, 3)
+			if analyte == "" {
+				parts := strings.Split(testID, componentSep)
+				analyte = strings.TrimSpace(parts[len(parts)-1])
+			}
+			value := component(field(fields, 3), repeat, componentSep, 0)
+			if analyte == "" || value == "" {
+				continue
+			}
+			if current == nil {
+				current = newMessage()
+			}
+			current.Results = append(current.Results, newResult(
+				analyte,
+				"",
+				value,
+				// 単位は 10^3/uL のように成分区切りを含むことがあるため分割しない
+				component(field(fields, 4), repeat, fieldSep, 0),
+				component(field(fields, 6), repeat, componentSep, 0),
+				true,
+			))
+			if current.ObservedAt == nil {
+				current.ObservedAt = parseTimestamp(field(fields, 12))
+			}
+		case "L":
+			current = nil
+		}
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("astm: message has no order or result records")
+	}
+	return messages, nil
+}
+
+// stripASTMFrame E1381のフレーム（STX・フレーム番号、ETB/ETX・チェックサム）を取り除く
+func stripASTMFrame(record string) string {
+	if len(record) >= 2 && record[0] == astmSTX {
+		record = record[2:]
+	}
+	if i := strings.IndexAny(record, string([]byte{astmETX, astmETB})); i >= 0 {
+		record = record[:i]
+	}
+	return record
+}
+
+// astmChecksum フレーム番号から終端文字（ETB/ETX）までのバイトの和の下位8ビット
+func astmChecksum(frame []byte) byte {
+	var sum byte
+	for _, b := range frame {
+		sum += b
+	}
+	return sum
+}
@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// 取り込み後のファイルの移動先（監視ディレクトリ内）
+const (
+	processedDir = "processed"
+	failedDir    = "failed"
+)
+
+// dropExtensions 取り込むファイルの拡張子
+// 書き込み途中のファイルを読まないよう、装置側は別名で書き込んでからこの拡張子に名前を変えること。
+var dropExtensions = map[string]bool{".hl7": true, ".astm": true, ".txt": true}
+
+// WatchDir ctxが終了するまでinterval間隔でdirの結果ファイルを取り込む（起動直後に1回取り込む）
+func WatchDir(ctx context.Context, dir string, interval time.Duration, handler Handler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := ProcessDir(ctx, dir, handler); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "analyzer drop directory scan failed", slog.String("dir", dir), slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDir dirの結果ファイルを名前順に取り込み、取り込んだファイル数を返す
+// 取り込めたファイルはprocessed/、読み取れない・処理に失敗したファイルはfailed/へ移す。
+func ProcessDir(ctx context.Context, dir string, handler Handler) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("read drop directory: %w", err)
+	}
+	for _, sub := range []string{processedDir, failedDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return 0, fmt.Errorf("create %s directory: %w", sub, err)
+		}
+	}
+
+	processed := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.Type().IsRegular() || strings.HasPrefix(name, ".") || !dropExtensions[strings.ToLower(filepath.Ext(name))] {
+			continue
+		}
+		if ctx.Err() != nil {
+			return processed, ctx.Err()
+		}
+		path := filepath.Join(dir, name)
+		dest := processedDir
+		if err := processFile(ctx, path, handler); err != nil {
+			slog.ErrorContext(ctx, "failed to import analyzer file", slog.String("file", path), slog.String("error", err.Error()))
+			dest = failedDir
+		} else {
+			processed++
+		}
+		if err := os.Rename(path, filepath.Join(dir, dest, name)); err != nil {
+			return processed, fmt.Errorf("move analyzer file: %w", err)
+		}
+	}
+	return processed, nil
+}
+
+// processFile 1ファイル分のメッセージを読み取って処理する
+func processFile(ctx context.Context, path string, handler Handler) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	messages, err := Parse(data)
+	if err != nil {
+		return err
+	}
+	source := "file:" + filepath.Base(path)
+	for _, msg := range messages {
+		if err := handler(ctx, source, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// hl7Delimiters MSHで宣言された区切り文字
+type hl7Delimiters struct {
+	field, component, repeat, escape, subcomponent string
+}
+
+// ParseHL7 HL7 v2の結果メッセージ（ORU^R01）を読み取る
+// PID-3（なければPID-2）をペット番号、OBR-2（なければOBR-3）を検体番号とし、OBXを結果とする。
+func ParseHL7(data []byte) ([]*Message, error) {
+	records := splitRecords(data)
+	if len(records) == 0 || !strings.HasPrefix(records[0], "MSH") || len(records[0]) < 8 {
+		return nil, errors.New("hl7: message must start with an MSH segment")
+	}
+	msh := records[0]
+	d := hl7Delimiters{
+		field:        msh[3:4],
+		component:    msh[4:5],
+		repeat:       msh[5:6],
+		escape:       msh[6:7],
+		subcomponent: msh[7:8],
+	}
+	// MSHは区切り文字自体がMSH-1のため、MSH-nはn-1番目になる
+	header := strings.Split(msh, d.field)
+	machine := d.unescape(component(field(header, 2), d.repeat, d.component, 0))
+	controlID := strings.TrimSpace(field(header, 9))
+
+	var (
+		messages  []*Message
+		current   *Message
+		patientID string
+	)
+	newMessage := func() *Message {
+		m := &Message{
+			Format:    FormatHL7,
+			ControlID: controlID,
+			Machine:   machine,
+			PatientID: patientID,
+			Raw:       strings.Join(records, "\r"),
+		}
+		messages = append(messages, m)
+		return m
+	}
+	for _, record := range records[1:] {
+		fields := strings.Split(record, d.field)
+		switch fields[0] {
+		case "PID":
+			patientID = d.unescape(component(field(fields, 3), d.repeat, d.component, 0))
+			if patientID == "" {
+				patientID = d.unescape(component(field(fields, 2), d.repeat, d.component, 0))
+			}
+			current = nil
+		case "OBR":
+			current = newMessage()
+			current.AccessionNo = d.unescape(component(field(fields, 2), d.repeat, d.component, 0))
+			if current.AccessionNo == "" {
+				current.AccessionNo = d.unescape(component(field(fields, 3), d.repeat, d.component, 0))
+			}
+			current.ObservedAt = parseTimestamp(field(fields, 7))
+		case "OBX":
+			// 取り消し（D）・測定不能（X）の結果は取り込まない
+			if status := strings.TrimSpace(field(fields, 11)); status == "D" || status == "X" {
+				continue
+			}
+			value := d.unescape(component(field(fields, 5), d.repeat, d.component, 0))
+			analyte := d.unescape(component(field(fields, 3), d.repeat, d.component, 0))
+			if analyte == "" || value == "" {
+				continue
+			}
+			if current == nil {
+				current = newMessage()
+			}
+			valueType := strings.TrimSpace(field(fields, 2))
+			current.Results = append(current.Results, newResult(
+				analyte,
+				d.unescape(component(field(fields, 3), d.repeat, d.component, 1)),
+				value,
+				d.unescape(component(field(fields, 6), d.repeat, d.component, 0)),
+				component(field(fields, 8), d.repeat, d.component, 0),
+				valueType == "" || valueType == "NM" || valueType == "SN",
+			))
+		}
+	}
+	if len(messages) == 0 {
+		return nil, errors.New("hl7: message has no OBR or OBX segments")
+	}
+	return messages, nil
+}
+
+// unescape HL7のエスケープ（\F\ など）を元の文字に戻す
+func (d hl7Delimiters) unescape(value string) string {
+	if !strings.Contains(value, d.escape) {
+		return value
+	}
+	r := strings.NewReplacer(
+		d.escape+"F"+d.escape, d.field,
+		d.escape+"S"+d.escape, d.component,
+		d.escape+"R"+d.escape, d.repeat,
+		d.escape+"T"+d.escape, d.subcomponent,
+		d.escape+"E"+d.escape, d.escape,
+	)
+	return r.Replace(value)
+}
+
+// HL7Ack 受信したメッセージへの応答（ACK）を作る
+// codeはAA（受理）またはAE（エラー）。
+func HL7Ack(controlID, code, text string, now time.Time) []byte {
+	msh := fmt.Sprintf("MSH|^~\\&|EKARTE||||%s||ACK|%s|P|2.5", now.Format("20060102150405"), controlID)
+	msa := fmt.Sprintf("MSA|%s|%s", code, controlID)
+	if text != "" {
+		msa += "|" + strings.NewReplacer("|", " ", "\r", " ", "\n", " ").Replace(text)
+	}
+	return []byte(msh + "\r" + msa + "\r")
+}
@@ -0,0 +1,235 @@
+package analyzer
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MLLP（HL7の最小下位層プロトコル）の区切り文字
+const (
+	mllpStart = 0x0b
+	mllpEnd   = 0x1c
+	mllpCR    = 0x0d
+)
+
+// maxMessageSize 1メッセージの上限（超えた接続は切断する）
+const maxMessageSize = 1 << 20
+
+// Handler 受信した結果メッセージを処理する
+// source は受信経路（tcp:<接続元>、file:<ファイル名>）。エラーを返すとHL7はAE、ファイルはfailed/への移動で送信元に伝える。
+type Handler func(ctx context.Context, source string, msg *Message) error
+
+// Server 検査装置からのTCP接続を受け付ける
+// 接続ごとに先頭のバイトで手順を判別し、HL7はMLLPで受けてACKを返し、ASTMはE1381の手順（ENQ・フレーム・EOT）で受ける。
+type Server struct {
+	handler     Handler
+	idleTimeout time.Duration
+}
+
+// NewServer 結果メッセージの処理を指定してServerを作成
+func NewServer(handler Handler) *Server {
+	return &Server{handler: handler, idleTimeout: 10 * time.Minute}
+}
+
+// ListenAndServe addrで接続を待ち受け、ctxが終了するまで受信を続ける
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("listen analyzer port: %w", err)
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve lnで受け付けた接続を処理する（ctxが終了するとlnを閉じて戻る）
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	stop := context.AfterFunc(ctx, func() { ln.Close() })
+	defer stop()
+
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("accept analyzer connection: %w", err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// serveConn 1接続分のメッセージを受信する（装置が切断するかctxが終了するまで）
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	source := "tcp:" + conn.RemoteAddr().String()
+	r := bufio.NewReader(conn)
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.idleTimeout)); err != nil {
+			return
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			if !errors.Is(err, io.EOF) && ctx.Err() == nil {
+				slog.WarnContext(ctx, "analyzer connection closed", slog.String("source", source), slog.String("error", err.Error()))
+			}
+			return
+		}
+		switch b {
+		case mllpStart:
+			err = s.receiveMLLP(ctx, source, r, conn)
+		case astmENQ:
+			err = s.receiveASTM(ctx, source, r, conn)
+		default:
+			// 手順外のバイト（改行・EOTなど）は読み飛ばす
+			continue
+		}
+		if err != nil {
+			if ctx.Err() == nil {
+				slog.WarnContext(ctx, "analyzer connection closed", slog.String("source", source), slog.String("error", err.Error()))
+			}
+			return
+		}
+	}
+}
+
+// receiveMLLP 開始文字に続くHL7メッセージを終了文字まで読み、処理結果をACKで返す
+func (s *Server) receiveMLLP(ctx context.Context, source string, r *bufio.Reader, w io.Writer) error {
+	var buf bytes.Buffer
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if b == mllpEnd {
+			if next, err := r.ReadByte(); err != nil {
+				return err
+			} else if next != mllpCR {
+				return fmt.Errorf("mllp: unexpected byte %#x after end block", next)
+			}
+			break
+		}
+		if buf.Len() >= maxMessageSize {
+			return errors.New("mllp: message too large")
+		}
+		buf.WriteByte(b)
+	}
+
+	messages, err := ParseHL7(buf.Bytes())
+	controlID := ""
+	if err == nil {
+		controlID = messages[0].ControlID
+		err = s.handle(ctx, source, messages)
+	}
+	code, text := "AA", ""
+	if err != nil {
+		code, text = "AE", err.Error()
+	}
+	ack := HL7Ack(controlID, code, text, time.Now())
+	_, err = w.Write(append(append([]byte{mllpStart}, ack...), mllpEnd, mllpCR))
+	return err
+}
+
+// receiveASTM ENQに応答し、EOTまでのフレームをつなげて1メッセージとして処理する
+// チェックサムの合わないフレームはNAKを返し、装置の再送を待つ。
+func (s *Server) receiveASTM(ctx context.Context, source string, r *bufio.Reader, w io.Writer) error {
+	if _, err := w.Write([]byte{astmACK}); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch b {
+		case astmEOT:
+			if buf.Len() == 0 {
+				return nil
+			}
+			messages, err := ParseASTM(buf.Bytes())
+			if err == nil {
+				err = s.handle(ctx, source, messages)
+			}
+			if err != nil {
+				// E1381には受信後のエラー応答がないため、ログに残して接続は続ける
+				slog.ErrorContext(ctx, "failed to import analyzer message", slog.String("source", source), slog.String("error", err.Error()))
+			}
+			return nil
+		case astmSTX:
+			text, ok, err := readASTMFrame(r)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				if _, err := w.Write([]byte{astmNAK}); err != nil {
+					return err
+				}
+				continue
+			}
+			if buf.Len()+len(text) > maxMessageSize {
+				return errors.New("astm: message too large")
+			}
+			buf.Write(text)
+			if _, err := w.Write([]byte{astmACK}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readASTMFrame STXに続く1フレームを読み、本文とチェックサムが合うかを返す
+// フレームは フレーム番号・本文・ETB/ETX・チェックサム（16進2桁）・CR・LF からなる。
+func readASTMFrame(r *bufio.Reader) ([]byte, bool, error) {
+	var frame []byte
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, false, err
+		}
+		if len(frame) >= maxMessageSize {
+			return nil, false, errors.New("astm: frame too large")
+		}
+		frame = append(frame, b)
+		if b == astmETX || b == astmETB {
+			break
+		}
+	}
+	trailer := make([]byte, 4)
+	if _, err := io.ReadFull(r, trailer); err != nil {
+		return nil, false, err
+	}
+	want := fmt.Sprintf("%02X", astmChecksum(frame))
+	if len(frame) < 2 || !strings.EqualFold(string(trailer[:2]), want) {
+		return nil, false, nil
+	}
+	// フレーム番号と終端文字を除いた本文
+	return frame[1 : len(frame)-1], true, nil
+}
+
+// handle 読み取ったメッセージを1件ずつ処理する
+func (s *Server) handle(ctx context.Context, source string, messages []*Message) error {
+	for _, msg := range messages {
+		if err := s.handler(ctx, source, msg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
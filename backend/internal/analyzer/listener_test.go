@@ -0,0 +1,140 @@
+package analyzer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer テスト用にループバックで待ち受け、受信したメッセージを記録する
+func startServer(t *testing.T, fail bool) (string, func() []*Message) {
+	t.Helper()
+	var (
+		mu       sync.Mutex
+		received []*Message
+	)
+	server := NewServer(func(_ context.Context, _ string, msg *Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, msg)
+		if fail {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = server.Serve(ctx, ln)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return ln.Addr().String(), func() []*Message {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]*Message(nil), received...)
+	}
+}
+
+func readMLLP(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	b, err := r.ReadByte()
+	require.NoError(t, err)
+	require.Equal(t, byte(mllpStart), b)
+	body, err := r.ReadString(mllpEnd)
+	require.NoError(t, err)
+	cr, err := r.ReadByte()
+	require.NoError(t, err)
+	require.Equal(t, byte(mllpCR), cr)
+	return strings.TrimSuffix(body, string(rune(mllpEnd)))
+}
+
+func TestServer_MLLP(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		fail bool
+		want string
+	}{
+		{name: "accepts", want: "MSA|AA|MSG0001"},
+		{name: "reports handler errors", fail: true, want: "MSA|AE|MSG0001|database unavailable"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, received := startServer(t, tt.fail)
+			conn, err := net.Dial("tcp", addr)
+			require.NoError(t, err)
+			defer conn.Close()
+			require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+			_, err = conn.Write([]byte(string(rune(mllpStart)) + hl7Sample + string([]byte{mllpEnd, mllpCR})))
+			require.NoError(t, err)
+
+			ack := readMLLP(t, bufio.NewReader(conn))
+			assert.Contains(t, ack, tt.want)
+			require.Len(t, received(), 1)
+			assert.Equal(t, "100023", received()[0].AccessionNo)
+		})
+	}
+}
+
+func TestServer_ASTM(t *testing.T) {
+	addr, received := startServer(t, false)
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+	r := bufio.NewReader(conn)
+
+	expect := func(want byte) {
+		t.Helper()
+		b, err := r.ReadByte()
+		require.NoError(t, err)
+		require.Equal(t, want, b)
+	}
+	frame := func(n int, text string, end byte, checksum string) []byte {
+		body := append([]byte(fmt.Sprintf("%d%s", n, text)), end)
+		if checksum == "" {
+			checksum = fmt.Sprintf("%02X", astmChecksum(body))
+		}
+		return append(append([]byte{astmSTX}, body...), []byte(checksum+"\r\n")...)
+	}
+
+	_, err = conn.Write([]byte{astmENQ})
+	require.NoError(t, err)
+	expect(astmACK)
+
+	// レコードの途中で分割された中間フレーム（ETB）と最終フレーム（ETX）をつなげて読む
+	half := len(astmSample) / 2
+	_, err = conn.Write(frame(1, astmSample[:half], astmETB, ""))
+	require.NoError(t, err)
+	expect(astmACK)
+
+	// チェックサムの合わないフレームはNAKを返し、再送を受け付ける
+	_, err = conn.Write(frame(2, astmSample[half:], astmETX, "00"))
+	require.NoError(t, err)
+	expect(astmNAK)
+	_, err = conn.Write(frame(2, astmSample[half:], astmETX, ""))
+	require.NoError(t, err)
+	expect(astmACK)
+
+	_, err = conn.Write([]byte{astmEOT})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(received()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	msg := received()[0]
+	assert.Equal(t, "100024", msg.AccessionNo)
+	assert.Len(t, msg.Results, 2)
+}
@@ -0,0 +1,117 @@
+// Package analyzer reads result messages sent by in-house lab analyzers (HL7 v2 ORU and ASTM E1394)
+// over TCP (MLLP / ASTM E1381) or from a drop directory.
+package analyzer
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// メッセージの形式
+const (
+	FormatHL7  = "hl7"
+	FormatASTM = "astm"
+)
+
+// ErrUnknownFormat HL7・ASTMのいずれでもないメッセージ
+var ErrUnknownFormat = errors.New("unknown analyzer message format")
+
+// Message 1検体分の検査結果
+// HL7はOBRごと、ASTMはOrderレコードごとに1件とする。
+type Message struct {
+	Format      string
+	ControlID   string     // HL7のメッセージ制御ID（MSH-10）。応答に使う
+	Machine     string     // 送信元の装置名
+	AccessionNo string     // 検体番号（検査の受付番号）
+	PatientID   string     // 患者ID（ペット番号）
+	ObservedAt  *time.Time // 測定日時
+	Results     []Result
+	Raw         string // 受信したメッセージ全体
+}
+
+// Result 1項目の検査結果
+type Result struct {
+	Analyte   string   // 項目コード
+	Name      string   // 項目名
+	Value     *float64 // 数値の結果
+	TextValue string   // 数値として読めない結果（陰性、<0.1 など）
+	Unit      string
+	Flag      string // 装置の判定（参考として持つだけで、判定は基準範囲で行う）
+}
+
+// Parse 先頭のレコードから形式を判別して結果メッセージを読み取る
+func Parse(data []byte) ([]*Message, error) {
+	text := strings.TrimLeft(string(data), "\r\n\t \x0b")
+	switch {
+	case strings.HasPrefix(text, "MSH"):
+		return ParseHL7(data)
+	case strings.HasPrefix(text, "H"), strings.HasPrefix(text, "\x02"):
+		return ParseASTM(data)
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// splitRecords CR・LFで区切られたレコードを空行を除いて返す
+func splitRecords(data []byte) []string {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\r"))
+	data = bytes.ReplaceAll(data, []byte("\n"), []byte("\r"))
+	var records []string
+	for _, line := range strings.Split(string(data), "\r") {
+		if line = strings.TrimSpace(line); line != "" {
+			records = append(records, line)
+		}
+	}
+	return records
+}
+
+// field i番目の項目（範囲外は空）
+func field(fields []string, i int) string {
+	if i < 0 || i >= len(fields) {
+		return ""
+	}
+	return fields[i]
+}
+
+// component 項目のi番目の成分（繰り返しは先頭のみ使う）
+func component(value, repeat, sep string, i int) string {
+	if repeat != "" {
+		value, _, _ = strings.Cut(value, repeat)
+	}
+	return strings.TrimSpace(field(strings.Split(value, sep), i))
+}
+
+// newResult 結果の値を数値として読めれば数値、読めなければ文字列として持つ
+func newResult(analyte, name, value, unit, flag string, numeric bool) Result {
+	result := Result{Analyte: analyte, Name: name, Unit: unit, Flag: flag}
+	value = strings.TrimSpace(value)
+	if numeric {
+		if v, err := strconv.ParseFloat(value, 64); err == nil {
+			result.Value = &v
+			return result
+		}
+	}
+	result.TextValue = value
+	return result
+}
+
+// parseTimestamp HL7・ASTMの日時（YYYYMMDD[HHMM[SS]]、院内の現地時刻）を読み取る
+func parseTimestamp(value string) *time.Time {
+	value = strings.TrimSpace(value)
+	// タイムゾーン・小数秒は使わない
+	if i := strings.IndexAny(value, "+-."); i >= 0 {
+		value = value[:i]
+	}
+	for _, layout := range []string{"20060102150405", "200601021504", "20060102"} {
+		if len(value) != len(layout) {
+			continue
+		}
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return &t
+		}
+	}
+	return nil
+}
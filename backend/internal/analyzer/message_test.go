@@ -0,0 +1,105 @@
+package analyzer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const hl7Sample = "MSH|^~\\&|VetChem^01|CLINIC|EKARTE||20260210103000||ORU^R01|MSG0001|P|2.5\r" +
+	"PID|1||P-0012^^^CLINIC||ポチ\r" +
+	"OBR|1|100023||CHEM^生化学|||20260210102500\r" +
+	"OBX|1|NM|ALT^ALT||182|U/L|10-100|H|||F\r" +
+	"OBX|2|NM|BUN^尿素窒素||21.5|mg/dL|9.2-29.2|N|||F\r" +
+	"OBX|3|ST|HW^フィラリア||陰性||||||F\r" +
+	"OBX|4|NM|GLU^GLU||||||||X\r"
+
+const astmSample = "H|\\^&|||CBC-Analyzer^2.1|||||||P|1394-97|20260210110000\r" +
+	"P|1|P-0012\r" +
+	"O|1|100024||^^^CBC|R||20260210105500\r" +
+	"R|1|^^^WBC|12.3|10^3/uL||H||F||||20260210110000\r" +
+	"R|2|^^^HCT|<10|%||L||F\r" +
+	"L|1|N\r"
+
+func TestParseHL7(t *testing.T) {
+	messages, err := Parse([]byte(hl7Sample))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	msg := messages[0]
+	assert.Equal(t, FormatHL7, msg.Format)
+	assert.Equal(t, "VetChem", msg.Machine)
+	assert.Equal(t, "MSG0001", msg.ControlID)
+	assert.Equal(t, "P-0012", msg.PatientID)
+	assert.Equal(t, "100023", msg.AccessionNo)
+	require.NotNil(t, msg.ObservedAt)
+	assert.Equal(t, time.Date(2026, 2, 10, 10, 25, 0, 0, time.Local), *msg.ObservedAt)
+
+	// 測定不能（X）の結果は取り込まない
+	require.Len(t, msg.Results, 3)
+	assert.Equal(t, "ALT", msg.Results[0].Analyte)
+	assert.Equal(t, 182.0, *msg.Results[0].Value)
+	assert.Equal(t, "U/L", msg.Results[0].Unit)
+	assert.Equal(t, "H", msg.Results[0].Flag)
+	assert.Equal(t, "尿素窒素", msg.Results[1].Name)
+	assert.Nil(t, msg.Results[2].Value)
+	assert.Equal(t, "陰性", msg.Results[2].TextValue)
+}
+
+func TestParseASTM(t *testing.T) {
+	messages, err := Parse([]byte(astmSample))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+
+	msg := messages[0]
+	assert.Equal(t, FormatASTM, msg.Format)
+	assert.Equal(t, "CBC-Analyzer", msg.Machine)
+	assert.Equal(t, "P-0012", msg.PatientID)
+	assert.Equal(t, "100024", msg.AccessionNo)
+	require.Len(t, msg.Results, 2)
+	assert.Equal(t, "WBC", msg.Results[0].Analyte)
+	assert.Equal(t, 12.3, *msg.Results[0].Value)
+	assert.Equal(t, "10^3/uL", msg.Results[0].Unit)
+	// 数値として読めない結果は文字列として持つ
+	assert.Nil(t, msg.Results[1].Value)
+	assert.Equal(t, "<10", msg.Results[1].TextValue)
+}
+
+func TestParse_UnknownFormat(t *testing.T) {
+	_, err := Parse([]byte("hello"))
+	assert.ErrorIs(t, err, ErrUnknownFormat)
+}
+
+func TestProcessDir(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.hl7"), []byte(hl7Sample), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.astm"), []byte(astmSample), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "c.txt"), []byte("garbage"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "d.tmp"), []byte(hl7Sample), 0o644))
+
+	var sources []string
+	handler := func(_ context.Context, source string, msg *Message) error {
+		sources = append(sources, source)
+		if msg.Format == FormatASTM {
+			return errors.New("database unavailable")
+		}
+		return nil
+	}
+
+	n, err := ProcessDir(context.Background(), dir, handler)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"file:a.hl7", "file:b.astm"}, sources)
+	assert.FileExists(t, filepath.Join(dir, processedDir, "a.hl7"))
+	assert.FileExists(t, filepath.Join(dir, failedDir, "b.astm"))
+	assert.FileExists(t, filepath.Join(dir, failedDir, "c.txt"))
+	// 対象外の拡張子は残す
+	assert.FileExists(t, filepath.Join(dir, "d.tmp"))
+}
@@ -0,0 +1,31 @@
+// Package audit records every write made through GORM into the audit_events table.
+package audit
+
+import "context"
+
+type (
+	actorKey     struct{}
+	requestIDKey struct{}
+)
+
+// WithActor 操作者をcontextに格納する
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext contextから操作者を取得する（未設定の場合は空文字）
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey{}).(string)
+	return actor
+}
+
+// WithRequestID リクエストIDをcontextに格納する
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext contextからリクエストIDを取得する（未設定の場合は空文字）
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
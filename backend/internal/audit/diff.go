@@ -0,0 +1,112 @@
+package audit
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ignoredColumns 差分に含めないカラム（書き込みのたびに変わるもの、ほかのカラムから作る検索用のもの）
+var ignoredColumns = map[string]bool{
+	"created_at":     true,
+	"updated_at":     true,
+	"search_text":    true,
+	"phone_digits":   true,
+	"search_bigrams": true,
+}
+
+// redactedColumns 値そのものを記録しないカラム（変更があったことのみ記録する）
+var redactedColumns = map[string]bool{
+	"password_hash": true,
+	"token_hash":    true,
+}
+
+// redactedValue 秘匿カラムの値の代わりに記録する文字列
+const redactedValue = "[REDACTED]"
+
+// Diff 変更前後の行から項目単位の差分を作成する
+// 作成時はbeforeに、削除時はafterにnilを渡す。
+func Diff(before, after map[string]any) model.AuditChanges {
+	changes := model.AuditChanges{}
+
+	for column, old := range before {
+		if ignoredColumns[column] {
+			continue
+		}
+		var current any
+		if after != nil {
+			current = after[column]
+		}
+		if !equal(old, current) || after == nil {
+			changes[column] = change(column, old, current)
+		}
+	}
+
+	for column, current := range after {
+		if ignoredColumns[column] {
+			continue
+		}
+		if _, ok := before[column]; ok {
+			continue
+		}
+		if before == nil && normalize(current) == nil {
+			continue
+		}
+		changes[column] = change(column, nil, current)
+	}
+
+	return changes
+}
+
+// change 1項目の変更内容を作成する（秘匿カラムは値を伏せる）
+func change(column string, before, after any) model.AuditChange {
+	before, after = normalize(before), normalize(after)
+	if redactedColumns[column] {
+		if before != nil {
+			before = redactedValue
+		}
+		if after != nil {
+			after = redactedValue
+		}
+	}
+	return model.AuditChange{Before: before, After: after}
+}
+
+// equal 2つのカラム値が等しいかどうか
+func equal(a, b any) bool {
+	a, b = normalize(a), normalize(b)
+	if at, ok := a.(time.Time); ok {
+		bt, ok := b.(time.Time)
+		return ok && at.Equal(bt)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// normalize ドライバから返される値をJSONとして比較・保存しやすい形に揃える
+func normalize(v any) any {
+	switch t := v.(type) {
+	case []byte:
+		return string(t)
+	case [16]byte:
+		return uuid.UUID(t).String()
+	case uuid.UUID:
+		return t.String()
+	case *uuid.UUID:
+		if t == nil {
+			return nil
+		}
+		return t.String()
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		return normalize(rv.Elem().Interface())
+	}
+	return v
+}
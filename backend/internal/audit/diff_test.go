@@ -0,0 +1,64 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestDiff(t *testing.T) {
+	visit := time.Date(2026, 2, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run("records only changed columns on update", func(t *testing.T) {
+		before := map[string]any{
+			"id":         "5f0c1d2e-0000-0000-0000-000000000001",
+			"diagnosis":  "膀胱炎",
+			"visit_date": visit,
+			"updated_at": visit,
+		}
+		after := map[string]any{
+			"id":         "5f0c1d2e-0000-0000-0000-000000000001",
+			"diagnosis":  "尿石症",
+			"visit_date": visit.In(time.FixedZone("JST", 9*60*60)),
+			"updated_at": visit.Add(time.Hour),
+		}
+
+		changes := Diff(before, after)
+
+		assert.Equal(t, model.AuditChanges{
+			"diagnosis": {Before: "膀胱炎", After: "尿石症"},
+		}, changes)
+	})
+
+	t.Run("records non-null columns on create", func(t *testing.T) {
+		changes := Diff(nil, map[string]any{
+			"id":    "5f0c1d2e-0000-0000-0000-000000000001",
+			"name":  "ポチ",
+			"notes": nil,
+		})
+
+		assert.Len(t, changes, 2)
+		assert.Equal(t, model.AuditChange{Before: nil, After: "ポチ"}, changes["name"])
+	})
+
+	t.Run("records all columns on delete", func(t *testing.T) {
+		changes := Diff(map[string]any{"name": "ポチ", "notes": []byte("メモ")}, nil)
+
+		assert.Equal(t, model.AuditChanges{
+			"name":  {Before: "ポチ", After: nil},
+			"notes": {Before: "メモ", After: nil},
+		}, changes)
+	})
+}
+
+func TestDiff_RedactsSecrets(t *testing.T) {
+	changes := Diff(
+		map[string]any{"password_hash": "$2a$10$old"},
+		map[string]any{"password_hash": "$2a$10$new"},
+	)
+
+	assert.Equal(t, model.AuditChange{Before: "[REDACTED]", After: "[REDACTED]"}, changes["password_hash"])
+}
@@ -0,0 +1,252 @@
+package audit
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// beforeKey 更新・削除前の行をステートメントに保持するためのキー
+const beforeKey = "audit:before"
+
+// parentSchemaKey 作成を始めたステートメントのモデル
+// GORMは関連付けの保存にステートメントの設定を引き継ぐため、モデルが異なれば関連付けの保存とわかる。
+const parentSchemaKey = "audit:parent_schema"
+
+// Plugin GORMの作成・更新・削除コールバックに監査ログの記録を差し込むプラグイン
+// db.Use(audit.NewPlugin()) で登録すると、主キーを持つ全モデルへの書き込みが
+// 同じトランザクション内でaudit_eventsに記録される。
+type Plugin struct{}
+
+// NewPlugin 新しい監査ログプラグインを作成
+func NewPlugin() *Plugin {
+	return &Plugin{}
+}
+
+// Name gorm.Pluginの実装
+func (p *Plugin) Name() string {
+	return "audit"
+}
+
+// Initialize gorm.Pluginの実装
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	// 記録は書き込みと同じトランザクションで行うため、コミット前に差し込む
+	const commit = "gorm:commit_or_rollback_transaction"
+	cb := db.Callback()
+	if err := cb.Create().Before("gorm:save_before_associations").Register("audit:before_create", p.markParent); err != nil {
+		return err
+	}
+	if err := cb.Create().After("gorm:create").Before(commit).Register("audit:after_create", p.afterCreate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("audit:before_update", p.captureBefore); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Before(commit).Register("audit:after_update", p.afterUpdate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("audit:before_delete", p.captureBefore); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Before(commit).Register("audit:after_delete", p.afterDelete)
+}
+
+// markParent 作成を始めたステートメントのモデルを記録する（関連付けの保存では引き継いだ値を残す）
+func (p *Plugin) markParent(db *gorm.DB) {
+	if db.Statement.Schema == nil {
+		return
+	}
+	if _, ok := db.Statement.Settings.Load(parentSchemaKey); !ok {
+		db.Statement.Settings.Store(parentSchemaKey, db.Statement.Schema)
+	}
+}
+
+// afterCreate 作成された行を記録する
+// upsert（ON CONFLICT）も記録する（DO NOTHINGで作成されなかった行は主キーがないため記録されない）。
+// 関連付けの保存は親のモデルの作成に伴う再保存なので記録しない。
+func (p *Plugin) afterCreate(db *gorm.DB) {
+	if skip(db) || isAssociationSave(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+
+	after, err := snapshot(db)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	for id, row := range after {
+		p.record(db, model.AuditActionCreate, id, Diff(nil, row))
+	}
+}
+
+// captureBefore 更新・削除される行を変更前の状態として保持する
+func (p *Plugin) captureBefore(db *gorm.DB) {
+	if skip(db) {
+		return
+	}
+	before, err := snapshot(db)
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	db.InstanceSet(beforeKey, before)
+}
+
+// afterUpdate 更新前後の差分を記録する
+func (p *Plugin) afterUpdate(db *gorm.DB) {
+	if skip(db) {
+		return
+	}
+	before := capturedBefore(db)
+	if len(before) == 0 {
+		return
+	}
+
+	after, err := snapshotByIDs(db, keys(before))
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+	for id, row := range after {
+		changes := Diff(before[id], row)
+		if len(changes) == 0 {
+			continue
+		}
+		p.record(db, model.AuditActionUpdate, id, changes)
+	}
+}
+
+// afterDelete 削除された行を記録する
+func (p *Plugin) afterDelete(db *gorm.DB) {
+	if skip(db) || db.Statement.RowsAffected == 0 {
+		return
+	}
+	for id, row := range capturedBefore(db) {
+		p.record(db, model.AuditActionDelete, id, Diff(row, nil))
+	}
+}
+
+// record 監査ログを書き込み中のステートメントと同じ接続（トランザクション）に保存する
+func (p *Plugin) record(db *gorm.DB, action, entityID string, changes model.AuditChanges) {
+	ctx := db.Statement.Context
+	event := &model.AuditEvent{
+		Actor:      ActorFromContext(ctx),
+		RequestID:  RequestIDFromContext(ctx),
+		EntityType: db.Statement.Table,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    changes,
+	}
+	if err := db.Session(&gorm.Session{NewDB: true}).Create(event).Error; err != nil {
+		slog.ErrorContext(ctx, "failed to record audit event",
+			slog.String("entity_type", event.EntityType),
+			slog.String("entity_id", entityID),
+			slog.String("error", err.Error()),
+		)
+		db.AddError(fmt.Errorf("failed to record audit event: %w", err))
+	}
+}
+
+// skip 監査対象外のステートメントかどうか
+// 主キーを持たないテーブル、失敗したステートメント、監査ログ自身への書き込みは対象外。
+func skip(db *gorm.DB) bool {
+	stmt := db.Statement
+	return db.Error != nil ||
+		stmt.Schema == nil ||
+		stmt.Schema.PrioritizedPrimaryField == nil ||
+		stmt.Table == (model.AuditEvent{}).TableName()
+}
+
+// isAssociationSave 親のモデルの作成に伴う関連付けの保存かどうか
+func isAssociationSave(db *gorm.DB) bool {
+	parent, ok := db.Statement.Settings.Load(parentSchemaKey)
+	return ok && parent != db.Statement.Schema
+}
+
+// snapshot ステートメントの対象行を主キーをキーとして取得する
+// 対象のモデルに主キーが設定されていればそれを、なければWHERE句を使って対象行を特定する。
+func snapshot(db *gorm.DB) (map[string]map[string]any, error) {
+	if ids := primaryKeys(db); len(ids) > 0 {
+		return snapshotByIDs(db, ids)
+	}
+
+	where, ok := db.Statement.Clauses["WHERE"]
+	if !ok {
+		return nil, nil
+	}
+	return loadRows(db, db.Session(&gorm.Session{NewDB: true}).Table(db.Statement.Table).Clauses(where.Expression))
+}
+
+// snapshotByIDs 主キーで対象行を取得する
+func snapshotByIDs(db *gorm.DB, ids []any) (map[string]map[string]any, error) {
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	q := db.Session(&gorm.Session{NewDB: true}).
+		Table(db.Statement.Table).
+		Where(clause.IN{Column: clause.Column{Name: pk}, Values: ids})
+	return loadRows(db, q)
+}
+
+// loadRows クエリ結果を主キーの文字列表現をキーとしたマップにする
+func loadRows(db *gorm.DB, q *gorm.DB) (map[string]map[string]any, error) {
+	var rows []map[string]any
+	if err := q.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load %s for audit: %w", db.Statement.Table, err)
+	}
+
+	pk := db.Statement.Schema.PrioritizedPrimaryField.DBName
+	result := make(map[string]map[string]any, len(rows))
+	for _, row := range rows {
+		result[fmt.Sprint(normalize(row[pk]))] = row
+	}
+	return result, nil
+}
+
+// primaryKeys ステートメントの対象モデル（単体またはスライス）から主キーの値を取り出す
+func primaryKeys(db *gorm.DB) []any {
+	stmt := db.Statement
+	field := stmt.Schema.PrioritizedPrimaryField
+
+	var ids []any
+	appendID := func(rv reflect.Value) {
+		if rv.Kind() != reflect.Struct {
+			return
+		}
+		if v, zero := field.ValueOf(stmt.Context, rv); !zero {
+			ids = append(ids, v)
+		}
+	}
+
+	switch rv := stmt.ReflectValue; rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			appendID(reflect.Indirect(rv.Index(i)))
+		}
+	case reflect.Struct:
+		appendID(rv)
+	}
+	return ids
+}
+
+// capturedBefore captureBeforeで保持した変更前の行を取り出す
+func capturedBefore(db *gorm.DB) map[string]map[string]any {
+	v, ok := db.InstanceGet(beforeKey)
+	if !ok {
+		return nil
+	}
+	before, _ := v.(map[string]map[string]any)
+	return before
+}
+
+// keys 主キーの一覧を返す
+func keys(rows map[string]map[string]any) []any {
+	ids := make([]any, 0, len(rows))
+	for id := range rows {
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,17 @@
+// Package auth provides staff credentials handling: password hashing, signed access tokens and request claims.
+package auth
+
+import "context"
+
+type claimsKey struct{}
+
+// WithClaims 認証済みスタッフのクレームをcontextに格納する
+func WithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext contextから認証済みスタッフのクレームを取得する
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// トークン検証エラー
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// jwtHeader HS256固定のJWTヘッダー
+var jwtHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+// Claims アクセストークンに含めるスタッフ情報
+type Claims struct {
+	Subject   string `json:"sub"` // スタッフID
+	Name      string `json:"name"`
+	Role      string `json:"role"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// HasRole いずれかのロールを持つかどうか
+func (c *Claims) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if c.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenManager アクセストークン（HS256署名のJWT）を発行・検証する
+type TokenManager struct {
+	secret     []byte
+	ttl        time.Duration
+	refreshTTL time.Duration
+	now        func() time.Time
+}
+
+// NewTokenManager 新しいTokenManagerを作成
+// ttlはアクセストークン、refreshTTLはリフレッシュトークンの有効期間。
+func NewTokenManager(secret string, ttl, refreshTTL time.Duration) *TokenManager {
+	return &TokenManager{secret: []byte(secret), ttl: ttl, refreshTTL: refreshTTL, now: time.Now}
+}
+
+// TTL アクセストークンの有効期間
+func (m *TokenManager) TTL() time.Duration {
+	return m.ttl
+}
+
+// RefreshTTL リフレッシュトークンの有効期間
+func (m *TokenManager) RefreshTTL() time.Duration {
+	return m.refreshTTL
+}
+
+// Issue スタッフのアクセストークンを発行する
+func (m *TokenManager) Issue(staffID, name, role string) (string, error) {
+	now := m.now()
+	claims := Claims{
+		Subject:   staffID,
+		Name:      name,
+		Role:      role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(m.ttl).Unix(),
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode claims: %w", err)
+	}
+
+	unsigned := jwtHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + m.sign(unsigned), nil
+}
+
+// Parse アクセストークンの署名と有効期限を検証してクレームを返す
+func (m *TokenManager) Parse(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != jwtHeader {
+		return nil, ErrInvalidToken
+	}
+
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var claims Claims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+
+	if m.now().Unix() >= claims.ExpiresAt {
+		return nil, ErrExpiredToken
+	}
+
+	return &claims, nil
+}
+
+func (m *TokenManager) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// NewRefreshToken ランダムなリフレッシュトークンを生成する
+// DBにはHashRefreshTokenで得たハッシュのみを保存する。
+func NewRefreshToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashRefreshToken リフレッシュトークンのSHA-256ハッシュ（16進）を返す
+func HashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
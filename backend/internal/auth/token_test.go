@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenManager(t *testing.T) {
+	m := NewTokenManager("test-secret", 15*time.Minute, time.Hour)
+
+	t.Run("issues and parses token", func(t *testing.T) {
+		token, err := m.Issue("staff-1", "山田 太郎", "veterinarian")
+		assert.NoError(t, err)
+
+		claims, err := m.Parse(token)
+		assert.NoError(t, err)
+		assert.Equal(t, "staff-1", claims.Subject)
+		assert.Equal(t, "veterinarian", claims.Role)
+	})
+
+	t.Run("rejects token signed with another secret", func(t *testing.T) {
+		token, _ := NewTokenManager("other-secret", time.Minute, time.Hour).Issue("staff-1", "", "admin")
+
+		_, err := m.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+
+	t.Run("rejects expired token", func(t *testing.T) {
+		expired := NewTokenManager("test-secret", time.Minute, time.Hour)
+		expired.now = func() time.Time { return time.Now().Add(-time.Hour) }
+		token, _ := expired.Issue("staff-1", "", "admin")
+
+		_, err := m.Parse(token)
+		assert.ErrorIs(t, err, ErrExpiredToken)
+	})
+
+	t.Run("rejects malformed token", func(t *testing.T) {
+		_, err := m.Parse("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestPassword(t *testing.T) {
+	hash, err := HashPassword("correct horse")
+	assert.NoError(t, err)
+
+	assert.NoError(t, CheckPassword(hash, "correct horse"))
+	assert.ErrorIs(t, CheckPassword(hash, "wrong"), ErrPasswordMismatch)
+}
@@ -0,0 +1,204 @@
+// Package careplan はケアプランの実施予定から1日分のケアタスクを展開し、ケアログと突き合わせて実施状況を判定する。
+package careplan
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// OverdueGrace 予定時刻を過ぎてから未実施を遅延とみなすまでの猶予
+const OverdueGrace = 30 * time.Minute
+
+// Occurrence 予定の1回分
+type Occurrence struct {
+	Key string    // CareLog.Timingに記録するキー
+	Due time.Time // 予定時刻
+}
+
+// Expand 実施予定を指定日（その日の暦日、time.Local）の予定時刻に展開する
+// 間隔指定はsince（項目の開始日時）の暦日のStartを起点にN時間ごとの時刻とし、日をまたいでも間隔を保つ。
+// sinceがゼロ値なら指定日のStartを起点とする。sinceより前の予定時刻は項目の登録前のため含めない。
+// 頓用と予定なしの場合は空を返す。不正な時刻は無視する。
+func Expand(schedule model.CareSchedule, since, day time.Time) []Occurrence {
+	var occurrences []Occurrence
+	switch {
+	case schedule.PRN:
+		return nil
+	case schedule.EveryHours > 0:
+		start, ok := ClockOffset(schedule.Start)
+		if schedule.Start == "" {
+			start, ok = 0, true
+		}
+		if !ok {
+			return nil
+		}
+		anchor := atOffset(day, start)
+		if !since.IsZero() {
+			anchor = atOffset(since.In(time.Local), start)
+		}
+		interval := time.Duration(schedule.EveryHours) * time.Hour
+		dayStart, dayEnd := atOffset(day, 0), atOffset(day, 24*time.Hour)
+		due := anchor
+		if due.Before(dayStart) {
+			// 起点から間隔の整数倍を進めた、指定日の最初の予定時刻
+			n := (dayStart.Sub(anchor) + interval - 1) / interval
+			due = anchor.Add(n * interval)
+		}
+		for ; due.Before(dayEnd); due = due.Add(interval) {
+			occurrences = append(occurrences, Occurrence{Key: due.Format("15:04"), Due: due})
+		}
+	default:
+		for _, timing := range schedule.Times {
+			offset, ok := ClockOffset(timing)
+			if !ok {
+				continue
+			}
+			occurrences = append(occurrences, Occurrence{Key: timing, Due: atOffset(day, offset)})
+		}
+		sort.SliceStable(occurrences, func(i, j int) bool {
+			return occurrences[i].Due.Before(occurrences[j].Due)
+		})
+	}
+	if since.IsZero() {
+		return occurrences
+	}
+	// 登録日の登録時刻より前の回は実施予定に含めない
+	for n, o := range occurrences {
+		if !o.Due.Before(since) {
+			return occurrences[n:]
+		}
+	}
+	return nil
+}
+
+// HasKey ケアプラン項目の指定日の実施予定にそのキーの回があるかどうか
+func HasKey(item model.CarePlanItem, key string, day time.Time) bool {
+	for _, o := range Expand(item.Timing, item.CreatedAt, day) {
+		if o.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Tasks 指定日に有効なケアプラン項目を予定ごとのタスクに展開し、その日のケアログと突き合わせる
+// 有効な項目は、ステータスがactiveでその日までに登録されたもの。
+// 未実施の予定は、予定時刻からOverdueGraceを過ぎていればoverdue、そうでなければpendingとする。
+// ケアログはタイミングが一致するものを優先し、タイミング未指定のログは予定時刻順に充てる。
+func Tasks(items []model.CarePlanItem, logs []model.CareLog, day, now time.Time) []model.CareTaskStatus {
+	tasks := []model.CareTaskStatus{}
+	used := make([]bool, len(logs))
+	dayEnd := atOffset(day, 24*time.Hour)
+
+	for _, item := range items {
+		if item.Status != "" && item.Status != "active" {
+			continue
+		}
+		if !item.CreatedAt.IsZero() && !item.CreatedAt.Before(dayEnd) {
+			continue
+		}
+
+		if item.Timing.PRN {
+			task := newTask(item, "", nil)
+			task.Status = model.CareTaskStatusAsNeeded
+			for i := range logs {
+				if !used[i] && logs[i].CarePlanItemID != nil && *logs[i].CarePlanItemID == item.ID {
+					used[i] = true
+					task.CareLog = &logs[i]
+				}
+			}
+			tasks = append(tasks, task)
+			continue
+		}
+
+		occurrences := Expand(item.Timing, item.CreatedAt, day)
+		// タイミングが一致するログを先にすべて割り当ててから、タイミング未指定のログを割り当てる
+		matched := make([]int, len(occurrences))
+		for n, o := range occurrences {
+			matched[n] = matchLog(logs, used, item.ID, o.Key, true)
+		}
+		for n := range occurrences {
+			if matched[n] < 0 {
+				matched[n] = matchLog(logs, used, item.ID, "", false)
+			}
+		}
+
+		for n, o := range occurrences {
+			due := o.Due
+			task := newTask(item, o.Key, &due)
+			switch i := matched[n]; {
+			case i >= 0 && logs[i].Status == model.CareLogStatusSkipped:
+				task.CareLog = &logs[i]
+				task.Status = model.CareTaskStatusSkipped
+			case i >= 0:
+				task.CareLog = &logs[i]
+				task.Status = model.CareTaskStatusDone
+			case now.After(due.Add(OverdueGrace)):
+				task.Status = model.CareTaskStatusOverdue
+			default:
+				task.Status = model.CareTaskStatusPending
+			}
+			tasks = append(tasks, task)
+		}
+	}
+
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return dueOrLast(tasks[i]).Before(dueOrLast(tasks[j]))
+	})
+	return tasks
+}
+
+// matchLog 項目の未使用のケアログを探し、見つかれば使用済みにする
+// exactの場合はタイミングが一致するもの、そうでなければタイミング未指定のものを対象とする。
+func matchLog(logs []model.CareLog, used []bool, itemID uuid.UUID, key string, exact bool) int {
+	for i, log := range logs {
+		if used[i] || log.CarePlanItemID == nil || *log.CarePlanItemID != itemID {
+			continue
+		}
+		if (exact && log.Timing == key) || (!exact && log.Timing == "") {
+			used[i] = true
+			return i
+		}
+	}
+	return -1
+}
+
+func newTask(item model.CarePlanItem, key string, due *time.Time) model.CareTaskStatus {
+	return model.CareTaskStatus{
+		CarePlanItemID: item.ID,
+		Type:           item.Type,
+		Name:           item.Name,
+		Timing:         key,
+		DueAt:          due,
+	}
+}
+
+// dueOrLast 並び替え用の予定時刻（頓用は最後）
+func dueOrLast(task model.CareTaskStatus) time.Time {
+	if task.DueAt == nil {
+		return time.Date(9999, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return *task.DueAt
+}
+
+// ClockOffset 時間帯またはHH:MMをその日の0時からの経過時間にする
+func ClockOffset(timing string) (time.Duration, bool) {
+	if slot, ok := model.CareTimingSlots[timing]; ok {
+		timing = slot
+	}
+	t, err := time.Parse("15:04", timing)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+// atOffset 指定日の暦日の0時（time.Local）からoffset後の時刻
+func atOffset(day time.Time, offset time.Duration) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local).Add(offset)
+}
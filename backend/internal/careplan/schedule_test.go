@@ -0,0 +1,148 @@
+package careplan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func keys(occurrences []Occurrence) []string {
+	result := make([]string, len(occurrences))
+	for i, o := range occurrences {
+		result[i] = o.Key
+	}
+	return result
+}
+
+func TestExpand(t *testing.T) {
+	day := time.Date(2030, 4, 2, 0, 0, 0, 0, time.UTC)
+
+	t.Run("times of day are sorted and slot names resolve to clock times", func(t *testing.T) {
+		occurrences := Expand(model.CareSchedule{Times: []string{"night", "07:30", "morning"}}, time.Time{}, day)
+
+		assert.Equal(t, []string{"07:30", "morning", "night"}, keys(occurrences))
+		assert.Equal(t, time.Date(2030, 4, 2, 8, 0, 0, 0, time.Local), occurrences[1].Due)
+		assert.Equal(t, time.Date(2030, 4, 2, 18, 0, 0, 0, time.Local), occurrences[2].Due)
+	})
+
+	t.Run("every N hours from start on the first day", func(t *testing.T) {
+		since := time.Date(2030, 4, 2, 5, 30, 0, 0, time.Local)
+		occurrences := Expand(model.CareSchedule{EveryHours: 8, Start: "06:00"}, since, day)
+
+		assert.Equal(t, []string{"06:00", "14:00", "22:00"}, keys(occurrences))
+	})
+
+	t.Run("every N hours defaults to midnight start", func(t *testing.T) {
+		assert.Equal(t, []string{"00:00", "12:00"}, keys(Expand(model.CareSchedule{EveryHours: 12}, time.Time{}, day)))
+	})
+
+	t.Run("every N hours keeps the interval across midnight", func(t *testing.T) {
+		since := time.Date(2030, 4, 1, 10, 0, 0, 0, time.Local)
+		q5h := model.CareSchedule{EveryHours: 5, Start: "06:00"}
+		q7h := model.CareSchedule{EveryHours: 7, Start: "08:00"}
+
+		// 4/1 06:00起点の5時間ごと: 06, 11, 16, 21, 4/2 02, 07, 12, 17, 22, 4/3 03...
+		// 登録した4/1 10:00より前の06:00の回は含めない
+		assert.Equal(t, []string{"11:00", "16:00", "21:00"}, keys(Expand(q5h, since, since)))
+		occurrences := Expand(q5h, since, day)
+		assert.Equal(t, []string{"02:00", "07:00", "12:00", "17:00", "22:00"}, keys(occurrences))
+		assert.Equal(t, time.Date(2030, 4, 2, 2, 0, 0, 0, time.Local), occurrences[0].Due)
+		assert.Equal(t, []string{"03:00", "08:00", "13:00", "18:00", "23:00"},
+			keys(Expand(q5h, since, time.Date(2030, 4, 3, 0, 0, 0, 0, time.UTC))))
+
+		// 4/1 08:00起点の7時間ごと: 08, 15, 22, 4/2 05, 12, 19
+		assert.Equal(t, []string{"05:00", "12:00", "19:00"}, keys(Expand(q7h, since, day)))
+	})
+
+	t.Run("every N hours has no occurrences before the start day", func(t *testing.T) {
+		since := time.Date(2030, 4, 3, 9, 0, 0, 0, time.Local)
+		assert.Empty(t, Expand(model.CareSchedule{EveryHours: 6}, since, day))
+	})
+
+	t.Run("times of day before registration are skipped on the first day", func(t *testing.T) {
+		since := time.Date(2030, 4, 2, 12, 30, 0, 0, time.Local)
+		schedule := model.CareSchedule{Times: []string{"morning", "noon", "night"}}
+
+		assert.Equal(t, []string{"night"}, keys(Expand(schedule, since, day)))
+		assert.Equal(t, []string{"morning", "noon", "night"}, keys(Expand(schedule, since, day.AddDate(0, 0, 1))))
+	})
+
+	t.Run("prn and empty schedules have no occurrences", func(t *testing.T) {
+		assert.Empty(t, Expand(model.CareSchedule{PRN: true}, time.Time{}, day))
+		assert.Empty(t, Expand(model.CareSchedule{}, time.Time{}, day))
+	})
+}
+
+func TestTasks(t *testing.T) {
+	day := time.Date(2030, 4, 2, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2030, 4, 2, 14, 10, 0, 0, time.Local)
+	antibioticID := uuid.New()
+	fluidID := uuid.New()
+	painkillerID := uuid.New()
+
+	items := []model.CarePlanItem{
+		{ID: antibioticID, Name: "抗生剤", Timing: model.CareSchedule{Times: []string{"morning", "night"}}, Status: "active"},
+		{ID: fluidID, Name: "点滴", Timing: model.CareSchedule{EveryHours: 6, Start: "02:00"}, Status: "active"},
+		{ID: painkillerID, Name: "鎮痛剤", Timing: model.CareSchedule{PRN: true}, Status: "active"},
+		{ID: uuid.New(), Name: "中止した薬", Timing: model.CareSchedule{Times: []string{"noon"}}, Status: "discontinued"},
+		// 翌日に追加された項目は対象外
+		{ID: uuid.New(), Name: "翌日から", Timing: model.CareSchedule{Times: []string{"noon"}}, Status: "active", CreatedAt: day.AddDate(0, 0, 1).Add(time.Hour)},
+	}
+	logs := []model.CareLog{
+		{CarePlanItemID: &antibioticID, Timing: "morning", Status: model.CareLogStatusCompleted},
+		// タイミング未指定のログは未実施の最初の回に充てる
+		{CarePlanItemID: &fluidID, Status: model.CareLogStatusCompleted},
+		{CarePlanItemID: &fluidID, Timing: "08:00", Status: model.CareLogStatusSkipped},
+		{CarePlanItemID: &painkillerID, RecordedTime: "03:00", Status: model.CareLogStatusCompleted},
+		{CarePlanItemID: &painkillerID, RecordedTime: "11:00", Status: model.CareLogStatusCompleted},
+	}
+
+	tasks := Tasks(items, logs, day, now)
+
+	statuses := map[string]string{}
+	for _, task := range tasks {
+		statuses[task.Name+"/"+task.Timing] = task.Status
+	}
+	assert.Equal(t, map[string]string{
+		"点滴/02:00":    model.CareTaskStatusDone,
+		"抗生剤/morning": model.CareTaskStatusDone,
+		"点滴/08:00":    model.CareTaskStatusSkipped,
+		// 14:00の回は猶予内のため未実施、14:10時点で20:00・夜の回も未実施
+		"点滴/14:00":  model.CareTaskStatusPending,
+		"抗生剤/night": model.CareTaskStatusPending,
+		"点滴/20:00":  model.CareTaskStatusPending,
+		"鎮痛剤/":      model.CareTaskStatusAsNeeded,
+	}, statuses)
+
+	// 予定時刻順に並び、頓用は最後
+	assert.Equal(t, "02:00", tasks[0].Timing)
+	assert.Equal(t, "鎮痛剤", tasks[len(tasks)-1].Name)
+	assert.Equal(t, "11:00", tasks[len(tasks)-1].CareLog.RecordedTime)
+
+	t.Run("pending task becomes overdue after the grace period", func(t *testing.T) {
+		later := Tasks(items, logs, day, time.Date(2030, 4, 2, 14, 31, 0, 0, time.Local))
+		for _, task := range later {
+			if task.Name == "点滴" && task.Timing == "14:00" {
+				assert.Equal(t, model.CareTaskStatusOverdue, task.Status)
+			}
+		}
+	})
+}
+
+func TestCareScheduleJSON(t *testing.T) {
+	var legacy model.CareSchedule
+	assert.NoError(t, legacy.Scan([]byte(`["morning","night"]`)))
+	assert.Equal(t, []string{"morning", "night"}, legacy.Times)
+
+	var interval model.CareSchedule
+	assert.NoError(t, interval.Scan(`{"every_hours":8,"start":"06:00"}`))
+	assert.Equal(t, 8, interval.EveryHours)
+
+	value, err := model.CareSchedule{PRN: true}.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"prn":true}`, value)
+}
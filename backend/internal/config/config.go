@@ -1,10 +1,16 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 )
 
+// DefaultJWTSecret JWT_SECRET未設定時の開発用シークレット（本番では必ず上書きすること）
+const DefaultJWTSecret = "dev-secret-change-me"
+
 type Config struct {
 	Port    string
 	DBHost  string
@@ -13,6 +19,41 @@ type Config struct {
 	DBPass  string
 	DBName  string
 	GinMode string
+
+	// 認証
+	JWTSecret       string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	AdminEmail      string
+	AdminPassword   string
+
+	// 帳票
+	PDFFontPath string
+
+	// ワクチン接種案内
+	ReminderInterval  time.Duration
+	ReminderLead      time.Duration
+	ReminderOutboxDir string
+	SMTPHost          string
+	SMTPPort          string
+	SMTPUsername      string
+	SMTPPassword      string
+	SMTPFrom          string
+
+	// 検査装置の結果取り込み（空なら無効）
+	AnalyzerListenAddr   string
+	AnalyzerDropDir      string
+	AnalyzerPollInterval time.Duration
+
+	// 添付ファイル（ATTACHMENT_STORAGE=local または s3）
+	AttachmentStorage     string
+	AttachmentDir         string
+	AttachmentMaxSize     int64
+	AttachmentS3Endpoint  string
+	AttachmentS3Region    string
+	AttachmentS3Bucket    string
+	AttachmentS3AccessKey string
+	AttachmentS3SecretKey string
 }
 
 func Load() *Config {
@@ -24,7 +65,46 @@ func Load() *Config {
 		DBPass:  getEnv("DB_PASSWORD", "ekarte_password"),
 		DBName:  getEnv("DB_NAME", "ekarte_db"),
 		GinMode: getEnv("GIN_MODE", "debug"),
+
+		JWTSecret:       getEnv("JWT_SECRET", DefaultJWTSecret),
+		AccessTokenTTL:  getDurationEnv("ACCESS_TOKEN_TTL", 15*time.Minute),
+		RefreshTokenTTL: getDurationEnv("REFRESH_TOKEN_TTL", 30*24*time.Hour),
+		AdminEmail:      getEnv("ADMIN_EMAIL", ""),
+		AdminPassword:   getEnv("ADMIN_PASSWORD", ""),
+
+		PDFFontPath: getEnv("PDF_FONT_PATH", ""),
+
+		ReminderInterval:  getDurationEnv("REMINDER_INTERVAL", 24*time.Hour),
+		ReminderLead:      getDurationEnv("REMINDER_LEAD", 30*24*time.Hour),
+		ReminderOutboxDir: getEnv("REMINDER_OUTBOX_DIR", "outbox"),
+		SMTPHost:          getEnv("SMTP_HOST", ""),
+		SMTPPort:          getEnv("SMTP_PORT", "587"),
+		SMTPUsername:      getEnv("SMTP_USERNAME", ""),
+		SMTPPassword:      getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:          getEnv("SMTP_FROM", ""),
+
+		AnalyzerListenAddr:   getEnv("ANALYZER_LISTEN_ADDR", ""),
+		AnalyzerDropDir:      getEnv("ANALYZER_DROP_DIR", ""),
+		AnalyzerPollInterval: getDurationEnv("ANALYZER_POLL_INTERVAL", 30*time.Second),
+
+		AttachmentStorage:     getEnv("ATTACHMENT_STORAGE", "local"),
+		AttachmentDir:         getEnv("ATTACHMENT_DIR", "attachments"),
+		AttachmentMaxSize:     getInt64Env("ATTACHMENT_MAX_SIZE", 50<<20),
+		AttachmentS3Endpoint:  getEnv("ATTACHMENT_S3_ENDPOINT", ""),
+		AttachmentS3Region:    getEnv("ATTACHMENT_S3_REGION", "us-east-1"),
+		AttachmentS3Bucket:    getEnv("ATTACHMENT_S3_BUCKET", ""),
+		AttachmentS3AccessKey: getEnv("ATTACHMENT_S3_ACCESS_KEY", ""),
+		AttachmentS3SecretKey: getEnv("ATTACHMENT_S3_SECRET_KEY", ""),
+	}
+}
+
+// Validate 起動できない設定を検出する
+// リリースモード（GIN_MODE=release）ではJWT_SECRETの未設定・開発用シークレットを許可しない。
+func (c *Config) Validate() error {
+	if c.GinMode == "release" && c.JWTSecret == DefaultJWTSecret {
+		return errors.New("JWT_SECRET must be set to a non-default value in release mode")
 	}
+	return nil
 }
 
 func (c *Config) DSN() string {
@@ -40,3 +120,21 @@ func getEnv(key, defaultVal string) string {
 	}
 	return defaultVal
 }
+
+func getDurationEnv(key string, defaultVal time.Duration) time.Duration {
+	if val := os.Getenv(key); val != "" {
+		if d, err := time.ParseDuration(val); err == nil {
+			return d
+		}
+	}
+	return defaultVal
+}
+
+func getInt64Env(key string, defaultVal int64) int64 {
+	if val := os.Getenv(key); val != "" {
+		if n, err := strconv.ParseInt(val, 10, 64); err == nil {
+			return n
+		}
+	}
+	return defaultVal
+}
@@ -0,0 +1,250 @@
+// Package decimal provides an exact fixed-point decimal type for money amounts and rates.
+//
+// Values are stored as an int64 count of 1/10000 units, which exactly represents
+// every value of the decimal(10,2) amount and decimal(3,2) rate columns as well as
+// their products. Rounding only ever happens explicitly (e.g. Floor when computing
+// yen amounts), never implicitly as with float64.
+package decimal
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Scale 小数部の桁数
+const Scale = 4
+
+// unit 1を表す内部値
+const unit = 10000
+
+// ErrInvalidDecimal 数値として解釈できない
+var ErrInvalidDecimal = errors.New("invalid decimal")
+
+// Decimal 固定小数点数（小数第4位まで）
+type Decimal struct {
+	v int64
+}
+
+// Zero 0
+var Zero = Decimal{}
+
+// FromInt 整数からDecimalを作成する
+func FromInt(n int64) Decimal {
+	return Decimal{v: n * unit}
+}
+
+// Parse "1234.56" や "-0.08" 形式の文字列をDecimalに変換する
+// 小数第4位を超える桁を持つ値はエラーとする（丸めない）。
+func Parse(s string) (Decimal, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Zero, ErrInvalidDecimal
+	}
+
+	neg := false
+	switch s[0] {
+	case '-':
+		neg = true
+		s = s[1:]
+	case '+':
+		s = s[1:]
+	}
+
+	// 符号は先頭に1つだけ。整数部・小数部は数字のみとする
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return Zero, ErrInvalidDecimal
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	fracPart = strings.TrimRight(fracPart, "0")
+	if len(fracPart) > Scale {
+		return Zero, fmt.Errorf("%w: more than %d decimal places", ErrInvalidDecimal, Scale)
+	}
+
+	i, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || i > math.MaxInt64/unit {
+		return Zero, ErrInvalidDecimal
+	}
+
+	var f int64
+	if fracPart != "" {
+		if f, err = strconv.ParseInt(fracPart+strings.Repeat("0", Scale-len(fracPart)), 10, 64); err != nil {
+			return Zero, ErrInvalidDecimal
+		}
+	}
+
+	v := i*unit + f
+	if neg {
+		v = -v
+	}
+	return Decimal{v: v}, nil
+}
+
+// isDigits 0〜9の数字のみからなるかどうか（空文字列も含む）
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// MustParse Parseに失敗した場合panicする（定数の定義用）
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Add 加算
+func (d Decimal) Add(o Decimal) Decimal {
+	return Decimal{v: d.v + o.v}
+}
+
+// Sub 減算
+func (d Decimal) Sub(o Decimal) Decimal {
+	return Decimal{v: d.v - o.v}
+}
+
+// MulInt 整数倍
+func (d Decimal) MulInt(n int64) Decimal {
+	return Decimal{v: d.v * n}
+}
+
+// Mul 乗算
+// 結果が小数第4位で割り切れない場合は0方向に切り捨てる。
+// 金額(小数第2位)×率(小数第2位)は常に割り切れる。
+func (d Decimal) Mul(o Decimal) Decimal {
+	return Decimal{v: d.v * o.v / unit}
+}
+
+// Floor 小数点以下を切り捨てた値（負の値は-∞方向）
+func (d Decimal) Floor() Decimal {
+	q := d.v / unit
+	if d.v%unit < 0 {
+		q--
+	}
+	return Decimal{v: q * unit}
+}
+
+// Cmp 比較（d<oなら-1、d==oなら0、d>oなら1）
+func (d Decimal) Cmp(o Decimal) int {
+	switch {
+	case d.v < o.v:
+		return -1
+	case d.v > o.v:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// IsZero 0かどうか
+func (d Decimal) IsZero() bool {
+	return d.v == 0
+}
+
+// IsNegative 負かどうか
+func (d Decimal) IsNegative() bool {
+	return d.v < 0
+}
+
+// IntPart 整数部
+func (d Decimal) IntPart() int64 {
+	return d.v / unit
+}
+
+// Ptr ポインタを返す（nullableなモデルフィールドへの代入用）
+func (d Decimal) Ptr() *Decimal {
+	return &d
+}
+
+// String 末尾の0を除いた10進表記
+func (d Decimal) String() string {
+	v := d.v
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	s := sign + strconv.FormatInt(v/unit, 10)
+	if frac := v % unit; frac != 0 {
+		s += "." + strings.TrimRight(fmt.Sprintf("%04d", frac), "0")
+	}
+	return s
+}
+
+// StringFixed 小数第places位まで0埋めした10進表記（placesはScale以下）
+func (d Decimal) StringFixed(places int) string {
+	if places <= 0 {
+		return strconv.FormatInt(d.IntPart(), 10)
+	}
+	s := d.String()
+	intPart, fracPart, _ := strings.Cut(s, ".")
+	if len(fracPart) > places {
+		fracPart = fracPart[:places]
+	}
+	return intPart + "." + fracPart + strings.Repeat("0", places-len(fracPart))
+}
+
+// MarshalJSON JSONの数値として出力する
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// UnmarshalJSON JSONの数値または文字列を受け付ける
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	s := strings.Trim(string(b), `"`)
+	if s == "null" {
+		return nil
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// Value driver.Valuerの実装
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan sql.Scannerの実装
+func (d *Decimal) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Zero
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	case int64:
+		*d = FromInt(v)
+		return nil
+	case float64:
+		return d.scanString(strconv.FormatFloat(v, 'f', -1, 64))
+	default:
+		return fmt.Errorf("unsupported type for Decimal: %T", value)
+	}
+}
+
+func (d *Decimal) scanString(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
@@ -0,0 +1,79 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1100", "1100"},
+		{"1100.50", "1100.5"},
+		{"0.08", "0.08"},
+		{"-12.3400", "-12.34"},
+		{".5", "0.5"},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		assert.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, d.String(), tt.in)
+	}
+
+	for _, in := range []string{
+		"",
+		"abc",
+		"1.00001",
+		"-",
+		".",
+		"--5",
+		"-+3",
+		"+-3",
+		"1.-5",
+		"1.+5",
+		"1.2.3",
+		"1 000",
+		"1e3",
+		"１００",
+	} {
+		_, err := Parse(in)
+		assert.ErrorIs(t, err, ErrInvalidDecimal, in)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	// 0.1 + 0.2 がfloat64と違い正確に0.3になる
+	assert.Equal(t, MustParse("0.3"), MustParse("0.1").Add(MustParse("0.2")))
+
+	// 1,980円×3点の8%
+	amount := MustParse("1980").MulInt(3)
+	assert.Equal(t, "475.2", amount.Mul(MustParse("0.08")).String())
+	assert.Equal(t, "475", amount.Mul(MustParse("0.08")).Floor().String())
+
+	assert.Equal(t, "-2", MustParse("-1.5").Floor().String())
+	assert.Equal(t, 1, MustParse("10").Cmp(MustParse("9.99")))
+}
+
+func TestStringFixed(t *testing.T) {
+	assert.Equal(t, "1100.00", MustParse("1100").StringFixed(2))
+	assert.Equal(t, "0.08", MustParse("0.08").StringFixed(2))
+	assert.Equal(t, "1100", MustParse("1100.5").StringFixed(0))
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A Decimal  `json:"a"`
+		B *Decimal `json:"b"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"a":"0.70","b":1234.5}`), &v))
+	assert.Equal(t, "0.7", v.A.String())
+	assert.Equal(t, "1234.5", v.B.String())
+
+	b, err := json.Marshal(v)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":0.7,"b":1234.5}`, string(b))
+}
@@ -0,0 +1,292 @@
+// Package dicom はDICOM Part 10ファイルからカルテの添付に必要なメタデータ（モダリティ・検査日・患者IDなど）を読み取る。
+// 画素データは読まず、リトルエンディアンの明示的VR・暗黙的VRの転送構文に対応する。
+package dicom
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrNotDICOM DICOM Part 10の形式（128バイトのプリアンブルと"DICM"）ではない
+var ErrNotDICOM = errors.New("not a DICOM part 10 file")
+
+// 転送構文
+const (
+	TransferSyntaxImplicitVRLittleEndian = "1.2.840.10008.1.2"
+	TransferSyntaxExplicitVRLittleEndian = "1.2.840.10008.1.2.1"
+	TransferSyntaxDeflated               = "1.2.840.10008.1.2.1.99"
+	TransferSyntaxExplicitVRBigEndian    = "1.2.840.10008.1.2.2"
+)
+
+// Metadata 添付ファイルとして記録するDICOMの属性
+type Metadata struct {
+	TransferSyntaxUID string
+	SOPClassUID       string
+	SOPInstanceUID    string
+	StudyDate         string // YYYYMMDD
+	Modality          string // CR, DX, US, CT, MR など
+	StudyDescription  string
+	PatientName       string
+	PatientID         string
+	BodyPartExamined  string
+	StudyInstanceUID  string
+}
+
+// tag グループ番号とエレメント番号
+type tag uint32
+
+func newTag(group, element uint16) tag {
+	return tag(uint32(group)<<16 | uint32(element))
+}
+
+func (t tag) group() uint16 {
+	return uint16(t >> 16)
+}
+
+var (
+	tagTransferSyntaxUID = newTag(0x0002, 0x0010)
+	tagSOPClassUID       = newTag(0x0008, 0x0016)
+	tagSOPInstanceUID    = newTag(0x0008, 0x0018)
+	tagStudyDate         = newTag(0x0008, 0x0020)
+	tagModality          = newTag(0x0008, 0x0060)
+	tagStudyDescription  = newTag(0x0008, 0x1030)
+	tagPatientName       = newTag(0x0010, 0x0010)
+	tagPatientID         = newTag(0x0010, 0x0020)
+	tagBodyPartExamined  = newTag(0x0018, 0x0015)
+	tagStudyInstanceUID  = newTag(0x0020, 0x000D)
+
+	tagItem              = newTag(0xFFFE, 0xE000)
+	tagItemDelimitation  = newTag(0xFFFE, 0xE00D)
+	tagSequenceDelimiter = newTag(0xFFFE, 0xE0DD)
+)
+
+// lastTag これより後ろの属性は読まない（画素データを含む）
+var lastTag = tagStudyInstanceUID
+
+const undefinedLength = 0xFFFFFFFF
+
+// maxSequenceDepth 長さ未定義のシーケンスの入れ子の上限
+// 細工されたファイルで読み飛ばしの再帰が深くなりすぎないようにする（実際の画像はせいぜい数段）。
+const maxSequenceDepth = 16
+
+// IsDICOM DICOM Part 10の形式かどうか
+func IsDICOM(data []byte) bool {
+	return len(data) >= 132 && string(data[128:132]) == "DICM"
+}
+
+// Parse DICOM Part 10ファイルのメタデータを読み取る
+func Parse(data []byte) (*Metadata, error) {
+	if !IsDICOM(data) {
+		return nil, ErrNotDICOM
+	}
+	meta := &Metadata{}
+
+	// ファイルメタ情報（グループ0002）は常に明示的VRのリトルエンディアン
+	r := &reader{data: data, pos: 132, explicit: true}
+	for r.pos < len(r.data) {
+		t, ok := r.peekTag()
+		if !ok || t.group() != 0x0002 {
+			break
+		}
+		el, err := r.next()
+		if err != nil {
+			return nil, fmt.Errorf("read file meta information: %w", err)
+		}
+		if el.tag == tagTransferSyntaxUID {
+			meta.TransferSyntaxUID = el.text()
+		}
+	}
+
+	switch meta.TransferSyntaxUID {
+	case TransferSyntaxImplicitVRLittleEndian:
+		r.explicit = false
+	case TransferSyntaxExplicitVRBigEndian, TransferSyntaxDeflated:
+		return nil, fmt.Errorf("unsupported DICOM transfer syntax %s", meta.TransferSyntaxUID)
+	default:
+		// 圧縮画像の転送構文もデータセットは明示的VRのリトルエンディアン
+		r.explicit = true
+	}
+
+	for r.pos < len(r.data) {
+		el, err := r.next()
+		if err != nil {
+			return nil, fmt.Errorf("read data set: %w", err)
+		}
+		if el.tag > lastTag {
+			break
+		}
+		switch el.tag {
+		case tagSOPClassUID:
+			meta.SOPClassUID = el.text()
+		case tagSOPInstanceUID:
+			meta.SOPInstanceUID = el.text()
+		case tagStudyDate:
+			meta.StudyDate = el.text()
+		case tagModality:
+			meta.Modality = el.text()
+		case tagStudyDescription:
+			meta.StudyDescription = el.text()
+		case tagPatientName:
+			// 姓^名^ミドルネーム^接頭辞^接尾辞 の区切りを空白にする
+			meta.PatientName = strings.Join(strings.FieldsFunc(el.text(), func(r rune) bool { return r == '^' }), " ")
+		case tagPatientID:
+			meta.PatientID = el.text()
+		case tagBodyPartExamined:
+			meta.BodyPartExamined = el.text()
+		case tagStudyInstanceUID:
+			meta.StudyInstanceUID = el.text()
+		}
+	}
+	return meta, nil
+}
+
+// element 読み取った属性（シーケンスは値を持たない）
+type element struct {
+	tag   tag
+	vr    string
+	value []byte
+}
+
+// text 文字列の値（末尾の空白・NULを除く）
+func (e element) text() string {
+	s := strings.TrimRight(string(e.value), " \x00")
+	return strings.ToValidUTF8(strings.TrimSpace(s), "")
+}
+
+type reader struct {
+	data     []byte
+	pos      int
+	explicit bool
+	depth    int // 読み飛ばし中のシーケンスの入れ子の深さ
+}
+
+func (r *reader) peekTag() (tag, bool) {
+	if r.pos+4 > len(r.data) {
+		return 0, false
+	}
+	group := binary.LittleEndian.Uint16(r.data[r.pos:])
+	element := binary.LittleEndian.Uint16(r.data[r.pos+2:])
+	return newTag(group, element), true
+}
+
+func (r *reader) uint16() (uint16, error) {
+	if r.pos+2 > len(r.data) {
+		return 0, errors.New("unexpected end of data")
+	}
+	v := binary.LittleEndian.Uint16(r.data[r.pos:])
+	r.pos += 2
+	return v, nil
+}
+
+func (r *reader) uint32() (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errors.New("unexpected end of data")
+	}
+	v := binary.LittleEndian.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// header 属性のタグ・VR・値の長さを読む
+func (r *reader) header() (tag, string, uint32, error) {
+	t, ok := r.peekTag()
+	if !ok {
+		return 0, "", 0, errors.New("unexpected end of data")
+	}
+	r.pos += 4
+
+	// 項目・区切りはVRを持たない
+	if !r.explicit || t.group() == 0xFFFE {
+		length, err := r.uint32()
+		return t, "", length, err
+	}
+	if r.pos+2 > len(r.data) {
+		return 0, "", 0, errors.New("unexpected end of data")
+	}
+	vr := string(r.data[r.pos : r.pos+2])
+	r.pos += 2
+	switch vr {
+	case "OB", "OD", "OF", "OL", "OV", "OW", "SQ", "SV", "UC", "UN", "UR", "UT", "UV":
+		r.pos += 2 // 予約領域
+		length, err := r.uint32()
+		return t, vr, length, err
+	default:
+		length, err := r.uint16()
+		return t, vr, uint32(length), err
+	}
+}
+
+// next 次の属性を読む（長さ未定義のシーケンスは読み飛ばす）
+func (r *reader) next() (element, error) {
+	t, vr, length, err := r.header()
+	if err != nil {
+		return element{}, err
+	}
+	if length == undefinedLength {
+		if t.group() == 0x7FE0 {
+			// カプセル化された画素データ（呼び出し側はここで読むのを止める）
+			return element{tag: t, vr: vr}, nil
+		}
+		if err := r.skipSequence(); err != nil {
+			return element{}, fmt.Errorf("skip sequence %08X: %w", uint32(t), err)
+		}
+		return element{tag: t, vr: vr}, nil
+	}
+	if int(length) < 0 || r.pos+int(length) > len(r.data) {
+		return element{}, fmt.Errorf("value of %08X exceeds the data", uint32(t))
+	}
+	value := r.data[r.pos : r.pos+int(length)]
+	r.pos += int(length)
+	return element{tag: t, vr: vr, value: value}, nil
+}
+
+// skipSequence 長さ未定義のシーケンスをシーケンス区切りまで読み飛ばす
+func (r *reader) skipSequence() error {
+	if r.depth >= maxSequenceDepth {
+		return fmt.Errorf("sequences nested deeper than %d levels", maxSequenceDepth)
+	}
+	r.depth++
+	defer func() { r.depth-- }()
+	for {
+		t, _, length, err := r.header()
+		if err != nil {
+			return err
+		}
+		switch t {
+		case tagSequenceDelimiter:
+			return nil
+		case tagItem:
+			if length != undefinedLength {
+				if r.pos+int(length) > len(r.data) {
+					return errors.New("item exceeds the data")
+				}
+				r.pos += int(length)
+				continue
+			}
+			if err := r.skipItem(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected tag %08X in sequence", uint32(t))
+		}
+	}
+}
+
+// skipItem 長さ未定義の項目を項目区切りまで読み飛ばす
+func (r *reader) skipItem() error {
+	for {
+		t, ok := r.peekTag()
+		if !ok {
+			return errors.New("unexpected end of data")
+		}
+		if t == tagItemDelimitation {
+			_, _, _, err := r.header()
+			return err
+		}
+		if _, err := r.next(); err != nil {
+			return err
+		}
+	}
+}
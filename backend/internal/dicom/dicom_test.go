@@ -0,0 +1,168 @@
+package dicom
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fileBuilder テスト用のDICOM Part 10ファイルを組み立てる
+type fileBuilder struct {
+	buf      bytes.Buffer
+	explicit bool
+}
+
+func newFile(transferSyntax string) *fileBuilder {
+	b := &fileBuilder{explicit: true}
+	b.buf.Write(make([]byte, 128))
+	b.buf.WriteString("DICM")
+	b.element(0x0002, 0x0001, "OB", []byte{0, 1})
+	b.element(0x0002, 0x0010, "UI", pad(transferSyntax, 0))
+	b.explicit = transferSyntax != TransferSyntaxImplicitVRLittleEndian
+	return b
+}
+
+func pad(s string, padding byte) []byte {
+	if len(s)%2 == 1 {
+		return append([]byte(s), padding)
+	}
+	return []byte(s)
+}
+
+func (b *fileBuilder) raw(v ...any) {
+	for _, x := range v {
+		_ = binary.Write(&b.buf, binary.LittleEndian, x)
+	}
+}
+
+func (b *fileBuilder) element(group, elem uint16, vr string, value []byte) *fileBuilder {
+	b.raw(group, elem)
+	switch {
+	case !b.explicit:
+		b.raw(uint32(len(value)))
+	case vr == "OB" || vr == "SQ" || vr == "UN" || vr == "UT":
+		b.buf.WriteString(vr)
+		b.raw(uint16(0), uint32(len(value)))
+	default:
+		b.buf.WriteString(vr)
+		b.raw(uint16(len(value)))
+	}
+	b.buf.Write(value)
+	return b
+}
+
+func (b *fileBuilder) text(group, elem uint16, vr, value string) *fileBuilder {
+	return b.element(group, elem, vr, pad(value, ' '))
+}
+
+// undefinedSequence 長さ未定義の項目を1つ持つ長さ未定義のシーケンス
+func (b *fileBuilder) undefinedSequence(group, elem uint16) *fileBuilder {
+	b.raw(group, elem)
+	if b.explicit {
+		b.buf.WriteString("SQ")
+		b.raw(uint16(0))
+	}
+	b.raw(uint32(undefinedLength))
+	b.raw(uint16(0xFFFE), uint16(0xE000), uint32(undefinedLength))
+	b.text(0x0008, 0x0100, "SH", "T-D0050")
+	b.raw(uint16(0xFFFE), uint16(0xE00D), uint32(0))
+	b.raw(uint16(0xFFFE), uint16(0xE0DD), uint32(0))
+	return b
+}
+
+func TestParse(t *testing.T) {
+	t.Run("explicit VR little endian", func(t *testing.T) {
+		b := newFile(TransferSyntaxExplicitVRLittleEndian)
+		b.text(0x0008, 0x0018, "UI", "1.2.392.200036.9125.1")
+		b.text(0x0008, 0x0020, "DA", "20261015")
+		b.text(0x0008, 0x0060, "CS", "DX")
+		b.undefinedSequence(0x0008, 0x1032)
+		b.text(0x0010, 0x0010, "PN", "POCHI^TANAKA")
+		b.text(0x0010, 0x0020, "LO", "P-0012")
+		b.text(0x0018, 0x0015, "CS", "CHEST")
+		b.text(0x0020, 0x000D, "UI", "1.2.392.200036.9125.2")
+		b.element(0x7FE0, 0x0010, "OB", []byte{0xFF, 0xD8})
+
+		meta, err := Parse(b.buf.Bytes())
+
+		require.NoError(t, err)
+		assert.Equal(t, &Metadata{
+			TransferSyntaxUID: TransferSyntaxExplicitVRLittleEndian,
+			SOPInstanceUID:    "1.2.392.200036.9125.1",
+			StudyDate:         "20261015",
+			Modality:          "DX",
+			PatientName:       "POCHI TANAKA",
+			PatientID:         "P-0012",
+			BodyPartExamined:  "CHEST",
+			StudyInstanceUID:  "1.2.392.200036.9125.2",
+		}, meta)
+	})
+
+	t.Run("implicit VR little endian", func(t *testing.T) {
+		b := newFile(TransferSyntaxImplicitVRLittleEndian)
+		b.text(0x0008, 0x0020, "DA", "20261016")
+		b.text(0x0008, 0x0060, "CS", "US")
+		b.undefinedSequence(0x0008, 0x1032)
+		b.text(0x0008, 0x1030, "LO", "ABDOMEN")
+		b.text(0x0010, 0x0020, "LO", "P-0042")
+
+		meta, err := Parse(b.buf.Bytes())
+
+		require.NoError(t, err)
+		assert.Equal(t, "US", meta.Modality)
+		assert.Equal(t, "20261016", meta.StudyDate)
+		assert.Equal(t, "ABDOMEN", meta.StudyDescription)
+		assert.Equal(t, "P-0042", meta.PatientID)
+	})
+
+	t.Run("rejects deeply nested sequences", func(t *testing.T) {
+		b := newFile(TransferSyntaxExplicitVRLittleEndian)
+		b.text(0x0008, 0x0060, "CS", "CR")
+		// 長さ未定義のシーケンスと項目を区切りなしで入れ子にし続ける
+		for i := 0; i < 100000; i++ {
+			b.raw(uint16(0x0008), uint16(0x1032))
+			b.buf.WriteString("SQ")
+			b.raw(uint16(0), uint32(undefinedLength))
+			b.raw(uint16(0xFFFE), uint16(0xE000), uint32(undefinedLength))
+		}
+
+		_, err := Parse(b.buf.Bytes())
+		assert.ErrorContains(t, err, "nested deeper than 16 levels")
+	})
+
+	t.Run("reads sequences nested within the limit", func(t *testing.T) {
+		b := newFile(TransferSyntaxExplicitVRLittleEndian)
+		for i := 0; i < maxSequenceDepth; i++ {
+			b.raw(uint16(0x0008), uint16(0x1032))
+			b.buf.WriteString("SQ")
+			b.raw(uint16(0), uint32(undefinedLength))
+			b.raw(uint16(0xFFFE), uint16(0xE000), uint32(undefinedLength))
+		}
+		for i := 0; i < maxSequenceDepth; i++ {
+			b.raw(uint16(0xFFFE), uint16(0xE00D), uint32(0))
+			b.raw(uint16(0xFFFE), uint16(0xE0DD), uint32(0))
+		}
+		b.text(0x0008, 0x1030, "LO", "THORAX")
+
+		meta, err := Parse(b.buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "THORAX", meta.StudyDescription)
+	})
+
+	t.Run("rejects other files", func(t *testing.T) {
+		_, err := Parse([]byte("\x89PNG\r\n\x1a\n"))
+		assert.ErrorIs(t, err, ErrNotDICOM)
+
+		_, err = Parse(newFile(TransferSyntaxExplicitVRBigEndian).buf.Bytes())
+		assert.ErrorContains(t, err, "unsupported DICOM transfer syntax")
+
+		b := newFile(TransferSyntaxExplicitVRLittleEndian)
+		b.text(0x0008, 0x0060, "CS", "CR")
+		truncated := b.buf.Bytes()[:b.buf.Len()-1]
+		_, err = Parse(truncated)
+		assert.Error(t, err)
+	})
+}
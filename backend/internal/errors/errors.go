@@ -9,6 +9,7 @@ import (
 var (
 	ErrNotFound      = errors.New("resource not found")
 	ErrAlreadyExists = errors.New("resource already exists")
+	ErrConflict      = errors.New("resource conflict")
 	ErrInvalidInput  = errors.New("invalid input")
 	ErrUnauthorized  = errors.New("unauthorized")
 	ErrForbidden     = errors.New("forbidden")
@@ -57,6 +58,30 @@ func WrapInvalidInput(message string) error {
 	}
 }
 
+func WrapConflict(message string) error {
+	return &AppError{
+		Code:    "CONFLICT",
+		Message: message,
+		Err:     ErrConflict,
+	}
+}
+
+func WrapUnauthorized(message string) error {
+	return &AppError{
+		Code:    "UNAUTHORIZED",
+		Message: message,
+		Err:     ErrUnauthorized,
+	}
+}
+
+func WrapForbidden(message string) error {
+	return &AppError{
+		Code:    "FORBIDDEN",
+		Message: message,
+		Err:     ErrForbidden,
+	}
+}
+
 func WrapInternal(err error, message string) error {
 	return &AppError{
 		Code:    "INTERNAL",
@@ -81,3 +106,15 @@ func IsNotFound(err error) bool {
 func IsInvalidInput(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+func IsForbidden(err error) bool {
+	return errors.Is(err, ErrForbidden)
+}
@@ -49,6 +49,19 @@ func TestWrapInvalidInput(t *testing.T) {
 	assert.Equal(t, "INVALID_INPUT", appErr.Code)
 }
 
+func TestWrapConflict(t *testing.T) {
+	err := WrapConflict("doctor is already booked")
+
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "doctor is already booked")
+	assert.True(t, IsConflict(err))
+	assert.False(t, IsInvalidInput(err))
+
+	var appErr *AppError
+	assert.True(t, errors.As(err, &appErr))
+	assert.Equal(t, "CONFLICT", appErr.Code)
+}
+
 func TestIsNotFound(t *testing.T) {
 	t.Run("returns true for ErrNotFound", func(t *testing.T) {
 		assert.True(t, IsNotFound(ErrNotFound))
@@ -137,3 +150,17 @@ func TestAs(t *testing.T) {
 		assert.Equal(t, "NOT_FOUND", appErr.Code)
 	})
 }
+
+func TestWrapUnauthorizedAndForbidden(t *testing.T) {
+	unauthorized := WrapUnauthorized("invalid email or password")
+	assert.True(t, IsUnauthorized(unauthorized))
+	assert.False(t, IsForbidden(unauthorized))
+
+	forbidden := WrapForbidden("role nurse is not allowed")
+	assert.True(t, IsForbidden(forbidden))
+	assert.False(t, IsUnauthorized(forbidden))
+
+	var appErr *AppError
+	assert.True(t, errors.As(forbidden, &appErr))
+	assert.Equal(t, "FORBIDDEN", appErr.Code)
+}
@@ -0,0 +1,198 @@
+// Package fulltext はPostgreSQLの全文検索（tsvector/tsquery）で日本語を検索するため、
+// 文章を2文字ずつの組（バイグラム）に分割した検索語を作る。
+//
+// 形態素解析を使わず、表記をtextnorm.FoldRunesでそろえてから文字・数字の並びごとに
+// バイグラムを位置付きで記録する。検索語も同じように分割し、隣り合うバイグラムを
+// 「<->」（フレーズ）で結ぶことで、検索語をそのまま含む文章だけに一致させる。
+package fulltext
+
+import (
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/animal-ekarte/backend/internal/textnorm"
+)
+
+const (
+	// maxPosition tsvectorに記録できる位置の上限（これより後ろは上限の位置として記録される）
+	maxPosition = 16383
+	// maxPositions tsvectorに1つの語について記録できる位置の数
+	maxPositions = 256
+	// maxQueryTerms 検索語の最大数
+	maxQueryTerms = 10
+)
+
+// Vector 文章のtsvector表記（'ab':1,5 'bc':2 ...）を作る
+// 文章ごとに位置を空けるため、複数の文章にまたがって検索語が一致することはない。
+func Vector(texts ...string) string {
+	positions := map[string][]int{}
+	pos := 1
+	add := func(lexeme string) {
+		p := min(pos, maxPosition)
+		if ps := positions[lexeme]; len(ps) < maxPositions && (len(ps) == 0 || ps[len(ps)-1] != p) {
+			positions[lexeme] = append(ps, p)
+		}
+		pos++
+	}
+	for _, text := range texts {
+		folded, _ := textnorm.FoldRunes(text)
+		for _, segment := range segments(folded) {
+			for _, lexeme := range bigrams(segment) {
+				add(lexeme)
+			}
+			pos++ // 語の区切りをまたいでフレーズが一致しないように位置を空ける
+		}
+	}
+
+	lexemes := make([]string, 0, len(positions))
+	for lexeme := range positions {
+		lexemes = append(lexemes, lexeme)
+	}
+	sort.Strings(lexemes)
+
+	var b strings.Builder
+	for i, lexeme := range lexemes {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(quote(lexeme))
+		for j, p := range positions[lexeme] {
+			if j == 0 {
+				b.WriteByte(':')
+			} else {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Itoa(p))
+		}
+	}
+	return b.String()
+}
+
+// Query 検索語から作った全文検索の条件
+type Query struct {
+	TSQuery string   // tsquery表記（空白区切りの各語をすべて含む）
+	Terms   []string // 表記をそろえた検索語（一致箇所の強調に使う）
+}
+
+// ParseQuery 空白区切りの検索語からtsqueryを作る（検索語がなければfalse）
+// 1文字の語はその文字で始まるバイグラムとの前方一致とする。
+func ParseQuery(q string) (Query, bool) {
+	var query Query
+	var parts []string
+	for _, word := range strings.Fields(q) {
+		folded, _ := textnorm.FoldRunes(word)
+		for _, segment := range segments(folded) {
+			if len(query.Terms) == maxQueryTerms {
+				break
+			}
+			query.Terms = append(query.Terms, string(segment))
+			if len(segment) == 1 {
+				parts = append(parts, quote(string(segment))+":*")
+				continue
+			}
+			grams := bigrams(segment)
+			quoted := make([]string, 0, len(grams)-1)
+			for _, gram := range grams[:len(grams)-1] { // 末尾の1文字の語は検索語には不要
+				quoted = append(quoted, quote(gram))
+			}
+			parts = append(parts, "("+strings.Join(quoted, " <-> ")+")")
+		}
+	}
+	if len(parts) == 0 {
+		return Query{}, false
+	}
+	query.TSQuery = strings.Join(parts, " & ")
+	return query, true
+}
+
+// Snippet 文章から最初の一致箇所の周辺を切り出し、検索語を<mark>で囲む（一致しなければfalse）
+// 文章はHTMLとしてエスケープし、改行は空白にする。切り詰めた側には「…」を付ける。
+func Snippet(text string, terms []string, before, length int) (string, bool) {
+	src := []rune(text)
+	folded, spans := textnorm.FoldRunes(text)
+
+	var matches []textnorm.Span
+	for _, term := range terms {
+		t := []rune(term)
+		for i := 0; i+len(t) <= len(folded); i++ {
+			if string(folded[i:i+len(t)]) == term {
+				matches = append(matches, textnorm.Span{Start: spans[i].Start, End: spans[i+len(t)-1].End})
+			}
+		}
+	}
+	if len(matches) == 0 {
+		return "", false
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Start < matches[j].Start })
+
+	start := max(0, matches[0].Start-before)
+	end := min(len(src), start+length)
+	if matches[0].End > end {
+		end = matches[0].End
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	cursor := start
+	for _, m := range matches {
+		if m.Start < cursor || m.Start >= end {
+			continue // 重なる一致と範囲外の一致は強調しない
+		}
+		b.WriteString(escape(src[cursor:m.Start]))
+		b.WriteString("<mark>")
+		b.WriteString(escape(src[m.Start:min(m.End, end)]))
+		b.WriteString("</mark>")
+		cursor = min(m.End, end)
+	}
+	b.WriteString(escape(src[cursor:end]))
+	if end < len(src) {
+		b.WriteString("…")
+	}
+	return b.String(), true
+}
+
+// segments 文字・数字が続く部分ごとに分ける（記号・空白は区切りとして除く）
+func segments(runes []rune) [][]rune {
+	var result [][]rune
+	start := -1
+	for i, r := range runes {
+		word := unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+		switch {
+		case word && start < 0:
+			start = i
+		case !word && start >= 0:
+			result = append(result, runes[start:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		result = append(result, runes[start:])
+	}
+	return result
+}
+
+// bigrams 2文字ずつの組と、末尾の1文字（1文字の検索語の前方一致用）
+func bigrams(segment []rune) []string {
+	grams := make([]string, 0, len(segment))
+	for i := 0; i+1 < len(segment); i++ {
+		grams = append(grams, string(segment[i:i+2]))
+	}
+	return append(grams, string(segment[len(segment)-1:]))
+}
+
+// quote tsvector/tsqueryの語を引用符で囲む
+func quote(lexeme string) string {
+	lexeme = strings.ReplaceAll(lexeme, `\`, `\\`)
+	return "'" + strings.ReplaceAll(lexeme, "'", "''") + "'"
+}
+
+// escape 文字列をHTMLとしてエスケープし、改行を空白にする
+func escape(runes []rune) string {
+	s := strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ").Replace(string(runes))
+	return html.EscapeString(s)
+}
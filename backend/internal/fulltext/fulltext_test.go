@@ -0,0 +1,47 @@
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVector(t *testing.T) {
+	assert.Equal(t, "'いえ':2 'えん':3 'すい':1 'ん':4", Vector("スイエン"))
+	// 記号で区切り、文章ごとに位置を空ける
+	assert.Equal(t, "'a':5 'ab':1 'b':2 'c':7 'ca':4", Vector("ab-ca", "c"))
+	assert.Equal(t, "'i':1 's':3", Vector("I's"))
+	assert.Equal(t, "", Vector("", "・"))
+}
+
+func TestParseQuery(t *testing.T) {
+	q, ok := ParseQuery("メロキシカム　膵")
+
+	require.True(t, ok)
+	assert.Equal(t, "('めろ' <-> 'ろき' <-> 'きし' <-> 'しか' <-> 'かむ') & '膵':*", q.TSQuery)
+	assert.Equal(t, []string{"めろきしかむ", "膵"}, q.Terms)
+
+	q, ok = ParseQuery("膵炎")
+	require.True(t, ok)
+	assert.Equal(t, "('膵炎')", q.TSQuery)
+
+	_, ok = ParseQuery(" ・ ")
+	assert.False(t, ok)
+}
+
+func TestSnippet(t *testing.T) {
+	text := "元気消失と嘔吐が続く。\n血液検査でリパーゼ高値。急性膵炎を疑う。ﾒﾛｷｼｶﾑは中止。"
+
+	snippet, ok := Snippet(text, []string{"すいえん"}, 5, 20)
+	assert.False(t, ok)
+	assert.Empty(t, snippet)
+
+	snippet, ok = Snippet(text, []string{"膵炎", "めろきしかむ"}, 5, 20)
+	require.True(t, ok)
+	assert.Equal(t, "…高値。急性<mark>膵炎</mark>を疑う。<mark>ﾒﾛｷｼｶﾑ</mark>は中止…", snippet)
+
+	snippet, ok = Snippet("<b>嘔吐</b>", []string{"嘔吐"}, 5, 20)
+	require.True(t, ok)
+	assert.Equal(t, "&lt;b&gt;<mark>嘔吐</mark>&lt;/b&gt;", snippet)
+}
@@ -0,0 +1,177 @@
+// Package growth は子犬・子猫の体重を、犬種・猫種の体格区分ごとの標準的な成長曲線と比べる。
+// 成長曲線は成体体重に対する週齢ごとの割合で表し、区分の代表的な成体体重を掛けて期待体重とする。
+package growth
+
+import (
+	"strings"
+
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/textnorm"
+)
+
+// 体格区分
+const (
+	SizeToy      = "toy"       // 超小型犬（成体 〜5kg）
+	SizeSmall    = "small"     // 小型犬（5〜10kg）
+	SizeMedium   = "medium"    // 中型犬（10〜25kg）
+	SizeLarge    = "large"     // 大型犬（25〜45kg）
+	SizeGiant    = "giant"     // 超大型犬（45kg〜）
+	SizeCat      = "cat"       // 一般的な猫
+	SizeLargeCat = "large_cat" // 大型の猫種
+)
+
+// bandFraction 期待体重の上下の許容幅（±20%）
+const bandFraction = 0.2
+
+// Status 期待体重との比較
+const (
+	StatusBelow  = "below"
+	StatusWithin = "within"
+	StatusAbove  = "above"
+)
+
+// point 週齢と成体体重に対する割合
+type point struct {
+	weeks    float64
+	fraction float64
+}
+
+// Curve 体格区分ごとの成長曲線
+type Curve struct {
+	SizeClass   string
+	AdultWeight float64 // 代表的な成体体重（kg）
+	MatureWeeks int     // 成体体重に達する週齢
+	points      []point
+}
+
+var curves = map[string]Curve{
+	SizeToy: {SizeClass: SizeToy, AdultWeight: 3, MatureWeeks: 44, points: []point{
+		{0, 0.05}, {8, 0.25}, {12, 0.40}, {16, 0.55}, {20, 0.68}, {26, 0.82}, {36, 0.95}, {44, 1},
+	}},
+	SizeSmall: {SizeClass: SizeSmall, AdultWeight: 8, MatureWeeks: 48, points: []point{
+		{0, 0.04}, {8, 0.22}, {12, 0.35}, {16, 0.50}, {20, 0.63}, {26, 0.77}, {36, 0.92}, {48, 1},
+	}},
+	SizeMedium: {SizeClass: SizeMedium, AdultWeight: 18, MatureWeeks: 60, points: []point{
+		{0, 0.03}, {8, 0.18}, {12, 0.30}, {16, 0.42}, {20, 0.53}, {26, 0.66}, {36, 0.82}, {52, 0.97}, {60, 1},
+	}},
+	SizeLarge: {SizeClass: SizeLarge, AdultWeight: 32, MatureWeeks: 72, points: []point{
+		{0, 0.02}, {8, 0.14}, {12, 0.24}, {16, 0.35}, {20, 0.45}, {26, 0.57}, {36, 0.74}, {52, 0.90}, {72, 1},
+	}},
+	SizeGiant: {SizeClass: SizeGiant, AdultWeight: 55, MatureWeeks: 96, points: []point{
+		{0, 0.01}, {8, 0.10}, {12, 0.18}, {16, 0.27}, {20, 0.36}, {26, 0.48}, {36, 0.64}, {52, 0.82}, {78, 0.95}, {96, 1},
+	}},
+	SizeCat: {SizeClass: SizeCat, AdultWeight: 4.2, MatureWeeks: 52, points: []point{
+		{0, 0.025}, {8, 0.22}, {12, 0.33}, {16, 0.45}, {20, 0.55}, {26, 0.68}, {36, 0.85}, {52, 1},
+	}},
+	SizeLargeCat: {SizeClass: SizeLargeCat, AdultWeight: 6.5, MatureWeeks: 78, points: []point{
+		{0, 0.02}, {8, 0.17}, {12, 0.26}, {16, 0.36}, {20, 0.45}, {26, 0.56}, {36, 0.70}, {52, 0.85}, {78, 1},
+	}},
+}
+
+// dogBreeds 犬種名（表記をそろえた部分一致）と体格区分
+// トイ・プードルとプードルのように名前が重なる犬種は、より限定的な名前を先に並べる。
+var dogBreeds = []struct {
+	names []string
+	size  string
+}{
+	{[]string{"トイプードル", "toypoodle", "ティーカッププードル"}, SizeToy},
+	{[]string{"ミニチュアダックス", "miniaturedachshund", "カニンヘンダックス"}, SizeSmall},
+	{[]string{"ミニチュアシュナウザー", "miniatureschnauzer"}, SizeSmall},
+	{[]string{"ミニチュアピンシャー", "miniaturepinscher"}, SizeToy},
+	{[]string{"ミニチュアプードル", "miniaturepoodle"}, SizeSmall},
+	{[]string{"スタンダードプードル", "standardpoodle"}, SizeLarge},
+	{[]string{"チワワ", "chihuahua", "ポメラニアン", "pomeranian", "ヨークシャーテリア", "yorkshireterrier",
+		"マルチーズ", "maltese", "パピヨン", "papillon", "狆"}, SizeToy},
+	{[]string{"シーズー", "shihtzu", "柴", "shiba", "パグ", "pug", "キャバリア", "cavalier",
+		"ジャックラッセル", "jackrussell", "ビションフリーゼ", "bichon", "ボストンテリア", "bostonterrier",
+		"ウェルシュテリア", "ミニチュア", "miniature"}, SizeSmall},
+	{[]string{"フレンチブルドッグ", "frenchbulldog", "ビーグル", "beagle", "コーギー", "corgi",
+		"ボーダーコリー", "bordercollie", "シェットランド", "shetland", "シェルティ", "sheltie",
+		"甲斐", "紀州", "四国", "ブルドッグ", "bulldog", "ダックス", "dachshund", "スピッツ", "spitz"}, SizeMedium},
+	{[]string{"ゴールデン", "golden", "ラブラドール", "labrador", "シェパード", "shepherd",
+		"秋田", "akita", "ドーベルマン", "doberman", "ボクサー", "boxer", "ハスキー", "husky",
+		"プードル", "poodle"}, SizeLarge},
+	{[]string{"グレートデーン", "greatdane", "セントバーナード", "stbernard", "saintbernard",
+		"バーニーズ", "bernese", "グレートピレニーズ", "pyrenees", "ニューファンドランド", "newfoundland",
+		"マスティフ", "mastiff", "土佐", "tosa"}, SizeGiant},
+}
+
+// largeCatBreeds 大型の猫種
+var largeCatBreeds = []string{
+	"メインクーン", "mainecoon", "ノルウェージャンフォレスト", "norwegianforest", "ラグドール", "ragdoll",
+	"サイベリアン", "siberian", "ラガマフィン", "ragamuffin", "ベンガル", "bengal",
+}
+
+// Lookup 動物種と品種から成長曲線を選ぶ（犬で品種から体格区分を決められない場合はfalse）
+// 猫は品種の指定がない・一般的な猫種なら一般的な猫の曲線とする。
+func Lookup(species, breed string) (Curve, bool) {
+	name := normalizeBreed(breed)
+	switch model.SpeciesKind(species) {
+	case model.SpeciesDog:
+		if name == "" {
+			return Curve{}, false
+		}
+		for _, b := range dogBreeds {
+			for _, n := range b.names {
+				if strings.Contains(name, normalizeBreed(n)) {
+					return curves[b.size], true
+				}
+			}
+		}
+		return Curve{}, false
+	case model.SpeciesCat:
+		for _, n := range largeCatBreeds {
+			if name != "" && strings.Contains(name, normalizeBreed(n)) {
+				return curves[SizeLargeCat], true
+			}
+		}
+		return curves[SizeCat], true
+	default:
+		return Curve{}, false
+	}
+}
+
+// normalizeBreed 品種名の表記ゆれ（全角・半角、中黒・空白・長音・大文字小文字）をそろえる
+func normalizeBreed(breed string) string {
+	s := textnorm.Fold(breed)
+	return strings.NewReplacer(" ", "", "・", "", "･", "", "-", "", ".", "", "'", "").Replace(s)
+}
+
+// Expected 週齢の期待体重と許容範囲（kg）
+// 成体体重に達する週齢を過ぎた場合はfalse。
+func (c Curve) Expected(ageWeeks float64) (low, expected, high float64, ok bool) {
+	if ageWeeks < 0 || ageWeeks > float64(c.MatureWeeks) || len(c.points) == 0 {
+		return 0, 0, 0, false
+	}
+	fraction := c.points[len(c.points)-1].fraction
+	for i := 1; i < len(c.points); i++ {
+		prev, next := c.points[i-1], c.points[i]
+		if ageWeeks <= next.weeks {
+			fraction = prev.fraction + (next.fraction-prev.fraction)*(ageWeeks-prev.weeks)/(next.weeks-prev.weeks)
+			break
+		}
+	}
+	expected = c.AdultWeight * fraction
+	return expected * (1 - bandFraction), expected, expected * (1 + bandFraction), true
+}
+
+// Compare 体重を期待体重の範囲と比べる
+func Compare(weight, low, high float64) string {
+	switch {
+	case weight < low:
+		return StatusBelow
+	case weight > high:
+		return StatusAbove
+	default:
+		return StatusWithin
+	}
+}
+
+// Weeks 曲線の描画用に、開始から成体体重に達するまでの区切りの週齢を返す
+func (c Curve) Weeks() []int {
+	weeks := make([]int, len(c.points))
+	for i, p := range c.points {
+		weeks[i] = int(p.weeks)
+	}
+	return weeks
+}
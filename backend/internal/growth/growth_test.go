@@ -0,0 +1,60 @@
+package growth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		species, breed string
+		size           string
+		ok             bool
+	}{
+		{"犬", "トイ・プードル", SizeToy, true},
+		{"イヌ", "ﾄｲﾌﾟｰﾄﾞﾙ", SizeToy, true},
+		{"dog", "Standard Poodle", SizeLarge, true},
+		{"犬", "ミニチュア・ダックスフンド", SizeSmall, true},
+		{"犬", "ダックスフンド", SizeMedium, true},
+		{"犬", "柴犬", SizeSmall, true},
+		{"犬", "ゴールデン・レトリーバー", SizeLarge, true},
+		{"犬", "バーニーズ・マウンテン・ドッグ", SizeGiant, true},
+		{"犬", "雑種", "", false},
+		{"犬", "", "", false},
+		{"猫", "", SizeCat, true},
+		{"ネコ", "アメリカン・ショートヘア", SizeCat, true},
+		{"猫", "メインクーン", SizeLargeCat, true},
+		{"ウサギ", "ネザーランドドワーフ", "", false},
+	}
+	for _, tt := range tests {
+		curve, ok := Lookup(tt.species, tt.breed)
+		assert.Equal(t, tt.ok, ok, "%s %s", tt.species, tt.breed)
+		assert.Equal(t, tt.size, curve.SizeClass, "%s %s", tt.species, tt.breed)
+	}
+}
+
+func TestCurveExpected(t *testing.T) {
+	curve, ok := Lookup("犬", "ラブラドール・レトリーバー")
+	require.True(t, ok)
+
+	low, expected, high, ok := curve.Expected(16)
+	require.True(t, ok)
+	assert.InDelta(t, 11.2, expected, 0.001) // 32kg × 0.35
+	assert.InDelta(t, 8.96, low, 0.001)
+	assert.InDelta(t, 13.44, high, 0.001)
+
+	// 区切りの間は直線で補間する
+	_, expected, _, ok = curve.Expected(14)
+	require.True(t, ok)
+	assert.InDelta(t, 32*0.295, expected, 0.001)
+
+	// 成体体重に達した後は比べない
+	_, _, _, ok = curve.Expected(80)
+	assert.False(t, ok)
+
+	assert.Equal(t, StatusBelow, Compare(8, low, high))
+	assert.Equal(t, StatusWithin, Compare(11, low, high))
+	assert.Equal(t, StatusAbove, Compare(14, low, high))
+}
@@ -0,0 +1,336 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllAccountings godoc
+// @Summary 会計一覧取得
+// @Description 登録されている会計の一覧をページ単位で取得します（既定は予定日の新しい順）
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param status query string false "ステータス"
+// @Param date_from query string false "予定日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "予定日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -scheduled_date"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Accounting]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllAccountings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListAccountingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	accountings, err := h.svc.ListAccountings(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "accounting", "")
+		return
+	}
+	c.JSON(http.StatusOK, accountings)
+}
+
+// GetAccounting godoc
+// @Summary 会計詳細取得
+// @Description 指定されたIDの会計を明細付きで取得します
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Success 200 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAccounting(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	accounting, err := h.svc.GetAccountingByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+	c.JSON(http.StatusOK, accounting)
+}
+
+// CreateAccounting godoc
+// @Summary 会計作成
+// @Description カルテの実施項目から会計を作成します。明細ごとの税率（10%/8%）・保険適用区分から合計・保険負担額・請求額を計算します
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param accounting body model.CreateAccountingRequest true "会計作成情報"
+// @Success 201 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateAccounting(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateAccountingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	accounting, err := h.svc.CreateAccountingFromMedicalRecord(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "accounting", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting created",
+		slog.String("accounting_id", accounting.ID.String()),
+		slog.String("medical_record_id", req.MedicalRecordID),
+	)
+	c.JSON(http.StatusCreated, accounting)
+}
+
+// UpdateAccounting godoc
+// @Summary 会計更新
+// @Description 未収・保留の会計のステータス・保険・値引を更新し、金額を再計算します
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Param accounting body model.UpdateAccountingRequest true "更新する会計情報"
+// @Success 200 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateAccounting(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateAccountingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	accounting, err := h.svc.UpdateAccounting(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting updated", slog.String("accounting_id", id))
+	c.JSON(http.StatusOK, accounting)
+}
+
+// DeleteAccounting godoc
+// @Summary 会計削除
+// @Description 指定されたIDの会計を明細とともに削除します。回収済の会計は削除できません
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteAccounting(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteAccounting(ctx, id); err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting deleted", slog.String("accounting_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "accounting deleted"})
+}
+
+// AddAccountingItem godoc
+// @Summary 会計明細追加
+// @Description 会計に明細を手動で追加し、金額を再計算します。master_idを指定した場合は未指定の項目にマスタの値を使います
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Param item body model.AddAccountingItemRequest true "明細情報"
+// @Success 201 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id}/items [post]
+// @Security ApiKeyAuth
+func (h *Handler) AddAccountingItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddAccountingItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	accounting, err := h.svc.AddAccountingItem(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting item added", slog.String("accounting_id", id))
+	c.JSON(http.StatusCreated, accounting)
+}
+
+// DeleteAccountingItem godoc
+// @Summary 会計明細削除
+// @Description 会計から明細を削除し、金額を再計算します
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Param item_id path string true "会計明細ID (UUID)"
+// @Success 200 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id}/items/{item_id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteAccountingItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	itemID := c.Param("item_id")
+
+	accounting, err := h.svc.DeleteAccountingItem(ctx, id, itemID)
+	if err != nil {
+		h.handleError(c, err, "accounting_item", itemID)
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting item deleted",
+		slog.String("accounting_id", id),
+		slog.String("item_id", itemID),
+	)
+	c.JSON(http.StatusOK, accounting)
+}
+
+// CompleteAccounting godoc
+// @Summary 会計完了（入金）
+// @Description 入金を記録して会計を回収済にします。現金の場合は預り金から釣銭を計算します
+// @Tags accountings
+// @Accept json
+// @Produce json
+// @Param id path string true "会計ID (UUID)"
+// @Param payment body model.CompleteAccountingRequest true "入金情報"
+// @Success 200 {object} model.Accounting
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id}/complete [post]
+// @Security ApiKeyAuth
+func (h *Handler) CompleteAccounting(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.CompleteAccountingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	accounting, err := h.svc.CompleteAccounting(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "accounting completed",
+		slog.String("accounting_id", id),
+		slog.String("payment_method", req.PaymentMethod),
+	)
+	c.JSON(http.StatusOK, accounting)
+}
+
+// GetAccountingReceipt godoc
+// @Summary 領収書PDF取得
+// @Description 回収済の会計の領収書をPDFで返します。登録番号・税率ごとの対象額と消費税額を記載した適格簡易請求書の形式です
+// @Tags accountings
+// @Produce application/pdf
+// @Param id path string true "会計ID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id}/receipt [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAccountingReceipt(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	body, err := h.svc.RenderAccountingReceipt(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "receipt issued", slog.String("accounting_id", id))
+	c.Header("Content-Disposition", `inline; filename="receipt-`+id+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", body)
+}
+
+// GetAccountingInvoice godoc
+// @Summary 請求書PDF取得
+// @Description 会計の請求書をPDFで返します。登録番号・明細（軽減税率対象の明示）・税率ごとの対象額と消費税額を記載した適格請求書の形式です
+// @Tags accountings
+// @Produce application/pdf
+// @Param id path string true "会計ID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /accountings/{id}/invoice [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAccountingInvoice(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	body, err := h.svc.RenderAccountingInvoice(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "accounting", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "invoice issued", slog.String("accounting_id", id))
+	c.Header("Content-Disposition", `inline; filename="invoice-`+id+`.pdf"`)
+	c.Data(http.StatusOK, "application/pdf", body)
+}
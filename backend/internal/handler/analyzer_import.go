@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllAnalyzerImports godoc
+// @Summary 検査装置の結果一覧取得
+// @Description 検査装置から受信した結果の一覧をページ単位で取得します（既定は受信の新しい順）。status=未照合で手動での割り当てを待つ結果を取得できます
+// @Tags analyzer-imports
+// @Accept json
+// @Produce json
+// @Param status query string false "照合状況（未照合, 照合済, 破棄）"
+// @Param date_from query string false "受信日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "受信日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -created_at"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.AnalyzerImport]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analyzer-imports [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllAnalyzerImports(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListAnalyzerImportsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	imports, err := h.svc.ListAnalyzerImports(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "analyzer_import", "")
+		return
+	}
+	c.JSON(http.StatusOK, imports)
+}
+
+// GetAnalyzerImport godoc
+// @Summary 検査装置の結果詳細取得
+// @Description 指定されたIDの検査装置の結果を、受信したメッセージ全体とともに取得します
+// @Tags analyzer-imports
+// @Accept json
+// @Produce json
+// @Param id path string true "検査装置の結果ID (UUID)"
+// @Success 200 {object} model.AnalyzerImport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analyzer-imports/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAnalyzerImport(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	record, err := h.svc.GetAnalyzerImportByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "analyzer_import", id)
+		return
+	}
+	c.JSON(http.StatusOK, record)
+}
+
+// AssignAnalyzerImport godoc
+// @Summary 検査装置の結果の割り当て
+// @Description 未照合の結果を指定した結果待ちの検査に割り当て、結果を記入して検査を完了にします
+// @Tags analyzer-imports
+// @Accept json
+// @Produce json
+// @Param id path string true "検査装置の結果ID (UUID)"
+// @Param assign body model.AssignAnalyzerImportRequest true "割り当て先の検査"
+// @Success 200 {object} model.AnalyzerImport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analyzer-imports/{id}/assign [post]
+// @Security ApiKeyAuth
+func (h *Handler) AssignAnalyzerImport(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AssignAnalyzerImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	record, err := h.svc.AssignAnalyzerImport(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "analyzer_import", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "analyzer import assigned",
+		slog.String("analyzer_import_id", id), slog.String("examination_id", req.ExaminationID))
+	c.JSON(http.StatusOK, record)
+}
+
+// DiscardAnalyzerImport godoc
+// @Summary 検査装置の結果の破棄
+// @Description 未照合の結果を破棄します（再検査・誤送信など）
+// @Tags analyzer-imports
+// @Accept json
+// @Produce json
+// @Param id path string true "検査装置の結果ID (UUID)"
+// @Param discard body model.DiscardAnalyzerImportRequest false "破棄の理由"
+// @Success 200 {object} model.AnalyzerImport
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /analyzer-imports/{id}/discard [post]
+// @Security ApiKeyAuth
+func (h *Handler) DiscardAnalyzerImport(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.DiscardAnalyzerImportRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	record, err := h.svc.DiscardAnalyzerImport(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "analyzer_import", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "analyzer import discarded", slog.String("analyzer_import_id", id))
+	c.JSON(http.StatusOK, record)
+}
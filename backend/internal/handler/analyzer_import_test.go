@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestAssignAnalyzerImport_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/analyzer-imports/:id/assign", h.AssignAnalyzerImport)
+
+	id := uuid.New().String()
+	reqBody := model.AssignAnalyzerImportRequest{ExaminationID: uuid.New().String()}
+	mockSvc.On("AssignAnalyzerImport", mock.Anything, id, &reqBody).
+		Return(nil, apperrors.WrapConflict("examination is already completed"))
+
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/analyzer-imports/"+id+"/assign", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
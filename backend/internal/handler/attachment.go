@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/imaging"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetMedicalRecordAttachments godoc
+// @Summary カルテの添付ファイル一覧取得
+// @Description カルテの添付ファイル（レントゲン・エコー画像、DICOM、PDF）を登録順に取得します。カルテに紐付く検査の添付ファイルも含みます
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Success 200 {array} model.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /medical-records/{id}/attachments [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetMedicalRecordAttachments(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	attachments, err := h.svc.GetMedicalRecordAttachments(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "medical_record", id)
+		return
+	}
+	c.JSON(http.StatusOK, attachments)
+}
+
+// UploadMedicalRecordAttachment godoc
+// @Summary カルテへのファイル添付
+// @Description カルテにファイルを添付します。JPEG・PNG・DICOM・PDFに対応し、種類はファイルの内容で判定します。DICOMはモダリティ・検査日・患者IDなどを読み取り、JPEG・PNGはサムネイルを作成します。確定済カルテにも添付できます
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Param file formData file true "添付ファイル"
+// @Param description formData string false "説明"
+// @Success 201 {object} model.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /medical-records/{id}/attachments [post]
+// @Security ApiKeyAuth
+func (h *Handler) UploadMedicalRecordAttachment(c *gin.Context) {
+	h.uploadAttachment(c, "medical_record", h.svc.UploadMedicalRecordAttachment)
+}
+
+// GetExaminationAttachments godoc
+// @Summary 検査の添付ファイル一覧取得
+// @Description 検査の添付ファイルを登録順に取得します
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "検査ID (UUID)"
+// @Success 200 {array} model.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations/{id}/attachments [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetExaminationAttachments(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	attachments, err := h.svc.GetExaminationAttachments(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "examination", id)
+		return
+	}
+	c.JSON(http.StatusOK, attachments)
+}
+
+// UploadExaminationAttachment godoc
+// @Summary 検査へのファイル添付
+// @Description 検査にファイルを添付します（画像検査のレントゲン・エコー画像など）。検査がカルテに紐付いていればカルテの添付ファイルにも表示します。対応するファイルはカルテへの添付と同じです
+// @Tags attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "検査ID (UUID)"
+// @Param file formData file true "添付ファイル"
+// @Param description formData string false "説明"
+// @Success 201 {object} model.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations/{id}/attachments [post]
+// @Security ApiKeyAuth
+func (h *Handler) UploadExaminationAttachment(c *gin.Context) {
+	h.uploadAttachment(c, "examination", h.svc.UploadExaminationAttachment)
+}
+
+// uploadAttachment multipartのfileを添付ファイルとして登録する
+func (h *Handler) uploadAttachment(c *gin.Context, resource string,
+	upload func(ctx context.Context, id string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error)) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UploadAttachmentRequest
+	if err := c.ShouldBind(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+	file, err := c.FormFile("file")
+	if err != nil {
+		slog.WarnContext(ctx, "invalid upload file", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload file"})
+		return
+	}
+	f, err := file.Open()
+	if err != nil {
+		slog.WarnContext(ctx, "invalid upload file", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload file"})
+		return
+	}
+	defer f.Close()
+	req.FileName = file.Filename
+
+	attachment, err := upload(ctx, id, f, &req)
+	if err != nil {
+		h.handleError(c, err, resource, id)
+		return
+	}
+
+	slog.InfoContext(ctx, "attachment uploaded",
+		slog.String(resource+"_id", id),
+		slog.String("attachment_id", attachment.ID.String()),
+		slog.String("content_type", attachment.ContentType),
+		slog.Int64("size", attachment.Size),
+	)
+	c.JSON(http.StatusCreated, attachment)
+}
+
+// GetAttachment godoc
+// @Summary 添付ファイル詳細取得
+// @Description 指定されたIDの添付ファイルの属性（DICOMの属性を含む）を取得します
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "添付ファイルID (UUID)"
+// @Success 200 {object} model.Attachment
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attachments/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	attachment, err := h.svc.GetAttachmentByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "attachment", id)
+		return
+	}
+	c.JSON(http.StatusOK, attachment)
+}
+
+// GetAttachmentContent godoc
+// @Summary 添付ファイルのダウンロード
+// @Description 添付ファイルの本体を登録時のContent-Typeで返します
+// @Tags attachments
+// @Produce image/jpeg,image/png,application/dicom,application/pdf
+// @Param id path string true "添付ファイルID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attachments/{id}/content [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAttachmentContent(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	attachment, body, err := h.svc.OpenAttachment(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "attachment", id)
+		return
+	}
+	defer body.Close()
+
+	disposition := mime.FormatMediaType("inline", map[string]string{"filename": attachment.FileName})
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body,
+		map[string]string{"Content-Disposition": disposition})
+}
+
+// GetAttachmentThumbnail godoc
+// @Summary 添付画像のサムネイル取得
+// @Description JPEG・PNGの添付画像のサムネイル（長辺256ピクセルのJPEG）を返します。DICOM・PDFにはサムネイルはありません
+// @Tags attachments
+// @Produce image/jpeg
+// @Param id path string true "添付ファイルID (UUID)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attachments/{id}/thumbnail [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAttachmentThumbnail(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	_, body, err := h.svc.OpenAttachmentThumbnail(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "attachment", id)
+		return
+	}
+	defer body.Close()
+
+	c.DataFromReader(http.StatusOK, -1, imaging.ThumbnailContentType, body, nil)
+}
+
+// DeleteAttachment godoc
+// @Summary 添付ファイル削除
+// @Description 添付ファイルを削除します。確定済カルテの添付ファイルは削除できません
+// @Tags attachments
+// @Accept json
+// @Produce json
+// @Param id path string true "添付ファイルID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /attachments/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteAttachment(ctx, id); err != nil {
+		h.handleError(c, err, "attachment", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "attachment deleted", slog.String("attachment_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "attachment deleted"})
+}
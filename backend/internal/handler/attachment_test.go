@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestUploadMedicalRecordAttachment(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/medical-records/:id/attachments", h.UploadMedicalRecordAttachment)
+
+	id := uuid.New().String()
+	readsFile := mock.MatchedBy(func(r io.Reader) bool {
+		b, err := io.ReadAll(r)
+		return err == nil && string(b) == "radiograph"
+	})
+	req := &model.UploadAttachmentRequest{FileName: "chest.png", Description: "胸部ラテラル"}
+	mockSvc.On("UploadMedicalRecordAttachment", mock.Anything, id, readsFile, req).
+		Return(&model.Attachment{ID: uuid.New(), FileName: "chest.png", ContentType: model.AttachmentContentTypePNG, HasThumbnail: true}, nil)
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	_ = mw.WriteField("description", "胸部ラテラル")
+	fw, _ := mw.CreateFormFile("file", "chest.png")
+	_, _ = fw.Write([]byte("radiograph"))
+	_ = mw.Close()
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(http.MethodPost, "/medical-records/"+id+"/attachments", &body)
+	httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+	r.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"has_thumbnail":true`)
+	assert.NotContains(t, w.Body.String(), "storage_key")
+	mockSvc.AssertExpectations(t)
+
+	t.Run("requires a file", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest(http.MethodPost, "/medical-records/"+id+"/attachments", nil)
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetAttachmentContent(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.GET("/attachments/:id/content", h.GetAttachmentContent)
+
+	id := uuid.New().String()
+	attachment := &model.Attachment{FileName: "腹部エコー.dcm", ContentType: model.AttachmentContentTypeDICOM, Size: 5}
+	mockSvc.On("OpenAttachment", mock.Anything, id).Return(attachment, io.NopCloser(bytes.NewReader([]byte("dicom"))), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/attachments/"+id+"/content", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/dicom", w.Header().Get("Content-Type"))
+	assert.Equal(t, "inline; filename*=utf-8''%E8%85%B9%E9%83%A8%E3%82%A8%E3%82%B3%E3%83%BC.dcm", w.Header().Get("Content-Disposition"))
+	assert.Equal(t, "dicom", w.Body.String())
+}
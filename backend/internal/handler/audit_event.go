@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ListAuditEvents godoc
+// @Summary 監査ログ検索
+// @Description 作成・更新・削除の監査ログを新しい順に検索します。entityはテーブル名（owners, pets, medical_records など）です
+// @Tags audit-events
+// @Accept json
+// @Produce json
+// @Param entity query string false "エンティティ種別"
+// @Param entity_id query string false "エンティティID（entity指定時のみ）"
+// @Param from query string false "検索開始日時（RFC3339またはYYYY-MM-DD）"
+// @Param to query string false "検索終了日時（RFC3339またはYYYY-MM-DD、日付のみの場合はその日を含む）"
+// @Param limit query int false "最大件数（既定100、最大1000）"
+// @Success 200 {array} model.AuditEvent
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /audit-events [get]
+// @Security ApiKeyAuth
+func (h *Handler) ListAuditEvents(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListAuditEventsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	events, err := h.svc.ListAuditEvents(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "audit_event", "")
+		return
+	}
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,136 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// Login godoc
+// @Summary ログイン
+// @Description スタッフのメールアドレスとパスワードで認証し、アクセストークンとリフレッシュトークンを発行します
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body model.LoginRequest true "ログイン情報"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/login [post]
+func (h *Handler) Login(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	resp, err := h.svc.Login(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "auth", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "staff logged in", slog.String("staff_id", resp.Staff.ID.String()))
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefreshToken godoc
+// @Summary トークン更新
+// @Description リフレッシュトークンを使って新しいアクセストークンとリフレッシュトークンを発行します。使用したリフレッシュトークンは失効します
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body model.RefreshTokenRequest true "リフレッシュトークン"
+// @Success 200 {object} model.TokenResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/refresh [post]
+func (h *Handler) RefreshToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	resp, err := h.svc.RefreshToken(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "auth", "")
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// Logout godoc
+// @Summary ログアウト
+// @Description リフレッシュトークンを失効させます
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param token body model.RefreshTokenRequest true "リフレッシュトークン"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /auth/logout [post]
+func (h *Handler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.svc.Logout(ctx, &req); err != nil {
+		h.handleError(c, err, "auth", "")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "logged out"})
+}
+
+// SetStaffPassword godoc
+// @Summary スタッフパスワード設定
+// @Description 指定されたスタッフのパスワードを設定します（管理者のみ）。既存のリフレッシュトークンは全て失効します
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path string true "スタッフID (UUID)"
+// @Param password body model.SetPasswordRequest true "新しいパスワード"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /staffs/{id}/password [put]
+// @Security ApiKeyAuth
+func (h *Handler) SetStaffPassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.SetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if err := h.svc.SetStaffPassword(ctx, id, &req); err != nil {
+		h.handleError(c, err, "staff", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "staff password updated", slog.String("staff_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "password updated"})
+}
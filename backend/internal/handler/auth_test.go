@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/animal-ekarte/backend/internal/auth"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func setupAuthTestRouter() (*gin.Engine, *MockService) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	r := gin.New()
+	New(mockSvc).RegisterRoutes(r)
+	return r, mockSvc
+}
+
+func TestRoutes_RequireAuthentication(t *testing.T) {
+	r, mockSvc := setupAuthTestRouter()
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/pets", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	mockSvc.AssertNotCalled(t, "ListPets", mock.Anything, mock.Anything)
+}
+
+func TestRoutes_RejectInvalidToken(t *testing.T) {
+	r, mockSvc := setupAuthTestRouter()
+
+	mockSvc.On("AuthenticateToken", mock.Anything, "bad-token").
+		Return(nil, apperrors.WrapUnauthorized("invalid access token"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/pets", http.NoBody)
+	req.Header.Set("Authorization", "Bearer bad-token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestRoutes_EnforceRolePolicy(t *testing.T) {
+	r, mockSvc := setupAuthTestRouter()
+
+	mockSvc.On("AuthenticateToken", mock.Anything, "vet-token").
+		Return(&auth.Claims{Subject: uuid.New().String(), Role: model.StaffRoleVeterinarian}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodDelete, "/api/v1/owners/"+uuid.New().String(), http.NoBody)
+	req.Header.Set("Authorization", "Bearer vet-token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	mockSvc.AssertNotCalled(t, "DeleteOwner", mock.Anything, mock.Anything)
+}
+
+func TestRoutes_RestrictDestructiveOperations(t *testing.T) {
+	id := uuid.New().String()
+	routes := []struct {
+		method, path, role string
+	}{
+		// 削除・廃棄は管理者のみ
+		{http.MethodDelete, "/api/v1/pets/" + id, model.StaffRoleVeterinarian},
+		{http.MethodDelete, "/api/v1/medical-records/" + id, model.StaffRoleVeterinarian},
+		{http.MethodDelete, "/api/v1/accountings/" + id, model.StaffRoleNurse},
+		{http.MethodDelete, "/api/v1/attachments/" + id, model.StaffRoleVeterinarian},
+		{http.MethodDelete, "/api/v1/hospitalizations/" + id, model.StaffRoleNurse},
+		{http.MethodPost, "/api/v1/inventory/lots/write-off-expired", model.StaffRoleNurse},
+		{http.MethodPost, "/api/v1/inventory/lots/" + id + "/write-off", model.StaffRoleVeterinarian},
+		// 検査機器の取り込み結果の割り当て・破棄は獣医師・看護師のみ
+		{http.MethodPost, "/api/v1/analyzer-imports/" + id + "/assign", model.StaffRoleGroomer},
+		{http.MethodPost, "/api/v1/analyzer-imports/" + id + "/discard", model.StaffRoleAdmin},
+	}
+
+	for _, route := range routes {
+		t.Run(route.method+" "+route.path, func(t *testing.T) {
+			r, mockSvc := setupAuthTestRouter()
+			mockSvc.On("AuthenticateToken", mock.Anything, "token").
+				Return(&auth.Claims{Subject: uuid.New().String(), Role: route.role}, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(route.method, route.path, http.NoBody)
+			req.Header.Set("Authorization", "Bearer token")
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRoutes_AllowAuthorizedRole(t *testing.T) {
+	r, mockSvc := setupAuthTestRouter()
+
+	id := uuid.New()
+	mockSvc.On("AuthenticateToken", mock.Anything, "vet-token").
+		Return(&auth.Claims{Subject: uuid.New().String(), Role: model.StaffRoleVeterinarian}, nil)
+	mockSvc.On("FinalizeMedicalRecord", mock.Anything, id.String()).
+		Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/medical-records/"+id.String()+"/finalize", http.NoBody)
+	req.Header.Set("Authorization", "Bearer vet-token")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestLogin_InvalidCredentials(t *testing.T) {
+	r, mockSvc := setupAuthTestRouter()
+
+	mockSvc.On("Login", mock.Anything, &model.LoginRequest{Email: "vet@example.com", Password: "wrong"}).
+		Return(nil, apperrors.WrapUnauthorized("invalid email or password"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/auth/login",
+		bytes.NewBufferString(`{"email":"vet@example.com","password":"wrong"}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetDailyRecords godoc
+// @Summary 日次記録一覧取得
+// @Description 入院の日次記録をバイタル・ケアログ・スタッフメモ付きで日付順に取得します
+// @Tags daily-records
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Success 200 {array} model.DailyRecord
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/daily-records [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetDailyRecords(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	records, err := h.svc.GetDailyRecords(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+	c.JSON(http.StatusOK, records)
+}
+
+// AddVital godoc
+// @Summary バイタル記録
+// @Description 体温・心拍数・呼吸数・体重を記録します。その日の日次記録がなければ作成します
+// @Tags daily-records
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param vital body model.AddVitalRequest true "バイタル"
+// @Success 201 {object} model.Vital
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/daily-records/vitals [post]
+// @Security ApiKeyAuth
+func (h *Handler) AddVital(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddVitalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	vital, err := h.svc.AddVital(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "vital recorded",
+		slog.String("hospitalization_id", id),
+		slog.String("daily_record_id", vital.DailyRecordID.String()),
+	)
+	c.JSON(http.StatusCreated, vital)
+}
+
+// AddCareLog godoc
+// @Summary ケアログ記録
+// @Description 食事・排泄・投薬・処置の実施を記録します。care_plan_item_idとtimingを指定すると温度板上でケアプランの実施として扱います
+// @Tags daily-records
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param care_log body model.AddCareLogRequest true "ケアログ"
+// @Success 201 {object} model.CareLog
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/daily-records/care-logs [post]
+// @Security ApiKeyAuth
+func (h *Handler) AddCareLog(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddCareLogRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	careLog, err := h.svc.AddCareLog(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "care log recorded",
+		slog.String("hospitalization_id", id),
+		slog.String("daily_record_id", careLog.DailyRecordID.String()),
+	)
+	c.JSON(http.StatusCreated, careLog)
+}
+
+// AddStaffNote godoc
+// @Summary スタッフメモ記録
+// @Description 日次記録にスタッフメモを記録します。その日の日次記録がなければ作成します
+// @Tags daily-records
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param note body model.AddStaffNoteRequest true "スタッフメモ"
+// @Success 201 {object} model.StaffNote
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/daily-records/staff-notes [post]
+// @Security ApiKeyAuth
+func (h *Handler) AddStaffNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddStaffNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	note, err := h.svc.AddStaffNote(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "staff note recorded",
+		slog.String("hospitalization_id", id),
+		slog.String("daily_record_id", note.DailyRecordID.String()),
+	)
+	c.JSON(http.StatusCreated, note)
+}
+
+// GetTemperatureChart godoc
+// @Summary 温度板取得
+// @Description 指定日に入院していたペットごとに、バイタルの推移とケアプランのタイミングごとの実施状況を取得します
+// @Tags daily-records
+// @Accept json
+// @Produce json
+// @Param date query string false "対象日（YYYY-MM-DD、省略時は当日）"
+// @Success 200 {object} model.TemperatureChart
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /ward/temperature-chart [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetTemperatureChart(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.TemperatureChartRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	chart, err := h.svc.GetTemperatureChart(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "temperature_chart", "")
+		return
+	}
+	c.JSON(http.StatusOK, chart)
+}
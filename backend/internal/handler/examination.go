@@ -0,0 +1,305 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllExaminations godoc
+// @Summary 検査一覧取得
+// @Description 登録されている検査の一覧をページ単位で取得します（既定は検査日の新しい順）
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param medical_record_id query string false "依頼元のカルテID (UUID)"
+// @Param status query string false "ステータス（依頼中, 検査中, 完了）"
+// @Param date_from query string false "検査日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "検査日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -examination_date"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Examination]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllExaminations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListExaminationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	examinations, err := h.svc.ListExaminations(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "examination", "")
+		return
+	}
+	c.JSON(http.StatusOK, examinations)
+}
+
+// GetExamination godoc
+// @Summary 検査詳細取得
+// @Description 指定されたIDの検査を取得します
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param id path string true "検査ID (UUID)"
+// @Success 200 {object} model.Examination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetExamination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	examination, err := h.svc.GetExaminationByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "examination", id)
+		return
+	}
+	c.JSON(http.StatusOK, examination)
+}
+
+// CreateExamination godoc
+// @Summary 検査依頼
+// @Description 検査を依頼します。medical_record_idを指定すると同じペットのカルテに紐付け、担当医を省略するとカルテの担当医を引き継ぎます
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param examination body model.CreateExaminationRequest true "依頼内容"
+// @Success 201 {object} model.Examination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateExamination(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateExaminationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	examination, err := h.svc.CreateExamination(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "examination", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "examination created", slog.String("examination_id", examination.ID.String()))
+	c.JSON(http.StatusCreated, examination)
+}
+
+// UpdateExamination godoc
+// @Summary 検査依頼変更
+// @Description 完了前の検査を変更します。analytesを指定すると依頼項目を置き換えます
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param id path string true "検査ID (UUID)"
+// @Param examination body model.UpdateExaminationRequest true "変更する依頼内容"
+// @Success 200 {object} model.Examination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateExamination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateExaminationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	examination, err := h.svc.UpdateExamination(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "examination", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "examination updated", slog.String("examination_id", id))
+	c.JSON(http.StatusOK, examination)
+}
+
+// CompleteExamination godoc
+// @Summary 検査完了
+// @Description 検査結果を記入して完了にします。数値の結果はペットの動物種の基準範囲（なければ動物種を問わない範囲）で判定し、高値はH、低値はLを付けます
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param id path string true "検査ID (UUID)"
+// @Param complete body model.CompleteExaminationRequest true "検査結果"
+// @Success 200 {object} model.Examination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /examinations/{id}/complete [post]
+// @Security ApiKeyAuth
+func (h *Handler) CompleteExamination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.CompleteExaminationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	examination, err := h.svc.CompleteExamination(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "examination", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "examination completed", slog.String("examination_id", id))
+	c.JSON(http.StatusOK, examination)
+}
+
+// GetExaminationTrend godoc
+// @Summary 検査項目の推移取得
+// @Description ペットの完了した検査から、指定した検査項目の結果を検査日の古い順に取得します
+// @Tags examinations
+// @Accept json
+// @Produce json
+// @Param id path string true "ペットID (UUID)"
+// @Param analyte query string true "検査項目コード（例: ALT）"
+// @Param date_from query string false "検査日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "検査日（終了、YYYY-MM-DD）"
+// @Success 200 {object} model.ExaminationTrend
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pets/{id}/examinations/trend [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetExaminationTrend(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.ExaminationTrendRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	trend, err := h.svc.GetExaminationTrend(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "pet", id)
+		return
+	}
+	c.JSON(http.StatusOK, trend)
+}
+
+// GetLabReferenceRanges godoc
+// @Summary 検査基準範囲一覧取得
+// @Description 検査項目の基準範囲を項目コード・動物種別の順に取得します
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param analyte query string false "検査項目コード"
+// @Param species query string false "動物種（犬・猫などの表記は動物種別にそろえます）"
+// @Success 200 {array} model.LabReferenceRange
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/lab-reference-ranges [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetLabReferenceRanges(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListLabReferenceRangesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	references, err := h.svc.ListLabReferenceRanges(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "lab_reference_range", "")
+		return
+	}
+	c.JSON(http.StatusOK, references)
+}
+
+// SaveLabReferenceRange godoc
+// @Summary 検査基準範囲登録
+// @Description 検査項目の基準範囲を登録します。同じ項目・動物種別の範囲があれば上書きします。speciesを省略すると動物種を問わない既定の範囲になります（管理者・獣医師のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param reference body model.SaveLabReferenceRangeRequest true "基準範囲"
+// @Success 200 {object} model.LabReferenceRange
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/lab-reference-ranges [put]
+// @Security ApiKeyAuth
+func (h *Handler) SaveLabReferenceRange(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SaveLabReferenceRangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	reference, err := h.svc.SaveLabReferenceRange(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "lab_reference_range", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "lab reference range saved",
+		slog.String("analyte", reference.Analyte), slog.String("species", reference.Species))
+	c.JSON(http.StatusOK, reference)
+}
+
+// DeleteLabReferenceRange godoc
+// @Summary 検査基準範囲削除
+// @Description 検査項目の基準範囲を削除します。完了済みの検査に写した範囲と判定は変わりません（管理者・獣医師のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "基準範囲ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/lab-reference-ranges/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteLabReferenceRange(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteLabReferenceRange(ctx, id); err != nil {
+		h.handleError(c, err, "lab_reference_range", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "lab reference range deleted", slog.String("lab_reference_range_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "lab reference range deleted"})
+}
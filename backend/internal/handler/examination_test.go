@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestCompleteExamination(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/examinations/:id/complete", h.CompleteExamination)
+
+	id := uuid.New()
+	value := 180.0
+	reqBody := model.CompleteExaminationRequest{
+		Results: []model.ExaminationResultInput{{Analyte: "ALT", Value: &value}},
+	}
+	expected := &model.Examination{
+		ID:     id,
+		Status: model.ExaminationStatusCompleted,
+		Items:  model.ExaminationItems{{Analyte: "ALT", Value: &value, Unit: "U/L", Flag: model.ExaminationFlagHigh}},
+	}
+	mockSvc.On("CompleteExamination", mock.Anything, id.String(), &reqBody).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/examinations/"+id.String()+"/complete", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response model.Examination
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, model.ExaminationFlagHigh, response.Items[0].Flag)
+
+	// 結果のない完了は本文の検証で弾く
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/examinations/"+id.String()+"/complete", bytes.NewBufferString(`{}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetExaminationTrend_PetNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.GET("/pets/:id/examinations/trend", h.GetExaminationTrend)
+
+	id := uuid.New().String()
+	mockSvc.On("GetExaminationTrend", mock.Anything, id, &model.ExaminationTrendRequest{Analyte: "ALT"}).
+		Return(nil, apperrors.WrapNotFound("pet", id))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/pets/"+id+"/examinations/trend?analyte=ALT", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+
+	// 検査項目の指定は必須
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/pets/"+id+"/examinations/trend", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -8,6 +8,7 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/animal-ekarte/backend/internal/middleware"
+	"github.com/animal-ekarte/backend/internal/model"
 	"github.com/animal-ekarte/backend/internal/service"
 )
 
@@ -16,6 +17,24 @@ type Service interface {
 	service.PetService
 	service.OwnerService
 	service.MedicalRecordService
+	service.ReservationService
+	service.AuditService
+	service.AuthService
+	service.AccountingService
+	service.HospitalizationService
+	service.DailyRecordService
+	service.WardService
+	service.VaccinationService
+	service.MasterItemService
+	service.InventoryService
+	service.PatientSearchService
+	service.OwnerMergeService
+	service.PetTransferService
+	service.TrimmingService
+	service.ExaminationService
+	service.AnalyzerImportService
+	service.AttachmentService
+	service.PetMeasurementService
 	GetDB() (interface{ DB() *gorm.DB }, error)
 }
 
@@ -47,27 +66,174 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	v1 := r.Group("/api/v1")
 	v1.GET("/", h.Welcome)
 
+	// Auth（認証不要）
+	v1.POST("/auth/login", h.Login)
+	v1.POST("/auth/refresh", h.RefreshToken)
+	v1.POST("/auth/logout", h.Logout)
+
+	// 以降のルートは認証必須
+	v1.Use(middleware.Authenticate(h.svc))
+
+	// Staffs
+	v1.PUT("/staffs/:id/password", middleware.RequireRole(model.StaffRoleAdmin), h.SetStaffPassword)
+
+	// 患者検索（飼い主・ペット横断）
+	v1.GET("/search", h.SearchPatients)
+
 	// Pets CRUD
 	v1.GET("/pets", h.GetPets)
 	v1.GET("/pets/:id", h.GetPet)
 	v1.POST("/pets", h.CreatePet)
 	v1.PUT("/pets/:id", h.UpdatePet)
-	v1.DELETE("/pets/:id", h.DeletePet)
+	v1.DELETE("/pets/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeletePet)
+	v1.POST("/pets/:id/transfer", h.TransferPet)
+	v1.GET("/pets/:id/owners", h.GetPetOwnerships)
+	v1.GET("/pets/:id/measurements", h.GetPetMeasurements)
+	v1.POST("/pets/:id/measurements", h.RecordPetMeasurements)
 
 	// Owners CRUD
 	v1.GET("/owners", h.GetAllOwners)
+	v1.GET("/owners/duplicates", h.FindOwnerDuplicates)
 	v1.GET("/owners/:id", h.GetOwnerByID)
+	v1.POST("/owners/:id/merge", middleware.RequireRole(model.StaffRoleAdmin), h.MergeOwner)
+	v1.GET("/owners/:id/merges", h.GetOwnerMerges)
 	v1.POST("/owners", h.CreateOwner)
 	v1.PUT("/owners/:id", h.UpdateOwner)
-	v1.DELETE("/owners/:id", h.DeleteOwner)
+	v1.DELETE("/owners/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteOwner)
 
 	// Medical Records CRUD
 	v1.GET("/medical-records", h.GetAllMedicalRecords)
-	v1.GET("/medical-records/paginated", h.GetMedicalRecordsWithPagination)
+	v1.GET("/medical-records/search", h.SearchMedicalRecords)
+	v1.GET("/medical-records/paginated", h.GetMedicalRecordsWithPagination) // 非推奨（/medical-recordsと同じ）
 	v1.GET("/medical-records/:id", h.GetMedicalRecord)
 	v1.POST("/medical-records", h.CreateMedicalRecord)
 	v1.PUT("/medical-records/:id", h.UpdateMedicalRecord)
-	v1.DELETE("/medical-records/:id", h.DeleteMedicalRecord)
+	v1.DELETE("/medical-records/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteMedicalRecord)
+	v1.POST("/medical-records/:id/finalize", middleware.RequireRole(model.StaffRoleVeterinarian), h.FinalizeMedicalRecord)
+	v1.GET("/medical-records/:id/amendments", h.GetMedicalRecordAmendments)
+	v1.POST("/medical-records/:id/amendments", middleware.RequireRole(model.StaffRoleVeterinarian), h.AmendMedicalRecord)
+	v1.GET("/medical-records/:id/items", h.GetMedicalRecordItems)
+	v1.POST("/medical-records/:id/items", h.AddMedicalRecordItem)
+	v1.DELETE("/medical-records/:id/items/:item_id", h.DeleteMedicalRecordItem)
+	v1.GET("/medical-records/:id/attachments", h.GetMedicalRecordAttachments)
+	v1.POST("/medical-records/:id/attachments", h.UploadMedicalRecordAttachment)
+
+	// Reservations
+	v1.GET("/reservations", h.GetAllReservations)
+	v1.GET("/reservations/:id", h.GetReservation)
+	v1.POST("/reservations", h.CreateReservation)
+	v1.PUT("/reservations/:id", h.UpdateReservation)
+	v1.DELETE("/reservations/:id", h.DeleteReservation)
+	v1.POST("/reservations/:id/cancel", h.CancelReservation)
+	v1.POST("/reservations/:id/check-in", h.CheckInReservation)
+
+	// Trimmings
+	v1.GET("/trimmings", h.GetAllTrimmings)
+	v1.GET("/trimmings/board", h.GetTrimmingBoard)
+	v1.GET("/trimmings/:id", h.GetTrimming)
+	v1.POST("/trimmings", h.CreateTrimming)
+	v1.PUT("/trimmings/:id", h.UpdateTrimming)
+	v1.POST("/trimmings/:id/start", h.StartTrimming)
+	v1.POST("/trimmings/:id/complete", h.CompleteTrimming)
+	v1.POST("/trimmings/:id/cancel", h.CancelTrimming)
+
+	// Examinations
+	v1.GET("/examinations", h.GetAllExaminations)
+	v1.GET("/examinations/:id", h.GetExamination)
+	v1.POST("/examinations", h.CreateExamination)
+	v1.PUT("/examinations/:id", h.UpdateExamination)
+	v1.POST("/examinations/:id/complete", h.CompleteExamination)
+	v1.GET("/pets/:id/examinations/trend", h.GetExaminationTrend)
+	v1.GET("/examinations/:id/attachments", h.GetExaminationAttachments)
+	v1.POST("/examinations/:id/attachments", h.UploadExaminationAttachment)
+
+	// Attachments（カルテ・検査の添付ファイル）
+	v1.GET("/attachments/:id", h.GetAttachment)
+	v1.GET("/attachments/:id/content", h.GetAttachmentContent)
+	v1.GET("/attachments/:id/thumbnail", h.GetAttachmentThumbnail)
+	v1.DELETE("/attachments/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteAttachment)
+
+	// Analyzer imports（検査装置の結果）
+	v1.GET("/analyzer-imports", h.GetAllAnalyzerImports)
+	v1.GET("/analyzer-imports/:id", h.GetAnalyzerImport)
+	v1.POST("/analyzer-imports/:id/assign", middleware.RequireRole(model.StaffRoleVeterinarian, model.StaffRoleNurse), h.AssignAnalyzerImport)
+	v1.POST("/analyzer-imports/:id/discard", middleware.RequireRole(model.StaffRoleVeterinarian, model.StaffRoleNurse), h.DiscardAnalyzerImport)
+
+	// Accountings
+	v1.GET("/accountings", h.GetAllAccountings)
+	v1.GET("/accountings/:id", h.GetAccounting)
+	v1.POST("/accountings", h.CreateAccounting)
+	v1.PUT("/accountings/:id", h.UpdateAccounting)
+	v1.DELETE("/accountings/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteAccounting)
+	v1.POST("/accountings/:id/items", h.AddAccountingItem)
+	v1.DELETE("/accountings/:id/items/:item_id", h.DeleteAccountingItem)
+	v1.POST("/accountings/:id/complete", h.CompleteAccounting)
+	v1.GET("/accountings/:id/receipt", h.GetAccountingReceipt)
+	v1.GET("/accountings/:id/invoice", h.GetAccountingInvoice)
+
+	// Hospitalizations
+	v1.GET("/hospitalizations", h.GetAllHospitalizations)
+	v1.GET("/hospitalizations/:id", h.GetHospitalization)
+	v1.POST("/hospitalizations", h.AdmitHospitalization)
+	v1.PUT("/hospitalizations/:id", h.UpdateHospitalization)
+	v1.DELETE("/hospitalizations/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteHospitalization)
+	v1.POST("/hospitalizations/:id/discharge", h.DischargeHospitalization)
+	v1.GET("/hospitalizations/:id/care-plan-items", h.GetCarePlanItems)
+	v1.POST("/hospitalizations/:id/care-plan-items", h.AddCarePlanItem)
+	v1.DELETE("/hospitalizations/:id/care-plan-items/:item_id", h.DeleteCarePlanItem)
+	v1.GET("/hospitalizations/:id/daily-records", h.GetDailyRecords)
+	v1.POST("/hospitalizations/:id/daily-records/vitals", h.AddVital)
+	v1.POST("/hospitalizations/:id/daily-records/care-logs", h.AddCareLog)
+	v1.POST("/hospitalizations/:id/daily-records/staff-notes", h.AddStaffNote)
+
+	// Ward（病棟）
+	v1.GET("/ward/temperature-chart", h.GetTemperatureChart)
+	v1.GET("/ward/tasks", h.GetWardTasks)
+
+	// Vaccinations
+	v1.GET("/vaccinations", h.GetAllVaccinations)
+	v1.GET("/vaccinations/due", h.GetDueVaccinations)
+	v1.GET("/vaccinations/:id", h.GetVaccination)
+	v1.POST("/vaccinations", h.CreateVaccination)
+	v1.PUT("/vaccinations/:id", h.UpdateVaccination)
+	v1.DELETE("/vaccinations/:id", h.DeleteVaccination)
+	v1.POST("/vaccinations/reminders/run", middleware.RequireRole(model.StaffRoleAdmin), h.RunVaccinationReminders)
+
+	// Master
+	v1.GET("/master/categories", h.GetMasterCategories)
+	v1.GET("/master/items", h.GetMasterItems)
+	v1.GET("/master/items/export", h.ExportMasterItems)
+	v1.POST("/master/items/import", middleware.RequireRole(model.StaffRoleAdmin), h.ImportMasterItems)
+	v1.GET("/master/items/:id", h.GetMasterItem)
+	v1.POST("/master/items", middleware.RequireRole(model.StaffRoleAdmin), h.CreateMasterItem)
+	v1.PUT("/master/items/:id", middleware.RequireRole(model.StaffRoleAdmin), h.UpdateMasterItem)
+	v1.DELETE("/master/items/:id", middleware.RequireRole(model.StaffRoleAdmin), h.DeleteMasterItem)
+	v1.GET("/master/items/:id/prices", h.GetMasterItemPrices)
+	v1.POST("/master/items/:id/prices", middleware.RequireRole(model.StaffRoleAdmin), h.CreateMasterItemPrice)
+	v1.PUT("/master/items/:id/vaccine-protocol", middleware.RequireRole(model.StaffRoleAdmin, model.StaffRoleVeterinarian), h.UpdateVaccineProtocol)
+	v1.GET("/master/lab-reference-ranges", h.GetLabReferenceRanges)
+	v1.PUT("/master/lab-reference-ranges", middleware.RequireRole(model.StaffRoleAdmin, model.StaffRoleVeterinarian), h.SaveLabReferenceRange)
+	v1.DELETE("/master/lab-reference-ranges/:id", middleware.RequireRole(model.StaffRoleAdmin, model.StaffRoleVeterinarian), h.DeleteLabReferenceRange)
+
+	// Inventory
+	v1.GET("/inventory", h.GetAllInventoryItems)
+	v1.GET("/inventory/low-stock", h.GetLowStockItems)
+	v1.GET("/inventory/lots/expiring", h.GetExpiringLots)
+	v1.POST("/inventory/lots/write-off-expired", middleware.RequireRole(model.StaffRoleAdmin), h.WriteOffExpiredLots)
+	v1.POST("/inventory/lots/:lot_id/write-off", middleware.RequireRole(model.StaffRoleAdmin), h.WriteOffLot)
+	v1.GET("/inventory/:id", h.GetInventoryItem)
+	v1.POST("/inventory", h.CreateInventoryItem)
+	v1.PUT("/inventory/:id", h.UpdateInventoryItem)
+	v1.GET("/inventory/:id/movements", h.GetStockMovements)
+	v1.POST("/inventory/:id/movements", h.RecordStockMovement)
+	v1.GET("/inventory/:id/lots", h.GetInventoryLots)
+
+	// Cages
+	v1.GET("/cages", h.GetAllCages)
+	v1.GET("/cages/available", h.GetAvailableCages)
+
+	// Audit Events
+	v1.GET("/audit-events", middleware.RequireRole(model.StaffRoleAdmin), h.ListAuditEvents)
 }
 
 // Health godoc
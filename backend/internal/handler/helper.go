@@ -28,6 +28,28 @@ func (h *Handler) handleError(c *gin.Context, err error, resource, id string) {
 		)
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 
+	case apperrors.IsConflict(err):
+		slog.WarnContext(ctx, "resource conflict",
+			slog.String("resource", resource),
+			slog.String("id", id),
+			slog.String("error", err.Error()),
+		)
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+
+	case apperrors.IsUnauthorized(err):
+		slog.WarnContext(ctx, "unauthorized",
+			slog.String("error", err.Error()),
+		)
+		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: err.Error()})
+
+	case apperrors.IsForbidden(err):
+		slog.WarnContext(ctx, "forbidden",
+			slog.String("resource", resource),
+			slog.String("id", id),
+			slog.String("error", err.Error()),
+		)
+		c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+
 	default:
 		slog.ErrorContext(ctx, "internal error",
 			slog.String("error", err.Error()),
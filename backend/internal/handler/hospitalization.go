@@ -0,0 +1,370 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllHospitalizations godoc
+// @Summary 入院一覧取得
+// @Description 登録されている入院・ホテルの一覧をページ単位で取得します（既定は開始日の新しい順）
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param status query string false "ステータス"
+// @Param type query string false "種別（入院/ホテル）"
+// @Param date_from query string false "開始日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "開始日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -start_date"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Hospitalization]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllHospitalizations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListHospitalizationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	hospitalizations, err := h.svc.ListHospitalizations(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", "")
+		return
+	}
+	c.JSON(http.StatusOK, hospitalizations)
+}
+
+// GetHospitalization godoc
+// @Summary 入院詳細取得
+// @Description 指定されたIDの入院をケージ・ケアプラン付きで取得します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Success 200 {object} model.Hospitalization
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetHospitalization(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	hospitalization, err := h.svc.GetHospitalizationByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+	c.JSON(http.StatusOK, hospitalization)
+}
+
+// AdmitHospitalization godoc
+// @Summary 入院受付
+// @Description 入院（ホテル）を受け付けます。cage_id省略時はペットの種類・サイズに合い、期間中に空いているケージを自動で割り当てます
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param hospitalization body model.CreateHospitalizationRequest true "入院受付情報"
+// @Success 201 {object} model.Hospitalization
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations [post]
+// @Security ApiKeyAuth
+func (h *Handler) AdmitHospitalization(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateHospitalizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	hospitalization, err := h.svc.AdmitHospitalization(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", "")
+		return
+	}
+
+	cageID := ""
+	if hospitalization.CageID != nil {
+		cageID = hospitalization.CageID.String()
+	}
+	slog.InfoContext(ctx, "hospitalization admitted",
+		slog.String("hospitalization_id", hospitalization.ID.String()),
+		slog.String("pet_id", req.PetID),
+		slog.String("cage_id", cageID),
+	)
+	c.JSON(http.StatusCreated, hospitalization)
+}
+
+// UpdateHospitalization godoc
+// @Summary 入院情報更新
+// @Description 退院予定日・ケージ・ステータス等を更新します。期間・ケージを変更する場合は他の入院との重複を確認します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param hospitalization body model.UpdateHospitalizationRequest true "更新する入院情報"
+// @Success 200 {object} model.Hospitalization
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateHospitalization(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateHospitalizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	hospitalization, err := h.svc.UpdateHospitalization(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "hospitalization updated", slog.String("hospitalization_id", id))
+	c.JSON(http.StatusOK, hospitalization)
+}
+
+// DeleteHospitalization godoc
+// @Summary 入院予約取消
+// @Description 予約状態の入院を削除します。入院中・退院済の入院は削除できません
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteHospitalization(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteHospitalization(ctx, id); err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "hospitalization deleted", slog.String("hospitalization_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "hospitalization deleted"})
+}
+
+// DischargeHospitalization godoc
+// @Summary 退院処理
+// @Description 退院日を記録してケージを解放します。create_accountingを指定するとケアプランの単価から未収の会計を作成します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param discharge body model.DischargeHospitalizationRequest false "退院情報"
+// @Success 200 {object} model.DischargeResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/discharge [post]
+// @Security ApiKeyAuth
+func (h *Handler) DischargeHospitalization(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.DischargeHospitalizationRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	result, err := h.svc.DischargeHospitalization(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	attrs := []any{slog.String("hospitalization_id", id)}
+	if result.Accounting != nil {
+		attrs = append(attrs, slog.String("accounting_id", result.Accounting.ID.String()))
+	}
+	slog.InfoContext(ctx, "hospitalization discharged", attrs...)
+	c.JSON(http.StatusOK, result)
+}
+
+// GetCarePlanItems godoc
+// @Summary ケアプラン項目一覧取得
+// @Description 入院のケアプラン項目を取得します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Success 200 {array} model.CarePlanItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/care-plan-items [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetCarePlanItems(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	items, err := h.svc.GetCarePlanItems(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// AddCarePlanItem godoc
+// @Summary ケアプラン項目追加
+// @Description 入院にケアプラン項目を追加します。master_idを指定した場合は名称・単価をマスタから補完します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param item body model.AddCarePlanItemRequest true "ケアプラン項目"
+// @Success 201 {object} model.CarePlanItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/care-plan-items [post]
+// @Security ApiKeyAuth
+func (h *Handler) AddCarePlanItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddCarePlanItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.AddCarePlanItem(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "hospitalization", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "care plan item added",
+		slog.String("hospitalization_id", id),
+		slog.String("item_id", item.ID.String()),
+	)
+	c.JSON(http.StatusCreated, item)
+}
+
+// DeleteCarePlanItem godoc
+// @Summary ケアプラン項目削除
+// @Description 入院からケアプラン項目を削除します
+// @Tags hospitalizations
+// @Accept json
+// @Produce json
+// @Param id path string true "入院ID (UUID)"
+// @Param item_id path string true "ケアプラン項目ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /hospitalizations/{id}/care-plan-items/{item_id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteCarePlanItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	itemID := c.Param("item_id")
+
+	if err := h.svc.DeleteCarePlanItem(ctx, id, itemID); err != nil {
+		h.handleError(c, err, "care_plan_item", itemID)
+		return
+	}
+
+	slog.InfoContext(ctx, "care plan item deleted",
+		slog.String("hospitalization_id", id),
+		slog.String("item_id", itemID),
+	)
+	c.JSON(http.StatusOK, gin.H{"message": "care plan item deleted"})
+}
+
+// GetAllCages godoc
+// @Summary ケージ一覧取得
+// @Description 登録されているケージの一覧を取得します
+// @Tags cages
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Cage
+// @Failure 500 {object} ErrorResponse
+// @Router /cages [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllCages(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	cages, err := h.svc.GetAllCages(ctx)
+	if err != nil {
+		h.handleError(c, err, "cage", "")
+		return
+	}
+	c.JSON(http.StatusOK, cages)
+}
+
+// GetAvailableCages godoc
+// @Summary 空きケージ検索
+// @Description 指定期間中に空いている、ペットの種類・サイズに合うケージを割り当て優先順に取得します
+// @Tags cages
+// @Accept json
+// @Produce json
+// @Param start_date query string true "開始日（YYYY-MM-DD）"
+// @Param end_date query string true "終了日（YYYY-MM-DD）"
+// @Param species query string false "ペットの種類（犬、猫など）"
+// @Param size query string false "最小ケージサイズ（S, M, L, XL）"
+// @Success 200 {array} model.Cage
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /cages/available [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAvailableCages(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.AvailableCagesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	cages, err := h.svc.GetAvailableCages(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "cage", "")
+		return
+	}
+	c.JSON(http.StatusOK, cages)
+}
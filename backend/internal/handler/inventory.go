@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllInventoryItems godoc
+// @Summary 在庫一覧取得
+// @Description 登録されている在庫品目の一覧を名前順に取得します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.InventoryItem
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllInventoryItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	items, err := h.svc.GetAllInventoryItems(ctx)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", "")
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// GetLowStockItems godoc
+// @Summary 在庫不足一覧取得
+// @Description 在庫数が最低在庫数以下の品目を、在庫切れ・不足数の多い順に取得します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.InventoryItem
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/low-stock [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetLowStockItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	items, err := h.svc.GetLowStockItems(ctx)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", "")
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// GetInventoryItem godoc
+// @Summary 在庫品目詳細取得
+// @Description 指定されたIDの在庫品目を取得します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "在庫品目ID (UUID)"
+// @Success 200 {object} model.InventoryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetInventoryItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	item, err := h.svc.GetInventoryItemByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", id)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// CreateInventoryItem godoc
+// @Summary 在庫品目登録
+// @Description 在庫品目を登録します。quantityを指定すると初期在庫としてlot_number・expiry_dateのロットに入庫を記録します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param item body model.CreateInventoryItemRequest true "在庫品目情報"
+// @Success 201 {object} model.InventoryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateInventoryItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateInventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.CreateInventoryItem(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "inventory item created", slog.String("inventory_item_id", item.ID.String()))
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateInventoryItem godoc
+// @Summary 在庫品目更新
+// @Description 在庫品目の名称・最低在庫数などを更新します。在庫数は入出庫で変更します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "在庫品目ID (UUID)"
+// @Param item body model.UpdateInventoryItemRequest true "更新情報"
+// @Success 200 {object} model.InventoryItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateInventoryItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateInventoryItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.UpdateInventoryItem(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "inventory item updated", slog.String("inventory_item_id", id))
+	c.JSON(http.StatusOK, item)
+}
+
+// GetStockMovements godoc
+// @Summary 入出庫履歴取得
+// @Description 在庫品目の入庫・払出・棚卸調整・廃棄の履歴を新しい順に取得します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "在庫品目ID (UUID)"
+// @Success 200 {array} model.StockMovement
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/{id}/movements [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetStockMovements(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	movements, err := h.svc.GetStockMovements(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", id)
+		return
+	}
+	c.JSON(http.StatusOK, movements)
+}
+
+// RecordStockMovement godoc
+// @Summary 入出庫登録
+// @Description 入庫(receive)・払出(dispense)・棚卸調整(adjust)・期限切れ廃棄(expire)を記録し、在庫数とステータスを更新します。入庫はロット番号・使用期限ごとに管理し、ロットを指定しない払出は期限切れでないロットを使用期限の近い順に使います（FEFO）。在庫数が負になる登録はできません
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "在庫品目ID (UUID)"
+// @Param movement body model.CreateStockMovementRequest true "入出庫情報"
+// @Success 201 {object} model.StockMovementResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/{id}/movements [post]
+// @Security ApiKeyAuth
+func (h *Handler) RecordStockMovement(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.CreateStockMovementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	result, err := h.svc.RecordStockMovement(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "stock movement recorded",
+		slog.String("inventory_item_id", id),
+		slog.String("type", req.Type),
+		slog.Int("quantity", req.Quantity),
+	)
+	c.JSON(http.StatusCreated, result)
+}
+
+// GetInventoryLots godoc
+// @Summary ロット一覧取得
+// @Description 在庫品目のロットを使用期限の近い順に取得します（在庫のないロットも含みます）
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param id path string true "在庫品目ID (UUID)"
+// @Success 200 {array} model.InventoryLot
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/{id}/lots [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetInventoryLots(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	lots, err := h.svc.GetInventoryLots(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "inventory_item", id)
+		return
+	}
+	c.JSON(http.StatusOK, lots)
+}
+
+// GetExpiringLots godoc
+// @Summary 使用期限切れ間近ロット一覧取得
+// @Description 指定期間内に使用期限を迎える在庫のあるロットを、品目付きで期限の近い順に取得します。期限切れのロットも含みます
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param within query string false "期間 (例: 30d, 4w。既定30d)"
+// @Success 200 {array} model.InventoryLot
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/lots/expiring [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetExpiringLots(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ExpiringLotsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	lots, err := h.svc.GetExpiringLots(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "inventory_lot", "")
+		return
+	}
+	c.JSON(http.StatusOK, lots)
+}
+
+// WriteOffLot godoc
+// @Summary ロット廃棄
+// @Description 指定したロットの残数をすべて廃棄(expire)として記録します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Param lot_id path string true "ロットID (UUID)"
+// @Param write_off body model.WriteOffLotRequest false "廃棄理由"
+// @Success 200 {object} model.StockMovementResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/lots/{lot_id}/write-off [post]
+// @Security ApiKeyAuth
+func (h *Handler) WriteOffLot(c *gin.Context) {
+	ctx := c.Request.Context()
+	lotID := c.Param("lot_id")
+
+	var req model.WriteOffLotRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	result, err := h.svc.WriteOffLot(ctx, lotID, &req)
+	if err != nil {
+		h.handleError(c, err, "inventory_lot", lotID)
+		return
+	}
+
+	slog.InfoContext(ctx, "inventory lot written off", slog.String("lot_id", lotID))
+	c.JSON(http.StatusOK, result)
+}
+
+// WriteOffExpiredLots godoc
+// @Summary 期限切れロット一括廃棄
+// @Description 使用期限を過ぎた在庫のあるロットをすべて廃棄(expire)として記録します
+// @Tags inventory
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.StockMovement
+// @Failure 500 {object} ErrorResponse
+// @Router /inventory/lots/write-off-expired [post]
+// @Security ApiKeyAuth
+func (h *Handler) WriteOffExpiredLots(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	movements, err := h.svc.WriteOffExpiredLots(ctx)
+	if err != nil {
+		h.handleError(c, err, "inventory_lot", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "expired lots written off", slog.Int("count", len(movements)))
+	c.JSON(http.StatusOK, movements)
+}
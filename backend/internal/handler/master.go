@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetMasterItems godoc
+// @Summary 診療項目マスタ一覧取得
+// @Description 診療項目マスタを区分・コード順に取得します。区分・ステータス・コードまたは名称の部分一致で絞り込めます。価格は当日時点の価格履歴によります
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param category query string false "区分 (examination, vaccine, medicine, staff, insurance, cage, serviceType, trimming_course, trimming_option)"
+// @Param status query string false "ステータス (active, inactive)"
+// @Param q query string false "コード・名称の部分一致"
+// @Success 200 {array} model.MasterItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetMasterItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListMasterItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	items, err := h.svc.GetMasterItems(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", "")
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+// GetMasterCategories godoc
+// @Summary マスタ区分一覧取得
+// @Description 登録されている診療項目マスタの区分ごとの件数（うち有効な件数）を取得します
+// @Tags master
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.MasterCategorySummary
+// @Failure 500 {object} ErrorResponse
+// @Router /master/categories [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetMasterCategories(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	categories, err := h.svc.GetMasterCategories(ctx)
+	if err != nil {
+		h.handleError(c, err, "master_item", "")
+		return
+	}
+	c.JSON(http.StatusOK, categories)
+}
+
+// GetMasterItem godoc
+// @Summary 診療項目マスタ詳細取得
+// @Description 指定されたIDの診療項目マスタを取得します。価格は当日時点の価格履歴によります
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Success 200 {object} model.MasterItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetMasterItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	item, err := h.svc.GetMasterItemByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+// CreateMasterItem godoc
+// @Summary 診療項目マスタ登録
+// @Description 診療項目マスタを登録します。コードは他のマスタと重複できません。価格は当日から適用する価格履歴としても記録します（管理者のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param item body model.CreateMasterItemRequest true "診療項目マスタ"
+// @Success 201 {object} model.MasterItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateMasterItem(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateMasterItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.CreateMasterItem(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "master item created", slog.String("master_item_id", item.ID.String()))
+	c.JSON(http.StatusCreated, item)
+}
+
+// UpdateMasterItem godoc
+// @Summary 診療項目マスタ更新
+// @Description 診療項目マスタを更新します。価格・税率を変更すると当日から適用する価格履歴を記録します。日付を指定した価格改定は価格履歴の登録を使います（管理者のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Param item body model.UpdateMasterItemRequest true "更新内容"
+// @Success 200 {object} model.MasterItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateMasterItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateMasterItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.UpdateMasterItem(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "master item updated", slog.String("master_item_id", id))
+	c.JSON(http.StatusOK, item)
+}
+
+// DeleteMasterItem godoc
+// @Summary 診療項目マスタ削除
+// @Description 診療項目マスタを無効（inactive）にします。過去の会計・カルテから参照されるため物理削除はしません（管理者のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteMasterItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteMasterItem(ctx, id); err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "master item deactivated", slog.String("master_item_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "master item deactivated"})
+}
+
+// GetMasterItemPrices godoc
+// @Summary 価格履歴取得
+// @Description 診療項目マスタの価格履歴を適用開始日の新しい順に取得します
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Success 200 {array} model.MasterItemPrice
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id}/prices [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetMasterItemPrices(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	prices, err := h.svc.GetMasterItemPrices(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+	c.JSON(http.StatusOK, prices)
+}
+
+// CreateMasterItemPrice godoc
+// @Summary 価格改定登録
+// @Description 適用開始日を指定して価格改定を登録します。会計明細の単価には会計日時点で有効な価格を使います。同じ適用開始日の改定は上書きします（管理者のみ）
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Param price body model.CreateMasterItemPriceRequest true "価格改定"
+// @Success 201 {object} model.MasterItemPrice
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id}/prices [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateMasterItemPrice(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.CreateMasterItemPriceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	price, err := h.svc.CreateMasterItemPrice(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "master item price registered",
+		slog.String("master_item_id", id),
+		slog.String("effective_from", req.EffectiveFrom),
+	)
+	c.JSON(http.StatusCreated, price)
+}
+
+// ExportMasterItems godoc
+// @Summary 診療項目マスタCSV出力
+// @Description 診療項目マスタをCSV（UTF-8 BOM付き）で出力します。列は code, name, category, price, tax_rate, is_insurance_applicable, status, description で、価格は指定日時点のものです。出力したCSVを編集して取込に使えます
+// @Tags master
+// @Produce text/csv
+// @Param category query string false "区分"
+// @Param date query string false "価格の基準日 YYYY-MM-DD（省略時は当日）"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/export [get]
+// @Security ApiKeyAuth
+func (h *Handler) ExportMasterItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ExportMasterItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	body, err := h.svc.ExportMasterItems(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", "")
+		return
+	}
+
+	c.Header("Content-Disposition", `attachment; filename="master-items.csv"`)
+	c.Data(http.StatusOK, "text/csv; charset=utf-8", body)
+}
+
+// ImportMasterItems godoc
+// @Summary 診療項目マスタCSV取込
+// @Description CSV（UTF-8）で診療項目マスタを一括登録・更新します。年次の価格改定向けで、codeで既存のマスタと照合し、ないコードは新規登録します（name・categoryが必要）。空欄の項目は変更しません。価格・税率が適用開始日時点の価格と異なる行は、その日から適用する価格履歴を記録します。不正な行が1行でもあれば何も反映せず、行番号付きのエラーを返します。CSVはmultipartのfileか、リクエスト本文で送ります（管理者のみ）
+// @Tags master
+// @Accept text/csv,multipart/form-data
+// @Produce json
+// @Param file formData file false "CSVファイル"
+// @Param effective_from query string false "価格の適用開始日 YYYY-MM-DD（省略時は当日）"
+// @Param dry_run query bool false "trueなら検証と件数の集計のみ行う"
+// @Success 200 {object} model.MasterItemImportResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/import [post]
+// @Security ApiKeyAuth
+func (h *Handler) ImportMasterItems(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ImportMasterItemsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	var body io.Reader = c.Request.Body
+	if file, err := c.FormFile("file"); err == nil {
+		f, err := file.Open()
+		if err != nil {
+			slog.WarnContext(ctx, "invalid upload file", slog.String("error", err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid upload file"})
+			return
+		}
+		defer f.Close()
+		body = f
+	}
+
+	result, err := h.svc.ImportMasterItems(ctx, body, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "master items imported",
+		slog.String("effective_from", result.EffectiveFrom),
+		slog.Bool("dry_run", result.DryRun),
+		slog.Int("created", result.Created),
+		slog.Int("updated", result.Updated),
+		slog.Int("price_changed", result.PriceChanged),
+	)
+	c.JSON(http.StatusOK, result)
+}
+
+// UpdateVaccineProtocol godoc
+// @Summary ワクチン接種プロトコル設定
+// @Description ワクチンマスタに対象動物種・幼齢期シリーズ・追加接種間隔などの接種プロトコルを設定します。接種記録の作成時に次回接種予定日の算出に使います。vaccine_protocolにnullを指定すると解除します
+// @Tags master
+// @Accept json
+// @Produce json
+// @Param id path string true "診療項目マスタID (UUID)"
+// @Param protocol body model.UpdateVaccineProtocolRequest true "接種プロトコル"
+// @Success 200 {object} model.MasterItem
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /master/items/{id}/vaccine-protocol [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateVaccineProtocol(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateVaccineProtocolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	item, err := h.svc.UpdateVaccineProtocol(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "master_item", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "vaccine protocol updated", slog.String("master_item_id", id))
+	c.JSON(http.StatusOK, item)
+}
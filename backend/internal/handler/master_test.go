@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestImportMasterItems(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const csv = "code,price\nC001,1200\n"
+	readsCSV := mock.MatchedBy(func(r io.Reader) bool {
+		b, err := io.ReadAll(r)
+		return err == nil && string(b) == csv
+	})
+	req := &model.ImportMasterItemsRequest{EffectiveFrom: "2030-04-01", DryRun: true}
+	result := &model.MasterItemImportResult{EffectiveFrom: "2030-04-01", DryRun: true, PriceChanged: 1}
+
+	t.Run("multipart file", func(t *testing.T) {
+		mockSvc := new(MockService)
+		h := New(mockSvc)
+		r := gin.New()
+		r.POST("/master/items/import", h.ImportMasterItems)
+
+		mockSvc.On("ImportMasterItems", mock.Anything, readsCSV, req).Return(result, nil)
+
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile("file", "prices.csv")
+		_, _ = fw.Write([]byte(csv))
+		_ = mw.Close()
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest(http.MethodPost, "/master/items/import?effective_from=2030-04-01&dry_run=true", &body)
+		httpReq.Header.Set("Content-Type", mw.FormDataContentType())
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"price_changed":1`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("raw CSV body", func(t *testing.T) {
+		mockSvc := new(MockService)
+		h := New(mockSvc)
+		r := gin.New()
+		r.POST("/master/items/import", h.ImportMasterItems)
+
+		mockSvc.On("ImportMasterItems", mock.Anything, readsCSV, req).Return(result, nil)
+
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest(http.MethodPost, "/master/items/import?effective_from=2030-04-01&dry_run=true", bytes.NewBufferString(csv))
+		httpReq.Header.Set("Content-Type", "text/csv")
+		r.ServeHTTP(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"log/slog"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,34 +11,113 @@ import (
 
 // GetAllMedicalRecords godoc
 // @Summary カルテ一覧取得
-// @Description 登録されているカルテの一覧を取得します
+// @Description 登録されているカルテの一覧をページ単位で取得します（既定は診察日の新しい順）
 // @Tags medical-records
 // @Accept json
 // @Produce json
-// @Param page query int false "ページ番号" default(1)
-// @Param limit query int false "1ページあたりの件数" default(10)
-// @Param pet_id query string false "ペットIDでフィルタリング"
-// @Param owner_id query string false "飼い主IDでフィルタリング"
-// @Param visit_type query string false "診察タイプでフィルタリング（初診/再診）"
-// @Param status query string false "ステータスでフィルタリング（作成中/確定済）"
-// @Param date_from query string false "診察日（開始）でフィルタリング（YYYY-MM-DD）"
-// @Param date_to query string false "診察日（終了）でフィルタリング（YYYY-MM-DD）"
-// @Success 200 {array} model.MedicalRecord
-// @Failure 500 {object} map[string]string
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param doctor_id query string false "担当獣医師ID (UUID)"
+// @Param species query string false "ペットの種別"
+// @Param visit_type query string false "診察タイプ（初診/再診）"
+// @Param status query string false "ステータス（作成中/確定済）"
+// @Param date_from query string false "診察日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "診察日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -visit_date,record_no"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.MedicalRecord]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
 // @Router /medical-records [get]
 // @Security ApiKeyAuth
 func (h *Handler) GetAllMedicalRecords(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	records, err := h.svc.GetAllMedicalRecords(ctx)
+	var req model.ListMedicalRecordsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	records, err := h.svc.ListMedicalRecords(ctx, &req)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to get medical records", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		h.handleError(c, err, "medical_record", "")
 		return
 	}
 	c.JSON(http.StatusOK, records)
 }
 
+// GetMedicalRecordsWithPagination godoc
+// @Summary カルテ一覧取得（ページング、非推奨）
+// @Description GET /medical-records の旧エンドポイントです。同じ一覧をカーソルページングで返します。page指定による2ページ目以降の取得はできないため、meta.next_cursorをcursorに指定してください
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param visit_type query string false "診察タイプ（初診/再診）"
+// @Param status query string false "ステータス（作成中/確定済）"
+// @Param date_from query string false "診察日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "診察日（終了、YYYY-MM-DD）"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.MedicalRecord]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /medical-records/paginated [get]
+// @Security ApiKeyAuth
+// @Deprecated
+func (h *Handler) GetMedicalRecordsWithPagination(c *gin.Context) {
+	c.Header("Deprecation", "true")
+	c.Header("Link", `</api/v1/medical-records>; rel="successor-version"`)
+
+	// 旧形式のpage（2ページ目以降）は同じ先頭ページを返してしまうため受け付けない
+	if page := c.Query("page"); page != "" && page != "1" {
+		slog.WarnContext(c.Request.Context(), "page parameter is no longer supported", slog.String("page", page))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "page is no longer supported; use cursor"})
+		return
+	}
+	h.GetAllMedicalRecords(c)
+}
+
+// SearchMedicalRecords godoc
+// @Summary カルテ全文検索
+// @Description SOAP（主訴・所見・評価・計画）・診断・処方の内容を全文検索します。空白区切りの検索語をすべて含むカルテに一致し、ひらがな/カタカナ、全角/半角の違いは区別しません。一致箇所を<mark>で囲んだ抜粋を返します
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param q query string true "検索語（100文字以内）"
+// @Param species query string false "ペットの種別"
+// @Param date_from query string false "診察日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "診察日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -visit_date,record_no"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.MedicalRecordSearchHit]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /medical-records/search [get]
+// @Security ApiKeyAuth
+func (h *Handler) SearchMedicalRecords(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SearchMedicalRecordsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	result, err := h.svc.SearchMedicalRecords(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "medical_record", "")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
 // GetMedicalRecord godoc
 // @Summary カルテ詳細取得
 // @Description 指定されたIDのカルテ情報を取得します
@@ -204,76 +282,182 @@ func (h *Handler) DeleteMedicalRecord(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "medical record deleted"})
 }
 
-// GetMedicalRecordsWithPagination godoc
-// @Summary カルテ一覧取得（ページング）
-// @Description カルテ一覧をページングして取得します。フィルタリング機能も利用できます。
+// FinalizeMedicalRecord godoc
+// @Summary カルテ確定
+// @Description 指定されたIDのカルテを確定します。確定後は更新・削除できず、修正は修正履歴として登録します
 // @Tags medical-records
 // @Accept json
 // @Produce json
-// @Param page query int false "ページ番号" default(1)
-// @Param limit query int false "1ページあたりの件数" default(10)
-// @Param pet_id query string false "ペットIDでフィルタリング"
-// @Param owner_id query string false "飼い主IDでフィルタリング"
-// @Param visit_type query string false "診察タイプでフィルタリング（初診/再診）"
-// @Param status query string false "ステータスでフィルタリング（作成中/確定済）"
-// @Param date_from query string false "診察日（開始）でフィルタリング（YYYY-MM-DD）"
-// @Param date_to query string false "診察日（終了）でフィルタリング（YYYY-MM-DD）"
-// @Success 200 {object} map[string]interface{}
+// @Param id path string true "カルテID (UUID)"
+// @Success 200 {object} model.MedicalRecord
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
 // @Failure 500 {object} map[string]string
-// @Router /medical-records/paginated [get]
-// @Security ApiKeyAuth
-func (h *Handler) GetMedicalRecordsWithPagination(c *gin.Context) {
+// @Router /medical-records/{id}/finalize [post]
+func (h *Handler) FinalizeMedicalRecord(c *gin.Context) {
 	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	record, err := h.svc.FinalizeMedicalRecord(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "medical_record", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "medical record finalized", slog.String("record_id", id))
+	c.JSON(http.StatusOK, record)
+}
 
-	// クエリパラメータを取得
-	pageStr := c.DefaultQuery("page", "1")
-	limitStr := c.DefaultQuery("limit", "10")
+// AmendMedicalRecord godoc
+// @Summary カルテ修正
+// @Description 確定済カルテを修正します。変更された項目ごとに変更前後の値と理由を修正履歴に記録します
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Param amendment body model.AmendMedicalRecordRequest true "修正内容"
+// @Success 200 {object} model.MedicalRecord
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /medical-records/{id}/amendments [post]
+func (h *Handler) AmendMedicalRecord(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
 
-	page, err := strconv.Atoi(pageStr)
-	if err != nil || page < 1 {
-		page = 1
+	var req model.AmendMedicalRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
 	}
 
-	limit, err := strconv.Atoi(limitStr)
-	if err != nil || limit < 1 || limit > 100 {
-		limit = 10
+	record, err := h.svc.AmendMedicalRecord(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "medical_record", id)
+		return
 	}
 
-	// 全件取得
-	allRecords, err := h.svc.GetAllMedicalRecords(ctx)
+	slog.InfoContext(ctx, "medical record amended", slog.String("record_id", id))
+	c.JSON(http.StatusOK, record)
+}
+
+// GetMedicalRecordAmendments godoc
+// @Summary カルテ修正履歴取得
+// @Description 指定されたIDのカルテの修正履歴を古い順に取得します
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Success 200 {array} model.MedicalRecordAmendment
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /medical-records/{id}/amendments [get]
+func (h *Handler) GetMedicalRecordAmendments(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	amendments, err := h.svc.GetMedicalRecordAmendments(ctx, id)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to get medical records", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		h.handleError(c, err, "medical_record", id)
 		return
 	}
+	c.JSON(http.StatusOK, amendments)
+}
 
-	// ページング計算
-	total := len(allRecords)
-	totalPages := (total + limit - 1) / limit
-	start := (page - 1) * limit
-	end := start + limit
+// GetMedicalRecordItems godoc
+// @Summary カルテ明細取得
+// @Description 指定されたカルテの実施項目（検査・処置・処方など）を登録順に取得します
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Success 200 {array} model.MedicalRecordItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /medical-records/{id}/items [get]
+func (h *Handler) GetMedicalRecordItems(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
 
-	if start > total {
-		start = total
+	items, err := h.svc.GetMedicalRecordItems(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "medical_record", id)
+		return
 	}
-	if end > total {
-		end = total
+
+	c.JSON(http.StatusOK, items)
+}
+
+// AddMedicalRecordItem godoc
+// @Summary カルテ明細追加
+// @Description カルテに実施項目をマスタ項目として追加します。会計作成時の明細の元になります
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Param item body model.AddMedicalRecordItemRequest true "実施項目"
+// @Success 201 {object} model.MedicalRecordItem
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /medical-records/{id}/items [post]
+func (h *Handler) AddMedicalRecordItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.AddMedicalRecordItemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
 	}
 
-	var records []model.MedicalRecord
-	if start < total {
-		records = allRecords[start:end]
+	item, err := h.svc.AddMedicalRecordItem(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "medical_record", id)
+		return
 	}
 
-	response := map[string]interface{}{
-		"records":      records,
-		"current_page": page,
-		"per_page":     limit,
-		"total":        total,
-		"total_pages":  totalPages,
-		"has_next":     page < totalPages,
-		"has_prev":     page > 1,
+	slog.InfoContext(ctx, "medical record item added",
+		slog.String("record_id", id),
+		slog.String("item_id", item.ID.String()),
+	)
+	c.JSON(http.StatusCreated, item)
+}
+
+// DeleteMedicalRecordItem godoc
+// @Summary カルテ明細削除
+// @Description カルテから実施項目を削除します。確定済カルテの明細は削除できません
+// @Tags medical-records
+// @Accept json
+// @Produce json
+// @Param id path string true "カルテID (UUID)"
+// @Param item_id path string true "カルテ明細ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /medical-records/{id}/items/{item_id} [delete]
+func (h *Handler) DeleteMedicalRecordItem(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	itemID := c.Param("item_id")
+
+	if err := h.svc.DeleteMedicalRecordItem(ctx, id, itemID); err != nil {
+		h.handleError(c, err, "medical_record_item", itemID)
+		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	slog.InfoContext(ctx, "medical record item deleted",
+		slog.String("record_id", id),
+		slog.String("item_id", itemID),
+	)
+	c.JSON(http.StatusOK, gin.H{"message": "medical record item deleted"})
 }
@@ -20,13 +20,18 @@ import (
 )
 
 // MockMedicalRecordService is a mock implementation of MedicalRecordService
+// MockService is embedded so that the remaining Service methods are satisfied.
 type MockMedicalRecordService struct {
 	mock.Mock
+	MockService
 }
 
-func (m *MockMedicalRecordService) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]model.Pet), args.Error(1)
+func (m *MockMedicalRecordService) ListPets(ctx context.Context, req *model.ListPetsRequest) (*model.ListResult[model.Pet], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Pet]), args.Error(1)
 }
 
 func (m *MockMedicalRecordService) GetPetByID(ctx context.Context, id string) (*model.Pet, error) {
@@ -49,9 +54,12 @@ func (m *MockMedicalRecordService) DeletePet(ctx context.Context, id string) err
 	return args.Error(0)
 }
 
-func (m *MockMedicalRecordService) GetAllOwners(ctx context.Context) ([]model.Owner, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]model.Owner), args.Error(1)
+func (m *MockMedicalRecordService) ListOwners(ctx context.Context, req *model.ListOwnersRequest) (*model.ListResult[model.Owner], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Owner]), args.Error(1)
 }
 
 func (m *MockMedicalRecordService) GetOwnerByID(ctx context.Context, id string) (*model.Owner, error) {
@@ -74,9 +82,12 @@ func (m *MockMedicalRecordService) DeleteOwner(ctx context.Context, id string) e
 	return args.Error(0)
 }
 
-func (m *MockMedicalRecordService) GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error) {
-	args := m.Called(ctx)
-	return args.Get(0).([]model.MedicalRecord), args.Error(1)
+func (m *MockMedicalRecordService) ListMedicalRecords(ctx context.Context, req *model.ListMedicalRecordsRequest) (*model.ListResult[model.MedicalRecord], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.MedicalRecord]), args.Error(1)
 }
 
 func (m *MockMedicalRecordService) GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error) {
@@ -112,11 +123,6 @@ func (m *MockMedicalRecordService) DeleteMedicalRecord(ctx context.Context, id s
 	return args.Error(0)
 }
 
-func (m *MockMedicalRecordService) GetMedicalRecordsWithPagination(ctx context.Context, page, limit int) (*model.PaginatedMedicalRecords, error) {
-	args := m.Called(ctx, page, limit)
-	return args.Get(0).(*model.PaginatedMedicalRecords), args.Error(0)
-}
-
 func (m *MockMedicalRecordService) GetDB() (interface{ DB() *gorm.DB }, error) {
 	args := m.Called()
 	return args.Get(0).(interface{ DB() *gorm.DB }), args.Error(1)
@@ -132,11 +138,11 @@ func setupMedicalRecordTestRouter() (*gin.Engine, *MockMedicalRecordService) {
 
 	router := gin.New()
 	router.GET("/medical-records", handler.GetAllMedicalRecords)
+	router.GET("/medical-records/paginated", handler.GetMedicalRecordsWithPagination)
 	router.GET("/medical-records/:id", handler.GetMedicalRecord)
 	router.POST("/medical-records", handler.CreateMedicalRecord)
 	router.PUT("/medical-records/:id", handler.UpdateMedicalRecord)
 	router.DELETE("/medical-records/:id", handler.DeleteMedicalRecord)
-	router.GET("/medical-records/paginated", handler.GetMedicalRecordsWithPagination)
 
 	return router, mockService
 }
@@ -171,7 +177,8 @@ func TestGetAllMedicalRecords(t *testing.T) {
 		},
 	}
 
-	mockService.On("GetAllMedicalRecords", mock.Anything).Return(expectedRecords, nil)
+	mockService.On("ListMedicalRecords", mock.Anything, &model.ListMedicalRecordsRequest{}).
+		Return(&model.ListResult[model.MedicalRecord]{Data: expectedRecords, Meta: model.ListMeta{Total: 2, Limit: 20}}, nil)
 
 	req, _ := http.NewRequest("GET", "/medical-records", http.NoBody)
 	w := httptest.NewRecorder()
@@ -179,11 +186,13 @@ func TestGetAllMedicalRecords(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []model.MedicalRecord
+	var response model.ListResult[model.MedicalRecord]
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Len(t, response, 2)
-	assert.Equal(t, expectedRecords[0].ID, response[0].ID)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, expectedRecords[0].ID, response.Data[0].ID)
+	assert.Equal(t, int64(2), response.Meta.Total)
+	assert.False(t, response.Meta.HasMore)
 
 	mockService.AssertExpectations(t)
 }
@@ -336,51 +345,81 @@ func TestDeleteMedicalRecord(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-func TestGetMedicalRecordsWithPagination(t *testing.T) {
+func TestGetAllMedicalRecords_FiltersAndCursor(t *testing.T) {
 	router, mockService := setupMedicalRecordTestRouter()
 
-	testID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
 	petID := uuid.MustParse("87a6e7a4-8b70-44b7-b7b5-e0b7e3b4e2bd")
-	ownerID := uuid.MustParse("a26c8688-6699-4bb1-8217-2a5630d2925e")
-
-	allRecords := []model.MedicalRecord{
-		{
-			ID:        testID,
-			RecordNo:  "MR123456",
-			PetID:     petID,
-			OwnerID:   ownerID,
-			VisitDate: time.Now(),
-			Status:    "確定済",
-		},
-	}
-
-	expectedResult := &model.PaginatedMedicalRecords{
-		Records:     allRecords,
-		CurrentPage: 1,
-		PerPage:     10,
-		Total:       1,
-		TotalPages:  1,
-		HasNext:     false,
-		HasPrev:     false,
+	expectedReq := &model.ListMedicalRecordsRequest{
+		ListOptions: model.ListOptions{Sort: "-visit_date,record_no", Cursor: "abc", Limit: 1},
+		PetID:       petID.String(),
+		Species:     "犬",
+		DateFrom:    "2024-04-01",
+		DateTo:      "2024-04-30",
 	}
+	mockService.On("ListMedicalRecords", mock.Anything, expectedReq).Return(&model.ListResult[model.MedicalRecord]{
+		Data: []model.MedicalRecord{{ID: uuid.New(), RecordNo: "MR123456", PetID: petID}},
+		Meta: model.ListMeta{Total: 3, Limit: 1, HasMore: true, NextCursor: "next"},
+	}, nil)
 
-	mockService.On("GetAllMedicalRecords", mock.Anything).Return(allRecords, nil)
-
-	req, _ := http.NewRequest("GET", "/medical-records/paginated?page=1&limit=10", http.NoBody)
+	req, _ := http.NewRequest("GET", "/medical-records?pet_id="+petID.String()+
+		"&species=%E7%8A%AC&date_from=2024-04-01&date_to=2024-04-30&sort=-visit_date,record_no&cursor=abc&limit=1", http.NoBody)
 	w := httptest.NewRecorder()
 	router.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
-
-	var response model.PaginatedMedicalRecords
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, expectedResult.CurrentPage, response.CurrentPage)
-	assert.Len(t, response.Records, 1)
+	var response map[string]any
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, map[string]any{"total": float64(3), "limit": float64(1), "has_more": true, "next_cursor": "next"}, response["meta"])
+	assert.Len(t, response["data"], 1)
 
 	mockService.AssertExpectations(t)
 }
 
+func TestGetMedicalRecordsWithPagination(t *testing.T) {
+	t.Run("serves the paginated list with deprecation headers", func(t *testing.T) {
+		router, mockService := setupMedicalRecordTestRouter()
+		expectedReq := &model.ListMedicalRecordsRequest{ListOptions: model.ListOptions{Cursor: "abc", Limit: 10}, Status: "確定済"}
+		mockService.On("ListMedicalRecords", mock.Anything, expectedReq).Return(&model.ListResult[model.MedicalRecord]{
+			Data: []model.MedicalRecord{{ID: uuid.New(), RecordNo: "MR123456"}},
+			Meta: model.ListMeta{Total: 1, Limit: 10},
+		}, nil)
+
+		req, _ := http.NewRequest("GET", "/medical-records/paginated?page=1&limit=10&cursor=abc&status=%E7%A2%BA%E5%AE%9A%E6%B8%88", http.NoBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "true", w.Header().Get("Deprecation"))
+		assert.Contains(t, w.Header().Get("Link"), "</api/v1/medical-records>")
+		var response model.ListResult[model.MedicalRecord]
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Len(t, response.Data, 1)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("rejects page numbers beyond the first", func(t *testing.T) {
+		router, mockService := setupMedicalRecordTestRouter()
+
+		req, _ := http.NewRequest("GET", "/medical-records/paginated?page=2", http.NoBody)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockService.AssertNotCalled(t, "ListMedicalRecords", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetAllMedicalRecords_InvalidQuery(t *testing.T) {
+	router, mockService := setupMedicalRecordTestRouter()
+
+	req, _ := http.NewRequest("GET", "/medical-records?limit=abc", http.NoBody)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	mockService.AssertNotCalled(t, "ListMedicalRecords", mock.Anything, mock.Anything)
+}
+
 func TestCreateMedicalRecord_InvalidRequest(t *testing.T) {
 	router, _ := setupMedicalRecordTestRouter()
 
@@ -411,3 +450,55 @@ func TestGetMedicalRecordByID_NotFound(t *testing.T) {
 
 	mockService.AssertExpectations(t)
 }
+
+func TestFinalizeMedicalRecord_AlreadyFinalized(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/medical-records/:id/finalize", h.FinalizeMedicalRecord)
+
+	id := uuid.New().String()
+	mockSvc.On("FinalizeMedicalRecord", mock.Anything, id).
+		Return(nil, apperrors.WrapConflict("medical record is already finalized"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/medical-records/"+id+"/finalize", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestAmendMedicalRecord(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/medical-records/:id/amendments", h.AmendMedicalRecord)
+
+	id := uuid.New()
+	diagnosis := "慢性腎臓病"
+	amendReq := &model.AmendMedicalRecordRequest{
+		Reason:    "検査結果判明による診断名の訂正",
+		Diagnosis: &diagnosis,
+	}
+	expected := &model.MedicalRecord{ID: id, Diagnosis: diagnosis, Status: model.MedicalRecordStatusFinalized}
+
+	mockSvc.On("AmendMedicalRecord", mock.Anything, id.String(), amendReq).Return(expected, nil)
+
+	body, _ := json.Marshal(amendReq)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/medical-records/"+id.String()+"/amendments", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response model.MedicalRecord
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, diagnosis, response.Diagnosis)
+	mockSvc.AssertExpectations(t)
+}
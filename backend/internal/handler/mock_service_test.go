@@ -2,10 +2,13 @@ package handler
 
 import (
 	"context"
+	"io"
 
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
 
+	"github.com/animal-ekarte/backend/internal/analyzer"
+	"github.com/animal-ekarte/backend/internal/auth"
 	"github.com/animal-ekarte/backend/internal/model"
 )
 
@@ -13,12 +16,12 @@ type MockService struct {
 	mock.Mock
 }
 
-func (m *MockService) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	args := m.Called(ctx)
+func (m *MockService) ListPets(ctx context.Context, req *model.ListPetsRequest) (*model.ListResult[model.Pet], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.Pet), args.Error(1)
+	return args.Get(0).(*model.ListResult[model.Pet]), args.Error(1)
 }
 
 func (m *MockService) GetPetByID(ctx context.Context, id string) (*model.Pet, error) {
@@ -51,12 +54,12 @@ func (m *MockService) DeletePet(ctx context.Context, id string) error {
 }
 
 // Owner Mock Methods
-func (m *MockService) GetAllOwners(ctx context.Context) ([]model.Owner, error) {
-	args := m.Called(ctx)
+func (m *MockService) ListOwners(ctx context.Context, req *model.ListOwnersRequest) (*model.ListResult[model.Owner], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.Owner), args.Error(1)
+	return args.Get(0).(*model.ListResult[model.Owner]), args.Error(1)
 }
 
 func (m *MockService) GetOwnerByID(ctx context.Context, id string) (*model.Owner, error) {
@@ -89,12 +92,20 @@ func (m *MockService) DeleteOwner(ctx context.Context, id string) error {
 }
 
 // Medical Records Mock Methods
-func (m *MockService) GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error) {
-	args := m.Called(ctx)
+func (m *MockService) ListMedicalRecords(ctx context.Context, req *model.ListMedicalRecordsRequest) (*model.ListResult[model.MedicalRecord], error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.MedicalRecord), args.Error(1)
+	return args.Get(0).(*model.ListResult[model.MedicalRecord]), args.Error(1)
+}
+
+func (m *MockService) SearchMedicalRecords(ctx context.Context, req *model.SearchMedicalRecordsRequest) (*model.ListResult[model.MedicalRecordSearchHit], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.MedicalRecordSearchHit]), args.Error(1)
 }
 
 func (m *MockService) GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error) {
@@ -142,6 +153,105 @@ func (m *MockService) DeleteMedicalRecord(ctx context.Context, id string) error
 	return args.Error(0)
 }
 
+func (m *MockService) FinalizeMedicalRecord(ctx context.Context, id string) (*model.MedicalRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockService) AmendMedicalRecord(ctx context.Context, id string, req *model.AmendMedicalRecordRequest) (*model.MedicalRecord, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockService) GetMedicalRecordAmendments(ctx context.Context, id string) ([]model.MedicalRecordAmendment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecordAmendment), args.Error(1)
+}
+
+func (m *MockService) GetMedicalRecordItems(ctx context.Context, id string) ([]model.MedicalRecordItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecordItem), args.Error(1)
+}
+
+func (m *MockService) AddMedicalRecordItem(ctx context.Context, id string, req *model.AddMedicalRecordItemRequest) (*model.MedicalRecordItem, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MedicalRecordItem), args.Error(1)
+}
+
+func (m *MockService) DeleteMedicalRecordItem(ctx context.Context, id, itemID string) error {
+	args := m.Called(ctx, id, itemID)
+	return args.Error(0)
+}
+
+// Reservation Mock Methods
+func (m *MockService) ListReservations(ctx context.Context, req *model.ListReservationsRequest) (*model.ListResult[model.Reservation], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Reservation]), args.Error(1)
+}
+
+func (m *MockService) GetReservationByID(ctx context.Context, id string) (*model.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockService) CreateReservation(ctx context.Context, req *model.CreateReservationRequest) (*model.Reservation, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockService) UpdateReservation(ctx context.Context, id string, req *model.UpdateReservationRequest) (*model.Reservation, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockService) DeleteReservation(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) CancelReservation(ctx context.Context, id string) (*model.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockService) CheckInReservation(ctx context.Context, id string) (*model.ReservationCheckInResult, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReservationCheckInResult), args.Error(1)
+}
+
 // GetDB Mock Method
 func (m *MockService) GetDB() (interface{ DB() *gorm.DB }, error) {
 	args := m.Called()
@@ -150,3 +260,785 @@ func (m *MockService) GetDB() (interface{ DB() *gorm.DB }, error) {
 	}
 	return args.Get(0).(interface{ DB() *gorm.DB }), args.Error(1)
 }
+
+// AuditEvent Mock Methods
+func (m *MockService) ListAuditEvents(ctx context.Context, req *model.ListAuditEventsRequest) ([]model.AuditEvent, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AuditEvent), args.Error(1)
+}
+
+// Auth Mock Methods
+func (m *MockService) Login(ctx context.Context, req *model.LoginRequest) (*model.TokenResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TokenResponse), args.Error(1)
+}
+
+func (m *MockService) RefreshToken(ctx context.Context, req *model.RefreshTokenRequest) (*model.TokenResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TokenResponse), args.Error(1)
+}
+
+func (m *MockService) Logout(ctx context.Context, req *model.RefreshTokenRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockService) AuthenticateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Claims), args.Error(1)
+}
+
+func (m *MockService) SetStaffPassword(ctx context.Context, staffID string, req *model.SetPasswordRequest) error {
+	args := m.Called(ctx, staffID, req)
+	return args.Error(0)
+}
+
+// Accounting Mock Methods
+func (m *MockService) ListAccountings(ctx context.Context, req *model.ListAccountingsRequest) (*model.ListResult[model.Accounting], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Accounting]), args.Error(1)
+}
+
+func (m *MockService) GetAccountingByID(ctx context.Context, id string) (*model.Accounting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) CreateAccountingFromMedicalRecord(ctx context.Context, req *model.CreateAccountingRequest) (*model.Accounting, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) UpdateAccounting(ctx context.Context, id string, req *model.UpdateAccountingRequest) (*model.Accounting, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) DeleteAccounting(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) AddAccountingItem(ctx context.Context, id string, req *model.AddAccountingItemRequest) (*model.Accounting, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) DeleteAccountingItem(ctx context.Context, id, itemID string) (*model.Accounting, error) {
+	args := m.Called(ctx, id, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) CompleteAccounting(ctx context.Context, id string, req *model.CompleteAccountingRequest) (*model.Accounting, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockService) RenderAccountingReceipt(ctx context.Context, id string) ([]byte, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) RenderAccountingInvoice(ctx context.Context, id string) ([]byte, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+// Hospitalization Mock Methods
+func (m *MockService) ListHospitalizations(ctx context.Context, req *model.ListHospitalizationsRequest) (*model.ListResult[model.Hospitalization], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Hospitalization]), args.Error(1)
+}
+
+func (m *MockService) GetHospitalizationByID(ctx context.Context, id string) (*model.Hospitalization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hospitalization), args.Error(1)
+}
+
+func (m *MockService) AdmitHospitalization(ctx context.Context, req *model.CreateHospitalizationRequest) (*model.Hospitalization, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hospitalization), args.Error(1)
+}
+
+func (m *MockService) UpdateHospitalization(ctx context.Context, id string, req *model.UpdateHospitalizationRequest) (*model.Hospitalization, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hospitalization), args.Error(1)
+}
+
+func (m *MockService) DeleteHospitalization(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) DischargeHospitalization(ctx context.Context, id string, req *model.DischargeHospitalizationRequest) (*model.DischargeResult, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DischargeResult), args.Error(1)
+}
+
+func (m *MockService) GetAllCages(ctx context.Context) ([]model.Cage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Cage), args.Error(1)
+}
+
+func (m *MockService) GetAvailableCages(ctx context.Context, req *model.AvailableCagesRequest) ([]model.Cage, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Cage), args.Error(1)
+}
+
+func (m *MockService) GetCarePlanItems(ctx context.Context, id string) ([]model.CarePlanItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CarePlanItem), args.Error(1)
+}
+
+func (m *MockService) AddCarePlanItem(ctx context.Context, id string, req *model.AddCarePlanItemRequest) (*model.CarePlanItem, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CarePlanItem), args.Error(1)
+}
+
+func (m *MockService) DeleteCarePlanItem(ctx context.Context, id, itemID string) error {
+	args := m.Called(ctx, id, itemID)
+	return args.Error(0)
+}
+
+// Daily Record Mock Methods
+func (m *MockService) GetDailyRecords(ctx context.Context, hospitalizationID string) ([]model.DailyRecord, error) {
+	args := m.Called(ctx, hospitalizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DailyRecord), args.Error(1)
+}
+
+func (m *MockService) AddVital(ctx context.Context, hospitalizationID string, req *model.AddVitalRequest) (*model.Vital, error) {
+	args := m.Called(ctx, hospitalizationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Vital), args.Error(1)
+}
+
+func (m *MockService) AddCareLog(ctx context.Context, hospitalizationID string, req *model.AddCareLogRequest) (*model.CareLog, error) {
+	args := m.Called(ctx, hospitalizationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.CareLog), args.Error(1)
+}
+
+func (m *MockService) AddStaffNote(ctx context.Context, hospitalizationID string, req *model.AddStaffNoteRequest) (*model.StaffNote, error) {
+	args := m.Called(ctx, hospitalizationID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StaffNote), args.Error(1)
+}
+
+func (m *MockService) GetTemperatureChart(ctx context.Context, req *model.TemperatureChartRequest) (*model.TemperatureChart, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TemperatureChart), args.Error(1)
+}
+
+// Ward Mock Methods
+func (m *MockService) GetWardTasks(ctx context.Context, req *model.WardTasksRequest) (*model.WardTasks, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.WardTasks), args.Error(1)
+}
+
+// Vaccination Mock Methods
+func (m *MockService) ListVaccinations(ctx context.Context, req *model.ListVaccinationsRequest) (*model.ListResult[model.Vaccination], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Vaccination]), args.Error(1)
+}
+
+func (m *MockService) GetVaccinationByID(ctx context.Context, id string) (*model.Vaccination, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Vaccination), args.Error(1)
+}
+
+func (m *MockService) CreateVaccination(ctx context.Context, req *model.CreateVaccinationRequest) (*model.Vaccination, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Vaccination), args.Error(1)
+}
+
+func (m *MockService) UpdateVaccination(ctx context.Context, id string, req *model.UpdateVaccinationRequest) (*model.Vaccination, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Vaccination), args.Error(1)
+}
+
+func (m *MockService) DeleteVaccination(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) GetDueVaccinations(ctx context.Context, req *model.DueVaccinationsRequest) ([]model.Vaccination, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Vaccination), args.Error(1)
+}
+
+func (m *MockService) SendVaccinationReminders(ctx context.Context) (*model.ReminderRunResult, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ReminderRunResult), args.Error(1)
+}
+
+// MasterItem Mock Methods
+func (m *MockService) GetMasterItems(ctx context.Context, req *model.ListMasterItemsRequest) ([]model.MasterItem, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItem), args.Error(1)
+}
+
+func (m *MockService) GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterCategorySummary), args.Error(1)
+}
+
+func (m *MockService) GetMasterItemByID(ctx context.Context, id string) (*model.MasterItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItem), args.Error(1)
+}
+
+func (m *MockService) CreateMasterItem(ctx context.Context, req *model.CreateMasterItemRequest) (*model.MasterItem, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItem), args.Error(1)
+}
+
+func (m *MockService) UpdateMasterItem(ctx context.Context, id string, req *model.UpdateMasterItemRequest) (*model.MasterItem, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItem), args.Error(1)
+}
+
+func (m *MockService) DeleteMasterItem(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) GetMasterItemPrices(ctx context.Context, id string) ([]model.MasterItemPrice, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItemPrice), args.Error(1)
+}
+
+func (m *MockService) CreateMasterItemPrice(ctx context.Context, id string, req *model.CreateMasterItemPriceRequest) (*model.MasterItemPrice, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItemPrice), args.Error(1)
+}
+
+func (m *MockService) ExportMasterItems(ctx context.Context, req *model.ExportMasterItemsRequest) ([]byte, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) ImportMasterItems(ctx context.Context, r io.Reader, req *model.ImportMasterItemsRequest) (*model.MasterItemImportResult, error) {
+	args := m.Called(ctx, r, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItemImportResult), args.Error(1)
+}
+
+func (m *MockService) UpdateVaccineProtocol(ctx context.Context, id string, req *model.UpdateVaccineProtocolRequest) (*model.MasterItem, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItem), args.Error(1)
+}
+
+// Inventory Mock Methods
+func (m *MockService) GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryItem), args.Error(1)
+}
+
+func (m *MockService) GetInventoryItemByID(ctx context.Context, id string) (*model.InventoryItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryItem), args.Error(1)
+}
+
+func (m *MockService) CreateInventoryItem(ctx context.Context, req *model.CreateInventoryItemRequest) (*model.InventoryItem, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryItem), args.Error(1)
+}
+
+func (m *MockService) UpdateInventoryItem(ctx context.Context, id string, req *model.UpdateInventoryItemRequest) (*model.InventoryItem, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryItem), args.Error(1)
+}
+
+func (m *MockService) GetLowStockItems(ctx context.Context) ([]model.InventoryItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryItem), args.Error(1)
+}
+
+func (m *MockService) GetStockMovements(ctx context.Context, id string) ([]model.StockMovement, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.StockMovement), args.Error(1)
+}
+
+func (m *MockService) RecordStockMovement(ctx context.Context, id string, req *model.CreateStockMovementRequest) (*model.StockMovementResult, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockMovementResult), args.Error(1)
+}
+
+func (m *MockService) GetInventoryLots(ctx context.Context, id string) ([]model.InventoryLot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryLot), args.Error(1)
+}
+
+func (m *MockService) GetExpiringLots(ctx context.Context, req *model.ExpiringLotsRequest) ([]model.InventoryLot, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryLot), args.Error(1)
+}
+
+func (m *MockService) WriteOffLot(ctx context.Context, lotID string, req *model.WriteOffLotRequest) (*model.StockMovementResult, error) {
+	args := m.Called(ctx, lotID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.StockMovementResult), args.Error(1)
+}
+
+func (m *MockService) WriteOffExpiredLots(ctx context.Context) ([]model.StockMovement, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.StockMovement), args.Error(1)
+}
+
+func (m *MockService) SearchPatients(ctx context.Context, req *model.PatientSearchRequest) (*model.ListResult[model.PatientSearchHit], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.PatientSearchHit]), args.Error(1)
+}
+
+func (m *MockService) FindOwnerDuplicates(ctx context.Context, req *model.FindOwnerDuplicatesRequest) ([]model.OwnerDuplicate, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OwnerDuplicate), args.Error(1)
+}
+
+func (m *MockService) MergeOwner(ctx context.Context, targetID string, req *model.MergeOwnerRequest) (*model.OwnerMergeResult, error) {
+	args := m.Called(ctx, targetID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.OwnerMergeResult), args.Error(1)
+}
+
+func (m *MockService) GetOwnerMerges(ctx context.Context, ownerID string) ([]model.OwnerMerge, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OwnerMerge), args.Error(1)
+}
+
+func (m *MockService) TransferPet(ctx context.Context, petID string, req *model.TransferPetRequest) (*model.PetTransferResult, error) {
+	args := m.Called(ctx, petID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PetTransferResult), args.Error(1)
+}
+
+func (m *MockService) GetPetOwnerships(ctx context.Context, petID string) ([]model.PetOwnership, error) {
+	args := m.Called(ctx, petID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PetOwnership), args.Error(1)
+}
+
+func (m *MockService) ListTrimmings(ctx context.Context, req *model.ListTrimmingsRequest) (*model.ListResult[model.Trimming], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Trimming]), args.Error(1)
+}
+
+func (m *MockService) GetTrimmingByID(ctx context.Context, id string) (*model.Trimming, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockService) CreateTrimming(ctx context.Context, req *model.CreateTrimmingRequest) (*model.Trimming, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockService) UpdateTrimming(ctx context.Context, id string, req *model.UpdateTrimmingRequest) (*model.Trimming, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockService) StartTrimming(ctx context.Context, id string) (*model.Trimming, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockService) CompleteTrimming(ctx context.Context, id string, req *model.CompleteTrimmingRequest) (*model.TrimmingCompletionResult, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TrimmingCompletionResult), args.Error(1)
+}
+
+func (m *MockService) CancelTrimming(ctx context.Context, id string) (*model.Trimming, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockService) GetTrimmingBoard(ctx context.Context, req *model.TrimmingBoardRequest) (*model.TrimmingBoard, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.TrimmingBoard), args.Error(1)
+}
+
+func (m *MockService) ListExaminations(ctx context.Context, req *model.ListExaminationsRequest) (*model.ListResult[model.Examination], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Examination]), args.Error(1)
+}
+
+func (m *MockService) GetExaminationByID(ctx context.Context, id string) (*model.Examination, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockService) CreateExamination(ctx context.Context, req *model.CreateExaminationRequest) (*model.Examination, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockService) UpdateExamination(ctx context.Context, id string, req *model.UpdateExaminationRequest) (*model.Examination, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockService) CompleteExamination(ctx context.Context, id string, req *model.CompleteExaminationRequest) (*model.Examination, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockService) GetExaminationTrend(ctx context.Context, petID string, req *model.ExaminationTrendRequest) (*model.ExaminationTrend, error) {
+	args := m.Called(ctx, petID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ExaminationTrend), args.Error(1)
+}
+
+func (m *MockService) ListLabReferenceRanges(ctx context.Context, req *model.ListLabReferenceRangesRequest) ([]model.LabReferenceRange, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.LabReferenceRange), args.Error(1)
+}
+
+func (m *MockService) SaveLabReferenceRange(ctx context.Context, req *model.SaveLabReferenceRangeRequest) (*model.LabReferenceRange, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LabReferenceRange), args.Error(1)
+}
+
+func (m *MockService) DeleteLabReferenceRange(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) ImportAnalyzerMessage(ctx context.Context, source string, msg *analyzer.Message) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, source, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockService) ListAnalyzerImports(ctx context.Context, req *model.ListAnalyzerImportsRequest) (*model.ListResult[model.AnalyzerImport], error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.AnalyzerImport]), args.Error(1)
+}
+
+func (m *MockService) GetAnalyzerImportByID(ctx context.Context, id string) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockService) AssignAnalyzerImport(ctx context.Context, id string, req *model.AssignAnalyzerImportRequest) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockService) DiscardAnalyzerImport(ctx context.Context, id string, req *model.DiscardAnalyzerImportRequest) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockService) UploadMedicalRecordAttachment(ctx context.Context, medicalRecordID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error) {
+	args := m.Called(ctx, medicalRecordID, body, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Attachment), args.Error(1)
+}
+
+func (m *MockService) UploadExaminationAttachment(ctx context.Context, examinationID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error) {
+	args := m.Called(ctx, examinationID, body, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Attachment), args.Error(1)
+}
+
+func (m *MockService) GetMedicalRecordAttachments(ctx context.Context, medicalRecordID string) ([]model.Attachment, error) {
+	args := m.Called(ctx, medicalRecordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Attachment), args.Error(1)
+}
+
+func (m *MockService) GetExaminationAttachments(ctx context.Context, examinationID string) ([]model.Attachment, error) {
+	args := m.Called(ctx, examinationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Attachment), args.Error(1)
+}
+
+func (m *MockService) GetAttachmentByID(ctx context.Context, id string) (*model.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Attachment), args.Error(1)
+}
+
+func (m *MockService) OpenAttachment(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.Attachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockService) OpenAttachmentThumbnail(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*model.Attachment), args.Get(1).(io.ReadCloser), args.Error(2)
+}
+
+func (m *MockService) DeleteAttachment(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockService) RecordPetMeasurements(ctx context.Context, petID string, req *model.RecordPetMeasurementRequest) ([]model.PetMeasurement, error) {
+	args := m.Called(ctx, petID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PetMeasurement), args.Error(1)
+}
+
+func (m *MockService) GetPetMeasurements(ctx context.Context, petID string, req *model.ListPetMeasurementsRequest) (*model.PetMeasurementSeries, error) {
+	args := m.Called(ctx, petID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PetMeasurementSeries), args.Error(1)
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -11,20 +12,30 @@ import (
 
 // GetAllOwners godoc
 // @Summary 飼い主一覧取得
-// @Description 登録されている飼い主の一覧を取得します
+// @Description 登録されている飼い主の一覧をページ単位で取得します。ペットは含みません
 // @Tags owners
 // @Accept json
 // @Produce json
-// @Param page query int false "ページ番号" default(1)
-// @Param limit query int false "1ページあたりの件数" default(10)
-// @Param search query string false "検索キーワード（名前、メール、電話番号）"
-// @Success 200 {array} model.Owner
-// @Failure 500 {object} map[string]string
+// @Param search query string false "検索キーワード（名前、フリガナ、電話番号、メール）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: name_kana,-created_at"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Owner]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
 // @Router /owners [get]
 // @Security ApiKeyAuth
 func (h *Handler) GetAllOwners(c *gin.Context) {
 	ctx := c.Request.Context()
-	owners, err := h.svc.GetAllOwners(ctx)
+
+	var req model.ListOwnersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	owners, err := h.svc.ListOwners(ctx, &req)
 	if err != nil {
 		h.handleError(c, err, "owner", "")
 		return
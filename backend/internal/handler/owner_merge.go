@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// FindOwnerDuplicates godoc
+// @Summary 飼い主の重複候補検索
+// @Description 重複登録の可能性がある飼い主の組を点数の高い順に取得します。氏名(30)・電話番号(30)・フリガナ(15)・メールアドレス(15)・住所(10)を表記ゆれをそろえて比べ、一致した項目の点数を合計します
+// @Tags owners
+// @Accept json
+// @Produce json
+// @Param owner_id query string false "指定した飼い主の重複候補に限る (UUID)"
+// @Param min_score query int false "最低点（1〜100）" default(40)
+// @Param limit query int false "最大件数（最大200）" default(50)
+// @Success 200 {array} model.OwnerDuplicate
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /owners/duplicates [get]
+// @Security ApiKeyAuth
+func (h *Handler) FindOwnerDuplicates(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.FindOwnerDuplicatesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	duplicates, err := h.svc.FindOwnerDuplicates(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "owner", "")
+		return
+	}
+	c.JSON(http.StatusOK, duplicates)
+}
+
+// MergeOwner godoc
+// @Summary 飼い主の統合
+// @Description 統合元の飼い主のペット・カルテ・予約・入院・ワクチン・トリミング・検査・会計を指定した飼い主（統合先）へ移し、統合元を削除します。統合先で未入力の連絡先は統合元の値で補い、統合元の登録内容は統合記録に残します（管理者のみ）
+// @Tags owners
+// @Accept json
+// @Produce json
+// @Param id path string true "統合先の飼い主ID (UUID)"
+// @Param merge body model.MergeOwnerRequest true "統合元と理由"
+// @Success 200 {object} model.OwnerMergeResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /owners/{id}/merge [post]
+// @Security ApiKeyAuth
+func (h *Handler) MergeOwner(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.MergeOwnerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	result, err := h.svc.MergeOwner(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "owner", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "owner merged",
+		slog.String("owner_id", id),
+		slog.String("source_owner_id", req.SourceOwnerID))
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOwnerMerges godoc
+// @Summary 飼い主の統合記録取得
+// @Description 指定された飼い主に統合された飼い主の記録（統合元の登録内容・移した件数）を古い順に取得します
+// @Tags owners
+// @Accept json
+// @Produce json
+// @Param id path string true "飼い主ID (UUID)"
+// @Success 200 {array} model.OwnerMerge
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /owners/{id}/merges [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetOwnerMerges(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	merges, err := h.svc.GetOwnerMerges(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "owner", id)
+		return
+	}
+	c.JSON(http.StatusOK, merges)
+}
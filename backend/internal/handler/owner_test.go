@@ -30,19 +30,21 @@ func TestGetAllOwners(t *testing.T) {
 		},
 	}
 
-	mockSvc.On("GetAllOwners", mock.Anything).Return(expectedOwners, nil)
+	mockSvc.On("ListOwners", mock.Anything, &model.ListOwnersRequest{Search: "Test"}).
+		Return(&model.ListResult[model.Owner]{Data: expectedOwners, Meta: model.ListMeta{Total: 1, Limit: 20}}, nil)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodGet, "/owners", http.NoBody)
+	req, _ := http.NewRequest(http.MethodGet, "/owners?search=Test", http.NoBody)
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 
-	var response []model.Owner
+	var response model.ListResult[model.Owner]
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err)
-	assert.Equal(t, len(expectedOwners), len(response))
-	assert.Equal(t, expectedOwners[0].ID, response[0].ID)
+	assert.Equal(t, len(expectedOwners), len(response.Data))
+	assert.Equal(t, expectedOwners[0].ID, response.Data[0].ID)
+	assert.Equal(t, int64(1), response.Meta.Total)
 }
 
 func TestGetOwnerByID(t *testing.T) {
@@ -98,3 +100,39 @@ func TestCreateOwner(t *testing.T) {
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 }
+
+func TestMergeOwner(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/owners/:id/merge", h.MergeOwner)
+
+	target := uuid.New()
+	source := uuid.New()
+	body := &model.MergeOwnerRequest{SourceOwnerID: source.String(), Reason: "二重登録"}
+	mockSvc.On("MergeOwner", mock.Anything, target.String(), body).Return(&model.OwnerMergeResult{
+		Owner: model.Owner{ID: target},
+		Merge: model.OwnerMerge{TargetOwnerID: target, SourceOwnerID: source, MovedRows: model.OwnerMergeCounts{"pets": 2}},
+	}, nil)
+
+	jsonBody, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/owners/"+target.String()+"/merge", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response model.OwnerMergeResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, target, response.Owner.ID)
+	assert.Equal(t, int64(2), response.Merge.MovedRows["pets"])
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/owners/"+target.String()+"/merge", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -11,24 +11,35 @@ import (
 
 // GetPets godoc
 // @Summary ペット一覧取得
-// @Description 登録されているペットの一覧を取得します
+// @Description 登録されているペットの一覧をページ単位で取得します。飼い主・種別・状態で絞り込めます
 // @Tags pets
 // @Accept json
 // @Produce json
-// @Param page query int false "ページ番号" default(1)
-// @Param limit query int false "1ページあたりの件数" default(10)
-// @Param search query string false "検索キーワード（名前、種別、品種）"
-// @Success 200 {array} model.Pet
-// @Failure 500 {object} map[string]string
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param species query string false "種別"
+// @Param status query string false "状態"
+// @Param search query string false "検索キーワード（名前、種別、品種、ペット番号）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -created_at,name"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Pet]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
 // @Router /pets [get]
 // @Security ApiKeyAuth
 func (h *Handler) GetPets(c *gin.Context) {
 	ctx := c.Request.Context()
 
-	pets, err := h.svc.GetAllPets(ctx)
+	var req model.ListPetsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	pets, err := h.svc.ListPets(ctx, &req)
 	if err != nil {
-		slog.ErrorContext(ctx, "failed to get pets", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		h.handleError(c, err, "pet", "")
 		return
 	}
 	c.JSON(http.StatusOK, pets)
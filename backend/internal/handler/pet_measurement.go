@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// RecordPetMeasurements godoc
+// @Summary ペットの体重・バイタルの記録
+// @Description 受付・診察で測った体重・ボディコンディションスコア・体温・心拍数・呼吸数を記録します。指定した項目ごとに測定値を作成し、最新の体重であればペットの体重も更新します
+// @Tags pets
+// @Accept json
+// @Produce json
+// @Param id path string true "ペットID (UUID)"
+// @Param measurement body model.RecordPetMeasurementRequest true "測定値"
+// @Success 201 {array} model.PetMeasurement
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pets/{id}/measurements [post]
+// @Security ApiKeyAuth
+func (h *Handler) RecordPetMeasurements(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.RecordPetMeasurementRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	measurements, err := h.svc.RecordPetMeasurements(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "pet", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "pet measurements recorded",
+		slog.String("pet_id", id),
+		slog.Int("count", len(measurements)))
+	c.JSON(http.StatusCreated, measurements)
+}
+
+// GetPetMeasurements godoc
+// @Summary ペットの体重・バイタルの推移取得
+// @Description ペットの登録・更新、入院中のバイタル記録、受付での測定から記録した測定値の推移を測定日時の古い順に取得します。前回からの変化率がしきい値以上の測定をalertsに挙げます。体重の推移には同じ日のボディコンディションスコアと、生年月日と品種がわかる成長期の子犬・子猫であれば成長曲線の期待体重との比較を含めます
+// @Tags pets
+// @Accept json
+// @Produce json
+// @Param id path string true "ペットID (UUID)"
+// @Param type query string false "測定項目（weight, body_condition_score, temperature, heart_rate, respiration_rate、省略時はweight）"
+// @Param date_from query string false "測定日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "測定日（終了、YYYY-MM-DD）"
+// @Param alert_percent query number false "前回からの変化率の警告しきい値（%、省略時は10）"
+// @Success 200 {object} model.PetMeasurementSeries
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pets/{id}/measurements [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetPetMeasurements(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.ListPetMeasurementsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	series, err := h.svc.GetPetMeasurements(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "pet", id)
+		return
+	}
+	c.JSON(http.StatusOK, series)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestRecordPetMeasurements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/pets/:id/measurements", h.RecordPetMeasurements)
+
+	id := uuid.New().String()
+	weight, score := 4.2, 5.0
+	req := &model.RecordPetMeasurementRequest{Weight: &weight, BodyConditionScore: &score}
+	mockSvc.On("RecordPetMeasurements", mock.Anything, id, req).Return([]model.PetMeasurement{
+		{Type: model.MeasurementTypeWeight, Value: weight, Unit: "kg"},
+		{Type: model.MeasurementTypeBodyConditionScore, Value: score, Unit: "/9"},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest(http.MethodPost, "/pets/"+id+"/measurements",
+		bytes.NewBufferString(`{"weight":4.2,"body_condition_score":5}`))
+	httpReq.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, httpReq)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"type":"body_condition_score"`)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetPetMeasurements(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.GET("/pets/:id/measurements", h.GetPetMeasurements)
+
+	id := uuid.New().String()
+	mockSvc.On("GetPetMeasurements", mock.Anything, id, &model.ListPetMeasurementsRequest{Type: "weight", AlertPercent: 5}).
+		Return(&model.PetMeasurementSeries{Type: "weight", Unit: "kg", Points: []model.PetMeasurementPoint{}, Alerts: []model.PetMeasurementAlert{}}, nil)
+	mockSvc.On("GetPetMeasurements", mock.Anything, id, &model.ListPetMeasurementsRequest{Type: "height"}).
+		Return(nil, apperrors.WrapInvalidInput("type must be one of weight, body_condition_score, temperature, heart_rate, respiration_rate"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/pets/"+id+"/measurements?type=weight&alert_percent=5", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"unit":"kg"`)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/pets/"+id+"/measurements?type=height", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodGet, "/pets/"+id+"/measurements?alert_percent=abc", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid query parameters")
+}
@@ -30,10 +30,11 @@ func TestGetPets(t *testing.T) {
 		},
 	}
 
-	mockSvc.On("GetAllPets", mock.Anything).Return(expectedPets, nil)
+	mockSvc.On("ListPets", mock.Anything, &model.ListPetsRequest{Species: "犬"}).
+		Return(&model.ListResult[model.Pet]{Data: expectedPets, Meta: model.ListMeta{Total: 1, Limit: 20}}, nil)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest(http.MethodGet, "/pets", http.NoBody)
+	req, _ := http.NewRequest(http.MethodGet, "/pets?species=%E7%8A%AC", http.NoBody)
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -91,3 +92,33 @@ func TestCreatePet(t *testing.T) {
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 }
+
+func TestTransferPet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/pets/:id/transfer", h.TransferPet)
+
+	petID, ownerID := uuid.New(), uuid.New()
+	body := &model.TransferPetRequest{OwnerID: ownerID.String(), TransferDate: "2024-05-10", OpenAccountingPayer: model.OpenAccountingPayerNewOwner}
+	mockSvc.On("TransferPet", mock.Anything, petID.String(), body).Return(&model.PetTransferResult{
+		Pet:                 model.Pet{ID: petID, OwnerID: ownerID},
+		Ownership:           model.PetOwnership{PetID: petID, OwnerID: ownerID},
+		MovedAccountings:    1,
+		OpenAccountingPayer: model.OpenAccountingPayerNewOwner,
+	}, nil)
+
+	jsonBody, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/pets/"+petID.String()+"/transfer", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response model.PetTransferResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, ownerID, response.Pet.OwnerID)
+	assert.Equal(t, int64(1), response.MovedAccountings)
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// TransferPet godoc
+// @Summary ペットの譲渡
+// @Description ペットを新しい飼い主へ譲渡し、飼い主の履歴に記録します。過去のカルテ・会計などはその時点の飼い主のまま残し、譲渡日以降の未受付の予約は新しい飼い主へ付け替えます。未精算（未収・保留）の会計はopen_accounting_payerがnew_ownerの場合だけ新しい飼い主へ付け替えます
+// @Tags pets
+// @Accept json
+// @Produce json
+// @Param id path string true "ペットID (UUID)"
+// @Param transfer body model.TransferPetRequest true "新しい飼い主と譲渡日"
+// @Success 200 {object} model.PetTransferResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pets/{id}/transfer [post]
+// @Security ApiKeyAuth
+func (h *Handler) TransferPet(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.TransferPetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	result, err := h.svc.TransferPet(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "pet", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "pet transferred",
+		slog.String("pet_id", id),
+		slog.String("owner_id", req.OwnerID))
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPetOwnerships godoc
+// @Summary ペットの飼い主の履歴取得
+// @Description 指定されたペットの飼い主の履歴を古い順に取得します。ended_atが空の期間が現在の飼い主です
+// @Tags pets
+// @Accept json
+// @Produce json
+// @Param id path string true "ペットID (UUID)"
+// @Success 200 {array} model.PetOwnership
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pets/{id}/owners [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetPetOwnerships(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	ownerships, err := h.svc.GetPetOwnerships(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "pet", id)
+		return
+	}
+	c.JSON(http.StatusOK, ownerships)
+}
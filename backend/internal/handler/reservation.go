@@ -0,0 +1,228 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllReservations godoc
+// @Summary 予約一覧取得
+// @Description 登録されている予約の一覧をページ単位で取得します（既定は開始時刻順）
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param doctor_id query string false "担当獣医師ID (UUID)"
+// @Param status query string false "ステータス"
+// @Param service_type query string false "サービス種別"
+// @Param date_from query string false "予約日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "予約日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: start_time"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Reservation]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllReservations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListReservationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	reservations, err := h.svc.ListReservations(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "reservation", "")
+		return
+	}
+	c.JSON(http.StatusOK, reservations)
+}
+
+// GetReservation godoc
+// @Summary 予約詳細取得
+// @Description 指定されたIDの予約情報を取得します
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "予約ID (UUID)"
+// @Success 200 {object} model.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	reservation, err := h.svc.GetReservationByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "reservation", id)
+		return
+	}
+	c.JSON(http.StatusOK, reservation)
+}
+
+// CreateReservation godoc
+// @Summary 予約作成
+// @Description 新しい予約を登録します。指名予約は同じ医師の指名予約と時間帯が重なる場合409を返します
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param reservation body model.CreateReservationRequest true "予約情報"
+// @Success 201 {object} model.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	reservation, err := h.svc.CreateReservation(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "reservation", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "reservation created", slog.String("reservation_id", reservation.ID.String()))
+	c.JSON(http.StatusCreated, reservation)
+}
+
+// UpdateReservation godoc
+// @Summary 予約更新
+// @Description 指定されたIDの予約情報を更新します。指名予約は同じ医師の指名予約と時間帯が重なる場合409を返します
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "予約ID (UUID)"
+// @Param reservation body model.UpdateReservationRequest true "更新する予約情報"
+// @Success 200 {object} model.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateReservationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	reservation, err := h.svc.UpdateReservation(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "reservation", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "reservation updated", slog.String("reservation_id", id))
+	c.JSON(http.StatusOK, reservation)
+}
+
+// DeleteReservation godoc
+// @Summary 予約削除
+// @Description 指定されたIDの予約を削除します
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "予約ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteReservation(ctx, id); err != nil {
+		h.handleError(c, err, "reservation", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "reservation deleted", slog.String("reservation_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "reservation deleted"})
+}
+
+// CancelReservation godoc
+// @Summary 予約キャンセル
+// @Description 指定されたIDの予約をキャンセルします
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "予約ID (UUID)"
+// @Success 200 {object} model.Reservation
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/cancel [post]
+// @Security ApiKeyAuth
+func (h *Handler) CancelReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	reservation, err := h.svc.CancelReservation(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "reservation", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "reservation canceled", slog.String("reservation_id", id))
+	c.JSON(http.StatusOK, reservation)
+}
+
+// CheckInReservation godoc
+// @Summary 受付処理
+// @Description 予約を受付済にし、予約内容から作成中のカルテを作成します
+// @Tags reservations
+// @Accept json
+// @Produce json
+// @Param id path string true "予約ID (UUID)"
+// @Success 201 {object} model.ReservationCheckInResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /reservations/{id}/check-in [post]
+// @Security ApiKeyAuth
+func (h *Handler) CheckInReservation(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	result, err := h.svc.CheckInReservation(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "reservation", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "reservation checked in",
+		slog.String("reservation_id", id),
+		slog.String("record_id", result.MedicalRecord.ID.String()),
+	)
+	c.JSON(http.StatusCreated, result)
+}
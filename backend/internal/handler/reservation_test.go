@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestCreateReservation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/reservations", h.CreateReservation)
+
+	reqBody := model.CreateReservationRequest{
+		PetID:        uuid.New().String(),
+		OwnerID:      uuid.New().String(),
+		DoctorID:     uuid.New().String(),
+		StartTime:    "2026-02-01T10:00:00+09:00",
+		EndTime:      "2026-02-01T10:30:00+09:00",
+		IsDesignated: true,
+	}
+	expected := &model.Reservation{ID: uuid.New(), Status: model.ReservationStatusPending}
+
+	mockSvc.On("CreateReservation", mock.Anything, &reqBody).Return(expected, nil)
+
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/reservations", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response model.Reservation
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, expected.ID, response.ID)
+}
+
+func TestCreateReservation_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/reservations", h.CreateReservation)
+
+	reqBody := model.CreateReservationRequest{
+		PetID:        uuid.New().String(),
+		OwnerID:      uuid.New().String(),
+		DoctorID:     uuid.New().String(),
+		StartTime:    "2026-02-01T10:00:00+09:00",
+		EndTime:      "2026-02-01T10:30:00+09:00",
+		IsDesignated: true,
+	}
+
+	mockSvc.On("CreateReservation", mock.Anything, &reqBody).
+		Return(nil, apperrors.WrapConflict("doctor already has a designated reservation"))
+
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/reservations", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestCancelReservation_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/reservations/:id/cancel", h.CancelReservation)
+
+	id := uuid.New()
+	mockSvc.On("CancelReservation", mock.Anything, id.String()).
+		Return(nil, apperrors.WrapNotFound("reservation", id.String()))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/reservations/"+id.String()+"/cancel", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
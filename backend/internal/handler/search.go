@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// SearchPatients godoc
+// @Summary 患者検索（飼い主・ペット横断）
+// @Description 飼い主の氏名・フリガナ・電話番号・飼い主番号、ペットの名前・ペット番号・マイクロチップ番号を横断して検索し、一致の度合いの高い順に返します。ひらがな/カタカナ、全角/半角、電話番号のハイフンの有無は区別しません
+// @Tags search
+// @Accept json
+// @Produce json
+// @Param q query string true "検索語（例: やまだ、ﾔﾏﾀﾞ、090-1234、30001、ポチ）"
+// @Param limit query int false "最大件数（最大50）" default(20)
+// @Success 200 {object} model.ListResult[model.PatientSearchHit]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /search [get]
+// @Security ApiKeyAuth
+func (h *Handler) SearchPatients(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.PatientSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	result, err := h.svc.SearchPatients(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "search", "")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllTrimmings godoc
+// @Summary トリミング一覧取得
+// @Description 登録されているトリミングの一覧をページ単位で取得します（既定は開始日時順）
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param staff_id query string false "担当トリマーID (UUID)"
+// @Param status query string false "ステータス（予約, 進行中, 完了, キャンセル）"
+// @Param date_from query string false "予約日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "予約日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: appointment_date"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Trimming]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllTrimmings(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListTrimmingsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	trimmings, err := h.svc.ListTrimmings(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "trimming", "")
+		return
+	}
+	c.JSON(http.StatusOK, trimmings)
+}
+
+// GetTrimmingBoard godoc
+// @Summary トリミングボード取得
+// @Description 指定日のトリミングを在籍中のトリマーごとに並べ、受付時間内の予約済み・空きの時間（分）を返します。担当未割当のトリミングはunassignedに入ります
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param date query string false "日付（YYYY-MM-DD、省略時は当日）"
+// @Success 200 {object} model.TrimmingBoard
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/board [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetTrimmingBoard(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.TrimmingBoardRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	board, err := h.svc.GetTrimmingBoard(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "trimming", "")
+		return
+	}
+	c.JSON(http.StatusOK, board)
+}
+
+// GetTrimming godoc
+// @Summary トリミング詳細取得
+// @Description 指定されたIDのトリミングを取得します
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param id path string true "トリミングID (UUID)"
+// @Success 200 {object} model.Trimming
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	trimming, err := h.svc.GetTrimmingByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "trimming", id)
+		return
+	}
+	c.JSON(http.StatusOK, trimming)
+}
+
+// CreateTrimming godoc
+// @Summary トリミング予約
+// @Description トリミングを予約します。価格と所要時間は予約日時点のコース・オプションのマスタから計算します。担当トリマーの予約と重なる場合や、同じ時間帯の予約がトリマーの人数を超える場合は409を返します
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param trimming body model.CreateTrimmingRequest true "予約情報"
+// @Success 201 {object} model.Trimming
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateTrimmingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	trimming, err := h.svc.CreateTrimming(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "trimming", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "trimming created", slog.String("trimming_id", trimming.ID.String()))
+	c.JSON(http.StatusCreated, trimming)
+}
+
+// UpdateTrimming godoc
+// @Summary トリミング予約変更
+// @Description 予約中のトリミングを変更します。コース・オプション・開始日時を変更すると価格と所要時間を計算し直します
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param id path string true "トリミングID (UUID)"
+// @Param trimming body model.UpdateTrimmingRequest true "変更する予約情報"
+// @Success 200 {object} model.Trimming
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateTrimmingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	trimming, err := h.svc.UpdateTrimming(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "trimming", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "trimming updated", slog.String("trimming_id", id))
+	c.JSON(http.StatusOK, trimming)
+}
+
+// StartTrimming godoc
+// @Summary トリミング開始
+// @Description 予約中のトリミングを進行中にします
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param id path string true "トリミングID (UUID)"
+// @Success 200 {object} model.Trimming
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/{id}/start [post]
+// @Security ApiKeyAuth
+func (h *Handler) StartTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	trimming, err := h.svc.StartTrimming(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "trimming", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "trimming started", slog.String("trimming_id", id))
+	c.JSON(http.StatusOK, trimming)
+}
+
+// CompleteTrimming godoc
+// @Summary トリミング完了
+// @Description トリミングを完了にします。create_accountingを指定するとコース・オプションの会計を作成し、accounting_idを指定すると同じペット・飼い主の未収・保留の会計に明細を追加します
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param id path string true "トリミングID (UUID)"
+// @Param complete body model.CompleteTrimmingRequest false "会計の作成・追加先"
+// @Success 200 {object} model.TrimmingCompletionResult
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/{id}/complete [post]
+// @Security ApiKeyAuth
+func (h *Handler) CompleteTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.CompleteTrimmingRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+			return
+		}
+	}
+
+	result, err := h.svc.CompleteTrimming(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "trimming", id)
+		return
+	}
+
+	attrs := []any{slog.String("trimming_id", id)}
+	if result.Accounting != nil {
+		attrs = append(attrs, slog.String("accounting_id", result.Accounting.ID.String()))
+	}
+	slog.InfoContext(ctx, "trimming completed", attrs...)
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelTrimming godoc
+// @Summary トリミングキャンセル
+// @Description 予約中・進行中のトリミングをキャンセルします
+// @Tags trimmings
+// @Accept json
+// @Produce json
+// @Param id path string true "トリミングID (UUID)"
+// @Success 200 {object} model.Trimming
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /trimmings/{id}/cancel [post]
+// @Security ApiKeyAuth
+func (h *Handler) CancelTrimming(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	trimming, err := h.svc.CancelTrimming(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "trimming", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "trimming canceled", slog.String("trimming_id", id))
+	c.JSON(http.StatusOK, trimming)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestCreateTrimming_Conflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/trimmings", h.CreateTrimming)
+
+	reqBody := model.CreateTrimmingRequest{
+		PetID:           uuid.New().String(),
+		StaffID:         uuid.New().String(),
+		AppointmentDate: "2026-02-01T10:00:00+09:00",
+		CourseID:        uuid.New().String(),
+	}
+	mockSvc.On("CreateTrimming", mock.Anything, &reqBody).
+		Return(nil, apperrors.WrapConflict("groomer already has a trimming"))
+
+	body, _ := json.Marshal(reqBody)
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/trimmings", bytes.NewBuffer(body))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestCompleteTrimming(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.POST("/trimmings/:id/complete", h.CompleteTrimming)
+
+	id := uuid.New()
+	accountingID := uuid.New()
+	expected := &model.TrimmingCompletionResult{
+		Trimming:   &model.Trimming{ID: id, Status: model.TrimmingStatusCompleted},
+		Accounting: &model.Accounting{ID: accountingID},
+	}
+	mockSvc.On("CompleteTrimming", mock.Anything, id.String(), &model.CompleteTrimmingRequest{CreateAccounting: true}).Return(expected, nil)
+	mockSvc.On("CompleteTrimming", mock.Anything, id.String(), &model.CompleteTrimmingRequest{}).
+		Return(&model.TrimmingCompletionResult{Trimming: expected.Trimming}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/trimmings/"+id.String()+"/complete", bytes.NewBufferString(`{"create_accounting":true}`))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response model.TrimmingCompletionResult
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, accountingID, response.Accounting.ID)
+
+	// 本文なしでも完了できる
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/trimmings/"+id.String()+"/complete", nil)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetAllVaccinations godoc
+// @Summary ワクチン接種記録一覧取得
+// @Description ワクチン接種記録をページ単位で取得します（既定は接種日の新しい順）。lot_numberでロットを絞り込めます（回収対象ロットの追跡）
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param pet_id query string false "ペットID (UUID)"
+// @Param owner_id query string false "飼い主ID (UUID)"
+// @Param doctor_id query string false "接種した獣医師ID (UUID)"
+// @Param lot_number query string false "ロット番号"
+// @Param date_from query string false "接種日（開始、YYYY-MM-DD）"
+// @Param date_to query string false "接種日（終了、YYYY-MM-DD）"
+// @Param sort query string false "並び替え（カンマ区切り、先頭に-で降順）例: -vaccination_date"
+// @Param cursor query string false "前のページのmeta.next_cursor"
+// @Param limit query int false "1ページあたりの件数（最大100）" default(20)
+// @Success 200 {object} model.ListResult[model.Vaccination]
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetAllVaccinations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ListVaccinationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	vaccinations, err := h.svc.ListVaccinations(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "vaccination", "")
+		return
+	}
+	c.JSON(http.StatusOK, vaccinations)
+}
+
+// GetDueVaccinations godoc
+// @Summary 接種予定一覧取得
+// @Description 指定期間内に次回接種予定日を迎えるワクチン接種記録を予定日順に取得します。その後に同じワクチンを接種済みの記録は含みません
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param within query string false "期間 (例: 30d, 4w。既定30d)"
+// @Param include_overdue query bool false "予定日を過ぎた未接種分を含める"
+// @Success 200 {array} model.Vaccination
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations/due [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetDueVaccinations(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.DueVaccinationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	vaccinations, err := h.svc.GetDueVaccinations(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "vaccination", "")
+		return
+	}
+	c.JSON(http.StatusOK, vaccinations)
+}
+
+// GetVaccination godoc
+// @Summary ワクチン接種記録詳細取得
+// @Description 指定されたIDのワクチン接種記録を取得します
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param id path string true "接種記録ID (UUID)"
+// @Success 200 {object} model.Vaccination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations/{id} [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetVaccination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	vaccination, err := h.svc.GetVaccinationByID(ctx, id)
+	if err != nil {
+		h.handleError(c, err, "vaccination", id)
+		return
+	}
+	c.JSON(http.StatusOK, vaccination)
+}
+
+// CreateVaccination godoc
+// @Summary ワクチン接種記録作成
+// @Description ワクチン接種を記録します。vaccine_master_id指定時はワクチン名をマスタから補完し、next_date省略時は接種プロトコルとペットの生年月日・接種歴から次回接種予定日を算出します。対象外の動物種への接種はwarningsで通知します。lot_id指定時、またはlot_number省略時はワクチンの在庫ロットからロット番号を補完します
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param vaccination body model.CreateVaccinationRequest true "接種情報"
+// @Success 201 {object} model.Vaccination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations [post]
+// @Security ApiKeyAuth
+func (h *Handler) CreateVaccination(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateVaccinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	vaccination, err := h.svc.CreateVaccination(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "vaccination", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "vaccination created", slog.String("vaccination_id", vaccination.ID.String()))
+	c.JSON(http.StatusCreated, vaccination)
+}
+
+// UpdateVaccination godoc
+// @Summary ワクチン接種記録更新
+// @Description 指定されたIDのワクチン接種記録を更新します。next_dateに空文字を指定すると次回接種予定日を取り消します
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param id path string true "接種記録ID (UUID)"
+// @Param vaccination body model.UpdateVaccinationRequest true "更新情報"
+// @Success 200 {object} model.Vaccination
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations/{id} [put]
+// @Security ApiKeyAuth
+func (h *Handler) UpdateVaccination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	var req model.UpdateVaccinationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		slog.WarnContext(ctx, "invalid request body", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	vaccination, err := h.svc.UpdateVaccination(ctx, id, &req)
+	if err != nil {
+		h.handleError(c, err, "vaccination", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "vaccination updated", slog.String("vaccination_id", id))
+	c.JSON(http.StatusOK, vaccination)
+}
+
+// DeleteVaccination godoc
+// @Summary ワクチン接種記録削除
+// @Description 指定されたIDのワクチン接種記録と送付済み案内の記録を削除します
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Param id path string true "接種記録ID (UUID)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations/{id} [delete]
+// @Security ApiKeyAuth
+func (h *Handler) DeleteVaccination(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+
+	if err := h.svc.DeleteVaccination(ctx, id); err != nil {
+		h.handleError(c, err, "vaccination", id)
+		return
+	}
+
+	slog.InfoContext(ctx, "vaccination deleted", slog.String("vaccination_id", id))
+	c.JSON(http.StatusOK, gin.H{"message": "vaccination deleted"})
+}
+
+// RunVaccinationReminders godoc
+// @Summary 接種案内の送付実行
+// @Description 接種予定日が近い飼い主へ、送付経路（はがき・メール）ごとに未送付の接種案内を送付します。定期実行と同じ処理を即時に実行します（管理者のみ）
+// @Tags vaccinations
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.ReminderRunResult
+// @Failure 403 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /vaccinations/reminders/run [post]
+// @Security ApiKeyAuth
+func (h *Handler) RunVaccinationReminders(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	result, err := h.svc.SendVaccinationReminders(ctx)
+	if err != nil {
+		h.handleError(c, err, "vaccination_reminder", "")
+		return
+	}
+
+	slog.InfoContext(ctx, "vaccination reminders sent")
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// GetWardTasks godoc
+// @Summary 病棟タスク一覧取得
+// @Description ケアプランの実施予定から指定日・時間帯のケアタスクを病棟（ケージ種別）ごとに取得します。ケアログと突き合わせて未実施・実施済・スキップ・遅延を判定します
+// @Tags ward
+// @Accept json
+// @Produce json
+// @Param date query string false "対象日（YYYY-MM-DD、省略時は当日）"
+// @Param from query string false "開始時刻（HH:MM、省略時は00:00）"
+// @Param to query string false "終了時刻（HH:MM、省略時は24:00）"
+// @Param ward query string false "病棟（犬用, 猫用, 共用, 未割当）"
+// @Success 200 {object} model.WardTasks
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /ward/tasks [get]
+// @Security ApiKeyAuth
+func (h *Handler) GetWardTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.WardTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		slog.WarnContext(ctx, "invalid query parameters", slog.String("error", err.Error()))
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid query parameters"})
+		return
+	}
+
+	tasks, err := h.svc.GetWardTasks(ctx, &req)
+	if err != nil {
+		h.handleError(c, err, "ward_tasks", "")
+		return
+	}
+	c.JSON(http.StatusOK, tasks)
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestGetWardTasks(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.GET("/ward/tasks", h.GetWardTasks)
+
+	itemID := uuid.New()
+	mockSvc.On("GetWardTasks", mock.Anything, &model.WardTasksRequest{Date: "2030-04-02", From: "06:00", To: "12:00"}).
+		Return(&model.WardTasks{
+			Date: "2030-04-02",
+			Wards: []model.WardTaskGroup{{
+				Ward: model.CageTypeDog,
+				Tasks: []model.WardTask{{
+					PetName:        "ポチ",
+					CareTaskStatus: model.CareTaskStatus{CarePlanItemID: itemID, Timing: "morning", Status: model.CareTaskStatusOverdue},
+				}},
+			}},
+		}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ward/tasks?date=2030-04-02&from=06:00&to=12:00", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Wards []struct {
+			Ward  string           `json:"ward"`
+			Tasks []map[string]any `json:"tasks"`
+		} `json:"wards"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	task := response.Wards[0].Tasks[0]
+	// タスクの実施状況はペット情報と同じ階層に展開される
+	assert.Equal(t, "ポチ", task["pet_name"])
+	assert.Equal(t, "overdue", task["status"])
+	assert.Equal(t, itemID.String(), task["care_plan_item_id"])
+	mockSvc.AssertExpectations(t)
+}
+
+func TestGetWardTasks_InvalidWindow(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := new(MockService)
+	h := New(mockSvc)
+
+	r := gin.New()
+	r.GET("/ward/tasks", h.GetWardTasks)
+
+	mockSvc.On("GetWardTasks", mock.Anything, mock.Anything).
+		Return(nil, apperrors.WrapInvalidInput("to must be after from"))
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, "/ward/tasks?from=12:00&to=06:00", http.NoBody)
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
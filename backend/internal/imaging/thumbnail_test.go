@@ -0,0 +1,57 @@
+package imaging
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetGray(x, y, color.Gray{Y: uint8(x % 256)})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestThumbnail(t *testing.T) {
+	t.Run("shrinks the long side", func(t *testing.T) {
+		thumb, err := Thumbnail(encodePNG(t, 1024, 512))
+		require.NoError(t, err)
+
+		cfg, format, err := image.DecodeConfig(bytes.NewReader(thumb))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, ThumbnailSize, cfg.Width)
+		assert.Equal(t, ThumbnailSize/2, cfg.Height)
+	})
+
+	t.Run("keeps small images", func(t *testing.T) {
+		var src bytes.Buffer
+		require.NoError(t, jpeg.Encode(&src, image.NewRGBA(image.Rect(0, 0, 40, 120)), nil))
+
+		thumb, err := Thumbnail(src.Bytes())
+		require.NoError(t, err)
+
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(thumb))
+		require.NoError(t, err)
+		assert.Equal(t, 40, cfg.Width)
+		assert.Equal(t, 120, cfg.Height)
+	})
+
+	t.Run("rejects broken images", func(t *testing.T) {
+		_, err := Thumbnail([]byte("\x89PNG\r\n\x1a\nbroken"))
+		assert.Error(t, err)
+	})
+}
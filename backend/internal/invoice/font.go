@@ -0,0 +1,53 @@
+package invoice
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/animal-ekarte/backend/internal/pdf"
+)
+
+// embeddedFonts ビルド時にfonts/に置かれたフォント（既定はM+ 1p Regular）
+//
+//go:embed fonts
+var embeddedFonts embed.FS
+
+// LoadFont 帳票に使うフォントを読み込む
+// pathを指定した場合はそのTrueTypeファイル、未指定ならfonts/に埋め込まれたTrueTypeファイルを使う。
+// オフラインでも同じ表示になるようフォントは必ずPDFに埋め込むため、埋め込むフォントがなければエラーとする。
+func LoadFont(fontPath string) (pdf.Font, error) {
+	if fontPath != "" {
+		data, err := os.ReadFile(fontPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read font file: %w", err)
+		}
+		return pdf.ParseTrueType(data)
+	}
+
+	entries, err := fs.ReadDir(embeddedFonts, "fonts")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded fonts: %w", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.EqualFold(path.Ext(e.Name()), ".ttf") {
+			names = append(names, e.Name())
+		}
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no embedded font: place a TrueType font in internal/invoice/fonts or set PDF_FONT_PATH")
+	}
+	sort.Strings(names)
+
+	data, err := embeddedFonts.ReadFile(path.Join("fonts", names[0]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded font: %w", err)
+	}
+	return pdf.ParseTrueType(data)
+}
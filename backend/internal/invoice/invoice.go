@@ -0,0 +1,433 @@
+// Package invoice は会計から領収書・請求書のPDFを作成する。
+//
+// いずれの帳票も適格請求書（インボイス制度）の記載事項を満たすよう、
+// 発行事業者の名称と登録番号、取引年月日、取引内容（軽減税率対象の明示）、
+// 税率ごとの対価の額と消費税額、交付先の名称を印字する。
+package invoice
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/pdf"
+)
+
+// Data 帳票に印字する内容
+type Data struct {
+	Clinic       *model.Clinic
+	Accounting   *model.Accounting // Owner・Pet・AccountingItemsを読み込んだもの
+	TaxBreakdown []model.TaxBreakdown
+	IssuedAt     time.Time
+}
+
+// Renderer 帳票のPDFを作成する
+type Renderer struct {
+	font pdf.Font
+}
+
+// NewRenderer 指定したフォントで帳票を作成するRendererを作成
+func NewRenderer(font pdf.Font) *Renderer {
+	return &Renderer{font: font}
+}
+
+// レイアウト（pt）
+const (
+	marginLeft   = 50.0
+	marginRight  = pdf.A4Width - 50
+	pageBottom   = pdf.A4Height - 60
+	rowHeight    = 18.0
+	bodyFontSize = 9.0
+)
+
+// reducedTaxRate 軽減税率
+var reducedTaxRate = decimal.MustParse("0.08")
+
+// Receipt 領収書を作成する
+func (r *Renderer) Receipt(d *Data) ([]byte, error) {
+	doc := pdf.New(r.font)
+	doc.SetTitle("領収書")
+	p := doc.AddPage()
+	a := d.Accounting
+
+	receivedAt := d.IssuedAt
+	if a.CompletedAt != nil {
+		receivedAt = *a.CompletedAt
+	}
+
+	header(p, d, "領収書")
+	y := addressee(p, d, 120)
+	clinicBlock(doc, p, d.Clinic, 110)
+
+	// 領収金額
+	y += 40
+	p.Rect(150, y, 295, 48, 1.2)
+	p.Text(160, y+18, 10, "金額")
+	p.TextCenter(pdf.A4Width/2+10, y+36, 24, yen(value(a.BillingAmount))+"-")
+	y += 72
+	p.TextCenter(pdf.A4Width/2, y, 11, "但し　診療費として")
+	y += 18
+	p.TextCenter(pdf.A4Width/2, y, 11, "上記正に領収いたしました。")
+
+	// 取引内容（軽減税率対象の明細に※を付ける）
+	y += 30
+	y = itemHeader(p, y)
+	for _, item := range a.AccountingItems {
+		if y+rowHeight > pageBottom {
+			p = doc.AddPage()
+			header(p, d, "領収書（続き）")
+			y = itemHeader(p, 110)
+		}
+		y = itemRow(doc, p, item, y)
+	}
+	if hasReducedItems(a.AccountingItems) {
+		p.Text(marginLeft, y+12, 8, "※は軽減税率（8%）対象品目です。")
+	}
+
+	// 内訳
+	rows := [][2]string{{"合計（税込）", yen(value(a.TotalAmount))}}
+	if ins := value(a.InsuranceAmount); !ins.IsZero() {
+		rows = append(rows, [2]string{insuranceLabel(a), "-" + yen(ins)})
+	}
+	if disc := value(a.DiscountAmount); !disc.IsZero() {
+		rows = append(rows, [2]string{"値引", "-" + yen(disc)})
+	}
+	rows = append(rows, [2]string{"領収金額", yen(value(a.BillingAmount))})
+
+	// 内訳・税率ごとの内訳・領収日がページに収まらなければ改ページする
+	y += 32
+	if y+float64(len(rows)+len(d.TaxBreakdown)+1)*rowHeight+80 > pageBottom {
+		p = doc.AddPage()
+		header(p, d, "領収書（続き）")
+		y = 110
+	}
+	p.Text(marginLeft, y, 10, "内訳")
+	y += 8
+	y = summaryRows(p, marginLeft, 280, y, rows)
+
+	y += 16
+	y = taxTable(p, d.TaxBreakdown, y)
+
+	y += 20
+	p.Text(marginLeft, y, bodyFontSize, "領収日　"+formatDate(receivedAt))
+	if a.PaymentMethod != "" {
+		y += 14
+		p.Text(marginLeft, y, bodyFontSize, "お支払方法　"+a.PaymentMethod)
+	}
+
+	return doc.Bytes()
+}
+
+// Invoice 請求書を作成する
+func (r *Renderer) Invoice(d *Data) ([]byte, error) {
+	doc := pdf.New(r.font)
+	doc.SetTitle("請求書")
+	p := doc.AddPage()
+	a := d.Accounting
+
+	header(p, d, "請求書")
+	y := addressee(p, d, 120)
+	clinicBlock(doc, p, d.Clinic, 110)
+
+	y += 16
+	p.Text(marginLeft, y, bodyFontSize, "下記のとおりご請求申し上げます。")
+	y += 10
+	p.Rect(marginLeft, y, 250, 30, 1.2)
+	p.Text(marginLeft+8, y+20, 11, "ご請求金額")
+	p.TextRight(marginLeft+242, y+21, 16, yen(value(a.BillingAmount))+"-")
+	y += 44
+	p.Text(marginLeft, y, bodyFontSize, "取引日　"+formatDate(a.ScheduledDate))
+	y += 14
+
+	// 明細
+	y = itemHeader(p, y)
+	for _, item := range a.AccountingItems {
+		if y+rowHeight > pageBottom {
+			p = doc.AddPage()
+			header(p, d, "請求書（続き）")
+			y = itemHeader(p, 110)
+		}
+		y = itemRow(doc, p, item, y)
+	}
+	if hasReducedItems(a.AccountingItems) {
+		p.Text(marginLeft, y+12, 8, "※は軽減税率（8%）対象品目です。")
+	}
+
+	// 合計
+	rows := [][2]string{{"小計（税抜）", yen(value(a.Subtotal))}}
+	for _, b := range d.TaxBreakdown {
+		rows = append(rows,
+			[2]string{percent(b.Rate) + "対象（税抜）", yen(b.Taxable)},
+			[2]string{"消費税（" + percent(b.Rate) + "）", yen(b.Tax)},
+		)
+	}
+	rows = append(rows, [2]string{"合計（税込）", yen(value(a.TotalAmount))})
+	if ins := value(a.InsuranceAmount); !ins.IsZero() {
+		rows = append(rows, [2]string{insuranceLabel(a), "-" + yen(ins)})
+	}
+	if disc := value(a.DiscountAmount); !disc.IsZero() {
+		rows = append(rows, [2]string{"値引", "-" + yen(disc)})
+	}
+	rows = append(rows, [2]string{"ご請求金額", yen(value(a.BillingAmount))})
+
+	y += 24
+	if y+float64(len(rows))*rowHeight > pageBottom {
+		p = doc.AddPage()
+		header(p, d, "請求書（続き）")
+		y = 110
+	}
+	y = summaryRows(p, 315, marginRight, y, rows)
+
+	if a.Memo != "" {
+		y += 20
+		p.Text(marginLeft, y, bodyFontSize, "備考")
+		for _, line := range wrap(doc, a.Memo, bodyFontSize, marginRight-marginLeft) {
+			y += 13
+			p.Text(marginLeft, y, bodyFontSize, line)
+		}
+	}
+
+	return doc.Bytes()
+}
+
+// header 表題と帳票番号・発行日
+func header(p *pdf.Page, d *Data, title string) {
+	p.TextRight(marginRight, 40, 8, "No. "+documentNumber(d.Accounting))
+	p.TextRight(marginRight, 52, 8, "発行日 "+formatDate(d.IssuedAt))
+	p.TextCenter(pdf.A4Width/2, 80, 22, title)
+}
+
+// addressee 宛名（飼い主名・ペット名）を印字し、次に印字するy座標を返す
+func addressee(p *pdf.Page, d *Data, y float64) float64 {
+	name := ""
+	if d.Accounting.Owner != nil {
+		name = d.Accounting.Owner.Name
+	}
+	p.Text(marginLeft, y, 14, name+"　様")
+	p.Line(marginLeft, y+5, 290, y+5, 0.8)
+	if d.Accounting.Pet != nil {
+		y += 18
+		p.Text(marginLeft, y, bodyFontSize, "（ペット名: "+d.Accounting.Pet.Name+"）")
+	}
+	return y
+}
+
+// clinicBlock 発行事業者（病院）の名称・所在地・登録番号
+func clinicBlock(doc *pdf.Document, p *pdf.Page, c *model.Clinic, y float64) {
+	if c == nil {
+		return
+	}
+	const x, width = 330.0, marginRight - 330
+	name := c.Name
+	if c.BranchName != "" {
+		name += "　" + c.BranchName
+	}
+	p.Text(x, y, 11, name)
+
+	lines := []string{}
+	if c.PostalCode != "" {
+		lines = append(lines, "〒"+c.PostalCode)
+	}
+	lines = append(lines, wrap(doc, c.Address, bodyFontSize, width)...)
+	if c.PhoneNumber != "" {
+		lines = append(lines, "TEL "+c.PhoneNumber)
+	}
+	if c.RegistrationNumber != "" {
+		lines = append(lines, "登録番号 "+c.RegistrationNumber)
+	}
+	if c.DirectorName != "" {
+		lines = append(lines, "院長 "+c.DirectorName)
+	}
+	for _, line := range lines {
+		y += 13
+		p.Text(x, y, bodyFontSize, line)
+	}
+}
+
+// 明細の列（左端x）
+const (
+	colName     = marginLeft
+	colPrice    = 300.0
+	colQuantity = 375.0
+	colAmount   = 420.0
+	colTax      = 490.0
+)
+
+// itemHeader 明細の見出し行
+func itemHeader(p *pdf.Page, y float64) float64 {
+	p.FillRect(marginLeft, y, marginRight-marginLeft, rowHeight, 0.9)
+	p.Rect(marginLeft, y, marginRight-marginLeft, rowHeight, 0.5)
+	base := y + 12.5
+	p.Text(colName+4, base, bodyFontSize, "品目")
+	p.TextRight(colQuantity-4, base, bodyFontSize, "単価")
+	p.TextRight(colAmount-4, base, bodyFontSize, "数量")
+	p.TextRight(colTax-4, base, bodyFontSize, "金額（税抜）")
+	p.TextRight(marginRight-4, base, bodyFontSize, "税率")
+	return y + rowHeight
+}
+
+// itemRow 明細1行
+func itemRow(doc *pdf.Document, p *pdf.Page, item model.AccountingItem, y float64) float64 {
+	rate := value(item.TaxRate)
+	reduced := rate.Cmp(reducedTaxRate) == 0
+	name := item.Name
+	if reduced {
+		name += " ※"
+	}
+	price := value(item.UnitPrice)
+
+	base := y + 12.5
+	p.Text(colName+4, base, bodyFontSize, truncate(doc, name, bodyFontSize, colPrice-colName-8))
+	p.TextRight(colQuantity-4, base, bodyFontSize, yen(price))
+	p.TextRight(colAmount-4, base, bodyFontSize, strconv.Itoa(item.Quantity))
+	p.TextRight(colTax-4, base, bodyFontSize, yen(price.MulInt(int64(item.Quantity))))
+	p.TextRight(marginRight-4, base, bodyFontSize, percent(rate))
+	p.Line(marginLeft, y+rowHeight, marginRight, y+rowHeight, 0.3)
+	return y + rowHeight
+}
+
+// summaryRows 見出しと金額の表を印字し、次に印字するy座標を返す（最終行を強調する）
+func summaryRows(p *pdf.Page, left, right, y float64, rows [][2]string) float64 {
+	for i, row := range rows {
+		last := i == len(rows)-1
+		if last {
+			p.FillRect(left, y, right-left, rowHeight, 0.9)
+		}
+		p.Text(left+4, y+12.5, bodyFontSize, row[0])
+		p.TextRight(right-4, y+12.5, bodyFontSize, row[1])
+		width := 0.3
+		if last {
+			width = 0.8
+		}
+		p.Line(left, y+rowHeight, right, y+rowHeight, width)
+		y += rowHeight
+	}
+	return y
+}
+
+// taxTable 税率ごとの対象額と消費税額
+func taxTable(p *pdf.Page, breakdown []model.TaxBreakdown, y float64) float64 {
+	const left, right = marginLeft, 400.0
+	p.FillRect(left, y, right-left, rowHeight, 0.9)
+	p.Text(left+4, y+12.5, bodyFontSize, "税率")
+	p.TextRight(280, y+12.5, bodyFontSize, "対象額（税抜）")
+	p.TextRight(right-4, y+12.5, bodyFontSize, "消費税額")
+	p.Line(left, y+rowHeight, right, y+rowHeight, 0.5)
+	y += rowHeight
+	for _, b := range breakdown {
+		label := percent(b.Rate) + "対象"
+		if b.Rate.Cmp(reducedTaxRate) == 0 {
+			label += "（軽減税率）"
+		}
+		p.Text(left+4, y+12.5, bodyFontSize, label)
+		p.TextRight(280, y+12.5, bodyFontSize, yen(b.Taxable))
+		p.TextRight(right-4, y+12.5, bodyFontSize, yen(b.Tax))
+		p.Line(left, y+rowHeight, right, y+rowHeight, 0.3)
+		y += rowHeight
+	}
+	return y
+}
+
+// insuranceLabel 保険負担額の見出し（保険名と負担割合）
+func insuranceLabel(a *model.Accounting) string {
+	label := "保険負担額"
+	var detail []string
+	if a.InsuranceName != "" {
+		detail = append(detail, a.InsuranceName)
+	}
+	if a.InsuranceRatio != nil && !a.InsuranceRatio.IsZero() {
+		detail = append(detail, percent(*a.InsuranceRatio))
+	}
+	if len(detail) > 0 {
+		label += "（" + strings.Join(detail, " ") + "）"
+	}
+	return label
+}
+
+// hasReducedItems 軽減税率対象の明細があるかどうか
+func hasReducedItems(items []model.AccountingItem) bool {
+	for _, item := range items {
+		if value(item.TaxRate).Cmp(reducedTaxRate) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// documentNumber 帳票番号（会計IDの先頭8桁）
+func documentNumber(a *model.Accounting) string {
+	return strings.ToUpper(strings.ReplaceAll(a.ID.String(), "-", "")[:8])
+}
+
+// yen 金額を「￥1,234」形式にする（円未満がある場合は小数も表示する）
+func yen(d decimal.Decimal) string {
+	sign := ""
+	if d.IsNegative() {
+		sign = "-"
+		d = decimal.Zero.Sub(d)
+	}
+	intPart, frac, _ := strings.Cut(d.String(), ".")
+
+	var b strings.Builder
+	for i, c := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if frac != "" {
+		b.WriteString("." + frac)
+	}
+	return sign + "￥" + b.String()
+}
+
+// percent 税率・割合を「10%」形式にする
+func percent(rate decimal.Decimal) string {
+	return rate.MulInt(100).String() + "%"
+}
+
+// formatDate 日付を「2006年1月2日」形式にする
+func formatDate(t time.Time) string {
+	return t.Format("2006年1月2日")
+}
+
+// value nilの金額を0として扱う
+func value(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+	return *d
+}
+
+// truncate 幅に収まらない文字列を「…」で切り詰める
+func truncate(doc *pdf.Document, s string, size, width float64) string {
+	if doc.Measure(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && doc.Measure(string(runes)+"…", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// wrap 幅に収まるように文字列を折り返す
+func wrap(doc *pdf.Document, s string, size, width float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		var line []rune
+		for _, r := range para {
+			if len(line) > 0 && doc.Measure(string(append(line, r)), size) > width {
+				lines = append(lines, string(line))
+				line = line[:0]
+			}
+			line = append(line, r)
+		}
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+		}
+	}
+	return lines
+}
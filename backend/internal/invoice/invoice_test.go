@@ -0,0 +1,173 @@
+package invoice
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/pdf"
+)
+
+// ucs2 CIDフォントで描画した文字列の16進表現
+func ucs2(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+// contents PDF内のストリームを展開して連結する
+func contents(t *testing.T, b []byte) string {
+	t.Helper()
+	var out strings.Builder
+	for _, m := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(b, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		out.Write(data)
+	}
+	return out.String()
+}
+
+func dec(s string) *decimal.Decimal {
+	return decimal.MustParse(s).Ptr()
+}
+
+func testData(items int) *Data {
+	completed := time.Date(2026, 10, 18, 15, 0, 0, 0, time.Local)
+	a := &model.Accounting{
+		ID:              uuid.MustParse("0f1e2d3c-4b5a-6978-8796-a5b4c3d2e1f0"),
+		ScheduledDate:   completed,
+		CompletedAt:     &completed,
+		Status:          model.AccountingStatusPaid,
+		Subtotal:        dec("11440"),
+		TaxTotal:        dec("1025"),
+		TotalAmount:     dec("12465"),
+		InsuranceName:   "どうぶつ保険",
+		InsuranceRatio:  dec("0.7"),
+		InsuranceAmount: dec("4235"),
+		DiscountAmount:  dec("100"),
+		BillingAmount:   dec("8130"),
+		PaymentMethod:   model.PaymentMethodCash,
+		Owner:           &model.Owner{Name: "山田太郎"},
+		Pet:             &model.Pet{Name: "ポチ"},
+	}
+	for i := 0; i < items; i++ {
+		a.AccountingItems = append(a.AccountingItems,
+			model.AccountingItem{Name: "血液検査", UnitPrice: dec("4400"), Quantity: 1, TaxRate: dec("0.10")},
+			model.AccountingItem{Name: "療法食", UnitPrice: dec("1980"), Quantity: 3, TaxRate: dec("0.08")},
+		)
+	}
+	return &Data{
+		Clinic: &model.Clinic{
+			Name:               "さくら動物病院",
+			Address:            "東京都千代田区1-1-1",
+			RegistrationNumber: "T1234567890123",
+		},
+		Accounting: a,
+		TaxBreakdown: []model.TaxBreakdown{
+			{Rate: decimal.MustParse("0.10"), Taxable: decimal.MustParse("5500"), Tax: decimal.MustParse("550")},
+			{Rate: decimal.MustParse("0.08"), Taxable: decimal.MustParse("5940"), Tax: decimal.MustParse("475")},
+		},
+		IssuedAt: completed,
+	}
+}
+
+func TestReceipt(t *testing.T) {
+	t.Run("itemizes reduced rate lines and tax per rate", func(t *testing.T) {
+		b, err := NewRenderer(pdf.DefaultJapaneseFont()).Receipt(testData(1))
+		require.NoError(t, err)
+
+		content := contents(t, b)
+		assert.Contains(t, content, ucs2("領収書"))
+		assert.Contains(t, content, ucs2("山田太郎　様"))
+		assert.Contains(t, content, ucs2("￥8,130-"))
+		assert.Contains(t, content, ucs2("登録番号 T1234567890123"))
+		assert.Contains(t, content, ucs2("血液検査"))
+		assert.NotContains(t, content, ucs2("血液検査 ※"))
+		assert.Contains(t, content, ucs2("療法食 ※"))
+		assert.Contains(t, content, ucs2("※は軽減税率（8%）対象品目です。"))
+		assert.Contains(t, content, ucs2("8%対象（軽減税率）"))
+		assert.Contains(t, content, ucs2("￥475"))
+		assert.Contains(t, content, ucs2("領収日　2026年10月18日"))
+		assert.Equal(t, 1, bytes.Count(b, []byte("/Type /Page /Parent")))
+	})
+
+	t.Run("omits the reduced rate note without reduced rate lines", func(t *testing.T) {
+		d := testData(1)
+		d.Accounting.AccountingItems = d.Accounting.AccountingItems[:1]
+		b, err := NewRenderer(pdf.DefaultJapaneseFont()).Receipt(d)
+		require.NoError(t, err)
+
+		assert.NotContains(t, contents(t, b), ucs2("※"))
+	})
+
+	t.Run("continues long item lists on following pages", func(t *testing.T) {
+		b, err := NewRenderer(pdf.DefaultJapaneseFont()).Receipt(testData(30))
+		require.NoError(t, err)
+
+		assert.Greater(t, bytes.Count(b, []byte("/Type /Page /Parent")), 1)
+		content := contents(t, b)
+		assert.Contains(t, content, ucs2("領収書（続き）"))
+		assert.Contains(t, content, ucs2("領収日　2026年10月18日"))
+	})
+}
+
+func TestInvoice(t *testing.T) {
+	t.Run("itemizes reduced rate lines and tax per rate", func(t *testing.T) {
+		b, err := NewRenderer(pdf.DefaultJapaneseFont()).Invoice(testData(1))
+		require.NoError(t, err)
+
+		content := contents(t, b)
+		assert.Contains(t, content, ucs2("請求書"))
+		assert.Contains(t, content, ucs2("療法食 ※"))
+		assert.Contains(t, content, ucs2("￥5,940"))
+		assert.Contains(t, content, ucs2("消費税（10%）"))
+		assert.Contains(t, content, ucs2("消費税（8%）"))
+		assert.Contains(t, content, ucs2("保険負担額（どうぶつ保険 70%）"))
+		assert.Contains(t, content, ucs2("登録番号 T1234567890123"))
+		assert.Equal(t, 1, bytes.Count(b, []byte("/Type /Page /Parent")))
+	})
+
+	t.Run("continues long item lists on following pages", func(t *testing.T) {
+		b, err := NewRenderer(pdf.DefaultJapaneseFont()).Invoice(testData(30))
+		require.NoError(t, err)
+
+		assert.Greater(t, bytes.Count(b, []byte("/Type /Page /Parent")), 1)
+		assert.Contains(t, contents(t, b), ucs2("請求書（続き）"))
+	})
+}
+
+func TestYen(t *testing.T) {
+	assert.Equal(t, "￥0", yen(decimal.Zero))
+	assert.Equal(t, "￥980", yen(decimal.MustParse("980")))
+	assert.Equal(t, "￥1,234,567", yen(decimal.MustParse("1234567")))
+	assert.Equal(t, "￥1,078.5", yen(decimal.MustParse("1078.5")))
+	assert.Equal(t, "-￥4,235", yen(decimal.MustParse("-4235")))
+}
+
+func TestLoadFont_DefaultsToEmbeddedFont(t *testing.T) {
+	font, err := LoadFont("")
+	require.NoError(t, err)
+	require.IsType(t, &pdf.TrueTypeFont{}, font)
+
+	b, err := NewRenderer(font).Receipt(testData(1))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "/FontFile2")
+
+	_, err = LoadFont("/nonexistent/font.ttf")
+	assert.Error(t, err)
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/audit"
+	"github.com/animal-ekarte/backend/internal/auth"
+)
+
+// TokenAuthenticator アクセストークンを検証するインターフェース
+type TokenAuthenticator interface {
+	AuthenticateToken(ctx context.Context, token string) (*auth.Claims, error)
+}
+
+// Authenticate Authorizationヘッダーのベアラートークンを検証するミドルウェア
+// 検証に成功するとスタッフのクレームをcontextに格納し、監査ログの操作者として記録されるようにする。
+func Authenticate(authenticator TokenAuthenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			slog.WarnContext(ctx, "missing bearer token", slog.String("path", c.Request.URL.Path))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+
+		claims, err := authenticator.AuthenticateToken(ctx, token)
+		if err != nil {
+			slog.WarnContext(ctx, "invalid access token", slog.String("error", err.Error()))
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+			return
+		}
+
+		ctx = auth.WithClaims(ctx, claims)
+		ctx = audit.WithActor(ctx, claims.Subject)
+		c.Request = c.Request.WithContext(ctx)
+		c.Set("staff_id", claims.Subject)
+		c.Set("staff_role", claims.Role)
+
+		c.Next()
+	}
+}
+
+// RequireRole 認証済みスタッフが指定ロールのいずれかを持つ場合のみ通すミドルウェア
+// Authenticateの後に登録すること。
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		claims, ok := auth.ClaimsFromContext(ctx)
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+
+		if !claims.HasRole(roles...) {
+			slog.WarnContext(ctx, "role not allowed",
+				slog.String("staff_id", claims.Subject),
+				slog.String("role", claims.Role),
+				slog.String("path", c.Request.URL.Path),
+			)
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "forbidden"})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// bearerToken "Bearer <token>" 形式のヘッダーからトークンを取り出す
+func bearerToken(header string) (string, bool) {
+	const prefix = "Bearer "
+	if len(header) <= len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+	return strings.TrimSpace(header[len(prefix):]), true
+}
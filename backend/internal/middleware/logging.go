@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/animal-ekarte/backend/internal/audit"
 )
 
 // RequestLoggingMiddleware リクエストロギングミドルウェア
@@ -98,6 +100,8 @@ func RequestID() gin.HandlerFunc {
 
 		c.Set("request_id", requestID)
 		c.Header("X-Request-ID", requestID)
+		// 監査ログに記録できるようリクエストのcontextにも格納する
+		c.Request = c.Request.WithContext(audit.WithRequestID(c.Request.Context(), requestID))
 
 		c.Next()
 	}
@@ -4,31 +4,35 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
 )
 
 // Accounting 会計モデル
 type Accounting struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	MedicalRecordID *uuid.UUID `json:"medical_record_id" gorm:"type:uuid"`
-	PetID           uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_acc_pet_id"`
-	OwnerID         uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
-	ScheduledDate   time.Time  `json:"scheduled_date" gorm:"type:date"`
-	CompletedAt     *time.Time `json:"completed_at"`
-	Status          string     `json:"status" gorm:"type:varchar(20);index:idx_acc_status;default:'未収'"` // 未収, 保留, 回収済, キャンセル
-	Subtotal        *float64   `json:"subtotal" gorm:"type:decimal(10,2)"`
-	TaxTotal        *float64   `json:"tax_total" gorm:"type:decimal(10,2)"`
-	TotalAmount     *float64   `json:"total_amount" gorm:"type:decimal(10,2)"`
-	InsuranceName   string     `json:"insurance_name" gorm:"type:varchar(100)"`
-	InsuranceRatio  *float64   `json:"insurance_ratio" gorm:"type:decimal(3,2)"`
-	InsuranceAmount *float64   `json:"insurance_amount" gorm:"type:decimal(10,2)"`
-	DiscountAmount  *float64   `json:"discount_amount" gorm:"type:decimal(10,2)"`
-	BillingAmount   *float64   `json:"billing_amount" gorm:"type:decimal(10,2)"`
-	ReceivedAmount  *float64   `json:"received_amount" gorm:"type:decimal(10,2)"`
-	ChangeAmount    *float64   `json:"change_amount" gorm:"type:decimal(10,2)"`
-	PaymentMethod   string     `json:"payment_method" gorm:"type:varchar(30)"` // 現金, クレジットカード, 電子マネー
-	Memo            string     `json:"memo" gorm:"type:text"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID                uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	MedicalRecordID   *uuid.UUID       `json:"medical_record_id" gorm:"type:uuid"`
+	HospitalizationID *uuid.UUID       `json:"hospitalization_id" gorm:"type:uuid;index:idx_acc_hospitalization_id"`
+	TrimmingID        *uuid.UUID       `json:"trimming_id" gorm:"type:uuid"`
+	PetID             uuid.UUID        `json:"pet_id" gorm:"type:uuid;not null;index:idx_acc_pet_id"`
+	OwnerID           uuid.UUID        `json:"owner_id" gorm:"type:uuid;not null"`
+	ScheduledDate     time.Time        `json:"scheduled_date" gorm:"type:date"`
+	CompletedAt       *time.Time       `json:"completed_at"`
+	Status            string           `json:"status" gorm:"type:varchar(20);index:idx_acc_status;default:'未収'"` // 未収, 保留, 回収済, キャンセル
+	Subtotal          *decimal.Decimal `json:"subtotal" gorm:"type:decimal(10,2)"`
+	TaxTotal          *decimal.Decimal `json:"tax_total" gorm:"type:decimal(10,2)"`
+	TotalAmount       *decimal.Decimal `json:"total_amount" gorm:"type:decimal(10,2)"`
+	InsuranceName     string           `json:"insurance_name" gorm:"type:varchar(100)"`
+	InsuranceRatio    *decimal.Decimal `json:"insurance_ratio" gorm:"type:decimal(3,2)"`
+	InsuranceAmount   *decimal.Decimal `json:"insurance_amount" gorm:"type:decimal(10,2)"`
+	DiscountAmount    *decimal.Decimal `json:"discount_amount" gorm:"type:decimal(10,2)"`
+	BillingAmount     *decimal.Decimal `json:"billing_amount" gorm:"type:decimal(10,2)"`
+	ReceivedAmount    *decimal.Decimal `json:"received_amount" gorm:"type:decimal(10,2)"`
+	ChangeAmount      *decimal.Decimal `json:"change_amount" gorm:"type:decimal(10,2)"`
+	PaymentMethod     string           `json:"payment_method" gorm:"type:varchar(30)"` // 現金, クレジットカード, 電子マネー
+	Memo              string           `json:"memo" gorm:"type:text"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
 
 	// Relations
 	Pet             *Pet             `json:"pet,omitempty" gorm:"foreignKey:PetID"`
@@ -44,21 +48,89 @@ func (Accounting) TableName() string {
 
 // AccountingItem 会計明細モデル
 type AccountingItem struct {
-	ID                    uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	AccountingID          uuid.UUID  `json:"accounting_id" gorm:"type:uuid;not null"`
-	MasterID              *uuid.UUID `json:"master_id" gorm:"type:uuid"`
-	Code                  string     `json:"code" gorm:"type:varchar(20)"`
-	Category              string     `json:"category" gorm:"type:varchar(50)"`
-	Name                  string     `json:"name" gorm:"type:varchar(200)"`
-	UnitPrice             *float64   `json:"unit_price" gorm:"type:decimal(10,2)"`
-	Quantity              int        `json:"quantity" gorm:"default:1"`
-	TaxRate               *float64   `json:"tax_rate" gorm:"type:decimal(3,2)"` // 0.1, 0.08
-	IsInsuranceApplicable bool       `json:"is_insurance_applicable" gorm:"default:false"`
-	Source                string     `json:"source" gorm:"type:varchar(20)"` // medical_record, manual
-	CreatedAt             time.Time  `json:"created_at"`
+	ID                    uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountingID          uuid.UUID        `json:"accounting_id" gorm:"type:uuid;not null"`
+	MasterID              *uuid.UUID       `json:"master_id" gorm:"type:uuid"`
+	Code                  string           `json:"code" gorm:"type:varchar(20)"`
+	Category              string           `json:"category" gorm:"type:varchar(50)"`
+	Name                  string           `json:"name" gorm:"type:varchar(200)"`
+	UnitPrice             *decimal.Decimal `json:"unit_price" gorm:"type:decimal(10,2)"`
+	Quantity              int              `json:"quantity" gorm:"default:1"`
+	TaxRate               *decimal.Decimal `json:"tax_rate" gorm:"type:decimal(3,2)"` // 0.1, 0.08
+	IsInsuranceApplicable bool             `json:"is_insurance_applicable" gorm:"default:false"`
+	Source                string           `json:"source" gorm:"type:varchar(20)"` // medical_record, hospitalization, manual
+	CreatedAt             time.Time        `json:"created_at"`
 }
 
 // TableName テーブル名を指定
 func (AccountingItem) TableName() string {
 	return "accounting_items"
 }
+
+// TaxBreakdown 税率ごとの対象額（税抜）と消費税額
+type TaxBreakdown struct {
+	Rate    decimal.Decimal `json:"rate"`
+	Taxable decimal.Decimal `json:"taxable"`
+	Tax     decimal.Decimal `json:"tax"`
+}
+
+// 会計ステータス
+const (
+	AccountingStatusUnpaid    = "未収"
+	AccountingStatusOnHold    = "保留"
+	AccountingStatusPaid      = "回収済"
+	AccountingStatusCancelled = "キャンセル"
+)
+
+// 会計明細の発生元
+const (
+	AccountingItemSourceMedicalRecord   = "medical_record"
+	AccountingItemSourceHospitalization = "hospitalization"
+	AccountingItemSourceTrimming        = "trimming"
+	AccountingItemSourceManual          = "manual"
+)
+
+// 支払方法
+const (
+	PaymentMethodCash       = "現金"
+	PaymentMethodCreditCard = "クレジットカード"
+	PaymentMethodEMoney     = "電子マネー"
+)
+
+// CreateAccountingRequest カルテからの会計作成リクエスト
+type CreateAccountingRequest struct {
+	MedicalRecordID string           `json:"medical_record_id" binding:"required"`
+	ScheduledDate   string           `json:"scheduled_date"`  // YYYY-MM-DD（省略時は当日）
+	InsuranceName   string           `json:"insurance_name"`  // 省略時はペットの保険名
+	InsuranceRatio  *decimal.Decimal `json:"insurance_ratio"` // 保険負担割合（0.5, 0.7 など）
+	DiscountAmount  *decimal.Decimal `json:"discount_amount"` // 値引額（円）
+	Memo            string           `json:"memo"`
+}
+
+// UpdateAccountingRequest 会計更新リクエスト
+type UpdateAccountingRequest struct {
+	Status         *string          `json:"status"`
+	InsuranceName  *string          `json:"insurance_name"`
+	InsuranceRatio *decimal.Decimal `json:"insurance_ratio"`
+	DiscountAmount *decimal.Decimal `json:"discount_amount"`
+	Memo           *string          `json:"memo"`
+}
+
+// AddAccountingItemRequest 会計明細の手動追加リクエスト
+// MasterIDを指定した場合、未指定の項目はマスタの値を使う。
+type AddAccountingItemRequest struct {
+	MasterID              string           `json:"master_id"`
+	Code                  string           `json:"code"`
+	Category              string           `json:"category"`
+	Name                  string           `json:"name"`
+	UnitPrice             *decimal.Decimal `json:"unit_price"`
+	Quantity              int              `json:"quantity"`
+	TaxRate               *decimal.Decimal `json:"tax_rate"`
+	IsInsuranceApplicable *bool            `json:"is_insurance_applicable"`
+}
+
+// CompleteAccountingRequest 会計完了（入金）リクエスト
+type CompleteAccountingRequest struct {
+	PaymentMethod  string           `json:"payment_method" binding:"required"`
+	ReceivedAmount *decimal.Decimal `json:"received_amount"` // 現金の場合は必須
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyzerImport 検査装置から受信した結果メッセージ（1検体分）
+// 検体番号またはペット番号で結果待ちの検査に照合し、照合できないものは未照合として手動での割り当てを待つ。
+type AnalyzerImport struct {
+	ID            uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Format        string          `json:"format" gorm:"type:varchar(10);not null"` // hl7, astm
+	Source        string          `json:"source" gorm:"type:varchar(255)"`         // 受信経路（tcp:<接続元>、file:<ファイル名>）
+	Machine       string          `json:"machine" gorm:"type:varchar(100)"`
+	AccessionNo   string          `json:"accession_no" gorm:"type:varchar(50)"`
+	PetNumber     string          `json:"pet_number" gorm:"type:varchar(50)"`
+	ObservedAt    *time.Time      `json:"observed_at"`
+	Results       AnalyzerResults `json:"results" gorm:"type:json"`
+	RawMessage    string          `json:"raw_message" gorm:"type:text"`
+	Status        string          `json:"status" gorm:"type:varchar(10);not null;index:idx_analyzer_import_status"` // 未照合, 照合済, 破棄
+	Reason        string          `json:"reason" gorm:"type:text"`                                                  // 照合できなかった理由
+	ExaminationID *uuid.UUID      `json:"examination_id" gorm:"type:uuid"`
+	ResolvedBy    *uuid.UUID      `json:"resolved_by,omitempty" gorm:"type:uuid"` // 手動で割り当て・破棄したスタッフ
+	ResolvedAt    *time.Time      `json:"resolved_at"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+
+	// Relations
+	Examination *Examination `json:"examination,omitempty" gorm:"foreignKey:ExaminationID"`
+}
+
+// TableName テーブル名を指定
+func (AnalyzerImport) TableName() string {
+	return "analyzer_imports"
+}
+
+// 検査装置の結果の照合状況
+const (
+	AnalyzerImportStatusUnmatched = "未照合"
+	AnalyzerImportStatusMatched   = "照合済"
+	AnalyzerImportStatusDiscarded = "破棄"
+)
+
+// AnalyzerResults 検査装置の結果（jsonとして保存）
+type AnalyzerResults []ExaminationResultInput
+
+// Value driver.Valuerの実装
+func (results AnalyzerResults) Value() (driver.Value, error) {
+	if results == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(results)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (results *AnalyzerResults) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*results = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for AnalyzerResults: %T", value)
+	}
+	return json.Unmarshal(b, results)
+}
+
+// AnalyzerImportFilter 検査装置の結果一覧の絞り込み条件
+type AnalyzerImportFilter struct {
+	Status     string
+	ReceivedAt DateRange
+}
+
+// ListAnalyzerImportsRequest 検査装置の結果一覧リクエスト
+type ListAnalyzerImportsRequest struct {
+	ListOptions
+	Status   string `form:"status"`    // 未照合, 照合済, 破棄
+	DateFrom string `form:"date_from"` // 受信日 YYYY-MM-DD
+	DateTo   string `form:"date_to"`
+}
+
+// AssignAnalyzerImportRequest 未照合の結果を検査へ割り当てるリクエスト
+type AssignAnalyzerImportRequest struct {
+	ExaminationID string `json:"examination_id" binding:"required"`
+}
+
+// DiscardAnalyzerImportRequest 未照合の結果を破棄するリクエスト
+type DiscardAnalyzerImportRequest struct {
+	Reason string `json:"reason"`
+}
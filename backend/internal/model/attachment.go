@@ -0,0 +1,56 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment カルテ・検査の添付ファイル（レントゲン・エコー画像、DICOM、PDFなど）
+// ファイル本体は添付ファイルの保存先（ローカルまたはS3互換ストレージ）に置き、ここには保存先のキーと属性を記録する。
+// 検査への添付は、検査がカルテに紐付いていればカルテの添付としても表示する。
+type Attachment struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID           uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_attachment_pet"`
+	MedicalRecordID *uuid.UUID `json:"medical_record_id" gorm:"type:uuid;index:idx_attachment_medical_record"`
+	ExaminationID   *uuid.UUID `json:"examination_id" gorm:"type:uuid;index:idx_attachment_examination"`
+	FileName        string     `json:"file_name" gorm:"type:varchar(255);not null"`
+	ContentType     string     `json:"content_type" gorm:"type:varchar(100);not null"` // image/jpeg, image/png, application/dicom, application/pdf
+	Size            int64      `json:"size" gorm:"not null"`
+	Checksum        string     `json:"checksum" gorm:"type:varchar(64)"`    // SHA-256（16進）
+	StorageKey      string     `json:"-" gorm:"type:varchar(255);not null"` // サムネイルは同じ階層の thumbnail.jpg
+	HasThumbnail    bool       `json:"has_thumbnail" gorm:"not null;default:false"`
+	Description     string     `json:"description" gorm:"type:text"`
+
+	// DICOMの属性（DICOMファイルのみ）
+	Modality         string     `json:"modality,omitempty" gorm:"type:varchar(16)"` // CR, DX, US など
+	StudyDate        *time.Time `json:"study_date,omitempty" gorm:"type:date"`
+	DicomPatientID   string     `json:"dicom_patient_id,omitempty" gorm:"type:varchar(64)"`
+	DicomPatientName string     `json:"dicom_patient_name,omitempty" gorm:"type:varchar(255)"`
+	StudyDescription string     `json:"study_description,omitempty" gorm:"type:varchar(255)"`
+	BodyPart         string     `json:"body_part,omitempty" gorm:"type:varchar(64)"`
+	StudyInstanceUID string     `json:"study_instance_uid,omitempty" gorm:"type:varchar(64)"`
+
+	UploadedBy *uuid.UUID `json:"uploaded_by,omitempty" gorm:"type:uuid"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (Attachment) TableName() string {
+	return "attachments"
+}
+
+// 添付できるファイルの種類
+const (
+	AttachmentContentTypeJPEG  = "image/jpeg"
+	AttachmentContentTypePNG   = "image/png"
+	AttachmentContentTypeDICOM = "application/dicom"
+	AttachmentContentTypePDF   = "application/pdf"
+)
+
+// UploadAttachmentRequest 添付ファイルの登録リクエスト（multipart/form-data）
+type UploadAttachmentRequest struct {
+	FileName    string `form:"-"` // アップロードされたファイルの名前
+	Description string `form:"description"`
+}
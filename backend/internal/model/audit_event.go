@@ -0,0 +1,91 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditEvent 監査ログモデル
+// 全エンティティの作成・更新・削除を、操作者・リクエストID・項目単位の変更前後の値とともに記録する。
+type AuditEvent struct {
+	ID         uuid.UUID    `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Actor      string       `json:"actor" gorm:"type:varchar(100);index:idx_audit_actor"`
+	RequestID  string       `json:"request_id" gorm:"type:varchar(64);index:idx_audit_request_id"`
+	EntityType string       `json:"entity_type" gorm:"type:varchar(50);not null;index:idx_audit_entity"`
+	EntityID   string       `json:"entity_id" gorm:"type:varchar(64);not null;index:idx_audit_entity"`
+	Action     string       `json:"action" gorm:"type:varchar(20);not null"` // create, update, delete
+	Changes    AuditChanges `json:"changes" gorm:"type:jsonb"`
+	CreatedAt  time.Time    `json:"created_at" gorm:"index:idx_audit_created_at"`
+}
+
+// TableName テーブル名を指定
+func (AuditEvent) TableName() string {
+	return "audit_events"
+}
+
+// 監査ログの操作種別
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditChange 1項目の変更前後の値
+type AuditChange struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditChanges カラム名をキーとした変更内容（jsonbとして保存）
+type AuditChanges map[string]AuditChange
+
+// Value driver.Valuerの実装
+func (c AuditChanges) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (c *AuditChanges) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for AuditChanges: %T", value)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// AuditEventFilter 監査ログ検索条件
+type AuditEventFilter struct {
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Limit      int
+}
+
+// ListAuditEventsRequest 監査ログ検索リクエスト
+type ListAuditEventsRequest struct {
+	Entity   string `form:"entity"`
+	EntityID string `form:"entity_id"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Limit    int    `form:"limit"`
+}
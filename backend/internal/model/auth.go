@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RefreshToken リフレッシュトークンモデル
+// トークン本体は保存せず、SHA-256ハッシュのみを保持する。使用時にローテーションする。
+type RefreshToken struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	StaffID   uuid.UUID  `json:"staff_id" gorm:"type:uuid;not null;index:idx_refresh_token_staff_id"`
+	TokenHash string     `json:"-" gorm:"type:varchar(64);not null;uniqueIndex:idx_refresh_token_hash"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Relations
+	Staff *Staff `json:"staff,omitempty" gorm:"foreignKey:StaffID"`
+}
+
+// TableName テーブル名を指定
+func (RefreshToken) TableName() string {
+	return "refresh_tokens"
+}
+
+// LoginRequest ログインリクエスト
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required"`
+	Password string `json:"password" binding:"required"`
+}
+
+// RefreshTokenRequest トークン更新・ログアウトリクエスト
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// SetPasswordRequest パスワード設定リクエスト
+type SetPasswordRequest struct {
+	Password string `json:"password" binding:"required"`
+}
+
+// TokenResponse ログイン・トークン更新レスポンス
+type TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // アクセストークンの有効秒数
+	Staff        *Staff `json:"staff"`
+}
@@ -0,0 +1,90 @@
+package model
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// CareSchedule ケアプラン項目の実施予定
+// 次のいずれか1つの形式で指定する。
+//   - 時刻指定: {"times": ["08:00", "18:00"]}（morning, noon, nightも可）
+//   - 間隔指定: {"every_hours": 8, "start": "06:00"}（項目の開始日のstartからN時間ごと、日をまたいでも間隔を保つ）
+//   - 頓用: {"prn": true}
+//
+// 旧形式のJSON配列（["morning", "night"]）は時刻指定として読み込む。
+type CareSchedule struct {
+	Times      []string `json:"times,omitempty"`
+	EveryHours int      `json:"every_hours,omitempty"`
+	Start      string   `json:"start,omitempty"` // HH:MM（間隔指定の起点、省略時は00:00）
+	PRN        bool     `json:"prn,omitempty"`
+}
+
+// ケアプランの時間帯
+const (
+	CareTimingMorning = "morning"
+	CareTimingNoon    = "noon"
+	CareTimingNight   = "night"
+)
+
+// CareTimingSlots 時間帯の予定時刻
+var CareTimingSlots = map[string]string{
+	CareTimingMorning: "08:00",
+	CareTimingNoon:    "12:00",
+	CareTimingNight:   "18:00",
+}
+
+// IsZero 予定が設定されていないかどうか
+func (s CareSchedule) IsZero() bool {
+	return len(s.Times) == 0 && s.EveryHours == 0 && !s.PRN
+}
+
+// UnmarshalJSON オブジェクト形式と旧形式（時刻の配列）の両方を受け付ける
+func (s *CareSchedule) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		*s = CareSchedule{}
+		return nil
+	case data[0] == '[':
+		var times []string
+		if err := json.Unmarshal(data, &times); err != nil {
+			return err
+		}
+		*s = CareSchedule{Times: times}
+		return nil
+	}
+
+	type plain CareSchedule
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	*s = CareSchedule(p)
+	return nil
+}
+
+// Value implements driver.Valuer
+func (s CareSchedule) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (s *CareSchedule) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*s = CareSchedule{}
+		return nil
+	case []byte:
+		return s.UnmarshalJSON(v)
+	case string:
+		return s.UnmarshalJSON([]byte(v))
+	default:
+		return fmt.Errorf("cannot scan %T into CareSchedule", src)
+	}
+}
@@ -34,15 +34,16 @@ func (Clinic) TableName() string {
 
 // Staff スタッフモデル
 type Staff struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	ClinicID  *uuid.UUID `json:"clinic_id" gorm:"type:uuid"`
-	Name      string     `json:"name" gorm:"type:varchar(100)"`
-	Role      string     `json:"role" gorm:"type:varchar(50)"` // veterinarian, nurse, groomer, admin
-	Email     string     `json:"email" gorm:"type:varchar(255)"`
-	Phone     string     `json:"phone" gorm:"type:varchar(20)"`
-	IsActive  bool       `json:"is_active" gorm:"default:true"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ClinicID     *uuid.UUID `json:"clinic_id" gorm:"type:uuid"`
+	Name         string     `json:"name" gorm:"type:varchar(100)"`
+	Role         string     `json:"role" gorm:"type:varchar(50)"` // veterinarian, nurse, groomer, admin
+	Email        string     `json:"email" gorm:"type:varchar(255);index:idx_staff_email"`
+	Phone        string     `json:"phone" gorm:"type:varchar(20)"`
+	PasswordHash string     `json:"-" gorm:"type:varchar(255)"`
+	IsActive     bool       `json:"is_active" gorm:"default:true"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
 
 	// Relations
 	Clinic *Clinic `json:"clinic,omitempty" gorm:"foreignKey:ClinicID"`
@@ -52,3 +53,11 @@ type Staff struct {
 func (Staff) TableName() string {
 	return "staffs"
 }
+
+// スタッフロール
+const (
+	StaffRoleVeterinarian = "veterinarian"
+	StaffRoleNurse        = "nurse"
+	StaffRoleGroomer      = "groomer"
+	StaffRoleAdmin        = "admin"
+)
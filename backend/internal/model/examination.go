@@ -1,27 +1,34 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 // Examination 検査記録モデル
+// 依頼時に検査項目（analyte）を並べ、完了時に結果を記入して動物種別の基準範囲で高値・低値を判定する。
 type Examination struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	PetID           uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null"`
-	OwnerID         uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
-	DoctorID        *uuid.UUID `json:"doctor_id" gorm:"type:uuid"`
-	MedicalRecordID *uuid.UUID `json:"medical_record_id" gorm:"type:uuid"`
-	ExaminationDate time.Time  `json:"examination_date"`
-	TestType        string     `json:"test_type" gorm:"type:varchar(100)"`
-	Machine         string     `json:"machine" gorm:"type:varchar(100)"`
-	Status          string     `json:"status" gorm:"type:varchar(20);default:'依頼中'"` // 依頼中, 検査中, 完了
-	ResultSummary   string     `json:"result_summary" gorm:"type:text"`
-	Items           string     `json:"items" gorm:"type:json"` // JSON array
-	Notes           string     `json:"notes" gorm:"type:text"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID           uuid.UUID        `json:"pet_id" gorm:"type:uuid;not null;index:idx_examination_pet_date"`
+	OwnerID         uuid.UUID        `json:"owner_id" gorm:"type:uuid;not null"`
+	DoctorID        *uuid.UUID       `json:"doctor_id" gorm:"type:uuid"`
+	MedicalRecordID *uuid.UUID       `json:"medical_record_id" gorm:"type:uuid;index:idx_examination_medical_record"`
+	AccessionNo     int              `json:"accession_no" gorm:"type:integer;autoIncrement;uniqueIndex:idx_examination_accession_no"` // 検体番号（検査装置で検体に付ける連番）
+	ExaminationDate time.Time        `json:"examination_date" gorm:"index:idx_examination_pet_date"`
+	TestType        string           `json:"test_type" gorm:"type:varchar(100)"`
+	Machine         string           `json:"machine" gorm:"type:varchar(100)"`
+	Status          string           `json:"status" gorm:"type:varchar(20);default:'依頼中'"` // 依頼中, 検査中, 完了
+	ResultSummary   string           `json:"result_summary" gorm:"type:text"`
+	Items           ExaminationItems `json:"items" gorm:"type:json"`
+	CompletedAt     *time.Time       `json:"completed_at"`
+	Notes           string           `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
 
 	// Relations
 	Pet           *Pet           `json:"pet,omitempty" gorm:"foreignKey:PetID"`
@@ -33,3 +40,182 @@ type Examination struct {
 func (Examination) TableName() string {
 	return "examinations"
 }
+
+// 検査のステータス
+const (
+	ExaminationStatusOrdered    = "依頼中"
+	ExaminationStatusInProgress = "検査中"
+	ExaminationStatusCompleted  = "完了"
+)
+
+// 検査結果の判定
+const (
+	ExaminationFlagHigh = "H" // 基準範囲より高い
+	ExaminationFlagLow  = "L" // 基準範囲より低い
+)
+
+// ExaminationItem 検査項目と結果
+// 基準範囲は完了時点のペットの動物種別のものを写して持つ。
+type ExaminationItem struct {
+	Analyte       string   `json:"analyte"`                  // 項目コード（ALT, BUN など）
+	Name          string   `json:"name,omitempty"`           // 項目名
+	Value         *float64 `json:"value,omitempty"`          // 数値の結果
+	TextValue     string   `json:"text_value,omitempty"`     // 定性の結果（陰性、2+ など）
+	Unit          string   `json:"unit,omitempty"`           // 単位
+	ReferenceLow  *float64 `json:"reference_low,omitempty"`  // 基準範囲の下限
+	ReferenceHigh *float64 `json:"reference_high,omitempty"` // 基準範囲の上限
+	Flag          string   `json:"flag,omitempty"`           // H, L（範囲内・判定なしは空）
+}
+
+// NormalizeAnalyte 検査項目コードを比較用にそろえる（前後の空白を除いて大文字にする）
+func NormalizeAnalyte(analyte string) string {
+	return strings.ToUpper(strings.TrimSpace(analyte))
+}
+
+// ExaminationItems 検査項目の一覧（jsonとして保存）
+type ExaminationItems []ExaminationItem
+
+// Value driver.Valuerの実装
+func (items ExaminationItems) Value() (driver.Value, error) {
+	if items == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (items *ExaminationItems) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*items = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for ExaminationItems: %T", value)
+	}
+	return json.Unmarshal(b, items)
+}
+
+// LabReferenceRange 検査項目の動物種別の基準範囲
+// Speciesは動物種別（SpeciesKindの戻り値）で、空なら動物種を問わない既定の範囲とする。
+type LabReferenceRange struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Analyte   string    `json:"analyte" gorm:"type:varchar(30);not null;uniqueIndex:idx_lab_reference_range"`
+	Species   string    `json:"species" gorm:"type:varchar(50);not null;default:'';uniqueIndex:idx_lab_reference_range"`
+	Name      string    `json:"name" gorm:"type:varchar(100)"`
+	Unit      string    `json:"unit" gorm:"type:varchar(30)"`
+	Low       *float64  `json:"low"`
+	High      *float64  `json:"high"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName テーブル名を指定
+func (LabReferenceRange) TableName() string {
+	return "lab_reference_ranges"
+}
+
+// ExaminationFilter 検査一覧の絞り込み条件
+type ExaminationFilter struct {
+	PetID           *uuid.UUID
+	OwnerID         *uuid.UUID
+	MedicalRecordID *uuid.UUID
+	Status          string
+	ExaminationDate DateRange
+}
+
+// ListExaminationsRequest 検査一覧リクエスト
+type ListExaminationsRequest struct {
+	ListOptions
+	PetID           string `form:"pet_id"`
+	OwnerID         string `form:"owner_id"`
+	MedicalRecordID string `form:"medical_record_id"`
+	Status          string `form:"status"`
+	DateFrom        string `form:"date_from"` // 検査日 YYYY-MM-DD
+	DateTo          string `form:"date_to"`
+}
+
+// CreateExaminationRequest 検査依頼リクエスト
+type CreateExaminationRequest struct {
+	PetID           string   `json:"pet_id" binding:"required"`
+	MedicalRecordID string   `json:"medical_record_id"` // 依頼元のカルテ（同じペットのもの）
+	DoctorID        string   `json:"doctor_id"`         // 省略時はカルテの担当医
+	ExaminationDate string   `json:"examination_date"`  // 省略時は現在日時
+	TestType        string   `json:"test_type"`
+	Machine         string   `json:"machine"`
+	Analytes        []string `json:"analytes"` // 依頼する検査項目コード
+	Notes           string   `json:"notes"`
+}
+
+// UpdateExaminationRequest 検査依頼変更リクエスト（完了前のみ変更できる）
+type UpdateExaminationRequest struct {
+	DoctorID        *string   `json:"doctor_id"` // 空文字で解除
+	ExaminationDate *string   `json:"examination_date"`
+	TestType        *string   `json:"test_type"`
+	Machine         *string   `json:"machine"`
+	Status          *string   `json:"status"` // 依頼中, 検査中
+	Analytes        *[]string `json:"analytes"`
+	Notes           *string   `json:"notes"`
+}
+
+// ExaminationResultInput 検査結果の入力
+type ExaminationResultInput struct {
+	Analyte   string   `json:"analyte" binding:"required"`
+	Name      string   `json:"name"`
+	Value     *float64 `json:"value"`
+	TextValue string   `json:"text_value"`
+	Unit      string   `json:"unit"` // 省略時は基準範囲の単位
+}
+
+// CompleteExaminationRequest 検査完了リクエスト
+// 依頼した項目に結果を記入し、依頼していない項目は追加する。
+type CompleteExaminationRequest struct {
+	Results       []ExaminationResultInput `json:"results" binding:"required"`
+	ResultSummary string                   `json:"result_summary"`
+}
+
+// ExaminationTrendRequest 検査項目の推移リクエスト
+type ExaminationTrendRequest struct {
+	Analyte  string `form:"analyte" binding:"required"`
+	DateFrom string `form:"date_from"` // 検査日 YYYY-MM-DD
+	DateTo   string `form:"date_to"`
+}
+
+// ExaminationTrend ペットの1項目の検査結果の推移（検査日の古い順）
+type ExaminationTrend struct {
+	PetID   uuid.UUID               `json:"pet_id"`
+	Analyte string                  `json:"analyte"`
+	Name    string                  `json:"name"`
+	Points  []ExaminationTrendPoint `json:"points"`
+}
+
+// ExaminationTrendPoint 推移の1点
+type ExaminationTrendPoint struct {
+	ExaminationID   uuid.UUID `json:"examination_id"`
+	ExaminationDate time.Time `json:"examination_date"`
+	ExaminationItem
+}
+
+// ListLabReferenceRangesRequest 基準範囲一覧リクエスト
+type ListLabReferenceRangesRequest struct {
+	Analyte string `form:"analyte"`
+	Species string `form:"species"` // 犬・猫などの表記ゆれは動物種別にそろえる
+}
+
+// SaveLabReferenceRangeRequest 基準範囲登録リクエスト（同じ項目・動物種別の範囲は上書きする）
+type SaveLabReferenceRangeRequest struct {
+	Analyte string   `json:"analyte" binding:"required"`
+	Species string   `json:"species"` // 空なら動物種を問わない既定の範囲
+	Name    string   `json:"name"`
+	Unit    string   `json:"unit"`
+	Low     *float64 `json:"low"`
+	High    *float64 `json:"high"`
+}
@@ -4,12 +4,14 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
 )
 
 // Hospitalization 入院/ホテルモデル
 type Hospitalization struct {
 	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	HospitalizationNo string     `json:"hospitalization_no" gorm:"type:varchar(20)"`
+	HospitalizationNo string     `json:"hospitalization_no" gorm:"type:varchar(20)"` // 一意インデックスは起動時に作成（重複した既存の番号を採番し直してから）
 	PetID             uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_hosp_pet_id"`
 	OwnerID           uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
 	CageID            *uuid.UUID `json:"cage_id" gorm:"type:uuid"`
@@ -17,6 +19,7 @@ type Hospitalization struct {
 	StartDate         time.Time  `json:"start_date" gorm:"type:date"`
 	EndDate           time.Time  `json:"end_date" gorm:"type:date"`
 	Status            string     `json:"status" gorm:"type:varchar(20);index:idx_hosp_status;default:'予約'"` // 入院中, 退院済, 予約, 一時帰宅
+	DischargedAt      *time.Time `json:"discharged_at"`
 	OwnerRequest      string     `json:"owner_request" gorm:"type:text"`
 	StaffNotes        string     `json:"staff_notes" gorm:"type:text"`
 	Memo              string     `json:"memo" gorm:"type:text"`
@@ -36,6 +39,57 @@ func (Hospitalization) TableName() string {
 	return "hospitalizations"
 }
 
+// 入院区分
+const (
+	HospitalizationTypeInpatient = "入院"
+	HospitalizationTypeHotel     = "ホテル"
+)
+
+// 入院ステータス
+const (
+	HospitalizationStatusReserved       = "予約"
+	HospitalizationStatusAdmitted       = "入院中"
+	HospitalizationStatusTemporaryLeave = "一時帰宅"
+	HospitalizationStatusDischarged     = "退院済"
+)
+
+// CreateHospitalizationRequest 入院（ホテル）受付リクエスト
+// CageIDを省略した場合、ペットの種類とCageSizeに合う空きケージを自動で割り当てる。
+type CreateHospitalizationRequest struct {
+	PetID        string `json:"pet_id" binding:"required"`
+	Type         string `json:"type"`                          // 入院, ホテル（省略時は入院）
+	StartDate    string `json:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate      string `json:"end_date" binding:"required"`   // YYYY-MM-DD（退院予定日）
+	CageID       string `json:"cage_id"`
+	CageSize     string `json:"cage_size"` // S, M, L, XL（指定サイズ以上のケージを割り当てる）
+	Status       string `json:"status"`    // 予約, 入院中（省略時は開始日が今日以前なら入院中）
+	OwnerRequest string `json:"owner_request"`
+	StaffNotes   string `json:"staff_notes"`
+	Memo         string `json:"memo"`
+}
+
+// UpdateHospitalizationRequest 入院情報更新リクエスト
+type UpdateHospitalizationRequest struct {
+	EndDate      *string `json:"end_date"`
+	CageID       *string `json:"cage_id"`
+	Status       *string `json:"status"` // 予約, 入院中, 一時帰宅（退院は退院処理で行う）
+	OwnerRequest *string `json:"owner_request"`
+	StaffNotes   *string `json:"staff_notes"`
+	Memo         *string `json:"memo"`
+}
+
+// DischargeHospitalizationRequest 退院リクエスト
+type DischargeHospitalizationRequest struct {
+	DischargeDate    string `json:"discharge_date"`    // YYYY-MM-DD（省略時は当日）
+	CreateAccounting bool   `json:"create_accounting"` // ケアプランの単価から会計を作成する
+}
+
+// DischargeResult 退院処理の結果
+type DischargeResult struct {
+	Hospitalization *Hospitalization `json:"hospitalization"`
+	Accounting      *Accounting      `json:"accounting,omitempty"`
+}
+
 // Cage ケージマスタモデル
 type Cage struct {
 	ID          uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
@@ -54,21 +108,52 @@ func (Cage) TableName() string {
 	return "cages"
 }
 
+// ケージ種別
+const (
+	CageTypeDog    = "犬用"
+	CageTypeCat    = "猫用"
+	CageTypeShared = "共用"
+)
+
+// CageSizes ケージサイズ（小さい順）
+var CageSizes = []string{"S", "M", "L", "XL"}
+
+// AvailableCagesRequest 空きケージ検索リクエスト
+type AvailableCagesRequest struct {
+	StartDate string `form:"start_date" binding:"required"` // YYYY-MM-DD
+	EndDate   string `form:"end_date" binding:"required"`   // YYYY-MM-DD
+	Species   string `form:"species"`                       // 犬, 猫 など（ケージ種別の絞り込み）
+	Size      string `form:"size"`                          // 指定サイズ以上
+}
+
+// AddCarePlanItemRequest ケアプラン項目追加リクエスト
+type AddCarePlanItemRequest struct {
+	MasterID    string           `json:"master_id"`
+	Type        string           `json:"type" binding:"required"` // food, medicine, treatment, instruction, item
+	Name        string           `json:"name"`                    // master_id指定時は省略可
+	Description string           `json:"description"`
+	UnitPrice   *decimal.Decimal `json:"unit_price"` // master_id指定時の省略はマスタ価格
+	Timing      *CareSchedule    `json:"timing"`     // 省略時は予定なし（指示のみ）
+	Category    string           `json:"category"`
+	Notes       string           `json:"notes"`
+}
+
 // CarePlanItem ケアプラン項目モデル
 type CarePlanItem struct {
-	ID                uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	HospitalizationID uuid.UUID  `json:"hospitalization_id" gorm:"type:uuid;not null"`
-	MasterID          *uuid.UUID `json:"master_id" gorm:"type:uuid"`
-	Type              string     `json:"type" gorm:"type:varchar(30)"` // food, medicine, treatment, instruction, item
-	Name              string     `json:"name" gorm:"type:varchar(100)"`
-	Description       string     `json:"description" gorm:"type:text"`
-	Timing            string     `json:"timing" gorm:"type:json"`                         // JSON array
-	Status            string     `json:"status" gorm:"type:varchar(20);default:'active'"` // active, completed, discontinued
-	UnitPrice         *float64   `json:"unit_price" gorm:"type:decimal(10,2)"`
-	Category          string     `json:"category" gorm:"type:varchar(50)"`
-	Notes             string     `json:"notes" gorm:"type:text"`
-	CreatedAt         time.Time  `json:"created_at"`
-	UpdatedAt         time.Time  `json:"updated_at"`
+	ID                uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	HospitalizationID uuid.UUID        `json:"hospitalization_id" gorm:"type:uuid;not null"`
+	MasterID          *uuid.UUID       `json:"master_id" gorm:"type:uuid"`
+	Type              string           `json:"type" gorm:"type:varchar(30)"` // food, medicine, treatment, instruction, item
+	Name              string           `json:"name" gorm:"type:varchar(100)"`
+	Description       string           `json:"description" gorm:"type:text"`
+	Timing            CareSchedule     `json:"timing" gorm:"type:json"`
+	Status            string           `json:"status" gorm:"type:varchar(20);default:'active'"` // active, completed, discontinued
+	UnitPrice         *decimal.Decimal `json:"unit_price" gorm:"type:decimal(10,2)"`
+	PriceFromMaster   bool             `json:"price_from_master" gorm:"not null;default:false"` // 単価をマスタから補完した（退院時に退院日時点の価格へ置き換える）
+	Category          string           `json:"category" gorm:"type:varchar(50)"`
+	Notes             string           `json:"notes" gorm:"type:text"`
+	CreatedAt         time.Time        `json:"created_at"`
+	UpdatedAt         time.Time        `json:"updated_at"`
 }
 
 // TableName テーブル名を指定
@@ -79,8 +164,8 @@ func (CarePlanItem) TableName() string {
 // DailyRecord 日次記録モデル
 type DailyRecord struct {
 	ID                uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	HospitalizationID uuid.UUID `json:"hospitalization_id" gorm:"type:uuid;not null"`
-	RecordDate        time.Time `json:"record_date" gorm:"type:date"`
+	HospitalizationID uuid.UUID `json:"hospitalization_id" gorm:"type:uuid;not null;uniqueIndex:idx_daily_record_hosp_date"`
+	RecordDate        time.Time `json:"record_date" gorm:"type:date;uniqueIndex:idx_daily_record_hosp_date"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
 
@@ -116,15 +201,17 @@ func (Vital) TableName() string {
 
 // CareLog ケアログモデル
 type CareLog struct {
-	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	DailyRecordID uuid.UUID  `json:"daily_record_id" gorm:"type:uuid;not null"`
-	StaffID       *uuid.UUID `json:"staff_id" gorm:"type:uuid"`
-	RecordedTime  string     `json:"recorded_time" gorm:"type:time"`
-	Type          string     `json:"type" gorm:"type:varchar(30)"`   // food, excretion, medicine, treatment, other
-	Status        string     `json:"status" gorm:"type:varchar(20)"` // completed, partial, skipped
-	Value         string     `json:"value" gorm:"type:varchar(100)"`
-	Notes         string     `json:"notes" gorm:"type:text"`
-	CreatedAt     time.Time  `json:"created_at"`
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	DailyRecordID  uuid.UUID  `json:"daily_record_id" gorm:"type:uuid;not null"`
+	StaffID        *uuid.UUID `json:"staff_id" gorm:"type:uuid"`
+	RecordedTime   string     `json:"recorded_time" gorm:"type:time"`
+	CarePlanItemID *uuid.UUID `json:"care_plan_item_id" gorm:"type:uuid;index:idx_care_log_plan_item"`
+	Timing         string     `json:"timing" gorm:"type:varchar(20)"` // 実施した予定のキー（CareScheduleのHH:MMまたはmorning, noon, night）
+	Type           string     `json:"type" gorm:"type:varchar(30)"`   // food, excretion, medicine, treatment, other
+	Status         string     `json:"status" gorm:"type:varchar(20)"` // completed, partial, skipped
+	Value          string     `json:"value" gorm:"type:varchar(100)"`
+	Notes          string     `json:"notes" gorm:"type:text"`
+	CreatedAt      time.Time  `json:"created_at"`
 }
 
 // TableName テーブル名を指定
@@ -146,3 +233,132 @@ type StaffNote struct {
 func (StaffNote) TableName() string {
 	return "staff_notes"
 }
+
+// ケアログの実施状況
+const (
+	CareLogStatusCompleted = "completed"
+	CareLogStatusPartial   = "partial"
+	CareLogStatusSkipped   = "skipped"
+)
+
+// DailyRecordEntry 日次記録への書き込みに共通する項目
+// RecordDate省略時は当日、RecordedTime省略時は現在時刻として記録する。
+type DailyRecordEntry struct {
+	RecordDate   string `json:"record_date"`   // YYYY-MM-DD
+	RecordedTime string `json:"recorded_time"` // HH:MM
+}
+
+// AddVitalRequest バイタル記録リクエスト
+type AddVitalRequest struct {
+	DailyRecordEntry
+	Temperature     *float64 `json:"temperature"`
+	HeartRate       *int     `json:"heart_rate"`
+	RespirationRate *int     `json:"respiration_rate"`
+	Weight          *float64 `json:"weight"`
+	Notes           string   `json:"notes"`
+}
+
+// AddCareLogRequest ケアログ記録リクエスト
+// CarePlanItemIDとTimingを指定すると、温度板上でそのケアプランの実施として扱う。
+type AddCareLogRequest struct {
+	DailyRecordEntry
+	CarePlanItemID string `json:"care_plan_item_id"`
+	Timing         string `json:"timing"`
+	Type           string `json:"type" binding:"required"` // food, excretion, medicine, treatment, other
+	Status         string `json:"status"`                  // completed, partial, skipped（省略時はcompleted）
+	Value          string `json:"value"`
+	Notes          string `json:"notes"`
+}
+
+// AddStaffNoteRequest スタッフメモ記録リクエスト
+type AddStaffNoteRequest struct {
+	DailyRecordEntry
+	Content string `json:"content" binding:"required"`
+}
+
+// TemperatureChart 温度板（1日分の入院ペットごとのバイタル推移とケア実施状況）
+type TemperatureChart struct {
+	Date    string                  `json:"date"`
+	Entries []TemperatureChartEntry `json:"entries"`
+}
+
+// TemperatureChartEntry 温度板の1入院分
+type TemperatureChartEntry struct {
+	Hospitalization *Hospitalization `json:"hospitalization"`
+	Vitals          []Vital          `json:"vitals"`
+	CareTasks       []CareTaskStatus `json:"care_tasks"`
+	StaffNotes      []StaffNote      `json:"staff_notes"`
+	ScheduledCount  int              `json:"scheduled_count"`
+	CompletedCount  int              `json:"completed_count"`
+}
+
+// ケアタスクの実施状況
+const (
+	CareTaskStatusPending  = "pending"
+	CareTaskStatusDone     = "done"
+	CareTaskStatusSkipped  = "skipped"
+	CareTaskStatusOverdue  = "overdue"
+	CareTaskStatusAsNeeded = "prn"
+)
+
+// CareTaskStatus ケアプランの1回分の予定と実施状況
+// 頓用（PRN）の項目は予定時刻を持たず、その日の最新のケアログを付けて返す。
+type CareTaskStatus struct {
+	CarePlanItemID uuid.UUID  `json:"care_plan_item_id"`
+	Type           string     `json:"type"`
+	Name           string     `json:"name"`
+	Timing         string     `json:"timing"` // CareLog.Timingに指定するキー
+	DueAt          *time.Time `json:"due_at,omitempty"`
+	Status         string     `json:"status"` // pending, done, skipped, overdue, prn
+	CareLog        *CareLog   `json:"care_log,omitempty"`
+}
+
+// TemperatureChartRequest 温度板取得リクエスト
+type TemperatureChartRequest struct {
+	Date string `form:"date"` // YYYY-MM-DD（省略時は当日）
+}
+
+// 病棟（ケージ未割当の入院）
+const WardUnassigned = "未割当"
+
+// WardTasksRequest 病棟タスク一覧リクエスト
+type WardTasksRequest struct {
+	Date string `form:"date"` // YYYY-MM-DD（省略時は当日）
+	From string `form:"from"` // HH:MM（省略時は00:00）
+	To   string `form:"to"`   // HH:MM（省略時は24:00、この時刻は含まない）
+	Ward string `form:"ward"` // 犬用, 猫用, 共用（ケージ種別）
+}
+
+// WardTasks 病棟ごとのケアタスク一覧
+type WardTasks struct {
+	Date    string          `json:"date"`
+	From    string          `json:"from"`
+	To      string          `json:"to"`
+	Summary WardTaskSummary `json:"summary"`
+	Wards   []WardTaskGroup `json:"wards"`
+}
+
+// WardTaskSummary ケアタスクの実施状況ごとの件数
+type WardTaskSummary struct {
+	Pending  int `json:"pending"`
+	Done     int `json:"done"`
+	Skipped  int `json:"skipped"`
+	Overdue  int `json:"overdue"`
+	AsNeeded int `json:"prn"`
+}
+
+// WardTaskGroup 病棟（ケージ種別）ごとのケアタスク
+type WardTaskGroup struct {
+	Ward  string     `json:"ward"`
+	Tasks []WardTask `json:"tasks"`
+}
+
+// WardTask 入院ペットのケアタスク
+type WardTask struct {
+	HospitalizationID uuid.UUID `json:"hospitalization_id"`
+	HospitalizationNo string    `json:"hospitalization_no"`
+	PetID             uuid.UUID `json:"pet_id"`
+	PetName           string    `json:"pet_name"`
+	CageCode          string    `json:"cage_code"`
+	CareTaskStatus
+}
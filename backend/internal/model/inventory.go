@@ -0,0 +1,117 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 在庫ステータス
+const (
+	InventoryStatusSufficient = "sufficient"
+	InventoryStatusLow        = "low"
+	InventoryStatusOutOfStock = "out_of_stock"
+)
+
+// 入出庫区分
+const (
+	StockMovementReceive  = "receive"  // 入庫
+	StockMovementDispense = "dispense" // 払出（処方・処置）
+	StockMovementAdjust   = "adjust"   // 棚卸調整
+	StockMovementExpire   = "expire"   // 期限切れ廃棄
+)
+
+// StockMovementResult 入出庫登録結果（ロットごとの入出庫と反映後の品目）
+type StockMovementResult struct {
+	Item      *InventoryItem  `json:"item"`
+	Movements []StockMovement `json:"movements"`
+}
+
+// InventoryLot 在庫品目のロット別在庫
+// ロット番号なしで入庫した在庫は、ロット番号が空のロットにまとめる。
+type InventoryLot struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	InventoryItemID uuid.UUID  `json:"inventory_item_id" gorm:"type:uuid;not null;uniqueIndex:idx_inv_lot_number"`
+	LotNumber       string     `json:"lot_number" gorm:"type:varchar(50);not null;default:'';uniqueIndex:idx_inv_lot_number"`
+	ExpiryDate      *time.Time `json:"expiry_date" gorm:"type:date;index:idx_inv_lot_expiry"`
+	Quantity        int        `json:"quantity" gorm:"default:0"`
+	ReceivedAt      time.Time  `json:"received_at" gorm:"type:date"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Relations
+	InventoryItem *InventoryItem `json:"inventory_item,omitempty" gorm:"foreignKey:InventoryItemID"`
+}
+
+// TableName テーブル名を指定
+func (InventoryLot) TableName() string {
+	return "inventory_lots"
+}
+
+// StockMovement 在庫の入出庫履歴
+// Quantityは在庫数の増減（払出・廃棄は負数）、QuantityAfterは反映後の品目全体の在庫数。
+// ロットをまたぐ払出はロットごとに記録する。
+type StockMovement struct {
+	ID               uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	InventoryItemID  uuid.UUID  `json:"inventory_item_id" gorm:"type:uuid;not null;index:idx_stock_mov_item_id"`
+	LotID            *uuid.UUID `json:"lot_id" gorm:"type:uuid;index:idx_stock_mov_lot_id"`
+	LotNumber        string     `json:"lot_number" gorm:"type:varchar(50)"`
+	Type             string     `json:"type" gorm:"type:varchar(20);not null"` // receive, dispense, adjust, expire
+	Quantity         int        `json:"quantity" gorm:"not null"`
+	QuantityAfter    int        `json:"quantity_after" gorm:"not null"`
+	AccountingItemID *uuid.UUID `json:"accounting_item_id" gorm:"type:uuid;index:idx_stock_mov_accounting_item_id"` // 会計からの自動払出
+	StaffID          *uuid.UUID `json:"staff_id" gorm:"type:uuid"`
+	Reason           string     `json:"reason" gorm:"type:text"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (StockMovement) TableName() string {
+	return "stock_movements"
+}
+
+// CreateInventoryItemRequest 在庫品目登録リクエスト（初期在庫数は入庫として記録する）
+type CreateInventoryItemRequest struct {
+	Name          string `json:"name" binding:"required"`
+	Category      string `json:"category"` // medicine, consumable, food, other
+	Quantity      int    `json:"quantity"`
+	Unit          string `json:"unit"`
+	MinStockLevel int    `json:"min_stock_level"`
+	Location      string `json:"location"`
+	LotNumber     string `json:"lot_number"`  // 初期在庫のロット番号
+	ExpiryDate    string `json:"expiry_date"` // 初期在庫の使用期限 YYYY-MM-DD
+	Supplier      string `json:"supplier"`
+}
+
+// UpdateInventoryItemRequest 在庫品目更新リクエスト（在庫数・使用期限は入出庫で変更する）
+type UpdateInventoryItemRequest struct {
+	Name          *string `json:"name"`
+	Category      *string `json:"category"`
+	Unit          *string `json:"unit"`
+	MinStockLevel *int    `json:"min_stock_level"`
+	Location      *string `json:"location"`
+	Supplier      *string `json:"supplier"`
+}
+
+// CreateStockMovementRequest 入出庫登録リクエスト
+// receive・dispense・expireは数量（正数）を、adjustは棚卸差異（増減）を指定する。
+// receiveはロット番号・使用期限を、dispense・expire・adjustは対象ロットを指定できる。
+// ロットを指定しない払出は使用期限の近いロットから払い出す（FEFO）。
+type CreateStockMovementRequest struct {
+	Type       string `json:"type" binding:"required"`
+	Quantity   int    `json:"quantity" binding:"required"`
+	LotNumber  string `json:"lot_number"`  // receive
+	ExpiryDate string `json:"expiry_date"` // receive（YYYY-MM-DD）
+	LotID      string `json:"lot_id"`      // dispense, expire, adjust
+	Reason     string `json:"reason"`
+}
+
+// ExpiringLotsRequest 期限切れ間近ロット一覧リクエスト
+type ExpiringLotsRequest struct {
+	Within string `form:"within"` // 30d, 4w, 30（日数、省略時は30日。期限切れのロットも含む）
+}
+
+// WriteOffLotRequest ロット廃棄リクエスト
+type WriteOffLotRequest struct {
+	Reason string `json:"reason"`
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// 一覧の1ページの件数
+const (
+	DefaultListLimit = 20
+	MaxListLimit     = 100
+)
+
+// ListOptions 一覧取得の共通パラメータ（並び替え・カーソルページング）
+type ListOptions struct {
+	Sort   string `form:"sort"`   // 並び替える項目をカンマ区切りで指定（先頭に-で降順）例: -visit_date,record_no
+	Cursor string `form:"cursor"` // 前のページのmeta.next_cursor
+	Limit  int    `form:"limit"`  // 1ページの件数（省略時は20、最大100）
+}
+
+// ListMeta 一覧レスポンスのメタ情報
+type ListMeta struct {
+	Total      int64  `json:"total"` // 絞り込み条件に一致する件数
+	Limit      int    `json:"limit"`
+	HasMore    bool   `json:"has_more"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ListResult 一覧レスポンス（data/meta形式）
+type ListResult[T any] struct {
+	Data []T      `json:"data"`
+	Meta ListMeta `json:"meta"`
+}
+
+// DateRange 日付の範囲（いずれも指定日を含む）
+type DateRange struct {
+	From *time.Time
+	To   *time.Time
+}
+
+// PetFilter ペット一覧の絞り込み条件
+type PetFilter struct {
+	OwnerID *uuid.UUID
+	Species string
+	Status  string
+	Search  string // 名前・種別・品種・ペット番号の部分一致
+}
+
+// ListPetsRequest ペット一覧リクエスト
+type ListPetsRequest struct {
+	ListOptions
+	OwnerID string `form:"owner_id"`
+	Species string `form:"species"`
+	Status  string `form:"status"`
+	Search  string `form:"search"`
+}
+
+// OwnerFilter 飼い主一覧の絞り込み条件
+type OwnerFilter struct {
+	Search string // 氏名・フリガナ・電話番号・メールアドレスの部分一致
+}
+
+// ListOwnersRequest 飼い主一覧リクエスト
+type ListOwnersRequest struct {
+	ListOptions
+	Search string `form:"search"`
+}
+
+// MedicalRecordFilter カルテ一覧の絞り込み条件
+type MedicalRecordFilter struct {
+	PetID     *uuid.UUID
+	OwnerID   *uuid.UUID
+	DoctorID  *uuid.UUID
+	VisitType string
+	Status    string
+	Species   string
+	VisitDate DateRange
+}
+
+// ListMedicalRecordsRequest カルテ一覧リクエスト
+type ListMedicalRecordsRequest struct {
+	ListOptions
+	PetID     string `form:"pet_id"`
+	OwnerID   string `form:"owner_id"`
+	DoctorID  string `form:"doctor_id"`
+	VisitType string `form:"visit_type"`
+	Status    string `form:"status"`
+	Species   string `form:"species"`
+	DateFrom  string `form:"date_from"` // 診察日 YYYY-MM-DD
+	DateTo    string `form:"date_to"`
+}
+
+// ReservationFilter 予約一覧の絞り込み条件
+type ReservationFilter struct {
+	PetID       *uuid.UUID
+	OwnerID     *uuid.UUID
+	DoctorID    *uuid.UUID
+	Status      string
+	ServiceType string
+	StartTime   DateRange
+}
+
+// ListReservationsRequest 予約一覧リクエスト
+type ListReservationsRequest struct {
+	ListOptions
+	PetID       string `form:"pet_id"`
+	OwnerID     string `form:"owner_id"`
+	DoctorID    string `form:"doctor_id"`
+	Status      string `form:"status"`
+	ServiceType string `form:"service_type"`
+	DateFrom    string `form:"date_from"` // 予約日 YYYY-MM-DD
+	DateTo      string `form:"date_to"`
+}
+
+// AccountingFilter 会計一覧の絞り込み条件
+type AccountingFilter struct {
+	PetID         *uuid.UUID
+	OwnerID       *uuid.UUID
+	Status        string
+	ScheduledDate DateRange
+}
+
+// ListAccountingsRequest 会計一覧リクエスト
+type ListAccountingsRequest struct {
+	ListOptions
+	PetID    string `form:"pet_id"`
+	OwnerID  string `form:"owner_id"`
+	Status   string `form:"status"`
+	DateFrom string `form:"date_from"` // 会計日 YYYY-MM-DD
+	DateTo   string `form:"date_to"`
+}
+
+// HospitalizationFilter 入院一覧の絞り込み条件
+type HospitalizationFilter struct {
+	PetID     *uuid.UUID
+	OwnerID   *uuid.UUID
+	Status    string
+	Type      string
+	StartDate DateRange
+}
+
+// ListHospitalizationsRequest 入院一覧リクエスト
+type ListHospitalizationsRequest struct {
+	ListOptions
+	PetID    string `form:"pet_id"`
+	OwnerID  string `form:"owner_id"`
+	Status   string `form:"status"`
+	Type     string `form:"type"`
+	DateFrom string `form:"date_from"` // 入院開始日 YYYY-MM-DD
+	DateTo   string `form:"date_to"`
+}
@@ -4,21 +4,27 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
 )
 
 // MasterItem 診療項目マスタモデル
 type MasterItem struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	Code            string     `json:"code" gorm:"type:varchar(20)"`
-	Name            string     `json:"name" gorm:"type:varchar(200)"`
-	Category        string     `json:"category" gorm:"type:varchar(50)"` // examination, vaccine, medicine, staff, insurance, cage, serviceType, trimming_course, trimming_option
-	Price           *float64   `json:"price" gorm:"type:decimal(10,2)"`
-	Status          string     `json:"status" gorm:"type:varchar(20);default:'active'"` // active, inactive
-	Description     string     `json:"description" gorm:"type:text"`
-	InventoryID     *uuid.UUID `json:"inventory_id" gorm:"type:uuid"`
-	DefaultQuantity *int       `json:"default_quantity"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID                    uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Code                  string           `json:"code" gorm:"type:varchar(20);index"`
+	Name                  string           `json:"name" gorm:"type:varchar(200)"`
+	Category              string           `json:"category" gorm:"type:varchar(50)"`               // examination, vaccine, medicine, staff, insurance, cage, serviceType, trimming_course, trimming_option
+	Price                 *decimal.Decimal `json:"price" gorm:"type:decimal(10,2)"`                // 現在の価格（価格履歴があれば当日時点の価格で上書きして返す）
+	TaxRate               *decimal.Decimal `json:"tax_rate" gorm:"type:decimal(3,2);default:0.10"` // 0.1（標準）, 0.08（軽減：療法食など）
+	IsInsuranceApplicable bool             `json:"is_insurance_applicable" gorm:"default:false"`
+	Status                string           `json:"status" gorm:"type:varchar(20);default:'active'"` // active, inactive
+	Description           string           `json:"description" gorm:"type:text"`
+	InventoryID           *uuid.UUID       `json:"inventory_id" gorm:"type:uuid"`
+	DefaultQuantity       *int             `json:"default_quantity"`
+	VaccineProtocol       *VaccineProtocol `json:"vaccine_protocol" gorm:"type:jsonb"` // category=vaccineのみ
+	DurationMinutes       *int             `json:"duration_minutes"`                   // 所要時間（分） category=trimming_course, trimming_optionのみ
+	CreatedAt             time.Time        `json:"created_at"`
+	UpdatedAt             time.Time        `json:"updated_at"`
 
 	// Relations
 	InventoryItem *InventoryItem `json:"inventory_item,omitempty" gorm:"foreignKey:InventoryID"`
@@ -29,6 +35,133 @@ func (MasterItem) TableName() string {
 	return "master_items"
 }
 
+// マスタ区分
+const (
+	MasterCategoryVaccine        = "vaccine"
+	MasterCategoryTrimmingCourse = "trimming_course"
+	MasterCategoryTrimmingOption = "trimming_option"
+)
+
+// MasterCategories マスタ区分の一覧
+var MasterCategories = []string{
+	"examination", MasterCategoryVaccine, "medicine", "staff", "insurance", "cage", "serviceType", MasterCategoryTrimmingCourse, MasterCategoryTrimmingOption,
+}
+
+// マスタのステータス
+const (
+	MasterStatusActive   = "active"
+	MasterStatusInactive = "inactive"
+)
+
+// MasterItemPrice 診療項目マスタの価格履歴
+// 適用開始日ごとに価格・税率を持ち、会計日時点で有効な価格を会計明細に使う。
+type MasterItemPrice struct {
+	ID            uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	MasterItemID  uuid.UUID        `json:"master_item_id" gorm:"type:uuid;not null;uniqueIndex:idx_master_item_prices_effective"`
+	Price         decimal.Decimal  `json:"price" gorm:"type:decimal(10,2);not null"`
+	TaxRate       *decimal.Decimal `json:"tax_rate" gorm:"type:decimal(3,2)"`
+	EffectiveFrom time.Time        `json:"effective_from" gorm:"type:date;not null;uniqueIndex:idx_master_item_prices_effective"`
+	Source        string           `json:"source" gorm:"type:varchar(20)"` // manual, import
+	CreatedBy     *uuid.UUID       `json:"created_by" gorm:"type:uuid"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (MasterItemPrice) TableName() string {
+	return "master_item_prices"
+}
+
+// 価格履歴の登録元
+const (
+	MasterPriceSourceManual = "manual"
+	MasterPriceSourceImport = "import"
+)
+
+// MasterItemFilter 診療項目マスタ一覧の絞り込み条件
+type MasterItemFilter struct {
+	Category string
+	Status   string
+	Query    string // コード・名称の部分一致
+}
+
+// MasterCategorySummary マスタ区分ごとの件数
+type MasterCategorySummary struct {
+	Category    string `json:"category"`
+	Count       int64  `json:"count"`
+	ActiveCount int64  `json:"active_count"`
+}
+
+// ListMasterItemsRequest 診療項目マスタ一覧リクエスト
+type ListMasterItemsRequest struct {
+	Category string `form:"category"`
+	Status   string `form:"status"` // active, inactive（省略時は全件）
+	Q        string `form:"q"`
+}
+
+// CreateMasterItemRequest 診療項目マスタ登録リクエスト
+type CreateMasterItemRequest struct {
+	Code                  string           `json:"code" binding:"required"`
+	Name                  string           `json:"name" binding:"required"`
+	Category              string           `json:"category" binding:"required"`
+	Price                 *decimal.Decimal `json:"price"`
+	TaxRate               *decimal.Decimal `json:"tax_rate"` // 省略時は0.10
+	IsInsuranceApplicable bool             `json:"is_insurance_applicable"`
+	Description           string           `json:"description"`
+	InventoryID           string           `json:"inventory_id"`
+	DefaultQuantity       *int             `json:"default_quantity"`
+	DurationMinutes       *int             `json:"duration_minutes"`
+}
+
+// UpdateMasterItemRequest 診療項目マスタ更新リクエスト
+// 価格・税率を変更すると当日から適用される価格履歴を記録する。
+type UpdateMasterItemRequest struct {
+	Code                  *string          `json:"code"`
+	Name                  *string          `json:"name"`
+	Category              *string          `json:"category"`
+	Price                 *decimal.Decimal `json:"price"`
+	TaxRate               *decimal.Decimal `json:"tax_rate"`
+	IsInsuranceApplicable *bool            `json:"is_insurance_applicable"`
+	Status                *string          `json:"status"`
+	Description           *string          `json:"description"`
+	InventoryID           *string          `json:"inventory_id"` // 空文字で紐付け解除
+	DefaultQuantity       *int             `json:"default_quantity"`
+	DurationMinutes       *int             `json:"duration_minutes"`
+}
+
+// CreateMasterItemPriceRequest 価格改定登録リクエスト（同じ適用開始日の改定は上書きする）
+type CreateMasterItemPriceRequest struct {
+	Price         *decimal.Decimal `json:"price" binding:"required"`
+	TaxRate       *decimal.Decimal `json:"tax_rate"`                          // 省略時は現在の税率
+	EffectiveFrom string           `json:"effective_from" binding:"required"` // YYYY-MM-DD
+}
+
+// ImportMasterItemsRequest マスタCSV取込リクエスト
+type ImportMasterItemsRequest struct {
+	EffectiveFrom string `form:"effective_from"` // 価格の適用開始日 YYYY-MM-DD（省略時は当日）
+	DryRun        bool   `form:"dry_run"`        // trueなら検証と件数の集計のみ行う
+}
+
+// MasterItemImportResult マスタCSV取込結果
+type MasterItemImportResult struct {
+	EffectiveFrom string `json:"effective_from"`
+	DryRun        bool   `json:"dry_run"`
+	Created       int    `json:"created"`
+	Updated       int    `json:"updated"`
+	PriceChanged  int    `json:"price_changed"`
+	Unchanged     int    `json:"unchanged"`
+}
+
+// ExportMasterItemsRequest マスタCSV出力リクエスト
+type ExportMasterItemsRequest struct {
+	Category string `form:"category"`
+	Date     string `form:"date"` // この日に有効な価格を出力する YYYY-MM-DD（省略時は当日）
+}
+
+// UpdateVaccineProtocolRequest ワクチン接種プロトコル設定リクエスト（nullで解除）
+type UpdateVaccineProtocolRequest struct {
+	VaccineProtocol *VaccineProtocol `json:"vaccine_protocol"`
+}
+
 // InventoryItem 在庫管理モデル
 type InventoryItem struct {
 	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
@@ -38,7 +171,7 @@ type InventoryItem struct {
 	Unit          string     `json:"unit" gorm:"type:varchar(20)"`
 	MinStockLevel int        `json:"min_stock_level" gorm:"default:0"`
 	Location      string     `json:"location" gorm:"type:varchar(100)"`
-	ExpiryDate    *time.Time `json:"expiry_date" gorm:"type:date"`
+	ExpiryDate    *time.Time `json:"expiry_date" gorm:"type:date"` // 在庫のあるロットのうち最も近い使用期限
 	Supplier      string     `json:"supplier" gorm:"type:varchar(200)"`
 	LastRestocked *time.Time `json:"last_restocked" gorm:"type:date"`
 	Status        string     `json:"status" gorm:"type:varchar(20);default:'sufficient'"` // sufficient, low, out_of_stock
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PetMeasurement ペットの体重・バイタルの測定値（ペットごとの時系列）
+// ペットの登録・更新時の体重、入院中のバイタル記録、受付での測定から記録する。
+type PetMeasurement struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID      uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_pet_measurement_series"`
+	Type       string     `json:"type" gorm:"type:varchar(30);not null;index:idx_pet_measurement_series"` // weight, body_condition_score, temperature, heart_rate, respiration_rate
+	Value      float64    `json:"value" gorm:"type:decimal(6,2);not null"`
+	Unit       string     `json:"unit" gorm:"type:varchar(10)"`
+	MeasuredAt time.Time  `json:"measured_at" gorm:"not null;index:idx_pet_measurement_series"`
+	Source     string     `json:"source" gorm:"type:varchar(10);not null"` // pet, vital, manual
+	VitalID    *uuid.UUID `json:"vital_id,omitempty" gorm:"type:uuid;index:idx_pet_measurement_vital"`
+	StaffID    *uuid.UUID `json:"staff_id,omitempty" gorm:"type:uuid"`
+	Notes      string     `json:"notes" gorm:"type:text"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (PetMeasurement) TableName() string {
+	return "pet_measurements"
+}
+
+// 測定項目
+const (
+	MeasurementTypeWeight             = "weight"
+	MeasurementTypeBodyConditionScore = "body_condition_score" // ボディコンディションスコア（9段階）
+	MeasurementTypeTemperature        = "temperature"
+	MeasurementTypeHeartRate          = "heart_rate"
+	MeasurementTypeRespirationRate    = "respiration_rate"
+)
+
+// MeasurementUnits 測定項目ごとの単位
+var MeasurementUnits = map[string]string{
+	MeasurementTypeWeight:             "kg",
+	MeasurementTypeBodyConditionScore: "/9",
+	MeasurementTypeTemperature:        "℃",
+	MeasurementTypeHeartRate:          "回/分",
+	MeasurementTypeRespirationRate:    "回/分",
+}
+
+// 測定値の記録元
+const (
+	MeasurementSourcePet    = "pet"    // ペットの登録・更新
+	MeasurementSourceVital  = "vital"  // 入院中のバイタル記録
+	MeasurementSourceManual = "manual" // 受付・診察での測定
+)
+
+// RecordPetMeasurementRequest 測定値の記録リクエスト（受付・診察での測定）
+// 指定した項目ごとに測定値を記録する。MeasuredAt省略時は現在時刻とする。
+type RecordPetMeasurementRequest struct {
+	MeasuredAt         string   `json:"measured_at"` // RFC3339 または YYYY-MM-DD
+	Weight             *float64 `json:"weight"`
+	BodyConditionScore *float64 `json:"body_condition_score"` // 1〜9（0.5刻み）
+	Temperature        *float64 `json:"temperature"`
+	HeartRate          *int     `json:"heart_rate"`
+	RespirationRate    *int     `json:"respiration_rate"`
+	Notes              string   `json:"notes"`
+}
+
+// ListPetMeasurementsRequest 測定値の推移取得リクエスト
+type ListPetMeasurementsRequest struct {
+	Type         string  `form:"type"`          // 省略時はweight
+	DateFrom     string  `form:"date_from"`     // 測定日 YYYY-MM-DD
+	DateTo       string  `form:"date_to"`       // 測定日 YYYY-MM-DD
+	AlertPercent float64 `form:"alert_percent"` // 前回からの変化率の警告しきい値（%、省略時は10）
+}
+
+// PetMeasurementSeries ペットの測定値の推移（測定日時の古い順）
+// 体重の推移には、同じ日のボディコンディションスコアと、子犬・子猫の成長曲線との比較を含める。
+type PetMeasurementSeries struct {
+	PetID              uuid.UUID             `json:"pet_id"`
+	Type               string                `json:"type"`
+	Unit               string                `json:"unit"`
+	Points             []PetMeasurementPoint `json:"points"`
+	Latest             *PetMeasurementPoint  `json:"latest"`
+	BodyConditionScore *PetMeasurement       `json:"body_condition_score,omitempty"` // 最新のボディコンディションスコア（体重のみ）
+	Alerts             []PetMeasurementAlert `json:"alerts"`
+	GrowthCurve        *PetGrowthCurve       `json:"growth_curve,omitempty"` // 成長期の子犬・子猫の体重のみ
+}
+
+// PetMeasurementPoint 推移の1点
+type PetMeasurementPoint struct {
+	PetMeasurement
+	ChangePercent      *float64           `json:"change_percent"`                 // 前回の測定値からの変化率（%）
+	BodyConditionScore *float64           `json:"body_condition_score,omitempty"` // 同じ日のボディコンディションスコア（体重のみ）
+	Growth             *PetGrowthExpected `json:"growth,omitempty"`               // 週齢の期待体重との比較（体重のみ）
+}
+
+// PetMeasurementAlert 前回の測定値からの変化がしきい値を超えた測定
+type PetMeasurementAlert struct {
+	MeasurementID uuid.UUID `json:"measurement_id"`
+	MeasuredAt    time.Time `json:"measured_at"`
+	PreviousValue float64   `json:"previous_value"`
+	Value         float64   `json:"value"`
+	ChangePercent float64   `json:"change_percent"`
+	Direction     string    `json:"direction"` // increase, decrease
+}
+
+// PetGrowthExpected 週齢の期待体重と比較結果
+type PetGrowthExpected struct {
+	AgeWeeks float64 `json:"age_weeks"`
+	Low      float64 `json:"low"`
+	Expected float64 `json:"expected"`
+	High     float64 `json:"high"`
+	Status   string  `json:"status"` // below, within, above
+}
+
+// PetGrowthCurve 品種の体格区分の成長曲線（描画用）
+type PetGrowthCurve struct {
+	SizeClass   string                `json:"size_class"` // toy, small, medium, large, giant, cat, large_cat
+	AdultWeight float64               `json:"adult_weight"`
+	MatureWeeks int                   `json:"mature_weeks"`
+	Curve       []PetGrowthCurvePoint `json:"curve"`
+}
+
+// PetGrowthCurvePoint 成長曲線の1点（週齢の期待体重と許容範囲）
+type PetGrowthCurvePoint struct {
+	AgeWeeks int     `json:"age_weeks"`
+	Low      float64 `json:"low"`
+	Expected float64 `json:"expected"`
+	High     float64 `json:"high"`
+}
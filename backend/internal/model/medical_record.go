@@ -4,6 +4,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/fulltext"
 )
 
 // MedicalRecord 電子カルテモデル
@@ -26,25 +28,99 @@ type MedicalRecord struct {
 	Prescription   string     `json:"prescription" gorm:"type:text"`
 	Notes          string     `json:"notes" gorm:"type:text"`
 	Status         string     `json:"status" gorm:"type:varchar(10);default:'作成中'"` // 作成中, 確定済
+	FinalizedAt    *time.Time `json:"finalized_at"`
 	CreatedAt      time.Time  `json:"created_at"`
 	UpdatedAt      time.Time  `json:"updated_at"`
 
+	// 全文検索用（SOAP・診断・処方のバイグラム。UpdateSearchKeysで更新し、読み出さない）
+	SearchBigrams string `json:"-" gorm:"type:tsvector;index:idx_mr_search_bigrams,type:gin;->:false;<-"`
+
 	// Relations
 	Pet   *Pet   `json:"pet,omitempty" gorm:"foreignKey:PetID"`
 	Owner *Owner `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
 }
 
-// PaginatedMedicalRecords ページングされたカルテ一覧レスポンス
-type PaginatedMedicalRecords struct {
-	Records     []MedicalRecord `json:"records"`
-	CurrentPage int             `json:"current_page"`
-	PerPage     int             `json:"per_page"`
-	Total       int64           `json:"total"`
-	TotalPages  int             `json:"total_pages"`
-	HasNext     bool            `json:"has_next"`
-	HasPrev     bool            `json:"has_prev"`
+// SearchFields 全文検索の対象項目（項目名と内容）
+func (m *MedicalRecord) SearchFields() []SearchField {
+	return []SearchField{
+		{Name: "subjective", Text: m.Subjective},
+		{Name: "objective", Text: m.Objective},
+		{Name: "assessment", Text: m.Assessment},
+		{Name: "plan", Text: m.Plan},
+		{Name: "diagnosis", Text: m.Diagnosis},
+		{Name: "prescription", Text: m.Prescription},
+	}
+}
+
+// UpdateSearchKeys 全文検索用のバイグラムを検索対象の項目から更新する
+func (m *MedicalRecord) UpdateSearchKeys() {
+	fields := m.SearchFields()
+	texts := make([]string, len(fields))
+	for i, f := range fields {
+		texts[i] = f.Text
+	}
+	m.SearchBigrams = fulltext.Vector(texts...)
+}
+
+// SearchField 全文検索の対象項目
+type SearchField struct {
+	Name string
+	Text string
+}
+
+// SearchMedicalRecordsRequest カルテ全文検索リクエスト
+type SearchMedicalRecordsRequest struct {
+	ListOptions
+	Q        string `form:"q"`       // 空白区切りの検索語（すべてを含むカルテに一致）
+	Species  string `form:"species"` // ペットの種別
+	DateFrom string `form:"date_from"`
+	DateTo   string `form:"date_to"`
+}
+
+// MedicalRecordSearchHit カルテ全文検索の結果
+type MedicalRecordSearchHit struct {
+	MedicalRecord
+	Snippets []MedicalRecordSnippet `json:"snippets"`
+}
+
+// MedicalRecordSnippet 検索語の一致箇所の抜粋（一致箇所は<mark>で囲み、ほかはHTMLエスケープ済み）
+type MedicalRecordSnippet struct {
+	Field string `json:"field"` // subjective, objective, assessment, plan, diagnosis, prescription
+	Text  string `json:"text"`
+}
+
+// MedicalRecordItem カルテ明細モデル
+// 診療で実施した検査・処置・処方などをマスタ項目として記録し、会計作成時の元データとする。
+type MedicalRecordItem struct {
+	ID              uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	MedicalRecordID uuid.UUID `json:"medical_record_id" gorm:"type:uuid;not null;index:idx_mri_medical_record_id"`
+	MasterItemID    uuid.UUID `json:"master_item_id" gorm:"type:uuid;not null"`
+	Quantity        int       `json:"quantity" gorm:"default:1"`
+	Notes           string    `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time `json:"created_at"`
+
+	// Relations
+	MasterItem *MasterItem `json:"master_item,omitempty" gorm:"foreignKey:MasterItemID"`
+}
+
+// TableName テーブル名を指定
+func (MedicalRecordItem) TableName() string {
+	return "medical_record_items"
+}
+
+// AddMedicalRecordItemRequest カルテ明細追加リクエスト
+type AddMedicalRecordItemRequest struct {
+	MasterItemID string `json:"master_item_id" binding:"required"`
+	Quantity     int    `json:"quantity"` // 省略時はマスタの既定数量または1
+	Notes        string `json:"notes"`
 }
 
+// カルテステータス
+const (
+	MedicalRecordStatusDraft     = "作成中"
+	MedicalRecordStatusFinalized = "確定済"
+)
+
 // TableName テーブル名を指定
 func (MedicalRecord) TableName() string {
 	return "medical_records"
@@ -89,3 +165,37 @@ type UpdateMedicalRecordRequest struct {
 	Notes          *string `json:"notes"`
 	Status         *string `json:"status"`
 }
+
+// MedicalRecordAmendment 確定済カルテの修正履歴モデル
+// 確定後の変更は直接上書きせず、項目ごとに変更前後の値と理由を記録する。
+type MedicalRecordAmendment struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	MedicalRecordID uuid.UUID  `json:"medical_record_id" gorm:"type:uuid;not null;index:idx_mra_medical_record_id"`
+	Field           string     `json:"field" gorm:"type:varchar(50);not null"`
+	OldValue        string     `json:"old_value" gorm:"type:text"`
+	NewValue        string     `json:"new_value" gorm:"type:text"`
+	Reason          string     `json:"reason" gorm:"type:text;not null"`
+	AmendedBy       *uuid.UUID `json:"amended_by" gorm:"type:uuid"`
+	AmendedAt       time.Time  `json:"amended_at"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (MedicalRecordAmendment) TableName() string {
+	return "medical_record_amendments"
+}
+
+// AmendMedicalRecordRequest 確定済カルテの修正リクエスト
+type AmendMedicalRecordRequest struct {
+	Reason         string  `json:"reason" binding:"required"`
+	ChiefComplaint *string `json:"chief_complaint"`
+	Subjective     *string `json:"subjective"`
+	Objective      *string `json:"objective"`
+	Assessment     *string `json:"assessment"`
+	Plan           *string `json:"plan"`
+	SurgeryNotes   *string `json:"surgery_notes"`
+	Diagnosis      *string `json:"diagnosis"`
+	Treatment      *string `json:"treatment"`
+	Prescription   *string `json:"prescription"`
+	Notes          *string `json:"notes"`
+}
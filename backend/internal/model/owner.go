@@ -1,9 +1,12 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/textnorm"
 )
 
 // Owner 飼い主モデル
@@ -19,10 +22,30 @@ type Owner struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// 検索用（氏名・フリガナ・電話番号の表記ゆれをそろえた値。UpdateSearchKeysで更新）
+	SearchText  string `json:"-" gorm:"type:text;not null;default:''"`
+	PhoneDigits string `json:"-" gorm:"type:varchar(20);not null;default:'';index:idx_owners_phone_key"`
+
+	// 重複候補の照合用（項目ごとに表記ゆれをそろえた値。UpdateSearchKeysで更新）
+	NameKey     string `json:"-" gorm:"type:varchar(100);not null;default:'';index:idx_owners_name_key"`
+	NameKanaKey string `json:"-" gorm:"type:varchar(100);not null;default:'';index:idx_owners_name_kana_key"`
+	EmailKey    string `json:"-" gorm:"type:varchar(255);not null;default:'';index:idx_owners_email_key"`
+	AddressKey  string `json:"-" gorm:"type:text;not null;default:'';index:idx_owners_address_key"`
+
 	// Relations
 	Pets []Pet `json:"pets,omitempty" gorm:"foreignKey:OwnerID"`
 }
 
+// UpdateSearchKeys 検索用・照合用の値を氏名・フリガナ・電話番号・メールアドレス・住所から更新する
+func (o *Owner) UpdateSearchKeys() {
+	o.NameKey = textnorm.Fold(o.Name)
+	o.NameKanaKey = textnorm.Fold(o.NameKana)
+	o.SearchText = o.NameKey + "\n" + o.NameKanaKey
+	o.PhoneDigits = textnorm.Digits(o.Phone)
+	o.EmailKey = strings.ToLower(strings.TrimSpace(o.Email))
+	o.AddressKey = textnorm.Address(o.Address)
+}
+
 // TableName テーブル名を指定
 func (Owner) TableName() string {
 	return "owners"
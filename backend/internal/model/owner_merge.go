@@ -0,0 +1,119 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OwnerMerge 飼い主の統合記録
+// 重複登録された飼い主（統合元）のペット・カルテ・予約・会計などを統合先へ移し、統合元を削除する。
+// 統合元は削除されるため、統合時点の登録内容をこの記録に残す。
+type OwnerMerge struct {
+	ID                uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TargetOwnerID     uuid.UUID        `json:"target_owner_id" gorm:"type:uuid;not null;index:idx_owner_merge_target"`
+	SourceOwnerID     uuid.UUID        `json:"source_owner_id" gorm:"type:uuid;not null;index:idx_owner_merge_source"`
+	SourceOwnerNumber int              `json:"source_owner_number" gorm:"type:integer;not null"`
+	SourceName        string           `json:"source_name" gorm:"type:varchar(100);not null"`
+	SourceNameKana    string           `json:"source_name_kana" gorm:"type:varchar(100)"`
+	SourcePhone       string           `json:"source_phone" gorm:"type:varchar(20)"`
+	SourceEmail       string           `json:"source_email" gorm:"type:varchar(255)"`
+	SourceAddress     string           `json:"source_address" gorm:"type:text"`
+	SourceNotes       string           `json:"source_notes" gorm:"type:text"`
+	MovedRows         OwnerMergeCounts `json:"moved_rows" gorm:"type:jsonb"` // テーブルごとの移した件数
+	Reason            string           `json:"reason" gorm:"type:text"`
+	MergedBy          *uuid.UUID       `json:"merged_by,omitempty" gorm:"type:uuid"`
+	CreatedAt         time.Time        `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (OwnerMerge) TableName() string {
+	return "owner_merges"
+}
+
+// OwnerMergeCounts テーブル名をキーとした移した件数（jsonbとして保存）
+type OwnerMergeCounts map[string]int64
+
+// Value driver.Valuerの実装
+func (c OwnerMergeCounts) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (c *OwnerMergeCounts) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	case nil:
+		*c = nil
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for OwnerMergeCounts: %T", value)
+	}
+	return json.Unmarshal(b, c)
+}
+
+// MergeOwnerRequest 飼い主統合リクエスト
+type MergeOwnerRequest struct {
+	SourceOwnerID string `json:"source_owner_id" binding:"required"` // 統合元（削除される飼い主）のID
+	Reason        string `json:"reason"`
+}
+
+// OwnerMergeResult 飼い主統合の結果
+type OwnerMergeResult struct {
+	Owner Owner      `json:"owner"` // 統合後の飼い主（ペット付き）
+	Merge OwnerMerge `json:"merge"`
+}
+
+// 重複候補の判定に使った項目
+const (
+	OwnerMatchName     = "name"
+	OwnerMatchNameKana = "name_kana"
+	OwnerMatchPhone    = "phone"
+	OwnerMatchEmail    = "email"
+	OwnerMatchAddress  = "address"
+)
+
+// OwnerMatchPoints 一致した項目ごとの点数（合計100）
+// 電話番号と住所が同じ家族の別名義での登録も候補になるよう、電話番号+住所で既定の最低点に届くようにしている。
+var OwnerMatchPoints = map[string]int{
+	OwnerMatchName:     30,
+	OwnerMatchPhone:    30,
+	OwnerMatchNameKana: 15,
+	OwnerMatchEmail:    15,
+	OwnerMatchAddress:  10,
+}
+
+// FindOwnerDuplicatesRequest 重複候補検索リクエスト
+type FindOwnerDuplicatesRequest struct {
+	OwnerID  string `form:"owner_id"`  // 指定した飼い主の重複候補に限る
+	MinScore int    `form:"min_score"` // 最低点（省略時は40）
+	Limit    int    `form:"limit"`     // 最大件数（省略時は50、最大200）
+}
+
+// OwnerPair 重複の可能性がある飼い主の組（Ownerが先に登録された方）
+type OwnerPair struct {
+	Owner     Owner
+	Duplicate Owner
+}
+
+// OwnerDuplicate 重複候補（一致した項目と点数）
+type OwnerDuplicate struct {
+	Owner     Owner    `json:"owner"`     // 先に登録された飼い主（統合先の候補）
+	Duplicate Owner    `json:"duplicate"` // 後に登録された飼い主
+	Score     int      `json:"score"`     // 0〜100
+	Matches   []string `json:"matches"`   // name, name_kana, phone, email, address
+}
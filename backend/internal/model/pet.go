@@ -1,9 +1,12 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/textnorm"
 )
 
 // Pet ペットモデル
@@ -27,16 +30,44 @@ type Pet struct {
 	CreatedAt        time.Time  `json:"created_at"`
 	UpdatedAt        time.Time  `json:"updated_at"`
 
+	// 検索用（名前・ペット番号・マイクロチップ番号の表記ゆれをそろえた値。UpdateSearchKeysで更新）
+	SearchText string `json:"-" gorm:"type:text;not null;default:''"`
+
 	// Relations
 	Owner          *Owner          `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
 	MedicalRecords []MedicalRecord `json:"medical_records,omitempty" gorm:"foreignKey:PetID"`
 }
 
+// UpdateSearchKeys 検索用の値を名前・ペット番号・マイクロチップ番号から更新する
+func (p *Pet) UpdateSearchKeys() {
+	p.SearchText = textnorm.Fold(p.Name) + "\n" + textnorm.Code(p.PetNumber) + "\n" + textnorm.Code(p.MicrochipID)
+}
+
 // TableName テーブル名を指定
 func (Pet) TableName() string {
 	return "pets"
 }
 
+// 動物種別（SpeciesKindの戻り値）
+const (
+	SpeciesDog = "dog"
+	SpeciesCat = "cat"
+)
+
+// SpeciesKind 種類の表記ゆれ（犬・イヌ・dog など）を動物種別にそろえる
+// 犬・猫以外は前後の空白を除いて小文字にした表記をそのまま返す。
+func SpeciesKind(species string) string {
+	s := strings.ToLower(strings.TrimSpace(species))
+	switch {
+	case strings.Contains(s, "犬") || strings.Contains(s, "イヌ") || strings.Contains(s, "いぬ") || strings.Contains(s, "dog"):
+		return SpeciesDog
+	case strings.Contains(s, "猫") || strings.Contains(s, "ネコ") || strings.Contains(s, "ねこ") || strings.Contains(s, "cat"):
+		return SpeciesCat
+	default:
+		return s
+	}
+}
+
 // CreatePetRequest ペット作成リクエスト
 type CreatePetRequest struct {
 	OwnerID          string  `json:"owner_id" binding:"required"`
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PetOwnership ペットの飼い主の履歴
+// 譲渡のたびに前の飼い主の期間を終了し、新しい飼い主の期間を追加する。EndedAtがnilの行が現在の飼い主。
+type PetOwnership struct {
+	ID            uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID         uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_pet_ownership_pet"`
+	OwnerID       uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null;index:idx_pet_ownership_owner"`
+	StartedAt     time.Time  `json:"started_at" gorm:"type:date;not null"`
+	EndedAt       *time.Time `json:"ended_at" gorm:"type:date"` // 譲渡日（この日から次の飼い主）
+	Reason        string     `json:"reason" gorm:"type:text"`   // 飼い主になった理由（譲渡時のみ）
+	TransferredBy *uuid.UUID `json:"transferred_by,omitempty" gorm:"type:uuid"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+
+	// Relations
+	Owner *Owner `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
+}
+
+// TableName テーブル名を指定
+func (PetOwnership) TableName() string {
+	return "pet_ownerships"
+}
+
+// 譲渡時点で未精算の会計を支払う飼い主
+const (
+	OpenAccountingPayerPreviousOwner = "previous_owner" // 前の飼い主のまま（既定）
+	OpenAccountingPayerNewOwner      = "new_owner"      // 新しい飼い主へ付け替える
+)
+
+// TransferPetRequest ペット譲渡リクエスト
+type TransferPetRequest struct {
+	OwnerID             string `json:"owner_id" binding:"required"` // 新しい飼い主のID
+	TransferDate        string `json:"transfer_date"`               // 譲渡日 YYYY-MM-DD（省略時は当日）
+	OpenAccountingPayer string `json:"open_accounting_payer"`       // previous_owner, new_owner
+	Reason              string `json:"reason"`
+}
+
+// PetTransferResult ペット譲渡の結果
+type PetTransferResult struct {
+	Pet                 Pet          `json:"pet"`
+	Ownership           PetOwnership `json:"ownership"`          // 新しい飼い主の期間
+	MovedAccountings    int64        `json:"moved_accountings"`  // 新しい飼い主へ付け替えた未精算の会計
+	MovedReservations   int64        `json:"moved_reservations"` // 新しい飼い主へ付け替えた譲渡日以降の予約
+	MovedVaccinations   int64        `json:"moved_vaccinations"` // 新しい飼い主へ付け替えた次回接種予定日が譲渡日以降の接種記録
+	MovedTrimmings      int64        `json:"moved_trimmings"`    // 新しい飼い主へ付け替えた譲渡日以降の予約中のトリミング
+	OpenAccountingPayer string       `json:"open_accounting_payer"`
+}
@@ -8,19 +8,20 @@ import (
 
 // Reservation 予約モデル
 type Reservation struct {
-	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	PetID        uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_res_pet_id"`
-	OwnerID      uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
-	DoctorID     *uuid.UUID `json:"doctor_id" gorm:"type:uuid;index:idx_res_doctor_id"`
-	StartTime    time.Time  `json:"start_time" gorm:"index:idx_res_start_time"`
-	EndTime      time.Time  `json:"end_time"`
-	VisitType    string     `json:"visit_type" gorm:"type:varchar(20)"`   // first, revisit
-	ServiceType  string     `json:"service_type" gorm:"type:varchar(30)"` // 診療, 検診, 手術, etc.
-	IsDesignated bool       `json:"is_designated" gorm:"default:false"`
-	Status       string     `json:"status" gorm:"type:varchar(30);default:'pending'"` // pending, confirmed, checked_in, in_consultation, accounting, completed, canceled
-	Notes        string     `json:"notes" gorm:"type:text"`
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID           uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null;index:idx_res_pet_id"`
+	OwnerID         uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
+	DoctorID        *uuid.UUID `json:"doctor_id" gorm:"type:uuid;index:idx_res_doctor_id"`
+	StartTime       time.Time  `json:"start_time" gorm:"index:idx_res_start_time"`
+	EndTime         time.Time  `json:"end_time"`
+	VisitType       string     `json:"visit_type" gorm:"type:varchar(20)"`   // first, revisit
+	ServiceType     string     `json:"service_type" gorm:"type:varchar(30)"` // 診療, 検診, 手術, etc.
+	IsDesignated    bool       `json:"is_designated" gorm:"default:false"`
+	Status          string     `json:"status" gorm:"type:varchar(30);default:'pending'"` // pending, confirmed, checked_in, in_consultation, accounting, completed, canceled
+	Notes           string     `json:"notes" gorm:"type:text"`
+	MedicalRecordID *uuid.UUID `json:"medical_record_id" gorm:"type:uuid"` // 受付時に作成されたカルテ
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 
 	// Relations
 	Pet   *Pet   `json:"pet,omitempty" gorm:"foreignKey:PetID"`
@@ -31,3 +32,48 @@ type Reservation struct {
 func (Reservation) TableName() string {
 	return "reservations"
 }
+
+// 予約ステータス
+const (
+	ReservationStatusPending        = "pending"
+	ReservationStatusConfirmed      = "confirmed"
+	ReservationStatusCheckedIn      = "checked_in"
+	ReservationStatusInConsultation = "in_consultation"
+	ReservationStatusAccounting     = "accounting"
+	ReservationStatusCompleted      = "completed"
+	ReservationStatusCanceled       = "canceled"
+)
+
+// CreateReservationRequest 予約作成リクエスト
+type CreateReservationRequest struct {
+	PetID        string `json:"pet_id" binding:"required"`
+	OwnerID      string `json:"owner_id" binding:"required"`
+	DoctorID     string `json:"doctor_id"`
+	StartTime    string `json:"start_time" binding:"required"`
+	EndTime      string `json:"end_time" binding:"required"`
+	VisitType    string `json:"visit_type"`
+	ServiceType  string `json:"service_type"`
+	IsDesignated bool   `json:"is_designated"`
+	Status       string `json:"status"`
+	Notes        string `json:"notes"`
+}
+
+// UpdateReservationRequest 予約更新リクエスト
+type UpdateReservationRequest struct {
+	PetID        *string `json:"pet_id"`
+	OwnerID      *string `json:"owner_id"`
+	DoctorID     *string `json:"doctor_id"`
+	StartTime    *string `json:"start_time"`
+	EndTime      *string `json:"end_time"`
+	VisitType    *string `json:"visit_type"`
+	ServiceType  *string `json:"service_type"`
+	IsDesignated *bool   `json:"is_designated"`
+	Status       *string `json:"status"`
+	Notes        *string `json:"notes"`
+}
+
+// ReservationCheckInResult 受付処理の結果
+type ReservationCheckInResult struct {
+	Reservation   *Reservation   `json:"reservation"`
+	MedicalRecord *MedicalRecord `json:"medical_record"`
+}
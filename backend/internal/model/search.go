@@ -0,0 +1,43 @@
+package model
+
+// 患者検索の結果の種類
+const (
+	SearchHitOwner = "owner"
+	SearchHitPet   = "pet"
+)
+
+// 一致の度合いごとの点数（PatientSearchHit.Score）
+const (
+	SearchScoreExactCode     = 100 // 飼い主番号・ペット番号・マイクロチップ番号の完全一致
+	SearchScoreExactPhone    = 95
+	SearchScoreExactName     = 90
+	SearchScorePrefixName    = 75
+	SearchScoreSuffixPhone   = 70 // 電話番号の下4桁など
+	SearchScorePrefixCode    = 65
+	SearchScoreContainsName  = 55
+	SearchScoreContainsPhone = 50
+	SearchScoreContainsCode  = 45
+)
+
+// PatientSearchRequest 患者検索リクエスト（飼い主・ペットの横断検索）
+type PatientSearchRequest struct {
+	Q     string `form:"q"`
+	Limit int    `form:"limit"` // 省略時は20、最大50
+}
+
+// PatientSearchQuery 表記ゆれをそろえた検索条件
+type PatientSearchQuery struct {
+	Text        string // 氏名・フリガナ・ペットの名前（textnorm.Fold）
+	Code        string // ペット番号・マイクロチップ番号（textnorm.Code）
+	Digits      string // 電話番号の一部（数字のみ。数字だけの入力のとき）
+	OwnerNumber *int   // 飼い主番号（数字だけの入力のとき）
+}
+
+// PatientSearchHit 患者検索の結果（飼い主またはペット）
+type PatientSearchHit struct {
+	Type         string `json:"type"`          // owner, pet
+	Score        int    `json:"score"`         // 一致の度合い（大きいほど上位）
+	MatchedField string `json:"matched_field"` // name, name_kana, phone, owner_number, pet_name, pet_number, microchip_id
+	Owner        *Owner `json:"owner,omitempty"`
+	Pet          *Pet   `json:"pet,omitempty"` // 飼い主はPet.Ownerに含む
+}
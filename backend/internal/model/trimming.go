@@ -1,33 +1,176 @@
 package model
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
 )
 
 // Trimming トリミング記録モデル
+// コース・オプションはマスタ（trimming_course, trimming_option）の予約日時点の名称・価格・所要時間を写して持つ。
 type Trimming struct {
-	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	PetID           uuid.UUID  `json:"pet_id" gorm:"type:uuid;not null"`
-	OwnerID         uuid.UUID  `json:"owner_id" gorm:"type:uuid;not null"`
-	StaffID         *uuid.UUID `json:"staff_id" gorm:"type:uuid"`
-	AppointmentDate time.Time  `json:"appointment_date"`
-	Course          string     `json:"course" gorm:"type:varchar(100)"`
-	Options         string     `json:"options" gorm:"type:json"` // JSON array
-	StyleRequest    string     `json:"style_request" gorm:"type:text"`
-	Status          string     `json:"status" gorm:"type:varchar(20);default:'予約'"` // 予約, 進行中, 完了
-	TotalPrice      *float64   `json:"total_price" gorm:"type:decimal(10,2)"`
-	Notes           string     `json:"notes" gorm:"type:text"`
-	CreatedAt       time.Time  `json:"created_at"`
-	UpdatedAt       time.Time  `json:"updated_at"`
+	ID              uuid.UUID        `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PetID           uuid.UUID        `json:"pet_id" gorm:"type:uuid;not null"`
+	OwnerID         uuid.UUID        `json:"owner_id" gorm:"type:uuid;not null"`
+	StaffID         *uuid.UUID       `json:"staff_id" gorm:"type:uuid;index:idx_trimming_staff_time"` // 担当トリマー
+	AppointmentDate time.Time        `json:"appointment_date" gorm:"index:idx_trimming_staff_time"`   // 開始日時
+	DurationMinutes int              `json:"duration_minutes" gorm:"not null;default:0"`              // コースとオプションの所要時間の合計
+	CourseID        *uuid.UUID       `json:"course_id" gorm:"type:uuid"`
+	Course          string           `json:"course" gorm:"type:varchar(100)"`
+	CoursePrice     *decimal.Decimal `json:"course_price" gorm:"type:decimal(10,2)"`
+	Options         TrimmingOptions  `json:"options" gorm:"type:json"`
+	StyleRequest    string           `json:"style_request" gorm:"type:text"`
+	Status          string           `json:"status" gorm:"type:varchar(20);default:'予約'"` // 予約, 進行中, 完了, キャンセル
+	TotalPrice      *decimal.Decimal `json:"total_price" gorm:"type:decimal(10,2)"`       // コースとオプションの価格の合計（税抜）
+	StartedAt       *time.Time       `json:"started_at"`
+	CompletedAt     *time.Time       `json:"completed_at"`
+	Notes           string           `json:"notes" gorm:"type:text"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
 
 	// Relations
 	Pet   *Pet   `json:"pet,omitempty" gorm:"foreignKey:PetID"`
 	Owner *Owner `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
+	Staff *Staff `json:"staff,omitempty" gorm:"foreignKey:StaffID"`
 }
 
 // TableName テーブル名を指定
 func (Trimming) TableName() string {
 	return "trimmings"
 }
+
+// EndTime 終了予定日時（開始日時＋所要時間）
+func (t *Trimming) EndTime() time.Time {
+	return t.AppointmentDate.Add(time.Duration(t.DurationMinutes) * time.Minute)
+}
+
+// トリミングのステータス
+const (
+	TrimmingStatusBooked     = "予約"
+	TrimmingStatusInProgress = "進行中"
+	TrimmingStatusCompleted  = "完了"
+	TrimmingStatusCanceled   = "キャンセル"
+)
+
+// TrimmingOption トリミングのオプション（マスタの名称・価格・所要時間を写したもの）
+type TrimmingOption struct {
+	MasterID        uuid.UUID       `json:"master_id"`
+	Code            string          `json:"code"`
+	Name            string          `json:"name"`
+	Price           decimal.Decimal `json:"price"`
+	DurationMinutes int             `json:"duration_minutes"`
+}
+
+// TrimmingOptions オプションの一覧（jsonとして保存）
+type TrimmingOptions []TrimmingOption
+
+// Value driver.Valuerの実装
+func (o TrimmingOptions) Value() (driver.Value, error) {
+	if o == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(o)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan sql.Scannerの実装
+func (o *TrimmingOptions) Scan(value any) error {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		*o = nil
+		return nil
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for TrimmingOptions: %T", value)
+	}
+	return json.Unmarshal(b, o)
+}
+
+// TrimmingFilter トリミング一覧の絞り込み条件
+type TrimmingFilter struct {
+	PetID           *uuid.UUID
+	OwnerID         *uuid.UUID
+	StaffID         *uuid.UUID
+	Status          string
+	AppointmentDate DateRange
+}
+
+// ListTrimmingsRequest トリミング一覧リクエスト
+type ListTrimmingsRequest struct {
+	ListOptions
+	PetID    string `form:"pet_id"`
+	OwnerID  string `form:"owner_id"`
+	StaffID  string `form:"staff_id"`
+	Status   string `form:"status"`
+	DateFrom string `form:"date_from"` // 予約日 YYYY-MM-DD
+	DateTo   string `form:"date_to"`
+}
+
+// CreateTrimmingRequest トリミング予約リクエスト
+type CreateTrimmingRequest struct {
+	PetID           string   `json:"pet_id" binding:"required"`
+	StaffID         string   `json:"staff_id"`                            // 担当トリマー（省略時は未割当）
+	AppointmentDate string   `json:"appointment_date" binding:"required"` // 開始日時 RFC3339
+	CourseID        string   `json:"course_id" binding:"required"`        // コースのマスタID
+	OptionIDs       []string `json:"option_ids"`                          // オプションのマスタID
+	StyleRequest    string   `json:"style_request"`
+	Notes           string   `json:"notes"`
+}
+
+// UpdateTrimmingRequest トリミング予約変更リクエスト（予約のみ変更できる）
+// コース・オプション・開始日時を変更すると、価格と所要時間を計算し直す。
+type UpdateTrimmingRequest struct {
+	StaffID         *string   `json:"staff_id"` // 空文字で未割当
+	AppointmentDate *string   `json:"appointment_date"`
+	CourseID        *string   `json:"course_id"`
+	OptionIDs       *[]string `json:"option_ids"`
+	StyleRequest    *string   `json:"style_request"`
+	Notes           *string   `json:"notes"`
+}
+
+// CompleteTrimmingRequest トリミング完了リクエスト
+// accounting_idを指定すると、同じペット・飼い主の未精算の会計にコース・オプションの明細を追加する。
+type CompleteTrimmingRequest struct {
+	CreateAccounting bool   `json:"create_accounting"` // コース・オプションの会計を新規に作成する
+	AccountingID     string `json:"accounting_id"`     // 明細を追加する未収・保留の会計
+}
+
+// TrimmingCompletionResult トリミング完了の結果
+type TrimmingCompletionResult struct {
+	Trimming   *Trimming   `json:"trimming"`
+	Accounting *Accounting `json:"accounting,omitempty"`
+}
+
+// TrimmingBoardRequest トリミングボードリクエスト
+type TrimmingBoardRequest struct {
+	Date string `form:"date"` // YYYY-MM-DD（省略時は当日）
+}
+
+// TrimmingBoard 1日のトリミングボード（トリマーごとの予約と空き時間）
+type TrimmingBoard struct {
+	Date       string                `json:"date"`
+	OpenTime   string                `json:"open_time"`  // 受付時間（HH:MM）
+	CloseTime  string                `json:"close_time"` // 受付終了（HH:MM）
+	Groomers   []TrimmingBoardColumn `json:"groomers"`
+	Unassigned []Trimming            `json:"unassigned"` // 担当トリマー未割当の予約
+}
+
+// TrimmingBoardColumn トリマー1人分の予約と空き時間
+type TrimmingBoardColumn struct {
+	Staff            Staff      `json:"staff"`
+	Trimmings        []Trimming `json:"trimmings"`
+	BookedMinutes    int        `json:"booked_minutes"`
+	AvailableMinutes int        `json:"available_minutes"`
+}
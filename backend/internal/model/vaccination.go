@@ -16,11 +16,14 @@ type Vaccination struct {
 	VaccineName     string     `json:"vaccine_name" gorm:"type:varchar(100)"`
 	VaccinationDate time.Time  `json:"vaccination_date" gorm:"type:date"`
 	NextDate        *time.Time `json:"next_date" gorm:"type:date;index:idx_vac_next_date"`
-	LotNumber       string     `json:"lot_number" gorm:"type:varchar(50)"`
+	LotNumber       string     `json:"lot_number" gorm:"type:varchar(50);index:idx_vac_lot_number"`
 	Notes           string     `json:"notes" gorm:"type:text"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
 
+	// 接種時の注意（対象外の動物種への接種など。保存しない）
+	Warnings []string `json:"warnings,omitempty" gorm:"-"`
+
 	// Relations
 	Pet   *Pet   `json:"pet,omitempty" gorm:"foreignKey:PetID"`
 	Owner *Owner `json:"owner,omitempty" gorm:"foreignKey:OwnerID"`
@@ -30,3 +33,83 @@ type Vaccination struct {
 func (Vaccination) TableName() string {
 	return "vaccinations"
 }
+
+// CreateVaccinationRequest ワクチン接種記録作成リクエスト
+type CreateVaccinationRequest struct {
+	PetID           string `json:"pet_id" binding:"required"`
+	DoctorID        string `json:"doctor_id"`
+	VaccineMasterID string `json:"vaccine_master_id"`
+	VaccineName     string `json:"vaccine_name"`                        // 狂犬病, 混合ワクチン など（vaccine_master_id指定時は省略可）
+	VaccinationDate string `json:"vaccination_date" binding:"required"` // YYYY-MM-DD
+	NextDate        string `json:"next_date"`                           // YYYY-MM-DD（省略時はワクチンマスタの接種プロトコルから算出）
+	LotNumber       string `json:"lot_number"`
+	LotID           string `json:"lot_id"` // 在庫ロット（指定時はロット番号をロットから補完）
+	Notes           string `json:"notes"`
+}
+
+// UpdateVaccinationRequest ワクチン接種記録更新リクエスト
+type UpdateVaccinationRequest struct {
+	DoctorID        *string `json:"doctor_id"`
+	VaccineName     *string `json:"vaccine_name"`
+	VaccinationDate *string `json:"vaccination_date"`
+	NextDate        *string `json:"next_date"` // 空文字で次回予定を解除
+	LotNumber       *string `json:"lot_number"`
+	Notes           *string `json:"notes"`
+}
+
+// ListVaccinationsRequest ワクチン接種記録一覧リクエスト
+type ListVaccinationsRequest struct {
+	ListOptions
+	PetID     string `form:"pet_id"`
+	OwnerID   string `form:"owner_id"`
+	DoctorID  string `form:"doctor_id"`
+	LotNumber string `form:"lot_number"` // 回収対象ロットの接種歴の追跡
+	DateFrom  string `form:"date_from"`  // 接種日 YYYY-MM-DD
+	DateTo    string `form:"date_to"`
+}
+
+// VaccinationFilter ワクチン接種記録の検索条件
+type VaccinationFilter struct {
+	PetID           *uuid.UUID
+	OwnerID         *uuid.UUID
+	DoctorID        *uuid.UUID
+	LotNumber       string
+	VaccinationDate DateRange
+}
+
+// DueVaccinationsRequest 接種予定一覧リクエスト
+type DueVaccinationsRequest struct {
+	Within         string `form:"within"`          // 30d, 4w, 30（日数、省略時は30日）
+	IncludeOverdue bool   `form:"include_overdue"` // 予定日を過ぎた未接種も含める
+}
+
+// VaccinationReminder ワクチン接種案内の送付記録
+// 同じ接種予定に同じ経路で二重に案内しないよう、接種記録と経路の組み合わせで一意とする。
+type VaccinationReminder struct {
+	ID            uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	VaccinationID uuid.UUID `json:"vaccination_id" gorm:"type:uuid;not null;uniqueIndex:idx_vac_reminder_channel"`
+	OwnerID       uuid.UUID `json:"owner_id" gorm:"type:uuid;not null;index:idx_vac_reminder_owner_id"`
+	Channel       string    `json:"channel" gorm:"type:varchar(20);not null;uniqueIndex:idx_vac_reminder_channel"` // postcard, email
+	DueDate       time.Time `json:"due_date" gorm:"type:date"`
+	SentAt        time.Time `json:"sent_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName テーブル名を指定
+func (VaccinationReminder) TableName() string {
+	return "vaccination_reminders"
+}
+
+// ReminderRunResult 接種案内の送付結果
+type ReminderRunResult struct {
+	Channels []ReminderChannelResult `json:"channels"`
+}
+
+// ReminderChannelResult 経路ごとの接種案内の送付結果
+type ReminderChannelResult struct {
+	Channel      string `json:"channel"`
+	Batches      int    `json:"batches"`      // 送付した飼い主数
+	Vaccinations int    `json:"vaccinations"` // 案内した接種予定数
+	Skipped      int    `json:"skipped"`      // 宛先がなく送付しなかった飼い主数
+	Failed       int    `json:"failed"`       // 送付に失敗した飼い主数
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// VaccineProtocol ワクチンマスタの接種プロトコル
+// 幼齢期は週齢でシリーズ接種し、成体で初めて接種する場合は初回接種回数を間隔をあけて接種する。
+// 初回接種が済んだ後はbooster_monthsごとに追加接種する。
+//
+//	{"species": ["犬"], "series_weeks": [8, 12, 16], "initial_doses": 2, "series_interval_weeks": 4, "booster_months": 12}
+type VaccineProtocol struct {
+	Species             []string `json:"species,omitempty"`               // 対象の動物種（空なら全種）
+	SeriesWeeks         []int    `json:"series_weeks,omitempty"`          // 幼齢期シリーズの接種週齢
+	InitialDoses        int      `json:"initial_doses,omitempty"`         // 成体で初めて接種する場合の初回接種回数
+	SeriesIntervalWeeks int      `json:"series_interval_weeks,omitempty"` // 初回接種の最短間隔（週、省略時は4週）
+	BoosterMonths       int      `json:"booster_months,omitempty"`        // 追加接種の間隔（月、0なら追加接種なし）
+}
+
+// Value implements driver.Valuer
+func (p VaccineProtocol) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements sql.Scanner
+func (p *VaccineProtocol) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*p = VaccineProtocol{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, p)
+	case string:
+		return json.Unmarshal([]byte(v), p)
+	default:
+		return fmt.Errorf("cannot scan %T into VaccineProtocol", src)
+	}
+}
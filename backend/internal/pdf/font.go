@@ -0,0 +1,79 @@
+package pdf
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Font 文書で使用するフォント
+// 実装はパッケージ内のCIDフォント（DefaultJapaneseFont）と埋め込みTrueTypeフォント（ParseTrueType）のみ。
+type Font interface {
+	// Measure 文字列をsizeポイントで描画したときの幅（pt）
+	Measure(s string, size float64) float64
+	// encode 文字列をTj演算子に渡す16進文字列にする
+	encode(s string) string
+	// write フォントのオブジェクトを書き出し、Type0フォント辞書のオブジェクト番号を返す
+	write(w *writer, used []rune) (int, error)
+}
+
+// replacementChar 表現できない文字の代わりに描画する文字（〓）
+const replacementChar = '〓'
+
+// cidFont Adobe-Japan1の定義済みCIDフォント（フォントファイルを埋め込まない）
+// Acrobat Reader等の主要なビューアは日本語フォントを内蔵しており、ネットワーク接続なしで表示できる。
+type cidFont struct {
+	name string
+}
+
+// DefaultJapaneseFont 埋め込みフォントが用意されていない場合に使用する日本語ゴシック体
+func DefaultJapaneseFont() Font {
+	return &cidFont{name: "HeiseiKakuGo-W5"}
+}
+
+// Measure Fontの実装
+// UniJIS-UCS2-HW-Hでは半角英数字・半角カナは500/1000em、それ以外は全角（1000/1000em）となる。
+func (f *cidFont) Measure(s string, size float64) float64 {
+	var units int
+	for _, r := range s {
+		if isHalfWidth(r) {
+			units += 500
+		} else {
+			units += 1000
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+func (f *cidFont) encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r > 0xFFFF {
+			r = replacementChar
+		}
+		fmt.Fprintf(&b, "%04X", r)
+	}
+	return b.String()
+}
+
+func (f *cidFont) write(w *writer, _ []rune) (int, error) {
+	descriptor := w.alloc()
+	w.object(descriptor, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [-92 -250 1010 922] /ItalicAngle 0 /Ascent 880 /Descent -120 /CapHeight 737 /StemV 114 >>",
+		f.name))
+
+	cid := w.alloc()
+	w.object(cid, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType0 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Japan1) /Supplement 2 >> /FontDescriptor %d 0 R /DW 1000 /W [231 389 500] >>",
+		f.name, descriptor))
+
+	font := w.alloc()
+	w.object(font, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /UniJIS-UCS2-HW-H /DescendantFonts [%d 0 R] >>",
+		f.name, cid))
+	return font, nil
+}
+
+// isHalfWidth 半角で描画される文字かどうか
+func isHalfWidth(r rune) bool {
+	return (r >= 0x20 && r <= 0x7E) || (r >= 0xFF61 && r <= 0xFF9F)
+}
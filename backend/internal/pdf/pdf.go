@@ -0,0 +1,213 @@
+// Package pdf は帳票出力用の最小限のPDF生成機能を提供する。
+//
+// 外部ライブラリやネットワークに依存せず、日本語テキスト・罫線・矩形のみを扱う。
+// 座標はページ左上を原点とするポイント単位（1pt = 1/72インチ）で指定する。
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// A4用紙サイズ（pt）
+const (
+	A4Width  = 595.28
+	A4Height = 841.89
+)
+
+// Document PDF文書
+type Document struct {
+	font  Font
+	title string
+	pages []*Page
+	used  map[rune]struct{}
+}
+
+// Page PDFの1ページ
+type Page struct {
+	doc     *Document
+	content bytes.Buffer
+}
+
+// New 指定したフォントで文書を作成する
+func New(font Font) *Document {
+	return &Document{
+		font: font,
+		used: map[rune]struct{}{},
+	}
+}
+
+// SetTitle 文書のタイトル（文書情報）を設定する
+func (d *Document) SetTitle(title string) {
+	d.title = title
+}
+
+// AddPage A4縦のページを追加する
+func (d *Document) AddPage() *Page {
+	p := &Page{doc: d}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Measure 文字列をsizeポイントで描画したときの幅（pt）
+func (d *Document) Measure(s string, size float64) float64 {
+	return d.font.Measure(s, size)
+}
+
+// Text 左端x・ベースラインyの位置に文字列を描画する
+func (p *Page) Text(x, y, size float64, s string) {
+	if s == "" {
+		return
+	}
+	for _, r := range s {
+		p.doc.used[r] = struct{}{}
+	}
+	fmt.Fprintf(&p.content, "BT /F1 %s Tf %s %s Td <%s> Tj ET\n",
+		num(size), num(x), num(A4Height-y), p.doc.font.encode(s))
+}
+
+// TextRight 右端がxとなるように文字列を描画する
+func (p *Page) TextRight(x, y, size float64, s string) {
+	p.Text(x-p.doc.Measure(s, size), y, size, s)
+}
+
+// TextCenter 中央がxとなるように文字列を描画する
+func (p *Page) TextCenter(x, y, size float64, s string) {
+	p.Text(x-p.doc.Measure(s, size)/2, y, size, s)
+}
+
+// Line 線を描画する
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(A4Height-y1), num(x2), num(A4Height-y2))
+}
+
+// Rect 左上(x, y)・幅w・高さhの矩形の枠を描画する
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(width), num(x), num(A4Height-y-h), num(w), num(h))
+}
+
+// FillRect 左上(x, y)・幅w・高さhの矩形をグレー（0=黒〜1=白）で塗りつぶす
+func (p *Page) FillRect(x, y, w, h, gray float64) {
+	fmt.Fprintf(&p.content, "q %s g %s %s %s %s re f Q\n",
+		num(gray), num(x), num(A4Height-y-h), num(w), num(h))
+}
+
+// Bytes PDFファイルの内容を生成する
+func (d *Document) Bytes() ([]byte, error) {
+	if len(d.pages) == 0 {
+		d.AddPage()
+	}
+
+	w := &writer{}
+	catalog := w.alloc()
+	pages := w.alloc()
+	info := w.alloc()
+
+	used := make([]rune, 0, len(d.used))
+	for r := range d.used {
+		used = append(used, r)
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i] < used[j] })
+
+	font, err := d.font.write(w, used)
+	if err != nil {
+		return nil, err
+	}
+
+	kids := make([]string, 0, len(d.pages))
+	for _, p := range d.pages {
+		content := w.alloc()
+		if err := w.stream(content, "", p.content.Bytes()); err != nil {
+			return nil, err
+		}
+		page := w.alloc()
+		w.object(page, fmt.Sprintf(
+			"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 %d 0 R >> >> /Contents %d 0 R >>",
+			pages, num(A4Width), num(A4Height), font, content))
+		kids = append(kids, fmt.Sprintf("%d 0 R", page))
+	}
+
+	w.object(pages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))
+	w.object(catalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pages))
+	w.object(info, fmt.Sprintf("<< /Title %s /Producer (animal-ekarte) >>", textString(d.title)))
+
+	return w.finish(catalog, info), nil
+}
+
+// writer PDFオブジェクトを書き出し、相互参照表用のオフセットを記録する
+type writer struct {
+	buf     bytes.Buffer
+	offsets []int
+}
+
+// alloc オブジェクト番号を割り当てる（書き出し順は問わない）
+func (w *writer) alloc() int {
+	if w.buf.Len() == 0 {
+		w.buf.WriteString("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+	}
+	w.offsets = append(w.offsets, 0)
+	return len(w.offsets)
+}
+
+// object 辞書などの間接オブジェクトを書き出す
+func (w *writer) object(n int, body string) {
+	w.offsets[n-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n%s\nendobj\n", n, body)
+}
+
+// stream データをFlate圧縮したストリームオブジェクトを書き出す
+// dictには/Lengthと/Filter以外に追加するエントリを指定する。
+func (w *writer) stream(n int, dict string, data []byte) error {
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	if _, err := zw.Write(data); err != nil {
+		return fmt.Errorf("failed to compress stream: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("failed to compress stream: %w", err)
+	}
+
+	w.offsets[n-1] = w.buf.Len()
+	fmt.Fprintf(&w.buf, "%d 0 obj\n<< /Length %d /Filter /FlateDecode %s>>\nstream\n", n, compressed.Len(), dict)
+	w.buf.Write(compressed.Bytes())
+	w.buf.WriteString("\nendstream\nendobj\n")
+	return nil
+}
+
+// finish 相互参照表とトレーラーを書き出してファイルを完成させる
+func (w *writer) finish(root, info int) []byte {
+	xref := w.buf.Len()
+	fmt.Fprintf(&w.buf, "xref\n0 %d\n0000000000 65535 f \n", len(w.offsets)+1)
+	for _, off := range w.offsets {
+		fmt.Fprintf(&w.buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&w.buf, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n",
+		len(w.offsets)+1, root, info, xref)
+	return w.buf.Bytes()
+}
+
+// num 数値をPDFの実数表記にする（小数第2位まで、末尾の0は省略）
+func num(f float64) string {
+	s := strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// textString 文字列をUTF-16BE（BOM付き）の16進文字列にする
+func textString(s string) string {
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, c := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", c)
+	}
+	b.WriteString(">")
+	return b.String()
+}
@@ -0,0 +1,81 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// streams PDF内のストリームを展開して連結する
+func streams(t *testing.T, b []byte) string {
+	t.Helper()
+	var out strings.Builder
+	for _, m := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(b, -1) {
+		r, err := zlib.NewReader(bytes.NewReader(m[1]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(r)
+		require.NoError(t, err)
+		out.Write(data)
+	}
+	return out.String()
+}
+
+func TestDocumentBytes(t *testing.T) {
+	doc := New(DefaultJapaneseFont())
+	doc.SetTitle("領収書")
+	p := doc.AddPage()
+	p.Text(50, 80, 12, "領収書 No.1")
+	p.Line(50, 90, 300, 90, 1)
+	doc.AddPage().Rect(50, 50, 100, 20, 0.5)
+
+	b, err := doc.Bytes()
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(b, []byte("%PDF-1.7\n")))
+	assert.True(t, bytes.HasSuffix(b, []byte("%%EOF\n")))
+	assert.Contains(t, string(b), "/Count 2")
+	assert.Contains(t, string(b), "/Encoding /UniJIS-UCS2-HW-H")
+
+	// 相互参照表の各オフセットが対応するオブジェクトの先頭を指している
+	xref := bytes.LastIndex(b, []byte("\nxref\n"))
+	require.Positive(t, xref)
+	lines := strings.Split(string(b[xref+1:]), "\n")
+	size, err := strconv.Atoi(strings.Fields(lines[1])[1])
+	require.NoError(t, err)
+	for n := 1; n < size; n++ {
+		off, err := strconv.Atoi(strings.Fields(lines[2+n])[0])
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(b[off:], []byte(strconv.Itoa(n)+" 0 obj\n")), "object %d", n)
+	}
+
+	// 「領収書」はUCS-2の16進で、y座標は下端基準に変換されて描画される
+	content := streams(t, b)
+	assert.Contains(t, content, "50 761.89 Td <981853CE66F80020004E006F002E0031> Tj")
+}
+
+func TestCIDFontMeasure(t *testing.T) {
+	f := DefaultJapaneseFont()
+
+	assert.Equal(t, 10.0, f.Measure("円", 10))
+	assert.Equal(t, 5.0, f.Measure("1", 10))
+	assert.Equal(t, 35.0, f.Measure("￥1,000", 10))
+}
+
+func TestParseTrueType_Unsupported(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"too short":  []byte("abc"),
+		"collection": append([]byte("ttcf"), make([]byte, 12)...),
+		"cff":        append([]byte("OTTO"), make([]byte, 12)...),
+		"no tables":  append([]byte{0, 1, 0, 0}, make([]byte, 12)...),
+	} {
+		_, err := ParseTrueType(data)
+		assert.ErrorIs(t, err, ErrUnsupportedFont, name)
+	}
+}
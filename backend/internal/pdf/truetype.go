@@ -0,0 +1,405 @@
+package pdf
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+// ErrUnsupportedFont 埋め込みに対応していないフォントファイル
+var ErrUnsupportedFont = errors.New("unsupported font")
+
+// TrueTypeFont PDFに埋め込むTrueTypeフォント
+// フォントファイル全体をFontFile2として埋め込み、文字はグリフIDで指定する（Identity-H）。
+type TrueTypeFont struct {
+	name       string
+	data       []byte
+	unitsPerEm int
+	ascent     int
+	descent    int
+	capHeight  int
+	bbox       [4]int
+	advances   []uint16
+	lookup     func(r rune) uint16
+}
+
+// ParseTrueType TrueTypeフォント（.ttf）を読み込む
+// CFFアウトラインのOpenTypeフォント（.otf）とフォントコレクション（.ttc）には対応しない。
+func ParseTrueType(data []byte) (*TrueTypeFont, error) {
+	if len(data) < 12 {
+		return nil, fmt.Errorf("%w: file too short", ErrUnsupportedFont)
+	}
+	switch string(data[:4]) {
+	case "ttcf":
+		return nil, fmt.Errorf("%w: font collections (.ttc) are not supported", ErrUnsupportedFont)
+	case "OTTO":
+		return nil, fmt.Errorf("%w: CFF-based OpenType fonts are not supported", ErrUnsupportedFont)
+	}
+
+	tables := map[string][]byte{}
+	numTables := int(binary.BigEndian.Uint16(data[4:]))
+	for i := 0; i < numTables; i++ {
+		rec := 12 + i*16
+		if rec+16 > len(data) {
+			return nil, fmt.Errorf("%w: truncated table directory", ErrUnsupportedFont)
+		}
+		tag := string(data[rec : rec+4])
+		off := int(binary.BigEndian.Uint32(data[rec+8:]))
+		length := int(binary.BigEndian.Uint32(data[rec+12:]))
+		if off < 0 || length < 0 || off+length > len(data) {
+			return nil, fmt.Errorf("%w: table %s out of range", ErrUnsupportedFont, tag)
+		}
+		tables[tag] = data[off : off+length]
+	}
+	for _, tag := range []string{"head", "hhea", "hmtx", "maxp", "cmap", "glyf"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, fmt.Errorf("%w: missing %s table", ErrUnsupportedFont, tag)
+		}
+	}
+
+	f := &TrueTypeFont{data: data}
+
+	head := tables["head"]
+	if len(head) < 54 {
+		return nil, fmt.Errorf("%w: invalid head table", ErrUnsupportedFont)
+	}
+	f.unitsPerEm = int(binary.BigEndian.Uint16(head[18:]))
+	if f.unitsPerEm == 0 {
+		return nil, fmt.Errorf("%w: invalid unitsPerEm", ErrUnsupportedFont)
+	}
+	for i := range f.bbox {
+		f.bbox[i] = int(int16(binary.BigEndian.Uint16(head[36+i*2:])))
+	}
+
+	hhea := tables["hhea"]
+	if len(hhea) < 36 {
+		return nil, fmt.Errorf("%w: invalid hhea table", ErrUnsupportedFont)
+	}
+	f.ascent = int(int16(binary.BigEndian.Uint16(hhea[4:])))
+	f.descent = int(int16(binary.BigEndian.Uint16(hhea[6:])))
+	f.capHeight = f.ascent
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+
+	maxp := tables["maxp"]
+	if len(maxp) < 6 {
+		return nil, fmt.Errorf("%w: invalid maxp table", ErrUnsupportedFont)
+	}
+	numGlyphs := int(binary.BigEndian.Uint16(maxp[4:]))
+
+	hmtx := tables["hmtx"]
+	if numHMetrics == 0 || len(hmtx) < numHMetrics*4 {
+		return nil, fmt.Errorf("%w: invalid hmtx table", ErrUnsupportedFont)
+	}
+	f.advances = make([]uint16, numGlyphs)
+	for i := range f.advances {
+		m := i
+		if m >= numHMetrics {
+			m = numHMetrics - 1
+		}
+		f.advances[i] = binary.BigEndian.Uint16(hmtx[m*4:])
+	}
+
+	if os2 := tables["OS/2"]; len(os2) >= 90 && binary.BigEndian.Uint16(os2) >= 2 {
+		f.capHeight = int(int16(binary.BigEndian.Uint16(os2[88:])))
+	}
+
+	lookup, err := parseCmap(tables["cmap"])
+	if err != nil {
+		return nil, err
+	}
+	f.lookup = lookup
+
+	f.name = postScriptName(tables["name"])
+	return f, nil
+}
+
+// Name PostScript名
+func (f *TrueTypeFont) Name() string {
+	return f.name
+}
+
+// Measure Fontの実装
+func (f *TrueTypeFont) Measure(s string, size float64) float64 {
+	var units int
+	for _, r := range s {
+		units += f.width(f.glyph(r))
+	}
+	return float64(units) * size / 1000
+}
+
+func (f *TrueTypeFont) encode(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		fmt.Fprintf(&b, "%04X", f.glyph(r))
+	}
+	return b.String()
+}
+
+func (f *TrueTypeFont) write(w *writer, used []rune) (int, error) {
+	file := w.alloc()
+	if err := w.stream(file, fmt.Sprintf("/Length1 %d ", len(f.data)), f.data); err != nil {
+		return 0, err
+	}
+
+	descriptor := w.alloc()
+	w.object(descriptor, fmt.Sprintf(
+		"<< /Type /FontDescriptor /FontName /%s /Flags 4 /FontBBox [%d %d %d %d] /ItalicAngle 0 /Ascent %d /Descent %d /CapHeight %d /StemV 80 /FontFile2 %d 0 R >>",
+		f.name, f.scale(f.bbox[0]), f.scale(f.bbox[1]), f.scale(f.bbox[2]), f.scale(f.bbox[3]),
+		f.scale(f.ascent), f.scale(f.descent), f.scale(f.capHeight), file))
+
+	// 使用したグリフの幅と、テキスト抽出用のグリフ→Unicode対応表を作成する
+	toUnicode := map[uint16]rune{}
+	for _, r := range used {
+		g := f.glyph(r)
+		if _, ok := toUnicode[g]; !ok && g != 0 {
+			toUnicode[g] = r
+		}
+	}
+	glyphs := make([]int, 0, len(toUnicode))
+	for g := range toUnicode {
+		glyphs = append(glyphs, int(g))
+	}
+	sort.Ints(glyphs)
+
+	var widths strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%d] ", g, f.width(uint16(g)))
+	}
+
+	cmap := w.alloc()
+	if err := w.stream(cmap, "", toUnicodeCMap(glyphs, toUnicode)); err != nil {
+		return 0, err
+	}
+
+	cid := w.alloc()
+	w.object(cid, fmt.Sprintf(
+		"<< /Type /Font /Subtype /CIDFontType2 /BaseFont /%s /CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> /FontDescriptor %d 0 R /DW 1000 /W [%s] /CIDToGIDMap /Identity >>",
+		f.name, descriptor, strings.TrimSpace(widths.String())))
+
+	font := w.alloc()
+	w.object(font, fmt.Sprintf(
+		"<< /Type /Font /Subtype /Type0 /BaseFont /%s /Encoding /Identity-H /DescendantFonts [%d 0 R] /ToUnicode %d 0 R >>",
+		f.name, cid, cmap))
+	return font, nil
+}
+
+// glyph 文字のグリフID（フォントに含まれない文字は〓、それもなければ.notdef）
+func (f *TrueTypeFont) glyph(r rune) uint16 {
+	if g := f.lookup(r); g != 0 {
+		return g
+	}
+	return f.lookup(replacementChar)
+}
+
+// width グリフの送り幅（1000/em単位）
+func (f *TrueTypeFont) width(g uint16) int {
+	if int(g) >= len(f.advances) {
+		return 0
+	}
+	return f.scale(int(f.advances[g]))
+}
+
+// scale フォント単位を1000/em単位に変換する
+func (f *TrueTypeFont) scale(v int) int {
+	return v * 1000 / f.unitsPerEm
+}
+
+// parseCmap Unicodeの文字→グリフIDの対応表を読み込む（フォーマット12または4）
+func parseCmap(cmap []byte) (func(rune) uint16, error) {
+	if len(cmap) < 4 {
+		return nil, fmt.Errorf("%w: invalid cmap table", ErrUnsupportedFont)
+	}
+
+	var format4, format12 []byte
+	n := int(binary.BigEndian.Uint16(cmap[2:]))
+	for i := 0; i < n; i++ {
+		rec := 4 + i*8
+		if rec+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		off := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+		if off+4 > len(cmap) {
+			continue
+		}
+		unicode := platform == 0 || (platform == 3 && (encoding == 1 || encoding == 10))
+		if !unicode {
+			continue
+		}
+		switch binary.BigEndian.Uint16(cmap[off:]) {
+		case 4:
+			format4 = cmap[off:]
+		case 12:
+			format12 = cmap[off:]
+		}
+	}
+
+	switch {
+	case format12 != nil:
+		return cmapFormat12(format12)
+	case format4 != nil:
+		return cmapFormat4(format4)
+	}
+	return nil, fmt.Errorf("%w: no unicode cmap subtable", ErrUnsupportedFont)
+}
+
+func cmapFormat4(t []byte) (func(rune) uint16, error) {
+	if len(t) < 14 {
+		return nil, fmt.Errorf("%w: invalid cmap format 4", ErrUnsupportedFont)
+	}
+	segX2 := int(binary.BigEndian.Uint16(t[6:]))
+	ends := 14
+	starts := ends + segX2 + 2
+	deltas := starts + segX2
+	rangeOffsets := deltas + segX2
+	if rangeOffsets+segX2 > len(t) {
+		return nil, fmt.Errorf("%w: invalid cmap format 4", ErrUnsupportedFont)
+	}
+	u16 := func(off int) uint16 {
+		if off+2 > len(t) {
+			return 0
+		}
+		return binary.BigEndian.Uint16(t[off:])
+	}
+
+	return func(r rune) uint16 {
+		if r > 0xFFFF {
+			return 0
+		}
+		c := uint16(r)
+		for i := 0; i < segX2; i += 2 {
+			if c > u16(ends+i) {
+				continue
+			}
+			start := u16(starts + i)
+			if c < start {
+				return 0
+			}
+			delta := u16(deltas + i)
+			ro := u16(rangeOffsets + i)
+			if ro == 0 {
+				return c + delta
+			}
+			g := u16(rangeOffsets + i + int(ro) + int(c-start)*2)
+			if g == 0 {
+				return 0
+			}
+			return g + delta
+		}
+		return 0
+	}, nil
+}
+
+func cmapFormat12(t []byte) (func(rune) uint16, error) {
+	if len(t) < 16 {
+		return nil, fmt.Errorf("%w: invalid cmap format 12", ErrUnsupportedFont)
+	}
+	n := int(binary.BigEndian.Uint32(t[12:]))
+	if 16+n*12 > len(t) {
+		return nil, fmt.Errorf("%w: invalid cmap format 12", ErrUnsupportedFont)
+	}
+	groups := t[16 : 16+n*12]
+
+	return func(r rune) uint16 {
+		c := uint32(r)
+		i := sort.Search(n, func(i int) bool {
+			return binary.BigEndian.Uint32(groups[i*12+4:]) >= c
+		})
+		if i == n {
+			return 0
+		}
+		g := groups[i*12:]
+		start := binary.BigEndian.Uint32(g)
+		if c < start {
+			return 0
+		}
+		return uint16(binary.BigEndian.Uint32(g[8:]) + c - start)
+	}, nil
+}
+
+// postScriptName nameテーブルからPostScript名（nameID 6）を取得する
+func postScriptName(t []byte) string {
+	const fallback = "EmbeddedFont"
+	if len(t) < 6 {
+		return fallback
+	}
+	count := int(binary.BigEndian.Uint16(t[2:]))
+	storage := int(binary.BigEndian.Uint16(t[4:]))
+	for i := 0; i < count; i++ {
+		rec := 6 + i*12
+		if rec+12 > len(t) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(t[rec:])
+		if binary.BigEndian.Uint16(t[rec+6:]) != 6 {
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(t[rec+8:]))
+		off := storage + int(binary.BigEndian.Uint16(t[rec+10:]))
+		if off+length > len(t) {
+			continue
+		}
+		raw := t[off : off+length]
+
+		var name string
+		switch platform {
+		case 1:
+			name = string(raw)
+		case 0, 3:
+			u := make([]uint16, len(raw)/2)
+			for j := range u {
+				u[j] = binary.BigEndian.Uint16(raw[j*2:])
+			}
+			name = string(utf16.Decode(u))
+		default:
+			continue
+		}
+		if name = sanitizeName(name); name != "" {
+			return name
+		}
+	}
+	return fallback
+}
+
+// sanitizeName PDFの名前オブジェクトとして使える文字だけを残す
+func sanitizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// toUnicodeCMap グリフID→Unicodeの対応を表すToUnicode CMapを作成する
+func toUnicodeCMap(glyphs []int, toUnicode map[uint16]rune) []byte {
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	b.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+
+	// bfcharは1ブロック100件まで
+	for start := 0; start < len(glyphs); start += 100 {
+		end := start + 100
+		if end > len(glyphs) {
+			end = len(glyphs)
+		}
+		fmt.Fprintf(&b, "%d beginbfchar\n", end-start)
+		for _, g := range glyphs[start:end] {
+			fmt.Fprintf(&b, "<%04X> <", g)
+			for _, c := range utf16.Encode([]rune{toUnicode[uint16(g)]}) {
+				fmt.Fprintf(&b, "%04X", c)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return []byte(b.String())
+}
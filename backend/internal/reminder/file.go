@@ -0,0 +1,49 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileNotifier はがき印刷用に案内を1世帯1ファイルのテキストとして書き出す
+// 住所のない飼い主は送付先なし（ErrNoAddress）とする。
+type FileNotifier struct {
+	dir string
+}
+
+// NewFileNotifier 書き出し先ディレクトリを指定してFileNotifierを作成
+func NewFileNotifier(dir string) *FileNotifier {
+	return &FileNotifier{dir: dir}
+}
+
+// Channel 送付経路
+func (n *FileNotifier) Channel() string {
+	return ChannelPostcard
+}
+
+// Notify 案内を <日付>/<飼い主番号>_<飼い主ID>.txt に書き出す
+func (n *FileNotifier) Notify(_ context.Context, batch *Batch) error {
+	if strings.TrimSpace(batch.Owner.Address) == "" {
+		return ErrNoAddress
+	}
+
+	dir := filepath.Join(n.dir, batch.GeneratedAt.Format("20060102"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create postcard directory: %w", err)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "宛先: %s\n", batch.Owner.Address)
+	fmt.Fprintf(&b, "宛名: %s 様\n", batch.Owner.Name)
+	fmt.Fprintf(&b, "件名: %s\n\n", Subject(batch))
+	b.WriteString(Body(batch))
+
+	name := fmt.Sprintf("%06d_%s.txt", batch.Owner.OwnerNumber, batch.Owner.ID)
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(b.String()), 0o644); err != nil {
+		return fmt.Errorf("write postcard: %w", err)
+	}
+	return nil
+}
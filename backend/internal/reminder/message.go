@@ -0,0 +1,56 @@
+package reminder
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Subject 案内の件名
+func Subject(batch *Batch) string {
+	if batch.Clinic != nil && batch.Clinic.Name != "" {
+		return fmt.Sprintf("【%s】ワクチン接種のご案内", batch.Clinic.Name)
+	}
+	return "ワクチン接種のご案内"
+}
+
+// Body 案内の本文（はがき・メール共通）
+func Body(batch *Batch) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s 様\n\n", batch.Owner.Name)
+	b.WriteString("いつも当院をご利用いただきありがとうございます。\n")
+	b.WriteString("下記のワクチン接種の時期が近づいてまいりましたのでご案内いたします。\n\n")
+
+	for _, pet := range batch.Pets {
+		fmt.Fprintf(&b, "■ %sちゃん\n", pet.Pet.Name)
+		for _, v := range pet.Vaccinations {
+			fmt.Fprintf(&b, "  ・%s　接種予定日 %s（前回 %s）\n",
+				v.VaccineName, formatDate(*v.NextDate), formatDate(v.VaccinationDate))
+		}
+	}
+
+	b.WriteString("\nご来院の際は、事前にご予約いただくとスムーズにご案内できます。\n")
+	if strings.Contains(b.String(), "狂犬病") {
+		b.WriteString("狂犬病予防注射は法律で年1回の接種が義務付けられています。\n")
+	}
+
+	if c := batch.Clinic; c != nil {
+		b.WriteString("\n")
+		b.WriteString(strings.TrimSpace(c.Name + " " + c.BranchName))
+		b.WriteString("\n")
+		if c.PostalCode != "" {
+			fmt.Fprintf(&b, "〒%s ", c.PostalCode)
+		}
+		if c.Address != "" || c.PostalCode != "" {
+			fmt.Fprintf(&b, "%s\n", c.Address)
+		}
+		if c.PhoneNumber != "" {
+			fmt.Fprintf(&b, "TEL %s\n", c.PhoneNumber)
+		}
+	}
+	return b.String()
+}
+
+func formatDate(t time.Time) string {
+	return t.Format("2006年1月2日")
+}
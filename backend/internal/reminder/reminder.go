@@ -0,0 +1,99 @@
+// Package reminder はワクチン接種予定を飼い主ごとの案内にまとめ、はがき・メールなどの経路で送付する。
+package reminder
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// 送付経路
+const (
+	ChannelPostcard = "postcard"
+	ChannelEmail    = "email"
+)
+
+// ErrNoAddress 飼い主に送付先がない（メールアドレス未登録など）
+var ErrNoAddress = errors.New("owner has no address for this channel")
+
+// Notifier 接種案内の送付先
+// Notifyは1世帯分の案内を送付する。送付先がない場合はErrNoAddressを返す。
+type Notifier interface {
+	Channel() string
+	Notify(ctx context.Context, batch *Batch) error
+}
+
+// Batch 1世帯（飼い主）分の接種案内
+type Batch struct {
+	Clinic      *model.Clinic // 差出人（未登録ならnil）
+	Owner       model.Owner
+	Pets        []PetVaccinations
+	GeneratedAt time.Time
+}
+
+// PetVaccinations ペットごとの接種予定
+type PetVaccinations struct {
+	Pet          model.Pet
+	Vaccinations []model.Vaccination
+}
+
+// Vaccinations 案内に含まれる全ての接種予定
+func (b *Batch) Vaccinations() []model.Vaccination {
+	var vaccinations []model.Vaccination
+	for _, pet := range b.Pets {
+		vaccinations = append(vaccinations, pet.Vaccinations...)
+	}
+	return vaccinations
+}
+
+// GroupByOwner 接種予定を飼い主ごと・ペットごとにまとめる
+// 接種記録にはPetとOwnerを読み込んでおくこと。飼い主は最も早い予定日順、ペットは名前順、接種予定は予定日順に並べる。
+func GroupByOwner(vaccinations []model.Vaccination, now time.Time) []Batch {
+	var batches []*Batch
+	byOwner := map[uuid.UUID]*Batch{}
+	petIndex := map[uuid.UUID]int{}
+	earliest := map[*Batch]time.Time{}
+
+	for _, v := range vaccinations {
+		if v.Owner == nil || v.Pet == nil || v.NextDate == nil {
+			continue
+		}
+		batch, ok := byOwner[v.OwnerID]
+		if !ok {
+			batch = &Batch{Owner: *v.Owner, GeneratedAt: now}
+			byOwner[v.OwnerID] = batch
+			batches = append(batches, batch)
+		}
+		if first, ok := earliest[batch]; !ok || v.NextDate.Before(first) {
+			earliest[batch] = *v.NextDate
+		}
+		i, ok := petIndex[v.PetID]
+		if !ok {
+			i = len(batch.Pets)
+			petIndex[v.PetID] = i
+			batch.Pets = append(batch.Pets, PetVaccinations{Pet: *v.Pet})
+		}
+		batch.Pets[i].Vaccinations = append(batch.Pets[i].Vaccinations, v)
+	}
+
+	sort.SliceStable(batches, func(i, j int) bool {
+		return earliest[batches[i]].Before(earliest[batches[j]])
+	})
+	result := make([]Batch, 0, len(batches))
+	for _, batch := range batches {
+		sort.SliceStable(batch.Pets, func(i, j int) bool {
+			return batch.Pets[i].Pet.Name < batch.Pets[j].Pet.Name
+		})
+		for _, pet := range batch.Pets {
+			vs := pet.Vaccinations
+			sort.SliceStable(vs, func(a, b int) bool { return vs[a].NextDate.Before(*vs[b].NextDate) })
+		}
+		result = append(result, *batch)
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+package reminder
+
+import (
+	"context"
+	"encoding/base64"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func vaccination(owner *model.Owner, pet *model.Pet, name, next string) model.Vaccination {
+	nextDate := date(next)
+	return model.Vaccination{
+		ID:              uuid.New(),
+		PetID:           pet.ID,
+		OwnerID:         owner.ID,
+		VaccineName:     name,
+		VaccinationDate: nextDate.AddDate(-1, 0, 0),
+		NextDate:        &nextDate,
+		Pet:             pet,
+		Owner:           owner,
+	}
+}
+
+func TestGroupByOwner(t *testing.T) {
+	yamada := &model.Owner{ID: uuid.New(), Name: "山田"}
+	sato := &model.Owner{ID: uuid.New(), Name: "佐藤"}
+	pochi := &model.Pet{ID: uuid.New(), Name: "ポチ"}
+	tama := &model.Pet{ID: uuid.New(), Name: "タマ"}
+	kuro := &model.Pet{ID: uuid.New(), Name: "クロ"}
+
+	now := time.Now()
+	batches := GroupByOwner([]model.Vaccination{
+		vaccination(yamada, pochi, "5種混合", "2024-06-20"),
+		vaccination(sato, kuro, "3種混合", "2024-06-05"),
+		vaccination(yamada, tama, "3種混合", "2024-06-10"),
+		vaccination(yamada, pochi, "狂犬病", "2024-06-01"),
+	}, now)
+
+	require.Len(t, batches, 2)
+	// 山田様はポチの狂犬病(6/1)が最も早い
+	assert.Equal(t, "山田", batches[0].Owner.Name)
+	assert.Equal(t, "佐藤", batches[1].Owner.Name)
+	assert.Equal(t, now, batches[0].GeneratedAt)
+
+	require.Len(t, batches[0].Pets, 2)
+	assert.Equal(t, "タマ", batches[0].Pets[0].Pet.Name)
+	assert.Equal(t, "ポチ", batches[0].Pets[1].Pet.Name)
+	require.Len(t, batches[0].Pets[1].Vaccinations, 2)
+	assert.Equal(t, "狂犬病", batches[0].Pets[1].Vaccinations[0].VaccineName)
+	assert.Len(t, batches[0].Vaccinations(), 3)
+}
+
+func testBatch() *Batch {
+	owner := &model.Owner{ID: uuid.New(), OwnerNumber: 12, Name: "山田 太郎", Address: "東京都千代田区1-1", Email: "taro@example.com"}
+	pet := &model.Pet{ID: uuid.New(), Name: "ポチ"}
+	batches := GroupByOwner([]model.Vaccination{vaccination(owner, pet, "狂犬病", "2024-06-01")}, date("2024-05-10"))
+	batch := batches[0]
+	batch.Clinic = &model.Clinic{Name: "さくら動物病院", PhoneNumber: "03-0000-0000"}
+	return &batch
+}
+
+func TestBody(t *testing.T) {
+	body := Body(testBatch())
+
+	assert.Contains(t, body, "山田 太郎 様")
+	assert.Contains(t, body, "■ ポチちゃん")
+	assert.Contains(t, body, "狂犬病　接種予定日 2024年6月1日（前回 2023年6月1日）")
+	assert.Contains(t, body, "法律で年1回")
+	assert.Contains(t, body, "TEL 03-0000-0000")
+	assert.Equal(t, "【さくら動物病院】ワクチン接種のご案内", Subject(testBatch()))
+}
+
+func TestFileNotifier(t *testing.T) {
+	dir := t.TempDir()
+	n := NewFileNotifier(dir)
+	batch := testBatch()
+
+	require.NoError(t, n.Notify(context.Background(), batch))
+
+	data, err := os.ReadFile(filepath.Join(dir, "20240510", "000012_"+batch.Owner.ID.String()+".txt"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(data), "宛先: 東京都千代田区1-1\n"))
+
+	batch.Owner.Address = ""
+	assert.ErrorIs(t, n.Notify(context.Background(), batch), ErrNoAddress)
+}
+
+func TestSMTPNotifier_Outbox(t *testing.T) {
+	dir := t.TempDir()
+	n := NewSMTPNotifier(SMTPConfig{From: "clinic@example.com", OutboxDir: dir})
+	batch := testBatch()
+
+	require.NoError(t, n.Notify(context.Background(), batch))
+
+	data, err := os.ReadFile(filepath.Join(dir, "20240510", "000012_"+batch.Owner.ID.String()+".eml"))
+	require.NoError(t, err)
+	header, encoded, ok := strings.Cut(string(data), "\r\n\r\n")
+	require.True(t, ok)
+	assert.Contains(t, header, "To: taro@example.com\r\n")
+	body, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(encoded, "\r\n", ""))
+	require.NoError(t, err)
+	assert.Equal(t, Body(batch), string(body))
+
+	batch.Owner.Email = ""
+	assert.ErrorIs(t, n.Notify(context.Background(), batch), ErrNoAddress)
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	n := NewSMTPNotifier(SMTPConfig{Host: "smtp.example.com", Port: "587", From: "clinic@example.com"})
+	var gotAddr string
+	var gotTo []string
+	n.send = func(addr string, _ smtp.Auth, _ string, to []string, _ []byte) error {
+		gotAddr, gotTo = addr, to
+		return nil
+	}
+
+	require.NoError(t, n.Notify(context.Background(), testBatch()))
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, []string{"taro@example.com"}, gotTo)
+}
@@ -0,0 +1,25 @@
+package reminder
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RunEvery ctxが終了するまでinterval間隔でjobを実行する（起動直後に1回実行する）
+// jobのエラーはログに記録して次回の実行を続ける。
+func RunEvery(ctx context.Context, interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := job(ctx); err != nil && ctx.Err() == nil {
+			slog.ErrorContext(ctx, "reminder job failed", slog.String("error", err.Error()))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
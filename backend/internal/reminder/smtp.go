@@ -0,0 +1,100 @@
+package reminder
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SMTPConfig メール送信設定
+// Hostが空の場合はスタブとして、送信する代わりにOutboxDirへ.emlファイルを書き出す。
+type SMTPConfig struct {
+	Host      string
+	Port      string
+	Username  string
+	Password  string
+	From      string
+	OutboxDir string
+}
+
+// SMTPNotifier 案内をメールで送付する
+// メールアドレスのない飼い主は送付先なし（ErrNoAddress）とする。
+type SMTPNotifier struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTPNotifier SMTPNotifierを作成
+func NewSMTPNotifier(cfg SMTPConfig) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg, send: smtp.SendMail}
+}
+
+// Channel 送付経路
+func (n *SMTPNotifier) Channel() string {
+	return ChannelEmail
+}
+
+// Notify 案内メールを送信する（スタブの場合は.emlファイルに書き出す）
+func (n *SMTPNotifier) Notify(_ context.Context, batch *Batch) error {
+	to := strings.TrimSpace(batch.Owner.Email)
+	if to == "" {
+		return ErrNoAddress
+	}
+	msg := n.message(batch, to)
+
+	if n.cfg.Host == "" {
+		return n.writeOutbox(batch, msg)
+	}
+
+	var a smtp.Auth
+	if n.cfg.Username != "" {
+		a = smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)
+	}
+	if err := n.send(net.JoinHostPort(n.cfg.Host, n.cfg.Port), a, n.cfg.From, []string{to}, msg); err != nil {
+		return fmt.Errorf("send reminder mail: %w", err)
+	}
+	return nil
+}
+
+// message RFC 5322形式のメッセージを作成（本文はUTF-8のbase64）
+func (n *SMTPNotifier) message(batch *Batch, to string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", n.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", to)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.BEncoding.Encode("UTF-8", Subject(batch)))
+	fmt.Fprintf(&b, "Date: %s\r\n", batch.GeneratedAt.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: base64\r\n\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(Body(batch)))
+	for len(encoded) > 76 {
+		b.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	b.WriteString(encoded + "\r\n")
+	return []byte(b.String())
+}
+
+func (n *SMTPNotifier) writeOutbox(batch *Batch, msg []byte) error {
+	if n.cfg.OutboxDir == "" {
+		return errors.New("either SMTP host or mail outbox directory must be configured")
+	}
+	dir := filepath.Join(n.cfg.OutboxDir, batch.GeneratedAt.Format("20060102"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create mail outbox: %w", err)
+	}
+	name := fmt.Sprintf("%06d_%s.eml", batch.Owner.OwnerNumber, batch.Owner.ID)
+	if err := os.WriteFile(filepath.Join(dir, name), msg, 0o644); err != nil {
+		return fmt.Errorf("write reminder mail: %w", err)
+	}
+	return nil
+}
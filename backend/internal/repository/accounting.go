@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// AccountingRepository 会計リポジトリインターフェース
+type AccountingRepository interface {
+	ListAccountings(ctx context.Context, filter model.AccountingFilter, opts model.ListOptions) (*model.ListResult[model.Accounting], error)
+	GetAccountingByID(ctx context.Context, id uuid.UUID) (*model.Accounting, error)
+	GetAccountingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Accounting, error)
+	FindActiveAccountingByMedicalRecordID(ctx context.Context, recordID uuid.UUID) (*model.Accounting, error)
+	CreateAccounting(ctx context.Context, accounting *model.Accounting) error
+	UpdateAccounting(ctx context.Context, accounting *model.Accounting) error
+	DeleteAccounting(ctx context.Context, id uuid.UUID) error
+	CreateAccountingItem(ctx context.Context, item *model.AccountingItem) error
+	DeleteAccountingItem(ctx context.Context, accountingID, itemID uuid.UUID) error
+}
+
+// accountingRepository 会計リポジトリ実装
+type accountingRepository struct {
+	db *gorm.DB
+}
+
+// NewAccountingRepository 新しい会計リポジトリを作成
+func NewAccountingRepository(db *gorm.DB) AccountingRepository {
+	return &accountingRepository{db: db}
+}
+
+// accountingListSpec 会計一覧の並び替え可能な列
+var accountingListSpec = listSpec[model.Accounting]{
+	columns: map[string]sortColumn[model.Accounting]{
+		"scheduled_date": {column: "scheduled_date", value: func(a *model.Accounting) any { return a.ScheduledDate }},
+		"created_at":     {column: "created_at", value: func(a *model.Accounting) any { return a.CreatedAt }},
+		"updated_at":     {column: "updated_at", value: func(a *model.Accounting) any { return a.UpdatedAt }},
+	},
+	defaultSort: "-scheduled_date,-created_at",
+	id:          sortColumn[model.Accounting]{column: "id", value: func(a *model.Accounting) any { return a.ID }},
+}
+
+// ListAccountings 条件に一致する会計を1ページ分取得
+func (r *accountingRepository) ListAccountings(ctx context.Context, filter model.AccountingFilter, opts model.ListOptions) (*model.ListResult[model.Accounting], error) {
+	query := conn(ctx, r.db).Model(&model.Accounting{})
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	query = whereDateRange(query, "scheduled_date", filter.ScheduledDate)
+	return findPage(query, accountingListSpec, opts, "Pet", "Owner")
+}
+
+// GetAccountingByID IDで会計を明細付きで取得
+func (r *accountingRepository) GetAccountingByID(ctx context.Context, id uuid.UUID) (*model.Accounting, error) {
+	var accounting model.Accounting
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		Preload("AccountingItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&accounting, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("accounting", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get accounting")
+	}
+	return &accounting, nil
+}
+
+// GetAccountingByIDForUpdate IDで会計を明細付き・行ロック付きで取得
+// トランザクション内で呼び出すこと。明細の追加と再計算を直列化する。
+func (r *accountingRepository) GetAccountingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Accounting, error) {
+	var accounting model.Accounting
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("AccountingItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&accounting, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("accounting", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get accounting")
+	}
+	return &accounting, nil
+}
+
+// FindActiveAccountingByMedicalRecordID カルテから作成されたキャンセル以外の会計を取得
+// 該当がなければnilを返す。
+func (r *accountingRepository) FindActiveAccountingByMedicalRecordID(ctx context.Context, recordID uuid.UUID) (*model.Accounting, error) {
+	var accounting model.Accounting
+	err := conn(ctx, r.db).
+		Where("medical_record_id = ?", recordID).
+		Where("status <> ?", model.AccountingStatusCancelled).
+		First(&accounting).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap(err, "failed to find accounting")
+	}
+	return &accounting, nil
+}
+
+// CreateAccounting 会計を明細とともに作成
+func (r *accountingRepository) CreateAccounting(ctx context.Context, accounting *model.Accounting) error {
+	if err := conn(ctx, r.db).Omit("Pet", "Owner", "MedicalRecord").Create(accounting).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create accounting")
+	}
+	return nil
+}
+
+// UpdateAccounting 会計を更新（明細は更新しない）
+func (r *accountingRepository) UpdateAccounting(ctx context.Context, accounting *model.Accounting) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(accounting).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update accounting")
+	}
+	return nil
+}
+
+// DeleteAccounting 会計を明細とともに削除
+func (r *accountingRepository) DeleteAccounting(ctx context.Context, id uuid.UUID) error {
+	if err := conn(ctx, r.db).Delete(&model.AccountingItem{}, "accounting_id = ?", id).Error; err != nil {
+		return apperrors.Wrap(err, "failed to delete accounting items")
+	}
+	result := conn(ctx, r.db).Delete(&model.Accounting{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete accounting")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("accounting", id.String())
+	}
+	return nil
+}
+
+// CreateAccountingItem 会計明細を作成
+func (r *accountingRepository) CreateAccountingItem(ctx context.Context, item *model.AccountingItem) error {
+	if err := conn(ctx, r.db).Create(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create accounting item")
+	}
+	return nil
+}
+
+// DeleteAccountingItem 会計明細を削除
+func (r *accountingRepository) DeleteAccountingItem(ctx context.Context, accountingID, itemID uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.AccountingItem{}, "id = ? AND accounting_id = ?", itemID, accountingID)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete accounting item")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("accounting_item", itemID.String())
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// AnalyzerImportRepository 検査装置の結果リポジトリインターフェース
+type AnalyzerImportRepository interface {
+	ListAnalyzerImports(ctx context.Context, filter model.AnalyzerImportFilter, opts model.ListOptions) (*model.ListResult[model.AnalyzerImport], error)
+	GetAnalyzerImportByID(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error)
+	GetAnalyzerImportByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error)
+	CreateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error
+	UpdateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error
+}
+
+// analyzerImportRepository 検査装置の結果リポジトリ実装
+type analyzerImportRepository struct {
+	db *gorm.DB
+}
+
+// NewAnalyzerImportRepository 新しい検査装置の結果リポジトリを作成
+func NewAnalyzerImportRepository(db *gorm.DB) AnalyzerImportRepository {
+	return &analyzerImportRepository{db: db}
+}
+
+// analyzerImportListSpec 検査装置の結果一覧の並び替え可能な列
+var analyzerImportListSpec = listSpec[model.AnalyzerImport]{
+	columns: map[string]sortColumn[model.AnalyzerImport]{
+		"created_at": {column: "created_at", value: func(a *model.AnalyzerImport) any { return a.CreatedAt }},
+		"updated_at": {column: "updated_at", value: func(a *model.AnalyzerImport) any { return a.UpdatedAt }},
+	},
+	defaultSort: "-created_at",
+	id:          sortColumn[model.AnalyzerImport]{column: "id", value: func(a *model.AnalyzerImport) any { return a.ID }},
+}
+
+// ListAnalyzerImports 条件に一致する検査装置の結果を1ページ分取得
+func (r *analyzerImportRepository) ListAnalyzerImports(ctx context.Context, filter model.AnalyzerImportFilter, opts model.ListOptions) (*model.ListResult[model.AnalyzerImport], error) {
+	query := conn(ctx, r.db).Model(&model.AnalyzerImport{})
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	query = whereDateRange(query, "created_at", filter.ReceivedAt)
+	return findPage(query, analyzerImportListSpec, opts)
+}
+
+// GetAnalyzerImportByID IDで検査装置の結果を取得
+func (r *analyzerImportRepository) GetAnalyzerImportByID(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error) {
+	var analyzerImport model.AnalyzerImport
+	if err := conn(ctx, r.db).
+		Preload("Examination").
+		First(&analyzerImport, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("analyzer_import", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get analyzer import")
+	}
+	return &analyzerImport, nil
+}
+
+// GetAnalyzerImportByIDForUpdate IDで検査装置の結果を行ロック付きで取得（トランザクション内で使う）
+func (r *analyzerImportRepository) GetAnalyzerImportByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error) {
+	var analyzerImport model.AnalyzerImport
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&analyzerImport, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("analyzer_import", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get analyzer import")
+	}
+	return &analyzerImport, nil
+}
+
+// CreateAnalyzerImport 検査装置の結果を登録
+func (r *analyzerImportRepository) CreateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(analyzerImport).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create analyzer import")
+	}
+	return nil
+}
+
+// UpdateAnalyzerImport 検査装置の結果を更新
+func (r *analyzerImportRepository) UpdateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(analyzerImport).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update analyzer import")
+	}
+	return nil
+}
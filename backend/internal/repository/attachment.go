@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// AttachmentRepository 添付ファイルリポジトリインターフェース
+type AttachmentRepository interface {
+	GetAttachmentsByMedicalRecordID(ctx context.Context, medicalRecordID uuid.UUID) ([]model.Attachment, error)
+	GetAttachmentsByExaminationID(ctx context.Context, examinationID uuid.UUID) ([]model.Attachment, error)
+	GetAttachmentByID(ctx context.Context, id uuid.UUID) (*model.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment *model.Attachment) error
+	DeleteAttachment(ctx context.Context, id uuid.UUID) error
+}
+
+// attachmentRepository 添付ファイルリポジトリ実装
+type attachmentRepository struct {
+	db *gorm.DB
+}
+
+// NewAttachmentRepository 新しい添付ファイルリポジトリを作成
+func NewAttachmentRepository(db *gorm.DB) AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// GetAttachmentsByMedicalRecordID カルテの添付ファイルを登録順に取得（カルテに紐付く検査の添付を含む）
+func (r *attachmentRepository) GetAttachmentsByMedicalRecordID(ctx context.Context, medicalRecordID uuid.UUID) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := conn(ctx, r.db).
+		Where("medical_record_id = ?", medicalRecordID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get attachments")
+	}
+	return attachments, nil
+}
+
+// GetAttachmentsByExaminationID 検査の添付ファイルを登録順に取得
+func (r *attachmentRepository) GetAttachmentsByExaminationID(ctx context.Context, examinationID uuid.UUID) ([]model.Attachment, error) {
+	var attachments []model.Attachment
+	if err := conn(ctx, r.db).
+		Where("examination_id = ?", examinationID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get attachments")
+	}
+	return attachments, nil
+}
+
+// GetAttachmentByID IDで添付ファイルを取得
+func (r *attachmentRepository) GetAttachmentByID(ctx context.Context, id uuid.UUID) (*model.Attachment, error) {
+	var attachment model.Attachment
+	if err := conn(ctx, r.db).First(&attachment, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("attachment", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get attachment")
+	}
+	return &attachment, nil
+}
+
+// CreateAttachment 添付ファイルを登録
+func (r *attachmentRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(attachment).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create attachment")
+	}
+	return nil
+}
+
+// DeleteAttachment 添付ファイルを削除
+func (r *attachmentRepository) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.Attachment{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete attachment")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("attachment", id.String())
+	}
+	return nil
+}
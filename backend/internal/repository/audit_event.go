@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// defaultAuditEventLimit 監査ログ検索の既定の最大件数
+const defaultAuditEventLimit = 100
+
+// AuditEventRepository 監査ログリポジトリインターフェース
+type AuditEventRepository interface {
+	ListAuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]model.AuditEvent, error)
+}
+
+// auditEventRepository 監査ログリポジトリ実装
+type auditEventRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditEventRepository 新しい監査ログリポジトリを作成
+func NewAuditEventRepository(db *gorm.DB) AuditEventRepository {
+	return &auditEventRepository{db: db}
+}
+
+// ListAuditEvents 条件に一致する監査ログを新しい順に取得
+func (r *auditEventRepository) ListAuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]model.AuditEvent, error) {
+	query := conn(ctx, r.db).Model(&model.AuditEvent{})
+
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultAuditEventLimit
+	}
+
+	var events []model.AuditEvent
+	if err := query.Order("created_at DESC").Limit(limit).Find(&events).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to list audit events")
+	}
+	return events, nil
+}
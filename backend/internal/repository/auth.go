@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// AuthRepository 認証（スタッフ・リフレッシュトークン）リポジトリインターフェース
+type AuthRepository interface {
+	GetStaffByID(ctx context.Context, id uuid.UUID) (*model.Staff, error)
+	GetStaffByEmail(ctx context.Context, email string) (*model.Staff, error)
+	CreateStaff(ctx context.Context, staff *model.Staff) error
+	UpdateStaff(ctx context.Context, staff *model.Staff) error
+	CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (*model.RefreshToken, error)
+	UpdateRefreshToken(ctx context.Context, token *model.RefreshToken) error
+	RevokeRefreshTokensByStaffID(ctx context.Context, staffID uuid.UUID, revokedAt time.Time) error
+}
+
+// authRepository 認証リポジトリ実装
+type authRepository struct {
+	db *gorm.DB
+}
+
+// NewAuthRepository 新しい認証リポジトリを作成
+func NewAuthRepository(db *gorm.DB) AuthRepository {
+	return &authRepository{db: db}
+}
+
+// GetStaffByID IDでスタッフを取得
+func (r *authRepository) GetStaffByID(ctx context.Context, id uuid.UUID) (*model.Staff, error) {
+	var staff model.Staff
+	if err := conn(ctx, r.db).First(&staff, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("staff", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get staff")
+	}
+	return &staff, nil
+}
+
+// GetStaffByEmail メールアドレス（大文字小文字を区別しない）でスタッフを取得
+func (r *authRepository) GetStaffByEmail(ctx context.Context, email string) (*model.Staff, error) {
+	var staff model.Staff
+	if err := conn(ctx, r.db).First(&staff, "LOWER(email) = LOWER(?)", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("staff", email)
+		}
+		return nil, apperrors.Wrap(err, "failed to get staff")
+	}
+	return &staff, nil
+}
+
+// CreateStaff スタッフを作成
+func (r *authRepository) CreateStaff(ctx context.Context, staff *model.Staff) error {
+	if err := conn(ctx, r.db).Create(staff).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create staff")
+	}
+	return nil
+}
+
+// UpdateStaff スタッフを更新
+func (r *authRepository) UpdateStaff(ctx context.Context, staff *model.Staff) error {
+	if err := conn(ctx, r.db).Omit("Clinic").Save(staff).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update staff")
+	}
+	return nil
+}
+
+// CreateRefreshToken リフレッシュトークンを作成
+func (r *authRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	if err := conn(ctx, r.db).Create(token).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create refresh token")
+	}
+	return nil
+}
+
+// GetRefreshTokenByHashForUpdate ハッシュでリフレッシュトークンを行ロック付きで取得
+// トランザクション内で呼び出すこと。同じトークンの同時使用を直列化する。
+func (r *authRepository) GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&token, "token_hash = ?", tokenHash).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("refresh_token", "")
+		}
+		return nil, apperrors.Wrap(err, "failed to get refresh token")
+	}
+	return &token, nil
+}
+
+// UpdateRefreshToken リフレッシュトークンを更新
+func (r *authRepository) UpdateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	if err := conn(ctx, r.db).Omit("Staff").Save(token).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update refresh token")
+	}
+	return nil
+}
+
+// RevokeRefreshTokensByStaffID スタッフの有効なリフレッシュトークンを全て失効させる
+func (r *authRepository) RevokeRefreshTokensByStaffID(ctx context.Context, staffID uuid.UUID, revokedAt time.Time) error {
+	if err := conn(ctx, r.db).
+		Model(&model.RefreshToken{}).
+		Where("staff_id = ? AND revoked_at IS NULL", staffID).
+		Update("revoked_at", revokedAt).Error; err != nil {
+		return apperrors.Wrap(err, "failed to revoke refresh tokens")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ClinicRepository クリニック情報リポジトリインターフェース
+type ClinicRepository interface {
+	GetClinic(ctx context.Context) (*model.Clinic, error)
+}
+
+// clinicRepository クリニック情報リポジトリ実装
+type clinicRepository struct {
+	db *gorm.DB
+}
+
+// NewClinicRepository 新しいクリニック情報リポジトリを作成
+func NewClinicRepository(db *gorm.DB) ClinicRepository {
+	return &clinicRepository{db: db}
+}
+
+// GetClinic 自院のクリニック情報を取得（複数登録されている場合は最初に登録されたもの）
+func (r *clinicRepository) GetClinic(ctx context.Context) (*model.Clinic, error) {
+	var clinic model.Clinic
+	if err := conn(ctx, r.db).Order("created_at ASC").First(&clinic).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("clinic", "")
+		}
+		return nil, apperrors.Wrap(err, "failed to get clinic")
+	}
+	return &clinic, nil
+}
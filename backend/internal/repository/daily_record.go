@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// DailyRecordRepository 入院日次記録（バイタル・ケアログ・スタッフメモ）リポジトリインターフェース
+type DailyRecordRepository interface {
+	GetDailyRecords(ctx context.Context, hospitalizationID uuid.UUID) ([]model.DailyRecord, error)
+	GetDailyRecordsByDate(ctx context.Context, hospitalizationIDs []uuid.UUID, date time.Time) ([]model.DailyRecord, error)
+	GetOrCreateDailyRecord(ctx context.Context, hospitalizationID uuid.UUID, date time.Time) (*model.DailyRecord, error)
+	CreateVital(ctx context.Context, vital *model.Vital) error
+	CreateCareLog(ctx context.Context, log *model.CareLog) error
+	CreateStaffNote(ctx context.Context, note *model.StaffNote) error
+}
+
+// dailyRecordRepository 入院日次記録リポジトリ実装
+type dailyRecordRepository struct {
+	db *gorm.DB
+}
+
+// NewDailyRecordRepository 新しい日次記録リポジトリを作成
+func NewDailyRecordRepository(db *gorm.DB) DailyRecordRepository {
+	return &dailyRecordRepository{db: db}
+}
+
+// preloadDailyRecordEntries 日次記録の各記録を記録時刻順に読み込む
+func preloadDailyRecordEntries(db *gorm.DB) *gorm.DB {
+	byTime := func(db *gorm.DB) *gorm.DB {
+		return db.Order("recorded_time ASC, created_at ASC")
+	}
+	return db.
+		Preload("Vitals", byTime).
+		Preload("CareLogs", byTime).
+		Preload("StaffNotes", byTime)
+}
+
+// GetDailyRecords 入院の日次記録を日付順に取得
+func (r *dailyRecordRepository) GetDailyRecords(ctx context.Context, hospitalizationID uuid.UUID) ([]model.DailyRecord, error) {
+	var records []model.DailyRecord
+	if err := preloadDailyRecordEntries(conn(ctx, r.db)).
+		Where("hospitalization_id = ?", hospitalizationID).
+		Order("record_date ASC").
+		Find(&records).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get daily records")
+	}
+	return records, nil
+}
+
+// GetDailyRecordsByDate 指定日の日次記録を入院ごとにまとめて取得
+func (r *dailyRecordRepository) GetDailyRecordsByDate(ctx context.Context, hospitalizationIDs []uuid.UUID, date time.Time) ([]model.DailyRecord, error) {
+	var records []model.DailyRecord
+	if len(hospitalizationIDs) == 0 {
+		return records, nil
+	}
+	if err := preloadDailyRecordEntries(conn(ctx, r.db)).
+		Where("hospitalization_id IN ? AND record_date = ?", hospitalizationIDs, date).
+		Find(&records).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get daily records")
+	}
+	return records, nil
+}
+
+// GetOrCreateDailyRecord 入院の指定日の日次記録を取得し、なければ作成する
+// 同時に最初の記録が書き込まれても1日1件になるよう、一意制約に対して INSERT ... ON CONFLICT DO NOTHING を使う。
+func (r *dailyRecordRepository) GetOrCreateDailyRecord(ctx context.Context, hospitalizationID uuid.UUID, date time.Time) (*model.DailyRecord, error) {
+	db := conn(ctx, r.db)
+	record := model.DailyRecord{HospitalizationID: hospitalizationID, RecordDate: date}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&record).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to create daily record")
+	}
+
+	var existing model.DailyRecord
+	if err := db.First(&existing, "hospitalization_id = ? AND record_date = ?", hospitalizationID, date).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("daily_record", date.Format("2006-01-02"))
+		}
+		return nil, apperrors.Wrap(err, "failed to get daily record")
+	}
+	return &existing, nil
+}
+
+// CreateVital バイタルを記録
+func (r *dailyRecordRepository) CreateVital(ctx context.Context, vital *model.Vital) error {
+	if err := conn(ctx, r.db).Create(vital).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create vital")
+	}
+	return nil
+}
+
+// CreateCareLog ケアログを記録
+func (r *dailyRecordRepository) CreateCareLog(ctx context.Context, log *model.CareLog) error {
+	if err := conn(ctx, r.db).Create(log).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create care log")
+	}
+	return nil
+}
+
+// CreateStaffNote スタッフメモを記録
+func (r *dailyRecordRepository) CreateStaffNote(ctx context.Context, note *model.StaffNote) error {
+	if err := conn(ctx, r.db).Create(note).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create staff note")
+	}
+	return nil
+}
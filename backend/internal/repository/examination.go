@@ -0,0 +1,267 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ExaminationRepository 検査・基準範囲リポジトリインターフェース
+type ExaminationRepository interface {
+	EnsureAccessionNoSequence(ctx context.Context) error
+	ListExaminations(ctx context.Context, filter model.ExaminationFilter, opts model.ListOptions) (*model.ListResult[model.Examination], error)
+	GetExaminationByID(ctx context.Context, id uuid.UUID) (*model.Examination, error)
+	GetExaminationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Examination, error)
+	GetExaminationByAccessionNoForUpdate(ctx context.Context, accessionNo int) (*model.Examination, error)
+	FindPendingExaminationsByPetNumber(ctx context.Context, petNumber string) ([]model.Examination, error)
+	GetCompletedExaminationsWithAnalyte(ctx context.Context, petID uuid.UUID, analyte string, dateRange model.DateRange) ([]model.Examination, error)
+	CreateExamination(ctx context.Context, examination *model.Examination) error
+	UpdateExamination(ctx context.Context, examination *model.Examination) error
+	ListLabReferenceRanges(ctx context.Context, analyte, species string) ([]model.LabReferenceRange, error)
+	FindLabReferenceRanges(ctx context.Context, analytes []string, species string) ([]model.LabReferenceRange, error)
+	GetLabReferenceRangeForUpdate(ctx context.Context, analyte, species string) (*model.LabReferenceRange, error)
+	CreateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error
+	UpdateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error
+	DeleteLabReferenceRange(ctx context.Context, id uuid.UUID) error
+}
+
+// examinationRepository 検査・基準範囲リポジトリ実装
+type examinationRepository struct {
+	db *gorm.DB
+}
+
+// NewExaminationRepository 新しい検査・基準範囲リポジトリを作成
+func NewExaminationRepository(db *gorm.DB) ExaminationRepository {
+	return &examinationRepository{db: db}
+}
+
+// accessionNoSequence 検体番号の採番に使うシーケンス
+const accessionNoSequence = "examination_accession_no_seq"
+
+// EnsureAccessionNoSequence 検体番号のシーケンスを作成し、列の既定値にする
+// マイグレーションSQLを適用していないデータベース（AutoMigrateのみ）では列に既定値がなく検体番号が採番されないため、
+// 起動時にそろえる。検体番号のない既存の検査にも採番する。
+func (r *examinationRepository) EnsureAccessionNoSequence(ctx context.Context) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, sql := range []string{
+			"CREATE SEQUENCE IF NOT EXISTS " + accessionNoSequence + " START WITH 100001",
+			"ALTER TABLE examinations ALTER COLUMN accession_no SET DEFAULT nextval('" + accessionNoSequence + "')",
+			"UPDATE examinations SET accession_no = nextval('" + accessionNoSequence + "') WHERE accession_no IS NULL",
+			"ALTER TABLE examinations ALTER COLUMN accession_no SET NOT NULL",
+		} {
+			if err := tx.Exec(sql).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return apperrors.Wrap(err, "failed to prepare examination accession number sequence")
+	}
+	return nil
+}
+
+// examinationListSpec 検査一覧の並び替え可能な列
+var examinationListSpec = listSpec[model.Examination]{
+	columns: map[string]sortColumn[model.Examination]{
+		"examination_date": {column: "examination_date", value: func(e *model.Examination) any { return e.ExaminationDate }},
+		"created_at":       {column: "created_at", value: func(e *model.Examination) any { return e.CreatedAt }},
+		"updated_at":       {column: "updated_at", value: func(e *model.Examination) any { return e.UpdatedAt }},
+	},
+	defaultSort: "-examination_date",
+	id:          sortColumn[model.Examination]{column: "id", value: func(e *model.Examination) any { return e.ID }},
+}
+
+// ListExaminations 条件に一致する検査を1ページ分取得
+func (r *examinationRepository) ListExaminations(ctx context.Context, filter model.ExaminationFilter, opts model.ListOptions) (*model.ListResult[model.Examination], error) {
+	query := conn(ctx, r.db).Model(&model.Examination{})
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.MedicalRecordID != nil {
+		query = query.Where("medical_record_id = ?", *filter.MedicalRecordID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	query = whereDateRange(query, "examination_date", filter.ExaminationDate)
+	return findPage(query, examinationListSpec, opts, "Pet", "Owner")
+}
+
+// GetExaminationByID IDで検査を取得
+func (r *examinationRepository) GetExaminationByID(ctx context.Context, id uuid.UUID) (*model.Examination, error) {
+	var examination model.Examination
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		First(&examination, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("examination", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get examination")
+	}
+	return &examination, nil
+}
+
+// GetExaminationByIDForUpdate IDで検査を行ロック付きで取得（トランザクション内で使う）
+func (r *examinationRepository) GetExaminationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Examination, error) {
+	var examination model.Examination
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&examination, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("examination", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get examination")
+	}
+	return &examination, nil
+}
+
+// GetExaminationByAccessionNoForUpdate 検体番号で検査を行ロック付きで取得（トランザクション内で使う）
+func (r *examinationRepository) GetExaminationByAccessionNoForUpdate(ctx context.Context, accessionNo int) (*model.Examination, error) {
+	var examination model.Examination
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&examination, "accession_no = ?", accessionNo).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("examination", strconv.Itoa(accessionNo))
+		}
+		return nil, apperrors.Wrap(err, "failed to get examination by accession number")
+	}
+	return &examination, nil
+}
+
+// FindPendingExaminationsByPetNumber ペット番号のペットの結果待ち（依頼中・検査中）の検査を行ロック付きで取得
+func (r *examinationRepository) FindPendingExaminationsByPetNumber(ctx context.Context, petNumber string) ([]model.Examination, error) {
+	var examinations []model.Examination
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE", Table: clause.Table{Name: "examinations"}}).
+		Joins("JOIN pets ON pets.id = examinations.pet_id").
+		Where("pets.pet_number = ? AND examinations.status IN ?", petNumber,
+			[]string{model.ExaminationStatusOrdered, model.ExaminationStatusInProgress}).
+		Order("examinations.examination_date DESC").
+		Find(&examinations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find pending examinations")
+	}
+	return examinations, nil
+}
+
+// GetCompletedExaminationsWithAnalyte ペットの完了した検査のうち、指定の検査項目を含むものを検査日の古い順に取得
+func (r *examinationRepository) GetCompletedExaminationsWithAnalyte(ctx context.Context, petID uuid.UUID, analyte string, dateRange model.DateRange) ([]model.Examination, error) {
+	contains, err := json.Marshal([]map[string]string{{"analyte": analyte}})
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to build analyte filter")
+	}
+	query := conn(ctx, r.db).
+		Where("pet_id = ? AND status = ?", petID, model.ExaminationStatusCompleted).
+		Where("CAST(items AS jsonb) @> CAST(? AS jsonb)", string(contains))
+	query = whereDateRange(query, "examination_date", dateRange)
+
+	var examinations []model.Examination
+	if err := query.Order("examination_date ASC, id ASC").Find(&examinations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get examinations with analyte")
+	}
+	return examinations, nil
+}
+
+// CreateExamination 検査を作成
+func (r *examinationRepository) CreateExamination(ctx context.Context, examination *model.Examination) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(examination).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create examination")
+	}
+	return nil
+}
+
+// UpdateExamination 検査を更新
+func (r *examinationRepository) UpdateExamination(ctx context.Context, examination *model.Examination) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(examination).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update examination")
+	}
+	return nil
+}
+
+// ListLabReferenceRanges 基準範囲を項目コード・動物種別の順に取得（空の条件は絞り込まない）
+func (r *examinationRepository) ListLabReferenceRanges(ctx context.Context, analyte, species string) ([]model.LabReferenceRange, error) {
+	query := conn(ctx, r.db)
+	if analyte != "" {
+		query = query.Where("analyte = ?", analyte)
+	}
+	if species != "" {
+		query = query.Where("species = ?", species)
+	}
+	var references []model.LabReferenceRange
+	if err := query.Order("analyte ASC, species ASC").Find(&references).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to list lab reference ranges")
+	}
+	return references, nil
+}
+
+// FindLabReferenceRanges 検査項目の基準範囲のうち、動物種別のものと動物種を問わない既定のものを取得
+func (r *examinationRepository) FindLabReferenceRanges(ctx context.Context, analytes []string, species string) ([]model.LabReferenceRange, error) {
+	if len(analytes) == 0 {
+		return nil, nil
+	}
+	var references []model.LabReferenceRange
+	if err := conn(ctx, r.db).
+		Where("analyte IN ? AND species IN ?", analytes, []string{species, ""}).
+		Find(&references).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find lab reference ranges")
+	}
+	return references, nil
+}
+
+// GetLabReferenceRangeForUpdate 項目・動物種別の基準範囲を行ロック付きで取得（未登録ならnil）
+// トランザクション内で呼び出すこと。
+func (r *examinationRepository) GetLabReferenceRangeForUpdate(ctx context.Context, analyte, species string) (*model.LabReferenceRange, error) {
+	var reference model.LabReferenceRange
+	err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("analyte = ? AND species = ?", analyte, species).
+		First(&reference).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap(err, "failed to get lab reference range")
+	}
+	return &reference, nil
+}
+
+// CreateLabReferenceRange 基準範囲を作成
+func (r *examinationRepository) CreateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error {
+	if err := conn(ctx, r.db).Create(reference).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create lab reference range")
+	}
+	return nil
+}
+
+// UpdateLabReferenceRange 基準範囲を更新
+func (r *examinationRepository) UpdateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error {
+	if err := conn(ctx, r.db).Save(reference).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update lab reference range")
+	}
+	return nil
+}
+
+// DeleteLabReferenceRange 基準範囲を削除
+func (r *examinationRepository) DeleteLabReferenceRange(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.LabReferenceRange{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete lab reference range")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("lab_reference_range", id.String())
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// HospitalizationRepository 入院・ケージ・ケアプランリポジトリインターフェース
+type HospitalizationRepository interface {
+	ListHospitalizations(ctx context.Context, filter model.HospitalizationFilter, opts model.ListOptions) (*model.ListResult[model.Hospitalization], error)
+	EnsureHospitalizationNoSequence(ctx context.Context) error
+	NextHospitalizationNo(ctx context.Context) (string, error)
+	GetHospitalizationByID(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error)
+	GetHospitalizationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error)
+	CreateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error
+	UpdateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error
+	DeleteHospitalization(ctx context.Context, id uuid.UUID) error
+	FindOverlappingHospitalizations(ctx context.Context, cageID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Hospitalization, error)
+	FindHospitalizationsOnDate(ctx context.Context, date time.Time) ([]model.Hospitalization, error)
+
+	GetAllCages(ctx context.Context) ([]model.Cage, error)
+	GetCageByID(ctx context.Context, id uuid.UUID) (*model.Cage, error)
+	FindCagesByTypes(ctx context.Context, types []string) ([]model.Cage, error)
+	LockCage(ctx context.Context, cageID uuid.UUID) error
+	UpdateCageAvailability(ctx context.Context, cageID uuid.UUID, available bool) error
+
+	GetCarePlanItems(ctx context.Context, hospitalizationID uuid.UUID) ([]model.CarePlanItem, error)
+	CreateCarePlanItem(ctx context.Context, item *model.CarePlanItem) error
+	DeleteCarePlanItem(ctx context.Context, hospitalizationID, itemID uuid.UUID) error
+}
+
+// hospitalizationRepository 入院・ケージ・ケアプランリポジトリ実装
+type hospitalizationRepository struct {
+	db *gorm.DB
+}
+
+// NewHospitalizationRepository 新しい入院リポジトリを作成
+func NewHospitalizationRepository(db *gorm.DB) HospitalizationRepository {
+	return &hospitalizationRepository{db: db}
+}
+
+// hospitalizationNoSequence 入院番号の採番に使うシーケンス
+const hospitalizationNoSequence = "hospitalization_no_seq"
+
+// EnsureHospitalizationNoSequence 入院番号のシーケンスと一意インデックスを作成する
+// 以前のタイムスタンプによる番号は同じ秒に受け付けた入院で重複しているため、
+// 一意インデックスを作る前に、同じ番号の入院のうち最初のもの以外をシーケンスから採番し直す。
+func (r *hospitalizationRepository) EnsureHospitalizationNoSequence(ctx context.Context) error {
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, sql := range []string{
+			"CREATE SEQUENCE IF NOT EXISTS " + hospitalizationNoSequence,
+			`UPDATE hospitalizations h SET hospitalization_no = 'HP' || LPAD(nextval('` + hospitalizationNoSequence + `')::text, 8, '0')
+			WHERE EXISTS (
+				SELECT 1 FROM hospitalizations o
+				WHERE o.hospitalization_no = h.hospitalization_no AND (o.created_at, o.id) < (h.created_at, h.id)
+			)`,
+			"CREATE UNIQUE INDEX IF NOT EXISTS idx_hospitalization_no ON hospitalizations(hospitalization_no)",
+		} {
+			if err := tx.Exec(sql).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return apperrors.Wrap(err, "failed to prepare hospitalization number sequence")
+	}
+	return nil
+}
+
+// NextHospitalizationNo シーケンスから次の入院番号（HP + 8桁の連番）を採番する
+// 同時に受け付けても番号が重複しない。
+func (r *hospitalizationRepository) NextHospitalizationNo(ctx context.Context) (string, error) {
+	var n int64
+	if err := conn(ctx, r.db).Raw("SELECT nextval(?)", hospitalizationNoSequence).Scan(&n).Error; err != nil {
+		return "", apperrors.Wrap(err, "failed to get next hospitalization number")
+	}
+	return fmt.Sprintf("HP%08d", n), nil
+}
+
+// hospitalizationListSpec 入院一覧の並び替え可能な列
+var hospitalizationListSpec = listSpec[model.Hospitalization]{
+	columns: map[string]sortColumn[model.Hospitalization]{
+		"start_date": {column: "start_date", value: func(h *model.Hospitalization) any { return h.StartDate }},
+		"end_date":   {column: "end_date", value: func(h *model.Hospitalization) any { return h.EndDate }},
+		"created_at": {column: "created_at", value: func(h *model.Hospitalization) any { return h.CreatedAt }},
+	},
+	defaultSort: "-start_date,-created_at",
+	id:          sortColumn[model.Hospitalization]{column: "id", value: func(h *model.Hospitalization) any { return h.ID }},
+}
+
+// ListHospitalizations 条件に一致する入院を1ページ分取得
+func (r *hospitalizationRepository) ListHospitalizations(ctx context.Context, filter model.HospitalizationFilter, opts model.ListOptions) (*model.ListResult[model.Hospitalization], error) {
+	query := conn(ctx, r.db).Model(&model.Hospitalization{})
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	query = whereDateRange(query, "start_date", filter.StartDate)
+	return findPage(query, hospitalizationListSpec, opts, "Pet", "Owner", "Cage")
+}
+
+// GetHospitalizationByID IDで入院をケアプラン付きで取得
+func (r *hospitalizationRepository) GetHospitalizationByID(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error) {
+	var hospitalization model.Hospitalization
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		Preload("Cage").
+		Preload("CarePlanItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&hospitalization, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("hospitalization", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get hospitalization")
+	}
+	return &hospitalization, nil
+}
+
+// GetHospitalizationByIDForUpdate IDで入院をケアプラン付き・行ロック付きで取得
+// トランザクション内で呼び出すこと。
+func (r *hospitalizationRepository) GetHospitalizationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error) {
+	var hospitalization model.Hospitalization
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("Pet").
+		Preload("CarePlanItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		First(&hospitalization, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("hospitalization", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get hospitalization")
+	}
+	return &hospitalization, nil
+}
+
+// CreateHospitalization 入院を作成
+func (r *hospitalizationRepository) CreateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(hospitalization).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create hospitalization")
+	}
+	return nil
+}
+
+// UpdateHospitalization 入院を更新（関連は更新しない）
+func (r *hospitalizationRepository) UpdateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(hospitalization).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update hospitalization")
+	}
+	return nil
+}
+
+// DeleteHospitalization 入院をケアプランとともに削除
+func (r *hospitalizationRepository) DeleteHospitalization(ctx context.Context, id uuid.UUID) error {
+	if err := conn(ctx, r.db).Delete(&model.CarePlanItem{}, "hospitalization_id = ?", id).Error; err != nil {
+		return apperrors.Wrap(err, "failed to delete care plan items")
+	}
+	result := conn(ctx, r.db).Delete(&model.Hospitalization{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete hospitalization")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("hospitalization", id.String())
+	}
+	return nil
+}
+
+// FindOverlappingHospitalizations 指定ケージの入院のうち期間[start, end)と重なるものを取得
+// 退院済の入院とexcludeIDの入院は対象外。開始日と終了日が同じ入院（日帰り）は1日として扱う。
+func (r *hospitalizationRepository) FindOverlappingHospitalizations(ctx context.Context, cageID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Hospitalization, error) {
+	var hospitalizations []model.Hospitalization
+	if err := conn(ctx, r.db).
+		Where("cage_id = ?", cageID).
+		Where("status <> ?", model.HospitalizationStatusDischarged).
+		Where("start_date < ? AND GREATEST(end_date, start_date + 1) > ?", end, start).
+		Where("id <> ?", excludeID).
+		Order("start_date ASC").
+		Find(&hospitalizations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find overlapping hospitalizations")
+	}
+	return hospitalizations, nil
+}
+
+// FindHospitalizationsOnDate 指定日に入院していた（入院中・一時帰宅中・その日以降に退院した）入院をケージ順に取得
+// 予約のままの入院は対象外。
+func (r *hospitalizationRepository) FindHospitalizationsOnDate(ctx context.Context, date time.Time) ([]model.Hospitalization, error) {
+	var hospitalizations []model.Hospitalization
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Cage").
+		Preload("CarePlanItems", func(db *gorm.DB) *gorm.DB {
+			return db.Order("created_at ASC")
+		}).
+		Joins("LEFT JOIN cages ON cages.id = hospitalizations.cage_id").
+		Where("hospitalizations.status <> ?", model.HospitalizationStatusReserved).
+		Where("hospitalizations.start_date <= ?", date).
+		Where("hospitalizations.status <> ? OR hospitalizations.end_date >= ?", model.HospitalizationStatusDischarged, date).
+		Order("cages.code ASC, hospitalizations.start_date ASC").
+		Find(&hospitalizations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find hospitalizations on date")
+	}
+	return hospitalizations, nil
+}
+
+// GetAllCages 全てのケージをコード順に取得
+func (r *hospitalizationRepository) GetAllCages(ctx context.Context) ([]model.Cage, error) {
+	var cages []model.Cage
+	if err := conn(ctx, r.db).Order("code ASC").Find(&cages).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get cages")
+	}
+	return cages, nil
+}
+
+// GetCageByID IDでケージを取得
+func (r *hospitalizationRepository) GetCageByID(ctx context.Context, id uuid.UUID) (*model.Cage, error) {
+	var cage model.Cage
+	if err := conn(ctx, r.db).First(&cage, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("cage", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get cage")
+	}
+	return &cage, nil
+}
+
+// FindCagesByTypes 指定種別のケージをコード順に取得（typesが空なら全て）
+func (r *hospitalizationRepository) FindCagesByTypes(ctx context.Context, types []string) ([]model.Cage, error) {
+	q := conn(ctx, r.db)
+	if len(types) > 0 {
+		q = q.Where("type IN ?", types)
+	}
+	var cages []model.Cage
+	if err := q.Order("code ASC").Find(&cages).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find cages")
+	}
+	return cages, nil
+}
+
+// LockCage ケージ単位のアドバイザリロックを取得する
+// トランザクション内で呼び出すこと。ロックはトランザクション終了時に解放される。
+func (r *hospitalizationRepository) LockCage(ctx context.Context, cageID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "cage:"+cageID.String()).Error; err != nil {
+		return apperrors.Wrap(err, "failed to lock cage")
+	}
+	return nil
+}
+
+// UpdateCageAvailability ケージの使用状況を更新
+func (r *hospitalizationRepository) UpdateCageAvailability(ctx context.Context, cageID uuid.UUID, available bool) error {
+	result := conn(ctx, r.db).Model(&model.Cage{ID: cageID}).Update("is_available", available)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to update cage")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("cage", cageID.String())
+	}
+	return nil
+}
+
+// GetCarePlanItems 入院のケアプラン項目を登録順に取得
+func (r *hospitalizationRepository) GetCarePlanItems(ctx context.Context, hospitalizationID uuid.UUID) ([]model.CarePlanItem, error) {
+	var items []model.CarePlanItem
+	if err := conn(ctx, r.db).
+		Where("hospitalization_id = ?", hospitalizationID).
+		Order("created_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get care plan items")
+	}
+	return items, nil
+}
+
+// CreateCarePlanItem ケアプラン項目を作成
+func (r *hospitalizationRepository) CreateCarePlanItem(ctx context.Context, item *model.CarePlanItem) error {
+	if err := conn(ctx, r.db).Create(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create care plan item")
+	}
+	return nil
+}
+
+// DeleteCarePlanItem ケアプラン項目を削除
+func (r *hospitalizationRepository) DeleteCarePlanItem(ctx context.Context, hospitalizationID, itemID uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.CarePlanItem{}, "id = ? AND hospitalization_id = ?", itemID, hospitalizationID)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete care plan item")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("care_plan_item", itemID.String())
+	}
+	return nil
+}
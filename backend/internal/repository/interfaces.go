@@ -10,7 +10,7 @@ import (
 
 // PetRepository defines the interface for pet data access operations.
 type PetRepository interface {
-	GetAllPets(ctx context.Context) ([]model.Pet, error)
+	ListPets(ctx context.Context, filter model.PetFilter, opts model.ListOptions) (*model.ListResult[model.Pet], error)
 	GetPetByID(ctx context.Context, id uuid.UUID) (*model.Pet, error)
 	CreatePet(ctx context.Context, pet *model.Pet) error
 	UpdatePet(ctx context.Context, pet *model.Pet) error
@@ -19,7 +19,7 @@ type PetRepository interface {
 
 // OwnerRepository defines the interface for owner data access operations.
 type OwnerRepository interface {
-	GetAllOwners(ctx context.Context) ([]model.Owner, error)
+	ListOwners(ctx context.Context, filter model.OwnerFilter, opts model.ListOptions) (*model.ListResult[model.Owner], error)
 	GetOwnerByID(ctx context.Context, id uuid.UUID) (*model.Owner, error)
 	CreateOwner(ctx context.Context, owner *model.Owner) error
 	UpdateOwner(ctx context.Context, owner *model.Owner) error
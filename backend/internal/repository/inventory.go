@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// InventoryRepository 在庫リポジトリインターフェース
+type InventoryRepository interface {
+	GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error)
+	GetInventoryItemByID(ctx context.Context, id uuid.UUID) (*model.InventoryItem, error)
+	GetInventoryItemsForUpdate(ctx context.Context, ids []uuid.UUID) ([]model.InventoryItem, error)
+	FindLowStockItems(ctx context.Context) ([]model.InventoryItem, error)
+	CreateInventoryItem(ctx context.Context, item *model.InventoryItem) error
+	UpdateInventoryItem(ctx context.Context, item *model.InventoryItem) error
+
+	GetLots(ctx context.Context, itemID uuid.UUID, inStockOnly bool) ([]model.InventoryLot, error)
+	GetLotByID(ctx context.Context, id uuid.UUID) (*model.InventoryLot, error)
+	FindLotByNumber(ctx context.Context, itemID uuid.UUID, lotNumber string) (*model.InventoryLot, error)
+	FindExpiringLots(ctx context.Context, until time.Time) ([]model.InventoryLot, error)
+	CreateLot(ctx context.Context, lot *model.InventoryLot) error
+	UpdateLot(ctx context.Context, lot *model.InventoryLot) error
+
+	GetStockMovements(ctx context.Context, itemID uuid.UUID) ([]model.StockMovement, error)
+	CreateStockMovement(ctx context.Context, movement *model.StockMovement) error
+}
+
+// fefoOrder 使用期限の近い順（期限なしは最後、同じ期限は入庫順）
+const fefoOrder = "expiry_date ASC NULLS LAST, received_at ASC, created_at ASC"
+
+// inventoryRepository 在庫リポジトリ実装
+type inventoryRepository struct {
+	db *gorm.DB
+}
+
+// NewInventoryRepository 新しい在庫リポジトリを作成
+func NewInventoryRepository(db *gorm.DB) InventoryRepository {
+	return &inventoryRepository{db: db}
+}
+
+// GetAllInventoryItems 全ての在庫品目を名前順に取得
+func (r *inventoryRepository) GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error) {
+	var items []model.InventoryItem
+	if err := conn(ctx, r.db).Order("name ASC").Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get inventory items")
+	}
+	return items, nil
+}
+
+// GetInventoryItemByID IDで在庫品目を取得
+func (r *inventoryRepository) GetInventoryItemByID(ctx context.Context, id uuid.UUID) (*model.InventoryItem, error) {
+	var item model.InventoryItem
+	if err := conn(ctx, r.db).First(&item, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("inventory_item", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get inventory item")
+	}
+	return &item, nil
+}
+
+// GetInventoryItemsForUpdate 在庫品目を行ロック付きで取得
+// トランザクション内で呼び出すこと。デッドロックを避けるためID順にロックする。
+func (r *inventoryRepository) GetInventoryItemsForUpdate(ctx context.Context, ids []uuid.UUID) ([]model.InventoryItem, error) {
+	var items []model.InventoryItem
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id IN ?", ids).
+		Order("id ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to lock inventory items")
+	}
+	return items, nil
+}
+
+// FindLowStockItems 在庫数が最低在庫数以下の品目を在庫切れ・在庫数の少ない順に取得
+func (r *inventoryRepository) FindLowStockItems(ctx context.Context) ([]model.InventoryItem, error) {
+	var items []model.InventoryItem
+	if err := conn(ctx, r.db).
+		Where("quantity <= 0 OR quantity <= min_stock_level").
+		Order("quantity - min_stock_level ASC, name ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get low stock items")
+	}
+	return items, nil
+}
+
+// CreateInventoryItem 在庫品目を作成
+func (r *inventoryRepository) CreateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	if err := conn(ctx, r.db).Create(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create inventory item")
+	}
+	return nil
+}
+
+// UpdateInventoryItem 在庫品目を更新
+func (r *inventoryRepository) UpdateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	if err := conn(ctx, r.db).Save(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update inventory item")
+	}
+	return nil
+}
+
+// GetLots 在庫品目のロットを使用期限の近い順に取得（inStockOnly指定時は在庫のあるロットのみ）
+func (r *inventoryRepository) GetLots(ctx context.Context, itemID uuid.UUID, inStockOnly bool) ([]model.InventoryLot, error) {
+	var lots []model.InventoryLot
+	query := conn(ctx, r.db).Where("inventory_item_id = ?", itemID)
+	if inStockOnly {
+		query = query.Where("quantity > 0")
+	}
+	if err := query.Order(fefoOrder).Find(&lots).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get inventory lots")
+	}
+	return lots, nil
+}
+
+// GetLotByID IDでロットを取得
+func (r *inventoryRepository) GetLotByID(ctx context.Context, id uuid.UUID) (*model.InventoryLot, error) {
+	var lot model.InventoryLot
+	if err := conn(ctx, r.db).First(&lot, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("inventory_lot", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get inventory lot")
+	}
+	return &lot, nil
+}
+
+// FindLotByNumber 在庫品目のロットをロット番号で取得
+func (r *inventoryRepository) FindLotByNumber(ctx context.Context, itemID uuid.UUID, lotNumber string) (*model.InventoryLot, error) {
+	var lot model.InventoryLot
+	if err := conn(ctx, r.db).
+		Where("inventory_item_id = ? AND lot_number = ?", itemID, lotNumber).
+		First(&lot).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("inventory_lot", lotNumber)
+		}
+		return nil, apperrors.Wrap(err, "failed to get inventory lot")
+	}
+	return &lot, nil
+}
+
+// FindExpiringLots 使用期限がuntil以前で在庫のあるロットを期限の近い順に品目付きで取得
+func (r *inventoryRepository) FindExpiringLots(ctx context.Context, until time.Time) ([]model.InventoryLot, error) {
+	var lots []model.InventoryLot
+	if err := conn(ctx, r.db).
+		Preload("InventoryItem").
+		Where("quantity > 0 AND expiry_date IS NOT NULL AND expiry_date <= ?", until).
+		Order(fefoOrder).
+		Find(&lots).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get expiring lots")
+	}
+	return lots, nil
+}
+
+// CreateLot ロットを作成
+func (r *inventoryRepository) CreateLot(ctx context.Context, lot *model.InventoryLot) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(lot).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create inventory lot")
+	}
+	return nil
+}
+
+// UpdateLot ロットを更新
+func (r *inventoryRepository) UpdateLot(ctx context.Context, lot *model.InventoryLot) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(lot).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update inventory lot")
+	}
+	return nil
+}
+
+// GetStockMovements 在庫品目の入出庫履歴を新しい順に取得
+func (r *inventoryRepository) GetStockMovements(ctx context.Context, itemID uuid.UUID) ([]model.StockMovement, error) {
+	var movements []model.StockMovement
+	if err := conn(ctx, r.db).
+		Where("inventory_item_id = ?", itemID).
+		Order("created_at DESC").
+		Find(&movements).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get stock movements")
+	}
+	return movements, nil
+}
+
+// CreateStockMovement 入出庫履歴を作成
+func (r *inventoryRepository) CreateStockMovement(ctx context.Context, movement *model.StockMovement) error {
+	if err := conn(ctx, r.db).Create(movement).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create stock movement")
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MasterItemRepository 診療項目マスタリポジトリインターフェース
+type MasterItemRepository interface {
+	GetMasterItemByID(ctx context.Context, id uuid.UUID) (*model.MasterItem, error)
+	GetMasterItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]model.MasterItem, error)
+	GetMasterItems(ctx context.Context, filter model.MasterItemFilter) ([]model.MasterItem, error)
+	GetMasterItemsByCodes(ctx context.Context, codes []string) ([]model.MasterItem, error)
+	GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error)
+	CreateMasterItem(ctx context.Context, item *model.MasterItem) error
+	UpdateMasterItem(ctx context.Context, item *model.MasterItem) error
+	GetMasterItemPrices(ctx context.Context, masterItemID uuid.UUID) ([]model.MasterItemPrice, error)
+	GetEffectivePrices(ctx context.Context, masterItemIDs []uuid.UUID, date time.Time) ([]model.MasterItemPrice, error)
+	SaveMasterItemPrice(ctx context.Context, price *model.MasterItemPrice) error
+}
+
+// masterItemRepository 診療項目マスタリポジトリ実装
+type masterItemRepository struct {
+	db *gorm.DB
+}
+
+// NewMasterItemRepository 新しい診療項目マスタリポジトリを作成
+func NewMasterItemRepository(db *gorm.DB) MasterItemRepository {
+	return &masterItemRepository{db: db}
+}
+
+// GetMasterItemByID IDで診療項目マスタを取得
+func (r *masterItemRepository) GetMasterItemByID(ctx context.Context, id uuid.UUID) (*model.MasterItem, error) {
+	var item model.MasterItem
+	if err := conn(ctx, r.db).First(&item, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("master_item", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get master item")
+	}
+	return &item, nil
+}
+
+// GetMasterItemsByIDs 複数のIDで診療項目マスタを取得（存在しないIDは無視する）
+func (r *masterItemRepository) GetMasterItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]model.MasterItem, error) {
+	var items []model.MasterItem
+	if err := conn(ctx, r.db).Where("id IN ?", ids).Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get master items")
+	}
+	return items, nil
+}
+
+// GetMasterItems 条件に合う診療項目マスタを区分・コード順に取得
+func (r *masterItemRepository) GetMasterItems(ctx context.Context, filter model.MasterItemFilter) ([]model.MasterItem, error) {
+	query := conn(ctx, r.db)
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Query != "" {
+		like := "%" + filter.Query + "%"
+		query = query.Where("code ILIKE ? OR name ILIKE ?", like, like)
+	}
+
+	var items []model.MasterItem
+	if err := query.Order("category ASC, code ASC, name ASC").Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get master items")
+	}
+	return items, nil
+}
+
+// GetMasterItemsByCodes 複数のコードで診療項目マスタを取得（存在しないコードは無視する）
+func (r *masterItemRepository) GetMasterItemsByCodes(ctx context.Context, codes []string) ([]model.MasterItem, error) {
+	var items []model.MasterItem
+	if err := conn(ctx, r.db).Where("code IN ?", codes).Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get master items")
+	}
+	return items, nil
+}
+
+// GetMasterCategories マスタ区分ごとの件数を取得
+func (r *masterItemRepository) GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error) {
+	var summaries []model.MasterCategorySummary
+	err := conn(ctx, r.db).Model(&model.MasterItem{}).
+		Select("category, COUNT(*) AS count, COUNT(*) FILTER (WHERE status = ?) AS active_count", model.MasterStatusActive).
+		Group("category").
+		Order("category ASC").
+		Scan(&summaries).Error
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get master categories")
+	}
+	return summaries, nil
+}
+
+// CreateMasterItem 診療項目マスタを作成
+func (r *masterItemRepository) CreateMasterItem(ctx context.Context, item *model.MasterItem) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create master item")
+	}
+	return nil
+}
+
+// UpdateMasterItem 診療項目マスタを更新
+func (r *masterItemRepository) UpdateMasterItem(ctx context.Context, item *model.MasterItem) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update master item")
+	}
+	return nil
+}
+
+// GetMasterItemPrices 診療項目マスタの価格履歴を適用開始日の新しい順に取得
+func (r *masterItemRepository) GetMasterItemPrices(ctx context.Context, masterItemID uuid.UUID) ([]model.MasterItemPrice, error) {
+	var prices []model.MasterItemPrice
+	err := conn(ctx, r.db).
+		Where("master_item_id = ?", masterItemID).
+		Order("effective_from DESC").
+		Find(&prices).Error
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get master item prices")
+	}
+	return prices, nil
+}
+
+// GetEffectivePrices 指定日に有効な価格（適用開始日が指定日以前で最新のもの）をマスタごとに取得
+// 指定日より前の価格履歴がないマスタは結果に含まれない。
+func (r *masterItemRepository) GetEffectivePrices(ctx context.Context, masterItemIDs []uuid.UUID, date time.Time) ([]model.MasterItemPrice, error) {
+	var prices []model.MasterItemPrice
+	err := conn(ctx, r.db).
+		Select("DISTINCT ON (master_item_id) *").
+		Where("master_item_id IN ? AND effective_from <= ?", masterItemIDs, date).
+		Order("master_item_id, effective_from DESC").
+		Find(&prices).Error
+	if err != nil {
+		return nil, apperrors.Wrap(err, "failed to get effective prices")
+	}
+	return prices, nil
+}
+
+// SaveMasterItemPrice 価格履歴を登録（同じ適用開始日の履歴があれば上書きする）
+func (r *masterItemRepository) SaveMasterItemPrice(ctx context.Context, price *model.MasterItemPrice) error {
+	err := conn(ctx, r.db).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "master_item_id"}, {Name: "effective_from"}},
+		DoUpdates: clause.AssignmentColumns([]string{"price", "tax_rate", "source", "created_by", "created_at"}),
+	}).Create(price).Error
+	if err != nil {
+		return apperrors.Wrap(err, "failed to save master item price")
+	}
+	return nil
+}
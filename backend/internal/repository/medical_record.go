@@ -3,8 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	apperrors "github.com/animal-ekarte/backend/internal/errors"
 	"github.com/animal-ekarte/backend/internal/model"
@@ -12,13 +15,23 @@ import (
 
 // MedicalRecordRepository カルテリポジトリインターフェース
 type MedicalRecordRepository interface {
-	GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error)
+	ListMedicalRecords(ctx context.Context, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error)
+	SearchMedicalRecords(ctx context.Context, tsquery string, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error)
+	RefreshSearchIndex(ctx context.Context) (int, error)
+	EnsureRecordNoSequence(ctx context.Context) error
+	NextRecordNo(ctx context.Context) (string, error)
 	GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error)
+	GetMedicalRecordByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.MedicalRecord, error)
 	GetMedicalRecordsByPetID(ctx context.Context, petID string) ([]model.MedicalRecord, error)
 	GetMedicalRecordsByOwnerID(ctx context.Context, ownerID string) ([]model.MedicalRecord, error)
 	CreateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error
 	UpdateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error
 	DeleteMedicalRecord(ctx context.Context, id string) error
+	CreateMedicalRecordAmendments(ctx context.Context, amendments []model.MedicalRecordAmendment) error
+	GetMedicalRecordAmendments(ctx context.Context, recordID string) ([]model.MedicalRecordAmendment, error)
+	GetMedicalRecordItems(ctx context.Context, recordID string) ([]model.MedicalRecordItem, error)
+	CreateMedicalRecordItem(ctx context.Context, item *model.MedicalRecordItem) error
+	DeleteMedicalRecordItem(ctx context.Context, recordID, itemID string) error
 }
 
 // medicalRecordRepository カルテリポジトリ実装
@@ -31,26 +44,103 @@ func NewMedicalRecordRepository(db *gorm.DB) MedicalRecordRepository {
 	return &medicalRecordRepository{db: db}
 }
 
-// GetAllMedicalRecords 全てのカルテを取得
-func (r *medicalRecordRepository) GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error) {
+// medicalRecordListSpec カルテ一覧の並び替え可能な列
+var medicalRecordListSpec = listSpec[model.MedicalRecord]{
+	columns: map[string]sortColumn[model.MedicalRecord]{
+		"visit_date": {column: "visit_date", value: func(m *model.MedicalRecord) any { return m.VisitDate }},
+		"created_at": {column: "created_at", value: func(m *model.MedicalRecord) any { return m.CreatedAt }},
+		"updated_at": {column: "updated_at", value: func(m *model.MedicalRecord) any { return m.UpdatedAt }},
+		"record_no":  {column: "record_no", value: func(m *model.MedicalRecord) any { return m.RecordNo }},
+	},
+	defaultSort: "-visit_date,-created_at",
+	id:          sortColumn[model.MedicalRecord]{column: "id", value: func(m *model.MedicalRecord) any { return m.ID }},
+}
+
+// ListMedicalRecords 条件に一致するカルテを1ページ分取得
+func (r *medicalRecordRepository) ListMedicalRecords(ctx context.Context, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error) {
+	query := medicalRecordFilter(conn(ctx, r.db).Model(&model.MedicalRecord{}), filter)
+	return findPage(query, medicalRecordListSpec, opts, "Pet", "Owner")
+}
+
+// SearchMedicalRecords SOAP・診断・処方の全文検索（tsquery）に一致するカルテを1ページ分取得
+// tsqueryはfulltext.ParseQueryで作ったもの。GINインデックスのあるsearch_bigramsを検索する。
+func (r *medicalRecordRepository) SearchMedicalRecords(ctx context.Context, tsquery string, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error) {
+	query := medicalRecordFilter(conn(ctx, r.db).Model(&model.MedicalRecord{}), filter).
+		Where("search_bigrams @@ ?::tsquery", tsquery)
+	return findPage(query, medicalRecordListSpec, opts, "Pet", "Owner")
+}
+
+// RefreshSearchIndex 全文検索用のバイグラムが未作成のカルテを更新し、更新した件数を返す
+// 全文検索の追加前に登録されたカルテを起動時に補完するためのもの。
+func (r *medicalRecordRepository) RefreshSearchIndex(ctx context.Context) (int, error) {
+	updated := 0
 	var records []model.MedicalRecord
-	result := r.db.WithContext(ctx).
-		Preload("Pet").
-		Preload("Owner").
-		Order("visit_date DESC, created_at DESC").
-		Find(&records)
+	err := conn(ctx, r.db).Where("search_bigrams IS NULL").FindInBatches(&records, 200, func(*gorm.DB, int) error {
+		for i := range records {
+			records[i].UpdateSearchKeys()
+			if err := conn(ctx, r.db).Exec("UPDATE medical_records SET search_bigrams = ?::tsvector WHERE id = ?",
+				records[i].SearchBigrams, records[i].ID).Error; err != nil {
+				return err
+			}
+		}
+		updated += len(records)
+		return nil
+	}).Error
+	if err != nil {
+		return updated, apperrors.Wrap(err, "failed to refresh medical record search index")
+	}
+	return updated, nil
+}
 
-	if result.Error != nil {
-		return nil, result.Error
+// recordNoSequence カルテ番号の採番に使うシーケンス
+const recordNoSequence = "medical_record_no_seq"
+
+// EnsureRecordNoSequence カルテ番号のシーケンスがなければ作成する
+// マイグレーションSQLを適用していないデータベース（AutoMigrateのみ）でも採番できるようにする。
+func (r *medicalRecordRepository) EnsureRecordNoSequence(ctx context.Context) error {
+	if err := conn(ctx, r.db).Exec("CREATE SEQUENCE IF NOT EXISTS " + recordNoSequence).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create medical record number sequence")
 	}
+	return nil
+}
 
-	return records, nil
+// NextRecordNo シーケンスから次のカルテ番号（MR + 8桁の連番）を採番する
+// 同時に受付しても番号が重複しない。
+func (r *medicalRecordRepository) NextRecordNo(ctx context.Context) (string, error) {
+	var n int64
+	if err := conn(ctx, r.db).Raw("SELECT nextval(?)", recordNoSequence).Scan(&n).Error; err != nil {
+		return "", apperrors.Wrap(err, "failed to get next medical record number")
+	}
+	return fmt.Sprintf("MR%08d", n), nil
+}
+
+// medicalRecordFilter カルテの絞り込み条件をクエリに適用する
+func medicalRecordFilter(query *gorm.DB, filter model.MedicalRecordFilter) *gorm.DB {
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.DoctorID != nil {
+		query = query.Where("doctor_id = ?", *filter.DoctorID)
+	}
+	if filter.VisitType != "" {
+		query = query.Where("visit_type = ?", filter.VisitType)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Species != "" {
+		query = query.Where("pet_id IN (SELECT id FROM pets WHERE species = ?)", filter.Species)
+	}
+	return whereDateRange(query, "visit_date", filter.VisitDate)
 }
 
 // GetMedicalRecordByID IDでカルテを取得
 func (r *medicalRecordRepository) GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error) {
 	var record model.MedicalRecord
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Pet").
 		Preload("Owner").
 		First(&record, "id = ?", id)
@@ -65,10 +155,25 @@ func (r *medicalRecordRepository) GetMedicalRecordByID(ctx context.Context, id s
 	return &record, nil
 }
 
+// GetMedicalRecordByIDForUpdate IDでカルテを行ロック付きで取得
+// トランザクション内で呼び出すこと。ステータスの確認と更新（確定・修正）を直列化する。
+func (r *medicalRecordRepository) GetMedicalRecordByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.MedicalRecord, error) {
+	var record model.MedicalRecord
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&record, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("medical record", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get medical record")
+	}
+	return &record, nil
+}
+
 // GetMedicalRecordsByPetID ペットIDでカルテを取得
 func (r *medicalRecordRepository) GetMedicalRecordsByPetID(ctx context.Context, petID string) ([]model.MedicalRecord, error) {
 	var records []model.MedicalRecord
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Pet").
 		Preload("Owner").
 		Where("pet_id = ?", petID).
@@ -85,7 +190,7 @@ func (r *medicalRecordRepository) GetMedicalRecordsByPetID(ctx context.Context,
 // GetMedicalRecordsByOwnerID 飼い主IDでカルテを取得
 func (r *medicalRecordRepository) GetMedicalRecordsByOwnerID(ctx context.Context, ownerID string) ([]model.MedicalRecord, error) {
 	var records []model.MedicalRecord
-	result := r.db.WithContext(ctx).
+	result := conn(ctx, r.db).
 		Preload("Pet").
 		Preload("Owner").
 		Where("owner_id = ?", ownerID).
@@ -101,7 +206,8 @@ func (r *medicalRecordRepository) GetMedicalRecordsByOwnerID(ctx context.Context
 
 // CreateMedicalRecord カルテを作成
 func (r *medicalRecordRepository) CreateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error {
-	result := r.db.WithContext(ctx).Create(record)
+	record.UpdateSearchKeys()
+	result := conn(ctx, r.db).Create(record)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -110,7 +216,8 @@ func (r *medicalRecordRepository) CreateMedicalRecord(ctx context.Context, recor
 
 // UpdateMedicalRecord カルテを更新
 func (r *medicalRecordRepository) UpdateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error {
-	result := r.db.WithContext(ctx).Save(record)
+	record.UpdateSearchKeys()
+	result := conn(ctx, r.db).Save(record)
 	if result.Error != nil {
 		return result.Error
 	}
@@ -119,9 +226,65 @@ func (r *medicalRecordRepository) UpdateMedicalRecord(ctx context.Context, recor
 
 // DeleteMedicalRecord カルテを削除
 func (r *medicalRecordRepository) DeleteMedicalRecord(ctx context.Context, id string) error {
-	result := r.db.WithContext(ctx).Delete(&model.MedicalRecord{}, "id = ?", id)
+	result := conn(ctx, r.db).Delete(&model.MedicalRecord{}, "id = ?", id)
 	if result.Error != nil {
 		return result.Error
 	}
 	return nil
 }
+
+// CreateMedicalRecordAmendments カルテ修正履歴を作成
+func (r *medicalRecordRepository) CreateMedicalRecordAmendments(ctx context.Context, amendments []model.MedicalRecordAmendment) error {
+	if len(amendments) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).Create(&amendments).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create medical record amendments")
+	}
+	return nil
+}
+
+// GetMedicalRecordAmendments カルテの修正履歴を修正日時順に取得
+func (r *medicalRecordRepository) GetMedicalRecordAmendments(ctx context.Context, recordID string) ([]model.MedicalRecordAmendment, error) {
+	var amendments []model.MedicalRecordAmendment
+	if err := conn(ctx, r.db).
+		Where("medical_record_id = ?", recordID).
+		Order("amended_at ASC, created_at ASC").
+		Find(&amendments).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get medical record amendments")
+	}
+	return amendments, nil
+}
+
+// GetMedicalRecordItems カルテ明細をマスタ項目付きで登録順に取得
+func (r *medicalRecordRepository) GetMedicalRecordItems(ctx context.Context, recordID string) ([]model.MedicalRecordItem, error) {
+	var items []model.MedicalRecordItem
+	if err := conn(ctx, r.db).
+		Preload("MasterItem").
+		Where("medical_record_id = ?", recordID).
+		Order("created_at ASC").
+		Find(&items).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get medical record items")
+	}
+	return items, nil
+}
+
+// CreateMedicalRecordItem カルテ明細を作成
+func (r *medicalRecordRepository) CreateMedicalRecordItem(ctx context.Context, item *model.MedicalRecordItem) error {
+	if err := conn(ctx, r.db).Omit("MasterItem").Create(item).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create medical record item")
+	}
+	return nil
+}
+
+// DeleteMedicalRecordItem カルテ明細を削除
+func (r *medicalRecordRepository) DeleteMedicalRecordItem(ctx context.Context, recordID, itemID string) error {
+	result := conn(ctx, r.db).Delete(&model.MedicalRecordItem{}, "id = ? AND medical_record_id = ?", itemID, recordID)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete medical record item")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("medical_record_item", itemID)
+	}
+	return nil
+}
@@ -11,19 +11,30 @@ import (
 	"github.com/animal-ekarte/backend/internal/model"
 )
 
-// GetAllOwners retrieves all owners from the database with their pets.
-func (r *Repository) GetAllOwners(ctx context.Context) ([]model.Owner, error) {
-	var owners []model.Owner
-	if err := r.db.WithContext(ctx).Preload("Pets").Find(&owners).Error; err != nil {
-		return nil, fmt.Errorf("failed to get all owners: %w", err)
-	}
-	return owners, nil
+// ownerListSpec defines the sortable columns of the owner list.
+var ownerListSpec = listSpec[model.Owner]{
+	columns: map[string]sortColumn[model.Owner]{
+		"owner_number": {column: "owner_number", value: func(o *model.Owner) any { return o.OwnerNumber }},
+		"name":         {column: "name", value: func(o *model.Owner) any { return o.Name }},
+		"name_kana":    {column: "name_kana", value: func(o *model.Owner) any { return o.NameKana }},
+		"created_at":   {column: "created_at", value: func(o *model.Owner) any { return o.CreatedAt }},
+		"updated_at":   {column: "updated_at", value: func(o *model.Owner) any { return o.UpdatedAt }},
+	},
+	defaultSort: "-created_at",
+	id:          sortColumn[model.Owner]{column: "id", value: func(o *model.Owner) any { return o.ID }},
+}
+
+// ListOwners retrieves a page of owners matching the filter. Pets are not preloaded.
+func (r *Repository) ListOwners(ctx context.Context, filter model.OwnerFilter, opts model.ListOptions) (*model.ListResult[model.Owner], error) {
+	query := conn(ctx, r.db).Model(&model.Owner{})
+	query = whereSearch(query, filter.Search, "name", "name_kana", "phone", "email")
+	return findPage(query, ownerListSpec, opts)
 }
 
 // GetOwnerByID retrieves a single owner by ID from the database.
 func (r *Repository) GetOwnerByID(ctx context.Context, id uuid.UUID) (*model.Owner, error) {
 	var owner model.Owner
-	if err := r.db.WithContext(ctx).Preload("Pets").First(&owner, "id = ?", id).Error; err != nil {
+	if err := conn(ctx, r.db).Preload("Pets").First(&owner, "id = ?", id).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("owner not found: %w", err)
 		}
@@ -34,7 +45,8 @@ func (r *Repository) GetOwnerByID(ctx context.Context, id uuid.UUID) (*model.Own
 
 // CreateOwner creates a new owner record in the database.
 func (r *Repository) CreateOwner(ctx context.Context, owner *model.Owner) error {
-	if err := r.db.WithContext(ctx).Create(owner).Error; err != nil {
+	owner.UpdateSearchKeys()
+	if err := conn(ctx, r.db).Create(owner).Error; err != nil {
 		return fmt.Errorf("failed to create owner: %w", err)
 	}
 	return nil
@@ -42,7 +54,8 @@ func (r *Repository) CreateOwner(ctx context.Context, owner *model.Owner) error
 
 // UpdateOwner updates an existing owner record in the database.
 func (r *Repository) UpdateOwner(ctx context.Context, owner *model.Owner) error {
-	if err := r.db.WithContext(ctx).Save(owner).Error; err != nil {
+	owner.UpdateSearchKeys()
+	if err := conn(ctx, r.db).Save(owner).Error; err != nil {
 		return fmt.Errorf("failed to update owner: %w", err)
 	}
 	return nil
@@ -50,7 +63,7 @@ func (r *Repository) UpdateOwner(ctx context.Context, owner *model.Owner) error
 
 // DeleteOwner deletes an owner record from the database.
 func (r *Repository) DeleteOwner(ctx context.Context, id uuid.UUID) error {
-	if err := r.db.WithContext(ctx).Delete(&model.Owner{}, "id = ?", id).Error; err != nil {
+	if err := conn(ctx, r.db).Delete(&model.Owner{}, "id = ?", id).Error; err != nil {
 		return fmt.Errorf("failed to delete owner: %w", err)
 	}
 	return nil
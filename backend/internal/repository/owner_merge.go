@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ownerLinkedModels 飼い主IDを持ち、統合時に統合先へ移すテーブル
+var ownerLinkedModels = []any{
+	&model.Pet{},
+	&model.PetOwnership{},
+	&model.MedicalRecord{},
+	&model.Reservation{},
+	&model.Hospitalization{},
+	&model.Vaccination{},
+	&model.VaccinationReminder{},
+	&model.Trimming{},
+	&model.Examination{},
+	&model.Accounting{},
+}
+
+// OwnerMergeRepository 飼い主の重複検出・統合リポジトリインターフェース
+type OwnerMergeRepository interface {
+	FindDuplicateOwnerPairs(ctx context.Context, ownerID *uuid.UUID, minScore, limit int) ([]model.OwnerPair, error)
+	GetOwnerForUpdate(ctx context.Context, id uuid.UUID) (*model.Owner, error)
+	MoveOwnerLinkedRows(ctx context.Context, sourceID, targetID uuid.UUID) (model.OwnerMergeCounts, error)
+	CreateOwnerMerge(ctx context.Context, merge *model.OwnerMerge) error
+	GetOwnerMerges(ctx context.Context, targetOwnerID uuid.UUID) ([]model.OwnerMerge, error)
+}
+
+// ownerMergeRepository 飼い主の重複検出・統合リポジトリ実装
+type ownerMergeRepository struct {
+	db *gorm.DB
+}
+
+// NewOwnerMergeRepository 新しい飼い主の重複検出・統合リポジトリを作成
+func NewOwnerMergeRepository(db *gorm.DB) OwnerMergeRepository {
+	return &ownerMergeRepository{db: db}
+}
+
+// ownerMatchColumns 重複候補の照合に使う項目と照合用の列
+var ownerMatchColumns = []struct {
+	match  string
+	column string
+}{
+	{model.OwnerMatchName, "name_key"},
+	{model.OwnerMatchNameKana, "name_kana_key"},
+	{model.OwnerMatchPhone, "phone_digits"},
+	{model.OwnerMatchEmail, "email_key"},
+	{model.OwnerMatchAddress, "address_key"},
+}
+
+// FindDuplicateOwnerPairs 氏名・フリガナ・電話番号・メールアドレス・住所のいずれかが一致する飼い主の組を、
+// 一致した項目の点数（model.OwnerMatchPoints）の合計がminScore以上のものに限って点数の高い順に最大limit件取得する
+// 項目ごとに表記ゆれをそろえた照合用の列の等値結合で組を作るため、各列のインデックスが使われる。
+// 組は飼い主番号の小さい方をOwnerとする。ownerIDを指定した場合は、その飼い主を含む組に限る。
+func (r *ownerMergeRepository) FindDuplicateOwnerPairs(ctx context.Context, ownerID *uuid.UUID, minScore, limit int) ([]model.OwnerPair, error) {
+	var candidates, score []string
+	var args []any
+	for _, m := range ownerMatchColumns {
+		if ownerID != nil {
+			candidates = append(candidates, fmt.Sprintf("SELECT CASE WHEN o.owner_number < x.owner_number THEN o.id ELSE x.id END AS owner_id,"+
+				" CASE WHEN o.owner_number < x.owner_number THEN x.id ELSE o.id END AS duplicate_id"+
+				" FROM owners AS o JOIN owners AS x ON x.%[1]s = o.%[1]s AND x.id <> o.id"+
+				" WHERE o.id = ? AND o.%[1]s <> ''", m.column))
+			args = append(args, *ownerID)
+		} else {
+			candidates = append(candidates, fmt.Sprintf("SELECT a.id AS owner_id, b.id AS duplicate_id"+
+				" FROM owners AS a JOIN owners AS b ON b.%[1]s = a.%[1]s AND a.owner_number < b.owner_number"+
+				" WHERE a.%[1]s <> ''", m.column))
+		}
+		score = append(score, fmt.Sprintf("CASE WHEN a.%[1]s <> '' AND a.%[1]s = b.%[1]s THEN %[2]d ELSE 0 END",
+			m.column, model.OwnerMatchPoints[m.match]))
+	}
+	sql := "SELECT owner_id, duplicate_id FROM (" +
+		"SELECT p.owner_id, p.duplicate_id, a.owner_number AS owner_number, b.owner_number AS duplicate_number, " +
+		strings.Join(score, " + ") + " AS score" +
+		" FROM (" + strings.Join(candidates, " UNION ") + ") AS p" +
+		" JOIN owners AS a ON a.id = p.owner_id JOIN owners AS b ON b.id = p.duplicate_id" +
+		") AS scored WHERE score >= ? ORDER BY score DESC, owner_number, duplicate_number LIMIT ?"
+	args = append(args, minScore, limit)
+
+	var rows []struct {
+		OwnerID     uuid.UUID
+		DuplicateID uuid.UUID
+	}
+	if err := conn(ctx, r.db).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find duplicate owners")
+	}
+	if len(rows) == 0 {
+		return []model.OwnerPair{}, nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(rows)*2)
+	for _, row := range rows {
+		ids = append(ids, row.OwnerID, row.DuplicateID)
+	}
+	var owners []model.Owner
+	if err := conn(ctx, r.db).Where("id IN ?", ids).Find(&owners).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get duplicate owners")
+	}
+	byID := make(map[uuid.UUID]model.Owner, len(owners))
+	for _, o := range owners {
+		byID[o.ID] = o
+	}
+
+	pairs := make([]model.OwnerPair, 0, len(rows))
+	for _, row := range rows {
+		owner, ok1 := byID[row.OwnerID]
+		duplicate, ok2 := byID[row.DuplicateID]
+		if ok1 && ok2 {
+			pairs = append(pairs, model.OwnerPair{Owner: owner, Duplicate: duplicate})
+		}
+	}
+	return pairs, nil
+}
+
+// GetOwnerForUpdate 飼い主を行ロック付きで取得（トランザクション内で使う）
+func (r *ownerMergeRepository) GetOwnerForUpdate(ctx context.Context, id uuid.UUID) (*model.Owner, error) {
+	var owner model.Owner
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&owner, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("owner", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get owner")
+	}
+	return &owner, nil
+}
+
+// MoveOwnerLinkedRows 統合元の飼い主に紐づく行を統合先へ移し、テーブルごとの件数を返す
+func (r *ownerMergeRepository) MoveOwnerLinkedRows(ctx context.Context, sourceID, targetID uuid.UUID) (model.OwnerMergeCounts, error) {
+	counts := model.OwnerMergeCounts{}
+	for _, m := range ownerLinkedModels {
+		result := conn(ctx, r.db).Model(m).Where("owner_id = ?", sourceID).Update("owner_id", targetID)
+		if result.Error != nil {
+			return nil, apperrors.Wrap(result.Error, "failed to move "+result.Statement.Table)
+		}
+		if result.RowsAffected > 0 {
+			counts[result.Statement.Table] = result.RowsAffected
+		}
+	}
+	return counts, nil
+}
+
+// CreateOwnerMerge 飼い主の統合記録を作成
+func (r *ownerMergeRepository) CreateOwnerMerge(ctx context.Context, merge *model.OwnerMerge) error {
+	if err := conn(ctx, r.db).Create(merge).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create owner merge")
+	}
+	return nil
+}
+
+// GetOwnerMerges 統合先の飼い主の統合記録を古い順に取得
+func (r *ownerMergeRepository) GetOwnerMerges(ctx context.Context, targetOwnerID uuid.UUID) ([]model.OwnerMerge, error) {
+	var merges []model.OwnerMerge
+	if err := conn(ctx, r.db).
+		Where("target_owner_id = ?", targetOwnerID).
+		Order("created_at ASC").
+		Find(&merges).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get owner merges")
+	}
+	return merges, nil
+}
@@ -11,18 +11,36 @@ import (
 	"github.com/animal-ekarte/backend/internal/model"
 )
 
-func (r *Repository) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	var pets []model.Pet
-	result := r.db.WithContext(ctx).Order("created_at DESC").Find(&pets)
-	if result.Error != nil {
-		return nil, apperrors.Wrap(result.Error, "failed to get pets")
+// petListSpec ペット一覧の並び替え可能な列
+var petListSpec = listSpec[model.Pet]{
+	columns: map[string]sortColumn[model.Pet]{
+		"created_at": {column: "created_at", value: func(p *model.Pet) any { return p.CreatedAt }},
+		"updated_at": {column: "updated_at", value: func(p *model.Pet) any { return p.UpdatedAt }},
+		"name":       {column: "name", value: func(p *model.Pet) any { return p.Name }},
+		"species":    {column: "species", value: func(p *model.Pet) any { return p.Species }},
+	},
+	defaultSort: "-created_at",
+	id:          sortColumn[model.Pet]{column: "id", value: func(p *model.Pet) any { return p.ID }},
+}
+
+func (r *Repository) ListPets(ctx context.Context, filter model.PetFilter, opts model.ListOptions) (*model.ListResult[model.Pet], error) {
+	query := conn(ctx, r.db).Model(&model.Pet{})
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.Species != "" {
+		query = query.Where("species = ?", filter.Species)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
 	}
-	return pets, nil
+	query = whereSearch(query, filter.Search, "name", "species", "breed", "pet_number")
+	return findPage(query, petListSpec, opts)
 }
 
 func (r *Repository) GetPetByID(ctx context.Context, id uuid.UUID) (*model.Pet, error) {
 	var pet model.Pet
-	result := r.db.WithContext(ctx).First(&pet, "id = ?", id)
+	result := conn(ctx, r.db).First(&pet, "id = ?", id)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, apperrors.WrapNotFound("pet", id.String())
@@ -33,21 +51,23 @@ func (r *Repository) GetPetByID(ctx context.Context, id uuid.UUID) (*model.Pet,
 }
 
 func (r *Repository) CreatePet(ctx context.Context, pet *model.Pet) error {
-	if err := r.db.WithContext(ctx).Create(pet).Error; err != nil {
+	pet.UpdateSearchKeys()
+	if err := conn(ctx, r.db).Create(pet).Error; err != nil {
 		return apperrors.Wrap(err, "failed to create pet")
 	}
 	return nil
 }
 
 func (r *Repository) UpdatePet(ctx context.Context, pet *model.Pet) error {
-	if err := r.db.WithContext(ctx).Save(pet).Error; err != nil {
+	pet.UpdateSearchKeys()
+	if err := conn(ctx, r.db).Save(pet).Error; err != nil {
 		return apperrors.Wrap(err, "failed to update pet")
 	}
 	return nil
 }
 
 func (r *Repository) DeletePet(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&model.Pet{}, "id = ?", id)
+	result := conn(ctx, r.db).Delete(&model.Pet{}, "id = ?", id)
 	if result.Error != nil {
 		return apperrors.Wrap(result.Error, "failed to delete pet")
 	}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// PetMeasurementRepository ペットの体重・バイタルの測定値リポジトリインターフェース
+type PetMeasurementRepository interface {
+	GetPetMeasurements(ctx context.Context, petID uuid.UUID, types []string, dateRange model.DateRange) ([]model.PetMeasurement, error)
+	GetLatestPetMeasurement(ctx context.Context, petID uuid.UUID, measurementType string) (*model.PetMeasurement, error)
+	CreatePetMeasurements(ctx context.Context, measurements []model.PetMeasurement) error
+	UpdatePetWeight(ctx context.Context, petID uuid.UUID, weight float64) error
+	BackfillPetMeasurements(ctx context.Context) (int64, error)
+}
+
+// petMeasurementRepository ペットの体重・バイタルの測定値リポジトリ実装
+type petMeasurementRepository struct {
+	db *gorm.DB
+}
+
+// NewPetMeasurementRepository 新しいペットの体重・バイタルの測定値リポジトリを作成
+func NewPetMeasurementRepository(db *gorm.DB) PetMeasurementRepository {
+	return &petMeasurementRepository{db: db}
+}
+
+// GetPetMeasurements ペットの指定した項目の測定値を測定日時の古い順に取得
+func (r *petMeasurementRepository) GetPetMeasurements(ctx context.Context, petID uuid.UUID, types []string, dateRange model.DateRange) ([]model.PetMeasurement, error) {
+	var measurements []model.PetMeasurement
+	query := conn(ctx, r.db).Where("pet_id = ? AND type IN ?", petID, types)
+	query = whereDateRange(query, "measured_at", dateRange)
+	if err := query.Order("measured_at ASC, created_at ASC").Find(&measurements).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get pet measurements")
+	}
+	return measurements, nil
+}
+
+// GetLatestPetMeasurement ペットの指定した項目の最新の測定値を取得（測定値がなければnil）
+func (r *petMeasurementRepository) GetLatestPetMeasurement(ctx context.Context, petID uuid.UUID, measurementType string) (*model.PetMeasurement, error) {
+	var measurement model.PetMeasurement
+	if err := conn(ctx, r.db).
+		Where("pet_id = ? AND type = ?", petID, measurementType).
+		Order("measured_at DESC, created_at DESC").
+		First(&measurement).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap(err, "failed to get latest pet measurement")
+	}
+	return &measurement, nil
+}
+
+// CreatePetMeasurements 測定値をまとめて作成
+func (r *petMeasurementRepository) CreatePetMeasurements(ctx context.Context, measurements []model.PetMeasurement) error {
+	if len(measurements) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).Create(&measurements).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create pet measurements")
+	}
+	return nil
+}
+
+// UpdatePetWeight ペットの現在の体重を更新
+func (r *petMeasurementRepository) UpdatePetWeight(ctx context.Context, petID uuid.UUID, weight float64) error {
+	if err := conn(ctx, r.db).Model(&model.Pet{}).Where("id = ?", petID).Update("weight", weight).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update pet weight")
+	}
+	return nil
+}
+
+// BackfillPetMeasurements 測定値の記録前のペットの体重と入院中のバイタル記録から測定値を作成し、件数を返す
+// 測定値の時系列の追加前に登録されたデータを起動時に補完するためのもの。
+func (r *petMeasurementRepository) BackfillPetMeasurements(ctx context.Context) (int64, error) {
+	pets := conn(ctx, r.db).Exec(`INSERT INTO pet_measurements (pet_id, type, value, unit, measured_at, source, notes, created_at)
+		SELECT p.id, 'weight', p.weight, 'kg', p.updated_at, 'pet', '', NOW() FROM pets p
+		WHERE p.weight > 0
+		AND NOT EXISTS (SELECT 1 FROM pet_measurements pm WHERE pm.pet_id = p.id AND pm.type = 'weight')`)
+	if pets.Error != nil {
+		return 0, apperrors.Wrap(pets.Error, "failed to backfill pet measurements")
+	}
+	vitals := conn(ctx, r.db).Exec(`INSERT INTO pet_measurements (pet_id, type, value, unit, measured_at, source, vital_id, staff_id, notes, created_at)
+		SELECT h.pet_id, m.type, m.value, m.unit, dr.record_date + v.recorded_time, 'vital', v.id, v.staff_id, '', NOW()
+		FROM vitals v
+		JOIN daily_records dr ON dr.id = v.daily_record_id
+		JOIN hospitalizations h ON h.id = dr.hospitalization_id
+		CROSS JOIN LATERAL (VALUES
+			('weight', v.weight, 'kg'),
+			('temperature', v.temperature, '℃'),
+			('heart_rate', CAST(v.heart_rate AS decimal), '回/分'),
+			('respiration_rate', CAST(v.respiration_rate AS decimal), '回/分')
+		) AS m(type, value, unit)
+		WHERE m.value IS NOT NULL
+		AND NOT EXISTS (SELECT 1 FROM pet_measurements pm WHERE pm.vital_id = v.id)`)
+	if vitals.Error != nil {
+		return 0, apperrors.Wrap(vitals.Error, "failed to backfill pet measurements")
+	}
+	return pets.RowsAffected + vitals.RowsAffected, nil
+}
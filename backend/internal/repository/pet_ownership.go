@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// PetOwnershipRepository ペットの飼い主の履歴・譲渡リポジトリインターフェース
+type PetOwnershipRepository interface {
+	GetPetForUpdate(ctx context.Context, id uuid.UUID) (*model.Pet, error)
+	GetCurrentPetOwnership(ctx context.Context, petID uuid.UUID) (*model.PetOwnership, error)
+	GetPetOwnerships(ctx context.Context, petID uuid.UUID) ([]model.PetOwnership, error)
+	CreatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error
+	UpdatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error
+	UpdatePetOwner(ctx context.Context, petID, ownerID uuid.UUID) error
+	MoveOpenAccountings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID) (int64, error)
+	MoveUpcomingReservations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error)
+	MoveUpcomingVaccinations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error)
+	MoveBookedTrimmings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error)
+	BackfillPetOwnerships(ctx context.Context) (int64, error)
+}
+
+// petOwnershipRepository ペットの飼い主の履歴・譲渡リポジトリ実装
+type petOwnershipRepository struct {
+	db *gorm.DB
+}
+
+// NewPetOwnershipRepository 新しいペットの飼い主の履歴・譲渡リポジトリを作成
+func NewPetOwnershipRepository(db *gorm.DB) PetOwnershipRepository {
+	return &petOwnershipRepository{db: db}
+}
+
+// GetPetForUpdate ペットを行ロック付きで取得（トランザクション内で使う）
+func (r *petOwnershipRepository) GetPetForUpdate(ctx context.Context, id uuid.UUID) (*model.Pet, error) {
+	var pet model.Pet
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&pet, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("pet", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get pet")
+	}
+	return &pet, nil
+}
+
+// GetCurrentPetOwnership ペットの現在の飼い主の期間を取得（履歴がなければnil）
+func (r *petOwnershipRepository) GetCurrentPetOwnership(ctx context.Context, petID uuid.UUID) (*model.PetOwnership, error) {
+	var ownership model.PetOwnership
+	if err := conn(ctx, r.db).
+		Where("pet_id = ? AND ended_at IS NULL", petID).
+		Order("started_at DESC").
+		First(&ownership).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, apperrors.Wrap(err, "failed to get current pet ownership")
+	}
+	return &ownership, nil
+}
+
+// GetPetOwnerships ペットの飼い主の履歴を古い順に取得（飼い主付き）
+func (r *petOwnershipRepository) GetPetOwnerships(ctx context.Context, petID uuid.UUID) ([]model.PetOwnership, error) {
+	var ownerships []model.PetOwnership
+	if err := conn(ctx, r.db).
+		Preload("Owner").
+		Where("pet_id = ?", petID).
+		Order("started_at ASC, created_at ASC").
+		Find(&ownerships).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get pet ownerships")
+	}
+	return ownerships, nil
+}
+
+// CreatePetOwnership 飼い主の期間を作成
+func (r *petOwnershipRepository) CreatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error {
+	if err := conn(ctx, r.db).Create(ownership).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create pet ownership")
+	}
+	return nil
+}
+
+// UpdatePetOwnership 飼い主の期間を更新
+func (r *petOwnershipRepository) UpdatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error {
+	if err := conn(ctx, r.db).Omit("Owner").Save(ownership).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update pet ownership")
+	}
+	return nil
+}
+
+// UpdatePetOwner ペットの現在の飼い主を変更
+func (r *petOwnershipRepository) UpdatePetOwner(ctx context.Context, petID, ownerID uuid.UUID) error {
+	if err := conn(ctx, r.db).Model(&model.Pet{}).Where("id = ?", petID).Update("owner_id", ownerID).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update pet owner")
+	}
+	return nil
+}
+
+// MoveOpenAccountings ペットの未精算（未収・保留）の会計を別の飼い主へ付け替え、件数を返す
+func (r *petOwnershipRepository) MoveOpenAccountings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Accounting{}).
+		Where("pet_id = ? AND owner_id = ?", petID, fromOwnerID).
+		Where("status IN ?", []string{model.AccountingStatusUnpaid, model.AccountingStatusOnHold}).
+		Update("owner_id", toOwnerID)
+	if result.Error != nil {
+		return 0, apperrors.Wrap(result.Error, "failed to move open accountings")
+	}
+	return result.RowsAffected, nil
+}
+
+// MoveUpcomingReservations ペットのsince以降の未受付（仮予約・確定）の予約を別の飼い主へ付け替え、件数を返す
+func (r *petOwnershipRepository) MoveUpcomingReservations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Reservation{}).
+		Where("pet_id = ? AND owner_id = ?", petID, fromOwnerID).
+		Where("start_time >= ?", since).
+		Where("status IN ?", []string{model.ReservationStatusPending, model.ReservationStatusConfirmed}).
+		Update("owner_id", toOwnerID)
+	if result.Error != nil {
+		return 0, apperrors.Wrap(result.Error, "failed to move upcoming reservations")
+	}
+	return result.RowsAffected, nil
+}
+
+// MoveUpcomingVaccinations 次回接種予定日がsince以降のペットの接種記録を別の飼い主へ付け替え、件数を返す
+// 接種案内は接種記録の飼い主へ送るため、譲渡後の案内が新しい飼い主へ届くようにする。
+func (r *petOwnershipRepository) MoveUpcomingVaccinations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Vaccination{}).
+		Where("pet_id = ? AND owner_id = ?", petID, fromOwnerID).
+		Where("next_date >= ?", since).
+		Update("owner_id", toOwnerID)
+	if result.Error != nil {
+		return 0, apperrors.Wrap(result.Error, "failed to move upcoming vaccinations")
+	}
+	return result.RowsAffected, nil
+}
+
+// MoveBookedTrimmings ペットのsince以降の予約中のトリミングを別の飼い主へ付け替え、件数を返す
+func (r *petOwnershipRepository) MoveBookedTrimmings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	result := conn(ctx, r.db).Model(&model.Trimming{}).
+		Where("pet_id = ? AND owner_id = ?", petID, fromOwnerID).
+		Where("appointment_date >= ?", since).
+		Where("status = ?", model.TrimmingStatusBooked).
+		Update("owner_id", toOwnerID)
+	if result.Error != nil {
+		return 0, apperrors.Wrap(result.Error, "failed to move booked trimmings")
+	}
+	return result.RowsAffected, nil
+}
+
+// BackfillPetOwnerships 履歴のないペットに、登録日からの現在の飼い主の期間を作成し、件数を返す
+// 飼い主の履歴の追加前に登録されたペットを起動時に補完するためのもの。
+func (r *petOwnershipRepository) BackfillPetOwnerships(ctx context.Context) (int64, error) {
+	result := conn(ctx, r.db).Exec(`INSERT INTO pet_ownerships (pet_id, owner_id, started_at, created_at, updated_at)
+		SELECT p.id, p.owner_id, CAST(p.created_at AS date), NOW(), NOW() FROM pets p
+		WHERE NOT EXISTS (SELECT 1 FROM pet_ownerships o WHERE o.pet_id = p.id)`)
+	if result.Error != nil {
+		return 0, apperrors.Wrap(result.Error, "failed to backfill pet ownerships")
+	}
+	return result.RowsAffected, nil
+}
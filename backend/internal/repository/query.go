@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// sortColumn 並び替えに使える列（valueはカーソルに記録する行の値。NULLにならない列に限る）
+type sortColumn[T any] struct {
+	column string
+	value  func(*T) any
+}
+
+// listSpec 一覧ごとの並び替え可能な列と既定の並び順
+// 並び順を一意にするため、最後にidの昇順を加える。
+type listSpec[T any] struct {
+	columns     map[string]sortColumn[T]
+	defaultSort string
+	id          sortColumn[T]
+}
+
+// sortKey 並び替えの1項目
+type sortKey[T any] struct {
+	sortColumn[T]
+	desc bool
+}
+
+// listCursor カーソルの内容（並び順と前のページの最終行の値）
+type listCursor struct {
+	Sort   string            `json:"s"`
+	Values []json.RawMessage `json:"v"`
+}
+
+// findPage 絞り込み済みのクエリを並び替え、カーソルの位置から1ページ分を取得する
+// 件数は絞り込み条件に一致する全件を数え、次のページがあればカーソルを返す。
+func findPage[T any](query *gorm.DB, spec listSpec[T], opts model.ListOptions, preloads ...string) (*model.ListResult[T], error) {
+	sort := opts.Sort
+	if sort == "" {
+		sort = spec.defaultSort
+	}
+	keys, err := spec.sortKeys(sort)
+	if err != nil {
+		return nil, err
+	}
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = model.DefaultListLimit
+	}
+	if limit > model.MaxListLimit {
+		limit = model.MaxListLimit
+	}
+
+	var total int64
+	if err := query.Session(&gorm.Session{}).Model(new(T)).Count(&total).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to count list")
+	}
+
+	page := query.Session(&gorm.Session{})
+	if opts.Cursor != "" {
+		values, err := decodeCursor(opts.Cursor, sort, keys)
+		if err != nil {
+			return nil, err
+		}
+		sql, args := keysetCondition(keys, values)
+		page = page.Where(sql, args...)
+	}
+	for _, key := range keys {
+		order := key.column + " ASC"
+		if key.desc {
+			order = key.column + " DESC"
+		}
+		page = page.Order(order)
+	}
+	for _, preload := range preloads {
+		page = page.Preload(preload)
+	}
+
+	rows := make([]T, 0, limit+1)
+	if err := page.Limit(limit + 1).Find(&rows).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get list")
+	}
+
+	result := &model.ListResult[T]{Data: rows, Meta: model.ListMeta{Total: total, Limit: limit}}
+	if len(rows) > limit {
+		result.Data = rows[:limit]
+		result.Meta.HasMore = true
+		next, err := encodeCursor(sort, keys, &rows[limit-1])
+		if err != nil {
+			return nil, err
+		}
+		result.Meta.NextCursor = next
+	}
+	return result, nil
+}
+
+// sortKeys 並び替えの指定（"-visit_date,record_no"形式）を列に変換する
+func (spec listSpec[T]) sortKeys(sort string) ([]sortKey[T], error) {
+	seen := map[string]bool{}
+	var keys []sortKey[T]
+	for _, field := range strings.Split(sort, ",") {
+		field = strings.TrimSpace(field)
+		desc := strings.HasPrefix(field, "-")
+		name := strings.TrimPrefix(field, "-")
+		col, ok := spec.columns[name]
+		if !ok {
+			return nil, apperrors.WrapInvalidInput("invalid sort field: " + name)
+		}
+		if seen[name] {
+			return nil, apperrors.WrapInvalidInput("duplicate sort field: " + name)
+		}
+		seen[name] = true
+		keys = append(keys, sortKey[T]{sortColumn: col, desc: desc})
+	}
+	return append(keys, sortKey[T]{sortColumn: spec.id}), nil
+}
+
+// keysetCondition 前のページの最終行より後ろの行を取り出す条件
+// (a > ?) OR (a = ? AND b < ?) OR (a = ? AND b = ? AND id > ?) の形になる。
+func keysetCondition[T any](keys []sortKey[T], values []any) (string, []any) {
+	var ors []string
+	var args []any
+	for i, key := range keys {
+		var ands []string
+		for j := 0; j < i; j++ {
+			ands = append(ands, keys[j].column+" = ?")
+			args = append(args, values[j])
+		}
+		op := " > ?"
+		if key.desc {
+			op = " < ?"
+		}
+		ands = append(ands, key.column+op)
+		args = append(args, values[i])
+		ors = append(ors, "("+strings.Join(ands, " AND ")+")")
+	}
+	return "(" + strings.Join(ors, " OR ") + ")", args
+}
+
+// encodeCursor 行の並び替え列の値をカーソルにする
+func encodeCursor[T any](sort string, keys []sortKey[T], row *T) (string, error) {
+	c := listCursor{Sort: sort, Values: make([]json.RawMessage, len(keys))}
+	for i, key := range keys {
+		v, err := json.Marshal(key.value(row))
+		if err != nil {
+			return "", apperrors.Wrap(err, "failed to encode cursor")
+		}
+		c.Values[i] = v
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return "", apperrors.Wrap(err, "failed to encode cursor")
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor カーソルから並び替え列の値を取り出す（並び順が異なるカーソルは使えない）
+func decodeCursor[T any](s, sort string, keys []sortKey[T]) ([]any, error) {
+	invalid := apperrors.WrapInvalidInput("invalid cursor")
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalid
+	}
+	var c listCursor
+	if err := json.Unmarshal(b, &c); err != nil || len(c.Values) != len(keys) {
+		return nil, invalid
+	}
+	if c.Sort != sort {
+		return nil, apperrors.WrapInvalidInput("cursor does not match the sort order")
+	}
+
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		// 列の型（time.Time, uuid.UUIDなど）で復元する
+		typ := reflect.TypeOf(key.value(new(T)))
+		v := reflect.New(typ)
+		if err := json.Unmarshal(c.Values[i], v.Interface()); err != nil {
+			return nil, invalid
+		}
+		values[i] = v.Elem().Interface()
+	}
+	return values, nil
+}
+
+// whereDateRange 日付の範囲で絞り込む（終了日はその日の終わりまで含む）
+func whereDateRange(query *gorm.DB, column string, r model.DateRange) *gorm.DB {
+	if r.From != nil {
+		query = query.Where(column+" >= ?", *r.From)
+	}
+	if r.To != nil {
+		query = query.Where(column+" < ?", r.To.AddDate(0, 0, 1))
+	}
+	return query
+}
+
+// whereSearch いずれかの列の部分一致で絞り込む
+func whereSearch(query *gorm.DB, search string, columns ...string) *gorm.DB {
+	if search == "" {
+		return query
+	}
+	like := containsPattern(search)
+	conds := make([]string, len(columns))
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		conds[i] = column + " ILIKE ?"
+		args[i] = like
+	}
+	return query.Where("("+strings.Join(conds, " OR ")+")", args...)
+}
+
+// containsPattern 部分一致のLIKEパターン
+func containsPattern(s string) string {
+	return "%" + escapeLike(s) + "%"
+}
+
+// prefixPattern 前方一致のLIKEパターン
+func prefixPattern(s string) string {
+	return escapeLike(s) + "%"
+}
+
+// suffixPattern 後方一致のLIKEパターン
+func suffixPattern(s string) string {
+	return "%" + escapeLike(s)
+}
+
+// escapeLike LIKEのワイルドカードをエスケープする
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+	"gorm.io/gorm/logger"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// fakeListDB DBに接続せず、発行したSELECTを記録して用意した行を返す
+type fakeListDB struct {
+	db    *gorm.DB
+	total int64
+	rows  []model.MedicalRecord
+	sql   string
+	vars  []any
+}
+
+func newFakeListDB(t *testing.T, total int64, rows ...model.MedicalRecord) *fakeListDB {
+	t.Helper()
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	f := &fakeListDB{db: db, total: total, rows: rows}
+	err = db.Callback().Query().Replace("gorm:query", func(tx *gorm.DB) {
+		callbacks.BuildQuerySQL(tx)
+		switch dest := tx.Statement.Dest.(type) {
+		case *int64:
+			*dest = f.total
+			tx.RowsAffected = 1
+		case *[]model.MedicalRecord:
+			f.sql, f.vars = tx.Statement.SQL.String(), tx.Statement.Vars
+			*dest = append(*dest, f.rows...)
+			tx.RowsAffected = int64(len(f.rows))
+		}
+	})
+	require.NoError(t, err)
+	return f
+}
+
+func (f *fakeListDB) findPage(opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error) {
+	return findPage(f.db.Model(&model.MedicalRecord{}), medicalRecordListSpec, opts)
+}
+
+func TestFindPage(t *testing.T) {
+	visit := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 10, 1, 9, 30, 0, 0, time.UTC)
+	// 2件目と3件目は受診日・登録日時が同じで、idだけで並び順が決まる
+	rows := []model.MedicalRecord{
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000003"), VisitDate: visit.AddDate(0, 0, 1), CreatedAt: created},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000001"), VisitDate: visit, CreatedAt: created},
+		{ID: uuid.MustParse("00000000-0000-0000-0000-000000000002"), VisitDate: visit, CreatedAt: created},
+	}
+
+	t.Run("returns a cursor that resumes after the last row", func(t *testing.T) {
+		first := newFakeListDB(t, 5, rows...)
+		page, err := first.findPage(model.ListOptions{Limit: 2})
+		require.NoError(t, err)
+
+		assert.Equal(t, rows[:2], page.Data)
+		assert.Equal(t, model.ListMeta{Total: 5, Limit: 2, HasMore: true, NextCursor: page.Meta.NextCursor}, page.Meta)
+		require.NotEmpty(t, page.Meta.NextCursor)
+		assert.Contains(t, first.sql, "ORDER BY visit_date DESC,created_at DESC,id ASC LIMIT $1")
+		assert.Equal(t, []any{3}, first.vars)
+
+		next := newFakeListDB(t, 5, rows[2])
+		page, err = next.findPage(model.ListOptions{Limit: 2, Cursor: page.Meta.NextCursor})
+		require.NoError(t, err)
+
+		assert.Equal(t, rows[2:], page.Data)
+		assert.False(t, page.Meta.HasMore)
+		assert.Empty(t, page.Meta.NextCursor)
+		// 同じ並び替えキーの行はidで続きを判定する
+		assert.Contains(t, next.sql, "((visit_date < $1) OR (visit_date = $2 AND created_at < $3) OR (visit_date = $4 AND created_at = $5 AND id > $6)) ORDER BY visit_date DESC,created_at DESC,id ASC LIMIT $7")
+		assert.Equal(t, []any{visit, visit, created, visit, created, rows[1].ID, 3}, next.vars)
+	})
+
+	t.Run("round-trips cursor values with their column types", func(t *testing.T) {
+		keys, err := medicalRecordListSpec.sortKeys("record_no,-updated_at")
+		require.NoError(t, err)
+		row := model.MedicalRecord{ID: uuid.New(), RecordNo: "MR00000042", UpdatedAt: created}
+
+		cursor, err := encodeCursor("record_no,-updated_at", keys, &row)
+		require.NoError(t, err)
+		values, err := decodeCursor(cursor, "record_no,-updated_at", keys)
+		require.NoError(t, err)
+
+		assert.Equal(t, []any{"MR00000042", created, row.ID}, values)
+	})
+
+	t.Run("rejects tampered cursors", func(t *testing.T) {
+		keys, err := medicalRecordListSpec.sortKeys(medicalRecordListSpec.defaultSort)
+		require.NoError(t, err)
+		valid, err := encodeCursor(medicalRecordListSpec.defaultSort, keys, &rows[0])
+		require.NoError(t, err)
+		raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+		cases := map[string]string{
+			"not base64":          "!!!",
+			"padded base64":       valid + "==",
+			"not json":            raw("visit_date"),
+			"missing values":      raw(`{"s":"-visit_date,-created_at","v":["2026-10-01T00:00:00Z"]}`),
+			"extra values":        raw(`{"s":"-visit_date,-created_at","v":["2026-10-01T00:00:00Z","2026-10-01T09:30:00Z","00000000-0000-0000-0000-000000000001",1]}`),
+			"wrong value type":    raw(`{"s":"-visit_date,-created_at","v":[1,"2026-10-01T09:30:00Z","00000000-0000-0000-0000-000000000001"]}`),
+			"invalid id":          raw(`{"s":"-visit_date,-created_at","v":["2026-10-01T00:00:00Z","2026-10-01T09:30:00Z","1' OR '1'='1"]}`),
+			"another sort order":  raw(strings.Replace(mustDecode(t, valid), "-visit_date,-created_at", "record_no", 1)),
+			"truncated":           valid[:len(valid)/2],
+			"another sort cursor": mustCursor(t, "record_no", &rows[0]),
+		}
+		for name, cursor := range cases {
+			t.Run(name, func(t *testing.T) {
+				f := newFakeListDB(t, 0)
+				_, err := f.findPage(model.ListOptions{Cursor: cursor})
+				assert.True(t, apperrors.IsInvalidInput(err), "%v", err)
+				assert.Empty(t, f.sql)
+			})
+		}
+	})
+
+	t.Run("rejects unknown and duplicate sort fields", func(t *testing.T) {
+		for _, sort := range []string{"name", "visit_date,-visit_date", "visit_date;DROP TABLE medical_records"} {
+			_, err := newFakeListDB(t, 0).findPage(model.ListOptions{Sort: sort})
+			assert.True(t, apperrors.IsInvalidInput(err), sort)
+		}
+	})
+}
+
+func mustDecode(t *testing.T, cursor string) string {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func mustCursor(t *testing.T, sort string, row *model.MedicalRecord) string {
+	t.Helper()
+	keys, err := medicalRecordListSpec.sortKeys(sort)
+	require.NoError(t, err)
+	cursor, err := encodeCursor(sort, keys, row)
+	require.NoError(t, err)
+	return cursor
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ReservationRepository 予約リポジトリインターフェース
+type ReservationRepository interface {
+	ListReservations(ctx context.Context, filter model.ReservationFilter, opts model.ListOptions) (*model.ListResult[model.Reservation], error)
+	GetReservationByID(ctx context.Context, id uuid.UUID) (*model.Reservation, error)
+	GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Reservation, error)
+	FindOverlappingReservations(ctx context.Context, doctorID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Reservation, error)
+	LockDoctorSchedule(ctx context.Context, doctorID uuid.UUID) error
+	CreateReservation(ctx context.Context, reservation *model.Reservation) error
+	UpdateReservation(ctx context.Context, reservation *model.Reservation) error
+	DeleteReservation(ctx context.Context, id uuid.UUID) error
+}
+
+// reservationRepository 予約リポジトリ実装
+type reservationRepository struct {
+	db *gorm.DB
+}
+
+// NewReservationRepository 新しい予約リポジトリを作成
+func NewReservationRepository(db *gorm.DB) ReservationRepository {
+	return &reservationRepository{db: db}
+}
+
+// reservationListSpec 予約一覧の並び替え可能な列
+var reservationListSpec = listSpec[model.Reservation]{
+	columns: map[string]sortColumn[model.Reservation]{
+		"start_time": {column: "start_time", value: func(r *model.Reservation) any { return r.StartTime }},
+		"created_at": {column: "created_at", value: func(r *model.Reservation) any { return r.CreatedAt }},
+		"updated_at": {column: "updated_at", value: func(r *model.Reservation) any { return r.UpdatedAt }},
+	},
+	defaultSort: "start_time",
+	id:          sortColumn[model.Reservation]{column: "id", value: func(r *model.Reservation) any { return r.ID }},
+}
+
+// ListReservations 条件に一致する予約を1ページ分取得
+func (r *reservationRepository) ListReservations(ctx context.Context, filter model.ReservationFilter, opts model.ListOptions) (*model.ListResult[model.Reservation], error) {
+	query := conn(ctx, r.db).Model(&model.Reservation{})
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.DoctorID != nil {
+		query = query.Where("doctor_id = ?", *filter.DoctorID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.ServiceType != "" {
+		query = query.Where("service_type = ?", filter.ServiceType)
+	}
+	query = whereDateRange(query, "start_time", filter.StartTime)
+	return findPage(query, reservationListSpec, opts, "Pet", "Owner")
+}
+
+// GetReservationByID IDで予約を取得
+func (r *reservationRepository) GetReservationByID(ctx context.Context, id uuid.UUID) (*model.Reservation, error) {
+	var reservation model.Reservation
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		First(&reservation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("reservation", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get reservation")
+	}
+	return &reservation, nil
+}
+
+// GetReservationByIDForUpdate IDで予約を行ロック付きで取得
+// トランザクション内で呼び出すこと。同じ予約への同時受付などを直列化する。
+func (r *reservationRepository) GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Reservation, error) {
+	var reservation model.Reservation
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&reservation, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("reservation", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get reservation")
+	}
+	return &reservation, nil
+}
+
+// FindOverlappingReservations 指定医師の指名予約のうち[start, end)と重なるものを取得
+// キャンセル済みの予約とexcludeIDの予約は対象外。
+func (r *reservationRepository) FindOverlappingReservations(ctx context.Context, doctorID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Reservation, error) {
+	var reservations []model.Reservation
+	if err := conn(ctx, r.db).
+		Where("doctor_id = ?", doctorID).
+		Where("is_designated = ?", true).
+		Where("status <> ?", model.ReservationStatusCanceled).
+		Where("start_time < ? AND end_time > ?", end, start).
+		Where("id <> ?", excludeID).
+		Order("start_time ASC").
+		Find(&reservations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find overlapping reservations")
+	}
+	return reservations, nil
+}
+
+// LockDoctorSchedule 医師単位のアドバイザリロックを取得する
+// トランザクション内で呼び出すこと。ロックはトランザクション終了時に解放される。
+func (r *reservationRepository) LockDoctorSchedule(ctx context.Context, doctorID uuid.UUID) error {
+	if err := conn(ctx, r.db).
+		Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "reservation:"+doctorID.String()).Error; err != nil {
+		return apperrors.Wrap(err, "failed to lock doctor schedule")
+	}
+	return nil
+}
+
+// CreateReservation 予約を作成
+func (r *reservationRepository) CreateReservation(ctx context.Context, reservation *model.Reservation) error {
+	if err := conn(ctx, r.db).Create(reservation).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create reservation")
+	}
+	return nil
+}
+
+// UpdateReservation 予約を更新
+func (r *reservationRepository) UpdateReservation(ctx context.Context, reservation *model.Reservation) error {
+	if err := conn(ctx, r.db).Omit("Pet", "Owner").Save(reservation).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update reservation")
+	}
+	return nil
+}
+
+// DeleteReservation 予約を削除
+func (r *reservationRepository) DeleteReservation(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Delete(&model.Reservation{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete reservation")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("reservation", id.String())
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// PatientSearchRepository 患者検索リポジトリインターフェース
+type PatientSearchRepository interface {
+	SearchOwners(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Owner, error)
+	SearchPets(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Pet, error)
+	CountOwners(ctx context.Context, query model.PatientSearchQuery) (int64, error)
+	CountPets(ctx context.Context, query model.PatientSearchQuery) (int64, error)
+	EnsureSearchExtension(ctx context.Context) error
+	RefreshSearchKeys(ctx context.Context) (int, error)
+}
+
+// patientSearchRepository 患者検索リポジトリ実装
+type patientSearchRepository struct {
+	db *gorm.DB
+}
+
+// NewPatientSearchRepository 新しい患者検索リポジトリを作成
+func NewPatientSearchRepository(db *gorm.DB) PatientSearchRepository {
+	return &patientSearchRepository{db: db}
+}
+
+// ownerSearchConds 氏名・フリガナ・電話番号・飼い主番号が検索条件に一致する飼い主の条件
+func ownerSearchConds(db *gorm.DB, query model.PatientSearchQuery) *gorm.DB {
+	conds := db.Where("search_text LIKE ?", containsPattern(query.Text))
+	if query.Digits != "" {
+		conds = conds.Or("phone_digits LIKE ?", containsPattern(query.Digits))
+	}
+	if query.OwnerNumber != nil {
+		conds = conds.Or("owner_number = ?", *query.OwnerNumber)
+	}
+	return conds
+}
+
+// ownerSearchOrder 飼い主の一致の度合い（サービスの点数と同じ）の高い順、同点はトライグラムの類似度・フリガナ順
+func ownerSearchOrder(query model.PatientSearchQuery) clause.OrderBy {
+	var whens []string
+	var vars []any
+	when := func(cond string, score int, args ...any) {
+		whens = append(whens, fmt.Sprintf("WHEN %s THEN %d", cond, score))
+		vars = append(vars, args...)
+	}
+	if query.OwnerNumber != nil {
+		when("owner_number = ?", model.SearchScoreExactCode, *query.OwnerNumber)
+	}
+	if query.Digits != "" {
+		when("phone_digits = ?", model.SearchScoreExactPhone, query.Digits)
+	}
+	when("name_key = ? OR name_kana_key = ?", model.SearchScoreExactName, query.Text, query.Text)
+	when("name_key LIKE ? OR name_kana_key LIKE ?", model.SearchScorePrefixName, prefixPattern(query.Text), prefixPattern(query.Text))
+	if query.Digits != "" {
+		when("phone_digits LIKE ?", model.SearchScoreSuffixPhone, suffixPattern(query.Digits))
+	}
+	when("name_key LIKE ? OR name_kana_key LIKE ?", model.SearchScoreContainsName, containsPattern(query.Text), containsPattern(query.Text))
+	if query.Digits != "" {
+		when("phone_digits LIKE ?", model.SearchScoreContainsPhone, containsPattern(query.Digits))
+	}
+	vars = append(vars, query.Text)
+	return relevanceOrder(whens, vars, "COALESCE(NULLIF(name_kana_key, ''), name_key), owner_number")
+}
+
+// SearchOwners 検索条件に一致する飼い主を一致の度合いの高い順に取得（ペット付き）
+func (r *patientSearchRepository) SearchOwners(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Owner, error) {
+	var owners []model.Owner
+	if err := conn(ctx, r.db).
+		Preload("Pets").
+		Where(ownerSearchConds(conn(ctx, r.db), query)).
+		Order(ownerSearchOrder(query)).
+		Limit(limit).
+		Find(&owners).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to search owners")
+	}
+	return owners, nil
+}
+
+// CountOwners 検索条件に一致する飼い主の件数
+func (r *patientSearchRepository) CountOwners(ctx context.Context, query model.PatientSearchQuery) (int64, error) {
+	var total int64
+	if err := conn(ctx, r.db).Model(&model.Owner{}).
+		Where(ownerSearchConds(conn(ctx, r.db), query)).
+		Count(&total).Error; err != nil {
+		return 0, apperrors.Wrap(err, "failed to count owners")
+	}
+	return total, nil
+}
+
+// petSearchConds 名前・ペット番号・マイクロチップ番号が検索条件に一致するペットの条件
+func petSearchConds(db *gorm.DB, query model.PatientSearchQuery) *gorm.DB {
+	conds := db.Where("search_text LIKE ?", containsPattern(query.Text))
+	if query.Code != "" && query.Code != query.Text {
+		conds = conds.Or("search_text LIKE ?", containsPattern(query.Code))
+	}
+	return conds
+}
+
+// petSearchOrder ペットの一致の度合い（サービスの点数と同じ）の高い順、同点はトライグラムの類似度・名前順
+// search_textは名前・ペット番号・マイクロチップ番号を改行で区切ったもの。
+func petSearchOrder(query model.PatientSearchQuery) clause.OrderBy {
+	const name, number, chip = "SPLIT_PART(search_text, CHR(10), 1)", "SPLIT_PART(search_text, CHR(10), 2)", "SPLIT_PART(search_text, CHR(10), 3)"
+	var whens []string
+	var vars []any
+	when := func(cond string, score int, args ...any) {
+		whens = append(whens, fmt.Sprintf("WHEN %s THEN %d", cond, score))
+		vars = append(vars, args...)
+	}
+	code := func(op string, score int, value string) {
+		if query.Code != "" {
+			when(number+" "+op+" ? OR "+chip+" "+op+" ?", score, value, value)
+		}
+	}
+	code("=", model.SearchScoreExactCode, query.Code)
+	when(name+" = ?", model.SearchScoreExactName, query.Text)
+	when(name+" LIKE ?", model.SearchScorePrefixName, prefixPattern(query.Text))
+	code("LIKE", model.SearchScorePrefixCode, prefixPattern(query.Code))
+	when(name+" LIKE ?", model.SearchScoreContainsName, containsPattern(query.Text))
+	code("LIKE", model.SearchScoreContainsCode, containsPattern(query.Code))
+	vars = append(vars, query.Text)
+	return relevanceOrder(whens, vars, name+", created_at")
+}
+
+// SearchPets 検索条件に一致するペットを一致の度合いの高い順に取得（飼い主付き）
+func (r *patientSearchRepository) SearchPets(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Pet, error) {
+	var pets []model.Pet
+	if err := conn(ctx, r.db).
+		Preload("Owner").
+		Where(petSearchConds(conn(ctx, r.db), query)).
+		Order(petSearchOrder(query)).
+		Limit(limit).
+		Find(&pets).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to search pets")
+	}
+	return pets, nil
+}
+
+// CountPets 検索条件に一致するペットの件数
+func (r *patientSearchRepository) CountPets(ctx context.Context, query model.PatientSearchQuery) (int64, error) {
+	var total int64
+	if err := conn(ctx, r.db).Model(&model.Pet{}).
+		Where(petSearchConds(conn(ctx, r.db), query)).
+		Count(&total).Error; err != nil {
+		return 0, apperrors.Wrap(err, "failed to count pets")
+	}
+	return total, nil
+}
+
+// relevanceOrder 一致の度合い（CASE式）の高い順、同点はsearch_textとの類似度（pg_trgm）の高い順、最後にtieBreakの順
+// varsの末尾は類似度を比べる検索語とする。
+func relevanceOrder(whens []string, vars []any, tieBreak string) clause.OrderBy {
+	return clause.OrderBy{Expression: clause.Expr{
+		SQL:                "CASE " + strings.Join(whens, " ") + " ELSE 0 END DESC, similarity(search_text, ?) DESC, " + tieBreak,
+		Vars:               vars,
+		WithoutParentheses: true,
+	}}
+}
+
+// EnsureSearchExtension 類似度による並び替えに使うpg_trgm拡張がなければ作成する
+// マイグレーションSQLを適用していないデータベース（AutoMigrateのみ）でもsimilarity()を使えるようにする。
+func (r *patientSearchRepository) EnsureSearchExtension(ctx context.Context) error {
+	if err := conn(ctx, r.db).Exec("CREATE EXTENSION IF NOT EXISTS pg_trgm").Error; err != nil {
+		return apperrors.Wrap(err, "failed to create pg_trgm extension")
+	}
+	return nil
+}
+
+// RefreshSearchKeys 検索用の値が未設定の飼い主・ペットを更新し、更新した件数を返す
+// 検索用カラムの追加前に登録された行を起動時に補完するためのもの。
+func (r *patientSearchRepository) RefreshSearchKeys(ctx context.Context) (int, error) {
+	updated := 0
+	var owners []model.Owner
+	// 照合用の列の追加前に登録された飼い主はname_keyが空のまま
+	err := conn(ctx, r.db).Where("search_text = '' OR name_key = ''").FindInBatches(&owners, 500, func(*gorm.DB, int) error {
+		for i := range owners {
+			owners[i].UpdateSearchKeys()
+			if err := conn(ctx, r.db).Exec("UPDATE owners SET search_text = ?, phone_digits = ?, name_key = ?, name_kana_key = ?, email_key = ?, address_key = ? WHERE id = ?",
+				owners[i].SearchText, owners[i].PhoneDigits, owners[i].NameKey, owners[i].NameKanaKey,
+				owners[i].EmailKey, owners[i].AddressKey, owners[i].ID).Error; err != nil {
+				return err
+			}
+		}
+		updated += len(owners)
+		return nil
+	}).Error
+	if err != nil {
+		return updated, apperrors.Wrap(err, "failed to refresh owner search keys")
+	}
+
+	var pets []model.Pet
+	err = conn(ctx, r.db).Where("search_text = ''").FindInBatches(&pets, 500, func(*gorm.DB, int) error {
+		for i := range pets {
+			pets[i].UpdateSearchKeys()
+			if err := conn(ctx, r.db).Exec("UPDATE pets SET search_text = ? WHERE id = ?", pets[i].SearchText, pets[i].ID).Error; err != nil {
+				return err
+			}
+		}
+		updated += len(pets)
+		return nil
+	}).Error
+	if err != nil {
+		return updated, apperrors.Wrap(err, "failed to refresh pet search keys")
+	}
+	return updated, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// TrimmingRepository トリミングリポジトリインターフェース
+type TrimmingRepository interface {
+	ListTrimmings(ctx context.Context, filter model.TrimmingFilter, opts model.ListOptions) (*model.ListResult[model.Trimming], error)
+	GetTrimmingByID(ctx context.Context, id uuid.UUID) (*model.Trimming, error)
+	GetTrimmingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Trimming, error)
+	FindOverlappingTrimmings(ctx context.Context, start, end time.Time, excludeID uuid.UUID) ([]model.Trimming, error)
+	LockTrimmingSchedule(ctx context.Context, date time.Time) error
+	ListGroomers(ctx context.Context) ([]model.Staff, error)
+	CreateTrimming(ctx context.Context, trimming *model.Trimming) error
+	UpdateTrimming(ctx context.Context, trimming *model.Trimming) error
+}
+
+// trimmingRepository トリミングリポジトリ実装
+type trimmingRepository struct {
+	db *gorm.DB
+}
+
+// NewTrimmingRepository 新しいトリミングリポジトリを作成
+func NewTrimmingRepository(db *gorm.DB) TrimmingRepository {
+	return &trimmingRepository{db: db}
+}
+
+// trimmingListSpec トリミング一覧の並び替え可能な列
+var trimmingListSpec = listSpec[model.Trimming]{
+	columns: map[string]sortColumn[model.Trimming]{
+		"appointment_date": {column: "appointment_date", value: func(t *model.Trimming) any { return t.AppointmentDate }},
+		"created_at":       {column: "created_at", value: func(t *model.Trimming) any { return t.CreatedAt }},
+		"updated_at":       {column: "updated_at", value: func(t *model.Trimming) any { return t.UpdatedAt }},
+	},
+	defaultSort: "appointment_date",
+	id:          sortColumn[model.Trimming]{column: "id", value: func(t *model.Trimming) any { return t.ID }},
+}
+
+// ListTrimmings 条件に一致するトリミングを1ページ分取得
+func (r *trimmingRepository) ListTrimmings(ctx context.Context, filter model.TrimmingFilter, opts model.ListOptions) (*model.ListResult[model.Trimming], error) {
+	query := conn(ctx, r.db).Model(&model.Trimming{})
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.StaffID != nil {
+		query = query.Where("staff_id = ?", *filter.StaffID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	query = whereDateRange(query, "appointment_date", filter.AppointmentDate)
+	return findPage(query, trimmingListSpec, opts, "Pet", "Owner", "Staff")
+}
+
+// GetTrimmingByID IDでトリミングを取得
+func (r *trimmingRepository) GetTrimmingByID(ctx context.Context, id uuid.UUID) (*model.Trimming, error) {
+	var trimming model.Trimming
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		Preload("Staff").
+		First(&trimming, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("trimming", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get trimming")
+	}
+	return &trimming, nil
+}
+
+// GetTrimmingByIDForUpdate IDでトリミングを行ロック付きで取得（トランザクション内で使う）
+func (r *trimmingRepository) GetTrimmingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Trimming, error) {
+	var trimming model.Trimming
+	if err := conn(ctx, r.db).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&trimming, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("trimming", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get trimming")
+	}
+	return &trimming, nil
+}
+
+// FindOverlappingTrimmings [start, end)と時間帯が重なるトリミングを開始日時順に取得（ペット付き）
+// キャンセル済みのトリミングとexcludeIDのトリミングは対象外。
+func (r *trimmingRepository) FindOverlappingTrimmings(ctx context.Context, start, end time.Time, excludeID uuid.UUID) ([]model.Trimming, error) {
+	var trimmings []model.Trimming
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Where("status <> ?", model.TrimmingStatusCanceled).
+		Where("appointment_date < ? AND appointment_date + duration_minutes * INTERVAL '1 minute' > ?", end, start).
+		Where("id <> ?", excludeID).
+		Order("appointment_date ASC, id ASC").
+		Find(&trimmings).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find overlapping trimmings")
+	}
+	return trimmings, nil
+}
+
+// LockTrimmingSchedule 日単位のトリミング予約のアドバイザリロックを取得する
+// トランザクション内で呼び出すこと。ロックはトランザクション終了時に解放される。
+func (r *trimmingRepository) LockTrimmingSchedule(ctx context.Context, date time.Time) error {
+	if err := conn(ctx, r.db).
+		Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "trimming:"+date.Format("2006-01-02")).Error; err != nil {
+		return apperrors.Wrap(err, "failed to lock trimming schedule")
+	}
+	return nil
+}
+
+// ListGroomers 在籍中のトリマーを名前順に取得
+func (r *trimmingRepository) ListGroomers(ctx context.Context) ([]model.Staff, error) {
+	var staffs []model.Staff
+	if err := conn(ctx, r.db).
+		Where("role = ? AND is_active = ?", model.StaffRoleGroomer, true).
+		Order("name ASC, id ASC").
+		Find(&staffs).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to list groomers")
+	}
+	return staffs, nil
+}
+
+// CreateTrimming トリミングを作成
+func (r *trimmingRepository) CreateTrimming(ctx context.Context, trimming *model.Trimming) error {
+	if err := conn(ctx, r.db).Omit("Pet", "Owner", "Staff").Create(trimming).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create trimming")
+	}
+	return nil
+}
+
+// UpdateTrimming トリミングを更新
+func (r *trimmingRepository) UpdateTrimming(ctx context.Context, trimming *model.Trimming) error {
+	if err := conn(ctx, r.db).Omit("Pet", "Owner", "Staff").Save(trimming).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update trimming")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey トランザクションをcontextに格納するためのキー
+type txKey struct{}
+
+// Transactor トランザクション境界を提供するインターフェース
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// WithinTransaction fnを単一トランザクション内で実行する
+// fnに渡されるcontextを使うリポジトリ呼び出しは全て同じトランザクションに参加する。
+// 既にトランザクション中のcontextが渡された場合はそのトランザクションに合流する。
+func (r *Repository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn contextにトランザクションがあればそれを、なければ通常の接続を返す
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// Ensure Repository implements Transactor
+var _ Transactor = (*Repository)(nil)
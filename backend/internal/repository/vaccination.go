@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// VaccinationRepository ワクチン接種記録リポジトリインターフェース
+type VaccinationRepository interface {
+	GetVaccinations(ctx context.Context, filter model.VaccinationFilter) ([]model.Vaccination, error)
+	ListVaccinations(ctx context.Context, filter model.VaccinationFilter, opts model.ListOptions) (*model.ListResult[model.Vaccination], error)
+	GetVaccinationByID(ctx context.Context, id uuid.UUID) (*model.Vaccination, error)
+	CreateVaccination(ctx context.Context, vaccination *model.Vaccination) error
+	UpdateVaccination(ctx context.Context, vaccination *model.Vaccination) error
+	DeleteVaccination(ctx context.Context, id uuid.UUID) error
+	FindDueVaccinations(ctx context.Context, from *time.Time, until time.Time) ([]model.Vaccination, error)
+	FindUnremindedVaccinations(ctx context.Context, from, until time.Time, channel string) ([]model.Vaccination, error)
+	CreateVaccinationReminders(ctx context.Context, reminders []model.VaccinationReminder) error
+}
+
+// vaccinationRepository ワクチン接種記録リポジトリ実装
+type vaccinationRepository struct {
+	db *gorm.DB
+}
+
+// NewVaccinationRepository 新しいワクチン接種記録リポジトリを作成
+func NewVaccinationRepository(db *gorm.DB) VaccinationRepository {
+	return &vaccinationRepository{db: db}
+}
+
+// GetVaccinations 条件に一致するワクチン接種記録を接種日の新しい順に取得
+func (r *vaccinationRepository) GetVaccinations(ctx context.Context, filter model.VaccinationFilter) ([]model.Vaccination, error) {
+	var vaccinations []model.Vaccination
+	query := vaccinationFilter(conn(ctx, r.db).Preload("Pet").Preload("Owner"), filter)
+	if err := query.Order("vaccination_date DESC, created_at DESC").Find(&vaccinations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to get vaccinations")
+	}
+	return vaccinations, nil
+}
+
+// vaccinationListSpec ワクチン接種記録一覧の並び替え可能な列
+var vaccinationListSpec = listSpec[model.Vaccination]{
+	columns: map[string]sortColumn[model.Vaccination]{
+		"vaccination_date": {column: "vaccination_date", value: func(v *model.Vaccination) any { return v.VaccinationDate }},
+		"created_at":       {column: "created_at", value: func(v *model.Vaccination) any { return v.CreatedAt }},
+	},
+	defaultSort: "-vaccination_date,-created_at",
+	id:          sortColumn[model.Vaccination]{column: "id", value: func(v *model.Vaccination) any { return v.ID }},
+}
+
+// ListVaccinations 条件に一致するワクチン接種記録を1ページ分取得
+func (r *vaccinationRepository) ListVaccinations(ctx context.Context, filter model.VaccinationFilter, opts model.ListOptions) (*model.ListResult[model.Vaccination], error) {
+	query := vaccinationFilter(conn(ctx, r.db).Model(&model.Vaccination{}), filter)
+	return findPage(query, vaccinationListSpec, opts, "Pet", "Owner")
+}
+
+// vaccinationFilter ワクチン接種記録の検索条件をクエリに適用する
+func vaccinationFilter(query *gorm.DB, filter model.VaccinationFilter) *gorm.DB {
+	if filter.PetID != nil {
+		query = query.Where("pet_id = ?", *filter.PetID)
+	}
+	if filter.OwnerID != nil {
+		query = query.Where("owner_id = ?", *filter.OwnerID)
+	}
+	if filter.DoctorID != nil {
+		query = query.Where("doctor_id = ?", *filter.DoctorID)
+	}
+	if filter.LotNumber != "" {
+		query = query.Where("lot_number = ?", filter.LotNumber)
+	}
+	return whereDateRange(query, "vaccination_date", filter.VaccinationDate)
+}
+
+// GetVaccinationByID IDでワクチン接種記録を取得
+func (r *vaccinationRepository) GetVaccinationByID(ctx context.Context, id uuid.UUID) (*model.Vaccination, error) {
+	var vaccination model.Vaccination
+	if err := conn(ctx, r.db).
+		Preload("Pet").
+		Preload("Owner").
+		First(&vaccination, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperrors.WrapNotFound("vaccination", id.String())
+		}
+		return nil, apperrors.Wrap(err, "failed to get vaccination")
+	}
+	return &vaccination, nil
+}
+
+// CreateVaccination ワクチン接種記録を作成
+func (r *vaccinationRepository) CreateVaccination(ctx context.Context, vaccination *model.Vaccination) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Create(vaccination).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create vaccination")
+	}
+	return nil
+}
+
+// UpdateVaccination ワクチン接種記録を更新（関連は更新しない）
+func (r *vaccinationRepository) UpdateVaccination(ctx context.Context, vaccination *model.Vaccination) error {
+	if err := conn(ctx, r.db).Omit(clause.Associations).Save(vaccination).Error; err != nil {
+		return apperrors.Wrap(err, "failed to update vaccination")
+	}
+	return nil
+}
+
+// DeleteVaccination ワクチン接種記録を送付記録とともに削除
+func (r *vaccinationRepository) DeleteVaccination(ctx context.Context, id uuid.UUID) error {
+	if err := conn(ctx, r.db).Delete(&model.VaccinationReminder{}, "vaccination_id = ?", id).Error; err != nil {
+		return apperrors.Wrap(err, "failed to delete vaccination reminders")
+	}
+	result := conn(ctx, r.db).Delete(&model.Vaccination{}, "id = ?", id)
+	if result.Error != nil {
+		return apperrors.Wrap(result.Error, "failed to delete vaccination")
+	}
+	if result.RowsAffected == 0 {
+		return apperrors.WrapNotFound("vaccination", id.String())
+	}
+	return nil
+}
+
+// dueVaccinations 次回接種予定のある最新の接種記録を対象にするクエリ
+// 同じワクチン（マスタまたは名称が同じ）をその後に接種済みの記録と、生存していないペットの記録は対象外。
+func dueVaccinations(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Pet").
+		Preload("Owner").
+		Joins("JOIN pets ON pets.id = vaccinations.pet_id").
+		Where("pets.status = ?", "生存").
+		Where("vaccinations.next_date IS NOT NULL").
+		Where(`NOT EXISTS (
+			SELECT 1 FROM vaccinations later
+			WHERE later.pet_id = vaccinations.pet_id
+			  AND later.vaccination_date > vaccinations.vaccination_date
+			  AND (later.vaccine_master_id = vaccinations.vaccine_master_id OR later.vaccine_name = vaccinations.vaccine_name)
+		)`).
+		Order("vaccinations.next_date ASC, vaccinations.owner_id ASC")
+}
+
+// FindDueVaccinations 次回接種予定日がfrom〜untilの接種記録を取得（from未指定なら予定日を過ぎたものも含む）
+func (r *vaccinationRepository) FindDueVaccinations(ctx context.Context, from *time.Time, until time.Time) ([]model.Vaccination, error) {
+	var vaccinations []model.Vaccination
+	query := dueVaccinations(conn(ctx, r.db)).Where("vaccinations.next_date <= ?", until)
+	if from != nil {
+		query = query.Where("vaccinations.next_date >= ?", *from)
+	}
+	if err := query.Find(&vaccinations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find due vaccinations")
+	}
+	return vaccinations, nil
+}
+
+// FindUnremindedVaccinations 次回接種予定日がfrom〜untilで、指定経路でまだ案内していない接種記録を取得
+func (r *vaccinationRepository) FindUnremindedVaccinations(ctx context.Context, from, until time.Time, channel string) ([]model.Vaccination, error) {
+	var vaccinations []model.Vaccination
+	if err := dueVaccinations(conn(ctx, r.db)).
+		Where("vaccinations.next_date BETWEEN ? AND ?", from, until).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM vaccination_reminders sent
+			WHERE sent.vaccination_id = vaccinations.id AND sent.channel = ?
+		)`, channel).
+		Find(&vaccinations).Error; err != nil {
+		return nil, apperrors.Wrap(err, "failed to find unreminded vaccinations")
+	}
+	return vaccinations, nil
+}
+
+// CreateVaccinationReminders 接種案内の送付記録を作成（記録済みのものは無視する）
+func (r *vaccinationRepository) CreateVaccinationReminders(ctx context.Context, reminders []model.VaccinationReminder) error {
+	if len(reminders) == 0 {
+		return nil
+	}
+	if err := conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(&reminders).Error; err != nil {
+		return apperrors.Wrap(err, "failed to create vaccination reminders")
+	}
+	return nil
+}
@@ -0,0 +1,500 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/invoice"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// AccountingService 会計サービスインターフェース
+type AccountingService interface {
+	ListAccountings(ctx context.Context, req *model.ListAccountingsRequest) (*model.ListResult[model.Accounting], error)
+	GetAccountingByID(ctx context.Context, id string) (*model.Accounting, error)
+	CreateAccountingFromMedicalRecord(ctx context.Context, req *model.CreateAccountingRequest) (*model.Accounting, error)
+	UpdateAccounting(ctx context.Context, id string, req *model.UpdateAccountingRequest) (*model.Accounting, error)
+	DeleteAccounting(ctx context.Context, id string) error
+	AddAccountingItem(ctx context.Context, id string, req *model.AddAccountingItemRequest) (*model.Accounting, error)
+	DeleteAccountingItem(ctx context.Context, id, itemID string) (*model.Accounting, error)
+	CompleteAccounting(ctx context.Context, id string, req *model.CompleteAccountingRequest) (*model.Accounting, error)
+	RenderAccountingReceipt(ctx context.Context, id string) ([]byte, error)
+	RenderAccountingInvoice(ctx context.Context, id string) ([]byte, error)
+}
+
+// Ensure Service implements AccountingService
+var _ AccountingService = (*Service)(nil)
+
+// defaultTaxRate 税率未設定の明細に適用する標準税率
+var defaultTaxRate = decimal.MustParse("0.10")
+
+// ListAccountings 条件に一致する会計を1ページ分取得（既定は会計日の新しい順）
+func (s *Service) ListAccountings(ctx context.Context, req *model.ListAccountingsRequest) (*model.ListResult[model.Accounting], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.AccountingFilter{Status: req.Status}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.ScheduledDate, err = parseDateRange(req.DateFrom, req.DateTo, time.Local); err != nil {
+		return nil, err
+	}
+	return s.accountingRepo.ListAccountings(ctx, filter, req.ListOptions)
+}
+
+// GetAccountingByID IDで会計を取得
+func (s *Service) GetAccountingByID(ctx context.Context, id string) (*model.Accounting, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid accounting ID format")
+	}
+	return s.accountingRepo.GetAccountingByID(ctx, uid)
+}
+
+// CreateAccountingFromMedicalRecord カルテの明細から会計を作成する
+// カルテ明細ごとにマスタの名称・単価・税率・保険適用区分を写した会計明細を作り、金額を計算する。
+// 1つのカルテに対して有効な（キャンセル以外の）会計は1件まで。
+func (s *Service) CreateAccountingFromMedicalRecord(ctx context.Context, req *model.CreateAccountingRequest) (*model.Accounting, error) {
+	if err := validation.ValidateCreateAccounting(req); err != nil {
+		return nil, err
+	}
+
+	record, err := s.GetMedicalRecordByID(ctx, req.MedicalRecordID)
+	if err != nil {
+		return nil, err
+	}
+
+	scheduledDate := time.Now()
+	if req.ScheduledDate != "" {
+		scheduledDate, err = time.ParseInLocation("2006-01-02", req.ScheduledDate, time.Local)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid scheduled date format")
+		}
+	}
+
+	insuranceName := req.InsuranceName
+	if insuranceName == "" && record.Pet != nil {
+		insuranceName = record.Pet.InsuranceName
+	}
+
+	accounting := &model.Accounting{
+		MedicalRecordID: &record.ID,
+		PetID:           record.PetID,
+		OwnerID:         record.OwnerID,
+		ScheduledDate:   scheduledDate,
+		Status:          model.AccountingStatusUnpaid,
+		InsuranceName:   insuranceName,
+		InsuranceRatio:  req.InsuranceRatio,
+		DiscountAmount:  req.DiscountAmount,
+		Memo:            req.Memo,
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.accountingRepo.FindActiveAccountingByMedicalRecordID(ctx, record.ID)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			return apperrors.WrapConflict("accounting already exists for this medical record")
+		}
+
+		items, err := s.medicalRecordRepo.GetMedicalRecordItems(ctx, record.ID.String())
+		if err != nil {
+			return err
+		}
+		masters := make([]*model.MasterItem, 0, len(items))
+		for _, item := range items {
+			if item.MasterItem == nil {
+				return apperrors.WrapNotFound("master_item", item.MasterItemID.String())
+			}
+			masters = append(masters, item.MasterItem)
+		}
+		// 明細の単価は会計日時点の価格履歴による
+		if err := s.applyEffectivePrices(ctx, accounting.ScheduledDate, masters...); err != nil {
+			return err
+		}
+		for _, item := range items {
+			accounting.AccountingItems = append(accounting.AccountingItems, accountingItemFromMaster(item.MasterItem, item.Quantity, model.AccountingItemSourceMedicalRecord))
+		}
+
+		if err := calculateAccounting(accounting); err != nil {
+			return err
+		}
+		return s.accountingRepo.CreateAccounting(ctx, accounting)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounting, nil
+}
+
+// UpdateAccounting 会計を更新し、金額を再計算する
+func (s *Service) UpdateAccounting(ctx context.Context, id string, req *model.UpdateAccountingRequest) (*model.Accounting, error) {
+	if err := validation.ValidateUpdateAccounting(req); err != nil {
+		return nil, err
+	}
+
+	return s.modifyAccounting(ctx, id, func(ctx context.Context, accounting *model.Accounting) error {
+		if req.Status != nil {
+			accounting.Status = *req.Status
+		}
+		if req.InsuranceName != nil {
+			accounting.InsuranceName = *req.InsuranceName
+		}
+		if req.InsuranceRatio != nil {
+			accounting.InsuranceRatio = req.InsuranceRatio
+		}
+		if req.DiscountAmount != nil {
+			accounting.DiscountAmount = req.DiscountAmount
+		}
+		if req.Memo != nil {
+			accounting.Memo = *req.Memo
+		}
+		return nil
+	})
+}
+
+// DeleteAccounting 会計を削除（回収済の会計は削除できない）
+func (s *Service) DeleteAccounting(ctx context.Context, id string) error {
+	accounting, err := s.GetAccountingByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if accounting.Status == model.AccountingStatusPaid {
+		return apperrors.WrapConflict("paid accounting cannot be deleted")
+	}
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		return s.accountingRepo.DeleteAccounting(ctx, accounting.ID)
+	})
+}
+
+// AddAccountingItem 会計に明細を手動で追加し、金額を再計算する
+func (s *Service) AddAccountingItem(ctx context.Context, id string, req *model.AddAccountingItemRequest) (*model.Accounting, error) {
+	if err := validation.ValidateAddAccountingItem(req); err != nil {
+		return nil, err
+	}
+
+	item := model.AccountingItem{Source: model.AccountingItemSourceManual}
+	var master *model.MasterItem
+	if req.MasterID != "" {
+		var err error
+		master, err = s.masterItemRepo.GetMasterItemByID(ctx, uuid.MustParse(req.MasterID))
+		if err != nil {
+			return nil, err
+		}
+		item = accountingItemFromMaster(master, 0, model.AccountingItemSourceManual)
+	}
+	if req.Code != "" {
+		item.Code = req.Code
+	}
+	if req.Category != "" {
+		item.Category = req.Category
+	}
+	if req.Name != "" {
+		item.Name = req.Name
+	}
+	if req.UnitPrice != nil {
+		item.UnitPrice = req.UnitPrice
+	}
+	if req.Quantity > 0 {
+		item.Quantity = req.Quantity
+	}
+	if item.Quantity == 0 {
+		item.Quantity = 1
+	}
+	if req.TaxRate != nil {
+		item.TaxRate = req.TaxRate
+	}
+	if item.TaxRate == nil {
+		item.TaxRate = defaultTaxRate.Ptr()
+	}
+	if req.IsInsuranceApplicable != nil {
+		item.IsInsuranceApplicable = *req.IsInsuranceApplicable
+	}
+
+	return s.modifyAccounting(ctx, id, func(ctx context.Context, accounting *model.Accounting) error {
+		// 単価・税率の指定がなければ会計日時点の価格履歴による
+		if master != nil {
+			if err := s.applyEffectivePrices(ctx, accounting.ScheduledDate, master); err != nil {
+				return err
+			}
+			if req.UnitPrice == nil {
+				item.UnitPrice = master.Price
+			}
+			if req.TaxRate == nil && master.TaxRate != nil {
+				item.TaxRate = master.TaxRate
+			}
+		}
+		item.AccountingID = accounting.ID
+		if err := s.accountingRepo.CreateAccountingItem(ctx, &item); err != nil {
+			return err
+		}
+		accounting.AccountingItems = append(accounting.AccountingItems, item)
+		return nil
+	})
+}
+
+// DeleteAccountingItem 会計明細を削除し、金額を再計算する
+func (s *Service) DeleteAccountingItem(ctx context.Context, id, itemID string) (*model.Accounting, error) {
+	itemUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid accounting item ID format")
+	}
+
+	return s.modifyAccounting(ctx, id, func(ctx context.Context, accounting *model.Accounting) error {
+		if err := s.accountingRepo.DeleteAccountingItem(ctx, accounting.ID, itemUID); err != nil {
+			return err
+		}
+		items := accounting.AccountingItems[:0]
+		for _, item := range accounting.AccountingItems {
+			if item.ID != itemUID {
+				items = append(items, item)
+			}
+		}
+		accounting.AccountingItems = items
+		return nil
+	})
+}
+
+// CompleteAccounting 入金を記録して会計を回収済にする
+// 現金の場合は預り金が請求額以上であることを確認し、釣銭を計算する。
+// 在庫品目に紐づくマスタ項目の明細は、同じトランザクションで在庫から払い出す。
+func (s *Service) CompleteAccounting(ctx context.Context, id string, req *model.CompleteAccountingRequest) (*model.Accounting, error) {
+	if err := validation.ValidateCompleteAccounting(req); err != nil {
+		return nil, err
+	}
+
+	return s.modifyAccounting(ctx, id, func(ctx context.Context, accounting *model.Accounting) error {
+		if accounting.Status == model.AccountingStatusCancelled {
+			return apperrors.WrapConflict("cancelled accounting cannot be completed")
+		}
+		if err := calculateAccounting(accounting); err != nil {
+			return err
+		}
+
+		billing := valueOrZero(accounting.BillingAmount)
+		received := billing
+		if req.PaymentMethod == model.PaymentMethodCash {
+			if req.ReceivedAmount == nil {
+				return apperrors.WrapInvalidInput("received amount is required for cash payment")
+			}
+			received = *req.ReceivedAmount
+			if received.Cmp(billing) < 0 {
+				return apperrors.WrapInvalidInput("received amount is less than billing amount")
+			}
+		}
+
+		now := time.Now()
+		accounting.Status = model.AccountingStatusPaid
+		accounting.PaymentMethod = req.PaymentMethod
+		accounting.ReceivedAmount = received.Ptr()
+		accounting.ChangeAmount = received.Sub(billing).Ptr()
+		accounting.CompletedAt = &now
+		return s.dispenseAccountingItems(ctx, accounting.AccountingItems)
+	})
+}
+
+// RenderAccountingReceipt 回収済の会計の領収書PDFを作成する
+func (s *Service) RenderAccountingReceipt(ctx context.Context, id string) ([]byte, error) {
+	data, err := s.accountingDocumentData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data.Accounting.Status != model.AccountingStatusPaid {
+		return nil, apperrors.WrapConflict("receipt can only be issued for a paid accounting")
+	}
+	renderer, err := s.documentRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return renderer.Receipt(data)
+}
+
+// RenderAccountingInvoice 会計の請求書PDFを作成する（キャンセル済を除く）
+func (s *Service) RenderAccountingInvoice(ctx context.Context, id string) ([]byte, error) {
+	data, err := s.accountingDocumentData(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if data.Accounting.Status == model.AccountingStatusCancelled {
+		return nil, apperrors.WrapConflict("invoice cannot be issued for a cancelled accounting")
+	}
+	renderer, err := s.documentRenderer()
+	if err != nil {
+		return nil, err
+	}
+	return renderer.Invoice(data)
+}
+
+// accountingDocumentData 帳票に印字する会計・クリニック情報・税率別内訳を集める
+func (s *Service) accountingDocumentData(ctx context.Context, id string) (*invoice.Data, error) {
+	accounting, err := s.GetAccountingByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	clinic, err := s.clinicRepo.GetClinic(ctx)
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, apperrors.WrapConflict("clinic information must be registered before issuing documents")
+		}
+		return nil, err
+	}
+
+	return &invoice.Data{
+		Clinic:       clinic,
+		Accounting:   accounting,
+		TaxBreakdown: taxBreakdown(accounting.AccountingItems, false),
+		IssuedAt:     time.Now(),
+	}, nil
+}
+
+// documentRenderer 帳票のRenderer（未設定なら埋め込みフォントで作成する）
+func (s *Service) documentRenderer() (*invoice.Renderer, error) {
+	if s.invoices != nil {
+		return s.invoices, nil
+	}
+	font, err := invoice.LoadFont("")
+	if err != nil {
+		return nil, apperrors.WrapInternal(err, "failed to load document font")
+	}
+	return invoice.NewRenderer(font), nil
+}
+
+// modifyAccounting 未収・保留の会計を行ロックして変更し、金額を再計算して保存する
+func (s *Service) modifyAccounting(ctx context.Context, id string, fn func(ctx context.Context, accounting *model.Accounting) error) (*model.Accounting, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid accounting ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		accounting, err := s.accountingRepo.GetAccountingByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if accounting.Status != model.AccountingStatusUnpaid && accounting.Status != model.AccountingStatusOnHold {
+			return apperrors.WrapConflict("accounting in status " + accounting.Status + " cannot be modified")
+		}
+		if err := fn(ctx, accounting); err != nil {
+			return err
+		}
+		if err := calculateAccounting(accounting); err != nil {
+			return err
+		}
+		return s.accountingRepo.UpdateAccounting(ctx, accounting)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.accountingRepo.GetAccountingByID(ctx, uid)
+}
+
+// accountingItemFromMaster マスタ項目から会計明細を作成する
+// quantityが0の場合はマスタの既定数量（未設定なら1）を使う。
+func accountingItemFromMaster(master *model.MasterItem, quantity int, source string) model.AccountingItem {
+	if quantity <= 0 {
+		quantity = 1
+		if master.DefaultQuantity != nil && *master.DefaultQuantity > 0 {
+			quantity = *master.DefaultQuantity
+		}
+	}
+	taxRate := master.TaxRate
+	if taxRate == nil {
+		taxRate = defaultTaxRate.Ptr()
+	}
+	return model.AccountingItem{
+		MasterID:              &master.ID,
+		Code:                  master.Code,
+		Category:              master.Category,
+		Name:                  master.Name,
+		UnitPrice:             master.Price,
+		Quantity:              quantity,
+		TaxRate:               taxRate,
+		IsInsuranceApplicable: master.IsInsuranceApplicable,
+		Source:                source,
+	}
+}
+
+// taxBreakdown 明細を税率ごとに集計する（税率の高い順）
+// 消費税は税率ごとの合計額に対して1回だけ計算し、円未満を切り捨てる（インボイス制度の端数処理）。
+func taxBreakdown(items []model.AccountingItem, insuredOnly bool) []model.TaxBreakdown {
+	byRate := map[decimal.Decimal]decimal.Decimal{}
+	for _, item := range items {
+		if insuredOnly && !item.IsInsuranceApplicable {
+			continue
+		}
+		rate := defaultTaxRate
+		if item.TaxRate != nil {
+			rate = *item.TaxRate
+		}
+		byRate[rate] = byRate[rate].Add(valueOrZero(item.UnitPrice).MulInt(int64(item.Quantity)))
+	}
+
+	result := make([]model.TaxBreakdown, 0, len(byRate))
+	for rate, taxable := range byRate {
+		result = append(result, model.TaxBreakdown{
+			Rate:    rate,
+			Taxable: taxable,
+			Tax:     taxable.Mul(rate).Floor(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Rate.Cmp(result[j].Rate) > 0
+	})
+	return result
+}
+
+// calculateAccounting 会計明細から小計・消費税・合計・保険負担額・請求額を計算する
+// 保険負担額は保険適用明細の税込額に負担割合を掛けて円未満を切り捨て、
+// 請求額は合計から保険負担額と値引額を差し引いた額とする。
+func calculateAccounting(a *model.Accounting) error {
+	subtotal, taxTotal := decimal.Zero, decimal.Zero
+	for _, b := range taxBreakdown(a.AccountingItems, false) {
+		subtotal = subtotal.Add(b.Taxable)
+		taxTotal = taxTotal.Add(b.Tax)
+	}
+	total := subtotal.Add(taxTotal)
+
+	insurance := decimal.Zero
+	if a.InsuranceRatio != nil && !a.InsuranceRatio.IsZero() {
+		insured := decimal.Zero
+		for _, b := range taxBreakdown(a.AccountingItems, true) {
+			insured = insured.Add(b.Taxable).Add(b.Tax)
+		}
+		insurance = insured.Mul(*a.InsuranceRatio).Floor()
+	}
+
+	billing := total.Sub(insurance).Sub(valueOrZero(a.DiscountAmount))
+	if billing.IsNegative() {
+		return apperrors.WrapInvalidInput("discount amount exceeds the amount to be billed")
+	}
+
+	a.Subtotal = subtotal.Ptr()
+	a.TaxTotal = taxTotal.Ptr()
+	a.TotalAmount = total.Ptr()
+	a.InsuranceAmount = insurance.Ptr()
+	a.BillingAmount = billing.Ptr()
+	return nil
+}
+
+// valueOrZero nilの金額を0として扱う
+func valueOrZero(d *decimal.Decimal) decimal.Decimal {
+	if d == nil {
+		return decimal.Zero
+	}
+	return *d
+}
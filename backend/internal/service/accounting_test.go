@@ -0,0 +1,341 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockAccountingRepository is a mock implementation of repository.AccountingRepository
+type MockAccountingRepository struct {
+	mock.Mock
+}
+
+func (m *MockAccountingRepository) ListAccountings(ctx context.Context, filter model.AccountingFilter, opts model.ListOptions) (*model.ListResult[model.Accounting], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Accounting]), args.Error(1)
+}
+
+func (m *MockAccountingRepository) GetAccountingByID(ctx context.Context, id uuid.UUID) (*model.Accounting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockAccountingRepository) GetAccountingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Accounting, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockAccountingRepository) FindActiveAccountingByMedicalRecordID(ctx context.Context, recordID uuid.UUID) (*model.Accounting, error) {
+	args := m.Called(ctx, recordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Accounting), args.Error(1)
+}
+
+func (m *MockAccountingRepository) CreateAccounting(ctx context.Context, accounting *model.Accounting) error {
+	args := m.Called(ctx, accounting)
+	return args.Error(0)
+}
+
+func (m *MockAccountingRepository) UpdateAccounting(ctx context.Context, accounting *model.Accounting) error {
+	args := m.Called(ctx, accounting)
+	return args.Error(0)
+}
+
+func (m *MockAccountingRepository) DeleteAccounting(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAccountingRepository) CreateAccountingItem(ctx context.Context, item *model.AccountingItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockAccountingRepository) DeleteAccountingItem(ctx context.Context, accountingID, itemID uuid.UUID) error {
+	args := m.Called(ctx, accountingID, itemID)
+	return args.Error(0)
+}
+
+func dec(s string) *decimal.Decimal {
+	return decimal.MustParse(s).Ptr()
+}
+
+// 診察料・血液検査（10%・保険適用）と療法食（軽減税率8%・保険対象外）の混在
+func mixedRateMasterItems() []model.MedicalRecordItem {
+	three := 3
+	return []model.MedicalRecordItem{
+		{Quantity: 1, MasterItem: &model.MasterItem{ID: uuid.New(), Code: "C001", Name: "再診料", Price: dec("1100"), TaxRate: dec("0.10"), IsInsuranceApplicable: true}},
+		{Quantity: 1, MasterItem: &model.MasterItem{ID: uuid.New(), Code: "E010", Name: "血液検査", Price: dec("4400"), TaxRate: dec("0.10"), IsInsuranceApplicable: true}},
+		{Quantity: 0, MasterItem: &model.MasterItem{ID: uuid.New(), Code: "F100", Name: "療法食", Price: dec("1980"), TaxRate: dec("0.08"), DefaultQuantity: &three}},
+	}
+}
+
+func TestCreateAccountingFromMedicalRecord(t *testing.T) {
+	ctx := context.Background()
+	recordID := uuid.New()
+	petID := uuid.New()
+	ownerID := uuid.New()
+
+	t.Run("calculates mixed tax rates and insurance on applicable lines only", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, mockRecordRepo, nil, WithAccountingRepository(mockAccountingRepo), WithMasterItemRepository(mockMasterRepo), WithTransactor(tx))
+
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, recordID.String()).Return(&model.MedicalRecord{
+			ID: recordID, PetID: petID, OwnerID: ownerID,
+			Pet: &model.Pet{InsuranceName: "どうぶつ保険"},
+		}, nil)
+		mockAccountingRepo.On("FindActiveAccountingByMedicalRecordID", ctx, recordID).Return(nil, nil)
+		mockRecordRepo.On("GetMedicalRecordItems", ctx, recordID.String()).Return(mixedRateMasterItems(), nil)
+		mockMasterRepo.On("GetEffectivePrices", ctx, mock.Anything, mock.Anything).Return([]model.MasterItemPrice{}, nil)
+		mockAccountingRepo.On("CreateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).Return(nil)
+
+		accounting, err := svc.CreateAccountingFromMedicalRecord(ctx, &model.CreateAccountingRequest{
+			MedicalRecordID: recordID.String(),
+			InsuranceRatio:  dec("0.7"),
+			DiscountAmount:  dec("100"),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, "どうぶつ保険", accounting.InsuranceName)
+		assert.Equal(t, model.AccountingStatusUnpaid, accounting.Status)
+		assert.Len(t, accounting.AccountingItems, 3)
+		assert.Equal(t, model.AccountingItemSourceMedicalRecord, accounting.AccountingItems[0].Source)
+		assert.Equal(t, 3, accounting.AccountingItems[2].Quantity)
+
+		// 小計 1,100 + 4,400 + 1,980×3 = 11,440
+		assert.Equal(t, "11440", accounting.Subtotal.String())
+		// 10%対象 5,500 → 550、8%対象 5,940 → 475.2 → 475（税率ごとに切り捨て）
+		assert.Equal(t, "1025", accounting.TaxTotal.String())
+		assert.Equal(t, "12465", accounting.TotalAmount.String())
+		// 保険適用明細の税込額 6,050 × 0.7 = 4,235
+		assert.Equal(t, "4235", accounting.InsuranceAmount.String())
+		assert.Equal(t, "8130", accounting.BillingAmount.String())
+	})
+
+	t.Run("uses prices effective on the accounting date", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, mockRecordRepo, nil, WithAccountingRepository(mockAccountingRepo), WithMasterItemRepository(mockMasterRepo))
+
+		items := mixedRateMasterItems()
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, recordID.String()).
+			Return(&model.MedicalRecord{ID: recordID, PetID: petID, OwnerID: ownerID}, nil)
+		mockAccountingRepo.On("FindActiveAccountingByMedicalRecordID", ctx, recordID).Return(nil, nil)
+		mockRecordRepo.On("GetMedicalRecordItems", ctx, recordID.String()).Return(items, nil)
+		// 2024年4月の改定前の価格（再診料1,000円）
+		onScheduledDate := mock.MatchedBy(func(d time.Time) bool { return d.Format("2006-01-02") == "2024-03-15" })
+		mockMasterRepo.On("GetEffectivePrices", ctx, mock.Anything, onScheduledDate).Return([]model.MasterItemPrice{
+			{MasterItemID: items[0].MasterItem.ID, Price: decimal.MustParse("1000"), TaxRate: dec("0.10")},
+		}, nil)
+		mockAccountingRepo.On("CreateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).Return(nil)
+
+		accounting, err := svc.CreateAccountingFromMedicalRecord(ctx, &model.CreateAccountingRequest{
+			MedicalRecordID: recordID.String(),
+			ScheduledDate:   "2024-03-15",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1000", accounting.AccountingItems[0].UnitPrice.String())
+		assert.Equal(t, "4400", accounting.AccountingItems[1].UnitPrice.String())
+		// 小計 1,000 + 4,400 + 1,980×3 = 11,340
+		assert.Equal(t, "11340", accounting.Subtotal.String())
+	})
+
+	t.Run("rejects second active accounting for the same record", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		svc := New(nil, nil, mockRecordRepo, nil, WithAccountingRepository(mockAccountingRepo))
+
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, recordID.String()).
+			Return(&model.MedicalRecord{ID: recordID, PetID: petID, OwnerID: ownerID}, nil)
+		mockAccountingRepo.On("FindActiveAccountingByMedicalRecordID", ctx, recordID).
+			Return(&model.Accounting{ID: uuid.New()}, nil)
+
+		_, err := svc.CreateAccountingFromMedicalRecord(ctx, &model.CreateAccountingRequest{MedicalRecordID: recordID.String()})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockAccountingRepo.AssertNotCalled(t, "CreateAccounting", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCalculateAccounting(t *testing.T) {
+	t.Run("no insurance and no discount", func(t *testing.T) {
+		a := &model.Accounting{AccountingItems: []model.AccountingItem{
+			{UnitPrice: dec("333"), Quantity: 3, TaxRate: dec("0.08")},
+			{UnitPrice: dec("0.5"), Quantity: 1},
+		}}
+
+		assert.NoError(t, calculateAccounting(a))
+		// 999 × 0.08 = 79.92 → 79、税率未設定は10%として 0.05 → 0
+		assert.Equal(t, "999.5", a.Subtotal.String())
+		assert.Equal(t, "79", a.TaxTotal.String())
+		assert.Equal(t, "0", a.InsuranceAmount.String())
+		assert.Equal(t, "1078.5", a.BillingAmount.String())
+	})
+
+	t.Run("discount larger than billing amount", func(t *testing.T) {
+		a := &model.Accounting{
+			DiscountAmount:  dec("2000"),
+			AccountingItems: []model.AccountingItem{{UnitPrice: dec("1000"), Quantity: 1, TaxRate: dec("0.10")}},
+		}
+
+		assert.True(t, apperrors.IsInvalidInput(calculateAccounting(a)))
+	})
+}
+
+func TestCompleteAccounting(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	unpaid := func() *model.Accounting {
+		return &model.Accounting{
+			ID:              id,
+			Status:          model.AccountingStatusUnpaid,
+			AccountingItems: []model.AccountingItem{{UnitPrice: dec("1980"), Quantity: 1, TaxRate: dec("0.10")}},
+		}
+	}
+
+	t.Run("cash payment calculates change", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo))
+
+		var saved *model.Accounting
+		mockAccountingRepo.On("GetAccountingByIDForUpdate", ctx, id).Return(unpaid(), nil)
+		mockAccountingRepo.On("UpdateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*model.Accounting) }).
+			Return(nil)
+		mockAccountingRepo.On("GetAccountingByID", ctx, id).Return(&model.Accounting{ID: id}, nil)
+
+		_, err := svc.CompleteAccounting(ctx, id.String(), &model.CompleteAccountingRequest{
+			PaymentMethod:  model.PaymentMethodCash,
+			ReceivedAmount: dec("5000"),
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.AccountingStatusPaid, saved.Status)
+		assert.Equal(t, "2178", saved.BillingAmount.String())
+		assert.Equal(t, "2822", saved.ChangeAmount.String())
+		assert.NotNil(t, saved.CompletedAt)
+	})
+
+	t.Run("cash payment short of billing amount", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo))
+
+		mockAccountingRepo.On("GetAccountingByIDForUpdate", ctx, id).Return(unpaid(), nil)
+
+		_, err := svc.CompleteAccounting(ctx, id.String(), &model.CompleteAccountingRequest{
+			PaymentMethod:  model.PaymentMethodCash,
+			ReceivedAmount: dec("2000"),
+		})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockAccountingRepo.AssertNotCalled(t, "UpdateAccounting", mock.Anything, mock.Anything)
+	})
+
+	t.Run("paid accounting cannot be modified", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo))
+
+		paid := unpaid()
+		paid.Status = model.AccountingStatusPaid
+		mockAccountingRepo.On("GetAccountingByIDForUpdate", ctx, id).Return(paid, nil)
+
+		_, err := svc.CompleteAccounting(ctx, id.String(), &model.CompleteAccountingRequest{PaymentMethod: model.PaymentMethodCreditCard})
+
+		assert.True(t, apperrors.IsConflict(err))
+	})
+}
+
+// MockClinicRepository is a mock implementation of repository.ClinicRepository
+type MockClinicRepository struct {
+	mock.Mock
+}
+
+func (m *MockClinicRepository) GetClinic(ctx context.Context) (*model.Clinic, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Clinic), args.Error(1)
+}
+
+func TestRenderAccountingReceipt(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	clinic := &model.Clinic{Name: "さくら動物病院", RegistrationNumber: "T1234567890123"}
+
+	t.Run("renders receipt for paid accounting", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockClinicRepo := new(MockClinicRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo), WithClinicRepository(mockClinicRepo))
+
+		mockAccountingRepo.On("GetAccountingByID", ctx, id).Return(&model.Accounting{
+			ID:              id,
+			Status:          model.AccountingStatusPaid,
+			BillingAmount:   dec("2178"),
+			AccountingItems: []model.AccountingItem{{UnitPrice: dec("1980"), Quantity: 1, TaxRate: dec("0.10")}},
+		}, nil)
+		mockClinicRepo.On("GetClinic", ctx).Return(clinic, nil)
+
+		body, err := svc.RenderAccountingReceipt(ctx, id.String())
+
+		assert.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(body, []byte("%PDF-")))
+	})
+
+	t.Run("rejects unpaid accounting", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockClinicRepo := new(MockClinicRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo), WithClinicRepository(mockClinicRepo))
+
+		mockAccountingRepo.On("GetAccountingByID", ctx, id).
+			Return(&model.Accounting{ID: id, Status: model.AccountingStatusUnpaid}, nil)
+		mockClinicRepo.On("GetClinic", ctx).Return(clinic, nil)
+
+		_, err := svc.RenderAccountingReceipt(ctx, id.String())
+
+		assert.True(t, apperrors.IsConflict(err))
+	})
+
+	t.Run("requires registered clinic", func(t *testing.T) {
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockClinicRepo := new(MockClinicRepository)
+		svc := New(nil, nil, nil, nil, WithAccountingRepository(mockAccountingRepo), WithClinicRepository(mockClinicRepo))
+
+		mockAccountingRepo.On("GetAccountingByID", ctx, id).
+			Return(&model.Accounting{ID: id, Status: model.AccountingStatusPaid}, nil)
+		mockClinicRepo.On("GetClinic", ctx).Return(nil, apperrors.WrapNotFound("clinic", ""))
+
+		_, err := svc.RenderAccountingReceipt(ctx, id.String())
+
+		assert.True(t, apperrors.IsConflict(err))
+	})
+}
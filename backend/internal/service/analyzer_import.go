@@ -0,0 +1,245 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/analyzer"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// AnalyzerImportService 検査装置の結果取り込みサービスインターフェース
+type AnalyzerImportService interface {
+	ImportAnalyzerMessage(ctx context.Context, source string, msg *analyzer.Message) (*model.AnalyzerImport, error)
+	ListAnalyzerImports(ctx context.Context, req *model.ListAnalyzerImportsRequest) (*model.ListResult[model.AnalyzerImport], error)
+	GetAnalyzerImportByID(ctx context.Context, id string) (*model.AnalyzerImport, error)
+	AssignAnalyzerImport(ctx context.Context, id string, req *model.AssignAnalyzerImportRequest) (*model.AnalyzerImport, error)
+	DiscardAnalyzerImport(ctx context.Context, id string, req *model.DiscardAnalyzerImportRequest) (*model.AnalyzerImport, error)
+}
+
+// Ensure Service implements AnalyzerImportService
+var _ AnalyzerImportService = (*Service)(nil)
+
+// ImportAnalyzerMessage 検査装置の結果を記録し、結果待ちの検査に照合できれば結果を記入して完了にする
+// 検体番号（検査の受付番号）を優先し、なければペット番号の結果待ちの検査が1件に限られる場合に照合する。
+// 照合できない結果は未照合として残し、AssignAnalyzerImportで手動で割り当てる。
+func (s *Service) ImportAnalyzerMessage(ctx context.Context, source string, msg *analyzer.Message) (*model.AnalyzerImport, error) {
+	record := &model.AnalyzerImport{
+		Format:      msg.Format,
+		Source:      source,
+		Machine:     msg.Machine,
+		AccessionNo: msg.AccessionNo,
+		PetNumber:   msg.PatientID,
+		ObservedAt:  msg.ObservedAt,
+		Results:     analyzerResults(msg.Results),
+		RawMessage:  msg.Raw,
+		Status:      model.AnalyzerImportStatusUnmatched,
+	}
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := validation.ValidateCompleteExamination(&model.CompleteExaminationRequest{Results: record.Results}); err != nil {
+			record.Reason = err.Error()
+			return s.analyzerImportRepo.CreateAnalyzerImport(ctx, record)
+		}
+		examination, reason, err := s.matchAnalyzerExamination(ctx, record)
+		if err != nil {
+			return err
+		}
+		if examination == nil {
+			record.Reason = reason
+		} else if err := s.applyAnalyzerResults(ctx, examination, record); err != nil {
+			return err
+		}
+		return s.analyzerImportRepo.CreateAnalyzerImport(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// matchAnalyzerExamination 結果を割り当てる検査を探す（見つからなければ理由を返す）
+func (s *Service) matchAnalyzerExamination(ctx context.Context, record *model.AnalyzerImport) (*model.Examination, string, error) {
+	reason := "message has no accession number or patient ID"
+	if accessionNo, err := strconv.Atoi(strings.TrimSpace(record.AccessionNo)); err == nil {
+		examination, err := s.examinationRepo.GetExaminationByAccessionNoForUpdate(ctx, accessionNo)
+		switch {
+		case apperrors.IsNotFound(err):
+			reason = fmt.Sprintf("no examination with accession number %d", accessionNo)
+		case err != nil:
+			return nil, "", err
+		case examination.Status == model.ExaminationStatusCompleted:
+			return nil, fmt.Sprintf("examination with accession number %d is already completed", accessionNo), nil
+		default:
+			if record.PetNumber != "" {
+				pet, err := s.repo.GetPetByID(ctx, examination.PetID)
+				if err != nil {
+					return nil, "", err
+				}
+				if !strings.EqualFold(strings.TrimSpace(pet.PetNumber), strings.TrimSpace(record.PetNumber)) {
+					return nil, fmt.Sprintf("patient ID %s does not match the pet of accession number %d", record.PetNumber, accessionNo), nil
+				}
+			}
+			return examination, "", nil
+		}
+	} else if record.AccessionNo != "" {
+		reason = fmt.Sprintf("accession number %s is not an examination accession number", record.AccessionNo)
+	}
+
+	// 検体番号で照合できない場合はペット番号で探す
+	if record.PetNumber == "" {
+		return nil, reason, nil
+	}
+	examinations, err := s.examinationRepo.FindPendingExaminationsByPetNumber(ctx, record.PetNumber)
+	if err != nil {
+		return nil, "", err
+	}
+	switch len(examinations) {
+	case 0:
+		return nil, fmt.Sprintf("no pending examination for pet number %s", record.PetNumber), nil
+	case 1:
+		return &examinations[0], "", nil
+	default:
+		return nil, fmt.Sprintf("%d pending examinations for pet number %s", len(examinations), record.PetNumber), nil
+	}
+}
+
+// applyAnalyzerResults 検査装置の結果を検査に記入して完了にし、結果を照合済にする
+func (s *Service) applyAnalyzerResults(ctx context.Context, examination *model.Examination, record *model.AnalyzerImport) error {
+	if examination.Machine == "" {
+		examination.Machine = record.Machine
+	}
+	if err := s.completeExamination(ctx, examination, record.Results); err != nil {
+		return err
+	}
+	if err := s.examinationRepo.UpdateExamination(ctx, examination); err != nil {
+		return err
+	}
+	record.Status = model.AnalyzerImportStatusMatched
+	record.ExaminationID = &examination.ID
+	record.Reason = ""
+	return nil
+}
+
+// analyzerResults 検査装置の結果を検査結果の入力に変換する
+func analyzerResults(results []analyzer.Result) model.AnalyzerResults {
+	inputs := make(model.AnalyzerResults, len(results))
+	for i, result := range results {
+		inputs[i] = model.ExaminationResultInput{
+			Analyte:   model.NormalizeAnalyte(result.Analyte),
+			Name:      result.Name,
+			Value:     result.Value,
+			TextValue: result.TextValue,
+			Unit:      result.Unit,
+		}
+	}
+	return inputs
+}
+
+// ListAnalyzerImports 条件に一致する検査装置の結果を1ページ分取得（既定は受信の新しい順）
+func (s *Service) ListAnalyzerImports(ctx context.Context, req *model.ListAnalyzerImportsRequest) (*model.ListResult[model.AnalyzerImport], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.AnalyzerImportFilter{Status: req.Status}
+	if filter.Status != "" {
+		if err := validation.ValidateAnalyzerImportStatus(filter.Status); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if filter.ReceivedAt, err = parseDateRange(req.DateFrom, req.DateTo, time.Local); err != nil {
+		return nil, err
+	}
+	return s.analyzerImportRepo.ListAnalyzerImports(ctx, filter, req.ListOptions)
+}
+
+// GetAnalyzerImportByID IDで検査装置の結果を取得
+func (s *Service) GetAnalyzerImportByID(ctx context.Context, id string) (*model.AnalyzerImport, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid analyzer import ID format")
+	}
+	return s.analyzerImportRepo.GetAnalyzerImportByID(ctx, uid)
+}
+
+// AssignAnalyzerImport 未照合の結果を指定の検査に割り当て、結果を記入して完了にする
+// 手動での割り当てのため、検体番号・ペット番号が検査と一致しなくても割り当てる。
+func (s *Service) AssignAnalyzerImport(ctx context.Context, id string, req *model.AssignAnalyzerImportRequest) (*model.AnalyzerImport, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid analyzer import ID format")
+	}
+	examinationID, err := uuid.Parse(req.ExaminationID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid examination ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.analyzerImportRepo.GetAnalyzerImportByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if record.Status != model.AnalyzerImportStatusUnmatched {
+			return apperrors.WrapConflict(fmt.Sprintf("analyzer import in status %s cannot be assigned", record.Status))
+		}
+		if err := validation.ValidateCompleteExamination(&model.CompleteExaminationRequest{Results: record.Results}); err != nil {
+			return err
+		}
+		examination, err := s.examinationRepo.GetExaminationByIDForUpdate(ctx, examinationID)
+		if err != nil {
+			return err
+		}
+		if examination.Status == model.ExaminationStatusCompleted {
+			return apperrors.WrapConflict("examination is already completed")
+		}
+		if err := s.applyAnalyzerResults(ctx, examination, record); err != nil {
+			return err
+		}
+		now := time.Now()
+		record.ResolvedBy = currentStaffID(ctx)
+		record.ResolvedAt = &now
+		return s.analyzerImportRepo.UpdateAnalyzerImport(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.analyzerImportRepo.GetAnalyzerImportByID(ctx, uid)
+}
+
+// DiscardAnalyzerImport 未照合の結果を破棄する（再検査・誤送信など）
+func (s *Service) DiscardAnalyzerImport(ctx context.Context, id string, req *model.DiscardAnalyzerImportRequest) (*model.AnalyzerImport, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid analyzer import ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.analyzerImportRepo.GetAnalyzerImportByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if record.Status != model.AnalyzerImportStatusUnmatched {
+			return apperrors.WrapConflict(fmt.Sprintf("analyzer import in status %s cannot be discarded", record.Status))
+		}
+		now := time.Now()
+		record.Status = model.AnalyzerImportStatusDiscarded
+		if req.Reason != "" {
+			record.Reason = req.Reason
+		}
+		record.ResolvedBy = currentStaffID(ctx)
+		record.ResolvedAt = &now
+		return s.analyzerImportRepo.UpdateAnalyzerImport(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.analyzerImportRepo.GetAnalyzerImportByID(ctx, uid)
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/analyzer"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockAnalyzerImportRepository is a mock implementation of AnalyzerImportRepository
+type MockAnalyzerImportRepository struct {
+	mock.Mock
+}
+
+func (m *MockAnalyzerImportRepository) ListAnalyzerImports(ctx context.Context, filter model.AnalyzerImportFilter, opts model.ListOptions) (*model.ListResult[model.AnalyzerImport], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.AnalyzerImport]), args.Error(1)
+}
+
+func (m *MockAnalyzerImportRepository) GetAnalyzerImportByID(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockAnalyzerImportRepository) GetAnalyzerImportByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.AnalyzerImport, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.AnalyzerImport), args.Error(1)
+}
+
+func (m *MockAnalyzerImportRepository) CreateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error {
+	args := m.Called(ctx, analyzerImport)
+	return args.Error(0)
+}
+
+func (m *MockAnalyzerImportRepository) UpdateAnalyzerImport(ctx context.Context, analyzerImport *model.AnalyzerImport) error {
+	args := m.Called(ctx, analyzerImport)
+	return args.Error(0)
+}
+
+func TestImportAnalyzerMessage(t *testing.T) {
+	ctx := context.Background()
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), PetNumber: "P-0012", Species: "犬"}
+	pending := func() *model.Examination {
+		return &model.Examination{ID: uuid.New(), PetID: pet.ID, AccessionNo: 100023, Status: model.ExaminationStatusOrdered,
+			Items: model.ExaminationItems{{Analyte: "ALT"}}}
+	}
+	message := func(accessionNo string) *analyzer.Message {
+		return &analyzer.Message{
+			Format: analyzer.FormatHL7, Machine: "VetChem", AccessionNo: accessionNo, PatientID: "p-0012",
+			Results: []analyzer.Result{{Analyte: "alt", Value: float(182), Unit: "U/L"}},
+		}
+	}
+
+	type mocks struct {
+		exams   *MockExaminationRepository
+		imports *MockAnalyzerImportRepository
+		pets    *MockPetRepository
+	}
+	newService := func() (*Service, mocks) {
+		m := mocks{new(MockExaminationRepository), new(MockAnalyzerImportRepository), new(MockPetRepository)}
+		svc := New(m.pets, nil, nil, nil,
+			WithExaminationRepository(m.exams), WithAnalyzerImportRepository(m.imports), WithTransactor(&fakeTransactor{}))
+		m.pets.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+		m.exams.On("FindLabReferenceRanges", ctx, []string{"ALT"}, model.SpeciesDog).Return([]model.LabReferenceRange{
+			{Analyte: "ALT", Unit: "U/L", Low: float(10), High: float(100)},
+		}, nil)
+		m.imports.On("CreateAnalyzerImport", ctx, mock.AnythingOfType("*model.AnalyzerImport")).Return(nil)
+		return svc, m
+	}
+
+	t.Run("completes the examination with the accession number", func(t *testing.T) {
+		svc, m := newService()
+		exam := pending()
+		m.exams.On("GetExaminationByAccessionNoForUpdate", ctx, 100023).Return(exam, nil)
+		m.exams.On("UpdateExamination", ctx, exam).Return(nil)
+
+		record, err := svc.ImportAnalyzerMessage(ctx, "tcp:10.0.0.5:4000", message("100023"))
+
+		require.NoError(t, err)
+		assert.Equal(t, model.AnalyzerImportStatusMatched, record.Status)
+		assert.Equal(t, &exam.ID, record.ExaminationID)
+		assert.Equal(t, model.ExaminationStatusCompleted, exam.Status)
+		assert.Equal(t, "VetChem", exam.Machine)
+		require.Len(t, exam.Items, 1)
+		assert.Equal(t, model.ExaminationFlagHigh, exam.Items[0].Flag)
+	})
+
+	t.Run("falls back to the only pending examination of the pet", func(t *testing.T) {
+		svc, m := newService()
+		exam := pending()
+		m.exams.On("FindPendingExaminationsByPetNumber", ctx, "p-0012").Return([]model.Examination{*exam}, nil)
+		m.exams.On("UpdateExamination", ctx, mock.AnythingOfType("*model.Examination")).Return(nil)
+
+		record, err := svc.ImportAnalyzerMessage(ctx, "file:a.hl7", message("S-77"))
+
+		require.NoError(t, err)
+		assert.Equal(t, model.AnalyzerImportStatusMatched, record.Status)
+		assert.Equal(t, &exam.ID, record.ExaminationID)
+	})
+
+	t.Run("queues results that cannot be matched", func(t *testing.T) {
+		svc, m := newService()
+		completed := pending()
+		completed.Status = model.ExaminationStatusCompleted
+		m.exams.On("GetExaminationByAccessionNoForUpdate", ctx, 100023).Return(completed, nil)
+		m.exams.On("GetExaminationByAccessionNoForUpdate", ctx, 100099).Return(nil, apperrors.WrapNotFound("examination", "100099"))
+		m.exams.On("FindPendingExaminationsByPetNumber", ctx, "p-0012").Return([]model.Examination{*pending(), *pending()}, nil)
+
+		record, err := svc.ImportAnalyzerMessage(ctx, "file:a.hl7", message("100023"))
+		require.NoError(t, err)
+		assert.Equal(t, model.AnalyzerImportStatusUnmatched, record.Status)
+		assert.Contains(t, record.Reason, "already completed")
+
+		record, err = svc.ImportAnalyzerMessage(ctx, "file:b.hl7", message("100099"))
+		require.NoError(t, err)
+		assert.Equal(t, model.AnalyzerImportStatusUnmatched, record.Status)
+		assert.Equal(t, "2 pending examinations for pet number p-0012", record.Reason)
+		assert.Nil(t, record.ExaminationID)
+		m.exams.AssertNotCalled(t, "UpdateExamination", mock.Anything, mock.Anything)
+	})
+
+	t.Run("queues results whose patient ID does not match the accession number", func(t *testing.T) {
+		svc, m := newService()
+		m.exams.On("GetExaminationByAccessionNoForUpdate", ctx, 100023).Return(pending(), nil)
+		msg := message("100023")
+		msg.PatientID = "P-9999"
+
+		record, err := svc.ImportAnalyzerMessage(ctx, "file:a.hl7", msg)
+
+		require.NoError(t, err)
+		assert.Equal(t, model.AnalyzerImportStatusUnmatched, record.Status)
+		assert.Contains(t, record.Reason, "does not match")
+	})
+}
+
+func TestAssignAnalyzerImport(t *testing.T) {
+	ctx := context.Background()
+	pet := &model.Pet{ID: uuid.New(), Species: "猫"}
+	exam := &model.Examination{ID: uuid.New(), PetID: pet.ID, Status: model.ExaminationStatusInProgress}
+	record := &model.AnalyzerImport{ID: uuid.New(), Status: model.AnalyzerImportStatusUnmatched, Reason: "no pending examination",
+		Results: model.AnalyzerResults{{Analyte: "WBC", Value: float(12.3)}}}
+
+	mockExams := new(MockExaminationRepository)
+	mockImports := new(MockAnalyzerImportRepository)
+	mockPets := new(MockPetRepository)
+	svc := New(mockPets, nil, nil, nil, WithExaminationRepository(mockExams), WithAnalyzerImportRepository(mockImports))
+
+	mockImports.On("GetAnalyzerImportByIDForUpdate", ctx, record.ID).Return(record, nil)
+	mockExams.On("GetExaminationByIDForUpdate", ctx, exam.ID).Return(exam, nil)
+	mockPets.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+	mockExams.On("FindLabReferenceRanges", ctx, []string{"WBC"}, model.SpeciesCat).Return([]model.LabReferenceRange{}, nil)
+	mockExams.On("UpdateExamination", ctx, exam).Return(nil)
+	mockImports.On("UpdateAnalyzerImport", ctx, record).Return(nil)
+	mockImports.On("GetAnalyzerImportByID", ctx, record.ID).Return(record, nil)
+
+	result, err := svc.AssignAnalyzerImport(ctx, record.ID.String(), &model.AssignAnalyzerImportRequest{ExaminationID: exam.ID.String()})
+
+	require.NoError(t, err)
+	assert.Equal(t, model.AnalyzerImportStatusMatched, result.Status)
+	assert.Empty(t, result.Reason)
+	assert.NotNil(t, result.ResolvedAt)
+	assert.Equal(t, model.ExaminationStatusCompleted, exam.Status)
+
+	// 照合済の結果は割り当て直せない
+	_, err = svc.AssignAnalyzerImport(ctx, record.ID.String(), &model.AssignAnalyzerImportRequest{ExaminationID: exam.ID.String()})
+	assert.True(t, apperrors.IsConflict(err))
+}
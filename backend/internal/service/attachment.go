@@ -0,0 +1,291 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/dicom"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/imaging"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/storage"
+)
+
+// defaultAttachmentMaxSize 添付ファイルの既定の上限（50MB）
+const defaultAttachmentMaxSize = 50 << 20
+
+// AttachmentService 添付ファイルサービスインターフェース
+type AttachmentService interface {
+	UploadMedicalRecordAttachment(ctx context.Context, medicalRecordID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error)
+	UploadExaminationAttachment(ctx context.Context, examinationID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error)
+	GetMedicalRecordAttachments(ctx context.Context, medicalRecordID string) ([]model.Attachment, error)
+	GetExaminationAttachments(ctx context.Context, examinationID string) ([]model.Attachment, error)
+	GetAttachmentByID(ctx context.Context, id string) (*model.Attachment, error)
+	OpenAttachment(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error)
+	OpenAttachmentThumbnail(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, id string) error
+}
+
+// Ensure Service implements AttachmentService
+var _ AttachmentService = (*Service)(nil)
+
+// errAttachmentStorageNotConfigured 添付ファイルの保存先が設定されていない
+var errAttachmentStorageNotConfigured = apperrors.WrapInternal(apperrors.ErrInternal, "attachment storage is not configured")
+
+// UploadMedicalRecordAttachment カルテにファイルを添付する
+// 画像は後日届くこともあるため、確定済カルテにも添付できる（削除はできない）。
+func (s *Service) UploadMedicalRecordAttachment(ctx context.Context, medicalRecordID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error) {
+	record, err := s.GetMedicalRecordByID(ctx, medicalRecordID)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &model.Attachment{PetID: record.PetID, MedicalRecordID: &record.ID}
+	if err := s.storeAttachment(ctx, attachment, body, req); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// UploadExaminationAttachment 検査にファイルを添付する（検査がカルテに紐付いていればカルテにも表示する）
+func (s *Service) UploadExaminationAttachment(ctx context.Context, examinationID string, body io.Reader, req *model.UploadAttachmentRequest) (*model.Attachment, error) {
+	examination, err := s.GetExaminationByID(ctx, examinationID)
+	if err != nil {
+		return nil, err
+	}
+	attachment := &model.Attachment{
+		PetID:           examination.PetID,
+		MedicalRecordID: examination.MedicalRecordID,
+		ExaminationID:   &examination.ID,
+	}
+	if err := s.storeAttachment(ctx, attachment, body, req); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// storeAttachment ファイルの種類と大きさを確かめ、DICOMの属性・サムネイルとともに保存して登録する
+func (s *Service) storeAttachment(ctx context.Context, attachment *model.Attachment, body io.Reader, req *model.UploadAttachmentRequest) error {
+	if s.attachments == nil {
+		return errAttachmentStorageNotConfigured
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, s.attachmentMaxSize+1))
+	if err != nil {
+		return apperrors.WrapInvalidInput("failed to read upload file")
+	}
+	if len(data) == 0 {
+		return apperrors.WrapInvalidInput("upload file is empty")
+	}
+	if int64(len(data)) > s.attachmentMaxSize {
+		return apperrors.WrapInvalidInput(fmt.Sprintf("upload file exceeds the maximum size of %d bytes", s.attachmentMaxSize))
+	}
+
+	attachment.ID = uuid.New()
+	attachment.FileName = attachmentFileName(req.FileName)
+	attachment.ContentType = detectAttachmentContentType(data)
+	attachment.Size = int64(len(data))
+	sum := sha256.Sum256(data)
+	attachment.Checksum = hex.EncodeToString(sum[:])
+	attachment.Description = req.Description
+	attachment.UploadedBy = currentStaffID(ctx)
+	attachment.StorageKey = fmt.Sprintf("attachments/%s/%s/original", attachment.PetID, attachment.ID)
+
+	var thumbnail []byte
+	switch attachment.ContentType {
+	case model.AttachmentContentTypeDICOM:
+		meta, err := dicom.Parse(data)
+		if err != nil {
+			return apperrors.WrapInvalidInput("invalid DICOM file: " + err.Error())
+		}
+		applyDicomMetadata(attachment, meta)
+	case model.AttachmentContentTypeJPEG, model.AttachmentContentTypePNG:
+		if thumbnail, err = imaging.Thumbnail(data); err != nil {
+			return apperrors.WrapInvalidInput("invalid image file: " + err.Error())
+		}
+		attachment.HasThumbnail = true
+	case model.AttachmentContentTypePDF:
+		// 属性の読み取り・サムネイルの作成はしない
+	default:
+		return apperrors.WrapInvalidInput(fmt.Sprintf("unsupported file type %s; upload JPEG, PNG, DICOM or PDF", attachment.ContentType))
+	}
+
+	if err := s.attachments.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, attachment.ContentType); err != nil {
+		return apperrors.Wrap(err, "failed to store attachment")
+	}
+	if thumbnail != nil {
+		if err := s.attachments.Put(ctx, thumbnailKey(attachment), bytes.NewReader(thumbnail), int64(len(thumbnail)), imaging.ThumbnailContentType); err != nil {
+			s.removeAttachmentFiles(ctx, attachment)
+			return apperrors.Wrap(err, "failed to store attachment thumbnail")
+		}
+	}
+	if err := s.attachmentRepo.CreateAttachment(ctx, attachment); err != nil {
+		s.removeAttachmentFiles(ctx, attachment)
+		return err
+	}
+	return nil
+}
+
+// detectAttachmentContentType ファイルの内容からContent-Typeを判定する（DICOMはプリアンブル後の"DICM"で判定）
+func detectAttachmentContentType(data []byte) string {
+	if dicom.IsDICOM(data) {
+		return model.AttachmentContentTypeDICOM
+	}
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	return contentType
+}
+
+// applyDicomMetadata DICOMの属性を添付ファイルに写す
+func applyDicomMetadata(attachment *model.Attachment, meta *dicom.Metadata) {
+	attachment.Modality = truncateRunes(meta.Modality, 16)
+	attachment.DicomPatientID = truncateRunes(meta.PatientID, 64)
+	attachment.DicomPatientName = truncateRunes(meta.PatientName, 255)
+	attachment.StudyDescription = truncateRunes(meta.StudyDescription, 255)
+	attachment.BodyPart = truncateRunes(meta.BodyPartExamined, 64)
+	attachment.StudyInstanceUID = truncateRunes(meta.StudyInstanceUID, 64)
+	if studyDate, err := time.ParseInLocation("20060102", meta.StudyDate, time.Local); err == nil {
+		attachment.StudyDate = &studyDate
+	}
+}
+
+// attachmentFileName アップロードされたファイル名からディレクトリを除き、列の長さに収める
+func attachmentFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+	return truncateRunes(name, 255)
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// thumbnailKey サムネイルの保存先のキー（元のファイルと同じ階層）
+func thumbnailKey(attachment *model.Attachment) string {
+	return path.Join(path.Dir(attachment.StorageKey), "thumbnail.jpg")
+}
+
+// removeAttachmentFiles 添付ファイルの本体とサムネイルを保存先から削除する（失敗しても記録のみ）
+func (s *Service) removeAttachmentFiles(ctx context.Context, attachment *model.Attachment) {
+	keys := []string{attachment.StorageKey}
+	if attachment.HasThumbnail {
+		keys = append(keys, thumbnailKey(attachment))
+	}
+	for _, key := range keys {
+		if err := s.attachments.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "failed to remove attachment file",
+				slog.String("attachment_id", attachment.ID.String()),
+				slog.String("key", key),
+				slog.String("error", err.Error()),
+			)
+		}
+	}
+}
+
+// GetMedicalRecordAttachments カルテの添付ファイルを登録順に取得（カルテに紐付く検査の添付を含む）
+func (s *Service) GetMedicalRecordAttachments(ctx context.Context, medicalRecordID string) ([]model.Attachment, error) {
+	record, err := s.GetMedicalRecordByID(ctx, medicalRecordID)
+	if err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetAttachmentsByMedicalRecordID(ctx, record.ID)
+}
+
+// GetExaminationAttachments 検査の添付ファイルを登録順に取得
+func (s *Service) GetExaminationAttachments(ctx context.Context, examinationID string) ([]model.Attachment, error) {
+	examination, err := s.GetExaminationByID(ctx, examinationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.attachmentRepo.GetAttachmentsByExaminationID(ctx, examination.ID)
+}
+
+// GetAttachmentByID IDで添付ファイルを取得
+func (s *Service) GetAttachmentByID(ctx context.Context, id string) (*model.Attachment, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid attachment ID format")
+	}
+	return s.attachmentRepo.GetAttachmentByID(ctx, uid)
+}
+
+// OpenAttachment 添付ファイルの本体を開く（呼び出し側で閉じること）
+func (s *Service) OpenAttachment(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.openAttachmentFile(ctx, attachment, attachment.StorageKey)
+}
+
+// OpenAttachmentThumbnail 添付画像のサムネイル（JPEG）を開く（呼び出し側で閉じること）
+func (s *Service) OpenAttachmentThumbnail(ctx context.Context, id string) (*model.Attachment, io.ReadCloser, error) {
+	attachment, err := s.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !attachment.HasThumbnail {
+		return nil, nil, apperrors.WrapNotFound("attachment thumbnail", id)
+	}
+	return s.openAttachmentFile(ctx, attachment, thumbnailKey(attachment))
+}
+
+func (s *Service) openAttachmentFile(ctx context.Context, attachment *model.Attachment, key string) (*model.Attachment, io.ReadCloser, error) {
+	if s.attachments == nil {
+		return nil, nil, errAttachmentStorageNotConfigured
+	}
+	r, err := s.attachments.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, apperrors.WrapNotFound("attachment file", attachment.ID.String())
+	}
+	if err != nil {
+		return nil, nil, apperrors.Wrap(err, "failed to open attachment")
+	}
+	return attachment, r, nil
+}
+
+// DeleteAttachment 添付ファイルを削除する
+// 確定済カルテ（検査経由を含む）の添付は法定保存対象のため削除できない。
+func (s *Service) DeleteAttachment(ctx context.Context, id string) error {
+	attachment, err := s.GetAttachmentByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if attachment.MedicalRecordID != nil {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByID(ctx, attachment.MedicalRecordID.String())
+		if err != nil && !apperrors.IsNotFound(err) {
+			return err
+		}
+		if record != nil && record.Status == model.MedicalRecordStatusFinalized {
+			return apperrors.WrapConflict("attachments of a finalized medical record cannot be deleted")
+		}
+	}
+
+	if err := s.attachmentRepo.DeleteAttachment(ctx, attachment.ID); err != nil {
+		return err
+	}
+	if s.attachments != nil {
+		s.removeAttachmentFiles(ctx, attachment)
+	}
+	return nil
+}
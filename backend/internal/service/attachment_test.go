@@ -0,0 +1,194 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/storage"
+)
+
+// MockAttachmentRepository is a mock implementation of AttachmentRepository
+type MockAttachmentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByMedicalRecordID(ctx context.Context, medicalRecordID uuid.UUID) ([]model.Attachment, error) {
+	args := m.Called(ctx, medicalRecordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentsByExaminationID(ctx context.Context, examinationID uuid.UUID) ([]model.Attachment, error) {
+	args := m.Called(ctx, examinationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) GetAttachmentByID(ctx context.Context, id uuid.UUID) (*model.Attachment, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Attachment), args.Error(1)
+}
+
+func (m *MockAttachmentRepository) CreateAttachment(ctx context.Context, attachment *model.Attachment) error {
+	args := m.Called(ctx, attachment)
+	return args.Error(0)
+}
+
+func (m *MockAttachmentRepository) DeleteAttachment(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// dicomFile モダリティ・検査日・患者IDだけを持つDICOM Part 10ファイル（明示的VRリトルエンディアン）
+func dicomFile() []byte {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, 128))
+	buf.WriteString("DICM")
+	element := func(group, elem uint16, vr, value string) {
+		_ = binary.Write(&buf, binary.LittleEndian, []uint16{group, elem})
+		buf.WriteString(vr)
+		_ = binary.Write(&buf, binary.LittleEndian, uint16(len(value)))
+		buf.WriteString(value)
+	}
+	element(0x0002, 0x0010, "UI", "1.2.840.10008.1.2.1\x00")
+	element(0x0008, 0x0020, "DA", "20261015")
+	element(0x0008, 0x0060, "CS", "US")
+	element(0x0010, 0x0020, "LO", "P-0012")
+	return buf.Bytes()
+}
+
+func readStored(t *testing.T, store storage.Store, key string) []byte {
+	t.Helper()
+	r, err := store.Get(context.Background(), key)
+	require.NoError(t, err)
+	defer r.Close()
+	b, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return b
+}
+
+func TestUploadAttachment(t *testing.T) {
+	ctx := context.Background()
+	record := &model.MedicalRecord{ID: uuid.New(), PetID: uuid.New(), Status: model.MedicalRecordStatusFinalized}
+
+	newService := func(t *testing.T, maxSize int64) (*Service, storage.Store, *MockAttachmentRepository, *MockMedicalRecordRepository, *MockExaminationRepository) {
+		store := storage.NewLocalStore(t.TempDir())
+		attachments := new(MockAttachmentRepository)
+		records := new(MockMedicalRecordRepository)
+		exams := new(MockExaminationRepository)
+		svc := New(nil, nil, records, nil, WithAttachmentRepository(attachments), WithExaminationRepository(exams),
+			WithAttachmentStorage(store, maxSize))
+		records.On("GetMedicalRecordByID", ctx, record.ID.String()).Return(record, nil)
+		attachments.On("CreateAttachment", ctx, mock.AnythingOfType("*model.Attachment")).Return(nil)
+		return svc, store, attachments, records, exams
+	}
+
+	t.Run("stores an image with its thumbnail on a finalized medical record", func(t *testing.T) {
+		svc, store, _, _, _ := newService(t, 0)
+		var img bytes.Buffer
+		require.NoError(t, png.Encode(&img, image.NewGray(image.Rect(0, 0, 600, 300))))
+
+		attachment, err := svc.UploadMedicalRecordAttachment(ctx, record.ID.String(), bytes.NewReader(img.Bytes()),
+			&model.UploadAttachmentRequest{FileName: `C:\xray\chest.png`, Description: "胸部"})
+
+		require.NoError(t, err)
+		assert.Equal(t, record.PetID, attachment.PetID)
+		assert.Equal(t, &record.ID, attachment.MedicalRecordID)
+		assert.Equal(t, "chest.png", attachment.FileName)
+		assert.Equal(t, model.AttachmentContentTypePNG, attachment.ContentType)
+		assert.Equal(t, int64(img.Len()), attachment.Size)
+		assert.Len(t, attachment.Checksum, 64)
+		assert.True(t, attachment.HasThumbnail)
+		assert.Equal(t, img.Bytes(), readStored(t, store, attachment.StorageKey))
+
+		thumb, format, err := image.DecodeConfig(bytes.NewReader(readStored(t, store, thumbnailKey(attachment))))
+		require.NoError(t, err)
+		assert.Equal(t, "jpeg", format)
+		assert.Equal(t, 256, thumb.Width)
+	})
+
+	t.Run("reads DICOM metadata for an examination", func(t *testing.T) {
+		svc, _, _, _, exams := newService(t, 0)
+		exam := &model.Examination{ID: uuid.New(), PetID: record.PetID, MedicalRecordID: &record.ID}
+		exams.On("GetExaminationByID", ctx, exam.ID).Return(exam, nil)
+
+		attachment, err := svc.UploadExaminationAttachment(ctx, exam.ID.String(), bytes.NewReader(dicomFile()),
+			&model.UploadAttachmentRequest{FileName: "abdomen.dcm"})
+
+		require.NoError(t, err)
+		assert.Equal(t, model.AttachmentContentTypeDICOM, attachment.ContentType)
+		assert.Equal(t, &exam.ID, attachment.ExaminationID)
+		assert.Equal(t, &record.ID, attachment.MedicalRecordID)
+		assert.Equal(t, "US", attachment.Modality)
+		assert.Equal(t, "P-0012", attachment.DicomPatientID)
+		require.NotNil(t, attachment.StudyDate)
+		assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local), *attachment.StudyDate)
+		assert.False(t, attachment.HasThumbnail)
+	})
+
+	t.Run("rejects unsupported and oversized files", func(t *testing.T) {
+		svc, _, attachments, _, _ := newService(t, 16)
+
+		_, err := svc.UploadMedicalRecordAttachment(ctx, record.ID.String(), strings.NewReader("plain text"),
+			&model.UploadAttachmentRequest{FileName: "memo.txt"})
+		assert.True(t, apperrors.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "unsupported file type text/plain")
+
+		_, err = svc.UploadMedicalRecordAttachment(ctx, record.ID.String(), strings.NewReader(strings.Repeat("x", 17)),
+			&model.UploadAttachmentRequest{FileName: "big.png"})
+		assert.True(t, apperrors.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "maximum size of 16 bytes")
+
+		attachments.AssertNotCalled(t, "CreateAttachment", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteAttachment(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocalStore(t.TempDir())
+	attachments := new(MockAttachmentRepository)
+	records := new(MockMedicalRecordRepository)
+	svc := New(nil, nil, records, nil, WithAttachmentRepository(attachments), WithAttachmentStorage(store, 0))
+
+	draft := &model.MedicalRecord{ID: uuid.New(), Status: model.MedicalRecordStatusDraft}
+	finalized := &model.MedicalRecord{ID: uuid.New(), Status: model.MedicalRecordStatusFinalized}
+	records.On("GetMedicalRecordByID", ctx, draft.ID.String()).Return(draft, nil)
+	records.On("GetMedicalRecordByID", ctx, finalized.ID.String()).Return(finalized, nil)
+
+	removable := &model.Attachment{ID: uuid.New(), MedicalRecordID: &draft.ID, StorageKey: "attachments/p/a/original"}
+	require.NoError(t, store.Put(ctx, removable.StorageKey, strings.NewReader("x"), 1, ""))
+	attachments.On("GetAttachmentByID", ctx, removable.ID).Return(removable, nil)
+	attachments.On("DeleteAttachment", ctx, removable.ID).Return(nil)
+
+	require.NoError(t, svc.DeleteAttachment(ctx, removable.ID.String()))
+	_, err := store.Get(ctx, removable.StorageKey)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+
+	retained := &model.Attachment{ID: uuid.New(), MedicalRecordID: &finalized.ID, StorageKey: "attachments/p/b/original"}
+	attachments.On("GetAttachmentByID", ctx, retained.ID).Return(retained, nil)
+
+	err = svc.DeleteAttachment(ctx, retained.ID.String())
+	assert.True(t, apperrors.IsConflict(err))
+	attachments.AssertNotCalled(t, "DeleteAttachment", ctx, retained.ID)
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// maxAuditEventLimit 監査ログ検索で一度に取得できる最大件数
+const maxAuditEventLimit = 1000
+
+// AuditService 監査ログサービスインターフェース
+type AuditService interface {
+	ListAuditEvents(ctx context.Context, req *model.ListAuditEventsRequest) ([]model.AuditEvent, error)
+}
+
+// Ensure Service implements AuditService
+var _ AuditService = (*Service)(nil)
+
+// ListAuditEvents 監査ログを検索
+// from/toはRFC3339または日付(YYYY-MM-DD)で指定する。日付のみのtoはその日の終わりまでを含む。
+func (s *Service) ListAuditEvents(ctx context.Context, req *model.ListAuditEventsRequest) ([]model.AuditEvent, error) {
+	if req.EntityID != "" && req.Entity == "" {
+		return nil, apperrors.WrapInvalidInput("entity is required when entity_id is specified")
+	}
+	if req.Limit < 0 || req.Limit > maxAuditEventLimit {
+		return nil, apperrors.WrapInvalidInput("limit must be between 1 and 1000")
+	}
+
+	filter := model.AuditEventFilter{
+		EntityType: req.Entity,
+		EntityID:   req.EntityID,
+		Limit:      req.Limit,
+	}
+
+	if req.From != "" {
+		from, err := parseVisitDate(req.From)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid from format")
+		}
+		filter.From = &from
+	}
+
+	if req.To != "" {
+		to, err := parseVisitDate(req.To)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid to format")
+		}
+		if len(req.To) == len("2006-01-02") {
+			to = to.AddDate(0, 0, 1)
+		}
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, apperrors.WrapInvalidInput("from must be before to")
+	}
+
+	return s.auditEventRepo.ListAuditEvents(ctx, filter)
+}
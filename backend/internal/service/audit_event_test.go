@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+type MockAuditEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuditEventRepository) ListAuditEvents(ctx context.Context, filter model.AuditEventFilter) ([]model.AuditEvent, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.AuditEvent), args.Error(1)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("date-only to includes the whole day", func(t *testing.T) {
+		mockRepo := new(MockAuditEventRepository)
+		svc := New(nil, nil, nil, nil, WithAuditEventRepository(mockRepo))
+
+		mockRepo.On("ListAuditEvents", ctx, mock.MatchedBy(func(f model.AuditEventFilter) bool {
+			return f.EntityType == "pets" &&
+				f.From.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) &&
+				f.To.Equal(time.Date(2026, 2, 3, 0, 0, 0, 0, time.UTC))
+		})).Return([]model.AuditEvent{}, nil)
+
+		_, err := svc.ListAuditEvents(ctx, &model.ListAuditEventsRequest{
+			Entity: "pets",
+			From:   "2026-02-01",
+			To:     "2026-02-02",
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects entity_id without entity", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithAuditEventRepository(new(MockAuditEventRepository)))
+
+		_, err := svc.ListAuditEvents(ctx, &model.ListAuditEventsRequest{EntityID: "abc"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+
+	t.Run("rejects invalid from", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithAuditEventRepository(new(MockAuditEventRepository)))
+
+		_, err := svc.ListAuditEvents(ctx, &model.ListAuditEventsRequest{From: "yesterday"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
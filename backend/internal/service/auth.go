@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/auth"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// AuthService 認証サービスインターフェース
+type AuthService interface {
+	Login(ctx context.Context, req *model.LoginRequest) (*model.TokenResponse, error)
+	RefreshToken(ctx context.Context, req *model.RefreshTokenRequest) (*model.TokenResponse, error)
+	Logout(ctx context.Context, req *model.RefreshTokenRequest) error
+	AuthenticateToken(ctx context.Context, token string) (*auth.Claims, error)
+	SetStaffPassword(ctx context.Context, staffID string, req *model.SetPasswordRequest) error
+}
+
+// Ensure Service implements AuthService
+var _ AuthService = (*Service)(nil)
+
+// errInvalidCredentials ログイン失敗時のエラー（存在しないメールアドレスかどうかを区別しない）
+var errInvalidCredentials = apperrors.WrapUnauthorized("invalid email or password")
+
+// Login メールアドレスとパスワードでスタッフを認証し、アクセストークンとリフレッシュトークンを発行する
+func (s *Service) Login(ctx context.Context, req *model.LoginRequest) (*model.TokenResponse, error) {
+	if err := validation.ValidateLogin(req); err != nil {
+		return nil, err
+	}
+
+	staff, err := s.authRepo.GetStaffByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		if apperrors.IsNotFound(err) {
+			return nil, errInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if !staff.IsActive || staff.PasswordHash == "" {
+		return nil, errInvalidCredentials
+	}
+
+	if err := auth.CheckPassword(staff.PasswordHash, req.Password); err != nil {
+		if errors.Is(err, auth.ErrPasswordMismatch) {
+			return nil, errInvalidCredentials
+		}
+		return nil, apperrors.WrapInternal(err, "failed to verify password")
+	}
+
+	return s.issueTokens(ctx, staff)
+}
+
+// RefreshToken リフレッシュトークンを検証し、新しいトークンの組を発行する
+// 使用したリフレッシュトークンは失効させる（ローテーション）。
+func (s *Service) RefreshToken(ctx context.Context, req *model.RefreshTokenRequest) (*model.TokenResponse, error) {
+	var resp *model.TokenResponse
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.authRepo.GetRefreshTokenByHashForUpdate(ctx, auth.HashRefreshToken(req.RefreshToken))
+		if err != nil {
+			if apperrors.IsNotFound(err) {
+				return apperrors.WrapUnauthorized("invalid refresh token")
+			}
+			return err
+		}
+
+		now := time.Now()
+		if token.RevokedAt != nil || !now.Before(token.ExpiresAt) {
+			return apperrors.WrapUnauthorized("refresh token is expired or revoked")
+		}
+
+		staff, err := s.authRepo.GetStaffByID(ctx, token.StaffID)
+		if err != nil {
+			if apperrors.IsNotFound(err) {
+				return apperrors.WrapUnauthorized("invalid refresh token")
+			}
+			return err
+		}
+		if !staff.IsActive {
+			return apperrors.WrapUnauthorized("staff is inactive")
+		}
+
+		token.RevokedAt = &now
+		if err := s.authRepo.UpdateRefreshToken(ctx, token); err != nil {
+			return err
+		}
+
+		resp, err = s.issueTokens(ctx, staff)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// Logout リフレッシュトークンを失効させる
+// 既に失効済み・存在しないトークンでもエラーにしない。
+func (s *Service) Logout(ctx context.Context, req *model.RefreshTokenRequest) error {
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		token, err := s.authRepo.GetRefreshTokenByHashForUpdate(ctx, auth.HashRefreshToken(req.RefreshToken))
+		if err != nil {
+			if apperrors.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+		if token.RevokedAt != nil {
+			return nil
+		}
+
+		now := time.Now()
+		token.RevokedAt = &now
+		return s.authRepo.UpdateRefreshToken(ctx, token)
+	})
+}
+
+// AuthenticateToken アクセストークンを検証してクレームを返す
+func (s *Service) AuthenticateToken(ctx context.Context, token string) (*auth.Claims, error) {
+	claims, err := s.tokens.Parse(token)
+	if err != nil {
+		if errors.Is(err, auth.ErrExpiredToken) {
+			return nil, apperrors.WrapUnauthorized("access token expired")
+		}
+		return nil, apperrors.WrapUnauthorized("invalid access token")
+	}
+	return claims, nil
+}
+
+// SetStaffPassword スタッフのパスワードを設定する
+// 既存のリフレッシュトークンは全て失効させる。
+func (s *Service) SetStaffPassword(ctx context.Context, staffID string, req *model.SetPasswordRequest) error {
+	if err := validation.ValidatePassword(req.Password); err != nil {
+		return err
+	}
+
+	uid, err := uuid.Parse(staffID)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid staff ID format")
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return apperrors.WrapInternal(err, "failed to hash password")
+	}
+
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		staff, err := s.authRepo.GetStaffByID(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		staff.PasswordHash = hash
+		if err := s.authRepo.UpdateStaff(ctx, staff); err != nil {
+			return err
+		}
+		return s.authRepo.RevokeRefreshTokensByStaffID(ctx, uid, time.Now())
+	})
+}
+
+// EnsureAdminStaff 指定したメールアドレスの管理者スタッフがいなければ作成する
+// 初回起動時に最初のログインユーザーを用意するために使う。
+func (s *Service) EnsureAdminStaff(ctx context.Context, name, email, password string) error {
+	if err := validation.ValidatePassword(password); err != nil {
+		return err
+	}
+
+	_, err := s.authRepo.GetStaffByEmail(ctx, email)
+	if err == nil {
+		return nil
+	}
+	if !apperrors.IsNotFound(err) {
+		return err
+	}
+
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return apperrors.WrapInternal(err, "failed to hash password")
+	}
+
+	return s.authRepo.CreateStaff(ctx, &model.Staff{
+		Name:         name,
+		Role:         model.StaffRoleAdmin,
+		Email:        email,
+		PasswordHash: hash,
+		IsActive:     true,
+	})
+}
+
+// issueTokens アクセストークンと新しいリフレッシュトークンを発行する
+func (s *Service) issueTokens(ctx context.Context, staff *model.Staff) (*model.TokenResponse, error) {
+	accessToken, err := s.tokens.Issue(staff.ID.String(), staff.Name, staff.Role)
+	if err != nil {
+		return nil, apperrors.WrapInternal(err, "failed to issue access token")
+	}
+
+	refreshToken, err := auth.NewRefreshToken()
+	if err != nil {
+		return nil, apperrors.WrapInternal(err, "failed to issue refresh token")
+	}
+
+	if err := s.authRepo.CreateRefreshToken(ctx, &model.RefreshToken{
+		StaffID:   staff.ID,
+		TokenHash: auth.HashRefreshToken(refreshToken),
+		ExpiresAt: time.Now().Add(s.tokens.RefreshTTL()),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &model.TokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(s.tokens.TTL().Seconds()),
+		Staff:        staff,
+	}, nil
+}
+
+// authorizeRole contextの認証済みスタッフが指定ロールのいずれかを持つことを確認する
+// 認証情報のないcontext（バッチ処理など内部呼び出し）は対象外とする。
+func authorizeRole(ctx context.Context, roles ...string) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok || claims.HasRole(roles...) {
+		return nil
+	}
+	return apperrors.WrapForbidden(fmt.Sprintf("role %s is not allowed to perform this action", claims.Role))
+}
+
+// currentStaffID contextの認証済みスタッフのIDを取得する（認証情報がない場合はnil）
+func currentStaffID(ctx context.Context) *uuid.UUID {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	id, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
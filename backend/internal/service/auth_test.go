@@ -0,0 +1,170 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/animal-ekarte/backend/internal/auth"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+type MockAuthRepository struct {
+	mock.Mock
+}
+
+func (m *MockAuthRepository) GetStaffByID(ctx context.Context, id uuid.UUID) (*model.Staff, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Staff), args.Error(1)
+}
+
+func (m *MockAuthRepository) GetStaffByEmail(ctx context.Context, email string) (*model.Staff, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Staff), args.Error(1)
+}
+
+func (m *MockAuthRepository) CreateStaff(ctx context.Context, staff *model.Staff) error {
+	args := m.Called(ctx, staff)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) UpdateStaff(ctx context.Context, staff *model.Staff) error {
+	args := m.Called(ctx, staff)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) CreateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) GetRefreshTokenByHashForUpdate(ctx context.Context, tokenHash string) (*model.RefreshToken, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.RefreshToken), args.Error(1)
+}
+
+func (m *MockAuthRepository) UpdateRefreshToken(ctx context.Context, token *model.RefreshToken) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthRepository) RevokeRefreshTokensByStaffID(ctx context.Context, staffID uuid.UUID, revokedAt time.Time) error {
+	args := m.Called(ctx, staffID, revokedAt)
+	return args.Error(0)
+}
+
+func newAuthService(repo *MockAuthRepository) *Service {
+	return New(nil, nil, nil, nil,
+		WithAuthRepository(repo),
+		WithTokenManager(auth.NewTokenManager("test-secret", 15*time.Minute, time.Hour)),
+	)
+}
+
+func TestLogin(t *testing.T) {
+	ctx := context.Background()
+	hash, _ := auth.HashPassword("password123")
+	staff := &model.Staff{ID: uuid.New(), Name: "山田", Role: model.StaffRoleVeterinarian, Email: "vet@example.com", PasswordHash: hash, IsActive: true}
+
+	t.Run("issues tokens for valid credentials", func(t *testing.T) {
+		repo := new(MockAuthRepository)
+		svc := newAuthService(repo)
+
+		repo.On("GetStaffByEmail", ctx, "vet@example.com").Return(staff, nil)
+		repo.On("CreateRefreshToken", ctx, mock.MatchedBy(func(tok *model.RefreshToken) bool {
+			return tok.StaffID == staff.ID && tok.TokenHash != ""
+		})).Return(nil)
+
+		resp, err := svc.Login(ctx, &model.LoginRequest{Email: "vet@example.com", Password: "password123"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer", resp.TokenType)
+		claims, err := svc.AuthenticateToken(ctx, resp.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, staff.ID.String(), claims.Subject)
+		assert.Equal(t, model.StaffRoleVeterinarian, claims.Role)
+	})
+
+	t.Run("rejects wrong password", func(t *testing.T) {
+		repo := new(MockAuthRepository)
+		svc := newAuthService(repo)
+
+		repo.On("GetStaffByEmail", ctx, "vet@example.com").Return(staff, nil)
+
+		_, err := svc.Login(ctx, &model.LoginRequest{Email: "vet@example.com", Password: "wrong-password"})
+
+		assert.True(t, apperrors.IsUnauthorized(err))
+		repo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects unknown email", func(t *testing.T) {
+		repo := new(MockAuthRepository)
+		svc := newAuthService(repo)
+
+		repo.On("GetStaffByEmail", ctx, "nobody@example.com").Return(nil, apperrors.WrapNotFound("staff", "nobody@example.com"))
+
+		_, err := svc.Login(ctx, &model.LoginRequest{Email: "nobody@example.com", Password: "password123"})
+
+		assert.True(t, apperrors.IsUnauthorized(err))
+	})
+}
+
+func TestRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	staff := &model.Staff{ID: uuid.New(), Role: model.StaffRoleNurse, IsActive: true}
+
+	t.Run("rotates refresh token", func(t *testing.T) {
+		repo := new(MockAuthRepository)
+		svc := newAuthService(repo)
+
+		stored := &model.RefreshToken{ID: uuid.New(), StaffID: staff.ID, ExpiresAt: time.Now().Add(time.Hour)}
+		repo.On("GetRefreshTokenByHashForUpdate", ctx, auth.HashRefreshToken("old-token")).Return(stored, nil)
+		repo.On("GetStaffByID", ctx, staff.ID).Return(staff, nil)
+		repo.On("UpdateRefreshToken", ctx, stored).Return(nil)
+		repo.On("CreateRefreshToken", ctx, mock.AnythingOfType("*model.RefreshToken")).Return(nil)
+
+		resp, err := svc.RefreshToken(ctx, &model.RefreshTokenRequest{RefreshToken: "old-token"})
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, "old-token", resp.RefreshToken)
+		assert.NotNil(t, stored.RevokedAt)
+	})
+
+	t.Run("rejects revoked refresh token", func(t *testing.T) {
+		repo := new(MockAuthRepository)
+		svc := newAuthService(repo)
+
+		revokedAt := time.Now().Add(-time.Minute)
+		repo.On("GetRefreshTokenByHashForUpdate", ctx, auth.HashRefreshToken("old-token")).
+			Return(&model.RefreshToken{StaffID: staff.ID, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}, nil)
+
+		_, err := svc.RefreshToken(ctx, &model.RefreshTokenRequest{RefreshToken: "old-token"})
+
+		assert.True(t, apperrors.IsUnauthorized(err))
+		repo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestFinalizeMedicalRecord_RequiresVeterinarian(t *testing.T) {
+	ctx := auth.WithClaims(context.Background(), &auth.Claims{Subject: uuid.New().String(), Role: model.StaffRoleNurse})
+	mockRecordRepo := new(MockMedicalRecordRepository)
+	svc := New(nil, nil, mockRecordRepo, nil)
+
+	_, err := svc.FinalizeMedicalRecord(ctx, uuid.New().String())
+
+	assert.True(t, apperrors.IsForbidden(err))
+	mockRecordRepo.AssertNotCalled(t, "GetMedicalRecordByID", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/careplan"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// DailyRecordService 入院日次記録・温度板サービスインターフェース
+type DailyRecordService interface {
+	GetDailyRecords(ctx context.Context, hospitalizationID string) ([]model.DailyRecord, error)
+	AddVital(ctx context.Context, hospitalizationID string, req *model.AddVitalRequest) (*model.Vital, error)
+	AddCareLog(ctx context.Context, hospitalizationID string, req *model.AddCareLogRequest) (*model.CareLog, error)
+	AddStaffNote(ctx context.Context, hospitalizationID string, req *model.AddStaffNoteRequest) (*model.StaffNote, error)
+	GetTemperatureChart(ctx context.Context, req *model.TemperatureChartRequest) (*model.TemperatureChart, error)
+}
+
+// Ensure Service implements DailyRecordService
+var _ DailyRecordService = (*Service)(nil)
+
+// GetDailyRecords 入院の日次記録をバイタル・ケアログ・スタッフメモ付きで日付順に取得
+func (s *Service) GetDailyRecords(ctx context.Context, hospitalizationID string) ([]model.DailyRecord, error) {
+	hospitalization, err := s.GetHospitalizationByID(ctx, hospitalizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.dailyRecordRepo.GetDailyRecords(ctx, hospitalization.ID)
+}
+
+// AddVital バイタルを記録する（その日の日次記録がなければ作成する）
+// 体重・体温・心拍数・呼吸数はペットの測定値の推移にも記録し、最新の体重であればペットの体重も更新する。
+func (s *Service) AddVital(ctx context.Context, hospitalizationID string, req *model.AddVitalRequest) (*model.Vital, error) {
+	if err := validation.ValidateAddVital(req); err != nil {
+		return nil, err
+	}
+
+	hospitalization, err := s.GetHospitalizationByID(ctx, hospitalizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	vital := &model.Vital{
+		StaffID:         currentStaffID(ctx),
+		RecordedTime:    recordedTimeOrNow(req.RecordedTime),
+		Temperature:     req.Temperature,
+		HeartRate:       req.HeartRate,
+		RespirationRate: req.RespirationRate,
+		Weight:          req.Weight,
+		Notes:           req.Notes,
+	}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.dailyRecordFor(ctx, hospitalization, req.RecordDate)
+		if err != nil {
+			return err
+		}
+		vital.DailyRecordID = record.ID
+		if err := s.dailyRecordRepo.CreateVital(ctx, vital); err != nil {
+			return err
+		}
+		// ペットの体重・バイタルの推移にも記録する
+		return s.recordPetMeasurements(ctx, hospitalization.PetID, vitalMeasurements(hospitalization.PetID, record.RecordDate, vital))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return vital, nil
+}
+
+// AddCareLog ケアログを記録する（その日の日次記録がなければ作成する）
+// ケアプラン項目を指定した場合は、入院のケアプランであることとタイミングが予定に含まれることを確認する。
+func (s *Service) AddCareLog(ctx context.Context, hospitalizationID string, req *model.AddCareLogRequest) (*model.CareLog, error) {
+	if err := validation.ValidateAddCareLog(req); err != nil {
+		return nil, err
+	}
+
+	hospitalization, err := s.GetHospitalizationByID(ctx, hospitalizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	careLog := &model.CareLog{
+		StaffID:      currentStaffID(ctx),
+		RecordedTime: recordedTimeOrNow(req.RecordedTime),
+		Timing:       req.Timing,
+		Type:         req.Type,
+		Status:       req.Status,
+		Value:        req.Value,
+		Notes:        req.Notes,
+	}
+	if careLog.Status == "" {
+		careLog.Status = model.CareLogStatusCompleted
+	}
+	var item *model.CarePlanItem
+	if req.CarePlanItemID != "" {
+		if item, err = findCarePlanItem(hospitalization, uuid.MustParse(req.CarePlanItemID)); err != nil {
+			return nil, err
+		}
+		careLog.CarePlanItemID = &item.ID
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.dailyRecordFor(ctx, hospitalization, req.RecordDate)
+		if err != nil {
+			return err
+		}
+		// 間隔指定の予定時刻は日によって異なるため、記録日の予定と照合する
+		if item != nil && req.Timing != "" && !careplan.HasKey(*item, req.Timing, record.RecordDate) {
+			return apperrors.WrapInvalidInput(fmt.Sprintf("timing %s is not scheduled for care plan item %s", req.Timing, item.Name))
+		}
+		careLog.DailyRecordID = record.ID
+		return s.dailyRecordRepo.CreateCareLog(ctx, careLog)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return careLog, nil
+}
+
+// AddStaffNote スタッフメモを記録する（その日の日次記録がなければ作成する）
+func (s *Service) AddStaffNote(ctx context.Context, hospitalizationID string, req *model.AddStaffNoteRequest) (*model.StaffNote, error) {
+	if err := validation.ValidateAddStaffNote(req); err != nil {
+		return nil, err
+	}
+
+	hospitalization, err := s.GetHospitalizationByID(ctx, hospitalizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	note := &model.StaffNote{
+		StaffID:      currentStaffID(ctx),
+		RecordedTime: recordedTimeOrNow(req.RecordedTime),
+		Content:      req.Content,
+	}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.dailyRecordFor(ctx, hospitalization, req.RecordDate)
+		if err != nil {
+			return err
+		}
+		note.DailyRecordID = record.ID
+		return s.dailyRecordRepo.CreateStaffNote(ctx, note)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// GetTemperatureChart 温度板を取得する
+// 指定日に入院していたペットごとに、その日のバイタル推移と有効なケアプランの予定ごとの実施状況をまとめる。
+func (s *Service) GetTemperatureChart(ctx context.Context, req *model.TemperatureChartRequest) (*model.TemperatureChart, error) {
+	date := today()
+	if req.Date != "" {
+		var err error
+		date, err = parseDateOnly(req.Date)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid date format (expected YYYY-MM-DD)")
+		}
+	}
+
+	hospitalizations, err := s.hospitalizationRepo.FindHospitalizationsOnDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+
+	ids := make([]uuid.UUID, len(hospitalizations))
+	for i, h := range hospitalizations {
+		ids[i] = h.ID
+	}
+	records, err := s.dailyRecordRepo.GetDailyRecordsByDate(ctx, ids, date)
+	if err != nil {
+		return nil, err
+	}
+	recordsByHospitalization := make(map[uuid.UUID]*model.DailyRecord, len(records))
+	for i := range records {
+		recordsByHospitalization[records[i].HospitalizationID] = &records[i]
+	}
+
+	chart := &model.TemperatureChart{
+		Date:    date.Format("2006-01-02"),
+		Entries: make([]model.TemperatureChartEntry, 0, len(hospitalizations)),
+	}
+	for i := range hospitalizations {
+		chart.Entries = append(chart.Entries,
+			temperatureChartEntry(&hospitalizations[i], recordsByHospitalization[hospitalizations[i].ID], date, now))
+	}
+	return chart, nil
+}
+
+// dailyRecordFor 記録日の日次記録を取得または作成する
+// 予約中の入院には記録できず、記録日は入院日から今日まで（退院済の場合は退院日まで）とする。
+func (s *Service) dailyRecordFor(ctx context.Context, h *model.Hospitalization, recordDate string) (*model.DailyRecord, error) {
+	if h.Status == model.HospitalizationStatusReserved {
+		return nil, apperrors.WrapConflict("hospitalization has not been admitted yet")
+	}
+
+	date := today()
+	if recordDate != "" {
+		date, _ = parseDateOnly(recordDate)
+	}
+	if date.Before(h.StartDate) {
+		return nil, apperrors.WrapInvalidInput("record date must not be before the admission date")
+	}
+	if date.After(today()) {
+		return nil, apperrors.WrapInvalidInput("record date must not be in the future")
+	}
+	if h.Status == model.HospitalizationStatusDischarged && date.After(h.EndDate) {
+		return nil, apperrors.WrapInvalidInput("record date must not be after the discharge date")
+	}
+
+	return s.dailyRecordRepo.GetOrCreateDailyRecord(ctx, h.ID, date)
+}
+
+// temperatureChartEntry 入院1件分の温度板を組み立てる
+func temperatureChartEntry(h *model.Hospitalization, record *model.DailyRecord, date, now time.Time) model.TemperatureChartEntry {
+	entry := model.TemperatureChartEntry{
+		Hospitalization: h,
+		Vitals:          []model.Vital{},
+		StaffNotes:      []model.StaffNote{},
+	}
+	var logs []model.CareLog
+	if record != nil {
+		entry.Vitals = append(entry.Vitals, record.Vitals...)
+		entry.StaffNotes = append(entry.StaffNotes, record.StaffNotes...)
+		logs = record.CareLogs
+	}
+
+	entry.CareTasks = careplan.Tasks(h.CarePlanItems, logs, date, now)
+	for _, task := range entry.CareTasks {
+		switch task.Status {
+		case model.CareTaskStatusAsNeeded:
+			continue
+		case model.CareTaskStatusDone:
+			entry.CompletedCount++
+		}
+		entry.ScheduledCount++
+	}
+	return entry
+}
+
+// findCarePlanItem 入院のケアプラン項目をIDで探す
+func findCarePlanItem(h *model.Hospitalization, itemID uuid.UUID) (*model.CarePlanItem, error) {
+	for i := range h.CarePlanItems {
+		if h.CarePlanItems[i].ID == itemID {
+			return &h.CarePlanItems[i], nil
+		}
+	}
+	return nil, apperrors.WrapNotFound("care_plan_item", itemID.String())
+}
+
+// recordedTimeOrNow 記録時刻（HH:MM）を返す。未指定なら現在時刻とする
+func recordedTimeOrNow(recordedTime string) string {
+	if recordedTime != "" {
+		return recordedTime
+	}
+	return time.Now().Format("15:04")
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockDailyRecordRepository is a mock implementation of repository.DailyRecordRepository
+type MockDailyRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockDailyRecordRepository) GetDailyRecords(ctx context.Context, hospitalizationID uuid.UUID) ([]model.DailyRecord, error) {
+	args := m.Called(ctx, hospitalizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DailyRecord), args.Error(1)
+}
+
+func (m *MockDailyRecordRepository) GetDailyRecordsByDate(ctx context.Context, hospitalizationIDs []uuid.UUID, date time.Time) ([]model.DailyRecord, error) {
+	args := m.Called(ctx, hospitalizationIDs, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.DailyRecord), args.Error(1)
+}
+
+func (m *MockDailyRecordRepository) GetOrCreateDailyRecord(ctx context.Context, hospitalizationID uuid.UUID, date time.Time) (*model.DailyRecord, error) {
+	args := m.Called(ctx, hospitalizationID, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.DailyRecord), args.Error(1)
+}
+
+func (m *MockDailyRecordRepository) CreateVital(ctx context.Context, vital *model.Vital) error {
+	args := m.Called(ctx, vital)
+	return args.Error(0)
+}
+
+func (m *MockDailyRecordRepository) CreateCareLog(ctx context.Context, log *model.CareLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockDailyRecordRepository) CreateStaffNote(ctx context.Context, note *model.StaffNote) error {
+	args := m.Called(ctx, note)
+	return args.Error(0)
+}
+
+func TestAddCareLog(t *testing.T) {
+	ctx := context.Background()
+	hospitalizationID := uuid.New()
+	itemID := uuid.New()
+	recordID := uuid.New()
+
+	admitted := func(status string) *model.Hospitalization {
+		return &model.Hospitalization{
+			ID:        hospitalizationID,
+			StartDate: today().AddDate(0, 0, -2),
+			EndDate:   today().AddDate(0, 0, 3),
+			Status:    status,
+			CarePlanItems: []model.CarePlanItem{
+				{ID: itemID, Type: "medicine", Name: "抗生剤", Timing: model.CareSchedule{Times: []string{"morning", "night"}}, Status: "active"},
+			},
+		}
+	}
+
+	t.Run("creates the day's record on first write", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, nil, nil,
+			WithHospitalizationRepository(mockRepo), WithDailyRecordRepository(mockDailyRepo), WithTransactor(tx))
+
+		mockRepo.On("GetHospitalizationByID", ctx, hospitalizationID).Return(admitted(model.HospitalizationStatusAdmitted), nil)
+		mockDailyRepo.On("GetOrCreateDailyRecord", ctx, hospitalizationID, today()).
+			Return(&model.DailyRecord{ID: recordID, HospitalizationID: hospitalizationID}, nil)
+		mockDailyRepo.On("CreateCareLog", ctx, mock.AnythingOfType("*model.CareLog")).Return(nil)
+
+		careLog, err := svc.AddCareLog(ctx, hospitalizationID.String(), &model.AddCareLogRequest{
+			DailyRecordEntry: model.DailyRecordEntry{RecordedTime: "08:15"},
+			CarePlanItemID:   itemID.String(),
+			Timing:           "morning",
+			Type:             "medicine",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, recordID, careLog.DailyRecordID)
+		assert.Equal(t, itemID, *careLog.CarePlanItemID)
+		assert.Equal(t, model.CareLogStatusCompleted, careLog.Status)
+		assert.Equal(t, "08:15", careLog.RecordedTime)
+	})
+
+	t.Run("rejects timing not in the care plan", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo), WithDailyRecordRepository(mockDailyRepo))
+
+		mockRepo.On("GetHospitalizationByID", ctx, hospitalizationID).Return(admitted(model.HospitalizationStatusAdmitted), nil)
+		mockDailyRepo.On("GetOrCreateDailyRecord", ctx, hospitalizationID, today()).
+			Return(&model.DailyRecord{ID: recordID, HospitalizationID: hospitalizationID, RecordDate: today()}, nil)
+
+		_, err := svc.AddCareLog(ctx, hospitalizationID.String(), &model.AddCareLogRequest{
+			CarePlanItemID: itemID.String(),
+			Timing:         "noon",
+			Type:           "medicine",
+		})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockDailyRepo.AssertNotCalled(t, "CreateCareLog", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects records for a reserved hospitalization", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo), WithDailyRecordRepository(mockDailyRepo))
+
+		mockRepo.On("GetHospitalizationByID", ctx, hospitalizationID).Return(admitted(model.HospitalizationStatusReserved), nil)
+
+		_, err := svc.AddCareLog(ctx, hospitalizationID.String(), &model.AddCareLogRequest{Type: "food"})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockDailyRepo.AssertNotCalled(t, "GetOrCreateDailyRecord", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestTemperatureChartEntry(t *testing.T) {
+	date := time.Date(2030, 4, 2, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2030, 4, 2, 13, 0, 0, 0, time.Local)
+	antibioticID := uuid.New()
+	foodID := uuid.New()
+
+	h := &model.Hospitalization{
+		ID: uuid.New(),
+		CarePlanItems: []model.CarePlanItem{
+			{ID: antibioticID, Type: "medicine", Name: "抗生剤", Timing: model.CareSchedule{Times: []string{"night", "morning"}}, Status: "active"},
+			{ID: foodID, Type: "food", Name: "療法食", Timing: model.CareSchedule{Times: []string{"morning", "noon", "night"}}, Status: "active"},
+			{ID: uuid.New(), Type: "medicine", Name: "鎮痛剤", Timing: model.CareSchedule{PRN: true}, Status: "active"},
+		},
+	}
+	record := &model.DailyRecord{
+		Vitals: []model.Vital{{RecordedTime: "09:00"}, {RecordedTime: "17:00"}},
+		CareLogs: []model.CareLog{
+			{CarePlanItemID: &antibioticID, Timing: "morning", Status: model.CareLogStatusCompleted},
+			{CarePlanItemID: &foodID, Timing: "morning", Status: model.CareLogStatusPartial},
+			{Type: "excretion", Status: model.CareLogStatusCompleted},
+		},
+	}
+
+	entry := temperatureChartEntry(h, record, date, now)
+
+	assert.Len(t, entry.Vitals, 2)
+	assert.Len(t, entry.CareTasks, 6)
+	// 頓用は予定数に含めない
+	assert.Equal(t, 5, entry.ScheduledCount)
+	assert.Equal(t, 2, entry.CompletedCount)
+}
+
+func TestTemperatureChartEntryWithoutRecord(t *testing.T) {
+	h := &model.Hospitalization{ID: uuid.New()}
+
+	entry := temperatureChartEntry(h, nil, time.Date(2030, 4, 2, 0, 0, 0, 0, time.UTC), time.Now())
+
+	assert.NotNil(t, entry.Vitals)
+	assert.NotNil(t, entry.CareTasks)
+	assert.Zero(t, entry.ScheduledCount)
+}
@@ -0,0 +1,413 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// ExaminationService 検査サービスインターフェース
+type ExaminationService interface {
+	ListExaminations(ctx context.Context, req *model.ListExaminationsRequest) (*model.ListResult[model.Examination], error)
+	GetExaminationByID(ctx context.Context, id string) (*model.Examination, error)
+	CreateExamination(ctx context.Context, req *model.CreateExaminationRequest) (*model.Examination, error)
+	UpdateExamination(ctx context.Context, id string, req *model.UpdateExaminationRequest) (*model.Examination, error)
+	CompleteExamination(ctx context.Context, id string, req *model.CompleteExaminationRequest) (*model.Examination, error)
+	GetExaminationTrend(ctx context.Context, petID string, req *model.ExaminationTrendRequest) (*model.ExaminationTrend, error)
+	ListLabReferenceRanges(ctx context.Context, req *model.ListLabReferenceRangesRequest) ([]model.LabReferenceRange, error)
+	SaveLabReferenceRange(ctx context.Context, req *model.SaveLabReferenceRangeRequest) (*model.LabReferenceRange, error)
+	DeleteLabReferenceRange(ctx context.Context, id string) error
+}
+
+// Ensure Service implements ExaminationService
+var _ ExaminationService = (*Service)(nil)
+
+// ListExaminations 条件に一致する検査を1ページ分取得（既定は検査日の新しい順）
+func (s *Service) ListExaminations(ctx context.Context, req *model.ListExaminationsRequest) (*model.ListResult[model.Examination], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.ExaminationFilter{Status: req.Status}
+	if filter.Status != "" {
+		if err := validation.ValidateExaminationStatus(filter.Status); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.MedicalRecordID, err = parseOptionalID(req.MedicalRecordID, "medical record"); err != nil {
+		return nil, err
+	}
+	// 検査日は日時のため、院内の日付で区切る
+	if filter.ExaminationDate, err = parseDateRange(req.DateFrom, req.DateTo, time.Local); err != nil {
+		return nil, err
+	}
+	return s.examinationRepo.ListExaminations(ctx, filter, req.ListOptions)
+}
+
+// GetExaminationByID IDで検査を取得
+func (s *Service) GetExaminationByID(ctx context.Context, id string) (*model.Examination, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid examination ID format")
+	}
+	return s.examinationRepo.GetExaminationByID(ctx, uid)
+}
+
+// CreateExamination 検査を依頼する
+// カルテを指定した場合は同じペットのカルテに限り、担当医を省略するとカルテの担当医を引き継ぐ。
+func (s *Service) CreateExamination(ctx context.Context, req *model.CreateExaminationRequest) (*model.Examination, error) {
+	if err := validation.ValidateCreateExamination(req); err != nil {
+		return nil, err
+	}
+	pet, err := s.repo.GetPetByID(ctx, uuid.MustParse(req.PetID))
+	if err != nil {
+		return nil, err
+	}
+
+	examination := &model.Examination{
+		PetID:           pet.ID,
+		OwnerID:         pet.OwnerID,
+		ExaminationDate: time.Now(),
+		TestType:        req.TestType,
+		Machine:         req.Machine,
+		Status:          model.ExaminationStatusOrdered,
+		Items:           orderedExaminationItems(req.Analytes),
+		Notes:           req.Notes,
+	}
+	if req.ExaminationDate != "" {
+		if examination.ExaminationDate, err = parseVisitDate(req.ExaminationDate); err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid examination date format")
+		}
+	}
+	if req.DoctorID != "" {
+		doctorID := uuid.MustParse(req.DoctorID)
+		examination.DoctorID = &doctorID
+	}
+	if req.MedicalRecordID != "" {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByID(ctx, req.MedicalRecordID)
+		if err != nil {
+			return nil, err
+		}
+		if record.PetID != pet.ID {
+			return nil, apperrors.WrapInvalidInput("medical record must be for the same pet as the examination")
+		}
+		examination.MedicalRecordID = &record.ID
+		if examination.DoctorID == nil {
+			examination.DoctorID = record.DoctorID
+		}
+	}
+
+	if err := s.examinationRepo.CreateExamination(ctx, examination); err != nil {
+		return nil, err
+	}
+	return s.examinationRepo.GetExaminationByID(ctx, examination.ID)
+}
+
+// UpdateExamination 完了前の検査を変更する
+// 検査項目を指定した場合は依頼項目を置き換える（記入済みの結果は同じ項目に限り残す）。
+func (s *Service) UpdateExamination(ctx context.Context, id string, req *model.UpdateExaminationRequest) (*model.Examination, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid examination ID format")
+	}
+	if err := validation.ValidateUpdateExamination(req); err != nil {
+		return nil, err
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		examination, err := s.examinationRepo.GetExaminationByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if examination.Status == model.ExaminationStatusCompleted {
+			return apperrors.WrapConflict("completed examination cannot be updated")
+		}
+
+		if req.DoctorID != nil {
+			examination.DoctorID = nil
+			if *req.DoctorID != "" {
+				doctorID := uuid.MustParse(*req.DoctorID)
+				examination.DoctorID = &doctorID
+			}
+		}
+		if req.ExaminationDate != nil {
+			if examination.ExaminationDate, err = parseVisitDate(*req.ExaminationDate); err != nil {
+				return apperrors.WrapInvalidInput("invalid examination date format")
+			}
+		}
+		if req.TestType != nil {
+			examination.TestType = *req.TestType
+		}
+		if req.Machine != nil {
+			examination.Machine = *req.Machine
+		}
+		if req.Status != nil {
+			examination.Status = *req.Status
+		}
+		if req.Analytes != nil {
+			existing := make(map[string]model.ExaminationItem, len(examination.Items))
+			for _, item := range examination.Items {
+				existing[item.Analyte] = item
+			}
+			items := orderedExaminationItems(*req.Analytes)
+			for i, item := range items {
+				if prev, ok := existing[item.Analyte]; ok {
+					items[i] = prev
+				}
+			}
+			examination.Items = items
+		}
+		if req.Notes != nil {
+			examination.Notes = *req.Notes
+		}
+		return s.examinationRepo.UpdateExamination(ctx, examination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.examinationRepo.GetExaminationByID(ctx, uid)
+}
+
+// CompleteExamination 検査結果を記入して完了にする
+// 結果は依頼した項目に記入し、依頼していない項目は追加する。数値の結果はペットの動物種の基準範囲で高値・低値を判定する。
+func (s *Service) CompleteExamination(ctx context.Context, id string, req *model.CompleteExaminationRequest) (*model.Examination, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid examination ID format")
+	}
+	if err := validation.ValidateCompleteExamination(req); err != nil {
+		return nil, err
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		examination, err := s.examinationRepo.GetExaminationByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if examination.Status == model.ExaminationStatusCompleted {
+			return apperrors.WrapConflict("examination is already completed")
+		}
+		if req.ResultSummary != "" {
+			examination.ResultSummary = req.ResultSummary
+		}
+		if err := s.completeExamination(ctx, examination, req.Results); err != nil {
+			return err
+		}
+		return s.examinationRepo.UpdateExamination(ctx, examination)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.examinationRepo.GetExaminationByID(ctx, uid)
+}
+
+// completeExamination 結果を検査項目に記入し、基準範囲で判定して完了にする（保存は呼び出し側で行う）
+func (s *Service) completeExamination(ctx context.Context, examination *model.Examination, results []model.ExaminationResultInput) error {
+	pet, err := s.repo.GetPetByID(ctx, examination.PetID)
+	if err != nil {
+		return err
+	}
+
+	index := make(map[string]int, len(examination.Items))
+	for i, item := range examination.Items {
+		index[item.Analyte] = i
+	}
+	recorded := make([]int, 0, len(results))
+	for _, result := range results {
+		analyte := model.NormalizeAnalyte(result.Analyte)
+		i, ok := index[analyte]
+		if !ok {
+			examination.Items = append(examination.Items, model.ExaminationItem{Analyte: analyte})
+			i = len(examination.Items) - 1
+			index[analyte] = i
+		}
+		item := &examination.Items[i]
+		if result.Name != "" {
+			item.Name = result.Name
+		}
+		if result.Unit != "" {
+			item.Unit = result.Unit
+		}
+		item.Value = result.Value
+		item.TextValue = strings.TrimSpace(result.TextValue)
+		recorded = append(recorded, i)
+	}
+
+	analytes := make([]string, len(recorded))
+	for n, i := range recorded {
+		analytes[n] = examination.Items[i].Analyte
+	}
+	species := model.SpeciesKind(pet.Species)
+	references, err := s.examinationRepo.FindLabReferenceRanges(ctx, analytes, species)
+	if err != nil {
+		return err
+	}
+	// 動物種別の範囲を、動物種を問わない既定の範囲より優先する
+	ranges := make(map[string]model.LabReferenceRange, len(references))
+	for _, reference := range references {
+		if _, ok := ranges[reference.Analyte]; ok && reference.Species == "" {
+			continue
+		}
+		ranges[reference.Analyte] = reference
+	}
+	for _, i := range recorded {
+		item := &examination.Items[i]
+		if reference, ok := ranges[item.Analyte]; ok {
+			flagExaminationItem(item, &reference)
+		} else {
+			flagExaminationItem(item, nil)
+		}
+	}
+
+	now := time.Now()
+	examination.Status = model.ExaminationStatusCompleted
+	examination.CompletedAt = &now
+	return nil
+}
+
+// flagExaminationItem 検査項目に基準範囲を写し、数値の結果が範囲外ならH・Lを付ける
+// 単位が基準範囲と異なる場合は比べられないため、範囲を写さず判定もしない。
+func flagExaminationItem(item *model.ExaminationItem, reference *model.LabReferenceRange) {
+	item.ReferenceLow, item.ReferenceHigh, item.Flag = nil, nil, ""
+	if reference == nil {
+		return
+	}
+	if item.Name == "" {
+		item.Name = reference.Name
+	}
+	if item.Unit == "" {
+		item.Unit = reference.Unit
+	}
+	if reference.Unit != "" && !strings.EqualFold(item.Unit, reference.Unit) {
+		return
+	}
+	item.ReferenceLow, item.ReferenceHigh = reference.Low, reference.High
+	if item.Value == nil {
+		return
+	}
+	switch {
+	case reference.High != nil && *item.Value > *reference.High:
+		item.Flag = model.ExaminationFlagHigh
+	case reference.Low != nil && *item.Value < *reference.Low:
+		item.Flag = model.ExaminationFlagLow
+	}
+}
+
+// orderedExaminationItems 依頼する検査項目コードから結果未記入の検査項目を作る
+func orderedExaminationItems(analytes []string) model.ExaminationItems {
+	items := make(model.ExaminationItems, len(analytes))
+	for i, analyte := range analytes {
+		items[i] = model.ExaminationItem{Analyte: model.NormalizeAnalyte(analyte)}
+	}
+	return items
+}
+
+// GetExaminationTrend ペットの完了した検査から1項目の結果を検査日の古い順に並べる
+func (s *Service) GetExaminationTrend(ctx context.Context, petID string, req *model.ExaminationTrendRequest) (*model.ExaminationTrend, error) {
+	uid, err := uuid.Parse(petID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if err := validation.ValidateExaminationTrend(req); err != nil {
+		return nil, err
+	}
+	dateRange, err := parseDateRange(req.DateFrom, req.DateTo, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.repo.GetPetByID(ctx, uid); err != nil {
+		return nil, err
+	}
+
+	analyte := model.NormalizeAnalyte(req.Analyte)
+	examinations, err := s.examinationRepo.GetCompletedExaminationsWithAnalyte(ctx, uid, analyte, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	trend := &model.ExaminationTrend{PetID: uid, Analyte: analyte, Points: []model.ExaminationTrendPoint{}}
+	for _, examination := range examinations {
+		for _, item := range examination.Items {
+			if item.Analyte != analyte {
+				continue
+			}
+			trend.Points = append(trend.Points, model.ExaminationTrendPoint{
+				ExaminationID:   examination.ID,
+				ExaminationDate: examination.ExaminationDate,
+				ExaminationItem: item,
+			})
+			if item.Name != "" {
+				trend.Name = item.Name
+			}
+			break
+		}
+	}
+	return trend, nil
+}
+
+// ListLabReferenceRanges 基準範囲を項目コード・動物種別の順に取得
+func (s *Service) ListLabReferenceRanges(ctx context.Context, req *model.ListLabReferenceRangesRequest) ([]model.LabReferenceRange, error) {
+	species := ""
+	if req.Species != "" {
+		species = model.SpeciesKind(req.Species)
+	}
+	return s.examinationRepo.ListLabReferenceRanges(ctx, model.NormalizeAnalyte(req.Analyte), species)
+}
+
+// SaveLabReferenceRange 基準範囲を登録する（同じ項目・動物種別の範囲は上書きする）
+// 登録済みの検査の判定は変えず、以後に完了する検査から使う。
+func (s *Service) SaveLabReferenceRange(ctx context.Context, req *model.SaveLabReferenceRangeRequest) (*model.LabReferenceRange, error) {
+	if err := validation.ValidateSaveLabReferenceRange(req); err != nil {
+		return nil, err
+	}
+	analyte, species := model.NormalizeAnalyte(req.Analyte), ""
+	if req.Species != "" {
+		species = model.SpeciesKind(req.Species)
+	}
+
+	// 既存の範囲は読み直して更新し、変更前後を監査ログに残す
+	var reference *model.LabReferenceRange
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.examinationRepo.GetLabReferenceRangeForUpdate(ctx, analyte, species)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			reference = &model.LabReferenceRange{Analyte: analyte, Species: species}
+		} else {
+			reference = existing
+		}
+		reference.Name = req.Name
+		reference.Unit = req.Unit
+		reference.Low = req.Low
+		reference.High = req.High
+		if existing == nil {
+			return s.examinationRepo.CreateLabReferenceRange(ctx, reference)
+		}
+		return s.examinationRepo.UpdateLabReferenceRange(ctx, reference)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return reference, nil
+}
+
+// DeleteLabReferenceRange 基準範囲を削除する
+func (s *Service) DeleteLabReferenceRange(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid lab reference range ID format")
+	}
+	return s.examinationRepo.DeleteLabReferenceRange(ctx, uid)
+}
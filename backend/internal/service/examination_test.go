@@ -0,0 +1,332 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockExaminationRepository is a mock implementation of ExaminationRepository
+type MockExaminationRepository struct {
+	mock.Mock
+}
+
+func (m *MockExaminationRepository) EnsureAccessionNoSequence(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockExaminationRepository) ListExaminations(ctx context.Context, filter model.ExaminationFilter, opts model.ListOptions) (*model.ListResult[model.Examination], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Examination]), args.Error(1)
+}
+
+func (m *MockExaminationRepository) GetExaminationByID(ctx context.Context, id uuid.UUID) (*model.Examination, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockExaminationRepository) GetExaminationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Examination, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockExaminationRepository) GetExaminationByAccessionNoForUpdate(ctx context.Context, accessionNo int) (*model.Examination, error) {
+	args := m.Called(ctx, accessionNo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Examination), args.Error(1)
+}
+
+func (m *MockExaminationRepository) FindPendingExaminationsByPetNumber(ctx context.Context, petNumber string) ([]model.Examination, error) {
+	args := m.Called(ctx, petNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Examination), args.Error(1)
+}
+
+func (m *MockExaminationRepository) GetCompletedExaminationsWithAnalyte(ctx context.Context, petID uuid.UUID, analyte string, dateRange model.DateRange) ([]model.Examination, error) {
+	args := m.Called(ctx, petID, analyte, dateRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Examination), args.Error(1)
+}
+
+func (m *MockExaminationRepository) CreateExamination(ctx context.Context, examination *model.Examination) error {
+	args := m.Called(ctx, examination)
+	return args.Error(0)
+}
+
+func (m *MockExaminationRepository) UpdateExamination(ctx context.Context, examination *model.Examination) error {
+	args := m.Called(ctx, examination)
+	return args.Error(0)
+}
+
+func (m *MockExaminationRepository) ListLabReferenceRanges(ctx context.Context, analyte, species string) ([]model.LabReferenceRange, error) {
+	args := m.Called(ctx, analyte, species)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.LabReferenceRange), args.Error(1)
+}
+
+func (m *MockExaminationRepository) FindLabReferenceRanges(ctx context.Context, analytes []string, species string) ([]model.LabReferenceRange, error) {
+	args := m.Called(ctx, analytes, species)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.LabReferenceRange), args.Error(1)
+}
+
+func (m *MockExaminationRepository) GetLabReferenceRangeForUpdate(ctx context.Context, analyte, species string) (*model.LabReferenceRange, error) {
+	args := m.Called(ctx, analyte, species)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.LabReferenceRange), args.Error(1)
+}
+
+func (m *MockExaminationRepository) CreateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error {
+	args := m.Called(ctx, reference)
+	return args.Error(0)
+}
+
+func (m *MockExaminationRepository) UpdateLabReferenceRange(ctx context.Context, reference *model.LabReferenceRange) error {
+	args := m.Called(ctx, reference)
+	return args.Error(0)
+}
+
+func (m *MockExaminationRepository) DeleteLabReferenceRange(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func float(v float64) *float64 { return &v }
+
+func TestCreateExamination(t *testing.T) {
+	ctx := context.Background()
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), Species: "犬"}
+	doctorID := uuid.New()
+
+	t.Run("links the medical record and takes over its doctor", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		mockPetRepo := new(MockPetRepository)
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(mockPetRepo, nil, mockRecordRepo, nil, WithExaminationRepository(mockRepo))
+
+		record := &model.MedicalRecord{ID: uuid.New(), PetID: pet.ID, DoctorID: &doctorID}
+		mockPetRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, record.ID.String()).Return(record, nil)
+		var created *model.Examination
+		mockRepo.On("CreateExamination", ctx, mock.AnythingOfType("*model.Examination")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*model.Examination) }).
+			Return(nil)
+		mockRepo.On("GetExaminationByID", ctx, mock.Anything).Return(&model.Examination{}, nil)
+
+		_, err := svc.CreateExamination(ctx, &model.CreateExaminationRequest{
+			PetID:           pet.ID.String(),
+			MedicalRecordID: record.ID.String(),
+			TestType:        "血液生化学",
+			Analytes:        []string{" alt ", "BUN"},
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.Equal(t, pet.OwnerID, created.OwnerID)
+		assert.Equal(t, &record.ID, created.MedicalRecordID)
+		assert.Equal(t, &doctorID, created.DoctorID)
+		assert.Equal(t, model.ExaminationStatusOrdered, created.Status)
+		assert.Equal(t, model.ExaminationItems{{Analyte: "ALT"}, {Analyte: "BUN"}}, created.Items)
+	})
+
+	t.Run("rejects a medical record for another pet", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		mockPetRepo := new(MockPetRepository)
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(mockPetRepo, nil, mockRecordRepo, nil, WithExaminationRepository(mockRepo))
+
+		record := &model.MedicalRecord{ID: uuid.New(), PetID: uuid.New()}
+		mockPetRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, record.ID.String()).Return(record, nil)
+
+		_, err := svc.CreateExamination(ctx, &model.CreateExaminationRequest{PetID: pet.ID.String(), MedicalRecordID: record.ID.String()})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockRepo.AssertNotCalled(t, "CreateExamination", mock.Anything, mock.Anything)
+	})
+}
+
+func TestCompleteExamination(t *testing.T) {
+	ctx := context.Background()
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), Species: "猫（雑種）"}
+	examination := func() *model.Examination {
+		return &model.Examination{
+			ID: uuid.New(), PetID: pet.ID, OwnerID: pet.OwnerID, Status: model.ExaminationStatusOrdered,
+			Items: model.ExaminationItems{{Analyte: "ALT"}, {Analyte: "BUN"}, {Analyte: "GLU"}},
+		}
+	}
+
+	t.Run("flags results against species ranges and falls back to the default range", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		mockPetRepo := new(MockPetRepository)
+		tx := &fakeTransactor{}
+		svc := New(mockPetRepo, nil, nil, nil, WithExaminationRepository(mockRepo), WithTransactor(tx))
+
+		exam := examination()
+		mockRepo.On("GetExaminationByIDForUpdate", ctx, exam.ID).Return(exam, nil)
+		mockPetRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+		mockRepo.On("FindLabReferenceRanges", ctx, []string{"ALT", "BUN", "GLU", "FIV"}, model.SpeciesCat).Return([]model.LabReferenceRange{
+			{Analyte: "ALT", Species: model.SpeciesCat, Name: "ALT", Unit: "U/L", Low: float(12), High: float(130)},
+			{Analyte: "ALT", Species: "", Unit: "U/L", Low: float(10), High: float(100)},
+			{Analyte: "BUN", Species: "", Name: "尿素窒素", Unit: "mg/dL", Low: float(16), High: float(36)},
+			{Analyte: "GLU", Species: model.SpeciesCat, Unit: "mg/dL", Low: float(71), High: float(159)},
+		}, nil)
+		mockRepo.On("UpdateExamination", ctx, exam).Return(nil)
+		mockRepo.On("GetExaminationByID", ctx, exam.ID).Return(exam, nil)
+
+		result, err := svc.CompleteExamination(ctx, exam.ID.String(), &model.CompleteExaminationRequest{
+			Results: []model.ExaminationResultInput{
+				{Analyte: "alt", Value: float(120)},
+				{Analyte: "BUN", Value: float(40)},
+				{Analyte: "GLU", Value: float(3.5), Unit: "mmol/L"},
+				{Analyte: "FIV", TextValue: "陰性"},
+			},
+			ResultSummary: "腎数値やや高値",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, model.ExaminationStatusCompleted, result.Status)
+		assert.NotNil(t, result.CompletedAt)
+		assert.Equal(t, "腎数値やや高値", result.ResultSummary)
+		require.Len(t, result.Items, 4)
+
+		// 猫の範囲（上限130）で判定するため、既定の上限100を超えていても範囲内
+		alt := result.Items[0]
+		assert.Equal(t, "U/L", alt.Unit)
+		assert.Equal(t, float(130), alt.ReferenceHigh)
+		assert.Empty(t, alt.Flag)
+
+		bun := result.Items[1]
+		assert.Equal(t, "尿素窒素", bun.Name)
+		assert.Equal(t, model.ExaminationFlagHigh, bun.Flag)
+
+		// 単位が基準範囲と異なる結果は判定しない
+		glu := result.Items[2]
+		assert.Nil(t, glu.ReferenceLow)
+		assert.Empty(t, glu.Flag)
+
+		fiv := result.Items[3]
+		assert.Equal(t, "FIV", fiv.Analyte)
+		assert.Equal(t, "陰性", fiv.TextValue)
+	})
+
+	t.Run("flags low values", func(t *testing.T) {
+		item := model.ExaminationItem{Analyte: "HCT", Value: float(22)}
+		flagExaminationItem(&item, &model.LabReferenceRange{Analyte: "HCT", Unit: "%", Low: float(30), High: float(45)})
+
+		assert.Equal(t, model.ExaminationFlagLow, item.Flag)
+		assert.Equal(t, "%", item.Unit)
+	})
+
+	t.Run("rejects a completed examination", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		svc := New(nil, nil, nil, nil, WithExaminationRepository(mockRepo))
+		exam := examination()
+		exam.Status = model.ExaminationStatusCompleted
+		mockRepo.On("GetExaminationByIDForUpdate", ctx, exam.ID).Return(exam, nil)
+
+		_, err := svc.CompleteExamination(ctx, exam.ID.String(), &model.CompleteExaminationRequest{
+			Results: []model.ExaminationResultInput{{Analyte: "ALT", Value: float(50)}},
+		})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "UpdateExamination", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetExaminationTrend(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockExaminationRepository)
+	mockPetRepo := new(MockPetRepository)
+	svc := New(mockPetRepo, nil, nil, nil, WithExaminationRepository(mockRepo))
+
+	pet := &model.Pet{ID: uuid.New()}
+	first := model.Examination{ID: uuid.New(), ExaminationDate: time.Date(2026, 1, 10, 10, 0, 0, 0, time.Local),
+		Items: model.ExaminationItems{{Analyte: "CRE", Value: float(1.6)}, {Analyte: "BUN", Value: float(30)}}}
+	second := model.Examination{ID: uuid.New(), ExaminationDate: time.Date(2026, 2, 10, 10, 0, 0, 0, time.Local),
+		Items: model.ExaminationItems{{Analyte: "CRE", Name: "クレアチニン", Value: float(2.4), Flag: model.ExaminationFlagHigh}}}
+	mockPetRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+	mockRepo.On("GetCompletedExaminationsWithAnalyte", ctx, pet.ID, "CRE", model.DateRange{}).
+		Return([]model.Examination{first, second}, nil)
+
+	trend, err := svc.GetExaminationTrend(ctx, pet.ID.String(), &model.ExaminationTrendRequest{Analyte: "cre"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "CRE", trend.Analyte)
+	assert.Equal(t, "クレアチニン", trend.Name)
+	require.Len(t, trend.Points, 2)
+	assert.Equal(t, first.ID, trend.Points[0].ExaminationID)
+	assert.Equal(t, float(1.6), trend.Points[0].Value)
+	assert.Equal(t, model.ExaminationFlagHigh, trend.Points[1].Flag)
+}
+
+func TestSaveLabReferenceRange(t *testing.T) {
+	ctx := context.Background()
+	req := &model.SaveLabReferenceRangeRequest{Analyte: "bun", Species: "犬", Name: "尿素窒素", Unit: "mg/dL", Low: float(9.2), High: float(29.2)}
+
+	t.Run("creates a new range", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, nil, nil, WithExaminationRepository(mockRepo), WithTransactor(tx))
+
+		mockRepo.On("GetLabReferenceRangeForUpdate", ctx, "BUN", model.SpeciesDog).Return(nil, nil)
+		mockRepo.On("CreateLabReferenceRange", ctx, mock.AnythingOfType("*model.LabReferenceRange")).Return(nil)
+
+		reference, err := svc.SaveLabReferenceRange(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, "BUN", reference.Analyte)
+		assert.Equal(t, model.SpeciesDog, reference.Species)
+		assert.Equal(t, 29.2, *reference.High)
+		mockRepo.AssertNotCalled(t, "UpdateLabReferenceRange", mock.Anything, mock.Anything)
+	})
+
+	t.Run("updates the existing range instead of upserting", func(t *testing.T) {
+		mockRepo := new(MockExaminationRepository)
+		svc := New(nil, nil, nil, nil, WithExaminationRepository(mockRepo), WithTransactor(&fakeTransactor{}))
+
+		existing := &model.LabReferenceRange{ID: uuid.New(), Analyte: "BUN", Species: model.SpeciesDog, Name: "BUN", Low: float(7), High: float(27)}
+		mockRepo.On("GetLabReferenceRangeForUpdate", ctx, "BUN", model.SpeciesDog).Return(existing, nil)
+		mockRepo.On("UpdateLabReferenceRange", ctx, existing).Return(nil)
+
+		reference, err := svc.SaveLabReferenceRange(ctx, req)
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, reference.ID)
+		assert.Equal(t, "尿素窒素", reference.Name)
+		assert.Equal(t, 9.2, *reference.Low)
+		mockRepo.AssertNotCalled(t, "CreateLabReferenceRange", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,701 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// HospitalizationService 入院・ホテルサービスインターフェース
+type HospitalizationService interface {
+	ListHospitalizations(ctx context.Context, req *model.ListHospitalizationsRequest) (*model.ListResult[model.Hospitalization], error)
+	GetHospitalizationByID(ctx context.Context, id string) (*model.Hospitalization, error)
+	AdmitHospitalization(ctx context.Context, req *model.CreateHospitalizationRequest) (*model.Hospitalization, error)
+	UpdateHospitalization(ctx context.Context, id string, req *model.UpdateHospitalizationRequest) (*model.Hospitalization, error)
+	DeleteHospitalization(ctx context.Context, id string) error
+	DischargeHospitalization(ctx context.Context, id string, req *model.DischargeHospitalizationRequest) (*model.DischargeResult, error)
+	GetAllCages(ctx context.Context) ([]model.Cage, error)
+	GetAvailableCages(ctx context.Context, req *model.AvailableCagesRequest) ([]model.Cage, error)
+	GetCarePlanItems(ctx context.Context, id string) ([]model.CarePlanItem, error)
+	AddCarePlanItem(ctx context.Context, id string, req *model.AddCarePlanItemRequest) (*model.CarePlanItem, error)
+	DeleteCarePlanItem(ctx context.Context, id, itemID string) error
+}
+
+// Ensure Service implements HospitalizationService
+var _ HospitalizationService = (*Service)(nil)
+
+// ListHospitalizations 条件に一致する入院を1ページ分取得（既定は開始日の新しい順）
+func (s *Service) ListHospitalizations(ctx context.Context, req *model.ListHospitalizationsRequest) (*model.ListResult[model.Hospitalization], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.HospitalizationFilter{Status: req.Status, Type: req.Type}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.StartDate, err = parseDateRange(req.DateFrom, req.DateTo, time.UTC); err != nil {
+		return nil, err
+	}
+	return s.hospitalizationRepo.ListHospitalizations(ctx, filter, req.ListOptions)
+}
+
+// GetHospitalizationByID IDで入院を取得
+func (s *Service) GetHospitalizationByID(ctx context.Context, id string) (*model.Hospitalization, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid hospitalization ID format")
+	}
+	return s.hospitalizationRepo.GetHospitalizationByID(ctx, uid)
+}
+
+// AdmitHospitalization 入院（ホテル）を受け付け、期間中に空いているケージを割り当てる
+// ケージ未指定の場合は、ペットの種類に合う種別（専用を共用より優先）で指定サイズ以上の最小のケージを選ぶ。
+// 同時受付による二重割り当てを防ぐため、ケージ単位のロックを取得してから期間の重複を確認する。
+func (s *Service) AdmitHospitalization(ctx context.Context, req *model.CreateHospitalizationRequest) (*model.Hospitalization, error) {
+	if err := validation.ValidateCreateHospitalization(req); err != nil {
+		return nil, err
+	}
+
+	start, end, err := parseStayPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	pet, err := s.repo.GetPetByID(ctx, uuid.MustParse(req.PetID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 入院番号をシーケンスから採番
+	hospitalizationNo, err := s.hospitalizationRepo.NextHospitalizationNo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hospitalization := &model.Hospitalization{
+		HospitalizationNo: hospitalizationNo,
+		PetID:             pet.ID,
+		OwnerID:           pet.OwnerID,
+		Type:              req.Type,
+		StartDate:         start,
+		EndDate:           end,
+		Status:            req.Status,
+		OwnerRequest:      req.OwnerRequest,
+		StaffNotes:        req.StaffNotes,
+		Memo:              req.Memo,
+	}
+	if hospitalization.Type == "" {
+		hospitalization.Type = model.HospitalizationTypeInpatient
+	}
+	if hospitalization.Status == "" {
+		hospitalization.Status = model.HospitalizationStatusReserved
+		if !start.After(today()) {
+			hospitalization.Status = model.HospitalizationStatusAdmitted
+		}
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		var cage *model.Cage
+		if req.CageID != "" {
+			cage, err = s.hospitalizationRepo.GetCageByID(ctx, uuid.MustParse(req.CageID))
+			if err != nil {
+				return err
+			}
+			if !cageCompatible(cage, pet.Species, req.CageSize) {
+				return apperrors.WrapInvalidInput(fmt.Sprintf("cage %s (%s %s) is not suitable for %s", cage.Code, cage.Type, cage.Size, pet.Species))
+			}
+			if err := s.reserveCage(ctx, cage, hospitalization); err != nil {
+				return err
+			}
+		} else {
+			cage, err = s.allocateCage(ctx, pet.Species, req.CageSize, hospitalization)
+			if err != nil {
+				return err
+			}
+		}
+		hospitalization.CageID = &cage.ID
+
+		if err := s.hospitalizationRepo.CreateHospitalization(ctx, hospitalization); err != nil {
+			return err
+		}
+		if hospitalization.Status == model.HospitalizationStatusAdmitted {
+			return s.hospitalizationRepo.UpdateCageAvailability(ctx, cage.ID, false)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.hospitalizationRepo.GetHospitalizationByID(ctx, hospitalization.ID)
+}
+
+// UpdateHospitalization 入院情報を更新する（退院予定日・ケージの変更時は重複を再確認する）
+func (s *Service) UpdateHospitalization(ctx context.Context, id string, req *model.UpdateHospitalizationRequest) (*model.Hospitalization, error) {
+	if err := validation.ValidateUpdateHospitalization(req); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid hospitalization ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		hospitalization, err := s.hospitalizationRepo.GetHospitalizationByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if hospitalization.Status == model.HospitalizationStatusDischarged {
+			return apperrors.WrapConflict("discharged hospitalization cannot be modified")
+		}
+
+		oldCageID := hospitalization.CageID
+		wasOccupying := occupiesCage(hospitalization.Status)
+		recheck := false
+
+		if req.EndDate != nil {
+			end, err := parseDateOnly(*req.EndDate)
+			if err != nil {
+				return apperrors.WrapInvalidInput("invalid end date format")
+			}
+			if end.Before(hospitalization.StartDate) {
+				return apperrors.WrapInvalidInput("end date must not be before start date")
+			}
+			hospitalization.EndDate = end
+			recheck = true
+		}
+		if req.Status != nil {
+			hospitalization.Status = *req.Status
+		}
+		if req.OwnerRequest != nil {
+			hospitalization.OwnerRequest = *req.OwnerRequest
+		}
+		if req.StaffNotes != nil {
+			hospitalization.StaffNotes = *req.StaffNotes
+		}
+		if req.Memo != nil {
+			hospitalization.Memo = *req.Memo
+		}
+
+		cageID := hospitalization.CageID
+		if req.CageID != nil {
+			cage, err := s.hospitalizationRepo.GetCageByID(ctx, uuid.MustParse(*req.CageID))
+			if err != nil {
+				return err
+			}
+			species := ""
+			if hospitalization.Pet != nil {
+				species = hospitalization.Pet.Species
+			}
+			if !cageCompatible(cage, species, "") {
+				return apperrors.WrapInvalidInput(fmt.Sprintf("cage %s (%s) is not suitable for %s", cage.Code, cage.Type, species))
+			}
+			cageID = &cage.ID
+			recheck = true
+		}
+
+		if recheck && cageID != nil {
+			if err := s.reserveCage(ctx, &model.Cage{ID: *cageID}, hospitalization); err != nil {
+				return err
+			}
+		}
+		hospitalization.CageID = cageID
+
+		if err := s.hospitalizationRepo.UpdateHospitalization(ctx, hospitalization); err != nil {
+			return err
+		}
+		return s.syncCageAvailability(ctx, oldCageID, wasOccupying, hospitalization)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.hospitalizationRepo.GetHospitalizationByID(ctx, uid)
+}
+
+// DeleteHospitalization 入院予約を取り消す（入院中・退院済の入院は削除できない）
+func (s *Service) DeleteHospitalization(ctx context.Context, id string) error {
+	hospitalization, err := s.GetHospitalizationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hospitalization.Status != model.HospitalizationStatusReserved {
+		return apperrors.WrapConflict(fmt.Sprintf("hospitalization in status %s cannot be deleted", hospitalization.Status))
+	}
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		return s.hospitalizationRepo.DeleteHospitalization(ctx, hospitalization.ID)
+	})
+}
+
+// DischargeHospitalization 退院処理を行う
+// 退院日を終了日として記録してケージを解放し、指定があればケアプランの単価から未収の会計を作成する。
+func (s *Service) DischargeHospitalization(ctx context.Context, id string, req *model.DischargeHospitalizationRequest) (*model.DischargeResult, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid hospitalization ID format")
+	}
+
+	dischargeDate := today()
+	if req.DischargeDate != "" {
+		dischargeDate, err = parseDateOnly(req.DischargeDate)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid discharge date format")
+		}
+	}
+
+	var result model.DischargeResult
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		hospitalization, err := s.hospitalizationRepo.GetHospitalizationByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		switch hospitalization.Status {
+		case model.HospitalizationStatusAdmitted, model.HospitalizationStatusTemporaryLeave:
+		case model.HospitalizationStatusDischarged:
+			return apperrors.WrapConflict("hospitalization is already discharged")
+		default:
+			return apperrors.WrapConflict(fmt.Sprintf("hospitalization in status %s cannot be discharged", hospitalization.Status))
+		}
+		if dischargeDate.Before(hospitalization.StartDate) {
+			return apperrors.WrapInvalidInput("discharge date must not be before start date")
+		}
+
+		now := time.Now()
+		hospitalization.Status = model.HospitalizationStatusDischarged
+		hospitalization.EndDate = dischargeDate
+		hospitalization.DischargedAt = &now
+		if err := s.hospitalizationRepo.UpdateHospitalization(ctx, hospitalization); err != nil {
+			return err
+		}
+		if hospitalization.CageID != nil {
+			if err := s.hospitalizationRepo.UpdateCageAvailability(ctx, *hospitalization.CageID, true); err != nil {
+				return err
+			}
+		}
+
+		if req.CreateAccounting {
+			accounting, err := s.accountingFromCarePlan(ctx, hospitalization)
+			if err != nil {
+				return err
+			}
+			result.Accounting = accounting
+		}
+		result.Hospitalization = hospitalization
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAllCages 全てのケージを取得
+func (s *Service) GetAllCages(ctx context.Context) ([]model.Cage, error) {
+	return s.hospitalizationRepo.GetAllCages(ctx)
+}
+
+// GetAvailableCages 期間中に空いている、ペットの種類・サイズに合うケージを割り当て優先順に取得
+func (s *Service) GetAvailableCages(ctx context.Context, req *model.AvailableCagesRequest) ([]model.Cage, error) {
+	if err := validation.ValidateAvailableCages(req); err != nil {
+		return nil, err
+	}
+	start, end, err := parseStayPeriod(req.StartDate, req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.compatibleCages(ctx, req.Species, req.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	available := []model.Cage{}
+	for _, cage := range candidates {
+		overlaps, err := s.hospitalizationRepo.FindOverlappingHospitalizations(ctx, cage.ID, start, stayEnd(start, end), uuid.Nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(overlaps) == 0 {
+			available = append(available, cage)
+		}
+	}
+	return available, nil
+}
+
+// GetCarePlanItems 入院のケアプラン項目を取得
+func (s *Service) GetCarePlanItems(ctx context.Context, id string) ([]model.CarePlanItem, error) {
+	hospitalization, err := s.GetHospitalizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.hospitalizationRepo.GetCarePlanItems(ctx, hospitalization.ID)
+}
+
+// AddCarePlanItem 入院にケアプラン項目を追加する（マスタ指定時は名称・単価をマスタから補完）
+func (s *Service) AddCarePlanItem(ctx context.Context, id string, req *model.AddCarePlanItemRequest) (*model.CarePlanItem, error) {
+	if err := validation.ValidateAddCarePlanItem(req); err != nil {
+		return nil, err
+	}
+
+	hospitalization, err := s.GetHospitalizationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if hospitalization.Status == model.HospitalizationStatusDischarged {
+		return nil, apperrors.WrapConflict("care plan of a discharged hospitalization cannot be modified")
+	}
+
+	item := &model.CarePlanItem{
+		HospitalizationID: hospitalization.ID,
+		Type:              req.Type,
+		Name:              req.Name,
+		Description:       req.Description,
+		Timing:            valueOrZeroSchedule(req.Timing),
+		Status:            "active",
+		UnitPrice:         req.UnitPrice,
+		Category:          req.Category,
+		Notes:             req.Notes,
+	}
+	if req.MasterID != "" {
+		master, err := s.masterItemRepo.GetMasterItemByID(ctx, uuid.MustParse(req.MasterID))
+		if err != nil {
+			return nil, err
+		}
+		item.MasterID = &master.ID
+		if item.Name == "" {
+			item.Name = master.Name
+		}
+		if item.UnitPrice == nil && master.Price != nil {
+			item.UnitPrice = master.Price
+			item.PriceFromMaster = true
+		}
+		if item.Category == "" {
+			item.Category = master.Category
+		}
+	}
+
+	if err := s.hospitalizationRepo.CreateCarePlanItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteCarePlanItem ケアプラン項目を削除
+func (s *Service) DeleteCarePlanItem(ctx context.Context, id, itemID string) error {
+	itemUID, err := uuid.Parse(itemID)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid care plan item ID format")
+	}
+	hospitalization, err := s.GetHospitalizationByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if hospitalization.Status == model.HospitalizationStatusDischarged {
+		return apperrors.WrapConflict("care plan of a discharged hospitalization cannot be modified")
+	}
+	return s.hospitalizationRepo.DeleteCarePlanItem(ctx, hospitalization.ID, itemUID)
+}
+
+// allocateCage 期間中に空いている互換ケージを優先順に探して確保する
+func (s *Service) allocateCage(ctx context.Context, species, minSize string, hospitalization *model.Hospitalization) (*model.Cage, error) {
+	candidates, err := s.compatibleCages(ctx, species, minSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range candidates {
+		cage := &candidates[i]
+		err := s.reserveCage(ctx, cage, hospitalization)
+		if err == nil {
+			return cage, nil
+		}
+		if !apperrors.IsConflict(err) {
+			return nil, err
+		}
+	}
+	return nil, apperrors.WrapConflict(fmt.Sprintf(
+		"no suitable cage is available from %s to %s",
+		hospitalization.StartDate.Format("2006-01-02"),
+		hospitalization.EndDate.Format("2006-01-02"),
+	))
+}
+
+// reserveCage ケージのロックを取得し、入院期間が他の入院と重ならないことを確認する
+func (s *Service) reserveCage(ctx context.Context, cage *model.Cage, hospitalization *model.Hospitalization) error {
+	if err := s.hospitalizationRepo.LockCage(ctx, cage.ID); err != nil {
+		return err
+	}
+
+	overlaps, err := s.hospitalizationRepo.FindOverlappingHospitalizations(ctx, cage.ID,
+		hospitalization.StartDate, stayEnd(hospitalization.StartDate, hospitalization.EndDate), hospitalization.ID)
+	if err != nil {
+		return err
+	}
+	if len(overlaps) > 0 {
+		conflict := overlaps[0]
+		return apperrors.WrapConflict(fmt.Sprintf(
+			"cage %s is already booked from %s to %s",
+			cage.ID.String(),
+			conflict.StartDate.Format("2006-01-02"),
+			conflict.EndDate.Format("2006-01-02"),
+		))
+	}
+	return nil
+}
+
+// syncCageAvailability ステータス・ケージの変更に合わせてケージの使用状況を更新する
+func (s *Service) syncCageAvailability(ctx context.Context, oldCageID *uuid.UUID, wasOccupying bool, h *model.Hospitalization) error {
+	occupying := occupiesCage(h.Status)
+	cageChanged := oldCageID != nil && h.CageID != nil && *oldCageID != *h.CageID
+
+	if oldCageID != nil && wasOccupying && (!occupying || cageChanged) {
+		if err := s.hospitalizationRepo.UpdateCageAvailability(ctx, *oldCageID, true); err != nil {
+			return err
+		}
+	}
+	if h.CageID != nil && occupying && (!wasOccupying || cageChanged) {
+		return s.hospitalizationRepo.UpdateCageAvailability(ctx, *h.CageID, false)
+	}
+	return nil
+}
+
+// compatibleCages ペットの種類・サイズに合うケージを割り当て優先順に取得
+// 優先順: 専用ケージ（犬用・猫用）→共用、小さいサイズ→大きいサイズ、コード順。
+func (s *Service) compatibleCages(ctx context.Context, species, minSize string) ([]model.Cage, error) {
+	types := cageTypesFor(species)
+	cages, err := s.hospitalizationRepo.FindCagesByTypes(ctx, types)
+	if err != nil {
+		return nil, err
+	}
+
+	result := cages[:0]
+	for _, cage := range cages {
+		if sizeAtLeast(cage.Size, minSize) {
+			result = append(result, cage)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		ti, tj := cageTypeRank(types, result[i].Type), cageTypeRank(types, result[j].Type)
+		if ti != tj {
+			return ti < tj
+		}
+		return sizeRank(result[i].Size) < sizeRank(result[j].Size)
+	})
+	return result, nil
+}
+
+// accountingFromCarePlan 単価が設定されたケアプラン項目から未収の会計を作成する
+// 実施予定のある項目は完了したケアログの回数、予定のない項目（入院料など）は入院泊数を数量とする。
+// マスタ指定の項目の単価・税率は退院日時点の価格履歴による。
+func (s *Service) accountingFromCarePlan(ctx context.Context, h *model.Hospitalization) (*model.Accounting, error) {
+	accounting := &model.Accounting{
+		HospitalizationID: &h.ID,
+		PetID:             h.PetID,
+		OwnerID:           h.OwnerID,
+		ScheduledDate:     h.EndDate,
+		Status:            model.AccountingStatusUnpaid,
+	}
+	if h.Pet != nil {
+		accounting.InsuranceName = h.Pet.InsuranceName
+	}
+
+	completed, err := s.completedCareCounts(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+	nights := stayNights(h.StartDate, h.EndDate)
+
+	for _, planItem := range h.CarePlanItems {
+		if planItem.UnitPrice == nil {
+			continue
+		}
+		quantity := nights
+		if !planItem.Timing.IsZero() {
+			quantity = completed[planItem.ID]
+		}
+		if quantity == 0 {
+			continue
+		}
+		item := model.AccountingItem{
+			MasterID:  planItem.MasterID,
+			Category:  planItem.Category,
+			Name:      planItem.Name,
+			UnitPrice: planItem.UnitPrice,
+			Quantity:  quantity,
+			TaxRate:   defaultTaxRate.Ptr(),
+			Source:    model.AccountingItemSourceHospitalization,
+		}
+		if planItem.MasterID != nil && s.masterItemRepo != nil {
+			master, err := s.masterItemRepo.GetMasterItemByID(ctx, *planItem.MasterID)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.applyEffectivePrices(ctx, h.EndDate, master); err != nil {
+				return nil, err
+			}
+			item.Code = master.Code
+			item.IsInsuranceApplicable = master.IsInsuranceApplicable
+			// マスタから補完した単価は退院日時点の価格に置き換え、個別に指定した単価はそのまま使う
+			if planItem.PriceFromMaster && master.Price != nil {
+				item.UnitPrice = master.Price
+			}
+			if master.TaxRate != nil {
+				item.TaxRate = master.TaxRate
+			}
+		}
+		accounting.AccountingItems = append(accounting.AccountingItems, item)
+	}
+
+	if err := calculateAccounting(accounting); err != nil {
+		return nil, err
+	}
+	if err := s.accountingRepo.CreateAccounting(ctx, accounting); err != nil {
+		return nil, err
+	}
+	return accounting, nil
+}
+
+// completedCareCounts ケアプラン項目ごとの完了したケアログの回数
+func (s *Service) completedCareCounts(ctx context.Context, h *model.Hospitalization) (map[uuid.UUID]int, error) {
+	counts := map[uuid.UUID]int{}
+	scheduled := false
+	for _, planItem := range h.CarePlanItems {
+		if planItem.UnitPrice != nil && !planItem.Timing.IsZero() {
+			scheduled = true
+			break
+		}
+	}
+	if !scheduled {
+		return counts, nil
+	}
+
+	records, err := s.dailyRecordRepo.GetDailyRecords(ctx, h.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		for _, log := range record.CareLogs {
+			if log.CarePlanItemID != nil && log.Status == model.CareLogStatusCompleted {
+				counts[*log.CarePlanItemID]++
+			}
+		}
+	}
+	return counts, nil
+}
+
+// cageTypesFor ペットの種類に使えるケージ種別（優先順）
+// 種類が未指定なら全種別、犬・猫以外は共用ケージのみ。
+func cageTypesFor(species string) []string {
+	switch model.SpeciesKind(species) {
+	case "":
+		return nil
+	case model.SpeciesDog:
+		return []string{model.CageTypeDog, model.CageTypeShared}
+	case model.SpeciesCat:
+		return []string{model.CageTypeCat, model.CageTypeShared}
+	default:
+		return []string{model.CageTypeShared}
+	}
+}
+
+// cageCompatible ケージがペットの種類・サイズに合うかどうか
+func cageCompatible(cage *model.Cage, species, minSize string) bool {
+	types := cageTypesFor(species)
+	if len(types) > 0 && cageTypeRank(types, cage.Type) == len(types) {
+		return false
+	}
+	return sizeAtLeast(cage.Size, minSize)
+}
+
+// cageTypeRank 種別の優先順位（typesに含まれない場合はlen(types)）
+func cageTypeRank(types []string, cageType string) int {
+	for i, t := range types {
+		if t == cageType {
+			return i
+		}
+	}
+	return len(types)
+}
+
+// sizeRank ケージサイズの順位（不明なサイズは最大より後ろ）
+func sizeRank(size string) int {
+	for i, s := range model.CageSizes {
+		if s == size {
+			return i
+		}
+	}
+	return len(model.CageSizes)
+}
+
+// sizeAtLeast ケージサイズが最小サイズ以上かどうか（最小サイズ未指定なら常にtrue）
+func sizeAtLeast(size, minSize string) bool {
+	if minSize == "" {
+		return true
+	}
+	r := sizeRank(size)
+	return r < len(model.CageSizes) && r >= sizeRank(minSize)
+}
+
+// occupiesCage ケージを使用中にするステータスかどうか（一時帰宅中もケージは確保したままにする）
+func occupiesCage(status string) bool {
+	return status == model.HospitalizationStatusAdmitted || status == model.HospitalizationStatusTemporaryLeave
+}
+
+// parseStayPeriod 開始日・終了日（YYYY-MM-DD）をパースし、終了日が開始日以降であることを確認する
+func parseStayPeriod(startDate, endDate string) (start, end time.Time, err error) {
+	start, err = parseDateOnly(startDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("invalid start date format")
+	}
+	end, err = parseDateOnly(endDate)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("invalid end date format")
+	}
+	if end.Before(start) {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("end date must not be before start date")
+	}
+	return start, end, nil
+}
+
+// stayEnd 重複判定に使う期間の終端（日帰りは翌日までの1日として扱う）
+func stayEnd(start, end time.Time) time.Time {
+	if end.After(start) {
+		return end
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// stayNights 入院泊数（日帰りは1泊として数える）
+func stayNights(start, end time.Time) int {
+	return int(stayEnd(start, end).Sub(start).Hours() / 24)
+}
+
+// parseDateOnly YYYY-MM-DD形式の日付をパースする（date型カラム用にUTCの0時とする）
+func parseDateOnly(s string) (time.Time, error) {
+	return time.Parse("2006-01-02", s)
+}
+
+// today 今日の日付（date型カラムとの比較用にUTCの0時とする）
+func today() time.Time {
+	y, m, d := time.Now().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// valueOrZeroSchedule 実施予定が未指定なら予定なしとする
+func valueOrZeroSchedule(schedule *model.CareSchedule) model.CareSchedule {
+	if schedule == nil {
+		return model.CareSchedule{}
+	}
+	return *schedule
+}
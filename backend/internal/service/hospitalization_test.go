@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockHospitalizationRepository is a mock implementation of repository.HospitalizationRepository
+type MockHospitalizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockHospitalizationRepository) EnsureHospitalizationNoSequence(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) NextHospitalizationNo(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) ListHospitalizations(ctx context.Context, filter model.HospitalizationFilter, opts model.ListOptions) (*model.ListResult[model.Hospitalization], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Hospitalization]), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) GetHospitalizationByID(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hospitalization), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) GetHospitalizationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Hospitalization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Hospitalization), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) CreateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error {
+	args := m.Called(ctx, hospitalization)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) UpdateHospitalization(ctx context.Context, hospitalization *model.Hospitalization) error {
+	args := m.Called(ctx, hospitalization)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) DeleteHospitalization(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) FindOverlappingHospitalizations(ctx context.Context, cageID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Hospitalization, error) {
+	args := m.Called(ctx, cageID, start, end, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Hospitalization), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) FindHospitalizationsOnDate(ctx context.Context, date time.Time) ([]model.Hospitalization, error) {
+	args := m.Called(ctx, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Hospitalization), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) GetAllCages(ctx context.Context) ([]model.Cage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Cage), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) GetCageByID(ctx context.Context, id uuid.UUID) (*model.Cage, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Cage), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) FindCagesByTypes(ctx context.Context, types []string) ([]model.Cage, error) {
+	args := m.Called(ctx, types)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Cage), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) LockCage(ctx context.Context, cageID uuid.UUID) error {
+	args := m.Called(ctx, cageID)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) UpdateCageAvailability(ctx context.Context, cageID uuid.UUID, available bool) error {
+	args := m.Called(ctx, cageID, available)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) GetCarePlanItems(ctx context.Context, hospitalizationID uuid.UUID) ([]model.CarePlanItem, error) {
+	args := m.Called(ctx, hospitalizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.CarePlanItem), args.Error(1)
+}
+
+func (m *MockHospitalizationRepository) CreateCarePlanItem(ctx context.Context, item *model.CarePlanItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockHospitalizationRepository) DeleteCarePlanItem(ctx context.Context, hospitalizationID, itemID uuid.UUID) error {
+	args := m.Called(ctx, hospitalizationID, itemID)
+	return args.Error(0)
+}
+
+func TestAdmitHospitalization(t *testing.T) {
+	ctx := context.Background()
+	petID := uuid.New()
+	ownerID := uuid.New()
+	dog := &model.Pet{ID: petID, OwnerID: ownerID, Species: "犬"}
+
+	sharedS := model.Cage{ID: uuid.New(), Code: "C01", Type: model.CageTypeShared, Size: "S"}
+	dogM := model.Cage{ID: uuid.New(), Code: "D01", Type: model.CageTypeDog, Size: "M"}
+	dogL := model.Cage{ID: uuid.New(), Code: "D02", Type: model.CageTypeDog, Size: "L"}
+	sharedL := model.Cage{ID: uuid.New(), Code: "C02", Type: model.CageTypeShared, Size: "L"}
+	dogTypes := []string{model.CageTypeDog, model.CageTypeShared}
+
+	request := func() *model.CreateHospitalizationRequest {
+		return &model.CreateHospitalizationRequest{
+			PetID:     petID.String(),
+			StartDate: "2030-04-01",
+			EndDate:   "2030-04-05",
+			CageSize:  "M",
+		}
+	}
+
+	t.Run("allocates the smallest free cage of the pet's type", func(t *testing.T) {
+		mockPetRepo := new(MockPetRepository)
+		mockRepo := new(MockHospitalizationRepository)
+		tx := &fakeTransactor{}
+		svc := New(mockPetRepo, nil, nil, nil, WithHospitalizationRepository(mockRepo), WithTransactor(tx))
+
+		mockPetRepo.On("GetPetByID", ctx, petID).Return(dog, nil)
+		mockRepo.On("NextHospitalizationNo", ctx).Return("HP00000042", nil)
+		mockRepo.On("FindCagesByTypes", ctx, dogTypes).
+			Return([]model.Cage{sharedL, sharedS, dogL, dogM}, nil)
+		mockRepo.On("LockCage", ctx, mock.Anything).Return(nil)
+		// D01 は期間が重なる入院があるため、次点の D02 を割り当てる
+		mockRepo.On("FindOverlappingHospitalizations", ctx, dogM.ID, mock.Anything, mock.Anything, uuid.Nil).
+			Return([]model.Hospitalization{{StartDate: time.Date(2030, 4, 3, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2030, 4, 8, 0, 0, 0, 0, time.UTC)}}, nil)
+		mockRepo.On("FindOverlappingHospitalizations", ctx, dogL.ID, mock.Anything, mock.Anything, uuid.Nil).
+			Return([]model.Hospitalization{}, nil)
+		var created *model.Hospitalization
+		mockRepo.On("CreateHospitalization", ctx, mock.AnythingOfType("*model.Hospitalization")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*model.Hospitalization) }).
+			Return(nil)
+		mockRepo.On("GetHospitalizationByID", ctx, mock.Anything).Return(&model.Hospitalization{CageID: &dogL.ID}, nil)
+
+		_, err := svc.AdmitHospitalization(ctx, request())
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, "HP00000042", created.HospitalizationNo)
+		assert.Equal(t, dogL.ID, *created.CageID)
+		assert.Equal(t, ownerID, created.OwnerID)
+		assert.Equal(t, model.HospitalizationTypeInpatient, created.Type)
+		// 開始日が未来なので予約として受け付け、ケージの使用状況は変えない
+		assert.Equal(t, model.HospitalizationStatusReserved, created.Status)
+		mockRepo.AssertNotCalled(t, "FindOverlappingHospitalizations", ctx, sharedS.ID, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateCageAvailability", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns conflict when no suitable cage is free", func(t *testing.T) {
+		mockPetRepo := new(MockPetRepository)
+		mockRepo := new(MockHospitalizationRepository)
+		svc := New(mockPetRepo, nil, nil, nil, WithHospitalizationRepository(mockRepo))
+
+		mockPetRepo.On("GetPetByID", ctx, petID).Return(dog, nil)
+		mockRepo.On("NextHospitalizationNo", ctx).Return("HP00000042", nil)
+		mockRepo.On("FindCagesByTypes", ctx, dogTypes).Return([]model.Cage{dogM}, nil)
+		mockRepo.On("LockCage", ctx, dogM.ID).Return(nil)
+		mockRepo.On("FindOverlappingHospitalizations", ctx, dogM.ID, mock.Anything, mock.Anything, uuid.Nil).
+			Return([]model.Hospitalization{{ID: uuid.New()}}, nil)
+
+		_, err := svc.AdmitHospitalization(ctx, request())
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateHospitalization", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects explicit cage for another species", func(t *testing.T) {
+		mockPetRepo := new(MockPetRepository)
+		mockRepo := new(MockHospitalizationRepository)
+		svc := New(mockPetRepo, nil, nil, nil, WithHospitalizationRepository(mockRepo))
+
+		catCage := &model.Cage{ID: uuid.New(), Code: "N01", Type: model.CageTypeCat, Size: "M"}
+		mockPetRepo.On("GetPetByID", ctx, petID).Return(dog, nil)
+		mockRepo.On("NextHospitalizationNo", ctx).Return("HP00000042", nil)
+		mockRepo.On("GetCageByID", ctx, catCage.ID).Return(catCage, nil)
+
+		req := request()
+		req.CageID = catCage.ID.String()
+		_, err := svc.AdmitHospitalization(ctx, req)
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockRepo.AssertNotCalled(t, "LockCage", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects end date before start date", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil)
+
+		req := request()
+		req.EndDate = "2030-03-31"
+		_, err := svc.AdmitHospitalization(ctx, req)
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestDischargeHospitalization(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	cageID := uuid.New()
+	feeID, dripID := uuid.New(), uuid.New()
+	dripMaster := &model.MasterItem{ID: uuid.New(), Code: "T-010", Name: "点滴", Price: dec("3300"), TaxRate: dec("0.10")}
+
+	admitted := func() *model.Hospitalization {
+		return &model.Hospitalization{
+			ID:        id,
+			PetID:     uuid.New(),
+			OwnerID:   uuid.New(),
+			CageID:    &cageID,
+			StartDate: time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2030, 4, 5, 0, 0, 0, 0, time.UTC),
+			Status:    model.HospitalizationStatusAdmitted,
+			Pet:       &model.Pet{InsuranceName: "どうぶつ保険"},
+			CarePlanItems: []model.CarePlanItem{
+				{ID: feeID, Name: "入院料", UnitPrice: dec("3300"), Category: "入院"},
+				{ID: dripID, MasterID: &dripMaster.ID, Name: "点滴", UnitPrice: dec("3300"), PriceFromMaster: true, Category: "処置",
+					Timing: model.CareSchedule{Times: []string{"08:00", "18:00"}}},
+				{Name: "散歩", Type: "instruction"},
+			},
+		}
+	}
+
+	t.Run("frees the cage and creates accounting from the care plan", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, nil, nil,
+			WithHospitalizationRepository(mockRepo), WithAccountingRepository(mockAccountingRepo),
+			WithDailyRecordRepository(mockDailyRepo), WithMasterItemRepository(mockMasterRepo), WithTransactor(tx))
+
+		mockRepo.On("GetHospitalizationByIDForUpdate", ctx, id).Return(admitted(), nil)
+		completed := model.CareLog{CarePlanItemID: &dripID, Status: model.CareLogStatusCompleted}
+		skipped := model.CareLog{CarePlanItemID: &dripID, Status: model.CareLogStatusSkipped}
+		mockDailyRepo.On("GetDailyRecords", ctx, id).Return([]model.DailyRecord{
+			{CareLogs: []model.CareLog{completed, completed}},
+			{CareLogs: []model.CareLog{completed, skipped}},
+		}, nil)
+		mockMasterRepo.On("GetMasterItemByID", ctx, dripMaster.ID).Return(dripMaster, nil)
+		// 退院日（4月4日）時点の価格は改定後の3,000円
+		dischargeDay := time.Date(2030, 4, 4, 0, 0, 0, 0, time.UTC)
+		mockMasterRepo.On("GetEffectivePrices", ctx, []uuid.UUID{dripMaster.ID}, dischargeDay).Return([]model.MasterItemPrice{
+			{MasterItemID: dripMaster.ID, Price: decimal.MustParse("3000"), TaxRate: dec("0.10")},
+		}, nil)
+		mockRepo.On("UpdateHospitalization", ctx, mock.AnythingOfType("*model.Hospitalization")).Return(nil)
+		mockRepo.On("UpdateCageAvailability", ctx, cageID, true).Return(nil)
+		mockAccountingRepo.On("CreateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).Return(nil)
+
+		result, err := svc.DischargeHospitalization(ctx, id.String(), &model.DischargeHospitalizationRequest{
+			DischargeDate:    "2030-04-04",
+			CreateAccounting: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, model.HospitalizationStatusDischarged, result.Hospitalization.Status)
+		assert.Equal(t, "2030-04-04", result.Hospitalization.EndDate.Format("2006-01-02"))
+		assert.NotNil(t, result.Hospitalization.DischargedAt)
+
+		accounting := result.Accounting
+		assert.Equal(t, id, *accounting.HospitalizationID)
+		assert.Equal(t, "どうぶつ保険", accounting.InsuranceName)
+		assert.Len(t, accounting.AccountingItems, 2)
+		assert.Equal(t, model.AccountingItemSourceHospitalization, accounting.AccountingItems[0].Source)
+		// 入院料は3泊、点滴は完了した3回分
+		assert.Equal(t, 3, accounting.AccountingItems[0].Quantity)
+		assert.Equal(t, 3, accounting.AccountingItems[1].Quantity)
+		assert.Equal(t, "T-010", accounting.AccountingItems[1].Code)
+		assert.Equal(t, "3000", accounting.AccountingItems[1].UnitPrice.String())
+		// 3,300×3 + 3,000×3 = 18,900、消費税10% 1,890
+		assert.Equal(t, "18900", accounting.Subtotal.String())
+		assert.Equal(t, "20790", accounting.BillingAmount.String())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("keeps a care plan price set by hand even if it matches the master", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil,
+			WithHospitalizationRepository(mockRepo), WithAccountingRepository(mockAccountingRepo),
+			WithDailyRecordRepository(mockDailyRepo), WithMasterItemRepository(mockMasterRepo))
+
+		manual := admitted()
+		manual.CarePlanItems[1].PriceFromMaster = false
+		mockRepo.On("GetHospitalizationByIDForUpdate", ctx, id).Return(manual, nil)
+		completed := model.CareLog{CarePlanItemID: &dripID, Status: model.CareLogStatusCompleted}
+		mockDailyRepo.On("GetDailyRecords", ctx, id).Return([]model.DailyRecord{{CareLogs: []model.CareLog{completed}}}, nil)
+		mockMasterRepo.On("GetMasterItemByID", ctx, dripMaster.ID).Return(dripMaster, nil)
+		mockMasterRepo.On("GetEffectivePrices", ctx, []uuid.UUID{dripMaster.ID}, mock.Anything).Return([]model.MasterItemPrice{
+			{MasterItemID: dripMaster.ID, Price: decimal.MustParse("3000"), TaxRate: dec("0.10")},
+		}, nil)
+		mockRepo.On("UpdateHospitalization", ctx, mock.AnythingOfType("*model.Hospitalization")).Return(nil)
+		mockRepo.On("UpdateCageAvailability", ctx, cageID, true).Return(nil)
+		mockAccountingRepo.On("CreateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).Return(nil)
+
+		result, err := svc.DischargeHospitalization(ctx, id.String(), &model.DischargeHospitalizationRequest{
+			DischargeDate:    "2030-04-04",
+			CreateAccounting: true,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "3300", result.Accounting.AccountingItems[1].UnitPrice.String())
+	})
+
+	t.Run("rejects reserved hospitalization", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo))
+
+		reserved := admitted()
+		reserved.Status = model.HospitalizationStatusReserved
+		mockRepo.On("GetHospitalizationByIDForUpdate", ctx, id).Return(reserved, nil)
+
+		_, err := svc.DischargeHospitalization(ctx, id.String(), &model.DischargeHospitalizationRequest{})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "UpdateCageAvailability", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects discharge date before start date", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo))
+
+		mockRepo.On("GetHospitalizationByIDForUpdate", ctx, id).Return(admitted(), nil)
+
+		_, err := svc.DischargeHospitalization(ctx, id.String(), &model.DischargeHospitalizationRequest{DischargeDate: "2030-03-31"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
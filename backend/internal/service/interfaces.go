@@ -8,7 +8,7 @@ import (
 
 // PetService defines the interface for pet business logic.
 type PetService interface {
-	GetAllPets(ctx context.Context) ([]model.Pet, error)
+	ListPets(ctx context.Context, req *model.ListPetsRequest) (*model.ListResult[model.Pet], error)
 	GetPetByID(ctx context.Context, id string) (*model.Pet, error)
 	CreatePet(ctx context.Context, req *model.CreatePetRequest) (*model.Pet, error)
 	UpdatePet(ctx context.Context, id string, req *model.UpdatePetRequest) (*model.Pet, error)
@@ -17,7 +17,7 @@ type PetService interface {
 
 // OwnerService defines the interface for owner business logic.
 type OwnerService interface {
-	GetAllOwners(ctx context.Context) ([]model.Owner, error)
+	ListOwners(ctx context.Context, req *model.ListOwnersRequest) (*model.ListResult[model.Owner], error)
 	GetOwnerByID(ctx context.Context, id string) (*model.Owner, error)
 	CreateOwner(ctx context.Context, req *model.CreateOwnerRequest) (*model.Owner, error)
 	UpdateOwner(ctx context.Context, id string, req *model.UpdateOwnerRequest) (*model.Owner, error)
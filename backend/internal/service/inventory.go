@@ -0,0 +1,605 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// InventoryService 在庫サービスインターフェース
+type InventoryService interface {
+	GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error)
+	GetInventoryItemByID(ctx context.Context, id string) (*model.InventoryItem, error)
+	CreateInventoryItem(ctx context.Context, req *model.CreateInventoryItemRequest) (*model.InventoryItem, error)
+	UpdateInventoryItem(ctx context.Context, id string, req *model.UpdateInventoryItemRequest) (*model.InventoryItem, error)
+	GetLowStockItems(ctx context.Context) ([]model.InventoryItem, error)
+	GetStockMovements(ctx context.Context, id string) ([]model.StockMovement, error)
+	RecordStockMovement(ctx context.Context, id string, req *model.CreateStockMovementRequest) (*model.StockMovementResult, error)
+	GetInventoryLots(ctx context.Context, id string) ([]model.InventoryLot, error)
+	GetExpiringLots(ctx context.Context, req *model.ExpiringLotsRequest) ([]model.InventoryLot, error)
+	WriteOffLot(ctx context.Context, lotID string, req *model.WriteOffLotRequest) (*model.StockMovementResult, error)
+	WriteOffExpiredLots(ctx context.Context) ([]model.StockMovement, error)
+}
+
+// Ensure Service implements InventoryService
+var _ InventoryService = (*Service)(nil)
+
+// GetAllInventoryItems 全ての在庫品目を取得
+func (s *Service) GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error) {
+	return s.inventoryRepo.GetAllInventoryItems(ctx)
+}
+
+// GetInventoryItemByID IDで在庫品目を取得
+func (s *Service) GetInventoryItemByID(ctx context.Context, id string) (*model.InventoryItem, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid inventory item ID format")
+	}
+	return s.inventoryRepo.GetInventoryItemByID(ctx, uid)
+}
+
+// CreateInventoryItem 在庫品目を登録する（初期在庫数は入庫として記録する）
+func (s *Service) CreateInventoryItem(ctx context.Context, req *model.CreateInventoryItemRequest) (*model.InventoryItem, error) {
+	if err := validation.ValidateCreateInventoryItem(req); err != nil {
+		return nil, err
+	}
+
+	item := &model.InventoryItem{
+		Name:          req.Name,
+		Category:      req.Category,
+		Unit:          req.Unit,
+		MinStockLevel: req.MinStockLevel,
+		Location:      req.Location,
+		Supplier:      req.Supplier,
+		Status:        inventoryStatus(0, req.MinStockLevel),
+	}
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.inventoryRepo.CreateInventoryItem(ctx, item); err != nil {
+			return err
+		}
+		if req.Quantity == 0 {
+			return nil
+		}
+		if _, err := s.receiveStock(ctx, item, req.Quantity, req.LotNumber, req.ExpiryDate, "初期在庫"); err != nil {
+			return err
+		}
+		return s.saveInventoryItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateInventoryItem 在庫品目を更新する（最低在庫数の変更に合わせてステータスを再計算する）
+func (s *Service) UpdateInventoryItem(ctx context.Context, id string, req *model.UpdateInventoryItemRequest) (*model.InventoryItem, error) {
+	if err := validation.ValidateUpdateInventoryItem(req); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid inventory item ID format")
+	}
+
+	var item *model.InventoryItem
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		item, err = s.lockInventoryItem(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if req.Name != nil {
+			item.Name = *req.Name
+		}
+		if req.Category != nil {
+			item.Category = *req.Category
+		}
+		if req.Unit != nil {
+			item.Unit = *req.Unit
+		}
+		if req.MinStockLevel != nil {
+			item.MinStockLevel = *req.MinStockLevel
+		}
+		if req.Location != nil {
+			item.Location = *req.Location
+		}
+		if req.Supplier != nil {
+			item.Supplier = *req.Supplier
+		}
+		item.Status = inventoryStatus(item.Quantity, item.MinStockLevel)
+		return s.inventoryRepo.UpdateInventoryItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// GetLowStockItems 在庫数が最低在庫数以下の品目を取得
+func (s *Service) GetLowStockItems(ctx context.Context) ([]model.InventoryItem, error) {
+	return s.inventoryRepo.FindLowStockItems(ctx)
+}
+
+// GetStockMovements 在庫品目の入出庫履歴を取得
+func (s *Service) GetStockMovements(ctx context.Context, id string) ([]model.StockMovement, error) {
+	item, err := s.GetInventoryItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.GetStockMovements(ctx, item.ID)
+}
+
+// RecordStockMovement 入庫・払出・棚卸調整・期限切れ廃棄を記録し、在庫数とステータスを更新する
+// ロットを指定しない払出は期限切れでないロットを使用期限の近い順に使い、ロットごとに記録する。
+// 手動の払出・廃棄・調整で在庫数が負になる場合は登録できない。
+func (s *Service) RecordStockMovement(ctx context.Context, id string, req *model.CreateStockMovementRequest) (*model.StockMovementResult, error) {
+	if err := validation.ValidateCreateStockMovement(req); err != nil {
+		return nil, err
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid inventory item ID format")
+	}
+
+	result := &model.StockMovementResult{}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		item, err := s.lockInventoryItem(ctx, uid)
+		if err != nil {
+			return err
+		}
+		result.Item = item
+
+		if req.Type == model.StockMovementReceive {
+			movement, err := s.receiveStock(ctx, item, req.Quantity, req.LotNumber, req.ExpiryDate, req.Reason)
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, *movement)
+			return s.saveInventoryItem(ctx, item)
+		}
+
+		lots, err := s.inventoryRepo.GetLots(ctx, item.ID, true)
+		if err != nil {
+			return err
+		}
+		delta := stockDelta(req.Type, req.Quantity)
+
+		var allocations []lotAllocation
+		switch {
+		case req.LotID != "":
+			lot, err := s.inventoryLot(ctx, item, uuid.MustParse(req.LotID))
+			if err != nil {
+				return err
+			}
+			if lot.Quantity+delta < 0 {
+				return apperrors.WrapConflict(fmt.Sprintf("insufficient stock in lot %s (on hand %d)", lot.LotNumber, lot.Quantity))
+			}
+			allocations = []lotAllocation{{lot: lot, quantity: delta}}
+		case req.Type == model.StockMovementDispense:
+			var shortage int
+			allocations, shortage = allocateFEFO(lots, unlottedQuantity(item, lots), req.Quantity, today())
+			if shortage > 0 {
+				return apperrors.WrapConflict(fmt.Sprintf("insufficient usable stock for %s (short by %d)", item.Name, shortage))
+			}
+		default:
+			if unlottedQuantity(item, lots)+delta < 0 {
+				return apperrors.WrapConflict(fmt.Sprintf("insufficient stock outside lots for %s (specify lot_id)", item.Name))
+			}
+			allocations = []lotAllocation{{quantity: delta}}
+		}
+
+		for _, a := range allocations {
+			movement, err := s.moveStock(ctx, item, a.lot, &model.StockMovement{
+				Type:     req.Type,
+				Quantity: a.quantity,
+				Reason:   req.Reason,
+			})
+			if err != nil {
+				return err
+			}
+			result.Movements = append(result.Movements, *movement)
+		}
+		return s.saveInventoryItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetInventoryLots 在庫品目のロットを使用期限の近い順に取得（在庫のないロットも含む）
+func (s *Service) GetInventoryLots(ctx context.Context, id string) ([]model.InventoryLot, error) {
+	item, err := s.GetInventoryItemByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.GetLots(ctx, item.ID, false)
+}
+
+// GetExpiringLots 今日からwithin日以内に使用期限を迎える在庫のあるロットを取得（期限切れを含む）
+func (s *Service) GetExpiringLots(ctx context.Context, req *model.ExpiringLotsRequest) ([]model.InventoryLot, error) {
+	days, err := parseWithinDays(req.Within)
+	if err != nil {
+		return nil, err
+	}
+	return s.inventoryRepo.FindExpiringLots(ctx, today().AddDate(0, 0, days))
+}
+
+// WriteOffLot ロットの残数をすべて廃棄する
+func (s *Service) WriteOffLot(ctx context.Context, lotID string, req *model.WriteOffLotRequest) (*model.StockMovementResult, error) {
+	uid, err := uuid.Parse(lotID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid lot ID format")
+	}
+	lot, err := s.inventoryRepo.GetLotByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.StockMovementResult{}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		item, err := s.lockInventoryItem(ctx, lot.InventoryItemID)
+		if err != nil {
+			return err
+		}
+		// 品目のロック後に最新の残数を読み直す
+		lot, err := s.inventoryLot(ctx, item, uid)
+		if err != nil {
+			return err
+		}
+		if lot.Quantity <= 0 {
+			return apperrors.WrapConflict("lot " + lot.LotNumber + " has no stock to write off")
+		}
+
+		movement, err := s.moveStock(ctx, item, lot, &model.StockMovement{
+			Type:     model.StockMovementExpire,
+			Quantity: -lot.Quantity,
+			Reason:   writeOffReason(req.Reason, lot),
+		})
+		if err != nil {
+			return err
+		}
+		result.Item = item
+		result.Movements = []model.StockMovement{*movement}
+		return s.saveInventoryItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// WriteOffExpiredLots 使用期限を過ぎた在庫のあるロットをすべて廃棄する
+func (s *Service) WriteOffExpiredLots(ctx context.Context) ([]model.StockMovement, error) {
+	yesterday := today().AddDate(0, 0, -1)
+	movements := []model.StockMovement{}
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		expired, err := s.inventoryRepo.FindExpiringLots(ctx, yesterday)
+		if err != nil {
+			return err
+		}
+		var itemIDs []uuid.UUID
+		for _, lot := range expired {
+			if !slices.Contains(itemIDs, lot.InventoryItemID) {
+				itemIDs = append(itemIDs, lot.InventoryItemID)
+			}
+		}
+		if len(itemIDs) == 0 {
+			return nil
+		}
+
+		locked, err := s.inventoryRepo.GetInventoryItemsForUpdate(ctx, itemIDs)
+		if err != nil {
+			return err
+		}
+		items := map[uuid.UUID]*model.InventoryItem{}
+		for i := range locked {
+			items[locked[i].ID] = &locked[i]
+		}
+		// 品目のロック後に最新の残数を読み直す
+		expired, err = s.inventoryRepo.FindExpiringLots(ctx, yesterday)
+		if err != nil {
+			return err
+		}
+		for i := range expired {
+			lot := &expired[i]
+			item, ok := items[lot.InventoryItemID]
+			if !ok {
+				continue
+			}
+			movement, err := s.moveStock(ctx, item, lot, &model.StockMovement{
+				Type:     model.StockMovementExpire,
+				Quantity: -lot.Quantity,
+				Reason:   writeOffReason("", lot),
+			})
+			if err != nil {
+				return err
+			}
+			movements = append(movements, *movement)
+		}
+		for _, item := range locked {
+			if err := s.saveInventoryItem(ctx, items[item.ID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return movements, nil
+}
+
+// dispenseAccountingItems 会計明細のうち在庫品目に紐づくマスタ項目を払い出す
+// トランザクション内で呼び出すこと。使用期限の近いロットから払い出し（FEFO）、
+// 会計の入金を止めないよう在庫不足でも払い出すため、在庫数は負になりうる。
+func (s *Service) dispenseAccountingItems(ctx context.Context, items []model.AccountingItem) error {
+	var masterIDs []uuid.UUID
+	for _, item := range items {
+		if item.MasterID != nil && item.Quantity > 0 {
+			masterIDs = append(masterIDs, *item.MasterID)
+		}
+	}
+	if len(masterIDs) == 0 {
+		return nil
+	}
+
+	masters, err := s.masterItemRepo.GetMasterItemsByIDs(ctx, masterIDs)
+	if err != nil {
+		return err
+	}
+	inventoryOf := map[uuid.UUID]uuid.UUID{}
+	var inventoryIDs []uuid.UUID
+	for _, master := range masters {
+		if master.InventoryID == nil {
+			continue
+		}
+		inventoryOf[master.ID] = *master.InventoryID
+		if !slices.Contains(inventoryIDs, *master.InventoryID) {
+			inventoryIDs = append(inventoryIDs, *master.InventoryID)
+		}
+	}
+	if len(inventoryIDs) == 0 {
+		return nil
+	}
+
+	locked, err := s.inventoryRepo.GetInventoryItemsForUpdate(ctx, inventoryIDs)
+	if err != nil {
+		return err
+	}
+	stock := map[uuid.UUID]*model.InventoryItem{}
+	lotsOf := map[uuid.UUID][]model.InventoryLot{}
+	for i := range locked {
+		stock[locked[i].ID] = &locked[i]
+		lots, err := s.inventoryRepo.GetLots(ctx, locked[i].ID, true)
+		if err != nil {
+			return err
+		}
+		lotsOf[locked[i].ID] = lots
+	}
+
+	day := today()
+	for _, item := range items {
+		if item.MasterID == nil || item.Quantity <= 0 {
+			continue
+		}
+		inventory, ok := stock[inventoryOf[*item.MasterID]]
+		if !ok {
+			continue
+		}
+		lots := lotsOf[inventory.ID]
+		allocations, shortage := allocateFEFO(lots, unlottedQuantity(inventory, lots), item.Quantity, day)
+		if shortage > 0 {
+			slog.WarnContext(ctx, "dispensing more than usable stock on hand",
+				slog.String("inventory_item_id", inventory.ID.String()),
+				slog.Int("quantity", item.Quantity),
+				slog.Int("shortage", shortage),
+			)
+			if n := len(allocations); n > 0 && allocations[n-1].lot == nil {
+				allocations[n-1].quantity -= shortage
+			} else {
+				allocations = append(allocations, lotAllocation{quantity: -shortage})
+			}
+		}
+
+		itemID := item.ID
+		for _, a := range allocations {
+			if _, err := s.moveStock(ctx, inventory, a.lot, &model.StockMovement{
+				Type:             model.StockMovementDispense,
+				Quantity:         a.quantity,
+				AccountingItemID: &itemID,
+				Reason:           item.Name,
+			}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, inventory := range locked {
+		if err := s.saveInventoryItem(ctx, stock[inventory.ID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// receiveStock ロットに入庫する（同じロット番号のロットがなければ作成する）
+func (s *Service) receiveStock(ctx context.Context, item *model.InventoryItem, quantity int, lotNumber, expiryDate, reason string) (*model.StockMovement, error) {
+	var expiry *time.Time
+	if expiryDate != "" {
+		d, _ := parseDateOnly(expiryDate)
+		expiry = &d
+	}
+
+	lot, err := s.inventoryRepo.FindLotByNumber(ctx, item.ID, lotNumber)
+	switch {
+	case apperrors.IsNotFound(err):
+		lot = &model.InventoryLot{
+			InventoryItemID: item.ID,
+			LotNumber:       lotNumber,
+			ExpiryDate:      expiry,
+			ReceivedAt:      today(),
+		}
+		if err := s.inventoryRepo.CreateLot(ctx, lot); err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case expiry != nil && lot.ExpiryDate != nil && !lot.ExpiryDate.Equal(*expiry):
+		return nil, apperrors.WrapInvalidInput(fmt.Sprintf("lot %s is already registered with expiry date %s", lotNumber, lot.ExpiryDate.Format("2006-01-02")))
+	case expiry != nil:
+		lot.ExpiryDate = expiry
+	}
+
+	return s.moveStock(ctx, item, lot, &model.StockMovement{
+		Type:     model.StockMovementReceive,
+		Quantity: quantity,
+		Reason:   reason,
+	})
+}
+
+// moveStock 入出庫をロット（nilならロット外の在庫）と在庫品目に反映し、履歴を保存する
+// 在庫品目の保存はsaveInventoryItemで行う。
+func (s *Service) moveStock(ctx context.Context, item *model.InventoryItem, lot *model.InventoryLot, movement *model.StockMovement) (*model.StockMovement, error) {
+	if lot != nil {
+		lot.Quantity += movement.Quantity
+		if err := s.inventoryRepo.UpdateLot(ctx, lot); err != nil {
+			return nil, err
+		}
+		movement.LotID = &lot.ID
+		movement.LotNumber = lot.LotNumber
+	}
+
+	item.Quantity += movement.Quantity
+	if movement.Type == model.StockMovementReceive {
+		restocked := today()
+		item.LastRestocked = &restocked
+	}
+
+	movement.InventoryItemID = item.ID
+	movement.QuantityAfter = item.Quantity
+	movement.StaffID = currentStaffID(ctx)
+	movement.CreatedAt = time.Now()
+	if err := s.inventoryRepo.CreateStockMovement(ctx, movement); err != nil {
+		return nil, err
+	}
+	return movement, nil
+}
+
+// saveInventoryItem 在庫ステータスと使用期限（在庫のあるロットの最も近い期限）を再計算して保存する
+func (s *Service) saveInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	lots, err := s.inventoryRepo.GetLots(ctx, item.ID, true)
+	if err != nil {
+		return err
+	}
+	item.ExpiryDate = nil
+	for _, lot := range lots {
+		if lot.ExpiryDate != nil && (item.ExpiryDate == nil || lot.ExpiryDate.Before(*item.ExpiryDate)) {
+			item.ExpiryDate = lot.ExpiryDate
+		}
+	}
+	item.Status = inventoryStatus(item.Quantity, item.MinStockLevel)
+	return s.inventoryRepo.UpdateInventoryItem(ctx, item)
+}
+
+// lockInventoryItem 在庫品目を行ロックして取得する
+func (s *Service) lockInventoryItem(ctx context.Context, id uuid.UUID) (*model.InventoryItem, error) {
+	items, err := s.inventoryRepo.GetInventoryItemsForUpdate(ctx, []uuid.UUID{id})
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, apperrors.WrapNotFound("inventory_item", id.String())
+	}
+	return &items[0], nil
+}
+
+// inventoryLot 在庫品目のロットを取得する（他の品目のロットは指定できない）
+func (s *Service) inventoryLot(ctx context.Context, item *model.InventoryItem, lotID uuid.UUID) (*model.InventoryLot, error) {
+	lot, err := s.inventoryRepo.GetLotByID(ctx, lotID)
+	if err != nil {
+		return nil, err
+	}
+	if lot.InventoryItemID != item.ID {
+		return nil, apperrors.WrapInvalidInput("lot does not belong to this inventory item")
+	}
+	return lot, nil
+}
+
+// lotAllocation 払出のロットへの割り当て（lotがnilならロット外の在庫、quantityは在庫数の増減）
+type lotAllocation struct {
+	lot      *model.InventoryLot
+	quantity int
+}
+
+// allocateFEFO 払出数量を使用期限の近いロットから割り当てる（lotsは使用期限の近い順）
+// 期限切れのロットは使わない。ロットで足りない分はロット外の在庫から、それでも足りない数をshortageとして返す。
+func allocateFEFO(lots []model.InventoryLot, unlotted, quantity int, day time.Time) ([]lotAllocation, int) {
+	var allocations []lotAllocation
+	remaining := quantity
+	for i := range lots {
+		lot := &lots[i]
+		if remaining == 0 {
+			break
+		}
+		if lot.Quantity <= 0 || (lot.ExpiryDate != nil && lot.ExpiryDate.Before(day)) {
+			continue
+		}
+		n := min(lot.Quantity, remaining)
+		allocations = append(allocations, lotAllocation{lot: lot, quantity: -n})
+		remaining -= n
+	}
+	if n := min(max(unlotted, 0), remaining); n > 0 {
+		allocations = append(allocations, lotAllocation{quantity: -n})
+		remaining -= n
+	}
+	return allocations, remaining
+}
+
+// unlottedQuantity ロットに属さない在庫数（ロット管理前からの在庫など）
+func unlottedQuantity(item *model.InventoryItem, lots []model.InventoryLot) int {
+	n := item.Quantity
+	for _, lot := range lots {
+		n -= lot.Quantity
+	}
+	return n
+}
+
+// writeOffReason 廃棄理由（未指定なら使用期限を記載する）
+func writeOffReason(reason string, lot *model.InventoryLot) string {
+	if reason != "" || lot.ExpiryDate == nil {
+		return reason
+	}
+	return "使用期限切れ（" + lot.ExpiryDate.Format("2006-01-02") + "）"
+}
+
+// stockDelta 入出庫区分と数量から在庫数の増減を求める（払出・廃棄は減算）
+func stockDelta(movementType string, quantity int) int {
+	switch movementType {
+	case model.StockMovementDispense, model.StockMovementExpire:
+		return -quantity
+	default:
+		return quantity
+	}
+}
+
+// inventoryStatus 在庫数と最低在庫数から在庫ステータスを求める
+func inventoryStatus(quantity, minStockLevel int) string {
+	switch {
+	case quantity <= 0:
+		return model.InventoryStatusOutOfStock
+	case quantity <= minStockLevel:
+		return model.InventoryStatusLow
+	default:
+		return model.InventoryStatusSufficient
+	}
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockInventoryRepository is a mock implementation of repository.InventoryRepository
+type MockInventoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockInventoryRepository) GetAllInventoryItems(ctx context.Context) ([]model.InventoryItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetInventoryItemByID(ctx context.Context, id uuid.UUID) (*model.InventoryItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetInventoryItemsForUpdate(ctx context.Context, ids []uuid.UUID) ([]model.InventoryItem, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) FindLowStockItems(ctx context.Context) ([]model.InventoryItem, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryItem), args.Error(1)
+}
+
+func (m *MockInventoryRepository) CreateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) UpdateInventoryItem(ctx context.Context, item *model.InventoryItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) GetLots(ctx context.Context, itemID uuid.UUID, inStockOnly bool) ([]model.InventoryLot, error) {
+	args := m.Called(ctx, itemID, inStockOnly)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryLot), args.Error(1)
+}
+
+func (m *MockInventoryRepository) GetLotByID(ctx context.Context, id uuid.UUID) (*model.InventoryLot, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryLot), args.Error(1)
+}
+
+func (m *MockInventoryRepository) FindLotByNumber(ctx context.Context, itemID uuid.UUID, lotNumber string) (*model.InventoryLot, error) {
+	args := m.Called(ctx, itemID, lotNumber)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.InventoryLot), args.Error(1)
+}
+
+func (m *MockInventoryRepository) FindExpiringLots(ctx context.Context, until time.Time) ([]model.InventoryLot, error) {
+	args := m.Called(ctx, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.InventoryLot), args.Error(1)
+}
+
+func (m *MockInventoryRepository) CreateLot(ctx context.Context, lot *model.InventoryLot) error {
+	args := m.Called(ctx, lot)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) UpdateLot(ctx context.Context, lot *model.InventoryLot) error {
+	args := m.Called(ctx, lot)
+	return args.Error(0)
+}
+
+func (m *MockInventoryRepository) GetStockMovements(ctx context.Context, itemID uuid.UUID) ([]model.StockMovement, error) {
+	args := m.Called(ctx, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.StockMovement), args.Error(1)
+}
+
+func (m *MockInventoryRepository) CreateStockMovement(ctx context.Context, movement *model.StockMovement) error {
+	args := m.Called(ctx, movement)
+	return args.Error(0)
+}
+
+func lotExpiring(itemID uuid.UUID, number, expiry string, quantity int) model.InventoryLot {
+	lot := model.InventoryLot{ID: uuid.New(), InventoryItemID: itemID, LotNumber: number, Quantity: quantity}
+	if expiry != "" {
+		d, _ := parseDateOnly(expiry)
+		lot.ExpiryDate = &d
+	}
+	return lot
+}
+
+func TestRecordStockMovement(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("receive creates lot and recomputes status", func(t *testing.T) {
+		mockRepo := new(MockInventoryRepository)
+		svc := New(nil, nil, nil, nil, WithInventoryRepository(mockRepo))
+
+		item := model.InventoryItem{ID: uuid.New(), Name: "セファレキシン錠", Quantity: 2, MinStockLevel: 10, Status: model.InventoryStatusLow}
+		existing := lotExpiring(item.ID, "A100", "2030-01-31", 2)
+		mockRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{item.ID}).Return([]model.InventoryItem{item}, nil)
+		mockRepo.On("FindLotByNumber", ctx, item.ID, "B200").Return(nil, apperrors.WrapNotFound("inventory_lot", "B200"))
+		var created *model.InventoryLot
+		mockRepo.On("CreateLot", ctx, mock.Anything).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*model.InventoryLot) }).
+			Return(nil)
+		mockRepo.On("UpdateLot", ctx, mock.Anything).Return(nil)
+		mockRepo.On("CreateStockMovement", ctx, mock.Anything).Return(nil)
+		mockRepo.On("GetLots", ctx, item.ID, true).Return([]model.InventoryLot{
+			lotExpiring(item.ID, "B200", "2029-06-30", 50), existing,
+		}, nil)
+		mockRepo.On("UpdateInventoryItem", ctx, mock.Anything).Return(nil)
+
+		result, err := svc.RecordStockMovement(ctx, item.ID.String(), &model.CreateStockMovementRequest{
+			Type: model.StockMovementReceive, Quantity: 50, LotNumber: "B200", ExpiryDate: "2029-06-30",
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Movements, 1)
+		assert.Equal(t, "B200", result.Movements[0].LotNumber)
+		assert.Equal(t, 52, result.Movements[0].QuantityAfter)
+		assert.Equal(t, 50, created.Quantity)
+		assert.Equal(t, model.InventoryStatusSufficient, result.Item.Status)
+		assert.NotNil(t, result.Item.LastRestocked)
+		// 品目の使用期限は在庫のあるロットの最も近い期限
+		assert.Equal(t, "2029-06-30", result.Item.ExpiryDate.Format("2006-01-02"))
+	})
+
+	t.Run("dispense uses earliest usable lots first", func(t *testing.T) {
+		mockRepo := new(MockInventoryRepository)
+		svc := New(nil, nil, nil, nil, WithInventoryRepository(mockRepo))
+
+		item := model.InventoryItem{ID: uuid.New(), Name: "セファレキシン錠", Quantity: 18}
+		lots := []model.InventoryLot{
+			lotExpiring(item.ID, "OLD", "2000-01-31", 5),
+			lotExpiring(item.ID, "A100", "2099-01-31", 3),
+			lotExpiring(item.ID, "B200", "2099-06-30", 10),
+		}
+		mockRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{item.ID}).Return([]model.InventoryItem{item}, nil)
+		mockRepo.On("GetLots", ctx, item.ID, true).Return(lots, nil)
+		mockRepo.On("UpdateLot", ctx, mock.Anything).Return(nil)
+		mockRepo.On("CreateStockMovement", ctx, mock.Anything).Return(nil)
+		mockRepo.On("UpdateInventoryItem", ctx, mock.Anything).Return(nil)
+
+		result, err := svc.RecordStockMovement(ctx, item.ID.String(), &model.CreateStockMovementRequest{
+			Type: model.StockMovementDispense, Quantity: 5,
+		})
+		require.NoError(t, err)
+		require.Len(t, result.Movements, 2)
+		assert.Equal(t, "A100", result.Movements[0].LotNumber)
+		assert.Equal(t, -3, result.Movements[0].Quantity)
+		assert.Equal(t, "B200", result.Movements[1].LotNumber)
+		assert.Equal(t, -2, result.Movements[1].Quantity)
+		assert.Equal(t, 13, result.Item.Quantity)
+	})
+
+	t.Run("dispense cannot use expired lots", func(t *testing.T) {
+		mockRepo := new(MockInventoryRepository)
+		svc := New(nil, nil, nil, nil, WithInventoryRepository(mockRepo))
+
+		item := model.InventoryItem{ID: uuid.New(), Name: "セファレキシン錠", Quantity: 5}
+		mockRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{item.ID}).Return([]model.InventoryItem{item}, nil)
+		mockRepo.On("GetLots", ctx, item.ID, true).Return([]model.InventoryLot{lotExpiring(item.ID, "OLD", "2000-01-31", 5)}, nil)
+
+		_, err := svc.RecordStockMovement(ctx, item.ID.String(), &model.CreateStockMovementRequest{
+			Type: model.StockMovementDispense, Quantity: 1,
+		})
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateStockMovement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("expire beyond lot stock is rejected", func(t *testing.T) {
+		mockRepo := new(MockInventoryRepository)
+		svc := New(nil, nil, nil, nil, WithInventoryRepository(mockRepo))
+
+		item := model.InventoryItem{ID: uuid.New(), Name: "セファレキシン錠", Quantity: 3}
+		lot := lotExpiring(item.ID, "A100", "2000-01-31", 3)
+		mockRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{item.ID}).Return([]model.InventoryItem{item}, nil)
+		mockRepo.On("GetLots", ctx, item.ID, true).Return([]model.InventoryLot{lot}, nil)
+		mockRepo.On("GetLotByID", ctx, lot.ID).Return(&lot, nil)
+
+		_, err := svc.RecordStockMovement(ctx, item.ID.String(), &model.CreateStockMovementRequest{
+			Type: model.StockMovementExpire, Quantity: 5, LotID: lot.ID.String(),
+		})
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateStockMovement", mock.Anything, mock.Anything)
+	})
+
+	t.Run("adjust requires reason", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithInventoryRepository(new(MockInventoryRepository)))
+
+		_, err := svc.RecordStockMovement(ctx, uuid.New().String(), &model.CreateStockMovementRequest{
+			Type: model.StockMovementAdjust, Quantity: -2,
+		})
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestWriteOffLot(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockInventoryRepository)
+	svc := New(nil, nil, nil, nil, WithInventoryRepository(mockRepo))
+
+	item := model.InventoryItem{ID: uuid.New(), Name: "混合ワクチン", Quantity: 7, MinStockLevel: 2}
+	lot := lotExpiring(item.ID, "V-001", "2024-03-31", 4)
+	mockRepo.On("GetLotByID", ctx, lot.ID).Return(&lot, nil)
+	mockRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{item.ID}).Return([]model.InventoryItem{item}, nil)
+	mockRepo.On("UpdateLot", ctx, mock.Anything).Return(nil)
+	mockRepo.On("CreateStockMovement", ctx, mock.Anything).Return(nil)
+	mockRepo.On("GetLots", ctx, item.ID, true).Return([]model.InventoryLot{}, nil)
+	mockRepo.On("UpdateInventoryItem", ctx, mock.Anything).Return(nil)
+
+	result, err := svc.WriteOffLot(ctx, lot.ID.String(), &model.WriteOffLotRequest{})
+	require.NoError(t, err)
+	require.Len(t, result.Movements, 1)
+	assert.Equal(t, model.StockMovementExpire, result.Movements[0].Type)
+	assert.Equal(t, -4, result.Movements[0].Quantity)
+	assert.Equal(t, "使用期限切れ（2024-03-31）", result.Movements[0].Reason)
+	assert.Equal(t, 3, result.Item.Quantity)
+	assert.Nil(t, result.Item.ExpiryDate)
+}
+
+func TestCompleteAccounting_DispensesStock(t *testing.T) {
+	ctx := context.Background()
+	accountingRepo := new(MockAccountingRepository)
+	masterRepo := new(MockMasterItemRepository)
+	inventoryRepo := new(MockInventoryRepository)
+	svc := New(nil, nil, nil, nil,
+		WithAccountingRepository(accountingRepo),
+		WithMasterItemRepository(masterRepo),
+		WithInventoryRepository(inventoryRepo),
+	)
+
+	stock := model.InventoryItem{ID: uuid.New(), Name: "セファレキシン錠", Quantity: 12, MinStockLevel: 10}
+	lot := lotExpiring(stock.ID, "A100", "2099-01-31", 10)
+	drug := model.MasterItem{ID: uuid.New(), Name: "セファレキシン錠", InventoryID: &stock.ID}
+	exam := model.MasterItem{ID: uuid.New(), Name: "血液検査"}
+	accounting := &model.Accounting{
+		ID:     uuid.New(),
+		Status: model.AccountingStatusUnpaid,
+		AccountingItems: []model.AccountingItem{
+			{ID: uuid.New(), MasterID: &drug.ID, Name: drug.Name, UnitPrice: dec("50"), Quantity: 14, TaxRate: dec("0.10")},
+			{ID: uuid.New(), MasterID: &exam.ID, Name: exam.Name, UnitPrice: dec("3000"), Quantity: 1, TaxRate: dec("0.10")},
+		},
+	}
+
+	accountingRepo.On("GetAccountingByIDForUpdate", ctx, accounting.ID).Return(accounting, nil)
+	accountingRepo.On("UpdateAccounting", ctx, mock.Anything).Return(nil)
+	accountingRepo.On("GetAccountingByID", ctx, accounting.ID).Return(accounting, nil)
+	masterRepo.On("GetMasterItemsByIDs", ctx, []uuid.UUID{drug.ID, exam.ID}).Return([]model.MasterItem{drug, exam}, nil)
+	inventoryRepo.On("GetInventoryItemsForUpdate", ctx, []uuid.UUID{stock.ID}).Return([]model.InventoryItem{stock}, nil)
+	inventoryRepo.On("GetLots", ctx, stock.ID, true).Return([]model.InventoryLot{lot}, nil)
+	inventoryRepo.On("UpdateLot", ctx, mock.Anything).Return(nil)
+	var saved *model.InventoryItem
+	inventoryRepo.On("UpdateInventoryItem", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { saved = args.Get(1).(*model.InventoryItem) }).
+		Return(nil)
+	var movements []*model.StockMovement
+	inventoryRepo.On("CreateStockMovement", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { movements = append(movements, args.Get(1).(*model.StockMovement)) }).
+		Return(nil)
+
+	_, err := svc.CompleteAccounting(ctx, accounting.ID.String(), &model.CompleteAccountingRequest{
+		PaymentMethod: model.PaymentMethodCreditCard,
+	})
+	require.NoError(t, err)
+
+	// ロット → ロット外の在庫の順に払い出し、在庫不足でも入金は止めない
+	require.Len(t, movements, 2)
+	assert.Equal(t, "A100", movements[0].LotNumber)
+	assert.Equal(t, -10, movements[0].Quantity)
+	assert.Nil(t, movements[1].LotID)
+	assert.Equal(t, -4, movements[1].Quantity)
+	for _, m := range movements {
+		assert.Equal(t, model.StockMovementDispense, m.Type)
+		assert.Equal(t, accounting.AccountingItems[0].ID, *m.AccountingItemID)
+	}
+	assert.Equal(t, -2, saved.Quantity)
+	assert.Equal(t, model.InventoryStatusOutOfStock, saved.Status)
+}
+
+func TestAllocateFEFO(t *testing.T) {
+	itemID := uuid.New()
+	day, _ := parseDateOnly("2024-06-01")
+	lots := []model.InventoryLot{
+		lotExpiring(itemID, "EXPIRED", "2024-05-31", 5),
+		lotExpiring(itemID, "TODAY", "2024-06-01", 2),
+		lotExpiring(itemID, "EMPTY", "2024-07-01", 0),
+		lotExpiring(itemID, "LATER", "2024-12-31", 4),
+	}
+
+	allocations, shortage := allocateFEFO(lots, 1, 10, day)
+	require.Len(t, allocations, 3)
+	assert.Equal(t, "TODAY", allocations[0].lot.LotNumber)
+	assert.Equal(t, -2, allocations[0].quantity)
+	assert.Equal(t, "LATER", allocations[1].lot.LotNumber)
+	assert.Equal(t, -4, allocations[1].quantity)
+	assert.Nil(t, allocations[2].lot)
+	assert.Equal(t, -1, allocations[2].quantity)
+	assert.Equal(t, 3, shortage)
+}
+
+func TestInventoryStatus(t *testing.T) {
+	assert.Equal(t, model.InventoryStatusOutOfStock, inventoryStatus(0, 5))
+	assert.Equal(t, model.InventoryStatusLow, inventoryStatus(5, 5))
+	assert.Equal(t, model.InventoryStatusSufficient, inventoryStatus(6, 5))
+	assert.Equal(t, model.InventoryStatusSufficient, inventoryStatus(1, 0))
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// parseOptionalID 一覧の絞り込みに指定されたIDを変換する（未指定ならnil）
+func parseOptionalID(s, name string) (*uuid.UUID, error) {
+	if s == "" {
+		return nil, nil
+	}
+	uid, err := uuid.Parse(s)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid " + name + " ID format")
+	}
+	return &uid, nil
+}
+
+// parseDateRange 一覧の絞り込みに指定された期間（YYYY-MM-DD、両端を含む）を変換する
+// 日時カラムで絞り込む場合は、院内の日付で区切るためlocにtime.Localを指定する。
+func parseDateRange(from, to string, loc *time.Location) (model.DateRange, error) {
+	var r model.DateRange
+	if from != "" {
+		t, err := time.ParseInLocation("2006-01-02", from, loc)
+		if err != nil {
+			return r, apperrors.WrapInvalidInput("invalid date_from format, expected YYYY-MM-DD")
+		}
+		r.From = &t
+	}
+	if to != "" {
+		t, err := time.ParseInLocation("2006-01-02", to, loc)
+		if err != nil {
+			return r, apperrors.WrapInvalidInput("invalid date_to format, expected YYYY-MM-DD")
+		}
+		r.To = &t
+	}
+	if r.From != nil && r.To != nil && r.To.Before(*r.From) {
+		return r, apperrors.WrapInvalidInput("date_to must be on or after date_from")
+	}
+	return r, nil
+}
@@ -0,0 +1,396 @@
+package service
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// MasterItemService 診療項目マスタサービスインターフェース
+type MasterItemService interface {
+	GetMasterItems(ctx context.Context, req *model.ListMasterItemsRequest) ([]model.MasterItem, error)
+	GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error)
+	GetMasterItemByID(ctx context.Context, id string) (*model.MasterItem, error)
+	CreateMasterItem(ctx context.Context, req *model.CreateMasterItemRequest) (*model.MasterItem, error)
+	UpdateMasterItem(ctx context.Context, id string, req *model.UpdateMasterItemRequest) (*model.MasterItem, error)
+	DeleteMasterItem(ctx context.Context, id string) error
+	GetMasterItemPrices(ctx context.Context, id string) ([]model.MasterItemPrice, error)
+	CreateMasterItemPrice(ctx context.Context, id string, req *model.CreateMasterItemPriceRequest) (*model.MasterItemPrice, error)
+	ExportMasterItems(ctx context.Context, req *model.ExportMasterItemsRequest) ([]byte, error)
+	ImportMasterItems(ctx context.Context, r io.Reader, req *model.ImportMasterItemsRequest) (*model.MasterItemImportResult, error)
+	UpdateVaccineProtocol(ctx context.Context, id string, req *model.UpdateVaccineProtocolRequest) (*model.MasterItem, error)
+}
+
+// Ensure Service implements MasterItemService
+var _ MasterItemService = (*Service)(nil)
+
+// GetMasterItems 診療項目マスタの一覧を取得（価格は当日時点のもの）
+func (s *Service) GetMasterItems(ctx context.Context, req *model.ListMasterItemsRequest) ([]model.MasterItem, error) {
+	if req.Category != "" {
+		if err := validation.ValidateMasterCategory(req.Category); err != nil {
+			return nil, err
+		}
+	}
+	if req.Status != "" {
+		if err := validation.ValidateMasterStatus(req.Status); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.masterItemRepo.GetMasterItems(ctx, model.MasterItemFilter{
+		Category: req.Category,
+		Status:   req.Status,
+		Query:    strings.TrimSpace(req.Q),
+	})
+	if err != nil {
+		return nil, err
+	}
+	masters := make([]*model.MasterItem, len(items))
+	for i := range items {
+		masters[i] = &items[i]
+	}
+	if err := s.applyEffectivePrices(ctx, today(), masters...); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetMasterCategories マスタ区分ごとの件数を取得
+func (s *Service) GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error) {
+	return s.masterItemRepo.GetMasterCategories(ctx)
+}
+
+// GetMasterItemByID IDで診療項目マスタを取得（価格は当日時点のもの）
+func (s *Service) GetMasterItemByID(ctx context.Context, id string) (*model.MasterItem, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	item, err := s.masterItemRepo.GetMasterItemByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyEffectivePrices(ctx, today(), item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// CreateMasterItem 診療項目マスタを登録する（価格は当日から適用する価格履歴としても記録する）
+func (s *Service) CreateMasterItem(ctx context.Context, req *model.CreateMasterItemRequest) (*model.MasterItem, error) {
+	if err := validation.ValidateCreateMasterItem(req); err != nil {
+		return nil, err
+	}
+
+	item := &model.MasterItem{
+		Code:                  strings.TrimSpace(req.Code),
+		Name:                  req.Name,
+		Category:              req.Category,
+		Price:                 req.Price,
+		TaxRate:               req.TaxRate,
+		IsInsuranceApplicable: req.IsInsuranceApplicable,
+		Status:                model.MasterStatusActive,
+		Description:           req.Description,
+		DefaultQuantity:       req.DefaultQuantity,
+		DurationMinutes:       req.DurationMinutes,
+	}
+	if item.TaxRate == nil {
+		item.TaxRate = defaultTaxRate.Ptr()
+	}
+	if req.InventoryID != "" {
+		inventoryID := uuid.MustParse(req.InventoryID)
+		item.InventoryID = &inventoryID
+	}
+
+	err := s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.ensureMasterCodeAvailable(ctx, item.Code, uuid.Nil); err != nil {
+			return err
+		}
+		if err := s.masterItemRepo.CreateMasterItem(ctx, item); err != nil {
+			return err
+		}
+		if item.Price == nil {
+			return nil
+		}
+		return s.masterItemRepo.SaveMasterItemPrice(ctx, newMasterItemPrice(ctx, item.ID, *item.Price, item.TaxRate, today(), model.MasterPriceSourceManual))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// UpdateMasterItem 診療項目マスタを更新する
+// 価格・税率を変更した場合は当日から適用する価格履歴を記録する。
+func (s *Service) UpdateMasterItem(ctx context.Context, id string, req *model.UpdateMasterItemRequest) (*model.MasterItem, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	if err := validation.ValidateUpdateMasterItem(req); err != nil {
+		return nil, err
+	}
+
+	var item *model.MasterItem
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		item, err = s.masterItemRepo.GetMasterItemByID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if req.Code != nil {
+			code := strings.TrimSpace(*req.Code)
+			if code != item.Code {
+				if err := s.ensureMasterCodeAvailable(ctx, code, item.ID); err != nil {
+					return err
+				}
+				item.Code = code
+			}
+		}
+		if req.Name != nil {
+			item.Name = *req.Name
+		}
+		if req.Category != nil {
+			if *req.Category != item.Category && item.VaccineProtocol != nil {
+				return apperrors.WrapConflict("master item with a vaccine protocol must stay in the vaccine category")
+			}
+			item.Category = *req.Category
+		}
+		if req.IsInsuranceApplicable != nil {
+			item.IsInsuranceApplicable = *req.IsInsuranceApplicable
+		}
+		if req.Status != nil {
+			item.Status = *req.Status
+		}
+		if req.Description != nil {
+			item.Description = *req.Description
+		}
+		if req.InventoryID != nil {
+			item.InventoryID = nil
+			if *req.InventoryID != "" {
+				inventoryID := uuid.MustParse(*req.InventoryID)
+				item.InventoryID = &inventoryID
+			}
+		}
+		if req.DefaultQuantity != nil {
+			item.DefaultQuantity = req.DefaultQuantity
+		}
+		if req.DurationMinutes != nil {
+			item.DurationMinutes = req.DurationMinutes
+		}
+
+		if req.Price != nil || req.TaxRate != nil {
+			if err := s.applyEffectivePrices(ctx, today(), item); err != nil {
+				return err
+			}
+			price, taxRate := item.Price, item.TaxRate
+			if req.Price != nil {
+				price = req.Price
+			}
+			if req.TaxRate != nil {
+				taxRate = req.TaxRate
+			}
+			if price == nil {
+				return apperrors.WrapInvalidInput("price is required to change the tax rate")
+			}
+			if !samePrice(item.Price, item.TaxRate, *price, taxRate) {
+				if err := s.masterItemRepo.SaveMasterItemPrice(ctx, newMasterItemPrice(ctx, item.ID, *price, taxRate, today(), model.MasterPriceSourceManual)); err != nil {
+					return err
+				}
+			}
+			item.Price, item.TaxRate = price, taxRate
+		}
+		return s.masterItemRepo.UpdateMasterItem(ctx, item)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// DeleteMasterItem 診療項目マスタを無効化する
+// 会計明細・カルテ明細から参照されるため物理削除はせず、ステータスをinactiveにする。
+func (s *Service) DeleteMasterItem(ctx context.Context, id string) error {
+	status := model.MasterStatusInactive
+	_, err := s.UpdateMasterItem(ctx, id, &model.UpdateMasterItemRequest{Status: &status})
+	return err
+}
+
+// GetMasterItemPrices 診療項目マスタの価格履歴を取得
+func (s *Service) GetMasterItemPrices(ctx context.Context, id string) ([]model.MasterItemPrice, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	if _, err := s.masterItemRepo.GetMasterItemByID(ctx, uid); err != nil {
+		return nil, err
+	}
+	return s.masterItemRepo.GetMasterItemPrices(ctx, uid)
+}
+
+// CreateMasterItemPrice 適用開始日を指定して価格改定を登録する
+// 適用開始日が当日以前なら、マスタの現在価格も当日時点の価格に更新する。
+func (s *Service) CreateMasterItemPrice(ctx context.Context, id string, req *model.CreateMasterItemPriceRequest) (*model.MasterItemPrice, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	if err := validation.ValidateCreateMasterItemPrice(req); err != nil {
+		return nil, err
+	}
+	effectiveFrom, _ := parseDateOnly(req.EffectiveFrom)
+
+	var price *model.MasterItemPrice
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		item, err := s.masterItemRepo.GetMasterItemByID(ctx, uid)
+		if err != nil {
+			return err
+		}
+		taxRate := req.TaxRate
+		if taxRate == nil {
+			taxRate = item.TaxRate
+		}
+		price = newMasterItemPrice(ctx, item.ID, *req.Price, taxRate, effectiveFrom, model.MasterPriceSourceManual)
+		if err := s.masterItemRepo.SaveMasterItemPrice(ctx, price); err != nil {
+			return err
+		}
+		if effectiveFrom.After(today()) {
+			return nil
+		}
+		return s.syncCurrentPrices(ctx, []*model.MasterItem{item})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return price, nil
+}
+
+// UpdateVaccineProtocol ワクチンマスタの接種プロトコルを設定する（nullで解除）
+func (s *Service) UpdateVaccineProtocol(ctx context.Context, id string, req *model.UpdateVaccineProtocolRequest) (*model.MasterItem, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	if err := validation.ValidateVaccineProtocol(req.VaccineProtocol); err != nil {
+		return nil, err
+	}
+
+	item, err := s.masterItemRepo.GetMasterItemByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	if item.Category != model.MasterCategoryVaccine {
+		return nil, apperrors.WrapInvalidInput("vaccine protocol can only be set on vaccine master items")
+	}
+
+	item.VaccineProtocol = req.VaccineProtocol
+	if err := s.masterItemRepo.UpdateMasterItem(ctx, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// applyEffectivePrices 価格履歴から指定日に有効な価格・税率をマスタに反映する
+// 指定日以前の価格履歴がないマスタはマスタの価格のままとする。
+func (s *Service) applyEffectivePrices(ctx context.Context, date time.Time, masters ...*model.MasterItem) error {
+	byMaster, err := s.effectivePrices(ctx, date, masters)
+	if err != nil {
+		return err
+	}
+	for _, master := range masters {
+		price, ok := byMaster[master.ID]
+		if !ok {
+			continue
+		}
+		master.Price = price.Price.Ptr()
+		if price.TaxRate != nil {
+			master.TaxRate = price.TaxRate
+		}
+	}
+	return nil
+}
+
+// effectivePrices 指定日に有効な価格履歴をマスタIDごとに取得する
+func (s *Service) effectivePrices(ctx context.Context, date time.Time, masters []*model.MasterItem) (map[uuid.UUID]model.MasterItemPrice, error) {
+	if len(masters) == 0 {
+		return nil, nil
+	}
+	ids := make([]uuid.UUID, len(masters))
+	for i, master := range masters {
+		ids[i] = master.ID
+	}
+	prices, err := s.masterItemRepo.GetEffectivePrices(ctx, ids, date)
+	if err != nil {
+		return nil, err
+	}
+	byMaster := make(map[uuid.UUID]model.MasterItemPrice, len(prices))
+	for _, price := range prices {
+		byMaster[price.MasterItemID] = price
+	}
+	return byMaster, nil
+}
+
+// syncCurrentPrices マスタの現在価格を当日時点の価格履歴に合わせて更新する
+func (s *Service) syncCurrentPrices(ctx context.Context, masters []*model.MasterItem) error {
+	stored := make(map[uuid.UUID]model.MasterItem, len(masters))
+	for _, master := range masters {
+		stored[master.ID] = *master
+	}
+	if err := s.applyEffectivePrices(ctx, today(), masters...); err != nil {
+		return err
+	}
+	for _, master := range masters {
+		before := stored[master.ID]
+		if master.Price == nil || samePrice(before.Price, before.TaxRate, *master.Price, master.TaxRate) {
+			continue
+		}
+		if err := s.masterItemRepo.UpdateMasterItem(ctx, master); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ensureMasterCodeAvailable コードが他の診療項目マスタで使われていないことを確認する
+func (s *Service) ensureMasterCodeAvailable(ctx context.Context, code string, self uuid.UUID) error {
+	existing, err := s.masterItemRepo.GetMasterItemsByCodes(ctx, []string{code})
+	if err != nil {
+		return err
+	}
+	for _, item := range existing {
+		if item.ID != self {
+			return apperrors.WrapConflict("master item code " + code + " is already in use")
+		}
+	}
+	return nil
+}
+
+// newMasterItemPrice 価格履歴を作成する（登録者は操作中のスタッフ）
+func newMasterItemPrice(ctx context.Context, masterItemID uuid.UUID, price decimal.Decimal, taxRate *decimal.Decimal, effectiveFrom time.Time, source string) *model.MasterItemPrice {
+	return &model.MasterItemPrice{
+		MasterItemID:  masterItemID,
+		Price:         price,
+		TaxRate:       taxRate,
+		EffectiveFrom: effectiveFrom,
+		Source:        source,
+		CreatedBy:     currentStaffID(ctx),
+	}
+}
+
+// samePrice 現在の価格・税率が指定の価格・税率と同じか
+func samePrice(currentPrice, currentTaxRate *decimal.Decimal, price decimal.Decimal, taxRate *decimal.Decimal) bool {
+	if currentPrice == nil || currentPrice.Cmp(price) != 0 {
+		return false
+	}
+	if currentTaxRate == nil || taxRate == nil {
+		return currentTaxRate == taxRate
+	}
+	return currentTaxRate.Cmp(*taxRate) == 0
+}
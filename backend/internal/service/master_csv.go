@@ -0,0 +1,425 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// masterCSVColumns マスタCSVの列（出力順）
+var masterCSVColumns = []string{"code", "name", "category", "price", "tax_rate", "is_insurance_applicable", "status", "description"}
+
+// utf8BOM Excelで文字化けしないようCSVの先頭に付けるBOM
+const utf8BOM = "\uFEFF"
+
+// maxImportErrors 取込エラーとして返す行数の上限
+const maxImportErrors = 20
+
+// masterCSVRow マスタCSVの1行（nilは列がない・空欄で変更しない項目）
+type masterCSVRow struct {
+	line                  int
+	code                  string
+	name                  *string
+	category              *string
+	price                 *decimal.Decimal
+	taxRate               *decimal.Decimal
+	isInsuranceApplicable *bool
+	status                *string
+	description           *string
+}
+
+// pendingPrice 取込で記録する価格履歴（新規登録分は登録後にIDを紐付ける）
+type pendingPrice struct {
+	item  *model.MasterItem
+	price *model.MasterItemPrice
+}
+
+// ExportMasterItems 診療項目マスタをCSVで出力する（価格は指定日時点のもの）
+func (s *Service) ExportMasterItems(ctx context.Context, req *model.ExportMasterItemsRequest) ([]byte, error) {
+	date := today()
+	if req.Date != "" {
+		d, err := parseDateOnly(req.Date)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("date must be in YYYY-MM-DD format")
+		}
+		date = d
+	}
+	if req.Category != "" {
+		if err := validation.ValidateMasterCategory(req.Category); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.masterItemRepo.GetMasterItems(ctx, model.MasterItemFilter{Category: req.Category})
+	if err != nil {
+		return nil, err
+	}
+	masters := make([]*model.MasterItem, len(items))
+	for i := range items {
+		masters[i] = &items[i]
+	}
+	if err := s.applyEffectivePrices(ctx, date, masters...); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(utf8BOM)
+	w := csv.NewWriter(&buf)
+	if err := w.Write(masterCSVColumns); err != nil {
+		return nil, apperrors.Wrap(err, "failed to write master CSV")
+	}
+	for _, item := range items {
+		record := []string{
+			item.Code,
+			item.Name,
+			item.Category,
+			decimalString(item.Price),
+			decimalString(item.TaxRate),
+			strconv.FormatBool(item.IsInsuranceApplicable),
+			item.Status,
+			item.Description,
+		}
+		if err := w.Write(record); err != nil {
+			return nil, apperrors.Wrap(err, "failed to write master CSV")
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, apperrors.Wrap(err, "failed to write master CSV")
+	}
+	return buf.Bytes(), nil
+}
+
+// ImportMasterItems 診療項目マスタをCSVから一括登録・更新する
+// コードで既存のマスタと照合し、ないコードは新規登録する。空欄の項目は変更しない。
+// 価格・税率が適用開始日時点の価格と異なる場合は、その日から適用する価格履歴を記録する。
+// 1行でも不正な行があれば何も反映せず、行番号付きのエラーを返す。
+func (s *Service) ImportMasterItems(ctx context.Context, r io.Reader, req *model.ImportMasterItemsRequest) (*model.MasterItemImportResult, error) {
+	effectiveFrom := today()
+	if req.EffectiveFrom != "" {
+		d, err := parseDateOnly(req.EffectiveFrom)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("effective from must be in YYYY-MM-DD format")
+		}
+		effectiveFrom = d
+	}
+
+	rows, rowErrors, err := parseMasterCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.MasterItemImportResult{
+		EffectiveFrom: effectiveFrom.Format("2006-01-02"),
+		DryRun:        req.DryRun,
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		codes := make([]string, len(rows))
+		for i, row := range rows {
+			codes[i] = row.code
+		}
+		existing, err := s.masterItemRepo.GetMasterItemsByCodes(ctx, codes)
+		if err != nil {
+			return err
+		}
+		byCode := make(map[string][]*model.MasterItem, len(existing))
+		masters := make([]*model.MasterItem, len(existing))
+		for i := range existing {
+			byCode[existing[i].Code] = append(byCode[existing[i].Code], &existing[i])
+			masters[i] = &existing[i]
+		}
+		// 適用開始日時点の価格と比べて改定の有無を判定する
+		effective, err := s.effectivePrices(ctx, effectiveFrom, masters)
+		if err != nil {
+			return err
+		}
+
+		var created, updated, repriced []*model.MasterItem
+		var prices []pendingPrice
+		for _, row := range rows {
+			matches := byCode[row.code]
+			if len(matches) > 1 {
+				rowErrors = append(rowErrors, fmt.Sprintf("line %d: code %s matches %d master items", row.line, row.code, len(matches)))
+				continue
+			}
+
+			item := &model.MasterItem{Code: row.code, Status: model.MasterStatusActive, TaxRate: defaultTaxRate.Ptr()}
+			isNew := len(matches) == 0
+			if !isNew {
+				item = matches[0]
+			}
+			changed, err := applyMasterCSVRow(item, row, isNew)
+			if err != nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("line %d: %s", row.line, importErrorMessage(err)))
+				continue
+			}
+
+			currentPrice, currentTaxRate := item.Price, item.TaxRate
+			if p, ok := effective[item.ID]; ok && !isNew {
+				currentPrice = p.Price.Ptr()
+				if p.TaxRate != nil {
+					currentTaxRate = p.TaxRate
+				}
+			}
+			price, taxRate := currentPrice, currentTaxRate
+			if row.price != nil {
+				price = row.price
+			}
+			if row.taxRate != nil {
+				taxRate = row.taxRate
+			}
+			priceChanged := false
+			if row.price != nil || row.taxRate != nil {
+				if price == nil {
+					rowErrors = append(rowErrors, fmt.Sprintf("line %d: price is required to change the tax rate", row.line))
+					continue
+				}
+				priceChanged = isNew || !samePrice(currentPrice, currentTaxRate, *price, taxRate)
+			}
+
+			switch {
+			case isNew:
+				item.Price, item.TaxRate = price, taxRate
+				created = append(created, item)
+				result.Created++
+			case changed:
+				updated = append(updated, item)
+				result.Updated++
+			case !priceChanged:
+				result.Unchanged++
+			}
+			if priceChanged {
+				prices = append(prices, pendingPrice{item: item, price: newMasterItemPrice(ctx, uuid.Nil, *price, taxRate, effectiveFrom, model.MasterPriceSourceImport)})
+				if !isNew {
+					repriced = append(repriced, item)
+					result.PriceChanged++
+				}
+			}
+		}
+
+		if len(rowErrors) > 0 {
+			return importError(rowErrors)
+		}
+		if req.DryRun {
+			return nil
+		}
+
+		for _, item := range created {
+			if err := s.masterItemRepo.CreateMasterItem(ctx, item); err != nil {
+				return err
+			}
+		}
+		for _, item := range updated {
+			if err := s.masterItemRepo.UpdateMasterItem(ctx, item); err != nil {
+				return err
+			}
+		}
+		for _, p := range prices {
+			p.price.MasterItemID = p.item.ID
+			if err := s.masterItemRepo.SaveMasterItemPrice(ctx, p.price); err != nil {
+				return err
+			}
+		}
+		if effectiveFrom.After(today()) {
+			return nil
+		}
+		return s.syncCurrentPrices(ctx, repriced)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// parseMasterCSV マスタCSVを読み込む
+// ヘッダー行の列名で列を判別し、code列は必須とする。行ごとの入力エラーは行番号付きで返す。
+func parseMasterCSV(r io.Reader) ([]masterCSVRow, []string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, apperrors.WrapInvalidInput("CSV is empty")
+	}
+	if err != nil {
+		return nil, nil, apperrors.WrapInvalidInput("invalid CSV: " + err.Error())
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, utf8BOM)))
+		if !slices.Contains(masterCSVColumns, name) {
+			return nil, nil, apperrors.WrapInvalidInput("unknown CSV column: " + name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, nil, apperrors.WrapInvalidInput("duplicate CSV column: " + name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["code"]; !ok {
+		return nil, nil, apperrors.WrapInvalidInput("CSV must have a code column")
+	}
+
+	var rows []masterCSVRow
+	var rowErrors []string
+	seen := map[string]int{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, apperrors.WrapInvalidInput("invalid CSV: " + err.Error())
+		}
+		line, _ := reader.FieldPos(0)
+
+		cell := func(name string) *string {
+			i, ok := columns[name]
+			if !ok {
+				return nil
+			}
+			v := strings.TrimSpace(record[i])
+			if v == "" {
+				return nil
+			}
+			return &v
+		}
+
+		row := masterCSVRow{
+			line:        line,
+			name:        cell("name"),
+			category:    cell("category"),
+			status:      cell("status"),
+			description: cell("description"),
+		}
+		if code := cell("code"); code != nil {
+			row.code = *code
+		}
+		if row.code == "" {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: code is required", line))
+			continue
+		}
+		if first, ok := seen[row.code]; ok {
+			rowErrors = append(rowErrors, fmt.Sprintf("line %d: code %s is duplicated on line %d", line, row.code, first))
+			continue
+		}
+		seen[row.code] = line
+
+		if v := cell("price"); v != nil {
+			price, err := decimal.Parse(strings.ReplaceAll(*v, ",", ""))
+			if err != nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("line %d: invalid price %q", line, *v))
+				continue
+			}
+			row.price = &price
+		}
+		if v := cell("tax_rate"); v != nil {
+			rate, err := decimal.Parse(*v)
+			if err != nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("line %d: invalid tax rate %q", line, *v))
+				continue
+			}
+			row.taxRate = &rate
+		}
+		if v := cell("is_insurance_applicable"); v != nil {
+			b, err := strconv.ParseBool(*v)
+			if err != nil {
+				rowErrors = append(rowErrors, fmt.Sprintf("line %d: is_insurance_applicable must be true or false", line))
+				continue
+			}
+			row.isInsuranceApplicable = &b
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 && len(rowErrors) == 0 {
+		return nil, nil, apperrors.WrapInvalidInput("CSV has no rows")
+	}
+	return rows, rowErrors, nil
+}
+
+// applyMasterCSVRow CSVの1行をマスタに反映し、価格以外の項目が変わったかを返す
+func applyMasterCSVRow(item *model.MasterItem, row masterCSVRow, isNew bool) (bool, error) {
+	if isNew && (row.name == nil || row.category == nil) {
+		return false, apperrors.WrapInvalidInput("name and category are required for a new code")
+	}
+	if err := validation.ValidateMasterPrice(row.price, row.taxRate); err != nil {
+		return false, err
+	}
+
+	changed := false
+	if row.name != nil && *row.name != item.Name {
+		if len([]rune(*row.name)) > 200 {
+			return false, apperrors.WrapInvalidInput("name must be 200 characters or less")
+		}
+		item.Name = *row.name
+		changed = true
+	}
+	if row.category != nil && *row.category != item.Category {
+		if err := validation.ValidateMasterCategory(*row.category); err != nil {
+			return false, err
+		}
+		if item.VaccineProtocol != nil {
+			return false, apperrors.WrapConflict("master item with a vaccine protocol must stay in the vaccine category")
+		}
+		item.Category = *row.category
+		changed = true
+	}
+	if row.isInsuranceApplicable != nil && *row.isInsuranceApplicable != item.IsInsuranceApplicable {
+		item.IsInsuranceApplicable = *row.isInsuranceApplicable
+		changed = true
+	}
+	if row.status != nil && *row.status != item.Status {
+		if err := validation.ValidateMasterStatus(*row.status); err != nil {
+			return false, err
+		}
+		item.Status = *row.status
+		changed = true
+	}
+	if row.description != nil && *row.description != item.Description {
+		item.Description = *row.description
+		changed = true
+	}
+	if len(item.Code) > 20 {
+		return false, apperrors.WrapInvalidInput("code must be 20 characters or less")
+	}
+	return changed, nil
+}
+
+// importError 行ごとのエラーをまとめて入力エラーにする（上限を超えた分は件数のみ）
+func importError(rowErrors []string) error {
+	msg := strings.Join(rowErrors[:min(len(rowErrors), maxImportErrors)], "; ")
+	if len(rowErrors) > maxImportErrors {
+		msg += fmt.Sprintf("; and %d more errors", len(rowErrors)-maxImportErrors)
+	}
+	return apperrors.WrapInvalidInput("invalid CSV: " + msg)
+}
+
+// importErrorMessage 行エラーに載せるメッセージ
+func importErrorMessage(err error) string {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// decimalString 10進数を文字列にする（nilは空文字）
+func decimalString(d *decimal.Decimal) string {
+	if d == nil {
+		return ""
+	}
+	return d.String()
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestExportMasterItems(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMasterItemRepository)
+	svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+	revisit := model.MasterItem{ID: uuid.New(), Code: "C001", Name: "再診料", Category: "examination", Price: dec("1100"), TaxRate: dec("0.10"), IsInsuranceApplicable: true, Status: model.MasterStatusActive}
+	food := model.MasterItem{ID: uuid.New(), Code: "F100", Name: "療法食, 2kg", Category: "medicine", Price: dec("1980"), TaxRate: dec("0.08"), Status: model.MasterStatusActive}
+	mockRepo.On("GetMasterItems", ctx, model.MasterItemFilter{}).Return([]model.MasterItem{revisit, food}, nil)
+	// 基準日時点では再診料は改定前の1,000円
+	mockRepo.On("GetEffectivePrices", ctx, []uuid.UUID{revisit.ID, food.ID}, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)).
+		Return([]model.MasterItemPrice{{MasterItemID: revisit.ID, Price: decimal.MustParse("1000")}}, nil)
+
+	body, err := svc.ExportMasterItems(ctx, &model.ExportMasterItemsRequest{Date: "2024-03-31"})
+
+	require.NoError(t, err)
+	assert.Equal(t, utf8BOM+
+		"code,name,category,price,tax_rate,is_insurance_applicable,status,description\n"+
+		"C001,再診料,examination,1000,0.1,true,active,\n"+
+		"F100,\"療法食, 2kg\",medicine,1980,0.08,false,active,\n", string(body))
+}
+
+func TestImportMasterItems(t *testing.T) {
+	ctx := context.Background()
+	april := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	csv := utf8BOM + "code,name,category,price,tax_rate\n" +
+		"C001,,,1200,\n" + // 価格改定
+		"E010,,,4400,\n" + // 価格据え置き
+		"F100,療法食（大袋）,,,\n" + // 名称のみ変更
+		"V001,混合ワクチン,vaccine,\"8,800\",\n" // 新規
+
+	newMocks := func() (*MockMasterItemRepository, []model.MasterItem) {
+		mockRepo := new(MockMasterItemRepository)
+		existing := []model.MasterItem{
+			{ID: uuid.New(), Code: "C001", Name: "再診料", Category: "examination", Price: dec("1100"), TaxRate: dec("0.10")},
+			{ID: uuid.New(), Code: "E010", Name: "血液検査", Category: "examination", Price: dec("4400"), TaxRate: dec("0.10")},
+			{ID: uuid.New(), Code: "F100", Name: "療法食", Category: "medicine", Price: dec("1980"), TaxRate: dec("0.08")},
+		}
+		mockRepo.On("GetMasterItemsByCodes", ctx, []string{"C001", "E010", "F100", "V001"}).Return(existing, nil)
+		mockRepo.On("GetEffectivePrices", ctx, mock.Anything, april).Return([]model.MasterItemPrice{}, nil)
+		return mockRepo, existing
+	}
+
+	t.Run("records price revisions from the effective date", func(t *testing.T) {
+		mockRepo, existing := newMocks()
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo), WithTransactor(tx))
+
+		var created *model.MasterItem
+		mockRepo.On("CreateMasterItem", ctx, mock.AnythingOfType("*model.MasterItem")).
+			Run(func(args mock.Arguments) {
+				created = args.Get(1).(*model.MasterItem)
+				created.ID = uuid.New()
+			}).Return(nil)
+		mockRepo.On("UpdateMasterItem", ctx, mock.AnythingOfType("*model.MasterItem")).Return(nil)
+		var prices []*model.MasterItemPrice
+		mockRepo.On("SaveMasterItemPrice", ctx, mock.AnythingOfType("*model.MasterItemPrice")).
+			Run(func(args mock.Arguments) { prices = append(prices, args.Get(1).(*model.MasterItemPrice)) }).
+			Return(nil)
+		mockRepo.On("GetEffectivePrices", ctx, []uuid.UUID{existing[0].ID}, today()).
+			Return([]model.MasterItemPrice{{MasterItemID: existing[0].ID, Price: decimal.MustParse("1200"), TaxRate: dec("0.10")}}, nil)
+
+		result, err := svc.ImportMasterItems(ctx, strings.NewReader(csv), &model.ImportMasterItemsRequest{EffectiveFrom: "2020-04-01"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, &model.MasterItemImportResult{EffectiveFrom: "2020-04-01", Created: 1, Updated: 1, PriceChanged: 1, Unchanged: 1}, result)
+
+		require.NotNil(t, created)
+		assert.Equal(t, "混合ワクチン", created.Name)
+		assert.Equal(t, "8800", created.Price.String())
+		assert.Equal(t, model.MasterStatusActive, created.Status)
+
+		require.Len(t, prices, 2)
+		assert.Equal(t, existing[0].ID, prices[0].MasterItemID)
+		assert.Equal(t, "1200", prices[0].Price.String())
+		assert.Equal(t, april, prices[0].EffectiveFrom)
+		assert.Equal(t, model.MasterPriceSourceImport, prices[0].Source)
+		assert.Equal(t, created.ID, prices[1].MasterItemID)
+
+		// 名称変更と、当日に有効となった改定価格を反映
+		assert.Equal(t, "療法食（大袋）", existing[2].Name)
+		assert.Equal(t, "1200", existing[0].Price.String())
+		mockRepo.AssertNumberOfCalls(t, "UpdateMasterItem", 2)
+	})
+
+	t.Run("dry run does not write", func(t *testing.T) {
+		mockRepo, _ := newMocks()
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+		result, err := svc.ImportMasterItems(ctx, strings.NewReader(csv), &model.ImportMasterItemsRequest{EffectiveFrom: "2020-04-01", DryRun: true})
+
+		require.NoError(t, err)
+		assert.True(t, result.DryRun)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, 1, result.PriceChanged)
+		mockRepo.AssertNotCalled(t, "CreateMasterItem", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "SaveMasterItemPrice", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects the whole file with line numbers", func(t *testing.T) {
+		mockRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+		mockRepo.On("GetMasterItemsByCodes", ctx, []string{"C001", "X001"}).Return([]model.MasterItem{
+			{ID: uuid.New(), Code: "C001", Name: "再診料", Category: "examination", Price: dec("1100")},
+		}, nil)
+		mockRepo.On("GetEffectivePrices", ctx, mock.Anything, mock.Anything).Return([]model.MasterItemPrice{}, nil)
+
+		bad := "code,name,category,price\n" +
+			"C001,,,-100\n" +
+			"X001,新項目,,500\n" +
+			"C001,,,1200\n" +
+			",名称のみ,,\n"
+		_, err := svc.ImportMasterItems(ctx, strings.NewReader(bad), &model.ImportMasterItemsRequest{})
+
+		require.True(t, apperrors.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "line 4: code C001 is duplicated on line 2")
+		assert.Contains(t, err.Error(), "line 5: code is required")
+		assert.Contains(t, err.Error(), "line 2: price must not be negative")
+		assert.Contains(t, err.Error(), "line 3: name and category are required for a new code")
+		mockRepo.AssertNotCalled(t, "CreateMasterItem", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "UpdateMasterItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects unknown columns", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(new(MockMasterItemRepository)))
+
+		_, err := svc.ImportMasterItems(ctx, strings.NewReader("code,価格\nC001,1200\n"), &model.ImportMasterItemsRequest{})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockMasterItemRepository is a mock implementation of repository.MasterItemRepository
+type MockMasterItemRepository struct {
+	mock.Mock
+}
+
+func (m *MockMasterItemRepository) GetMasterItemByID(ctx context.Context, id uuid.UUID) (*model.MasterItem, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MasterItem), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) GetMasterItemsByIDs(ctx context.Context, ids []uuid.UUID) ([]model.MasterItem, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItem), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) GetMasterItems(ctx context.Context, filter model.MasterItemFilter) ([]model.MasterItem, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItem), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) GetMasterItemsByCodes(ctx context.Context, codes []string) ([]model.MasterItem, error) {
+	args := m.Called(ctx, codes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItem), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) GetMasterCategories(ctx context.Context) ([]model.MasterCategorySummary, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterCategorySummary), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) CreateMasterItem(ctx context.Context, item *model.MasterItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockMasterItemRepository) UpdateMasterItem(ctx context.Context, item *model.MasterItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockMasterItemRepository) GetMasterItemPrices(ctx context.Context, masterItemID uuid.UUID) ([]model.MasterItemPrice, error) {
+	args := m.Called(ctx, masterItemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItemPrice), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) GetEffectivePrices(ctx context.Context, masterItemIDs []uuid.UUID, date time.Time) ([]model.MasterItemPrice, error) {
+	args := m.Called(ctx, masterItemIDs, date)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MasterItemPrice), args.Error(1)
+}
+
+func (m *MockMasterItemRepository) SaveMasterItemPrice(ctx context.Context, price *model.MasterItemPrice) error {
+	args := m.Called(ctx, price)
+	return args.Error(0)
+}
+
+func TestUpdateVaccineProtocol(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMasterItemRepository)
+	svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+	rabies := &model.MasterItem{ID: uuid.New(), Name: "狂犬病ワクチン", Category: model.MasterCategoryVaccine}
+	mockRepo.On("GetMasterItemByID", ctx, rabies.ID).Return(rabies, nil)
+	mockRepo.On("UpdateMasterItem", ctx, rabies).Return(nil)
+
+	protocol := &model.VaccineProtocol{Species: []string{"犬"}, BoosterMonths: 12}
+	item, err := svc.UpdateVaccineProtocol(ctx, rabies.ID.String(), &model.UpdateVaccineProtocolRequest{VaccineProtocol: protocol})
+	require.NoError(t, err)
+	assert.Equal(t, protocol, item.VaccineProtocol)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateVaccineProtocol_NotVaccine(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMasterItemRepository)
+	svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+	exam := &model.MasterItem{ID: uuid.New(), Name: "血液検査", Category: "examination"}
+	mockRepo.On("GetMasterItemByID", ctx, exam.ID).Return(exam, nil)
+
+	_, err := svc.UpdateVaccineProtocol(ctx, exam.ID.String(), &model.UpdateVaccineProtocolRequest{
+		VaccineProtocol: &model.VaccineProtocol{BoosterMonths: 12},
+	})
+	assert.True(t, apperrors.IsInvalidInput(err))
+	mockRepo.AssertNotCalled(t, "UpdateMasterItem", mock.Anything, mock.Anything)
+}
+
+func TestUpdateVaccineProtocol_InvalidSeries(t *testing.T) {
+	svc := New(nil, nil, nil, nil, WithMasterItemRepository(new(MockMasterItemRepository)))
+
+	_, err := svc.UpdateVaccineProtocol(context.Background(), uuid.New().String(), &model.UpdateVaccineProtocolRequest{
+		VaccineProtocol: &model.VaccineProtocol{SeriesWeeks: []int{12, 8}},
+	})
+	assert.True(t, apperrors.IsInvalidInput(err))
+}
+
+func TestCreateMasterItem(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records the initial price", func(t *testing.T) {
+		mockRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+		mockRepo.On("GetMasterItemsByCodes", ctx, []string{"E020"}).Return([]model.MasterItem{}, nil)
+		mockRepo.On("CreateMasterItem", ctx, mock.AnythingOfType("*model.MasterItem")).Return(nil)
+		var price *model.MasterItemPrice
+		mockRepo.On("SaveMasterItemPrice", ctx, mock.AnythingOfType("*model.MasterItemPrice")).
+			Run(func(args mock.Arguments) { price = args.Get(1).(*model.MasterItemPrice) }).
+			Return(nil)
+
+		item, err := svc.CreateMasterItem(ctx, &model.CreateMasterItemRequest{
+			Code: "E020", Name: "レントゲン検査", Category: "examination", Price: dec("5500"),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "0.1", item.TaxRate.String())
+		assert.Equal(t, model.MasterStatusActive, item.Status)
+		require.NotNil(t, price)
+		assert.Equal(t, "5500", price.Price.String())
+		assert.Equal(t, today(), price.EffectiveFrom)
+	})
+
+	t.Run("rejects a duplicate code", func(t *testing.T) {
+		mockRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+		mockRepo.On("GetMasterItemsByCodes", ctx, []string{"E020"}).Return([]model.MasterItem{{ID: uuid.New(), Code: "E020"}}, nil)
+
+		_, err := svc.CreateMasterItem(ctx, &model.CreateMasterItemRequest{Code: "E020", Name: "レントゲン検査", Category: "examination"})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateMasterItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown category", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(new(MockMasterItemRepository)))
+
+		_, err := svc.CreateMasterItem(ctx, &model.CreateMasterItemRequest{Code: "E020", Name: "レントゲン検査", Category: "xray"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestUpdateMasterItem_PriceChange(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockMasterItemRepository)
+	svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+	item := &model.MasterItem{ID: uuid.New(), Code: "C001", Name: "再診料", Category: "examination", Price: dec("1100"), TaxRate: dec("0.10")}
+	mockRepo.On("GetMasterItemByID", ctx, item.ID).Return(item, nil)
+	mockRepo.On("GetEffectivePrices", ctx, []uuid.UUID{item.ID}, today()).Return([]model.MasterItemPrice{}, nil)
+	var price *model.MasterItemPrice
+	mockRepo.On("SaveMasterItemPrice", ctx, mock.AnythingOfType("*model.MasterItemPrice")).
+		Run(func(args mock.Arguments) { price = args.Get(1).(*model.MasterItemPrice) }).
+		Return(nil)
+	mockRepo.On("UpdateMasterItem", ctx, item).Return(nil)
+
+	updated, err := svc.UpdateMasterItem(ctx, item.ID.String(), &model.UpdateMasterItemRequest{Price: dec("1210")})
+
+	require.NoError(t, err)
+	assert.Equal(t, "1210", updated.Price.String())
+	require.NotNil(t, price)
+	assert.Equal(t, "1210", price.Price.String())
+	assert.Equal(t, "0.1", price.TaxRate.String())
+	assert.Equal(t, model.MasterPriceSourceManual, price.Source)
+}
+
+func TestCreateMasterItemPrice(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("future revision keeps the current price", func(t *testing.T) {
+		mockRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+		item := &model.MasterItem{ID: uuid.New(), Code: "C001", Price: dec("1100"), TaxRate: dec("0.10")}
+		mockRepo.On("GetMasterItemByID", ctx, item.ID).Return(item, nil)
+		mockRepo.On("SaveMasterItemPrice", ctx, mock.AnythingOfType("*model.MasterItemPrice")).Return(nil)
+
+		effective := today().AddDate(0, 1, 0).Format("2006-01-02")
+		price, err := svc.CreateMasterItemPrice(ctx, item.ID.String(), &model.CreateMasterItemPriceRequest{Price: dec("1200"), EffectiveFrom: effective})
+
+		require.NoError(t, err)
+		assert.Equal(t, "0.1", price.TaxRate.String())
+		assert.Equal(t, "1100", item.Price.String())
+		mockRepo.AssertNotCalled(t, "UpdateMasterItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("past revision updates the current price", func(t *testing.T) {
+		mockRepo := new(MockMasterItemRepository)
+		svc := New(nil, nil, nil, nil, WithMasterItemRepository(mockRepo))
+
+		item := &model.MasterItem{ID: uuid.New(), Code: "C001", Price: dec("1100"), TaxRate: dec("0.10")}
+		mockRepo.On("GetMasterItemByID", ctx, item.ID).Return(item, nil)
+		mockRepo.On("SaveMasterItemPrice", ctx, mock.AnythingOfType("*model.MasterItemPrice")).Return(nil)
+		mockRepo.On("GetEffectivePrices", ctx, []uuid.UUID{item.ID}, today()).
+			Return([]model.MasterItemPrice{{MasterItemID: item.ID, Price: decimal.MustParse("1200"), TaxRate: dec("0.10")}}, nil)
+		mockRepo.On("UpdateMasterItem", ctx, item).Return(nil)
+
+		_, err := svc.CreateMasterItemPrice(ctx, item.ID.String(), &model.CreateMasterItemPriceRequest{Price: dec("1200"), EffectiveFrom: "2024-04-01"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "1200", item.Price.String())
+		mockRepo.AssertExpectations(t)
+	})
+}
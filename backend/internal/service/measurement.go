@@ -0,0 +1,336 @@
+package service
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/growth"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// PetMeasurementService ペットの体重・バイタルの測定値サービスインターフェース
+type PetMeasurementService interface {
+	RecordPetMeasurements(ctx context.Context, petID string, req *model.RecordPetMeasurementRequest) ([]model.PetMeasurement, error)
+	GetPetMeasurements(ctx context.Context, petID string, req *model.ListPetMeasurementsRequest) (*model.PetMeasurementSeries, error)
+}
+
+// Ensure Service implements PetMeasurementService
+var _ PetMeasurementService = (*Service)(nil)
+
+// defaultMeasurementAlertPercent 前回の測定値からの変化率の警告しきい値の既定値（%）
+const defaultMeasurementAlertPercent = 10
+
+// RecordPetMeasurements 受付・診察で測った体重・バイタルを記録する
+// 最新の体重であればペットの体重も更新する。
+func (s *Service) RecordPetMeasurements(ctx context.Context, petID string, req *model.RecordPetMeasurementRequest) ([]model.PetMeasurement, error) {
+	uid, err := uuid.Parse(petID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if err := validation.ValidateRecordPetMeasurement(req); err != nil {
+		return nil, err
+	}
+	measuredAt := time.Now()
+	if req.MeasuredAt != "" {
+		measuredAt, err = parseMeasuredAt(req.MeasuredAt)
+		if err != nil {
+			return nil, err
+		}
+		if measuredAt.After(time.Now()) {
+			return nil, apperrors.WrapInvalidInput("measured_at must not be in the future")
+		}
+	}
+	if _, err := s.repo.GetPetByID(ctx, uid); err != nil {
+		return nil, err
+	}
+
+	measurements := petMeasurements(uid, measuredAt, model.MeasurementSourceManual, map[string]*float64{
+		model.MeasurementTypeWeight:             req.Weight,
+		model.MeasurementTypeBodyConditionScore: req.BodyConditionScore,
+		model.MeasurementTypeTemperature:        req.Temperature,
+		model.MeasurementTypeHeartRate:          intValue(req.HeartRate),
+		model.MeasurementTypeRespirationRate:    intValue(req.RespirationRate),
+	})
+	staffID := currentStaffID(ctx)
+	for i := range measurements {
+		measurements[i].StaffID = staffID
+		measurements[i].Notes = req.Notes
+	}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		return s.recordPetMeasurements(ctx, uid, measurements)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
+
+// GetPetMeasurements ペットの1項目の測定値の推移を測定日時の古い順に取得する
+// 前回からの変化率がしきい値以上の測定を警告として挙げる。体重の推移には同じ日のボディコンディションスコアと、
+// 生年月日と品種がわかる成長期の子犬・子猫であれば成長曲線の期待体重との比較を含める。
+func (s *Service) GetPetMeasurements(ctx context.Context, petID string, req *model.ListPetMeasurementsRequest) (*model.PetMeasurementSeries, error) {
+	uid, err := uuid.Parse(petID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if err := validation.ValidateListPetMeasurements(req); err != nil {
+		return nil, err
+	}
+	dateRange, err := parseDateRange(req.DateFrom, req.DateTo, time.Local)
+	if err != nil {
+		return nil, err
+	}
+	pet, err := s.repo.GetPetByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	measurementType := req.Type
+	if measurementType == "" {
+		measurementType = model.MeasurementTypeWeight
+	}
+	alertPercent := req.AlertPercent
+	if alertPercent == 0 {
+		alertPercent = defaultMeasurementAlertPercent
+	}
+	types := []string{measurementType}
+	if measurementType == model.MeasurementTypeWeight {
+		types = append(types, model.MeasurementTypeBodyConditionScore)
+	}
+	measurements, err := s.petMeasurementRepo.GetPetMeasurements(ctx, uid, types, dateRange)
+	if err != nil {
+		return nil, err
+	}
+
+	series := &model.PetMeasurementSeries{
+		PetID:  uid,
+		Type:   measurementType,
+		Unit:   model.MeasurementUnits[measurementType],
+		Points: []model.PetMeasurementPoint{},
+		Alerts: []model.PetMeasurementAlert{},
+	}
+	// 同じ日のボディコンディションスコア（その日の最後の測定）
+	bodyConditionScores := map[string]float64{}
+	for _, m := range measurements {
+		if measurementType == model.MeasurementTypeWeight && m.Type == model.MeasurementTypeBodyConditionScore {
+			bodyConditionScores[measurementDay(m.MeasuredAt)] = m.Value
+		}
+	}
+	for _, m := range measurements {
+		if m.Type != measurementType {
+			continue
+		}
+		point := model.PetMeasurementPoint{PetMeasurement: m}
+		if n := len(series.Points); n > 0 {
+			previous := series.Points[n-1].Value
+			if previous != 0 {
+				change := roundTo((m.Value-previous)/previous*100, 1)
+				point.ChangePercent = &change
+				if math.Abs(change) >= alertPercent {
+					series.Alerts = append(series.Alerts, model.PetMeasurementAlert{
+						MeasurementID: m.ID,
+						MeasuredAt:    m.MeasuredAt,
+						PreviousValue: previous,
+						Value:         m.Value,
+						ChangePercent: change,
+						Direction:     changeDirection(change),
+					})
+				}
+			}
+		}
+		if score, ok := bodyConditionScores[measurementDay(m.MeasuredAt)]; ok {
+			point.BodyConditionScore = &score
+		}
+		series.Points = append(series.Points, point)
+	}
+	if n := len(series.Points); n > 0 {
+		series.Latest = &series.Points[n-1]
+	}
+
+	if measurementType != model.MeasurementTypeWeight {
+		return series, nil
+	}
+	series.BodyConditionScore, err = s.petMeasurementRepo.GetLatestPetMeasurement(ctx, uid, model.MeasurementTypeBodyConditionScore)
+	if err != nil {
+		return nil, err
+	}
+	applyGrowthCurve(series, pet)
+	return series, nil
+}
+
+// applyGrowthCurve 体重の推移を品種の成長曲線と比べる（生年月日か成長曲線がなければ何もしない）
+// 成長曲線は現在または推移のいずれかの測定が成長期にあるときだけ付ける。
+func applyGrowthCurve(series *model.PetMeasurementSeries, pet *model.Pet) {
+	if pet.BirthDate == nil {
+		return
+	}
+	curve, ok := growth.Lookup(pet.Species, pet.Breed)
+	if !ok {
+		return
+	}
+	juvenile := ageInWeeks(*pet.BirthDate, time.Now()) <= float64(curve.MatureWeeks)
+	for i := range series.Points {
+		point := &series.Points[i]
+		age := ageInWeeks(*pet.BirthDate, point.MeasuredAt)
+		low, expected, high, ok := curve.Expected(age)
+		if !ok {
+			continue
+		}
+		juvenile = true
+		point.Growth = &model.PetGrowthExpected{
+			AgeWeeks: roundTo(age, 1),
+			Low:      roundTo(low, 2),
+			Expected: roundTo(expected, 2),
+			High:     roundTo(high, 2),
+			Status:   growth.Compare(point.Value, low, high),
+		}
+	}
+	if !juvenile {
+		return
+	}
+	series.GrowthCurve = &model.PetGrowthCurve{
+		SizeClass:   curve.SizeClass,
+		AdultWeight: curve.AdultWeight,
+		MatureWeeks: curve.MatureWeeks,
+	}
+	for _, weeks := range curve.Weeks() {
+		low, expected, high, _ := curve.Expected(float64(weeks))
+		series.GrowthCurve.Curve = append(series.GrowthCurve.Curve, model.PetGrowthCurvePoint{
+			AgeWeeks: weeks,
+			Low:      roundTo(low, 2),
+			Expected: roundTo(expected, 2),
+			High:     roundTo(high, 2),
+		})
+	}
+}
+
+// recordPetMeasurements 測定値を作成し、既存の体重より新しい体重の測定であればペットの体重も更新する
+func (s *Service) recordPetMeasurements(ctx context.Context, petID uuid.UUID, measurements []model.PetMeasurement) error {
+	var weight *model.PetMeasurement
+	for i := range measurements {
+		if measurements[i].Type == model.MeasurementTypeWeight {
+			weight = &measurements[i]
+		}
+	}
+	if weight != nil {
+		latest, err := s.petMeasurementRepo.GetLatestPetMeasurement(ctx, petID, model.MeasurementTypeWeight)
+		if err != nil {
+			return err
+		}
+		if latest != nil && latest.MeasuredAt.After(weight.MeasuredAt) {
+			weight = nil
+		}
+	}
+	if err := s.petMeasurementRepo.CreatePetMeasurements(ctx, measurements); err != nil {
+		return err
+	}
+	if weight == nil {
+		return nil
+	}
+	return s.petMeasurementRepo.UpdatePetWeight(ctx, petID, weight.Value)
+}
+
+// petWeightMeasurement ペットの登録・更新時の体重の測定値
+func petWeightMeasurement(pet *model.Pet) []model.PetMeasurement {
+	return petMeasurements(pet.ID, time.Now(), model.MeasurementSourcePet, map[string]*float64{
+		model.MeasurementTypeWeight: pet.Weight,
+	})
+}
+
+// vitalMeasurements バイタル記録の測定値（測定日時は日次記録の日付と記録時刻）
+func vitalMeasurements(petID uuid.UUID, recordDate time.Time, vital *model.Vital) []model.PetMeasurement {
+	measuredAt := time.Date(recordDate.Year(), recordDate.Month(), recordDate.Day(), 0, 0, 0, 0, time.Local)
+	if clock, err := time.Parse("15:04", vital.RecordedTime); err == nil {
+		measuredAt = measuredAt.Add(time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute)
+	}
+	measurements := petMeasurements(petID, measuredAt, model.MeasurementSourceVital, map[string]*float64{
+		model.MeasurementTypeWeight:          vital.Weight,
+		model.MeasurementTypeTemperature:     vital.Temperature,
+		model.MeasurementTypeHeartRate:       intValue(vital.HeartRate),
+		model.MeasurementTypeRespirationRate: intValue(vital.RespirationRate),
+	})
+	for i := range measurements {
+		measurements[i].VitalID = &vital.ID
+		measurements[i].StaffID = vital.StaffID
+	}
+	return measurements
+}
+
+// measurementTypes 測定値を作成する項目の順
+var measurementTypes = []string{
+	model.MeasurementTypeWeight,
+	model.MeasurementTypeBodyConditionScore,
+	model.MeasurementTypeTemperature,
+	model.MeasurementTypeHeartRate,
+	model.MeasurementTypeRespirationRate,
+}
+
+// petMeasurements 値のある項目ごとに測定値を作る
+func petMeasurements(petID uuid.UUID, measuredAt time.Time, source string, values map[string]*float64) []model.PetMeasurement {
+	var measurements []model.PetMeasurement
+	for _, measurementType := range measurementTypes {
+		value := values[measurementType]
+		if value == nil {
+			continue
+		}
+		measurements = append(measurements, model.PetMeasurement{
+			PetID:      petID,
+			Type:       measurementType,
+			Value:      *value,
+			Unit:       model.MeasurementUnits[measurementType],
+			MeasuredAt: measuredAt,
+			Source:     source,
+		})
+	}
+	return measurements
+}
+
+// parseMeasuredAt 測定日時（RFC3339、または日付のみならその日の0時）を解析する
+func parseMeasuredAt(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, apperrors.WrapInvalidInput("invalid measured_at format, expected RFC3339 or YYYY-MM-DD")
+	}
+	return t, nil
+}
+
+// ageInWeeks 生年月日から測定日までの週齢（日単位）
+func ageInWeeks(birthDate, at time.Time) float64 {
+	y, m, d := at.In(time.Local).Date()
+	born := time.Date(birthDate.Year(), birthDate.Month(), birthDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := math.Round(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Sub(born).Hours() / 24)
+	return days / 7
+}
+
+// measurementDay 測定日（ボディコンディションスコアを同じ日の体重に対応付けるためのキー）
+func measurementDay(t time.Time) string {
+	return t.In(time.Local).Format("2006-01-02")
+}
+
+func changeDirection(change float64) string {
+	if change > 0 {
+		return "increase"
+	}
+	return "decrease"
+}
+
+func intValue(v *int) *float64 {
+	if v == nil {
+		return nil
+	}
+	f := float64(*v)
+	return &f
+}
+
+func roundTo(v float64, digits int) float64 {
+	p := math.Pow(10, float64(digits))
+	return math.Round(v*p) / p
+}
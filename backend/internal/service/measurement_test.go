@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockPetMeasurementRepository is a mock implementation of PetMeasurementRepository
+type MockPetMeasurementRepository struct {
+	mock.Mock
+}
+
+func (m *MockPetMeasurementRepository) GetPetMeasurements(ctx context.Context, petID uuid.UUID, types []string, dateRange model.DateRange) ([]model.PetMeasurement, error) {
+	args := m.Called(ctx, petID, types, dateRange)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PetMeasurement), args.Error(1)
+}
+
+func (m *MockPetMeasurementRepository) GetLatestPetMeasurement(ctx context.Context, petID uuid.UUID, measurementType string) (*model.PetMeasurement, error) {
+	args := m.Called(ctx, petID, measurementType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PetMeasurement), args.Error(1)
+}
+
+func (m *MockPetMeasurementRepository) CreatePetMeasurements(ctx context.Context, measurements []model.PetMeasurement) error {
+	args := m.Called(ctx, measurements)
+	return args.Error(0)
+}
+
+func (m *MockPetMeasurementRepository) UpdatePetWeight(ctx context.Context, petID uuid.UUID, weight float64) error {
+	args := m.Called(ctx, petID, weight)
+	return args.Error(0)
+}
+
+func (m *MockPetMeasurementRepository) BackfillPetMeasurements(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestRecordPetMeasurements(t *testing.T) {
+	ctx := context.Background()
+	petID := uuid.New()
+
+	newService := func() (*Service, *MockPetMeasurementRepository) {
+		pets := new(MockPetRepository)
+		measurements := new(MockPetMeasurementRepository)
+		pets.On("GetPetByID", ctx, petID).Return(&model.Pet{ID: petID}, nil)
+		return New(pets, nil, nil, nil, WithPetMeasurementRepository(measurements)), measurements
+	}
+
+	t.Run("records each value and updates the pet's weight", func(t *testing.T) {
+		svc, measurements := newService()
+		measurements.On("GetLatestPetMeasurement", ctx, petID, model.MeasurementTypeWeight).
+			Return(&model.PetMeasurement{MeasuredAt: time.Now().AddDate(0, 0, -7)}, nil)
+		measurements.On("CreatePetMeasurements", ctx, mock.MatchedBy(func(ms []model.PetMeasurement) bool {
+			return len(ms) == 2 &&
+				ms[0].Type == model.MeasurementTypeWeight && ms[0].Value == 4.2 && ms[0].Unit == "kg" &&
+				ms[1].Type == model.MeasurementTypeBodyConditionScore && ms[1].Value == 5 &&
+				ms[0].Source == model.MeasurementSourceManual && ms[1].Notes == "受付時"
+		})).Return(nil)
+		measurements.On("UpdatePetWeight", ctx, petID, 4.2).Return(nil)
+
+		recorded, err := svc.RecordPetMeasurements(ctx, petID.String(),
+			&model.RecordPetMeasurementRequest{Weight: float(4.2), BodyConditionScore: float(5), Notes: "受付時"})
+
+		require.NoError(t, err)
+		assert.Len(t, recorded, 2)
+		measurements.AssertExpectations(t)
+	})
+
+	t.Run("keeps the pet's weight when an older weight is entered", func(t *testing.T) {
+		svc, measurements := newService()
+		measurements.On("GetLatestPetMeasurement", ctx, petID, model.MeasurementTypeWeight).
+			Return(&model.PetMeasurement{MeasuredAt: time.Now()}, nil)
+		measurements.On("CreatePetMeasurements", ctx, mock.Anything).Return(nil)
+
+		recorded, err := svc.RecordPetMeasurements(ctx, petID.String(),
+			&model.RecordPetMeasurementRequest{MeasuredAt: "2026-01-10", Weight: float(3.8)})
+
+		require.NoError(t, err)
+		assert.True(t, time.Date(2026, 1, 10, 0, 0, 0, 0, time.Local).Equal(recorded[0].MeasuredAt))
+		measurements.AssertNotCalled(t, "UpdatePetWeight", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects out of range values", func(t *testing.T) {
+		svc, measurements := newService()
+
+		_, err := svc.RecordPetMeasurements(ctx, petID.String(), &model.RecordPetMeasurementRequest{BodyConditionScore: float(10)})
+		assert.True(t, apperrors.IsInvalidInput(err))
+		_, err = svc.RecordPetMeasurements(ctx, petID.String(), &model.RecordPetMeasurementRequest{})
+		assert.True(t, apperrors.IsInvalidInput(err))
+		measurements.AssertNotCalled(t, "CreatePetMeasurements", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetPetMeasurements(t *testing.T) {
+	ctx := context.Background()
+	petID := uuid.New()
+	day := func(offset int) time.Time {
+		return time.Date(2026, 6, 1, 10, 0, 0, 0, time.Local).AddDate(0, 0, offset)
+	}
+	weight := func(offset int, value float64) model.PetMeasurement {
+		return model.PetMeasurement{ID: uuid.New(), PetID: petID, Type: model.MeasurementTypeWeight, Value: value, MeasuredAt: day(offset)}
+	}
+	bcs := model.PetMeasurement{ID: uuid.New(), PetID: petID, Type: model.MeasurementTypeBodyConditionScore, Value: 4, MeasuredAt: day(28).Add(time.Hour)}
+
+	t.Run("compares a puppy's weight with its breed's growth curve", func(t *testing.T) {
+		// 2026-04-06生まれのトイ・プードル（2026-06-01で8週齢）
+		birthDate := time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC)
+		pets := new(MockPetRepository)
+		measurements := new(MockPetMeasurementRepository)
+		svc := New(pets, nil, nil, nil, WithPetMeasurementRepository(measurements))
+		pets.On("GetPetByID", ctx, petID).Return(&model.Pet{ID: petID, Species: "犬", Breed: "トイ・プードル", BirthDate: &birthDate}, nil)
+		measurements.On("GetPetMeasurements", ctx, petID,
+			[]string{model.MeasurementTypeWeight, model.MeasurementTypeBodyConditionScore}, model.DateRange{}).
+			Return([]model.PetMeasurement{weight(0, 0.75), weight(14, 0.95), weight(28, 0.8), bcs}, nil)
+		measurements.On("GetLatestPetMeasurement", ctx, petID, model.MeasurementTypeBodyConditionScore).Return(&bcs, nil)
+
+		series, err := svc.GetPetMeasurements(ctx, petID.String(), &model.ListPetMeasurementsRequest{})
+
+		require.NoError(t, err)
+		assert.Equal(t, "kg", series.Unit)
+		require.Len(t, series.Points, 3)
+		assert.Nil(t, series.Points[0].ChangePercent)
+		assert.Equal(t, 26.7, *series.Points[1].ChangePercent)
+		assert.Equal(t, -15.8, *series.Points[2].ChangePercent)
+		assert.Equal(t, series.Points[2].ID, series.Latest.ID)
+
+		// 8週齢の期待体重は成体3kgの25%（0.75kg、±20%）
+		require.NotNil(t, series.Points[0].Growth)
+		assert.Equal(t, 8.0, series.Points[0].Growth.AgeWeeks)
+		assert.Equal(t, 0.75, series.Points[0].Growth.Expected)
+		assert.Equal(t, 0.6, series.Points[0].Growth.Low)
+		assert.Equal(t, 0.9, series.Points[0].Growth.High)
+		assert.Equal(t, "within", series.Points[0].Growth.Status)
+		assert.Equal(t, "below", series.Points[2].Growth.Status)
+
+		require.NotNil(t, series.Points[2].BodyConditionScore)
+		assert.Equal(t, 4.0, *series.Points[2].BodyConditionScore)
+		assert.Nil(t, series.Points[1].BodyConditionScore)
+		assert.Equal(t, &bcs, series.BodyConditionScore)
+
+		require.Len(t, series.Alerts, 2)
+		assert.Equal(t, "increase", series.Alerts[0].Direction)
+		assert.Equal(t, 0.75, series.Alerts[0].PreviousValue)
+		assert.Equal(t, "decrease", series.Alerts[1].Direction)
+
+		require.NotNil(t, series.GrowthCurve)
+		assert.Equal(t, "toy", series.GrowthCurve.SizeClass)
+		assert.Equal(t, 44, series.GrowthCurve.MatureWeeks)
+		assert.Equal(t, 3.0, series.GrowthCurve.Curve[len(series.GrowthCurve.Curve)-1].Expected)
+	})
+
+	t.Run("skips the growth curve for adults and honours the alert threshold", func(t *testing.T) {
+		birthDate := time.Date(2018, 4, 6, 0, 0, 0, 0, time.UTC)
+		pets := new(MockPetRepository)
+		measurements := new(MockPetMeasurementRepository)
+		svc := New(pets, nil, nil, nil, WithPetMeasurementRepository(measurements))
+		pets.On("GetPetByID", ctx, petID).Return(&model.Pet{ID: petID, Species: "猫", BirthDate: &birthDate}, nil)
+		measurements.On("GetPetMeasurements", ctx, petID, mock.Anything, mock.Anything).
+			Return([]model.PetMeasurement{weight(0, 5), weight(30, 4.6)}, nil)
+		measurements.On("GetLatestPetMeasurement", ctx, petID, model.MeasurementTypeBodyConditionScore).Return(nil, nil)
+
+		series, err := svc.GetPetMeasurements(ctx, petID.String(), &model.ListPetMeasurementsRequest{AlertPercent: 5})
+
+		require.NoError(t, err)
+		assert.Nil(t, series.GrowthCurve)
+		assert.Nil(t, series.Points[1].Growth)
+		require.Len(t, series.Alerts, 1)
+		assert.Equal(t, -8.0, series.Alerts[0].ChangePercent)
+	})
+
+	t.Run("rejects unknown types", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil)
+
+		_, err := svc.GetPetMeasurements(ctx, petID.String(), &model.ListPetMeasurementsRequest{Type: "height"})
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestAddVitalRecordsPetMeasurements(t *testing.T) {
+	ctx := context.Background()
+	petID := uuid.New()
+	h := &model.Hospitalization{ID: uuid.New(), PetID: petID, Status: model.HospitalizationStatusAdmitted, StartDate: today().AddDate(0, 0, -2)}
+	record := &model.DailyRecord{ID: uuid.New(), HospitalizationID: h.ID, RecordDate: today().AddDate(0, 0, -1)}
+
+	hospitalizations := new(MockHospitalizationRepository)
+	dailyRecords := new(MockDailyRecordRepository)
+	measurements := new(MockPetMeasurementRepository)
+	svc := New(nil, nil, nil, nil, WithHospitalizationRepository(hospitalizations),
+		WithDailyRecordRepository(dailyRecords), WithPetMeasurementRepository(measurements))
+	hospitalizations.On("GetHospitalizationByID", ctx, h.ID).Return(h, nil)
+	dailyRecords.On("GetOrCreateDailyRecord", ctx, h.ID, record.RecordDate).Return(record, nil)
+	dailyRecords.On("CreateVital", ctx, mock.AnythingOfType("*model.Vital")).Return(nil)
+	measurements.On("GetLatestPetMeasurement", ctx, petID, model.MeasurementTypeWeight).Return(nil, nil)
+	measuredAt := record.RecordDate
+	wantMeasuredAt := time.Date(measuredAt.Year(), measuredAt.Month(), measuredAt.Day(), 8, 30, 0, 0, time.Local)
+	measurements.On("CreatePetMeasurements", ctx, mock.MatchedBy(func(ms []model.PetMeasurement) bool {
+		return len(ms) == 2 &&
+			ms[0].Type == model.MeasurementTypeWeight && ms[0].Value == 5.1 &&
+			ms[1].Type == model.MeasurementTypeTemperature && ms[1].Value == 38.6 &&
+			ms[0].Source == model.MeasurementSourceVital && ms[0].VitalID != nil &&
+			wantMeasuredAt.Equal(ms[0].MeasuredAt)
+	})).Return(nil)
+	measurements.On("UpdatePetWeight", ctx, petID, 5.1).Return(nil)
+
+	_, err := svc.AddVital(ctx, h.ID.String(), &model.AddVitalRequest{
+		DailyRecordEntry: model.DailyRecordEntry{RecordDate: record.RecordDate.Format("2006-01-02"), RecordedTime: "08:30"},
+		Weight:           float(5.1),
+		Temperature:      float(38.6),
+	})
+
+	require.NoError(t, err)
+	measurements.AssertExpectations(t)
+}
+
+func TestUpdatePetRecordsWeightChange(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	pets := new(MockPetRepository)
+	measurements := new(MockPetMeasurementRepository)
+	svc := New(pets, nil, nil, nil, WithPetMeasurementRepository(measurements))
+	pets.On("GetPetByID", ctx, id).Return(&model.Pet{ID: id, Name: "Pochi", Weight: float(6)}, nil)
+	pets.On("UpdatePet", ctx, mock.Anything).Return(nil)
+	measurements.On("CreatePetMeasurements", ctx, mock.MatchedBy(func(ms []model.PetMeasurement) bool {
+		return len(ms) == 1 && ms[0].PetID == id && ms[0].Value == 6.4 && ms[0].Source == model.MeasurementSourcePet
+	})).Return(nil)
+
+	_, err := svc.UpdatePet(ctx, id.String(), &model.UpdatePetRequest{Weight: 6.4})
+	require.NoError(t, err)
+
+	_, err = svc.UpdatePet(ctx, id.String(), &model.UpdatePetRequest{Weight: 6.4, Name: "Pochi"})
+	require.NoError(t, err)
+	measurements.AssertNumberOfCalls(t, "CreatePetMeasurements", 1)
+}
@@ -2,30 +2,118 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
 	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/fulltext"
 	"github.com/animal-ekarte/backend/internal/model"
 	"github.com/animal-ekarte/backend/internal/validation"
 )
 
 // MedicalRecordService カルテサービスインターフェース
 type MedicalRecordService interface {
-	GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error)
+	ListMedicalRecords(ctx context.Context, req *model.ListMedicalRecordsRequest) (*model.ListResult[model.MedicalRecord], error)
+	SearchMedicalRecords(ctx context.Context, req *model.SearchMedicalRecordsRequest) (*model.ListResult[model.MedicalRecordSearchHit], error)
 	GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error)
 	GetMedicalRecordsByPetID(ctx context.Context, petID string) ([]model.MedicalRecord, error)
 	GetMedicalRecordsByOwnerID(ctx context.Context, ownerID string) ([]model.MedicalRecord, error)
 	CreateMedicalRecord(ctx context.Context, req *model.CreateMedicalRecordRequest) (*model.MedicalRecord, error)
 	UpdateMedicalRecord(ctx context.Context, id string, req *model.UpdateMedicalRecordRequest) (*model.MedicalRecord, error)
 	DeleteMedicalRecord(ctx context.Context, id string) error
+	FinalizeMedicalRecord(ctx context.Context, id string) (*model.MedicalRecord, error)
+	AmendMedicalRecord(ctx context.Context, id string, req *model.AmendMedicalRecordRequest) (*model.MedicalRecord, error)
+	GetMedicalRecordAmendments(ctx context.Context, id string) ([]model.MedicalRecordAmendment, error)
+	GetMedicalRecordItems(ctx context.Context, id string) ([]model.MedicalRecordItem, error)
+	AddMedicalRecordItem(ctx context.Context, id string, req *model.AddMedicalRecordItemRequest) (*model.MedicalRecordItem, error)
+	DeleteMedicalRecordItem(ctx context.Context, id, itemID string) error
 }
 
-// GetAllMedicalRecords 全てのカルテを取得
-func (s *Service) GetAllMedicalRecords(ctx context.Context) ([]model.MedicalRecord, error) {
-	return s.medicalRecordRepo.GetAllMedicalRecords(ctx)
+// ListMedicalRecords 条件に一致するカルテを1ページ分取得（既定は診察日の新しい順）
+func (s *Service) ListMedicalRecords(ctx context.Context, req *model.ListMedicalRecordsRequest) (*model.ListResult[model.MedicalRecord], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.MedicalRecordFilter{
+		VisitType: req.VisitType,
+		Status:    req.Status,
+		Species:   req.Species,
+	}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.DoctorID, err = parseOptionalID(req.DoctorID, "doctor"); err != nil {
+		return nil, err
+	}
+	if filter.VisitDate, err = parseDateRange(req.DateFrom, req.DateTo, time.UTC); err != nil {
+		return nil, err
+	}
+	return s.medicalRecordRepo.ListMedicalRecords(ctx, filter, req.ListOptions)
+}
+
+// カルテ全文検索の抜粋
+const (
+	// snippetContext 一致箇所の前に含める文字数
+	snippetContext = 20
+	// snippetLength 抜粋の最大文字数
+	snippetLength = 80
+	// maxSnippets 1件のカルテから返す抜粋の最大数
+	maxSnippets = 3
+)
+
+// SearchMedicalRecords SOAP・診断・処方の全文検索（既定は診察日の新しい順）
+// 検索語は2文字ずつに分けて一致を調べるため、ひらがな/カタカナ、全角/半角の違いを区別しない。
+// 一致箇所を<mark>で囲んだ抜粋を項目ごとに返す。
+func (s *Service) SearchMedicalRecords(ctx context.Context, req *model.SearchMedicalRecordsRequest) (*model.ListResult[model.MedicalRecordSearchHit], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	if len([]rune(req.Q)) > maxSearchQueryLength {
+		return nil, apperrors.WrapInvalidInput("q must be less than 100 characters")
+	}
+	query, ok := fulltext.ParseQuery(req.Q)
+	if !ok {
+		return nil, apperrors.WrapInvalidInput("q is required")
+	}
+	filter := model.MedicalRecordFilter{Species: req.Species}
+	var err error
+	if filter.VisitDate, err = parseDateRange(req.DateFrom, req.DateTo, time.UTC); err != nil {
+		return nil, err
+	}
+
+	records, err := s.medicalRecordRepo.SearchMedicalRecords(ctx, query.TSQuery, filter, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	hits := make([]model.MedicalRecordSearchHit, len(records.Data))
+	for i := range records.Data {
+		hits[i] = model.MedicalRecordSearchHit{
+			MedicalRecord: records.Data[i],
+			Snippets:      medicalRecordSnippets(&records.Data[i], query.Terms),
+		}
+	}
+	return &model.ListResult[model.MedicalRecordSearchHit]{Data: hits, Meta: records.Meta}, nil
+}
+
+// medicalRecordSnippets 検索語を含む項目の抜粋（項目の順に最大maxSnippets件）
+func medicalRecordSnippets(record *model.MedicalRecord, terms []string) []model.MedicalRecordSnippet {
+	snippets := []model.MedicalRecordSnippet{}
+	for _, field := range record.SearchFields() {
+		text, ok := fulltext.Snippet(field.Text, terms, snippetContext, snippetLength)
+		if !ok {
+			continue
+		}
+		snippets = append(snippets, model.MedicalRecordSnippet{Field: field.Name, Text: text})
+		if len(snippets) == maxSnippets {
+			break
+		}
+	}
+	return snippets
 }
 
 // GetMedicalRecordByID IDでカルテを取得
@@ -107,8 +195,11 @@ func (s *Service) CreateMedicalRecord(ctx context.Context, req *model.CreateMedi
 		doctorID = &doctorUUID
 	}
 
-	// RecordNoを生成（現在のタイムスタンプを使用）
-	recordNo := generateRecordNo()
+	// RecordNoをシーケンスから採番
+	recordNo, err := s.medicalRecordRepo.NextRecordNo(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	record := &model.MedicalRecord{
 		RecordNo:       recordNo,
@@ -135,7 +226,14 @@ func (s *Service) CreateMedicalRecord(ctx context.Context, req *model.CreateMedi
 		record.VisitType = "初診"
 	}
 	if record.Status == "" {
-		record.Status = "作成中"
+		record.Status = model.MedicalRecordStatusDraft
+	}
+	if record.Status == model.MedicalRecordStatusFinalized {
+		if err := authorizeRole(ctx, model.StaffRoleVeterinarian); err != nil {
+			return nil, err
+		}
+		now := time.Now()
+		record.FinalizedAt = &now
 	}
 
 	if err := s.medicalRecordRepo.CreateMedicalRecord(ctx, record); err != nil {
@@ -146,22 +244,47 @@ func (s *Service) CreateMedicalRecord(ctx context.Context, req *model.CreateMedi
 }
 
 // UpdateMedicalRecord カルテを更新
+// 確定と同時に更新されても確定済カルテを上書きしないよう、行ロックを取ってからステータスを確認する。
 func (s *Service) UpdateMedicalRecord(ctx context.Context, id string, req *model.UpdateMedicalRecordRequest) (*model.MedicalRecord, error) {
 	// バリデーション
 	if err := validation.ValidateUpdateMedicalRecord(req); err != nil {
 		return nil, err
 	}
 
-	record, err := s.GetMedicalRecordByID(ctx, id)
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid medical record ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		// 確定済カルテは直接更新できない（修正履歴として登録する）
+		if record.Status == model.MedicalRecordStatusFinalized {
+			return apperrors.WrapConflict("finalized medical record cannot be updated; submit an amendment instead")
+		}
+
+		if err := applyMedicalRecordUpdate(ctx, record, req); err != nil {
+			return err
+		}
+		return s.medicalRecordRepo.UpdateMedicalRecord(ctx, record)
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	// 各フィールドを更新
+	return s.GetMedicalRecordByID(ctx, id)
+}
+
+// applyMedicalRecordUpdate 更新リクエストで指定された項目をカルテに反映する
+func applyMedicalRecordUpdate(ctx context.Context, record *model.MedicalRecord, req *model.UpdateMedicalRecordRequest) error {
 	if req.PetID != nil {
 		petID, err := uuid.Parse(*req.PetID)
 		if err != nil {
-			return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+			return apperrors.WrapInvalidInput("invalid pet ID format")
 		}
 		record.PetID = petID
 	}
@@ -169,7 +292,7 @@ func (s *Service) UpdateMedicalRecord(ctx context.Context, id string, req *model
 	if req.OwnerID != nil {
 		ownerID, err := uuid.Parse(*req.OwnerID)
 		if err != nil {
-			return nil, apperrors.WrapInvalidInput("invalid owner ID format")
+			return apperrors.WrapInvalidInput("invalid owner ID format")
 		}
 		record.OwnerID = ownerID
 	}
@@ -180,7 +303,7 @@ func (s *Service) UpdateMedicalRecord(ctx context.Context, id string, req *model
 		} else {
 			doctorID, err := uuid.Parse(*req.DoctorID)
 			if err != nil {
-				return nil, apperrors.WrapInvalidInput("invalid doctor ID format")
+				return apperrors.WrapInvalidInput("invalid doctor ID format")
 			}
 			record.DoctorID = &doctorID
 		}
@@ -189,7 +312,7 @@ func (s *Service) UpdateMedicalRecord(ctx context.Context, id string, req *model
 	if req.VisitDate != nil {
 		visitDate, err := parseVisitDate(*req.VisitDate)
 		if err != nil {
-			return nil, apperrors.WrapInvalidInput("invalid visit date format")
+			return apperrors.WrapInvalidInput("invalid visit date format")
 		}
 		record.VisitDate = visitDate
 	}
@@ -240,13 +363,16 @@ func (s *Service) UpdateMedicalRecord(ctx context.Context, id string, req *model
 
 	if req.Status != nil {
 		record.Status = *req.Status
+		if record.Status == model.MedicalRecordStatusFinalized {
+			if err := authorizeRole(ctx, model.StaffRoleVeterinarian); err != nil {
+				return err
+			}
+			now := time.Now()
+			record.FinalizedAt = &now
+		}
 	}
 
-	if err := s.medicalRecordRepo.UpdateMedicalRecord(ctx, record); err != nil {
-		return nil, err
-	}
-
-	return record, nil
+	return nil
 }
 
 // DeleteMedicalRecord カルテを削除
@@ -256,13 +382,214 @@ func (s *Service) DeleteMedicalRecord(ctx context.Context, id string) error {
 		return apperrors.WrapInvalidInput("invalid medical record ID format")
 	}
 
-	// 存在確認
-	_, err = s.GetMedicalRecordByID(ctx, uid.String())
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		// 確定済カルテは法定保存対象のため削除できない
+		if record.Status == model.MedicalRecordStatusFinalized {
+			return apperrors.WrapConflict("finalized medical record cannot be deleted")
+		}
+
+		return s.medicalRecordRepo.DeleteMedicalRecord(ctx, uid.String())
+	})
+}
+
+// FinalizeMedicalRecord カルテを確定する
+// 確定後は直接の更新・削除ができなくなり、変更はAmendMedicalRecordで行う。
+func (s *Service) FinalizeMedicalRecord(ctx context.Context, id string) (*model.MedicalRecord, error) {
+	if err := authorizeRole(ctx, model.StaffRoleVeterinarian); err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid medical record ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		if record.Status == model.MedicalRecordStatusFinalized {
+			return apperrors.WrapConflict("medical record is already finalized")
+		}
+
+		now := time.Now()
+		record.Status = model.MedicalRecordStatusFinalized
+		record.FinalizedAt = &now
+		return s.medicalRecordRepo.UpdateMedicalRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetMedicalRecordByID(ctx, id)
+}
+
+// AmendMedicalRecord 確定済カルテを修正する
+// 変更された項目ごとに変更前後の値・理由・日時・修正したスタッフを修正履歴に残し、カルテ本体と同一トランザクションで保存する。
+func (s *Service) AmendMedicalRecord(ctx context.Context, id string, req *model.AmendMedicalRecordRequest) (*model.MedicalRecord, error) {
+	if err := authorizeRole(ctx, model.StaffRoleVeterinarian); err != nil {
+		return nil, err
+	}
+
+	if err := validation.ValidateAmendMedicalRecord(req); err != nil {
+		return nil, err
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid medical record ID format")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		record, err := s.medicalRecordRepo.GetMedicalRecordByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		if record.Status != model.MedicalRecordStatusFinalized {
+			return apperrors.WrapConflict("only finalized medical records can be amended; update the draft directly")
+		}
+
+		now := time.Now()
+		amendedBy := currentStaffID(ctx)
+		var amendments []model.MedicalRecordAmendment
+		for _, f := range amendableFields(record, req) {
+			if f.newValue == nil || *f.newValue == *f.current {
+				continue
+			}
+			amendments = append(amendments, model.MedicalRecordAmendment{
+				MedicalRecordID: record.ID,
+				Field:           f.name,
+				OldValue:        *f.current,
+				NewValue:        *f.newValue,
+				Reason:          req.Reason,
+				AmendedBy:       amendedBy,
+				AmendedAt:       now,
+			})
+			*f.current = *f.newValue
+		}
+
+		if len(amendments) == 0 {
+			return apperrors.WrapInvalidInput("amendment does not change any field")
+		}
+
+		if err := s.medicalRecordRepo.CreateMedicalRecordAmendments(ctx, amendments); err != nil {
+			return err
+		}
+		return s.medicalRecordRepo.UpdateMedicalRecord(ctx, record)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetMedicalRecordByID(ctx, id)
+}
+
+// GetMedicalRecordAmendments カルテの修正履歴を取得
+func (s *Service) GetMedicalRecordAmendments(ctx context.Context, id string) ([]model.MedicalRecordAmendment, error) {
+	record, err := s.GetMedicalRecordByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.medicalRecordRepo.GetMedicalRecordAmendments(ctx, record.ID.String())
+}
+
+// GetMedicalRecordItems カルテ明細を取得
+func (s *Service) GetMedicalRecordItems(ctx context.Context, id string) ([]model.MedicalRecordItem, error) {
+	record, err := s.GetMedicalRecordByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.medicalRecordRepo.GetMedicalRecordItems(ctx, record.ID.String())
+}
+
+// AddMedicalRecordItem カルテに実施項目（マスタ項目）を追加する
+// 確定済カルテには追加できない。数量省略時はマスタの既定数量（未設定なら1）とする。
+func (s *Service) AddMedicalRecordItem(ctx context.Context, id string, req *model.AddMedicalRecordItemRequest) (*model.MedicalRecordItem, error) {
+	if err := validation.ValidateAddMedicalRecordItem(req); err != nil {
+		return nil, err
+	}
+
+	record, err := s.GetMedicalRecordByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record.Status == model.MedicalRecordStatusFinalized {
+		return nil, apperrors.WrapConflict("items cannot be added to a finalized medical record")
+	}
+
+	master, err := s.masterItemRepo.GetMasterItemByID(ctx, uuid.MustParse(req.MasterItemID))
+	if err != nil {
+		return nil, err
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+		if master.DefaultQuantity != nil && *master.DefaultQuantity > 0 {
+			quantity = *master.DefaultQuantity
+		}
+	}
+
+	item := &model.MedicalRecordItem{
+		MedicalRecordID: record.ID,
+		MasterItemID:    master.ID,
+		Quantity:        quantity,
+		Notes:           req.Notes,
+	}
+	if err := s.medicalRecordRepo.CreateMedicalRecordItem(ctx, item); err != nil {
+		return nil, err
+	}
+	item.MasterItem = master
+
+	return item, nil
+}
+
+// DeleteMedicalRecordItem カルテ明細を削除（確定済カルテの明細は削除できない）
+func (s *Service) DeleteMedicalRecordItem(ctx context.Context, id, itemID string) error {
+	if _, err := uuid.Parse(itemID); err != nil {
+		return apperrors.WrapInvalidInput("invalid medical record item ID format")
+	}
+
+	record, err := s.GetMedicalRecordByID(ctx, id)
 	if err != nil {
 		return err
 	}
+	if record.Status == model.MedicalRecordStatusFinalized {
+		return apperrors.WrapConflict("items cannot be removed from a finalized medical record")
+	}
 
-	return s.medicalRecordRepo.DeleteMedicalRecord(ctx, uid.String())
+	return s.medicalRecordRepo.DeleteMedicalRecordItem(ctx, record.ID.String(), itemID)
+}
+
+// amendableField 修正可能な項目と、カルテ上の現在値への参照
+type amendableField struct {
+	name     string
+	newValue *string
+	current  *string
+}
+
+// amendableFields 修正リクエストの各項目とカルテの対応するフィールドを組にして返す
+func amendableFields(record *model.MedicalRecord, req *model.AmendMedicalRecordRequest) []amendableField {
+	return []amendableField{
+		{"chief_complaint", req.ChiefComplaint, &record.ChiefComplaint},
+		{"subjective", req.Subjective, &record.Subjective},
+		{"objective", req.Objective, &record.Objective},
+		{"assessment", req.Assessment, &record.Assessment},
+		{"plan", req.Plan, &record.Plan},
+		{"surgery_notes", req.SurgeryNotes, &record.SurgeryNotes},
+		{"diagnosis", req.Diagnosis, &record.Diagnosis},
+		{"treatment", req.Treatment, &record.Treatment},
+		{"prescription", req.Prescription, &record.Prescription},
+		{"notes", req.Notes, &record.Notes},
+	}
 }
 
 // parseVisitDate 診察日時をパースするヘルパー関数
@@ -284,8 +611,3 @@ func parseVisitDate(dateStr string) (time.Time, error) {
 
 	return time.Time{}, apperrors.WrapInvalidInput("invalid date format")
 }
-
-// generateRecordNo カルテ番号を生成するヘルパー関数
-func generateRecordNo() string {
-	return fmt.Sprintf("MR%d", time.Now().Unix())
-}
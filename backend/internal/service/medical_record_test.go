@@ -0,0 +1,232 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/auth"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestUpdateMedicalRecord(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("updates draft record under a row lock", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, mockRecordRepo, nil, WithTransactor(tx))
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusDraft}, nil)
+		mockRecordRepo.On("UpdateMedicalRecord", ctx, mock.MatchedBy(func(r *model.MedicalRecord) bool {
+			return r.Notes == "追記" && r.Status == model.MedicalRecordStatusDraft
+		})).Return(nil)
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, id.String()).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusDraft, Notes: "追記"}, nil)
+
+		notes := "追記"
+		record, err := svc.UpdateMedicalRecord(ctx, id.String(), &model.UpdateMedicalRecordRequest{Notes: &notes})
+
+		require.NoError(t, err)
+		assert.Equal(t, "追記", record.Notes)
+		assert.Equal(t, 1, tx.calls)
+		mockRecordRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects finalized record", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil)
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized}, nil)
+
+		notes := "追記"
+		_, err := svc.UpdateMedicalRecord(ctx, id.String(), &model.UpdateMedicalRecordRequest{Notes: &notes})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRecordRepo.AssertNotCalled(t, "UpdateMedicalRecord", mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteMedicalRecord_Finalized(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	mockRecordRepo := new(MockMedicalRecordRepository)
+	svc := New(nil, nil, mockRecordRepo, nil)
+
+	mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+		Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized}, nil)
+
+	err := svc.DeleteMedicalRecord(ctx, id.String())
+
+	assert.True(t, apperrors.IsConflict(err))
+	mockRecordRepo.AssertNotCalled(t, "DeleteMedicalRecord", mock.Anything, mock.Anything)
+}
+
+func TestFinalizeMedicalRecord(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("finalizes draft record", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, mockRecordRepo, nil, WithTransactor(tx))
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusDraft}, nil)
+		mockRecordRepo.On("UpdateMedicalRecord", ctx, mock.MatchedBy(func(r *model.MedicalRecord) bool {
+			return r.Status == model.MedicalRecordStatusFinalized && r.FinalizedAt != nil
+		})).Return(nil)
+		now := time.Now()
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, id.String()).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized, FinalizedAt: &now}, nil)
+
+		record, err := svc.FinalizeMedicalRecord(ctx, id.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, model.MedicalRecordStatusFinalized, record.Status)
+		assert.NotNil(t, record.FinalizedAt)
+		assert.Equal(t, 1, tx.calls)
+		mockRecordRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects already finalized record", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil)
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized}, nil)
+
+		_, err := svc.FinalizeMedicalRecord(ctx, id.String())
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRecordRepo.AssertNotCalled(t, "UpdateMedicalRecord", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAmendMedicalRecord(t *testing.T) {
+	id := uuid.New()
+
+	t.Run("records amendment for each changed field by the signed-in vet", func(t *testing.T) {
+		vetID := uuid.New()
+		ctx := auth.WithClaims(context.Background(), &auth.Claims{Subject: vetID.String(), Role: model.StaffRoleVeterinarian})
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, mockRecordRepo, nil, WithTransactor(tx))
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).Return(&model.MedicalRecord{
+			ID:        id,
+			Status:    model.MedicalRecordStatusFinalized,
+			Diagnosis: "膀胱炎",
+			Plan:      "抗生剤7日間",
+		}, nil)
+		mockRecordRepo.On("CreateMedicalRecordAmendments", ctx, mock.MatchedBy(func(a []model.MedicalRecordAmendment) bool {
+			return len(a) == 1 &&
+				a[0].MedicalRecordID == id &&
+				a[0].Field == "diagnosis" &&
+				a[0].OldValue == "膀胱炎" &&
+				a[0].NewValue == "尿石症" &&
+				a[0].Reason == "尿検査結果による訂正" &&
+				a[0].AmendedBy != nil && *a[0].AmendedBy == vetID
+		})).Return(nil)
+		mockRecordRepo.On("UpdateMedicalRecord", ctx, mock.MatchedBy(func(r *model.MedicalRecord) bool {
+			return r.Diagnosis == "尿石症"
+		})).Return(nil)
+		mockRecordRepo.On("GetMedicalRecordByID", ctx, id.String()).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusFinalized, Diagnosis: "尿石症"}, nil)
+
+		diagnosis := "尿石症"
+		plan := "抗生剤7日間"
+		record, err := svc.AmendMedicalRecord(ctx, id.String(), &model.AmendMedicalRecordRequest{
+			Reason:    "尿検査結果による訂正",
+			Diagnosis: &diagnosis,
+			Plan:      &plan,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "尿石症", record.Diagnosis)
+		assert.Equal(t, 1, tx.calls)
+		mockRecordRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects amendment of draft record", func(t *testing.T) {
+		ctx := context.Background()
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil)
+
+		mockRecordRepo.On("GetMedicalRecordByIDForUpdate", ctx, id).
+			Return(&model.MedicalRecord{ID: id, Status: model.MedicalRecordStatusDraft}, nil)
+
+		diagnosis := "尿石症"
+		_, err := svc.AmendMedicalRecord(ctx, id.String(), &model.AmendMedicalRecordRequest{
+			Reason:    "訂正",
+			Diagnosis: &diagnosis,
+		})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRecordRepo.AssertNotCalled(t, "CreateMedicalRecordAmendments", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires reason", func(t *testing.T) {
+		svc := New(nil, nil, new(MockMedicalRecordRepository), nil)
+
+		diagnosis := "尿石症"
+		_, err := svc.AmendMedicalRecord(context.Background(), id.String(), &model.AmendMedicalRecordRequest{Diagnosis: &diagnosis})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestSearchMedicalRecords(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns highlighted snippets per field", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil)
+
+		record := model.MedicalRecord{
+			ID:         uuid.New(),
+			Subjective: "昨夜から嘔吐が3回",
+			Objective:  "腹部触診で疼痛あり",
+			Assessment: "急性膵炎の疑い",
+			Plan:       "ﾒﾛｷｼｶﾑは中止し、膵炎の精査を行う",
+		}
+		from := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+		filter := model.MedicalRecordFilter{Species: "犬", VisitDate: model.DateRange{From: &from}}
+		opts := model.ListOptions{Limit: 10}
+		mockRecordRepo.On("SearchMedicalRecords", ctx, "('膵炎')", filter, opts).
+			Return(&model.ListResult[model.MedicalRecord]{Data: []model.MedicalRecord{record}, Meta: model.ListMeta{Total: 1, Limit: 10}}, nil)
+
+		result, err := svc.SearchMedicalRecords(ctx, &model.SearchMedicalRecordsRequest{
+			ListOptions: opts, Q: "膵炎", Species: "犬", DateFrom: "2024-04-01",
+		})
+
+		require.NoError(t, err)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, record.ID, result.Data[0].ID)
+		assert.Equal(t, []model.MedicalRecordSnippet{
+			{Field: "assessment", Text: "急性<mark>膵炎</mark>の疑い"},
+			{Field: "plan", Text: "ﾒﾛｷｼｶﾑは中止し、<mark>膵炎</mark>の精査を行う"},
+		}, result.Data[0].Snippets)
+		assert.Equal(t, int64(1), result.Meta.Total)
+	})
+
+	t.Run("rejects an empty query", func(t *testing.T) {
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil)
+
+		_, err := svc.SearchMedicalRecords(ctx, &model.SearchMedicalRecordsRequest{Q: " 、 "})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockRecordRepo.AssertNotCalled(t, "SearchMedicalRecords", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
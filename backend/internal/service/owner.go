@@ -9,11 +9,16 @@ import (
 
 	"github.com/animal-ekarte/backend/internal/errors"
 	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
 )
 
-// GetAllOwners retrieves all owners.
-func (s *Service) GetAllOwners(ctx context.Context) ([]model.Owner, error) {
-	return s.ownerRepo.GetAllOwners(ctx)
+// ListOwners retrieves a page of owners matching the search.
+// Pets are not loaded; fetch them per owner when needed.
+func (s *Service) ListOwners(ctx context.Context, req *model.ListOwnersRequest) (*model.ListResult[model.Owner], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	return s.ownerRepo.ListOwners(ctx, model.OwnerFilter{Search: req.Search}, req.ListOptions)
 }
 
 // GetOwnerByID retrieves an owner by ID.
@@ -93,6 +98,10 @@ func (s *Service) UpdateOwner(ctx context.Context, id string, req *model.UpdateO
 
 // DeleteOwner deletes an owner.
 func (s *Service) DeleteOwner(ctx context.Context, id string) error {
+	if err := authorizeRole(ctx, model.StaffRoleAdmin); err != nil {
+		return err
+	}
+
 	uid, err := uuid.Parse(id)
 	if err != nil {
 		return errors.ErrInvalidInput
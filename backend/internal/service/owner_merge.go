@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// OwnerMergeService 飼い主の重複検出・統合サービスインターフェース
+type OwnerMergeService interface {
+	FindOwnerDuplicates(ctx context.Context, req *model.FindOwnerDuplicatesRequest) ([]model.OwnerDuplicate, error)
+	MergeOwner(ctx context.Context, targetID string, req *model.MergeOwnerRequest) (*model.OwnerMergeResult, error)
+	GetOwnerMerges(ctx context.Context, ownerID string) ([]model.OwnerMerge, error)
+}
+
+// Ensure Service implements OwnerMergeService
+var _ OwnerMergeService = (*Service)(nil)
+
+// 重複候補の件数と点数
+const (
+	defaultDuplicateMinScore = 40
+	defaultDuplicateLimit    = 50
+	maxDuplicateLimit        = 200
+)
+
+// FindOwnerDuplicates 重複登録の可能性がある飼い主の組を点数の高い順に返す
+// 氏名・フリガナ・電話番号・メールアドレス・住所を表記ゆれをそろえて比べ、一致した項目の点数を合計する。
+// 点数での絞り込みと並び替えはリポジトリが行う。
+func (s *Service) FindOwnerDuplicates(ctx context.Context, req *model.FindOwnerDuplicatesRequest) ([]model.OwnerDuplicate, error) {
+	minScore := req.MinScore
+	if minScore == 0 {
+		minScore = defaultDuplicateMinScore
+	}
+	if minScore < 0 || minScore > 100 {
+		return nil, apperrors.WrapInvalidInput("min_score must be between 1 and 100")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultDuplicateLimit
+	}
+	if limit < 0 || limit > maxDuplicateLimit {
+		return nil, apperrors.WrapInvalidInput("limit must be between 1 and 200")
+	}
+	ownerID, err := parseOptionalID(req.OwnerID, "owner")
+	if err != nil {
+		return nil, err
+	}
+
+	pairs, err := s.ownerMergeRepo.FindDuplicateOwnerPairs(ctx, ownerID, minScore, limit)
+	if err != nil {
+		return nil, err
+	}
+	duplicates := make([]model.OwnerDuplicate, 0, len(pairs))
+	for _, pair := range pairs {
+		score, matches := scoreOwnerPair(pair.Owner, pair.Duplicate)
+		duplicates = append(duplicates, model.OwnerDuplicate{
+			Owner:     pair.Owner,
+			Duplicate: pair.Duplicate,
+			Score:     score,
+			Matches:   matches,
+		})
+	}
+	return duplicates, nil
+}
+
+// scoreOwnerPair 2人の飼い主の一致した項目と点数（リポジトリの照合と同じ照合用の値で比べる）
+func scoreOwnerPair(a, b model.Owner) (int, []string) {
+	a.UpdateSearchKeys()
+	b.UpdateSearchKeys()
+	score := 0
+	matches := []string{}
+	match := func(name, x, y string) {
+		if x != "" && x == y {
+			score += model.OwnerMatchPoints[name]
+			matches = append(matches, name)
+		}
+	}
+	match(model.OwnerMatchName, a.NameKey, b.NameKey)
+	match(model.OwnerMatchNameKana, a.NameKanaKey, b.NameKanaKey)
+	match(model.OwnerMatchPhone, a.PhoneDigits, b.PhoneDigits)
+	match(model.OwnerMatchEmail, a.EmailKey, b.EmailKey)
+	match(model.OwnerMatchAddress, a.AddressKey, b.AddressKey)
+	return score, matches
+}
+
+// MergeOwner 統合元の飼い主のペット・カルテ・予約・会計などを統合先へ移し、統合元を削除する
+// 統合先で未入力の連絡先は統合元の値で補い、備考は統合元の備考を追記する。
+// 統合元の登録内容と移した件数は統合記録として残す。すべて1つのトランザクションで行う。
+func (s *Service) MergeOwner(ctx context.Context, targetID string, req *model.MergeOwnerRequest) (*model.OwnerMergeResult, error) {
+	if err := authorizeRole(ctx, model.StaffRoleAdmin); err != nil {
+		return nil, err
+	}
+	target, err := uuid.Parse(targetID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid owner id")
+	}
+	source, err := uuid.Parse(req.SourceOwnerID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid source_owner_id")
+	}
+	if source == target {
+		return nil, apperrors.WrapInvalidInput("source_owner_id must differ from the target owner")
+	}
+
+	result := &model.OwnerMergeResult{}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		// 同時に逆向きの統合が行われてもデッドロックしないよう、IDの順にロックする
+		first, second := target, source
+		if source.String() < target.String() {
+			first, second = source, target
+		}
+		locked := map[uuid.UUID]*model.Owner{}
+		for _, id := range []uuid.UUID{first, second} {
+			owner, err := s.ownerMergeRepo.GetOwnerForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			locked[id] = owner
+		}
+		into, from := locked[target], locked[source]
+
+		moved, err := s.ownerMergeRepo.MoveOwnerLinkedRows(ctx, from.ID, into.ID)
+		if err != nil {
+			return err
+		}
+		if fillOwnerFrom(into, from) {
+			if err := s.ownerRepo.UpdateOwner(ctx, into); err != nil {
+				return apperrors.Wrap(err, "failed to update owner")
+			}
+		}
+
+		merge := model.OwnerMerge{
+			TargetOwnerID:     into.ID,
+			SourceOwnerID:     from.ID,
+			SourceOwnerNumber: from.OwnerNumber,
+			SourceName:        from.Name,
+			SourceNameKana:    from.NameKana,
+			SourcePhone:       from.Phone,
+			SourceEmail:       from.Email,
+			SourceAddress:     from.Address,
+			SourceNotes:       from.Notes,
+			MovedRows:         moved,
+			Reason:            req.Reason,
+			MergedBy:          currentStaffID(ctx),
+		}
+		if err := s.ownerMergeRepo.CreateOwnerMerge(ctx, &merge); err != nil {
+			return err
+		}
+		if err := s.ownerRepo.DeleteOwner(ctx, from.ID); err != nil {
+			return apperrors.Wrap(err, "failed to delete merged owner")
+		}
+
+		owner, err := s.ownerRepo.GetOwnerByID(ctx, into.ID)
+		if err != nil {
+			return apperrors.Wrap(err, "failed to get merged owner")
+		}
+		result.Owner = *owner
+		result.Merge = merge
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// fillOwnerFrom 統合先で未入力の連絡先を統合元の値で補い、統合元の備考を追記する（変更があればtrue）
+func fillOwnerFrom(into, from *model.Owner) bool {
+	changed := false
+	fill := func(dst *string, src string) {
+		if *dst == "" && src != "" {
+			*dst = src
+			changed = true
+		}
+	}
+	fill(&into.NameKana, from.NameKana)
+	fill(&into.Phone, from.Phone)
+	fill(&into.Email, from.Email)
+	fill(&into.Address, from.Address)
+	if from.Notes != "" && from.Notes != into.Notes {
+		if into.Notes == "" {
+			into.Notes = from.Notes
+		} else {
+			into.Notes += "\n" + from.Notes
+		}
+		changed = true
+	}
+	return changed
+}
+
+// GetOwnerMerges 飼い主に統合された飼い主の統合記録を古い順に取得
+func (s *Service) GetOwnerMerges(ctx context.Context, ownerID string) ([]model.OwnerMerge, error) {
+	id, err := uuid.Parse(ownerID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid owner id")
+	}
+	return s.ownerMergeRepo.GetOwnerMerges(ctx, id)
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockOwnerMergeRepository is a mock implementation of OwnerMergeRepository
+type MockOwnerMergeRepository struct {
+	mock.Mock
+}
+
+func (m *MockOwnerMergeRepository) FindDuplicateOwnerPairs(ctx context.Context, ownerID *uuid.UUID, minScore, limit int) ([]model.OwnerPair, error) {
+	args := m.Called(ctx, ownerID, minScore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OwnerPair), args.Error(1)
+}
+
+func (m *MockOwnerMergeRepository) GetOwnerForUpdate(ctx context.Context, id uuid.UUID) (*model.Owner, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Owner), args.Error(1)
+}
+
+func (m *MockOwnerMergeRepository) MoveOwnerLinkedRows(ctx context.Context, sourceID, targetID uuid.UUID) (model.OwnerMergeCounts, error) {
+	args := m.Called(ctx, sourceID, targetID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(model.OwnerMergeCounts), args.Error(1)
+}
+
+func (m *MockOwnerMergeRepository) CreateOwnerMerge(ctx context.Context, merge *model.OwnerMerge) error {
+	args := m.Called(ctx, merge)
+	return args.Error(0)
+}
+
+func (m *MockOwnerMergeRepository) GetOwnerMerges(ctx context.Context, targetOwnerID uuid.UUID) ([]model.OwnerMerge, error) {
+	args := m.Called(ctx, targetOwnerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.OwnerMerge), args.Error(1)
+}
+
+func TestFindOwnerDuplicates(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockOwnerMergeRepository)
+	svc := New(nil, nil, nil, nil, WithOwnerMergeRepository(mockRepo))
+
+	yamada := model.Owner{ID: uuid.New(), OwnerNumber: 1, Name: "山田 太郎", NameKana: "ヤマダ タロウ", Phone: "090-1234-5678", Address: "東京都港区芝公園4丁目2番8号"}
+	// 既定の最低点（40点）以上の組を点数の高い順に返す
+	pairs := []model.OwnerPair{
+		// 表記ゆれのある同一人物
+		{Owner: yamada, Duplicate: model.Owner{ID: uuid.New(), OwnerNumber: 7, Name: "山田太郎", NameKana: "やまだたろう", Phone: "09012345678", Address: "東京都港区芝公園４－２－８"}},
+		// 同じ家族の別名義（電話番号と住所が同じ）
+		{Owner: yamada, Duplicate: model.Owner{ID: uuid.New(), OwnerNumber: 9, Name: "山田 花子", NameKana: "ヤマダ ハナコ", Phone: "090-1234-5678", Address: "東京都港区芝公園4-2-8"}},
+	}
+	mockRepo.On("FindDuplicateOwnerPairs", ctx, (*uuid.UUID)(nil), 40, 50).Return(pairs, nil)
+
+	duplicates, err := svc.FindOwnerDuplicates(ctx, &model.FindOwnerDuplicatesRequest{})
+
+	require.NoError(t, err)
+	require.Len(t, duplicates, 2)
+	assert.Equal(t, 85, duplicates[0].Score)
+	assert.Equal(t, []string{"name", "name_kana", "phone", "address"}, duplicates[0].Matches)
+	assert.Equal(t, 7, duplicates[0].Duplicate.OwnerNumber)
+	assert.Equal(t, 40, duplicates[1].Score)
+	assert.Equal(t, []string{"phone", "address"}, duplicates[1].Matches)
+
+	// 同姓同名の別人は氏名だけの一致（最低点に届かない）
+	score, matches := scoreOwnerPair(yamada, model.Owner{Name: "山田太郎", Phone: "03-0000-0000"})
+	assert.Equal(t, 30, score)
+	assert.Equal(t, []string{"name"}, matches)
+
+	_, err = svc.FindOwnerDuplicates(ctx, &model.FindOwnerDuplicatesRequest{MinScore: 101})
+	assert.True(t, apperrors.IsInvalidInput(err))
+}
+
+func TestMergeOwner(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("moves linked rows and records the merge", func(t *testing.T) {
+		mockRepo := new(MockOwnerMergeRepository)
+		mockOwnerRepo := new(MockOwnerRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, mockOwnerRepo, nil, nil, WithOwnerMergeRepository(mockRepo), WithTransactor(tx))
+
+		target := &model.Owner{ID: uuid.New(), OwnerNumber: 1, Name: "山田 太郎", Phone: "090-1234-5678", Notes: "猫アレルギーあり"}
+		source := &model.Owner{ID: uuid.New(), OwnerNumber: 7, Name: "山田太郎", Email: "taro@example.com", Notes: "電話は夕方以降"}
+		moved := model.OwnerMergeCounts{"pets": 2, "medical_records": 5, "accountings": 3}
+
+		mockRepo.On("GetOwnerForUpdate", ctx, target.ID).Return(target, nil)
+		mockRepo.On("GetOwnerForUpdate", ctx, source.ID).Return(source, nil)
+		mockRepo.On("MoveOwnerLinkedRows", ctx, source.ID, target.ID).Return(moved, nil)
+		mockOwnerRepo.On("UpdateOwner", ctx, target).Return(nil)
+		var merge *model.OwnerMerge
+		mockRepo.On("CreateOwnerMerge", ctx, mock.AnythingOfType("*model.OwnerMerge")).
+			Run(func(args mock.Arguments) { merge = args.Get(1).(*model.OwnerMerge) }).
+			Return(nil)
+		mockOwnerRepo.On("DeleteOwner", ctx, source.ID).Return(nil)
+		mockOwnerRepo.On("GetOwnerByID", ctx, target.ID).Return(target, nil)
+
+		result, err := svc.MergeOwner(ctx, target.ID.String(), &model.MergeOwnerRequest{SourceOwnerID: source.ID.String(), Reason: "二重登録"})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, "taro@example.com", result.Owner.Email)
+		assert.Equal(t, "猫アレルギーあり\n電話は夕方以降", result.Owner.Notes)
+
+		require.NotNil(t, merge)
+		assert.Equal(t, target.ID, merge.TargetOwnerID)
+		assert.Equal(t, source.ID, merge.SourceOwnerID)
+		assert.Equal(t, 7, merge.SourceOwnerNumber)
+		assert.Equal(t, "山田太郎", merge.SourceName)
+		assert.Equal(t, moved, merge.MovedRows)
+		assert.Equal(t, "二重登録", merge.Reason)
+		mockOwnerRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects merging an owner into itself", func(t *testing.T) {
+		mockRepo := new(MockOwnerMergeRepository)
+		svc := New(nil, nil, nil, nil, WithOwnerMergeRepository(mockRepo))
+		id := uuid.New().String()
+
+		_, err := svc.MergeOwner(ctx, id, &model.MergeOwnerRequest{SourceOwnerID: id})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockRepo.AssertNotCalled(t, "MoveOwnerLinkedRows", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns not found without moving rows", func(t *testing.T) {
+		mockRepo := new(MockOwnerMergeRepository)
+		svc := New(nil, nil, nil, nil, WithOwnerMergeRepository(mockRepo))
+		target, source := uuid.New(), uuid.New()
+		mockRepo.On("GetOwnerForUpdate", ctx, mock.Anything).Return(nil, apperrors.WrapNotFound("owner", source.String()))
+
+		_, err := svc.MergeOwner(ctx, target.String(), &model.MergeOwnerRequest{SourceOwnerID: source.String()})
+
+		assert.True(t, apperrors.IsNotFound(err))
+		mockRepo.AssertNotCalled(t, "MoveOwnerLinkedRows", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
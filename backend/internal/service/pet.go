@@ -11,8 +11,21 @@ import (
 	"github.com/animal-ekarte/backend/internal/validation"
 )
 
-func (s *Service) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	return s.repo.GetAllPets(ctx)
+func (s *Service) ListPets(ctx context.Context, req *model.ListPetsRequest) (*model.ListResult[model.Pet], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	ownerID, err := parseOptionalID(req.OwnerID, "owner")
+	if err != nil {
+		return nil, err
+	}
+	filter := model.PetFilter{
+		OwnerID: ownerID,
+		Species: req.Species,
+		Status:  req.Status,
+		Search:  req.Search,
+	}
+	return s.repo.ListPets(ctx, filter, req.ListOptions)
 }
 
 func (s *Service) GetPetByID(ctx context.Context, id string) (*model.Pet, error) {
@@ -56,7 +69,24 @@ func (s *Service) CreatePet(ctx context.Context, req *model.CreatePetRequest) (*
 		pet.BirthDate = &t
 	}
 
-	if err := s.repo.CreatePet(ctx, pet); err != nil {
+	// 飼い主の履歴の最初の期間と、体重の最初の測定値もあわせて作成する
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreatePet(ctx, pet); err != nil {
+			return err
+		}
+		if err := s.petOwnershipRepo.CreatePetOwnership(ctx, &model.PetOwnership{
+			PetID:     pet.ID,
+			OwnerID:   pet.OwnerID,
+			StartedAt: today(),
+		}); err != nil {
+			return err
+		}
+		if pet.Weight == nil {
+			return nil
+		}
+		return s.petMeasurementRepo.CreatePetMeasurements(ctx, petWeightMeasurement(pet))
+	})
+	if err != nil {
 		return nil, err
 	}
 	return pet, nil
@@ -72,6 +102,10 @@ func (s *Service) UpdatePet(ctx context.Context, id string, req *model.UpdatePet
 	if err != nil {
 		return nil, err
 	}
+	// 飼い主の変更は履歴を残すため譲渡（POST /pets/:id/transfer）で行う
+	if req.OwnerID != "" && req.OwnerID != pet.OwnerID.String() {
+		return nil, apperrors.WrapInvalidInput("owner_id cannot be changed by update; use the pet transfer instead")
+	}
 
 	if req.Name != "" {
 		pet.Name = req.Name
@@ -85,6 +119,8 @@ func (s *Service) UpdatePet(ctx context.Context, id string, req *model.UpdatePet
 	if req.Gender != "" {
 		pet.Gender = req.Gender
 	}
+	// 体重が変わった場合は測定値の推移にも記録する
+	weightChanged := req.Weight > 0 && (pet.Weight == nil || *pet.Weight != req.Weight)
 	if req.Weight > 0 {
 		pet.Weight = &req.Weight
 	}
@@ -96,7 +132,16 @@ func (s *Service) UpdatePet(ctx context.Context, id string, req *model.UpdatePet
 		pet.BirthDate = &t
 	}
 
-	if err := s.repo.UpdatePet(ctx, pet); err != nil {
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdatePet(ctx, pet); err != nil {
+			return err
+		}
+		if !weightChanged {
+			return nil
+		}
+		return s.petMeasurementRepo.CreatePetMeasurements(ctx, petWeightMeasurement(pet))
+	})
+	if err != nil {
 		return nil, err
 	}
 	return pet, nil
@@ -17,12 +17,12 @@ type MockPetRepository struct {
 	mock.Mock
 }
 
-func (m *MockPetRepository) GetAllPets(ctx context.Context) ([]model.Pet, error) {
-	args := m.Called(ctx)
+func (m *MockPetRepository) ListPets(ctx context.Context, filter model.PetFilter, opts model.ListOptions) (*model.ListResult[model.Pet], error) {
+	args := m.Called(ctx, filter, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.Pet), args.Error(1)
+	return args.Get(0).(*model.ListResult[model.Pet]), args.Error(1)
 }
 
 func (m *MockPetRepository) GetPetByID(ctx context.Context, id uuid.UUID) (*model.Pet, error) {
@@ -52,12 +52,12 @@ type MockOwnerRepository struct {
 	mock.Mock
 }
 
-func (m *MockOwnerRepository) GetAllOwners(ctx context.Context) ([]model.Owner, error) {
-	args := m.Called(ctx)
+func (m *MockOwnerRepository) ListOwners(ctx context.Context, filter model.OwnerFilter, opts model.ListOptions) (*model.ListResult[model.Owner], error) {
+	args := m.Called(ctx, filter, opts)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).([]model.Owner), args.Error(1)
+	return args.Get(0).(*model.ListResult[model.Owner]), args.Error(1)
 }
 
 func (m *MockOwnerRepository) GetOwnerByID(ctx context.Context, id uuid.UUID) (*model.Owner, error) {
@@ -83,23 +83,30 @@ func (m *MockOwnerRepository) DeleteOwner(ctx context.Context, id uuid.UUID) err
 	return args.Error(0)
 }
 
-func TestGetAllPets(t *testing.T) {
+func TestListPets(t *testing.T) {
 	mockRepo := new(MockPetRepository)
 	mockOwnerRepo := new(MockOwnerRepository)
 	svc := New(mockRepo, mockOwnerRepo, nil, nil)
 	ctx := context.Background()
 
-	expectedPets := []model.Pet{
-		{ID: uuid.New(), Name: "Pochi"},
-	}
-
-	mockRepo.On("GetAllPets", ctx).Return(expectedPets, nil)
+	ownerID := uuid.New()
+	expected := &model.ListResult[model.Pet]{Data: []model.Pet{{ID: uuid.New(), Name: "Pochi"}}, Meta: model.ListMeta{Total: 1, Limit: 20}}
+	opts := model.ListOptions{Sort: "name"}
+	mockRepo.On("ListPets", ctx, model.PetFilter{OwnerID: &ownerID, Species: "犬"}, opts).Return(expected, nil)
 
-	pets, err := svc.GetAllPets(ctx)
+	pets, err := svc.ListPets(ctx, &model.ListPetsRequest{ListOptions: opts, OwnerID: ownerID.String(), Species: "犬"})
 
 	assert.NoError(t, err)
-	assert.Equal(t, expectedPets, pets)
+	assert.Equal(t, expected, pets)
 	mockRepo.AssertExpectations(t)
+
+	t.Run("rejects invalid parameters", func(t *testing.T) {
+		_, err := svc.ListPets(ctx, &model.ListPetsRequest{OwnerID: "invalid"})
+		assert.True(t, apperrors.IsInvalidInput(err))
+
+		_, err = svc.ListPets(ctx, &model.ListPetsRequest{ListOptions: model.ListOptions{Limit: 101}})
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
 }
 
 func TestGetPetByID(t *testing.T) {
@@ -123,7 +130,8 @@ func TestGetPetByID(t *testing.T) {
 func TestCreatePet(t *testing.T) {
 	mockRepo := new(MockPetRepository)
 	mockOwnerRepo := new(MockOwnerRepository)
-	svc := New(mockRepo, mockOwnerRepo, nil, nil)
+	mockOwnershipRepo := new(MockPetOwnershipRepository)
+	svc := New(mockRepo, mockOwnerRepo, nil, nil, WithPetOwnershipRepository(mockOwnershipRepo))
 	ctx := context.Background()
 
 	req := &model.CreatePetRequest{
@@ -135,6 +143,9 @@ func TestCreatePet(t *testing.T) {
 	mockRepo.On("CreatePet", ctx, mock.MatchedBy(func(p *model.Pet) bool {
 		return p.Name == req.Name && p.Species == req.Species
 	})).Return(nil)
+	mockOwnershipRepo.On("CreatePetOwnership", ctx, mock.MatchedBy(func(o *model.PetOwnership) bool {
+		return o.OwnerID.String() == req.OwnerID && o.EndedAt == nil
+	})).Return(nil)
 
 	pet, err := svc.CreatePet(ctx, req)
 
@@ -142,6 +153,7 @@ func TestCreatePet(t *testing.T) {
 	assert.NotNil(t, pet)
 	assert.Equal(t, req.Name, pet.Name)
 	mockRepo.AssertExpectations(t)
+	mockOwnershipRepo.AssertExpectations(t)
 }
 
 func TestCreatePet_Validation(t *testing.T) {
@@ -200,6 +212,20 @@ func TestUpdatePet(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestUpdatePet_OwnerChange(t *testing.T) {
+	mockRepo := new(MockPetRepository)
+	svc := New(mockRepo, nil, nil, nil)
+	ctx := context.Background()
+
+	id := uuid.New()
+	mockRepo.On("GetPetByID", ctx, id).Return(&model.Pet{ID: id, OwnerID: uuid.New(), Name: "Pochi", Species: "Dog"}, nil)
+
+	_, err := svc.UpdatePet(ctx, id.String(), &model.UpdatePetRequest{OwnerID: uuid.New().String()})
+
+	assert.True(t, apperrors.IsInvalidInput(err))
+	mockRepo.AssertNotCalled(t, "UpdatePet", mock.Anything, mock.Anything)
+}
+
 func TestUpdatePet_NotFound(t *testing.T) {
 	mockRepo := new(MockPetRepository)
 	mockOwnerRepo := new(MockOwnerRepository)
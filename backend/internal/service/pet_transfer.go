@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// PetTransferService ペットの譲渡・飼い主の履歴サービスインターフェース
+type PetTransferService interface {
+	TransferPet(ctx context.Context, petID string, req *model.TransferPetRequest) (*model.PetTransferResult, error)
+	GetPetOwnerships(ctx context.Context, petID string) ([]model.PetOwnership, error)
+}
+
+// Ensure Service implements PetTransferService
+var _ PetTransferService = (*Service)(nil)
+
+// TransferPet ペットを新しい飼い主へ譲渡し、飼い主の履歴に記録する
+// 過去のカルテ・会計などはその時点の飼い主のまま残し、譲渡日以降の未受付の予約・予約中のトリミングと、
+// 次回接種予定日が譲渡日以降の接種記録（接種案内の送り先）は新しい飼い主へ付け替える。
+// 未精算の会計は、open_accounting_payerがnew_ownerの場合だけ新しい飼い主へ付け替える（既定は前の飼い主が支払う）。
+func (s *Service) TransferPet(ctx context.Context, petID string, req *model.TransferPetRequest) (*model.PetTransferResult, error) {
+	id, err := uuid.Parse(petID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	newOwnerID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid owner ID format")
+	}
+	payer := req.OpenAccountingPayer
+	if payer == "" {
+		payer = model.OpenAccountingPayerPreviousOwner
+	}
+	if payer != model.OpenAccountingPayerPreviousOwner && payer != model.OpenAccountingPayerNewOwner {
+		return nil, apperrors.WrapInvalidInput("open_accounting_payer must be previous_owner or new_owner")
+	}
+	date := today()
+	if req.TransferDate != "" {
+		if date, err = parseDateOnly(req.TransferDate); err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid transfer_date format, expected YYYY-MM-DD")
+		}
+		if date.After(today()) {
+			return nil, apperrors.WrapInvalidInput("transfer_date must not be in the future")
+		}
+	}
+	if _, err := s.ownerRepo.GetOwnerByID(ctx, newOwnerID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, apperrors.WrapNotFound("owner", req.OwnerID)
+		}
+		return nil, err
+	}
+
+	result := &model.PetTransferResult{OpenAccountingPayer: payer}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		pet, err := s.petOwnershipRepo.GetPetForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if pet.OwnerID == newOwnerID {
+			return apperrors.WrapInvalidInput("pet already belongs to the owner")
+		}
+		previousOwnerID := pet.OwnerID
+
+		current, err := s.petOwnershipRepo.GetCurrentPetOwnership(ctx, pet.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			current = &model.PetOwnership{PetID: pet.ID, OwnerID: previousOwnerID, StartedAt: dateOf(pet.CreatedAt)}
+		}
+		if date.Before(current.StartedAt) {
+			return apperrors.WrapInvalidInput("transfer_date must not be before the current owner's start date")
+		}
+		current.EndedAt = &date
+		if current.ID == uuid.Nil {
+			err = s.petOwnershipRepo.CreatePetOwnership(ctx, current)
+		} else {
+			err = s.petOwnershipRepo.UpdatePetOwnership(ctx, current)
+		}
+		if err != nil {
+			return err
+		}
+
+		ownership := model.PetOwnership{
+			PetID:         pet.ID,
+			OwnerID:       newOwnerID,
+			StartedAt:     date,
+			Reason:        req.Reason,
+			TransferredBy: currentStaffID(ctx),
+		}
+		if err := s.petOwnershipRepo.CreatePetOwnership(ctx, &ownership); err != nil {
+			return err
+		}
+		if err := s.petOwnershipRepo.UpdatePetOwner(ctx, pet.ID, newOwnerID); err != nil {
+			return err
+		}
+
+		if payer == model.OpenAccountingPayerNewOwner {
+			if result.MovedAccountings, err = s.petOwnershipRepo.MoveOpenAccountings(ctx, pet.ID, previousOwnerID, newOwnerID); err != nil {
+				return err
+			}
+		}
+		// 予約の日時は現地時刻のため、譲渡日の0時（現地時刻）以降を付け替える
+		since := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+		if result.MovedReservations, err = s.petOwnershipRepo.MoveUpcomingReservations(ctx, pet.ID, previousOwnerID, newOwnerID, since); err != nil {
+			return err
+		}
+		if result.MovedTrimmings, err = s.petOwnershipRepo.MoveBookedTrimmings(ctx, pet.ID, previousOwnerID, newOwnerID, since); err != nil {
+			return err
+		}
+		// 次回接種予定日はdate型のため譲渡日と比べる
+		if result.MovedVaccinations, err = s.petOwnershipRepo.MoveUpcomingVaccinations(ctx, pet.ID, previousOwnerID, newOwnerID, date); err != nil {
+			return err
+		}
+
+		pet.OwnerID = newOwnerID
+		result.Pet = *pet
+		result.Ownership = ownership
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetPetOwnerships ペットの飼い主の履歴を古い順に取得
+func (s *Service) GetPetOwnerships(ctx context.Context, petID string) ([]model.PetOwnership, error) {
+	id, err := uuid.Parse(petID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	return s.petOwnershipRepo.GetPetOwnerships(ctx, id)
+}
+
+// dateOf 日時の日付部分（date型カラムとの比較用にUTCの0時とする）
+func dateOf(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
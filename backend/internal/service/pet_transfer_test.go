@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockPetOwnershipRepository is a mock implementation of PetOwnershipRepository
+type MockPetOwnershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockPetOwnershipRepository) GetPetForUpdate(ctx context.Context, id uuid.UUID) (*model.Pet, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Pet), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) GetCurrentPetOwnership(ctx context.Context, petID uuid.UUID) (*model.PetOwnership, error) {
+	args := m.Called(ctx, petID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.PetOwnership), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) GetPetOwnerships(ctx context.Context, petID uuid.UUID) ([]model.PetOwnership, error) {
+	args := m.Called(ctx, petID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.PetOwnership), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) CreatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error {
+	args := m.Called(ctx, ownership)
+	return args.Error(0)
+}
+
+func (m *MockPetOwnershipRepository) UpdatePetOwnership(ctx context.Context, ownership *model.PetOwnership) error {
+	args := m.Called(ctx, ownership)
+	return args.Error(0)
+}
+
+func (m *MockPetOwnershipRepository) UpdatePetOwner(ctx context.Context, petID, ownerID uuid.UUID) error {
+	args := m.Called(ctx, petID, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockPetOwnershipRepository) MoveOpenAccountings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, petID, fromOwnerID, toOwnerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) MoveUpcomingReservations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	args := m.Called(ctx, petID, fromOwnerID, toOwnerID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) MoveUpcomingVaccinations(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	args := m.Called(ctx, petID, fromOwnerID, toOwnerID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) MoveBookedTrimmings(ctx context.Context, petID, fromOwnerID, toOwnerID uuid.UUID, since time.Time) (int64, error) {
+	args := m.Called(ctx, petID, fromOwnerID, toOwnerID, since)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPetOwnershipRepository) BackfillPetOwnerships(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestTransferPet(t *testing.T) {
+	ctx := context.Background()
+	previousOwner, newOwner := uuid.New(), uuid.New()
+	transferDate := time.Date(2024, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	newMocks := func() (*Service, *MockPetOwnershipRepository, *model.PetOwnership, *model.Pet, *fakeTransactor) {
+		mockOwnerRepo := new(MockOwnerRepository)
+		mockOwnershipRepo := new(MockPetOwnershipRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, mockOwnerRepo, nil, nil, WithPetOwnershipRepository(mockOwnershipRepo), WithTransactor(tx))
+
+		pet := &model.Pet{ID: uuid.New(), OwnerID: previousOwner, Name: "ポチ"}
+		current := &model.PetOwnership{ID: uuid.New(), PetID: pet.ID, OwnerID: previousOwner, StartedAt: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}
+		mockOwnerRepo.On("GetOwnerByID", ctx, newOwner).Return(&model.Owner{ID: newOwner}, nil)
+		mockOwnershipRepo.On("GetPetForUpdate", ctx, pet.ID).Return(pet, nil)
+		mockOwnershipRepo.On("GetCurrentPetOwnership", ctx, pet.ID).Return(current, nil)
+		return svc, mockOwnershipRepo, current, pet, tx
+	}
+
+	t.Run("keeps open accountings with the previous owner by default", func(t *testing.T) {
+		svc, mockRepo, current, pet, tx := newMocks()
+		mockRepo.On("UpdatePetOwnership", ctx, current).Return(nil)
+		mockRepo.On("CreatePetOwnership", ctx, mock.AnythingOfType("*model.PetOwnership")).Return(nil)
+		mockRepo.On("UpdatePetOwner", ctx, pet.ID, newOwner).Return(nil)
+		since := time.Date(2024, 5, 10, 0, 0, 0, 0, time.Local)
+		mockRepo.On("MoveUpcomingReservations", ctx, pet.ID, previousOwner, newOwner, since).Return(int64(1), nil)
+		mockRepo.On("MoveBookedTrimmings", ctx, pet.ID, previousOwner, newOwner, since).Return(int64(1), nil)
+		mockRepo.On("MoveUpcomingVaccinations", ctx, pet.ID, previousOwner, newOwner, transferDate).Return(int64(2), nil)
+
+		result, err := svc.TransferPet(ctx, pet.ID.String(), &model.TransferPetRequest{
+			OwnerID: newOwner.String(), TransferDate: "2024-05-10", Reason: "親族へ譲渡",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, transferDate, *current.EndedAt)
+		assert.Equal(t, newOwner, result.Pet.OwnerID)
+		assert.Equal(t, newOwner, result.Ownership.OwnerID)
+		assert.Equal(t, transferDate, result.Ownership.StartedAt)
+		assert.Equal(t, "親族へ譲渡", result.Ownership.Reason)
+		assert.Equal(t, model.OpenAccountingPayerPreviousOwner, result.OpenAccountingPayer)
+		assert.Equal(t, int64(0), result.MovedAccountings)
+		assert.Equal(t, int64(1), result.MovedReservations)
+		assert.Equal(t, int64(1), result.MovedTrimmings)
+		assert.Equal(t, int64(2), result.MovedVaccinations)
+		mockRepo.AssertNotCalled(t, "MoveOpenAccountings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("moves open accountings to the new owner", func(t *testing.T) {
+		svc, mockRepo, current, pet, _ := newMocks()
+		mockRepo.On("UpdatePetOwnership", ctx, current).Return(nil)
+		mockRepo.On("CreatePetOwnership", ctx, mock.AnythingOfType("*model.PetOwnership")).Return(nil)
+		mockRepo.On("UpdatePetOwner", ctx, pet.ID, newOwner).Return(nil)
+		mockRepo.On("MoveOpenAccountings", ctx, pet.ID, previousOwner, newOwner).Return(int64(2), nil)
+		mockRepo.On("MoveUpcomingReservations", ctx, pet.ID, previousOwner, newOwner, mock.Anything).Return(int64(0), nil)
+		mockRepo.On("MoveBookedTrimmings", ctx, pet.ID, previousOwner, newOwner, mock.Anything).Return(int64(0), nil)
+		mockRepo.On("MoveUpcomingVaccinations", ctx, pet.ID, previousOwner, newOwner, mock.Anything).Return(int64(0), nil)
+
+		result, err := svc.TransferPet(ctx, pet.ID.String(), &model.TransferPetRequest{
+			OwnerID: newOwner.String(), TransferDate: "2024-05-10", OpenAccountingPayer: model.OpenAccountingPayerNewOwner,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), result.MovedAccountings)
+	})
+
+	t.Run("rejects a transfer date before the current ownership", func(t *testing.T) {
+		svc, mockRepo, _, pet, _ := newMocks()
+
+		_, err := svc.TransferPet(ctx, pet.ID.String(), &model.TransferPetRequest{OwnerID: newOwner.String(), TransferDate: "2019-12-31"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockRepo.AssertNotCalled(t, "UpdatePetOwner", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown payer", func(t *testing.T) {
+		svc, _, _, pet, _ := newMocks()
+
+		_, err := svc.TransferPet(ctx, pet.ID.String(), &model.TransferPetRequest{OwnerID: newOwner.String(), OpenAccountingPayer: "split"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
@@ -0,0 +1,362 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// ReservationService 予約サービスインターフェース
+type ReservationService interface {
+	ListReservations(ctx context.Context, req *model.ListReservationsRequest) (*model.ListResult[model.Reservation], error)
+	GetReservationByID(ctx context.Context, id string) (*model.Reservation, error)
+	CreateReservation(ctx context.Context, req *model.CreateReservationRequest) (*model.Reservation, error)
+	UpdateReservation(ctx context.Context, id string, req *model.UpdateReservationRequest) (*model.Reservation, error)
+	DeleteReservation(ctx context.Context, id string) error
+	CancelReservation(ctx context.Context, id string) (*model.Reservation, error)
+	CheckInReservation(ctx context.Context, id string) (*model.ReservationCheckInResult, error)
+}
+
+// Ensure Service implements ReservationService
+var _ ReservationService = (*Service)(nil)
+
+// ListReservations 条件に一致する予約を1ページ分取得（既定は開始時刻順）
+func (s *Service) ListReservations(ctx context.Context, req *model.ListReservationsRequest) (*model.ListResult[model.Reservation], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.ReservationFilter{
+		Status:      req.Status,
+		ServiceType: req.ServiceType,
+	}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.DoctorID, err = parseOptionalID(req.DoctorID, "doctor"); err != nil {
+		return nil, err
+	}
+	// 開始時刻は日時のため、院内の日付で区切る
+	if filter.StartTime, err = parseDateRange(req.DateFrom, req.DateTo, time.Local); err != nil {
+		return nil, err
+	}
+	return s.reservationRepo.ListReservations(ctx, filter, req.ListOptions)
+}
+
+// GetReservationByID IDで予約を取得
+func (s *Service) GetReservationByID(ctx context.Context, id string) (*model.Reservation, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid reservation ID format")
+	}
+	return s.reservationRepo.GetReservationByID(ctx, uid)
+}
+
+// CreateReservation 予約を作成
+// 指名予約の場合、同じ医師の指名予約と時間帯が重なると競合エラーを返す。
+func (s *Service) CreateReservation(ctx context.Context, req *model.CreateReservationRequest) (*model.Reservation, error) {
+	if err := validation.ValidateCreateReservation(req); err != nil {
+		return nil, err
+	}
+
+	petID, err := uuid.Parse(req.PetID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+
+	ownerID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid owner ID format")
+	}
+
+	var doctorID *uuid.UUID
+	if req.DoctorID != "" {
+		doctorUUID, err := uuid.Parse(req.DoctorID)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+		doctorID = &doctorUUID
+	}
+
+	startTime, endTime, err := parseReservationWindow(req.StartTime, req.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation := &model.Reservation{
+		PetID:        petID,
+		OwnerID:      ownerID,
+		DoctorID:     doctorID,
+		StartTime:    startTime,
+		EndTime:      endTime,
+		VisitType:    req.VisitType,
+		ServiceType:  req.ServiceType,
+		IsDesignated: req.IsDesignated,
+		Status:       req.Status,
+		Notes:        req.Notes,
+	}
+
+	if reservation.Status == "" {
+		reservation.Status = model.ReservationStatusPending
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkDoctorAvailability(ctx, reservation); err != nil {
+			return err
+		}
+		return s.reservationRepo.CreateReservation(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// UpdateReservation 予約を更新
+func (s *Service) UpdateReservation(ctx context.Context, id string, req *model.UpdateReservationRequest) (*model.Reservation, error) {
+	if err := validation.ValidateUpdateReservation(req); err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.PetID != nil {
+		petID, err := uuid.Parse(*req.PetID)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid pet ID format")
+		}
+		reservation.PetID = petID
+		reservation.Pet = nil
+	}
+
+	if req.OwnerID != nil {
+		ownerID, err := uuid.Parse(*req.OwnerID)
+		if err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid owner ID format")
+		}
+		reservation.OwnerID = ownerID
+		reservation.Owner = nil
+	}
+
+	if req.DoctorID != nil {
+		if *req.DoctorID == "" {
+			reservation.DoctorID = nil
+		} else {
+			doctorID, err := uuid.Parse(*req.DoctorID)
+			if err != nil {
+				return nil, apperrors.WrapInvalidInput("invalid doctor ID format")
+			}
+			reservation.DoctorID = &doctorID
+		}
+	}
+
+	if req.StartTime != nil || req.EndTime != nil {
+		start := reservation.StartTime.Format(time.RFC3339)
+		end := reservation.EndTime.Format(time.RFC3339)
+		if req.StartTime != nil {
+			start = *req.StartTime
+		}
+		if req.EndTime != nil {
+			end = *req.EndTime
+		}
+		startTime, endTime, err := parseReservationWindow(start, end)
+		if err != nil {
+			return nil, err
+		}
+		reservation.StartTime = startTime
+		reservation.EndTime = endTime
+	}
+
+	if req.VisitType != nil {
+		reservation.VisitType = *req.VisitType
+	}
+
+	if req.ServiceType != nil {
+		reservation.ServiceType = *req.ServiceType
+	}
+
+	if req.IsDesignated != nil {
+		reservation.IsDesignated = *req.IsDesignated
+	}
+
+	if req.Status != nil {
+		reservation.Status = *req.Status
+	}
+
+	if req.Notes != nil {
+		reservation.Notes = *req.Notes
+	}
+
+	if reservation.IsDesignated && reservation.DoctorID == nil {
+		return nil, apperrors.WrapInvalidInput("doctor ID is required for a designated reservation")
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkDoctorAvailability(ctx, reservation); err != nil {
+			return err
+		}
+		return s.reservationRepo.UpdateReservation(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// DeleteReservation 予約を削除
+func (s *Service) DeleteReservation(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid reservation ID format")
+	}
+	return s.reservationRepo.DeleteReservation(ctx, uid)
+}
+
+// CancelReservation 予約をキャンセル
+func (s *Service) CancelReservation(ctx context.Context, id string) (*model.Reservation, error) {
+	reservation, err := s.GetReservationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reservation.Status {
+	case model.ReservationStatusCanceled:
+		return nil, apperrors.WrapConflict("reservation is already canceled")
+	case model.ReservationStatusCompleted:
+		return nil, apperrors.WrapConflict("completed reservation cannot be canceled")
+	}
+
+	reservation.Status = model.ReservationStatusCanceled
+	if err := s.reservationRepo.UpdateReservation(ctx, reservation); err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CheckInReservation 予約の受付処理を行う
+// 予約を受付済にし、予約のペット・飼い主・医師・診察タイプから作成中のカルテを作成する。
+// 両方の書き込みは単一トランザクションで行う。
+func (s *Service) CheckInReservation(ctx context.Context, id string) (*model.ReservationCheckInResult, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid reservation ID format")
+	}
+
+	var result model.ReservationCheckInResult
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		reservation, err := s.reservationRepo.GetReservationByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status != model.ReservationStatusPending && reservation.Status != model.ReservationStatusConfirmed {
+			return apperrors.WrapConflict(fmt.Sprintf("reservation in status %s cannot be checked in", reservation.Status))
+		}
+
+		recordReq := &model.CreateMedicalRecordRequest{
+			PetID:     reservation.PetID.String(),
+			OwnerID:   reservation.OwnerID.String(),
+			VisitDate: time.Now().Format(time.RFC3339),
+			VisitType: medicalRecordVisitType(reservation.VisitType),
+			Status:    model.MedicalRecordStatusDraft,
+		}
+		if reservation.DoctorID != nil {
+			recordReq.DoctorID = reservation.DoctorID.String()
+		}
+
+		record, err := s.CreateMedicalRecord(ctx, recordReq)
+		if err != nil {
+			return err
+		}
+
+		reservation.Status = model.ReservationStatusCheckedIn
+		reservation.MedicalRecordID = &record.ID
+		if err := s.reservationRepo.UpdateReservation(ctx, reservation); err != nil {
+			return err
+		}
+
+		result.Reservation = reservation
+		result.MedicalRecord = record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// checkDoctorAvailability 指名予約が同じ医師の他の指名予約と重ならないことを確認する
+// 同時登録による二重予約を防ぐため、医師単位のロックを取得してから重複を検索する。
+func (s *Service) checkDoctorAvailability(ctx context.Context, reservation *model.Reservation) error {
+	if !reservation.IsDesignated || reservation.DoctorID == nil || reservation.Status == model.ReservationStatusCanceled {
+		return nil
+	}
+
+	if err := s.reservationRepo.LockDoctorSchedule(ctx, *reservation.DoctorID); err != nil {
+		return err
+	}
+
+	overlaps, err := s.reservationRepo.FindOverlappingReservations(ctx, *reservation.DoctorID, reservation.StartTime, reservation.EndTime, reservation.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(overlaps) > 0 {
+		conflict := overlaps[0]
+		return apperrors.WrapConflict(fmt.Sprintf(
+			"doctor %s already has a designated reservation from %s to %s",
+			reservation.DoctorID.String(),
+			conflict.StartTime.Format(time.RFC3339),
+			conflict.EndTime.Format(time.RFC3339),
+		))
+	}
+
+	return nil
+}
+
+// parseReservationWindow 予約の開始・終了時刻をパースし、開始が終了より前であることを確認する
+func parseReservationWindow(start, end string) (startTime, endTime time.Time, err error) {
+	startTime, err = parseVisitDate(start)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("invalid start time format")
+	}
+
+	endTime, err = parseVisitDate(end)
+	if err != nil {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("invalid end time format")
+	}
+
+	if !startTime.Before(endTime) {
+		return time.Time{}, time.Time{}, apperrors.WrapInvalidInput("start time must be before end time")
+	}
+
+	return startTime, endTime, nil
+}
+
+// medicalRecordVisitType 予約の診察タイプ(first/revisit)をカルテの診察タイプ(初診/再診)に変換する
+func medicalRecordVisitType(visitType string) string {
+	switch visitType {
+	case "first":
+		return "初診"
+	case "revisit":
+		return "再診"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+type MockReservationRepository struct {
+	mock.Mock
+}
+
+func (m *MockReservationRepository) ListReservations(ctx context.Context, filter model.ReservationFilter, opts model.ListOptions) (*model.ListResult[model.Reservation], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Reservation]), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetReservationByID(ctx context.Context, id uuid.UUID) (*model.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Reservation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) FindOverlappingReservations(ctx context.Context, doctorID uuid.UUID, start, end time.Time, excludeID uuid.UUID) ([]model.Reservation, error) {
+	args := m.Called(ctx, doctorID, start, end, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Reservation), args.Error(1)
+}
+
+func (m *MockReservationRepository) LockDoctorSchedule(ctx context.Context, doctorID uuid.UUID) error {
+	args := m.Called(ctx, doctorID)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) CreateReservation(ctx context.Context, reservation *model.Reservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) UpdateReservation(ctx context.Context, reservation *model.Reservation) error {
+	args := m.Called(ctx, reservation)
+	return args.Error(0)
+}
+
+func (m *MockReservationRepository) DeleteReservation(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockMedicalRecordRepository struct {
+	mock.Mock
+}
+
+func (m *MockMedicalRecordRepository) ListMedicalRecords(ctx context.Context, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.MedicalRecord]), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) SearchMedicalRecords(ctx context.Context, tsquery string, filter model.MedicalRecordFilter, opts model.ListOptions) (*model.ListResult[model.MedicalRecord], error) {
+	args := m.Called(ctx, tsquery, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.MedicalRecord]), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) RefreshSearchIndex(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordByID(ctx context.Context, id string) (*model.MedicalRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.MedicalRecord, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordsByPetID(ctx context.Context, petID string) ([]model.MedicalRecord, error) {
+	args := m.Called(ctx, petID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordsByOwnerID(ctx context.Context, ownerID string) ([]model.MedicalRecord, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecord), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) EnsureRecordNoSequence(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) NextRecordNo(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) CreateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) UpdateMedicalRecord(ctx context.Context, record *model.MedicalRecord) error {
+	args := m.Called(ctx, record)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) DeleteMedicalRecord(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) CreateMedicalRecordAmendments(ctx context.Context, amendments []model.MedicalRecordAmendment) error {
+	args := m.Called(ctx, amendments)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordAmendments(ctx context.Context, recordID string) ([]model.MedicalRecordAmendment, error) {
+	args := m.Called(ctx, recordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecordAmendment), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) GetMedicalRecordItems(ctx context.Context, recordID string) ([]model.MedicalRecordItem, error) {
+	args := m.Called(ctx, recordID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.MedicalRecordItem), args.Error(1)
+}
+
+func (m *MockMedicalRecordRepository) CreateMedicalRecordItem(ctx context.Context, item *model.MedicalRecordItem) error {
+	args := m.Called(ctx, item)
+	return args.Error(0)
+}
+
+func (m *MockMedicalRecordRepository) DeleteMedicalRecordItem(ctx context.Context, recordID, itemID string) error {
+	args := m.Called(ctx, recordID, itemID)
+	return args.Error(0)
+}
+
+// fakeTransactor records whether work was run inside a transaction.
+type fakeTransactor struct {
+	calls int
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	f.calls++
+	return fn(ctx)
+}
+
+func newReservationRequest(doctorID uuid.UUID, designated bool) *model.CreateReservationRequest {
+	return &model.CreateReservationRequest{
+		PetID:        uuid.New().String(),
+		OwnerID:      uuid.New().String(),
+		DoctorID:     doctorID.String(),
+		StartTime:    "2026-02-01T10:00:00+09:00",
+		EndTime:      "2026-02-01T10:30:00+09:00",
+		IsDesignated: designated,
+	}
+}
+
+func TestCreateReservation(t *testing.T) {
+	ctx := context.Background()
+	doctorID := uuid.New()
+
+	t.Run("creates designated reservation when slot is free", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		mockRepo.On("LockDoctorSchedule", ctx, doctorID).Return(nil)
+		mockRepo.On("FindOverlappingReservations", ctx, doctorID, mock.Anything, mock.Anything, uuid.Nil).
+			Return([]model.Reservation{}, nil)
+		mockRepo.On("CreateReservation", ctx, mock.AnythingOfType("*model.Reservation")).Return(nil)
+
+		reservation, err := svc.CreateReservation(ctx, newReservationRequest(doctorID, true))
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReservationStatusPending, reservation.Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects overlapping designated reservation", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		existing := model.Reservation{
+			ID:        uuid.New(),
+			DoctorID:  &doctorID,
+			StartTime: time.Date(2026, 2, 1, 1, 15, 0, 0, time.UTC),
+			EndTime:   time.Date(2026, 2, 1, 1, 45, 0, 0, time.UTC),
+		}
+		mockRepo.On("LockDoctorSchedule", ctx, doctorID).Return(nil)
+		mockRepo.On("FindOverlappingReservations", ctx, doctorID, mock.Anything, mock.Anything, uuid.Nil).
+			Return([]model.Reservation{existing}, nil)
+
+		reservation, err := svc.CreateReservation(ctx, newReservationRequest(doctorID, true))
+
+		assert.Nil(t, reservation)
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateReservation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skips conflict check for non-designated reservation", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		mockRepo.On("CreateReservation", ctx, mock.AnythingOfType("*model.Reservation")).Return(nil)
+
+		_, err := svc.CreateReservation(ctx, newReservationRequest(doctorID, false))
+
+		assert.NoError(t, err)
+		mockRepo.AssertNotCalled(t, "FindOverlappingReservations", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects end time before start time", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		req := newReservationRequest(doctorID, true)
+		req.EndTime = "2026-02-01T09:00:00+09:00"
+
+		_, err := svc.CreateReservation(ctx, req)
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestCancelReservation(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+
+	t.Run("cancels pending reservation", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		mockRepo.On("GetReservationByID", ctx, id).
+			Return(&model.Reservation{ID: id, Status: model.ReservationStatusPending}, nil)
+		mockRepo.On("UpdateReservation", ctx, mock.AnythingOfType("*model.Reservation")).Return(nil)
+
+		reservation, err := svc.CancelReservation(ctx, id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReservationStatusCanceled, reservation.Status)
+	})
+
+	t.Run("rejects already canceled reservation", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		svc := New(nil, nil, nil, nil, WithReservationRepository(mockRepo))
+
+		mockRepo.On("GetReservationByID", ctx, id).
+			Return(&model.Reservation{ID: id, Status: model.ReservationStatusCanceled}, nil)
+
+		_, err := svc.CancelReservation(ctx, id.String())
+
+		assert.True(t, apperrors.IsConflict(err))
+	})
+}
+
+func TestCheckInReservation(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	doctorID := uuid.New()
+
+	t.Run("checks in reservation and opens draft medical record", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, mockRecordRepo, nil, WithReservationRepository(mockRepo), WithTransactor(tx))
+
+		reservation := &model.Reservation{
+			ID:        id,
+			PetID:     uuid.New(),
+			OwnerID:   uuid.New(),
+			DoctorID:  &doctorID,
+			VisitType: "revisit",
+			Status:    model.ReservationStatusConfirmed,
+		}
+		mockRepo.On("GetReservationByIDForUpdate", ctx, id).Return(reservation, nil)
+		mockRecordRepo.On("NextRecordNo", ctx).Return("MR00000042", nil)
+		mockRecordRepo.On("CreateMedicalRecord", ctx, mock.MatchedBy(func(r *model.MedicalRecord) bool {
+			return r.RecordNo == "MR00000042" &&
+				r.PetID == reservation.PetID &&
+				r.OwnerID == reservation.OwnerID &&
+				r.DoctorID != nil && *r.DoctorID == doctorID &&
+				r.VisitType == "再診" &&
+				r.Status == model.MedicalRecordStatusDraft
+		})).Return(nil)
+		mockRepo.On("UpdateReservation", ctx, reservation).Return(nil)
+
+		result, err := svc.CheckInReservation(ctx, id.String())
+
+		assert.NoError(t, err)
+		assert.Equal(t, model.ReservationStatusCheckedIn, result.Reservation.Status)
+		assert.Equal(t, &result.MedicalRecord.ID, result.Reservation.MedicalRecordID)
+		assert.Equal(t, 1, tx.calls)
+		mockRepo.AssertExpectations(t)
+		mockRecordRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects reservation that is already checked in", func(t *testing.T) {
+		mockRepo := new(MockReservationRepository)
+		mockRecordRepo := new(MockMedicalRecordRepository)
+		svc := New(nil, nil, mockRecordRepo, nil, WithReservationRepository(mockRepo))
+
+		mockRepo.On("GetReservationByIDForUpdate", ctx, id).
+			Return(&model.Reservation{ID: id, Status: model.ReservationStatusCheckedIn}, nil)
+
+		_, err := svc.CheckInReservation(ctx, id.String())
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRecordRepo.AssertNotCalled(t, "CreateMedicalRecord", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/textnorm"
+)
+
+// PatientSearchService 患者検索サービスインターフェース
+type PatientSearchService interface {
+	SearchPatients(ctx context.Context, req *model.PatientSearchRequest) (*model.ListResult[model.PatientSearchHit], error)
+}
+
+// Ensure Service implements PatientSearchService
+var _ PatientSearchService = (*Service)(nil)
+
+// 患者検索の件数
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	// maxSearchQueryLength 検索語の最大文字数
+	maxSearchQueryLength = 100
+	// minPhoneDigits 電話番号の部分一致に使う最小の桁数
+	minPhoneDigits = 3
+)
+
+// SearchPatients 飼い主・ペットを横断して検索し、一致の度合いの高い順に返す
+// 氏名・フリガナ・ペットの名前はひらがな/カタカナ、全角/半角の違いを区別しない。
+// 数字だけの入力は、電話番号（ハイフンの有無を問わない）と飼い主番号でも検索する。
+func (s *Service) SearchPatients(ctx context.Context, req *model.PatientSearchRequest) (*model.ListResult[model.PatientSearchHit], error) {
+	if len([]rune(req.Q)) > maxSearchQueryLength {
+		return nil, apperrors.WrapInvalidInput("q must be less than 100 characters")
+	}
+	query := newPatientSearchQuery(req.Q)
+	if query.Text == "" {
+		return nil, apperrors.WrapInvalidInput("q is required")
+	}
+	limit := req.Limit
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+	if limit < 0 || limit > maxSearchLimit {
+		return nil, apperrors.WrapInvalidInput("limit must be between 1 and 50")
+	}
+
+	// 飼い主・ペットはそれぞれ一致の度合いの高い順に取得するため、合わせた上位limit件は各limit件に含まれる
+	owners, err := s.searchRepo.SearchOwners(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	pets, err := s.searchRepo.SearchPets(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	ownerTotal, err := s.searchRepo.CountOwners(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	petTotal, err := s.searchRepo.CountPets(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]model.PatientSearchHit, 0, len(owners)+len(pets))
+	for i := range owners {
+		if hit, ok := rankOwner(&owners[i], query); ok {
+			hits = append(hits, hit)
+		}
+	}
+	for i := range pets {
+		if hit, ok := rankPet(&pets[i], query); ok {
+			hits = append(hits, hit)
+		}
+	}
+	// 同点なら飼い主を先に、それ以外は取得した順（類似度・フリガナ順）のまま並べる
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Type == model.SearchHitOwner && hits[j].Type != model.SearchHitOwner
+	})
+
+	total := ownerTotal + petTotal
+	result := &model.ListResult[model.PatientSearchHit]{
+		Data: hits,
+		Meta: model.ListMeta{Total: total, Limit: limit, HasMore: total > int64(limit)},
+	}
+	if len(hits) > limit {
+		result.Data = hits[:limit]
+	}
+	return result, nil
+}
+
+// newPatientSearchQuery 入力を検索条件にする（電話番号・飼い主番号は数字だけの入力のときのみ）
+func newPatientSearchQuery(q string) model.PatientSearchQuery {
+	query := model.PatientSearchQuery{
+		Text: textnorm.Fold(q),
+		Code: textnorm.Code(q),
+	}
+	if !textnorm.IsNumeric(q) {
+		return query
+	}
+	digits := textnorm.Digits(q)
+	if len(digits) >= minPhoneDigits {
+		query.Digits = digits
+	}
+	if n, err := strconv.Atoi(digits); err == nil && len(digits) <= 9 {
+		query.OwnerNumber = &n
+	}
+	return query
+}
+
+// rankOwner 飼い主の最も一致度の高い項目と点数
+func rankOwner(owner *model.Owner, query model.PatientSearchQuery) (model.PatientSearchHit, bool) {
+	best := model.PatientSearchHit{Type: model.SearchHitOwner, Owner: owner}
+	consider := func(field string, score int) {
+		if score > best.Score {
+			best.Score, best.MatchedField = score, field
+		}
+	}
+	if query.OwnerNumber != nil && owner.OwnerNumber == *query.OwnerNumber {
+		consider("owner_number", model.SearchScoreExactCode)
+	}
+	consider("name", matchScore(textnorm.Fold(owner.Name), query.Text, model.SearchScoreExactName, model.SearchScorePrefixName, model.SearchScoreContainsName))
+	consider("name_kana", matchScore(textnorm.Fold(owner.NameKana), query.Text, model.SearchScoreExactName, model.SearchScorePrefixName, model.SearchScoreContainsName))
+	if query.Digits != "" {
+		phone := textnorm.Digits(owner.Phone)
+		switch {
+		case phone == query.Digits:
+			consider("phone", model.SearchScoreExactPhone)
+		case strings.HasSuffix(phone, query.Digits):
+			consider("phone", model.SearchScoreSuffixPhone)
+		case strings.Contains(phone, query.Digits):
+			consider("phone", model.SearchScoreContainsPhone)
+		}
+	}
+	return best, best.Score > 0
+}
+
+// rankPet ペットの最も一致度の高い項目と点数
+func rankPet(pet *model.Pet, query model.PatientSearchQuery) (model.PatientSearchHit, bool) {
+	best := model.PatientSearchHit{Type: model.SearchHitPet, Pet: pet}
+	consider := func(field string, score int) {
+		if score > best.Score {
+			best.Score, best.MatchedField = score, field
+		}
+	}
+	consider("pet_name", matchScore(textnorm.Fold(pet.Name), query.Text, model.SearchScoreExactName, model.SearchScorePrefixName, model.SearchScoreContainsName))
+	if query.Code != "" {
+		consider("pet_number", matchScore(textnorm.Code(pet.PetNumber), query.Code, model.SearchScoreExactCode, model.SearchScorePrefixCode, model.SearchScoreContainsCode))
+		consider("microchip_id", matchScore(textnorm.Code(pet.MicrochipID), query.Code, model.SearchScoreExactCode, model.SearchScorePrefixCode, model.SearchScoreContainsCode))
+	}
+	return best, best.Score > 0
+}
+
+// matchScore 完全一致・前方一致・部分一致に応じた点数（一致しなければ0）
+func matchScore(value, query string, exact, prefix, contains int) int {
+	switch {
+	case value == "" || query == "":
+		return 0
+	case value == query:
+		return exact
+	case strings.HasPrefix(value, query):
+		return prefix
+	case strings.Contains(value, query):
+		return contains
+	default:
+		return 0
+	}
+}
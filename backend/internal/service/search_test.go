@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockPatientSearchRepository is a mock implementation of PatientSearchRepository
+type MockPatientSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockPatientSearchRepository) SearchOwners(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Owner, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Owner), args.Error(1)
+}
+
+func (m *MockPatientSearchRepository) SearchPets(ctx context.Context, query model.PatientSearchQuery, limit int) ([]model.Pet, error) {
+	args := m.Called(ctx, query, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Pet), args.Error(1)
+}
+
+func (m *MockPatientSearchRepository) CountOwners(ctx context.Context, query model.PatientSearchQuery) (int64, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPatientSearchRepository) CountPets(ctx context.Context, query model.PatientSearchQuery) (int64, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPatientSearchRepository) EnsureSearchExtension(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockPatientSearchRepository) RefreshSearchKeys(ctx context.Context) (int, error) {
+	args := m.Called(ctx)
+	return args.Int(0), args.Error(1)
+}
+
+func TestSearchPatients(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("matches kana and width variants and ranks exact matches first", func(t *testing.T) {
+		mockRepo := new(MockPatientSearchRepository)
+		svc := New(nil, nil, nil, nil, WithPatientSearchRepository(mockRepo))
+
+		yamada := model.Owner{ID: uuid.New(), OwnerNumber: 30001, Name: "山田 太郎", NameKana: "ヤマダ タロウ"}
+		yamadaya := model.Owner{ID: uuid.New(), OwnerNumber: 30002, Name: "山田屋 花子", NameKana: "ヤマダヤ ハナコ"}
+		pet := model.Pet{ID: uuid.New(), Name: "ヤマト", PetNumber: "P-001"}
+		query := model.PatientSearchQuery{Text: "やまだ", Code: "やまだ"}
+		// リポジトリは同点をフリガナ順に返す
+		mockRepo.On("SearchOwners", ctx, query, defaultSearchLimit).Return([]model.Owner{yamada, yamadaya}, nil)
+		mockRepo.On("SearchPets", ctx, query, defaultSearchLimit).Return([]model.Pet{pet}, nil)
+		mockRepo.On("CountOwners", ctx, query).Return(int64(2), nil)
+		mockRepo.On("CountPets", ctx, query).Return(int64(0), nil)
+
+		result, err := svc.SearchPatients(ctx, &model.PatientSearchRequest{Q: "ﾔﾏﾀﾞ"})
+
+		require.NoError(t, err)
+		// ペットは名前が一致しないため除く。どちらも前方一致で同点のため取得した順
+		require.Len(t, result.Data, 2)
+		assert.Equal(t, yamada.ID, result.Data[0].Owner.ID)
+		assert.Equal(t, "name_kana", result.Data[0].MatchedField)
+		assert.Equal(t, model.SearchScorePrefixName, result.Data[0].Score)
+		assert.Equal(t, yamadaya.ID, result.Data[1].Owner.ID)
+		assert.Equal(t, int64(2), result.Meta.Total)
+		assert.False(t, result.Meta.HasMore)
+	})
+
+	t.Run("numeric input searches phone, owner number and identifiers", func(t *testing.T) {
+		mockRepo := new(MockPatientSearchRepository)
+		svc := New(nil, nil, nil, nil, WithPatientSearchRepository(mockRepo))
+
+		byPhone := model.Owner{ID: uuid.New(), OwnerNumber: 30010, Name: "佐藤", Phone: "090-1234-5678"}
+		byNumber := model.Owner{ID: uuid.New(), OwnerNumber: 5678, Name: "鈴木"}
+		chipped := model.Pet{ID: uuid.New(), Name: "ポチ", MicrochipID: "392141000005678"}
+		mockRepo.On("SearchOwners", ctx, mock.MatchedBy(func(q model.PatientSearchQuery) bool {
+			return q.Digits == "5678" && q.OwnerNumber != nil && *q.OwnerNumber == 5678
+		}), defaultSearchLimit).Return([]model.Owner{byNumber, byPhone}, nil)
+		mockRepo.On("SearchPets", ctx, mock.Anything, defaultSearchLimit).Return([]model.Pet{chipped}, nil)
+		mockRepo.On("CountOwners", ctx, mock.Anything).Return(int64(2), nil)
+		mockRepo.On("CountPets", ctx, mock.Anything).Return(int64(1), nil)
+
+		result, err := svc.SearchPatients(ctx, &model.PatientSearchRequest{Q: "５６７８"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Data, 3)
+		assert.Equal(t, byNumber.ID, result.Data[0].Owner.ID)
+		assert.Equal(t, "owner_number", result.Data[0].MatchedField)
+		assert.Equal(t, byPhone.ID, result.Data[1].Owner.ID)
+		assert.Equal(t, "phone", result.Data[1].MatchedField)
+		assert.Equal(t, model.SearchScoreSuffixPhone, result.Data[1].Score)
+		assert.Equal(t, model.SearchHitPet, result.Data[2].Type)
+		assert.Equal(t, "microchip_id", result.Data[2].MatchedField)
+	})
+
+	t.Run("phone hyphens are ignored", func(t *testing.T) {
+		owner := model.Owner{Name: "佐藤", Phone: "09012345678"}
+		hit, ok := rankOwner(&owner, newPatientSearchQuery("090-1234-5678"))
+
+		assert.True(t, ok)
+		assert.Equal(t, model.SearchScoreExactPhone, hit.Score)
+	})
+
+	t.Run("truncates to the limit", func(t *testing.T) {
+		mockRepo := new(MockPatientSearchRepository)
+		svc := New(nil, nil, nil, nil, WithPatientSearchRepository(mockRepo))
+		// 各リポジトリは上位limit件だけを返し、件数は別に数える
+		mockRepo.On("SearchOwners", ctx, mock.Anything, 1).Return([]model.Owner{{Name: "ポチ田"}}, nil)
+		mockRepo.On("SearchPets", ctx, mock.Anything, 1).Return([]model.Pet{{Name: "ポチ"}}, nil)
+		mockRepo.On("CountOwners", ctx, mock.Anything).Return(int64(2), nil)
+		mockRepo.On("CountPets", ctx, mock.Anything).Return(int64(1), nil)
+
+		result, err := svc.SearchPatients(ctx, &model.PatientSearchRequest{Q: "ぽち", Limit: 1})
+
+		require.NoError(t, err)
+		require.Len(t, result.Data, 1)
+		assert.Equal(t, "pet_name", result.Data[0].MatchedField)
+		assert.Equal(t, int64(3), result.Meta.Total)
+		assert.True(t, result.Meta.HasMore)
+	})
+
+	t.Run("rejects empty query", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil, WithPatientSearchRepository(new(MockPatientSearchRepository)))
+
+		_, err := svc.SearchPatients(ctx, &model.PatientSearchRequest{Q: "　"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
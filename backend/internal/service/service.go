@@ -1,29 +1,236 @@
 package service
 
 import (
+	"context"
 	"errors"
+	"time"
 
 	"gorm.io/gorm"
 
+	"github.com/animal-ekarte/backend/internal/auth"
+	"github.com/animal-ekarte/backend/internal/invoice"
+	"github.com/animal-ekarte/backend/internal/reminder"
 	"github.com/animal-ekarte/backend/internal/repository"
+	"github.com/animal-ekarte/backend/internal/storage"
 )
 
 // Service contains the business logic layer.
 type Service struct {
-	repo              repository.PetRepository
-	ownerRepo         repository.OwnerRepository
-	medicalRecordRepo repository.MedicalRecordRepository
-	db                interface{ DB() *gorm.DB }
+	repo                repository.PetRepository
+	ownerRepo           repository.OwnerRepository
+	medicalRecordRepo   repository.MedicalRecordRepository
+	reservationRepo     repository.ReservationRepository
+	auditEventRepo      repository.AuditEventRepository
+	authRepo            repository.AuthRepository
+	accountingRepo      repository.AccountingRepository
+	masterItemRepo      repository.MasterItemRepository
+	clinicRepo          repository.ClinicRepository
+	hospitalizationRepo repository.HospitalizationRepository
+	dailyRecordRepo     repository.DailyRecordRepository
+	vaccinationRepo     repository.VaccinationRepository
+	inventoryRepo       repository.InventoryRepository
+	searchRepo          repository.PatientSearchRepository
+	ownerMergeRepo      repository.OwnerMergeRepository
+	petOwnershipRepo    repository.PetOwnershipRepository
+	trimmingRepo        repository.TrimmingRepository
+	examinationRepo     repository.ExaminationRepository
+	analyzerImportRepo  repository.AnalyzerImportRepository
+	attachmentRepo      repository.AttachmentRepository
+	petMeasurementRepo  repository.PetMeasurementRepository
+	attachments         storage.Store
+	attachmentMaxSize   int64
+	notifiers           []reminder.Notifier
+	reminderLead        time.Duration
+	invoices            *invoice.Renderer
+	tokens              *auth.TokenManager
+	tx                  repository.Transactor
+	db                  interface{ DB() *gorm.DB }
+}
+
+// Option configures optional dependencies of the Service.
+type Option func(*Service)
+
+// WithReservationRepository sets the reservation repository.
+func WithReservationRepository(r repository.ReservationRepository) Option {
+	return func(s *Service) {
+		s.reservationRepo = r
+	}
+}
+
+// WithAuditEventRepository sets the audit event repository.
+func WithAuditEventRepository(r repository.AuditEventRepository) Option {
+	return func(s *Service) {
+		s.auditEventRepo = r
+	}
+}
+
+// WithAuthRepository sets the staff authentication repository.
+func WithAuthRepository(r repository.AuthRepository) Option {
+	return func(s *Service) {
+		s.authRepo = r
+	}
+}
+
+// WithAccountingRepository sets the accounting repository.
+func WithAccountingRepository(r repository.AccountingRepository) Option {
+	return func(s *Service) {
+		s.accountingRepo = r
+	}
+}
+
+// WithMasterItemRepository sets the master item repository.
+func WithMasterItemRepository(r repository.MasterItemRepository) Option {
+	return func(s *Service) {
+		s.masterItemRepo = r
+	}
+}
+
+// WithClinicRepository sets the clinic repository.
+func WithClinicRepository(r repository.ClinicRepository) Option {
+	return func(s *Service) {
+		s.clinicRepo = r
+	}
+}
+
+// WithHospitalizationRepository sets the hospitalization repository.
+func WithHospitalizationRepository(r repository.HospitalizationRepository) Option {
+	return func(s *Service) {
+		s.hospitalizationRepo = r
+	}
+}
+
+// WithDailyRecordRepository sets the inpatient daily record repository.
+func WithDailyRecordRepository(r repository.DailyRecordRepository) Option {
+	return func(s *Service) {
+		s.dailyRecordRepo = r
+	}
+}
+
+// WithVaccinationRepository sets the vaccination repository.
+func WithVaccinationRepository(r repository.VaccinationRepository) Option {
+	return func(s *Service) {
+		s.vaccinationRepo = r
+	}
+}
+
+// WithInventoryRepository sets the inventory repository.
+func WithInventoryRepository(r repository.InventoryRepository) Option {
+	return func(s *Service) {
+		s.inventoryRepo = r
+	}
+}
+
+// WithPatientSearchRepository sets the owner/pet search repository.
+func WithPatientSearchRepository(r repository.PatientSearchRepository) Option {
+	return func(s *Service) {
+		s.searchRepo = r
+	}
+}
+
+// WithOwnerMergeRepository sets the duplicate owner detection and merge repository.
+func WithOwnerMergeRepository(r repository.OwnerMergeRepository) Option {
+	return func(s *Service) {
+		s.ownerMergeRepo = r
+	}
+}
+
+// WithPetOwnershipRepository sets the pet ownership history repository.
+func WithPetOwnershipRepository(r repository.PetOwnershipRepository) Option {
+	return func(s *Service) {
+		s.petOwnershipRepo = r
+	}
+}
+
+// WithTrimmingRepository sets the trimming repository.
+func WithTrimmingRepository(r repository.TrimmingRepository) Option {
+	return func(s *Service) {
+		s.trimmingRepo = r
+	}
+}
+
+// WithExaminationRepository sets the examination and lab reference range repository.
+func WithExaminationRepository(r repository.ExaminationRepository) Option {
+	return func(s *Service) {
+		s.examinationRepo = r
+	}
+}
+
+// WithAnalyzerImportRepository sets the lab analyzer result import repository.
+func WithAnalyzerImportRepository(r repository.AnalyzerImportRepository) Option {
+	return func(s *Service) {
+		s.analyzerImportRepo = r
+	}
+}
+
+// WithAttachmentRepository sets the medical record and examination attachment repository.
+func WithAttachmentRepository(r repository.AttachmentRepository) Option {
+	return func(s *Service) {
+		s.attachmentRepo = r
+	}
+}
+
+// WithPetMeasurementRepository sets the pet weight and vitals history repository.
+func WithPetMeasurementRepository(r repository.PetMeasurementRepository) Option {
+	return func(s *Service) {
+		s.petMeasurementRepo = r
+	}
+}
+
+// WithAttachmentStorage sets where attachment files are stored and the maximum
+// upload size in bytes (0 keeps the default).
+func WithAttachmentStorage(store storage.Store, maxSize int64) Option {
+	return func(s *Service) {
+		s.attachments = store
+		if maxSize > 0 {
+			s.attachmentMaxSize = maxSize
+		}
+	}
+}
+
+// WithVaccinationReminders sets the notifiers used for vaccination reminders
+// and how long before the due date owners are notified.
+func WithVaccinationReminders(lead time.Duration, notifiers ...reminder.Notifier) Option {
+	return func(s *Service) {
+		s.reminderLead = lead
+		s.notifiers = notifiers
+	}
+}
+
+// WithInvoiceRenderer sets the renderer used for receipt and invoice PDFs.
+func WithInvoiceRenderer(r *invoice.Renderer) Option {
+	return func(s *Service) {
+		s.invoices = r
+	}
+}
+
+// WithTokenManager sets the token manager used to issue and verify access tokens.
+func WithTokenManager(tokens *auth.TokenManager) Option {
+	return func(s *Service) {
+		s.tokens = tokens
+	}
+}
+
+// WithTransactor sets the transaction manager used for multi-step writes.
+func WithTransactor(tx repository.Transactor) Option {
+	return func(s *Service) {
+		s.tx = tx
+	}
 }
 
 // New creates a new Service with the given repositories.
-func New(repo repository.PetRepository, ownerRepo repository.OwnerRepository, medicalRecordRepo repository.MedicalRecordRepository, db interface{ DB() *gorm.DB }) *Service {
-	return &Service{
+func New(repo repository.PetRepository, ownerRepo repository.OwnerRepository, medicalRecordRepo repository.MedicalRecordRepository, db interface{ DB() *gorm.DB }, opts ...Option) *Service {
+	s := &Service{
 		repo:              repo,
 		ownerRepo:         ownerRepo,
 		medicalRecordRepo: medicalRecordRepo,
 		db:                db,
+		reminderLead:      defaultReminderLead,
+		attachmentMaxSize: defaultAttachmentMaxSize,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
 // GetDB returns the database instance for health checks
@@ -33,3 +240,11 @@ func (s *Service) GetDB() (interface{ DB() *gorm.DB }, error) {
 	}
 	return s.db, nil
 }
+
+// withinTransaction runs fn in a single transaction when a Transactor is configured.
+func (s *Service) withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTransaction(ctx, fn)
+}
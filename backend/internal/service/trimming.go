@@ -0,0 +1,609 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// TrimmingService トリミングサービスインターフェース
+type TrimmingService interface {
+	ListTrimmings(ctx context.Context, req *model.ListTrimmingsRequest) (*model.ListResult[model.Trimming], error)
+	GetTrimmingByID(ctx context.Context, id string) (*model.Trimming, error)
+	CreateTrimming(ctx context.Context, req *model.CreateTrimmingRequest) (*model.Trimming, error)
+	UpdateTrimming(ctx context.Context, id string, req *model.UpdateTrimmingRequest) (*model.Trimming, error)
+	StartTrimming(ctx context.Context, id string) (*model.Trimming, error)
+	CompleteTrimming(ctx context.Context, id string, req *model.CompleteTrimmingRequest) (*model.TrimmingCompletionResult, error)
+	CancelTrimming(ctx context.Context, id string) (*model.Trimming, error)
+	GetTrimmingBoard(ctx context.Context, req *model.TrimmingBoardRequest) (*model.TrimmingBoard, error)
+}
+
+// Ensure Service implements TrimmingService
+var _ TrimmingService = (*Service)(nil)
+
+// トリミングの受付時間（院内の現地時刻）
+const (
+	trimmingOpenHour  = 9
+	trimmingCloseHour = 18
+)
+
+// ListTrimmings 条件に一致するトリミングを1ページ分取得（既定は開始日時順）
+func (s *Service) ListTrimmings(ctx context.Context, req *model.ListTrimmingsRequest) (*model.ListResult[model.Trimming], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.TrimmingFilter{Status: req.Status}
+	if filter.Status != "" {
+		if err := validation.ValidateTrimmingStatus(filter.Status); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.StaffID, err = parseOptionalID(req.StaffID, "staff"); err != nil {
+		return nil, err
+	}
+	// 開始日時は日時のため、院内の日付で区切る
+	if filter.AppointmentDate, err = parseDateRange(req.DateFrom, req.DateTo, time.Local); err != nil {
+		return nil, err
+	}
+	return s.trimmingRepo.ListTrimmings(ctx, filter, req.ListOptions)
+}
+
+// GetTrimmingByID IDでトリミングを取得
+func (s *Service) GetTrimmingByID(ctx context.Context, id string) (*model.Trimming, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid trimming ID format")
+	}
+	return s.trimmingRepo.GetTrimmingByID(ctx, uid)
+}
+
+// CreateTrimming トリミングを予約する
+// コース・オプションの価格と所要時間は予約日時点のマスタから計算する。
+// 担当トリマーの予約と時間帯が重なる場合や、同じ時間帯の予約がトリマーの人数を超える場合は競合エラーを返す。
+func (s *Service) CreateTrimming(ctx context.Context, req *model.CreateTrimmingRequest) (*model.Trimming, error) {
+	if err := validation.ValidateCreateTrimming(req); err != nil {
+		return nil, err
+	}
+	appointment, err := parseVisitDate(req.AppointmentDate)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid appointment date format")
+	}
+	pet, err := s.repo.GetPetByID(ctx, uuid.MustParse(req.PetID))
+	if err != nil {
+		return nil, err
+	}
+
+	trimming := &model.Trimming{
+		PetID:           pet.ID,
+		OwnerID:         pet.OwnerID,
+		AppointmentDate: appointment,
+		StyleRequest:    req.StyleRequest,
+		Status:          model.TrimmingStatusBooked,
+		Notes:           req.Notes,
+	}
+	if req.StaffID != "" {
+		staffID := uuid.MustParse(req.StaffID)
+		trimming.StaffID = &staffID
+	}
+	if err := s.priceTrimming(ctx, trimming, uuid.MustParse(req.CourseID), parseIDs(req.OptionIDs)); err != nil {
+		return nil, err
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.checkTrimmingCapacity(ctx, trimming); err != nil {
+			return err
+		}
+		return s.trimmingRepo.CreateTrimming(ctx, trimming)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.trimmingRepo.GetTrimmingByID(ctx, trimming.ID)
+}
+
+// UpdateTrimming 予約中のトリミングを変更する
+// コース・オプション・開始日時を変更した場合は、変更後の開始日時点のマスタで価格と所要時間を計算し直す。
+func (s *Service) UpdateTrimming(ctx context.Context, id string, req *model.UpdateTrimmingRequest) (*model.Trimming, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid trimming ID format")
+	}
+	if err := validation.ValidateUpdateTrimming(req); err != nil {
+		return nil, err
+	}
+
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		trimming, err := s.trimmingRepo.GetTrimmingByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if trimming.Status != model.TrimmingStatusBooked {
+			return apperrors.WrapConflict(fmt.Sprintf("trimming in status %s cannot be updated", trimming.Status))
+		}
+
+		if req.StaffID != nil {
+			trimming.StaffID = nil
+			if *req.StaffID != "" {
+				staffID := uuid.MustParse(*req.StaffID)
+				trimming.StaffID = &staffID
+			}
+		}
+		if req.StyleRequest != nil {
+			trimming.StyleRequest = *req.StyleRequest
+		}
+		if req.Notes != nil {
+			trimming.Notes = *req.Notes
+		}
+		if req.AppointmentDate != nil || req.CourseID != nil || req.OptionIDs != nil {
+			if req.AppointmentDate != nil {
+				if trimming.AppointmentDate, err = parseVisitDate(*req.AppointmentDate); err != nil {
+					return apperrors.WrapInvalidInput("invalid appointment date format")
+				}
+			}
+			if req.CourseID != nil {
+				courseID := uuid.MustParse(*req.CourseID)
+				trimming.CourseID = &courseID
+			}
+			if trimming.CourseID == nil {
+				return apperrors.WrapInvalidInput("course ID is required")
+			}
+			optionIDs := make([]uuid.UUID, len(trimming.Options))
+			for i, option := range trimming.Options {
+				optionIDs[i] = option.MasterID
+			}
+			if req.OptionIDs != nil {
+				optionIDs = parseIDs(*req.OptionIDs)
+			}
+			if err := s.priceTrimming(ctx, trimming, *trimming.CourseID, optionIDs); err != nil {
+				return err
+			}
+		}
+
+		if err := s.checkTrimmingCapacity(ctx, trimming); err != nil {
+			return err
+		}
+		return s.trimmingRepo.UpdateTrimming(ctx, trimming)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.trimmingRepo.GetTrimmingByID(ctx, uid)
+}
+
+// StartTrimming 予約中のトリミングを進行中にする
+func (s *Service) StartTrimming(ctx context.Context, id string) (*model.Trimming, error) {
+	return s.changeTrimmingStatus(ctx, id, func(trimming *model.Trimming) error {
+		if trimming.Status != model.TrimmingStatusBooked {
+			return apperrors.WrapConflict(fmt.Sprintf("trimming in status %s cannot be started", trimming.Status))
+		}
+		now := time.Now()
+		trimming.Status = model.TrimmingStatusInProgress
+		trimming.StartedAt = &now
+		return nil
+	})
+}
+
+// CancelTrimming 予約中・進行中のトリミングをキャンセルする
+func (s *Service) CancelTrimming(ctx context.Context, id string) (*model.Trimming, error) {
+	return s.changeTrimmingStatus(ctx, id, func(trimming *model.Trimming) error {
+		switch trimming.Status {
+		case model.TrimmingStatusBooked, model.TrimmingStatusInProgress:
+		case model.TrimmingStatusCanceled:
+			return apperrors.WrapConflict("trimming is already canceled")
+		default:
+			return apperrors.WrapConflict(fmt.Sprintf("trimming in status %s cannot be canceled", trimming.Status))
+		}
+		trimming.Status = model.TrimmingStatusCanceled
+		return nil
+	})
+}
+
+// CompleteTrimming トリミングを完了にする
+// create_accountingを指定するとコース・オプションの会計を新規に作成し、accounting_idを指定すると
+// 同じペット・飼い主の未収・保留の会計に明細を追加する。いずれも完了と同じトランザクションで行う。
+func (s *Service) CompleteTrimming(ctx context.Context, id string, req *model.CompleteTrimmingRequest) (*model.TrimmingCompletionResult, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid trimming ID format")
+	}
+	if err := validation.ValidateCompleteTrimming(req); err != nil {
+		return nil, err
+	}
+
+	var result model.TrimmingCompletionResult
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		trimming, err := s.trimmingRepo.GetTrimmingByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		switch trimming.Status {
+		case model.TrimmingStatusBooked, model.TrimmingStatusInProgress:
+		case model.TrimmingStatusCompleted:
+			return apperrors.WrapConflict("trimming is already completed")
+		default:
+			return apperrors.WrapConflict(fmt.Sprintf("trimming in status %s cannot be completed", trimming.Status))
+		}
+
+		now := time.Now()
+		trimming.Status = model.TrimmingStatusCompleted
+		trimming.CompletedAt = &now
+		if err := s.trimmingRepo.UpdateTrimming(ctx, trimming); err != nil {
+			return err
+		}
+
+		switch {
+		case req.CreateAccounting:
+			accounting, err := s.accountingFromTrimming(ctx, trimming)
+			if err != nil {
+				return err
+			}
+			result.Accounting = accounting
+		case req.AccountingID != "":
+			items, err := s.trimmingAccountingItems(ctx, trimming)
+			if err != nil {
+				return err
+			}
+			accounting, err := s.modifyAccounting(ctx, req.AccountingID, func(ctx context.Context, accounting *model.Accounting) error {
+				if accounting.PetID != trimming.PetID || accounting.OwnerID != trimming.OwnerID {
+					return apperrors.WrapInvalidInput("accounting must be for the same pet and owner as the trimming")
+				}
+				for _, item := range items {
+					item.AccountingID = accounting.ID
+					if err := s.accountingRepo.CreateAccountingItem(ctx, &item); err != nil {
+						return err
+					}
+					accounting.AccountingItems = append(accounting.AccountingItems, item)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			result.Accounting = accounting
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Trimming, err = s.trimmingRepo.GetTrimmingByID(ctx, uid); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetTrimmingBoard 1日のトリミングをトリマーごとに並べ、予約済み・空きの時間（分）を集計する
+// 担当が未割当、または担当が在籍中のトリマーでないトリミングはunassignedに入れる。
+func (s *Service) GetTrimmingBoard(ctx context.Context, req *model.TrimmingBoardRequest) (*model.TrimmingBoard, error) {
+	day := time.Now()
+	if req.Date != "" {
+		var err error
+		if day, err = time.ParseInLocation("2006-01-02", req.Date, time.Local); err != nil {
+			return nil, apperrors.WrapInvalidInput("invalid date format, expected YYYY-MM-DD")
+		}
+	}
+	open, closing := trimmingHours(day)
+	dayStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.Local)
+
+	groomers, err := s.trimmingRepo.ListGroomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trimmings, err := s.trimmingRepo.FindOverlappingTrimmings(ctx, dayStart, dayStart.AddDate(0, 0, 1), uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	board := &model.TrimmingBoard{
+		Date:       dayStart.Format("2006-01-02"),
+		OpenTime:   open.Format("15:04"),
+		CloseTime:  closing.Format("15:04"),
+		Groomers:   make([]model.TrimmingBoardColumn, len(groomers)),
+		Unassigned: []model.Trimming{},
+	}
+	columns := make(map[uuid.UUID]*model.TrimmingBoardColumn, len(groomers))
+	for i, groomer := range groomers {
+		board.Groomers[i] = model.TrimmingBoardColumn{Staff: groomer, Trimmings: []model.Trimming{}}
+		columns[groomer.ID] = &board.Groomers[i]
+	}
+	for _, trimming := range trimmings {
+		var column *model.TrimmingBoardColumn
+		if trimming.StaffID != nil {
+			column = columns[*trimming.StaffID]
+		}
+		if column == nil {
+			board.Unassigned = append(board.Unassigned, trimming)
+			continue
+		}
+		column.Trimmings = append(column.Trimmings, trimming)
+		column.BookedMinutes += overlapMinutes(trimming.AppointmentDate, trimming.EndTime(), open, closing)
+	}
+	opening := int(closing.Sub(open) / time.Minute)
+	for i := range board.Groomers {
+		board.Groomers[i].AvailableMinutes = max(opening-board.Groomers[i].BookedMinutes, 0)
+	}
+	return board, nil
+}
+
+// changeTrimmingStatus トリミングを行ロックして状態を変更し、保存する
+func (s *Service) changeTrimmingStatus(ctx context.Context, id string, fn func(trimming *model.Trimming) error) (*model.Trimming, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid trimming ID format")
+	}
+	err = s.withinTransaction(ctx, func(ctx context.Context) error {
+		trimming, err := s.trimmingRepo.GetTrimmingByIDForUpdate(ctx, uid)
+		if err != nil {
+			return err
+		}
+		if err := fn(trimming); err != nil {
+			return err
+		}
+		return s.trimmingRepo.UpdateTrimming(ctx, trimming)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.trimmingRepo.GetTrimmingByID(ctx, uid)
+}
+
+// priceTrimming コースとオプションのマスタから、予約日時点の価格と所要時間をトリミングに写す
+// コースは所要時間と価格の設定が必須。オプションの所要時間は未設定なら0分とする。
+func (s *Service) priceTrimming(ctx context.Context, trimming *model.Trimming, courseID uuid.UUID, optionIDs []uuid.UUID) error {
+	masters, err := s.masterItemRepo.GetMasterItemsByIDs(ctx, append([]uuid.UUID{courseID}, optionIDs...))
+	if err != nil {
+		return err
+	}
+	byID := make(map[uuid.UUID]*model.MasterItem, len(masters))
+	ptrs := make([]*model.MasterItem, len(masters))
+	for i := range masters {
+		byID[masters[i].ID] = &masters[i]
+		ptrs[i] = &masters[i]
+	}
+	if err := s.applyEffectivePrices(ctx, dateOf(trimming.AppointmentDate.In(time.Local)), ptrs...); err != nil {
+		return err
+	}
+
+	course, err := trimmingMaster(byID, courseID, model.MasterCategoryTrimmingCourse)
+	if err != nil {
+		return err
+	}
+	if course.DurationMinutes == nil || *course.DurationMinutes <= 0 {
+		return apperrors.WrapInvalidInput("course " + course.Code + " has no duration")
+	}
+	total := *course.Price
+	duration := *course.DurationMinutes
+
+	options := make(model.TrimmingOptions, 0, len(optionIDs))
+	for _, id := range optionIDs {
+		master, err := trimmingMaster(byID, id, model.MasterCategoryTrimmingOption)
+		if err != nil {
+			return err
+		}
+		option := model.TrimmingOption{MasterID: master.ID, Code: master.Code, Name: master.Name, Price: *master.Price}
+		if master.DurationMinutes != nil {
+			option.DurationMinutes = *master.DurationMinutes
+		}
+		options = append(options, option)
+		total = total.Add(option.Price)
+		duration += option.DurationMinutes
+	}
+
+	trimming.CourseID = &course.ID
+	trimming.Course = course.Name
+	trimming.CoursePrice = course.Price
+	trimming.Options = options
+	trimming.TotalPrice = total.Ptr()
+	trimming.DurationMinutes = duration
+	return nil
+}
+
+// trimmingMaster トリミングのコース・オプションとして使えるマスタを取り出す
+func trimmingMaster(byID map[uuid.UUID]*model.MasterItem, id uuid.UUID, category string) (*model.MasterItem, error) {
+	master, ok := byID[id]
+	if !ok {
+		return nil, apperrors.WrapNotFound("master item", id.String())
+	}
+	if master.Category != category {
+		return nil, apperrors.WrapInvalidInput("master item " + master.Code + " is not a " + category)
+	}
+	if master.Status == model.MasterStatusInactive {
+		return nil, apperrors.WrapInvalidInput("master item " + master.Code + " is inactive")
+	}
+	if master.Price == nil {
+		return nil, apperrors.WrapInvalidInput("master item " + master.Code + " has no price")
+	}
+	return master, nil
+}
+
+// checkTrimmingCapacity トリミングが受付時間内で、担当トリマーと店全体の空きがあることを確認する
+// 同時登録による超過を防ぐため、日単位のロックを取得してから重なる予約を検索する。
+// 担当未割当の予約も、いずれかのトリマーが担当する前提で店全体の枠を使う。
+func (s *Service) checkTrimmingCapacity(ctx context.Context, trimming *model.Trimming) error {
+	start, end := trimming.AppointmentDate, trimming.EndTime()
+	open, closing := trimmingHours(start.In(time.Local))
+	if start.Before(open) || end.After(closing) {
+		return apperrors.WrapInvalidInput(fmt.Sprintf("trimming must be between %s and %s", open.Format("15:04"), closing.Format("15:04")))
+	}
+
+	groomers, err := s.trimmingRepo.ListGroomers(ctx)
+	if err != nil {
+		return err
+	}
+	if trimming.StaffID != nil && !slices.ContainsFunc(groomers, func(g model.Staff) bool { return g.ID == *trimming.StaffID }) {
+		return apperrors.WrapInvalidInput("staff " + trimming.StaffID.String() + " is not an active groomer")
+	}
+
+	if err := s.trimmingRepo.LockTrimmingSchedule(ctx, open); err != nil {
+		return err
+	}
+	overlaps, err := s.trimmingRepo.FindOverlappingTrimmings(ctx, start, end, trimming.ID)
+	if err != nil {
+		return err
+	}
+
+	if trimming.StaffID != nil {
+		for _, other := range overlaps {
+			if other.StaffID != nil && *other.StaffID == *trimming.StaffID {
+				return apperrors.WrapConflict(fmt.Sprintf(
+					"groomer %s already has a trimming from %s to %s",
+					trimming.StaffID.String(),
+					other.AppointmentDate.Format(time.RFC3339),
+					other.EndTime().Format(time.RFC3339),
+				))
+			}
+		}
+	}
+	if peakTrimmings(overlaps, start, end)+1 > len(groomers) {
+		return apperrors.WrapConflict(fmt.Sprintf(
+			"no groomer is available from %s to %s",
+			start.Format(time.RFC3339),
+			end.Format(time.RFC3339),
+		))
+	}
+	return nil
+}
+
+// peakTrimmings [start, end)の間で同時に行われるトリミングの最大数
+// 同時数が変わるのは開始時刻だけのため、区間の開始と各トリミングの開始時点で数える。
+func peakTrimmings(trimmings []model.Trimming, start, end time.Time) int {
+	points := []time.Time{start}
+	for _, t := range trimmings {
+		if t.AppointmentDate.After(start) && t.AppointmentDate.Before(end) {
+			points = append(points, t.AppointmentDate)
+		}
+	}
+	peak := 0
+	for _, point := range points {
+		n := 0
+		for _, t := range trimmings {
+			if !t.AppointmentDate.After(point) && t.EndTime().After(point) {
+				n++
+			}
+		}
+		peak = max(peak, n)
+	}
+	return peak
+}
+
+// trimmingHours 指定日のトリミングの受付開始・終了日時（現地時刻）
+func trimmingHours(day time.Time) (open, closing time.Time) {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, trimmingOpenHour, 0, 0, 0, time.Local),
+		time.Date(y, m, d, trimmingCloseHour, 0, 0, 0, time.Local)
+}
+
+// overlapMinutes [start, end)のうち[from, to)と重なる時間（分）
+func overlapMinutes(start, end, from, to time.Time) int {
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	if !start.Before(end) {
+		return 0
+	}
+	return int(end.Sub(start) / time.Minute)
+}
+
+// accountingFromTrimming トリミングのコース・オプションから会計を作成する
+func (s *Service) accountingFromTrimming(ctx context.Context, trimming *model.Trimming) (*model.Accounting, error) {
+	items, err := s.trimmingAccountingItems(ctx, trimming)
+	if err != nil {
+		return nil, err
+	}
+	accounting := &model.Accounting{
+		TrimmingID:      &trimming.ID,
+		PetID:           trimming.PetID,
+		OwnerID:         trimming.OwnerID,
+		ScheduledDate:   today(),
+		Status:          model.AccountingStatusUnpaid,
+		AccountingItems: items,
+	}
+	if err := calculateAccounting(accounting); err != nil {
+		return nil, err
+	}
+	if err := s.accountingRepo.CreateAccounting(ctx, accounting); err != nil {
+		return nil, err
+	}
+	return accounting, nil
+}
+
+// trimmingAccountingItems トリミングのコース・オプションの会計明細を作成する
+// 単価は予約時に写した価格を使い、コード・税率・保険適用はマスタから取る（マスタが削除済みなら標準税率）。
+func (s *Service) trimmingAccountingItems(ctx context.Context, trimming *model.Trimming) ([]model.AccountingItem, error) {
+	type line struct {
+		masterID *uuid.UUID
+		category string
+		name     string
+		price    *decimal.Decimal
+	}
+	lines := []line{{trimming.CourseID, model.MasterCategoryTrimmingCourse, trimming.Course, trimming.CoursePrice}}
+	for _, option := range trimming.Options {
+		lines = append(lines, line{&option.MasterID, model.MasterCategoryTrimmingOption, option.Name, option.Price.Ptr()})
+	}
+
+	var ids []uuid.UUID
+	for _, l := range lines {
+		if l.masterID != nil {
+			ids = append(ids, *l.masterID)
+		}
+	}
+	masters, err := s.masterItemRepo.GetMasterItemsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*model.MasterItem, len(masters))
+	for i := range masters {
+		byID[masters[i].ID] = &masters[i]
+	}
+
+	items := make([]model.AccountingItem, 0, len(lines))
+	for _, l := range lines {
+		if l.price == nil {
+			continue
+		}
+		item := model.AccountingItem{
+			MasterID: l.masterID,
+			Category: l.category,
+			Quantity: 1,
+			TaxRate:  defaultTaxRate.Ptr(),
+			Source:   model.AccountingItemSourceTrimming,
+		}
+		if l.masterID != nil {
+			if master, ok := byID[*l.masterID]; ok {
+				item = accountingItemFromMaster(master, 1, model.AccountingItemSourceTrimming)
+			}
+		}
+		item.Name = l.name
+		item.UnitPrice = l.price
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// parseIDs 検証済みのID文字列をUUIDに変換する
+func parseIDs(ids []string) []uuid.UUID {
+	uids := make([]uuid.UUID, len(ids))
+	for i, id := range ids {
+		uids[i] = uuid.MustParse(id)
+	}
+	return uids
+}
@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// MockTrimmingRepository is a mock implementation of TrimmingRepository
+type MockTrimmingRepository struct {
+	mock.Mock
+}
+
+func (m *MockTrimmingRepository) ListTrimmings(ctx context.Context, filter model.TrimmingFilter, opts model.ListOptions) (*model.ListResult[model.Trimming], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Trimming]), args.Error(1)
+}
+
+func (m *MockTrimmingRepository) GetTrimmingByID(ctx context.Context, id uuid.UUID) (*model.Trimming, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockTrimmingRepository) GetTrimmingByIDForUpdate(ctx context.Context, id uuid.UUID) (*model.Trimming, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Trimming), args.Error(1)
+}
+
+func (m *MockTrimmingRepository) FindOverlappingTrimmings(ctx context.Context, start, end time.Time, excludeID uuid.UUID) ([]model.Trimming, error) {
+	args := m.Called(ctx, start, end, excludeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Trimming), args.Error(1)
+}
+
+func (m *MockTrimmingRepository) LockTrimmingSchedule(ctx context.Context, date time.Time) error {
+	args := m.Called(ctx, date)
+	return args.Error(0)
+}
+
+func (m *MockTrimmingRepository) ListGroomers(ctx context.Context) ([]model.Staff, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Staff), args.Error(1)
+}
+
+func (m *MockTrimmingRepository) CreateTrimming(ctx context.Context, trimming *model.Trimming) error {
+	args := m.Called(ctx, trimming)
+	return args.Error(0)
+}
+
+func (m *MockTrimmingRepository) UpdateTrimming(ctx context.Context, trimming *model.Trimming) error {
+	args := m.Called(ctx, trimming)
+	return args.Error(0)
+}
+
+// trimmingMasters シャンプーコース（60分）と爪切り・歯磨きオプションのマスタ
+func trimmingMasters() (course, nail, teeth model.MasterItem) {
+	duration := func(n int) *int { return &n }
+	course = model.MasterItem{ID: uuid.New(), Code: "TC01", Name: "シャンプーコース", Category: model.MasterCategoryTrimmingCourse,
+		Price: decimal.FromInt(5000).Ptr(), TaxRate: defaultTaxRate.Ptr(), DurationMinutes: duration(60)}
+	nail = model.MasterItem{ID: uuid.New(), Code: "TO01", Name: "爪切り", Category: model.MasterCategoryTrimmingOption,
+		Price: decimal.FromInt(500).Ptr(), TaxRate: defaultTaxRate.Ptr(), DurationMinutes: duration(10)}
+	teeth = model.MasterItem{ID: uuid.New(), Code: "TO02", Name: "歯磨き", Category: model.MasterCategoryTrimmingOption,
+		Price: decimal.FromInt(800).Ptr(), TaxRate: defaultTaxRate.Ptr()}
+	return course, nail, teeth
+}
+
+// sameTime タイムゾーンの表現によらず同じ時刻に一致する
+func sameTime(want time.Time) any {
+	return mock.MatchedBy(func(got time.Time) bool { return got.Equal(want) })
+}
+
+func TestCreateTrimming(t *testing.T) {
+	ctx := context.Background()
+	course, nail, teeth := trimmingMasters()
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New()}
+	groomer := model.Staff{ID: uuid.New(), Name: "佐藤", Role: model.StaffRoleGroomer, IsActive: true}
+	start := time.Date(2026, 2, 1, 10, 0, 0, 0, time.Local)
+
+	newService := func(overlaps []model.Trimming, groomers ...model.Staff) (*Service, *MockTrimmingRepository) {
+		mockRepo := new(MockTrimmingRepository)
+		mockPetRepo := new(MockPetRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		svc := New(mockPetRepo, nil, nil, nil,
+			WithTrimmingRepository(mockRepo), WithMasterItemRepository(mockMasterRepo), WithTransactor(&fakeTransactor{}))
+
+		mockPetRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+		mockMasterRepo.On("GetMasterItemsByIDs", ctx, []uuid.UUID{course.ID, nail.ID, teeth.ID}).
+			Return([]model.MasterItem{course, nail, teeth}, nil)
+		mockMasterRepo.On("GetEffectivePrices", ctx, mock.Anything, mock.Anything).Return([]model.MasterItemPrice{}, nil)
+		mockRepo.On("ListGroomers", ctx).Return(groomers, nil)
+		mockRepo.On("LockTrimmingSchedule", ctx, mock.Anything).Return(nil)
+		mockRepo.On("FindOverlappingTrimmings", ctx, sameTime(start), sameTime(start.Add(70*time.Minute)), uuid.Nil).Return(overlaps, nil)
+		return svc, mockRepo
+	}
+	request := func(staffID string) *model.CreateTrimmingRequest {
+		return &model.CreateTrimmingRequest{
+			PetID:           pet.ID.String(),
+			StaffID:         staffID,
+			AppointmentDate: start.Format(time.RFC3339),
+			CourseID:        course.ID.String(),
+			OptionIDs:       []string{nail.ID.String(), teeth.ID.String()},
+		}
+	}
+
+	t.Run("prices course and options and books the groomer", func(t *testing.T) {
+		svc, mockRepo := newService(nil, groomer)
+		var created *model.Trimming
+		mockRepo.On("CreateTrimming", ctx, mock.AnythingOfType("*model.Trimming")).
+			Run(func(args mock.Arguments) { created = args.Get(1).(*model.Trimming) }).
+			Return(nil)
+		mockRepo.On("GetTrimmingByID", ctx, mock.Anything).Return(&model.Trimming{}, nil)
+
+		_, err := svc.CreateTrimming(ctx, request(groomer.ID.String()))
+
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.Equal(t, pet.OwnerID, created.OwnerID)
+		assert.Equal(t, "シャンプーコース", created.Course)
+		assert.Equal(t, 70, created.DurationMinutes)
+		assert.Equal(t, "6300", created.TotalPrice.String())
+		require.Len(t, created.Options, 2)
+		assert.Equal(t, "爪切り", created.Options[0].Name)
+		assert.Equal(t, 0, created.Options[1].DurationMinutes)
+		assert.Equal(t, model.TrimmingStatusBooked, created.Status)
+	})
+
+	t.Run("rejects an overlapping trimming for the same groomer", func(t *testing.T) {
+		other := model.Trimming{ID: uuid.New(), StaffID: &groomer.ID, AppointmentDate: start.Add(30 * time.Minute), DurationMinutes: 60}
+		svc, mockRepo := newService([]model.Trimming{other}, groomer, model.Staff{ID: uuid.New(), Role: model.StaffRoleGroomer})
+
+		_, err := svc.CreateTrimming(ctx, request(groomer.ID.String()))
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateTrimming", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unassigned trimming when every groomer is busy", func(t *testing.T) {
+		// 10:00-10:30と10:40-11:30の予約は同時に行われないため、トリマー1人分の枠しか使わない
+		second := model.Staff{ID: uuid.New(), Role: model.StaffRoleGroomer}
+		overlaps := []model.Trimming{
+			{ID: uuid.New(), StaffID: &groomer.ID, AppointmentDate: start, DurationMinutes: 30},
+			{ID: uuid.New(), AppointmentDate: start.Add(40 * time.Minute), DurationMinutes: 50},
+		}
+		svc, mockRepo := newService(overlaps, groomer, second)
+		mockRepo.On("CreateTrimming", ctx, mock.Anything).Return(nil)
+		mockRepo.On("GetTrimmingByID", ctx, mock.Anything).Return(&model.Trimming{}, nil)
+
+		_, err := svc.CreateTrimming(ctx, request(""))
+		require.NoError(t, err)
+
+		svc, mockRepo = newService(append(overlaps, model.Trimming{ID: uuid.New(), StaffID: &second.ID, AppointmentDate: start.Add(20 * time.Minute), DurationMinutes: 30}), groomer, second)
+		_, err = svc.CreateTrimming(ctx, request(""))
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "CreateTrimming", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects staff who is not a groomer and bookings outside opening hours", func(t *testing.T) {
+		svc, _ := newService(nil, groomer)
+
+		_, err := svc.CreateTrimming(ctx, request(uuid.New().String()))
+		assert.True(t, apperrors.IsInvalidInput(err))
+
+		req := request(groomer.ID.String())
+		req.AppointmentDate = time.Date(2026, 2, 1, 17, 30, 0, 0, time.Local).Format(time.RFC3339)
+		_, err = svc.CreateTrimming(ctx, req)
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
+
+func TestCompleteTrimming(t *testing.T) {
+	ctx := context.Background()
+	course, nail, _ := trimmingMasters()
+	trimming := func() *model.Trimming {
+		return &model.Trimming{
+			ID: uuid.New(), PetID: uuid.New(), OwnerID: uuid.New(), Status: model.TrimmingStatusInProgress,
+			CourseID: &course.ID, Course: course.Name, CoursePrice: decimal.FromInt(4800).Ptr(),
+			Options: model.TrimmingOptions{{MasterID: nail.ID, Code: nail.Code, Name: nail.Name, Price: decimal.FromInt(500), DurationMinutes: 10}},
+		}
+	}
+
+	t.Run("creates an accounting from the booked prices", func(t *testing.T) {
+		mockRepo := new(MockTrimmingRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		tx := &fakeTransactor{}
+		svc := New(nil, nil, nil, nil, WithTrimmingRepository(mockRepo), WithMasterItemRepository(mockMasterRepo),
+			WithAccountingRepository(mockAccountingRepo), WithTransactor(tx))
+
+		tr := trimming()
+		mockRepo.On("GetTrimmingByIDForUpdate", ctx, tr.ID).Return(tr, nil)
+		mockRepo.On("UpdateTrimming", ctx, tr).Return(nil)
+		mockRepo.On("GetTrimmingByID", ctx, tr.ID).Return(tr, nil)
+		mockMasterRepo.On("GetMasterItemsByIDs", ctx, []uuid.UUID{course.ID, nail.ID}).Return([]model.MasterItem{course, nail}, nil)
+		var accounting *model.Accounting
+		mockAccountingRepo.On("CreateAccounting", ctx, mock.AnythingOfType("*model.Accounting")).
+			Run(func(args mock.Arguments) { accounting = args.Get(1).(*model.Accounting) }).
+			Return(nil)
+
+		result, err := svc.CompleteTrimming(ctx, tr.ID.String(), &model.CompleteTrimmingRequest{CreateAccounting: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		assert.Equal(t, model.TrimmingStatusCompleted, result.Trimming.Status)
+		assert.NotNil(t, result.Trimming.CompletedAt)
+		require.NotNil(t, accounting)
+		assert.Equal(t, tr.PetID, accounting.PetID)
+		assert.Equal(t, tr.OwnerID, accounting.OwnerID)
+		assert.Equal(t, &tr.ID, accounting.TrimmingID)
+		require.Len(t, accounting.AccountingItems, 2)
+		assert.Equal(t, "TC01", accounting.AccountingItems[0].Code)
+		assert.Equal(t, "4800", accounting.AccountingItems[0].UnitPrice.String())
+		assert.Equal(t, model.AccountingItemSourceTrimming, accounting.AccountingItems[1].Source)
+		assert.Equal(t, "5830", accounting.TotalAmount.String())
+	})
+
+	t.Run("rejects an accounting for another pet", func(t *testing.T) {
+		mockRepo := new(MockTrimmingRepository)
+		mockMasterRepo := new(MockMasterItemRepository)
+		mockAccountingRepo := new(MockAccountingRepository)
+		svc := New(nil, nil, nil, nil, WithTrimmingRepository(mockRepo), WithMasterItemRepository(mockMasterRepo),
+			WithAccountingRepository(mockAccountingRepo))
+
+		tr := trimming()
+		other := &model.Accounting{ID: uuid.New(), PetID: uuid.New(), OwnerID: tr.OwnerID, Status: model.AccountingStatusUnpaid}
+		mockRepo.On("GetTrimmingByIDForUpdate", ctx, tr.ID).Return(tr, nil)
+		mockRepo.On("UpdateTrimming", ctx, tr).Return(nil)
+		mockMasterRepo.On("GetMasterItemsByIDs", ctx, mock.Anything).Return([]model.MasterItem{course, nail}, nil)
+		mockAccountingRepo.On("GetAccountingByIDForUpdate", ctx, other.ID).Return(other, nil)
+
+		_, err := svc.CompleteTrimming(ctx, tr.ID.String(), &model.CompleteTrimmingRequest{AccountingID: other.ID.String()})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+		mockAccountingRepo.AssertNotCalled(t, "CreateAccountingItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a canceled trimming", func(t *testing.T) {
+		mockRepo := new(MockTrimmingRepository)
+		svc := New(nil, nil, nil, nil, WithTrimmingRepository(mockRepo))
+		tr := trimming()
+		tr.Status = model.TrimmingStatusCanceled
+		mockRepo.On("GetTrimmingByIDForUpdate", ctx, tr.ID).Return(tr, nil)
+
+		_, err := svc.CompleteTrimming(ctx, tr.ID.String(), &model.CompleteTrimmingRequest{})
+
+		assert.True(t, apperrors.IsConflict(err))
+		mockRepo.AssertNotCalled(t, "UpdateTrimming", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetTrimmingBoard(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockTrimmingRepository)
+	svc := New(nil, nil, nil, nil, WithTrimmingRepository(mockRepo))
+
+	sato := model.Staff{ID: uuid.New(), Name: "佐藤", Role: model.StaffRoleGroomer}
+	suzuki := model.Staff{ID: uuid.New(), Name: "鈴木", Role: model.StaffRoleGroomer}
+	day := time.Date(2026, 2, 1, 0, 0, 0, 0, time.Local)
+	retired := uuid.New()
+	trimmings := []model.Trimming{
+		{ID: uuid.New(), StaffID: &sato.ID, AppointmentDate: day.Add(10 * time.Hour), DurationMinutes: 90},
+		{ID: uuid.New(), StaffID: &sato.ID, AppointmentDate: day.Add(17 * time.Hour), DurationMinutes: 60},
+		{ID: uuid.New(), AppointmentDate: day.Add(11 * time.Hour), DurationMinutes: 60},
+		{ID: uuid.New(), StaffID: &retired, AppointmentDate: day.Add(13 * time.Hour), DurationMinutes: 60},
+	}
+	mockRepo.On("ListGroomers", ctx).Return([]model.Staff{sato, suzuki}, nil)
+	mockRepo.On("FindOverlappingTrimmings", ctx, day, day.AddDate(0, 0, 1), uuid.Nil).Return(trimmings, nil)
+
+	board, err := svc.GetTrimmingBoard(ctx, &model.TrimmingBoardRequest{Date: "2026-02-01"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "09:00", board.OpenTime)
+	assert.Equal(t, "18:00", board.CloseTime)
+	require.Len(t, board.Groomers, 2)
+	assert.Len(t, board.Groomers[0].Trimmings, 2)
+	assert.Equal(t, 150, board.Groomers[0].BookedMinutes)
+	assert.Equal(t, 390, board.Groomers[0].AvailableMinutes)
+	assert.Empty(t, board.Groomers[1].Trimmings)
+	assert.Equal(t, 540, board.Groomers[1].AvailableMinutes)
+	assert.Len(t, board.Unassigned, 2)
+
+	_, err = svc.GetTrimmingBoard(ctx, &model.TrimmingBoardRequest{Date: "2026/02/01"})
+	assert.True(t, apperrors.IsInvalidInput(err))
+}
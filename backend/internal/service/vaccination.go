@@ -0,0 +1,368 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/reminder"
+	"github.com/animal-ekarte/backend/internal/vaccine"
+	"github.com/animal-ekarte/backend/internal/validation"
+)
+
+// VaccinationService ワクチン接種記録サービスインターフェース
+type VaccinationService interface {
+	ListVaccinations(ctx context.Context, req *model.ListVaccinationsRequest) (*model.ListResult[model.Vaccination], error)
+	GetVaccinationByID(ctx context.Context, id string) (*model.Vaccination, error)
+	CreateVaccination(ctx context.Context, req *model.CreateVaccinationRequest) (*model.Vaccination, error)
+	UpdateVaccination(ctx context.Context, id string, req *model.UpdateVaccinationRequest) (*model.Vaccination, error)
+	DeleteVaccination(ctx context.Context, id string) error
+	GetDueVaccinations(ctx context.Context, req *model.DueVaccinationsRequest) ([]model.Vaccination, error)
+	SendVaccinationReminders(ctx context.Context) (*model.ReminderRunResult, error)
+}
+
+// Ensure Service implements VaccinationService
+var _ VaccinationService = (*Service)(nil)
+
+// defaultReminderLead 接種予定日の何日前から案内するか（既定）
+const defaultReminderLead = 30 * 24 * time.Hour
+
+// maxDueWithinDays 接種予定一覧で指定できる最大日数
+const maxDueWithinDays = 366
+
+// ListVaccinations 条件に一致するワクチン接種記録を1ページ分取得（既定は接種日の新しい順）
+// ロット番号を指定すると、回収対象ロットを接種したペットと飼い主を追跡できる。
+func (s *Service) ListVaccinations(ctx context.Context, req *model.ListVaccinationsRequest) (*model.ListResult[model.Vaccination], error) {
+	if err := validation.ValidateListOptions(req.ListOptions); err != nil {
+		return nil, err
+	}
+	filter := model.VaccinationFilter{LotNumber: req.LotNumber}
+	var err error
+	if filter.PetID, err = parseOptionalID(req.PetID, "pet"); err != nil {
+		return nil, err
+	}
+	if filter.OwnerID, err = parseOptionalID(req.OwnerID, "owner"); err != nil {
+		return nil, err
+	}
+	if filter.DoctorID, err = parseOptionalID(req.DoctorID, "doctor"); err != nil {
+		return nil, err
+	}
+	if filter.VaccinationDate, err = parseDateRange(req.DateFrom, req.DateTo, time.UTC); err != nil {
+		return nil, err
+	}
+	return s.vaccinationRepo.ListVaccinations(ctx, filter, req.ListOptions)
+}
+
+// GetVaccinationByID IDでワクチン接種記録を取得
+func (s *Service) GetVaccinationByID(ctx context.Context, id string) (*model.Vaccination, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, apperrors.WrapInvalidInput("invalid vaccination ID format")
+	}
+	return s.vaccinationRepo.GetVaccinationByID(ctx, uid)
+}
+
+// CreateVaccination ワクチン接種を記録する
+// ワクチンマスタ指定時は名称をマスタから補完し、接種プロトコルがあれば次回接種予定日を算出する。
+func (s *Service) CreateVaccination(ctx context.Context, req *model.CreateVaccinationRequest) (*model.Vaccination, error) {
+	if err := validation.ValidateCreateVaccination(req); err != nil {
+		return nil, err
+	}
+
+	pet, err := s.repo.GetPetByID(ctx, uuid.MustParse(req.PetID))
+	if err != nil {
+		return nil, err
+	}
+
+	vaccination := &model.Vaccination{
+		PetID:       pet.ID,
+		OwnerID:     pet.OwnerID,
+		VaccineName: req.VaccineName,
+		LotNumber:   req.LotNumber,
+		Notes:       req.Notes,
+	}
+	vaccination.VaccinationDate, _ = parseDateOnly(req.VaccinationDate)
+	if req.NextDate != "" {
+		next, _ := parseDateOnly(req.NextDate)
+		vaccination.NextDate = &next
+	}
+	if req.DoctorID != "" {
+		doctorID := uuid.MustParse(req.DoctorID)
+		vaccination.DoctorID = &doctorID
+	}
+	if req.VaccineMasterID != "" {
+		master, err := s.masterItemRepo.GetMasterItemByID(ctx, uuid.MustParse(req.VaccineMasterID))
+		if err != nil {
+			return nil, err
+		}
+		vaccination.VaccineMasterID = &master.ID
+		if vaccination.VaccineName == "" {
+			vaccination.VaccineName = master.Name
+		}
+		if err := s.applyVaccineLot(ctx, vaccination, master, req.LotID); err != nil {
+			return nil, err
+		}
+		if err := s.applyVaccineProtocol(ctx, vaccination, pet, master); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.vaccinationRepo.CreateVaccination(ctx, vaccination); err != nil {
+		return nil, err
+	}
+	created, err := s.vaccinationRepo.GetVaccinationByID(ctx, vaccination.ID)
+	if err != nil {
+		return nil, err
+	}
+	created.Warnings = vaccination.Warnings
+	return created, nil
+}
+
+// applyVaccineLot 接種したワクチンの在庫ロットからロット番号を補完する
+// ロット指定時はそのロットの番号を、ロット番号の入力もない場合は次に払い出されるロット（FEFO）の番号を使う。
+// 在庫の払出は会計の入金時に行う。
+func (s *Service) applyVaccineLot(ctx context.Context, vaccination *model.Vaccination, master *model.MasterItem, lotID string) error {
+	if lotID != "" {
+		lot, err := s.inventoryRepo.GetLotByID(ctx, uuid.MustParse(lotID))
+		if err != nil {
+			return err
+		}
+		if master.InventoryID == nil || lot.InventoryItemID != *master.InventoryID {
+			return apperrors.WrapInvalidInput("lot is not stock of " + master.Name)
+		}
+		vaccination.LotNumber = lot.LotNumber
+		return nil
+	}
+	if vaccination.LotNumber != "" || master.InventoryID == nil {
+		return nil
+	}
+
+	lots, err := s.inventoryRepo.GetLots(ctx, *master.InventoryID, true)
+	if err != nil {
+		return err
+	}
+	allocations, _ := allocateFEFO(lots, 0, 1, vaccination.VaccinationDate)
+	if len(allocations) > 0 && allocations[0].lot != nil {
+		vaccination.LotNumber = allocations[0].lot.LotNumber
+	}
+	return nil
+}
+
+// applyVaccineProtocol ワクチンマスタの接種プロトコルに照らして接種記録を補完する
+// 対象外の動物種への接種は記録したうえで警告を付け、次回接種予定日が未入力なら
+// ペットの生年月日とこれまでの同じワクチンの接種歴から算出する。
+func (s *Service) applyVaccineProtocol(ctx context.Context, vaccination *model.Vaccination, pet *model.Pet, master *model.MasterItem) error {
+	protocol := master.VaccineProtocol
+	if protocol == nil {
+		return nil
+	}
+	if !vaccine.AppliesTo(protocol, pet.Species) {
+		vaccination.Warnings = append(vaccination.Warnings,
+			fmt.Sprintf("%sは%sを対象としていません（対象: %s）", master.Name, pet.Species, strings.Join(protocol.Species, "・")))
+		slog.WarnContext(ctx, "vaccine given to non-target species",
+			slog.String("pet_id", pet.ID.String()),
+			slog.String("master_item_id", master.ID.String()),
+			slog.String("species", pet.Species),
+		)
+	}
+	if vaccination.NextDate != nil {
+		return nil
+	}
+
+	history, err := s.vaccinationRepo.GetVaccinations(ctx, model.VaccinationFilter{PetID: &pet.ID})
+	if err != nil {
+		return err
+	}
+	var previous []time.Time
+	for _, v := range history {
+		if v.VaccineMasterID != nil && *v.VaccineMasterID == master.ID && v.VaccinationDate.Before(vaccination.VaccinationDate) {
+			previous = append(previous, v.VaccinationDate)
+		}
+	}
+	if next, ok := vaccine.NextDate(protocol, pet.BirthDate, vaccination.VaccinationDate, previous); ok {
+		vaccination.NextDate = &next
+	}
+	return nil
+}
+
+// UpdateVaccination ワクチン接種記録を更新する
+func (s *Service) UpdateVaccination(ctx context.Context, id string, req *model.UpdateVaccinationRequest) (*model.Vaccination, error) {
+	if err := validation.ValidateUpdateVaccination(req); err != nil {
+		return nil, err
+	}
+
+	vaccination, err := s.GetVaccinationByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DoctorID != nil {
+		vaccination.DoctorID = nil
+		if *req.DoctorID != "" {
+			doctorID := uuid.MustParse(*req.DoctorID)
+			vaccination.DoctorID = &doctorID
+		}
+	}
+	if req.VaccineName != nil {
+		vaccination.VaccineName = *req.VaccineName
+	}
+	if req.VaccinationDate != nil {
+		vaccination.VaccinationDate, _ = parseDateOnly(*req.VaccinationDate)
+	}
+	if req.NextDate != nil {
+		vaccination.NextDate = nil
+		if *req.NextDate != "" {
+			next, _ := parseDateOnly(*req.NextDate)
+			vaccination.NextDate = &next
+		}
+	}
+	if vaccination.NextDate != nil && !vaccination.NextDate.After(vaccination.VaccinationDate) {
+		return nil, apperrors.WrapInvalidInput("next date must be after vaccination date")
+	}
+	if req.LotNumber != nil {
+		vaccination.LotNumber = *req.LotNumber
+	}
+	if req.Notes != nil {
+		vaccination.Notes = *req.Notes
+	}
+
+	if err := s.vaccinationRepo.UpdateVaccination(ctx, vaccination); err != nil {
+		return nil, err
+	}
+	return s.vaccinationRepo.GetVaccinationByID(ctx, vaccination.ID)
+}
+
+// DeleteVaccination ワクチン接種記録を削除
+func (s *Service) DeleteVaccination(ctx context.Context, id string) error {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid vaccination ID format")
+	}
+	return s.withinTransaction(ctx, func(ctx context.Context) error {
+		return s.vaccinationRepo.DeleteVaccination(ctx, uid)
+	})
+}
+
+// GetDueVaccinations 今日からwithin日以内に次回接種予定日を迎える接種記録を予定日順に取得
+// 同じワクチンをその後に接種済みの記録は含めない。
+func (s *Service) GetDueVaccinations(ctx context.Context, req *model.DueVaccinationsRequest) ([]model.Vaccination, error) {
+	days, err := parseWithinDays(req.Within)
+	if err != nil {
+		return nil, err
+	}
+
+	from := today()
+	until := from.AddDate(0, 0, days)
+	if req.IncludeOverdue {
+		return s.vaccinationRepo.FindDueVaccinations(ctx, nil, until)
+	}
+	return s.vaccinationRepo.FindDueVaccinations(ctx, &from, until)
+}
+
+// SendVaccinationReminders 接種予定日が近い接種記録の案内を、送付経路ごとに飼い主単位でまとめて送付する
+// 同じ世帯のペットは1通にまとめ、送付済みの接種予定は経路ごとに記録して二重に送付しない。
+// 送付先のない飼い主はスキップし、送付に失敗した飼い主は次回の実行で再送する。
+func (s *Service) SendVaccinationReminders(ctx context.Context) (*model.ReminderRunResult, error) {
+	result := &model.ReminderRunResult{Channels: []model.ReminderChannelResult{}}
+	if len(s.notifiers) == 0 {
+		return result, nil
+	}
+
+	clinic, err := s.reminderClinic(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	from := today()
+	until := from.Add(s.reminderLead)
+	for _, notifier := range s.notifiers {
+		channel := notifier.Channel()
+		channelResult := model.ReminderChannelResult{Channel: channel}
+
+		due, err := s.vaccinationRepo.FindUnremindedVaccinations(ctx, from, until, channel)
+		if err != nil {
+			return nil, err
+		}
+		for _, batch := range reminder.GroupByOwner(due, now) {
+			batch.Clinic = clinic
+			if err := notifier.Notify(ctx, &batch); err != nil {
+				if errors.Is(err, reminder.ErrNoAddress) {
+					channelResult.Skipped++
+					continue
+				}
+				slog.ErrorContext(ctx, "failed to send vaccination reminder",
+					slog.String("channel", channel),
+					slog.String("owner_id", batch.Owner.ID.String()),
+					slog.String("error", err.Error()),
+				)
+				channelResult.Failed++
+				continue
+			}
+
+			vaccinations := batch.Vaccinations()
+			reminders := make([]model.VaccinationReminder, 0, len(vaccinations))
+			for _, v := range vaccinations {
+				reminders = append(reminders, model.VaccinationReminder{
+					VaccinationID: v.ID,
+					OwnerID:       v.OwnerID,
+					Channel:       channel,
+					DueDate:       *v.NextDate,
+					SentAt:        now,
+				})
+			}
+			if err := s.vaccinationRepo.CreateVaccinationReminders(ctx, reminders); err != nil {
+				return nil, err
+			}
+			channelResult.Batches++
+			channelResult.Vaccinations += len(vaccinations)
+		}
+		result.Channels = append(result.Channels, channelResult)
+	}
+	return result, nil
+}
+
+// reminderClinic 案内の差出人とする医院（未登録ならnil）
+func (s *Service) reminderClinic(ctx context.Context) (*model.Clinic, error) {
+	if s.clinicRepo == nil {
+		return nil, nil
+	}
+	clinic, err := s.clinicRepo.GetClinic(ctx)
+	if apperrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return clinic, err
+}
+
+var withinPattern = regexp.MustCompile(`^(\d+)([dw]?)$`)
+
+// parseWithinDays 期間指定（30d, 4w, 30）を日数にする（省略時は30日）
+func parseWithinDays(within string) (int, error) {
+	if within == "" {
+		return int(defaultReminderLead.Hours() / 24), nil
+	}
+	m := withinPattern.FindStringSubmatch(within)
+	if m == nil {
+		return 0, apperrors.WrapInvalidInput("within must be a number of days such as 30d or 4w")
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, apperrors.WrapInvalidInput("within must be a number of days such as 30d or 4w")
+	}
+	if m[2] == "w" {
+		n *= 7
+	}
+	if n > maxDueWithinDays {
+		return 0, apperrors.WrapInvalidInput("within must not exceed 366 days")
+	}
+	return n, nil
+}
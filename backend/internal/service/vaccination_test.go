@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+	"github.com/animal-ekarte/backend/internal/reminder"
+)
+
+// MockVaccinationRepository is a mock implementation of repository.VaccinationRepository
+type MockVaccinationRepository struct {
+	mock.Mock
+}
+
+func (m *MockVaccinationRepository) GetVaccinations(ctx context.Context, filter model.VaccinationFilter) ([]model.Vaccination, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Vaccination), args.Error(1)
+}
+
+func (m *MockVaccinationRepository) ListVaccinations(ctx context.Context, filter model.VaccinationFilter, opts model.ListOptions) (*model.ListResult[model.Vaccination], error) {
+	args := m.Called(ctx, filter, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.ListResult[model.Vaccination]), args.Error(1)
+}
+
+func (m *MockVaccinationRepository) GetVaccinationByID(ctx context.Context, id uuid.UUID) (*model.Vaccination, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*model.Vaccination), args.Error(1)
+}
+
+func (m *MockVaccinationRepository) CreateVaccination(ctx context.Context, vaccination *model.Vaccination) error {
+	args := m.Called(ctx, vaccination)
+	return args.Error(0)
+}
+
+func (m *MockVaccinationRepository) UpdateVaccination(ctx context.Context, vaccination *model.Vaccination) error {
+	args := m.Called(ctx, vaccination)
+	return args.Error(0)
+}
+
+func (m *MockVaccinationRepository) DeleteVaccination(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockVaccinationRepository) FindDueVaccinations(ctx context.Context, from *time.Time, until time.Time) ([]model.Vaccination, error) {
+	args := m.Called(ctx, from, until)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Vaccination), args.Error(1)
+}
+
+func (m *MockVaccinationRepository) FindUnremindedVaccinations(ctx context.Context, from, until time.Time, channel string) ([]model.Vaccination, error) {
+	args := m.Called(ctx, from, until, channel)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]model.Vaccination), args.Error(1)
+}
+
+func (m *MockVaccinationRepository) CreateVaccinationReminders(ctx context.Context, reminders []model.VaccinationReminder) error {
+	args := m.Called(ctx, reminders)
+	return args.Error(0)
+}
+
+// fakeNotifier records the batches it was asked to send.
+type fakeNotifier struct {
+	channel string
+	sent    []reminder.Batch
+}
+
+func (n *fakeNotifier) Channel() string { return n.channel }
+
+func (n *fakeNotifier) Notify(_ context.Context, batch *reminder.Batch) error {
+	if batch.Owner.Email == "" {
+		return reminder.ErrNoAddress
+	}
+	n.sent = append(n.sent, *batch)
+	return nil
+}
+
+func dueVaccination(owner *model.Owner, pet *model.Pet, name string, next time.Time) model.Vaccination {
+	return model.Vaccination{
+		ID:              uuid.New(),
+		PetID:           pet.ID,
+		OwnerID:         owner.ID,
+		VaccineName:     name,
+		VaccinationDate: next.AddDate(-1, 0, 0),
+		NextDate:        &next,
+		Pet:             pet,
+		Owner:           owner,
+	}
+}
+
+func TestSendVaccinationReminders(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockVaccinationRepository)
+	notifier := &fakeNotifier{channel: reminder.ChannelEmail}
+	svc := New(nil, nil, nil, nil,
+		WithVaccinationRepository(mockRepo),
+		WithVaccinationReminders(14*24*time.Hour, notifier),
+	)
+
+	household := &model.Owner{ID: uuid.New(), Name: "山田", Email: "yamada@example.com"}
+	noEmail := &model.Owner{ID: uuid.New(), Name: "佐藤"}
+	pochi := &model.Pet{ID: uuid.New(), Name: "ポチ", OwnerID: household.ID}
+	tama := &model.Pet{ID: uuid.New(), Name: "タマ", OwnerID: household.ID}
+	kuro := &model.Pet{ID: uuid.New(), Name: "クロ", OwnerID: noEmail.ID}
+	due := []model.Vaccination{
+		dueVaccination(household, pochi, "狂犬病", today().AddDate(0, 0, 3)),
+		dueVaccination(household, tama, "3種混合", today().AddDate(0, 0, 10)),
+		dueVaccination(noEmail, kuro, "5種混合", today().AddDate(0, 0, 1)),
+	}
+
+	mockRepo.On("FindUnremindedVaccinations", ctx, today(), today().AddDate(0, 0, 14), reminder.ChannelEmail).Return(due, nil)
+	var recorded []model.VaccinationReminder
+	mockRepo.On("CreateVaccinationReminders", ctx, mock.Anything).
+		Run(func(args mock.Arguments) { recorded = args.Get(1).([]model.VaccinationReminder) }).
+		Return(nil)
+
+	result, err := svc.SendVaccinationReminders(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Channels, 1)
+	assert.Equal(t, model.ReminderChannelResult{
+		Channel: reminder.ChannelEmail, Batches: 1, Vaccinations: 2, Skipped: 1,
+	}, result.Channels[0])
+
+	// 同じ世帯の2頭は1通にまとまり、送付先のない飼い主は記録しない
+	require.Len(t, notifier.sent, 1)
+	assert.Len(t, notifier.sent[0].Pets, 2)
+	require.Len(t, recorded, 2)
+	for _, r := range recorded {
+		assert.Equal(t, household.ID, r.OwnerID)
+		assert.Equal(t, reminder.ChannelEmail, r.Channel)
+	}
+	mockRepo.AssertNumberOfCalls(t, "CreateVaccinationReminders", 1)
+}
+
+func TestSendVaccinationReminders_NoNotifiers(t *testing.T) {
+	mockRepo := new(MockVaccinationRepository)
+	svc := New(nil, nil, nil, nil, WithVaccinationRepository(mockRepo))
+
+	result, err := svc.SendVaccinationReminders(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Channels)
+	mockRepo.AssertNotCalled(t, "FindUnremindedVaccinations", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetDueVaccinations(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockVaccinationRepository)
+	svc := New(nil, nil, nil, nil, WithVaccinationRepository(mockRepo))
+
+	from := today()
+	mockRepo.On("FindDueVaccinations", ctx, &from, from.AddDate(0, 0, 28)).Return([]model.Vaccination{}, nil).Once()
+	_, err := svc.GetDueVaccinations(ctx, &model.DueVaccinationsRequest{Within: "4w"})
+	require.NoError(t, err)
+
+	mockRepo.On("FindDueVaccinations", ctx, (*time.Time)(nil), from.AddDate(0, 0, 30)).Return([]model.Vaccination{}, nil).Once()
+	_, err = svc.GetDueVaccinations(ctx, &model.DueVaccinationsRequest{IncludeOverdue: true})
+	require.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestParseWithinDays(t *testing.T) {
+	tests := []struct {
+		within  string
+		want    int
+		wantErr bool
+	}{
+		{"", 30, false},
+		{"45", 45, false},
+		{"10d", 10, false},
+		{"2w", 14, false},
+		{"1y", 0, true},
+		{"-3d", 0, true},
+		{"400d", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.within, func(t *testing.T) {
+			got, err := parseWithinDays(tt.within)
+			if tt.wantErr {
+				assert.True(t, apperrors.IsInvalidInput(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCreateVaccination_Protocol(t *testing.T) {
+	ctx := context.Background()
+	petRepo := new(MockPetRepository)
+	masterRepo := new(MockMasterItemRepository)
+	vacRepo := new(MockVaccinationRepository)
+	svc := New(petRepo, nil, nil, nil,
+		WithMasterItemRepository(masterRepo),
+		WithVaccinationRepository(vacRepo),
+	)
+
+	birth, _ := parseDateOnly("2024-01-01")
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), Name: "ポチ", Species: "犬", BirthDate: &birth}
+	combo := &model.MasterItem{ID: uuid.New(), Name: "5種混合", Category: model.MasterCategoryVaccine,
+		VaccineProtocol: &model.VaccineProtocol{Species: []string{"犬"}, SeriesWeeks: []int{8, 12, 16}, BoosterMonths: 12}}
+	first, _ := parseDateOnly("2024-02-26")
+	petRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+	masterRepo.On("GetMasterItemByID", ctx, combo.ID).Return(combo, nil)
+	vacRepo.On("GetVaccinations", ctx, model.VaccinationFilter{PetID: &pet.ID}).Return([]model.Vaccination{
+		{PetID: pet.ID, VaccineMasterID: &combo.ID, VaccinationDate: first},
+	}, nil)
+	stored := &model.Vaccination{}
+	vacRepo.On("CreateVaccination", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*model.Vaccination)
+	}).Return(nil)
+	vacRepo.On("GetVaccinationByID", ctx, mock.Anything).Return(stored, nil)
+
+	// 12週齢で2回目 → 16週齢で3回目
+	v, err := svc.CreateVaccination(ctx, &model.CreateVaccinationRequest{
+		PetID:           pet.ID.String(),
+		VaccineMasterID: combo.ID.String(),
+		VaccinationDate: "2024-03-25",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "5種混合", v.VaccineName)
+	require.NotNil(t, v.NextDate)
+	assert.Equal(t, "2024-04-22", v.NextDate.Format("2006-01-02"))
+	assert.Empty(t, v.Warnings)
+}
+
+func TestCreateVaccination_WrongSpecies(t *testing.T) {
+	ctx := context.Background()
+	petRepo := new(MockPetRepository)
+	masterRepo := new(MockMasterItemRepository)
+	vacRepo := new(MockVaccinationRepository)
+	svc := New(petRepo, nil, nil, nil,
+		WithMasterItemRepository(masterRepo),
+		WithVaccinationRepository(vacRepo),
+	)
+
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), Name: "タマ", Species: "猫"}
+	rabies := &model.MasterItem{ID: uuid.New(), Name: "狂犬病ワクチン", Category: model.MasterCategoryVaccine,
+		VaccineProtocol: &model.VaccineProtocol{Species: []string{"犬"}, BoosterMonths: 12}}
+	petRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+	masterRepo.On("GetMasterItemByID", ctx, rabies.ID).Return(rabies, nil)
+	vacRepo.On("CreateVaccination", ctx, mock.Anything).Return(nil)
+	vacRepo.On("GetVaccinationByID", ctx, mock.Anything).Return(&model.Vaccination{}, nil)
+
+	// 次回接種予定日を手入力した場合は接種歴を参照しない
+	v, err := svc.CreateVaccination(ctx, &model.CreateVaccinationRequest{
+		PetID:           pet.ID.String(),
+		VaccineMasterID: rabies.ID.String(),
+		VaccinationDate: "2024-04-01",
+		NextDate:        "2025-04-01",
+	})
+	require.NoError(t, err)
+	require.Len(t, v.Warnings, 1)
+	assert.Contains(t, v.Warnings[0], "猫")
+	vacRepo.AssertNotCalled(t, "GetVaccinations", mock.Anything, mock.Anything)
+}
+
+func TestCreateVaccination_LotFromStock(t *testing.T) {
+	ctx := context.Background()
+	petRepo := new(MockPetRepository)
+	masterRepo := new(MockMasterItemRepository)
+	vacRepo := new(MockVaccinationRepository)
+	inventoryRepo := new(MockInventoryRepository)
+	svc := New(petRepo, nil, nil, nil,
+		WithMasterItemRepository(masterRepo),
+		WithVaccinationRepository(vacRepo),
+		WithInventoryRepository(inventoryRepo),
+	)
+
+	pet := &model.Pet{ID: uuid.New(), OwnerID: uuid.New(), Name: "ポチ", Species: "犬"}
+	stockID := uuid.New()
+	combo := &model.MasterItem{ID: uuid.New(), Name: "5種混合", Category: model.MasterCategoryVaccine, InventoryID: &stockID}
+	petRepo.On("GetPetByID", ctx, pet.ID).Return(pet, nil)
+	masterRepo.On("GetMasterItemByID", ctx, combo.ID).Return(combo, nil)
+	inventoryRepo.On("GetLots", ctx, stockID, true).Return([]model.InventoryLot{
+		lotExpiring(stockID, "V-OLD", "2024-03-31", 2),
+		lotExpiring(stockID, "V-NEW", "2025-03-31", 10),
+	}, nil)
+	stored := &model.Vaccination{}
+	vacRepo.On("CreateVaccination", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		*stored = *args.Get(1).(*model.Vaccination)
+	}).Return(nil)
+	vacRepo.On("GetVaccinationByID", ctx, mock.Anything).Return(stored, nil)
+
+	// 接種日に期限切れのロットは使わない
+	v, err := svc.CreateVaccination(ctx, &model.CreateVaccinationRequest{
+		PetID:           pet.ID.String(),
+		VaccineMasterID: combo.ID.String(),
+		VaccinationDate: "2024-04-10",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "V-NEW", v.LotNumber)
+
+	// 他のワクチンのロットは指定できない
+	other := lotExpiring(uuid.New(), "X-1", "2025-01-31", 1)
+	inventoryRepo.On("GetLotByID", ctx, other.ID).Return(&other, nil)
+	_, err = svc.CreateVaccination(ctx, &model.CreateVaccinationRequest{
+		PetID:           pet.ID.String(),
+		VaccineMasterID: combo.ID.String(),
+		VaccinationDate: "2024-04-10",
+		LotID:           other.ID.String(),
+	})
+	assert.True(t, apperrors.IsInvalidInput(err))
+}
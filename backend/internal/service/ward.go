@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/careplan"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// WardService 病棟サービスインターフェース
+type WardService interface {
+	GetWardTasks(ctx context.Context, req *model.WardTasksRequest) (*model.WardTasks, error)
+}
+
+// Ensure Service implements WardService
+var _ WardService = (*Service)(nil)
+
+// wardOrder 病棟の表示順
+var wardOrder = []string{model.CageTypeDog, model.CageTypeCat, model.CageTypeShared, model.WardUnassigned}
+
+// GetWardTasks 指定日・時間帯のケアタスクを病棟ごとに取得する
+// ケアプランの実施予定をタスクに展開し、ケアログと突き合わせて pending / done / skipped / overdue を判定する。
+// 一時帰宅中の入院と、退院後の予定は対象外。頓用の項目は時間帯に関わらず含める。
+func (s *Service) GetWardTasks(ctx context.Context, req *model.WardTasksRequest) (*model.WardTasks, error) {
+	date, from, to, err := parseWardWindow(req)
+	if err != nil {
+		return nil, err
+	}
+
+	hospitalizations, err := s.hospitalizationRepo.FindHospitalizationsOnDate(ctx, date)
+	if err != nil {
+		return nil, err
+	}
+	targets := hospitalizations[:0]
+	for _, h := range hospitalizations {
+		if h.Status == model.HospitalizationStatusTemporaryLeave {
+			continue
+		}
+		if req.Ward != "" && wardOf(&h) != req.Ward {
+			continue
+		}
+		targets = append(targets, h)
+	}
+
+	ids := make([]uuid.UUID, len(targets))
+	for i, h := range targets {
+		ids[i] = h.ID
+	}
+	records, err := s.dailyRecordRepo.GetDailyRecordsByDate(ctx, ids, date)
+	if err != nil {
+		return nil, err
+	}
+	logsByHospitalization := make(map[uuid.UUID][]model.CareLog, len(records))
+	for _, record := range records {
+		logsByHospitalization[record.HospitalizationID] = record.CareLogs
+	}
+
+	result := &model.WardTasks{
+		Date:  date.Format("2006-01-02"),
+		From:  formatWindowClock(from),
+		To:    formatWindowClock(to),
+		Wards: []model.WardTaskGroup{},
+	}
+	dayStart := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	windowStart, windowEnd := dayStart.Add(from), dayStart.Add(to)
+	now := time.Now()
+
+	groups := map[string][]model.WardTask{}
+	for i := range targets {
+		h := &targets[i]
+		for _, task := range careplan.Tasks(h.CarePlanItems, logsByHospitalization[h.ID], date, now) {
+			if task.DueAt != nil {
+				if task.DueAt.Before(windowStart) || !task.DueAt.Before(windowEnd) {
+					continue
+				}
+				if h.DischargedAt != nil && task.DueAt.After(*h.DischargedAt) {
+					continue
+				}
+			}
+			groups[wardOf(h)] = append(groups[wardOf(h)], wardTask(h, task))
+			countWardTask(&result.Summary, task.Status)
+		}
+	}
+
+	for _, ward := range wardOrder {
+		tasks, ok := groups[ward]
+		if !ok {
+			continue
+		}
+		sort.SliceStable(tasks, func(i, j int) bool {
+			if ti, tj := tasks[i].DueAt, tasks[j].DueAt; ti != nil && tj != nil && !ti.Equal(*tj) {
+				return ti.Before(*tj)
+			} else if (ti == nil) != (tj == nil) {
+				return tj == nil
+			}
+			return tasks[i].CageCode < tasks[j].CageCode
+		})
+		result.Wards = append(result.Wards, model.WardTaskGroup{Ward: ward, Tasks: tasks})
+	}
+	return result, nil
+}
+
+// parseWardWindow 対象日と時間帯（0時からの経過時間）をパースする
+func parseWardWindow(req *model.WardTasksRequest) (date time.Time, from, to time.Duration, err error) {
+	date = today()
+	if req.Date != "" {
+		if date, err = parseDateOnly(req.Date); err != nil {
+			return time.Time{}, 0, 0, apperrors.WrapInvalidInput("invalid date format (expected YYYY-MM-DD)")
+		}
+	}
+	to = 24 * time.Hour
+	if req.From != "" {
+		var ok bool
+		if from, ok = careplan.ClockOffset(req.From); !ok {
+			return time.Time{}, 0, 0, apperrors.WrapInvalidInput("invalid from format (expected HH:MM)")
+		}
+	}
+	if req.To != "" && req.To != "24:00" {
+		var ok bool
+		if to, ok = careplan.ClockOffset(req.To); !ok {
+			return time.Time{}, 0, 0, apperrors.WrapInvalidInput("invalid to format (expected HH:MM)")
+		}
+	}
+	if to <= from {
+		return time.Time{}, 0, 0, apperrors.WrapInvalidInput("to must be after from")
+	}
+	if req.Ward != "" && !slices.Contains(wardOrder, req.Ward) {
+		return time.Time{}, 0, 0, apperrors.WrapInvalidInput("ward must be one of 犬用, 猫用, 共用, 未割当")
+	}
+	return date, from, to, nil
+}
+
+// formatWindowClock 0時からの経過時間をHH:MMにする（24時間は24:00）
+func formatWindowClock(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// wardOf 入院の病棟（ケージ種別）
+func wardOf(h *model.Hospitalization) string {
+	if h.Cage == nil || h.Cage.Type == "" {
+		return model.WardUnassigned
+	}
+	return h.Cage.Type
+}
+
+func wardTask(h *model.Hospitalization, task model.CareTaskStatus) model.WardTask {
+	wt := model.WardTask{
+		HospitalizationID: h.ID,
+		HospitalizationNo: h.HospitalizationNo,
+		PetID:             h.PetID,
+		CareTaskStatus:    task,
+	}
+	if h.Pet != nil {
+		wt.PetName = h.Pet.Name
+	}
+	if h.Cage != nil {
+		wt.CageCode = h.Cage.Code
+	}
+	return wt
+}
+
+func countWardTask(summary *model.WardTaskSummary, status string) {
+	switch status {
+	case model.CareTaskStatusPending:
+		summary.Pending++
+	case model.CareTaskStatusDone:
+		summary.Done++
+	case model.CareTaskStatusSkipped:
+		summary.Skipped++
+	case model.CareTaskStatusOverdue:
+		summary.Overdue++
+	case model.CareTaskStatusAsNeeded:
+		summary.AsNeeded++
+	}
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func TestGetWardTasks(t *testing.T) {
+	ctx := context.Background()
+	date := time.Date(2024, 4, 2, 0, 0, 0, 0, time.UTC)
+
+	dogItemID := uuid.New()
+	dog := model.Hospitalization{
+		ID:     uuid.New(),
+		Status: model.HospitalizationStatusAdmitted,
+		Pet:    &model.Pet{Name: "ポチ"},
+		Cage:   &model.Cage{Code: "D01", Type: model.CageTypeDog},
+		CarePlanItems: []model.CarePlanItem{
+			{ID: dogItemID, Name: "抗生剤", Timing: model.CareSchedule{Times: []string{"morning", "night"}}, Status: "active"},
+			{ID: uuid.New(), Name: "鎮痛剤", Timing: model.CareSchedule{PRN: true}, Status: "active"},
+		},
+	}
+	cat := model.Hospitalization{
+		ID:     uuid.New(),
+		Status: model.HospitalizationStatusAdmitted,
+		Pet:    &model.Pet{Name: "タマ"},
+		Cage:   &model.Cage{Code: "N01", Type: model.CageTypeCat},
+		CarePlanItems: []model.CarePlanItem{
+			{ID: uuid.New(), Name: "点滴", Timing: model.CareSchedule{EveryHours: 4}, Status: "active"},
+		},
+	}
+	onLeave := model.Hospitalization{
+		ID:     uuid.New(),
+		Status: model.HospitalizationStatusTemporaryLeave,
+		CarePlanItems: []model.CarePlanItem{
+			{ID: uuid.New(), Name: "療法食", Timing: model.CareSchedule{Times: []string{"morning"}}, Status: "active"},
+		},
+	}
+
+	t.Run("groups tasks by ward within the time window", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo), WithDailyRecordRepository(mockDailyRepo))
+
+		mockRepo.On("FindHospitalizationsOnDate", ctx, date).
+			Return([]model.Hospitalization{cat, dog, onLeave}, nil)
+		mockDailyRepo.On("GetDailyRecordsByDate", ctx, []uuid.UUID{cat.ID, dog.ID}, date).
+			Return([]model.DailyRecord{{
+				HospitalizationID: dog.ID,
+				CareLogs:          []model.CareLog{{CarePlanItemID: &dogItemID, Timing: "morning", Status: model.CareLogStatusCompleted}},
+			}}, nil)
+
+		result, err := svc.GetWardTasks(ctx, &model.WardTasksRequest{Date: "2024-04-02", From: "06:00", To: "13:00"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "06:00", result.From)
+		assert.Equal(t, "13:00", result.To)
+		// 犬用→猫用の順、一時帰宅中の入院は含めない
+		assert.Len(t, result.Wards, 2)
+		assert.Equal(t, model.CageTypeDog, result.Wards[0].Ward)
+		assert.Equal(t, model.CageTypeCat, result.Wards[1].Ward)
+
+		// 犬用: 朝の抗生剤（実施済）と頓用。夜の回は時間帯外
+		dogTasks := result.Wards[0].Tasks
+		assert.Len(t, dogTasks, 2)
+		assert.Equal(t, "ポチ", dogTasks[0].PetName)
+		assert.Equal(t, model.CareTaskStatusDone, dogTasks[0].Status)
+		assert.Equal(t, model.CareTaskStatusAsNeeded, dogTasks[1].Status)
+
+		// 猫用: 4時間ごとのうち 08:00, 12:00
+		catTasks := result.Wards[1].Tasks
+		assert.Len(t, catTasks, 2)
+		assert.Equal(t, "08:00", catTasks[0].Timing)
+		assert.Equal(t, "12:00", catTasks[1].Timing)
+		// 過去日の未実施は遅延
+		assert.Equal(t, model.CareTaskStatusOverdue, catTasks[0].Status)
+
+		assert.Equal(t, model.WardTaskSummary{Done: 1, Overdue: 2, AsNeeded: 1}, result.Summary)
+	})
+
+	t.Run("filters by ward", func(t *testing.T) {
+		mockRepo := new(MockHospitalizationRepository)
+		mockDailyRepo := new(MockDailyRecordRepository)
+		svc := New(nil, nil, nil, nil, WithHospitalizationRepository(mockRepo), WithDailyRecordRepository(mockDailyRepo))
+
+		mockRepo.On("FindHospitalizationsOnDate", ctx, date).Return([]model.Hospitalization{cat, dog}, nil)
+		mockDailyRepo.On("GetDailyRecordsByDate", ctx, []uuid.UUID{cat.ID}, date).Return([]model.DailyRecord{}, nil)
+
+		result, err := svc.GetWardTasks(ctx, &model.WardTasksRequest{Date: "2024-04-02", Ward: model.CageTypeCat})
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Wards, 1)
+		assert.Len(t, result.Wards[0].Tasks, 6)
+		assert.Equal(t, "24:00", result.To)
+	})
+
+	t.Run("rejects inverted window", func(t *testing.T) {
+		svc := New(nil, nil, nil, nil)
+
+		_, err := svc.GetWardTasks(ctx, &model.WardTasksRequest{From: "12:00", To: "06:00"})
+
+		assert.True(t, apperrors.IsInvalidInput(err))
+	})
+}
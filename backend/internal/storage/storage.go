@@ -0,0 +1,35 @@
+// Package storage はカルテの添付ファイル（画像・DICOM・PDF）の保存先を抽象化する。
+// ローカルのファイルシステムと、MinIOなどのS3互換オブジェクトストレージを切り替えて使う。
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// ErrNotFound 指定のキーのオブジェクトがない
+var ErrNotFound = errors.New("object not found")
+
+// Store 添付ファイルの保存先
+// キーは "/" 区切りの相対パス（例: attachments/<ペットID>/<添付ID>/original）。
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// cleanKey キーを正規化し、保存先の外を指すキーを拒否する
+func cleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	cleaned := path.Clean(key)
+	if cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return cleaned, nil
+}
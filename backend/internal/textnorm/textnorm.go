@@ -0,0 +1,115 @@
+// Package textnorm は氏名・フリガナ・電話番号などを検索用の表記にそろえる。
+// 全角・半角、ひらがな・カタカナ、大文字・小文字、ハイフンの表記ゆれを吸収する。
+package textnorm
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Fold 検索用に表記をそろえる
+// NFKCで全角英数字を半角に、半角カタカナを全角に（濁点も合成）してから、
+// カタカナをひらがなに、英字を小文字にし、空白を除いてハイフン類を"-"にそろえる。
+func Fold(s string) string {
+	folded, _ := FoldRunes(s)
+	var b strings.Builder
+	b.Grow(len(folded))
+	for _, r := range folded {
+		if !unicode.IsSpace(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Span 元の文字列での位置（文字単位、Endは含まない）
+type Span struct {
+	Start, End int
+}
+
+// FoldRunes Foldと同じように表記をそろえ、そろえた1文字ごとに元の文字列での位置を返す
+// 空白は除かずにそのまま残す。検索語の一致箇所を元の文字列で示すために使う。
+func FoldRunes(s string) ([]rune, []Span) {
+	src := []rune(s)
+	folded := make([]rune, 0, len(src))
+	spans := make([]Span, 0, len(src))
+	for i := 0; i < len(src); {
+		// 濁点・半濁点などの結合文字は直前の文字とまとめて正規化する
+		j := i + 1
+		for j < len(src) && isCombining(src[j]) {
+			j++
+		}
+		for _, r := range norm.NFKC.String(string(src[i:j])) {
+			switch {
+			case r >= 'ァ' && r <= 'ヶ':
+				r -= 'ァ' - 'ぁ'
+			case isHyphen(r):
+				r = '-'
+			default:
+				r = unicode.ToLower(r)
+			}
+			folded = append(folded, r)
+			spans = append(spans, Span{Start: i, End: j})
+		}
+		i = j
+	}
+	return folded, spans
+}
+
+// Code 番号・マイクロチップなどの識別子を検索用にそろえる（Foldに加えてハイフンも除く）
+func Code(s string) string {
+	return strings.ReplaceAll(Fold(s), "-", "")
+}
+
+// addressReplacer 住所の「丁目・番地・番・号」をハイフン区切りにそろえる
+var addressReplacer = strings.NewReplacer("丁目", "-", "番地", "-", "番", "-", "号", "")
+
+// Address 住所を照合用にそろえる（Foldに加えて「1丁目2番3号」と「1-2-3」の違いをそろえる）
+func Address(s string) string {
+	return strings.TrimRight(addressReplacer.Replace(Fold(s)), "-")
+}
+
+// Digits 電話番号などから数字だけを取り出す（全角数字も半角にする）
+func Digits(s string) string {
+	s = norm.NFKC.String(s)
+	var b strings.Builder
+	for _, r := range s {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// IsNumeric 数字と区切り文字（ハイフン・空白・括弧・+）だけからなるかどうか
+// 電話番号や飼い主番号として検索できる入力かを判定する。長音符「ー」も電話番号の区切りとみなす。
+func IsNumeric(s string) bool {
+	s = norm.NFKC.String(s)
+	digits := 0
+	for _, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits++
+		case unicode.IsSpace(r), isHyphen(r), r == 'ー', r == '(', r == ')', r == '+':
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
+
+// isCombining 直前の文字と合成される文字（結合文字と半角の濁点・半濁点）
+func isCombining(r rune) bool {
+	return unicode.Is(unicode.Mn, r) || r == 'ﾞ' || r == 'ﾟ'
+}
+
+// isHyphen ハイフン・ダッシュ・マイナス記号の類
+func isHyphen(r rune) bool {
+	switch r {
+	case '-', '‐', '‑', '‒', '–', '—', '―', '−', '﹣', '－':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+package textnorm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFold(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"katakana to hiragana", "ヤマダ タロウ", "やまだたろう"},
+		{"half-width katakana with voiced marks", "ﾔﾏﾀﾞ ｼﾞﾛｳ", "やまだじろう"},
+		{"full-width alphanumerics", "ＰＯＣＨＩ１２３", "pochi123"},
+		{"long vowel mark is kept", "ルーシー", "るーしー"},
+		{"hyphen variants", "P‐001", "p-001"},
+		{"kanji unchanged", "山田　太郎", "山田太郎"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Fold(tt.in))
+		})
+	}
+}
+
+func TestCode(t *testing.T) {
+	assert.Equal(t, "392141000123456", Code("392-141-000 123 456"))
+	assert.Equal(t, "p001", Code("Ｐ－００１"))
+}
+
+func TestAddress(t *testing.T) {
+	assert.Equal(t, "東京都港区芝公園4-2-8", Address("東京都港区芝公園4丁目2番8号"))
+	assert.Equal(t, "東京都港区芝公園4-2-8", Address("東京都港区芝公園４－２－８"))
+	assert.Equal(t, "東京都港区芝公園4-2", Address("東京都 港区 芝公園4丁目2番地"))
+}
+
+func TestDigits(t *testing.T) {
+	assert.Equal(t, "09012345678", Digits("090-1234-5678"))
+	assert.Equal(t, "0312345678", Digits("（０３）１２３４ー５６７８"))
+	assert.Equal(t, "", Digits("やまだ"))
+}
+
+func TestIsNumeric(t *testing.T) {
+	assert.True(t, IsNumeric("090-1234"))
+	assert.True(t, IsNumeric("０９０ー１２３４"))
+	assert.True(t, IsNumeric("30001"))
+	assert.False(t, IsNumeric("ポチ1"))
+	assert.False(t, IsNumeric("--"))
+	assert.False(t, IsNumeric(""))
+}
+
+func TestFoldRunes(t *testing.T) {
+	folded, spans := FoldRunes("ﾒﾛｷｼｶﾑ ５mg")
+
+	assert.Equal(t, "めろきしかむ 5mg", string(folded))
+	assert.Equal(t, Span{Start: 0, End: 1}, spans[0])
+	// 半角の濁点は直前の文字とまとめて1文字になる
+	folded, spans = FoldRunes("ｶﾞﾑ")
+	assert.Equal(t, "がむ", string(folded))
+	assert.Equal(t, []Span{{Start: 0, End: 2}, {Start: 2, End: 3}}, spans)
+}
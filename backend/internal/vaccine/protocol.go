@@ -0,0 +1,91 @@
+// Package vaccine はワクチンマスタの接種プロトコルから次回接種予定日を求める。
+package vaccine
+
+import (
+	"time"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// DefaultSeriesIntervalWeeks 初回接種の最短間隔（プロトコルで省略した場合）
+const DefaultSeriesIntervalWeeks = 4
+
+// AppliesTo プロトコルが動物種に適用できるかどうか
+// 対象種の指定がない場合と、ペットの種類が不明な場合は適用できるものとする。
+func AppliesTo(p *model.VaccineProtocol, species string) bool {
+	kind := model.SpeciesKind(species)
+	if p == nil || len(p.Species) == 0 || kind == "" {
+		return true
+	}
+	for _, s := range p.Species {
+		if model.SpeciesKind(s) == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// NextDate 接種日dateの次の接種予定日を求める（次回接種がない場合はfalse）
+// birthDateはペットの生年月日（不明ならnil）、previousは同じワクチンのdateより前の接種日。
+//
+//   - 幼齢期シリーズの途中なら、次の接種週齢（前回から最短間隔を空ける）
+//   - 成体で初回接種の途中なら、最短間隔の後
+//   - それ以外は追加接種間隔の後
+func NextDate(p *model.VaccineProtocol, birthDate *time.Time, date time.Time, previous []time.Time) (time.Time, bool) {
+	if p == nil {
+		return time.Time{}, false
+	}
+	interval := p.SeriesIntervalWeeks
+	if interval <= 0 {
+		interval = DefaultSeriesIntervalWeeks
+	}
+	earliest := date.AddDate(0, 0, 7*interval)
+
+	if birthDate != nil {
+		for _, weeks := range p.SeriesWeeks {
+			due := birthDate.AddDate(0, 0, 7*weeks)
+			if due.After(date) {
+				return later(due, earliest), true
+			}
+		}
+	}
+	if !inJuvenileSeries(p, birthDate, previous) && dosesBefore(previous, date)+1 < p.InitialDoses {
+		return earliest, true
+	}
+	if p.BoosterMonths > 0 {
+		return date.AddDate(0, p.BoosterMonths, 0), true
+	}
+	return time.Time{}, false
+}
+
+// inJuvenileSeries 幼齢期シリーズで初回接種を済ませたかどうか
+// シリーズの最終週齢までに接種歴があれば、成体の初回接種回数は適用しない。
+func inJuvenileSeries(p *model.VaccineProtocol, birthDate *time.Time, previous []time.Time) bool {
+	if birthDate == nil || len(p.SeriesWeeks) == 0 {
+		return false
+	}
+	last := birthDate.AddDate(0, 0, 7*p.SeriesWeeks[len(p.SeriesWeeks)-1])
+	for _, d := range previous {
+		if !d.After(last) {
+			return true
+		}
+	}
+	return false
+}
+
+func dosesBefore(previous []time.Time, date time.Time) int {
+	n := 0
+	for _, d := range previous {
+		if d.Before(date) {
+			n++
+		}
+	}
+	return n
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,86 @@
+package vaccine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+func datePtr(s string) *time.Time {
+	t := date(s)
+	return &t
+}
+
+func TestAppliesTo(t *testing.T) {
+	dogOnly := &model.VaccineProtocol{Species: []string{"犬"}}
+
+	assert.True(t, AppliesTo(dogOnly, "イヌ"))
+	assert.True(t, AppliesTo(dogOnly, "Dog"))
+	assert.False(t, AppliesTo(dogOnly, "猫"))
+	assert.True(t, AppliesTo(dogOnly, ""))
+	assert.True(t, AppliesTo(&model.VaccineProtocol{}, "うさぎ"))
+	assert.True(t, AppliesTo(nil, "猫"))
+}
+
+func TestNextDate(t *testing.T) {
+	combo := &model.VaccineProtocol{
+		SeriesWeeks:         []int{8, 12, 16},
+		InitialDoses:        2,
+		SeriesIntervalWeeks: 3,
+		BoosterMonths:       12,
+	}
+	rabies := &model.VaccineProtocol{BoosterMonths: 12}
+	birth := datePtr("2024-01-01")
+
+	tests := []struct {
+		name     string
+		protocol *model.VaccineProtocol
+		birth    *time.Time
+		date     string
+		previous []string
+		want     string
+	}{
+		// 8週齢で接種 → 12週齢
+		{"series first dose", combo, birth, "2024-02-26", nil, "2024-03-25"},
+		// 11週齢で接種 → 12週齢は近すぎるので3週後
+		{"series keeps interval", combo, birth, "2024-03-18", []string{"2024-02-26"}, "2024-04-08"},
+		// 16週齢で接種 → シリーズ完了、1年後
+		{"series complete", combo, birth, "2024-04-22", []string{"2024-02-26", "2024-03-25"}, "2025-04-22"},
+		// 成体で初めて → 3週後に2回目
+		{"adult initial", combo, datePtr("2020-01-01"), "2024-05-01", nil, "2024-05-22"},
+		// 成体の2回目 → 1年後
+		{"adult second dose", combo, datePtr("2020-01-01"), "2024-05-22", []string{"2024-05-01"}, "2025-05-22"},
+		// 子犬期に接種済みの追加接種 → 1年後
+		{"booster after puppy series", combo, birth, "2025-04-22", []string{"2024-02-26", "2024-03-25", "2024-04-22"}, "2026-04-22"},
+		// 生年月日不明の初回 → 初回接種回数に従う
+		{"unknown birth date", combo, nil, "2024-05-01", nil, "2024-05-22"},
+		{"annual booster", rabies, birth, "2024-04-22", nil, "2025-04-22"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var previous []time.Time
+			for _, p := range tt.previous {
+				previous = append(previous, date(p))
+			}
+			got, ok := NextDate(tt.protocol, tt.birth, date(tt.date), previous)
+			assert.True(t, ok)
+			assert.Equal(t, tt.want, got.Format("2006-01-02"))
+		})
+	}
+}
+
+func TestNextDate_NoBooster(t *testing.T) {
+	_, ok := NextDate(&model.VaccineProtocol{}, nil, date("2024-05-01"), nil)
+	assert.False(t, ok)
+
+	_, ok = NextDate(nil, nil, date("2024-05-01"), nil)
+	assert.False(t, ok)
+}
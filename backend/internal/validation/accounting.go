@@ -0,0 +1,142 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// 会計で更新可能なステータス（回収済への変更は入金処理で行う）
+var accountingStatuses = map[string]bool{
+	model.AccountingStatusUnpaid:    true,
+	model.AccountingStatusOnHold:    true,
+	model.AccountingStatusCancelled: true,
+}
+
+var paymentMethods = map[string]bool{
+	model.PaymentMethodCash:       true,
+	model.PaymentMethodCreditCard: true,
+	model.PaymentMethodEMoney:     true,
+}
+
+// 消費税率（標準10%・軽減8%）
+var taxRates = []decimal.Decimal{
+	decimal.MustParse("0.10"),
+	decimal.MustParse("0.08"),
+}
+
+// ValidateCreateAccounting validates the create accounting request
+func ValidateCreateAccounting(req *model.CreateAccountingRequest) error {
+	if req.MedicalRecordID == "" {
+		return apperrors.WrapInvalidInput("medical record ID is required")
+	}
+	if _, err := uuid.Parse(req.MedicalRecordID); err != nil {
+		return apperrors.WrapInvalidInput("invalid medical record ID format")
+	}
+	if err := validateInsuranceRatio(req.InsuranceRatio); err != nil {
+		return err
+	}
+	if err := validateDiscountAmount(req.DiscountAmount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateUpdateAccounting validates the update accounting request
+func ValidateUpdateAccounting(req *model.UpdateAccountingRequest) error {
+	if req.Status != nil && !accountingStatuses[*req.Status] {
+		return apperrors.WrapInvalidInput("status must be one of 未収, 保留, キャンセル")
+	}
+	if req.InsuranceName != nil && len(*req.InsuranceName) > 100 {
+		return apperrors.WrapInvalidInput("insurance name must be less than 100 characters")
+	}
+	if err := validateInsuranceRatio(req.InsuranceRatio); err != nil {
+		return err
+	}
+	if err := validateDiscountAmount(req.DiscountAmount); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ValidateAddAccountingItem validates the add accounting item request
+func ValidateAddAccountingItem(req *model.AddAccountingItemRequest) error {
+	if req.MasterID != "" {
+		if _, err := uuid.Parse(req.MasterID); err != nil {
+			return apperrors.WrapInvalidInput("invalid master ID format")
+		}
+	} else {
+		if req.Name == "" {
+			return apperrors.WrapInvalidInput("name is required when master ID is not specified")
+		}
+		if req.UnitPrice == nil {
+			return apperrors.WrapInvalidInput("unit price is required when master ID is not specified")
+		}
+	}
+	if len(req.Name) > 200 {
+		return apperrors.WrapInvalidInput("name must be less than 200 characters")
+	}
+	if req.UnitPrice != nil && req.UnitPrice.IsNegative() {
+		return apperrors.WrapInvalidInput("unit price must not be negative")
+	}
+	if req.Quantity < 0 {
+		return apperrors.WrapInvalidInput("quantity must not be negative")
+	}
+	if req.TaxRate != nil && !isTaxRate(*req.TaxRate) {
+		return apperrors.WrapInvalidInput("tax rate must be 0.10 or 0.08")
+	}
+	return nil
+}
+
+// ValidateCompleteAccounting validates the complete accounting request
+func ValidateCompleteAccounting(req *model.CompleteAccountingRequest) error {
+	if !paymentMethods[req.PaymentMethod] {
+		return apperrors.WrapInvalidInput("payment method must be one of 現金, クレジットカード, 電子マネー")
+	}
+	if req.ReceivedAmount != nil && req.ReceivedAmount.IsNegative() {
+		return apperrors.WrapInvalidInput("received amount must not be negative")
+	}
+	return nil
+}
+
+// ValidateAddMedicalRecordItem validates the add medical record item request
+func ValidateAddMedicalRecordItem(req *model.AddMedicalRecordItemRequest) error {
+	if req.MasterItemID == "" {
+		return apperrors.WrapInvalidInput("master item ID is required")
+	}
+	if _, err := uuid.Parse(req.MasterItemID); err != nil {
+		return apperrors.WrapInvalidInput("invalid master item ID format")
+	}
+	if req.Quantity < 0 {
+		return apperrors.WrapInvalidInput("quantity must not be negative")
+	}
+	return nil
+}
+
+func validateInsuranceRatio(ratio *decimal.Decimal) error {
+	if ratio == nil {
+		return nil
+	}
+	if ratio.IsNegative() || ratio.Cmp(decimal.FromInt(1)) > 0 {
+		return apperrors.WrapInvalidInput("insurance ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+func validateDiscountAmount(amount *decimal.Decimal) error {
+	if amount != nil && amount.IsNegative() {
+		return apperrors.WrapInvalidInput("discount amount must not be negative")
+	}
+	return nil
+}
+
+func isTaxRate(rate decimal.Decimal) bool {
+	for _, r := range taxRates {
+		if r.Cmp(rate) == 0 {
+			return true
+		}
+	}
+	return false
+}
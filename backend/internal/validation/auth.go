@@ -0,0 +1,34 @@
+package validation
+
+import (
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+const (
+	minPasswordLength = 8
+	// bcryptは72バイトを超える部分を無視するため、それ以上は受け付けない
+	maxPasswordLength = 72
+)
+
+// ValidateLogin validates the login request
+func ValidateLogin(req *model.LoginRequest) error {
+	if req.Email == "" {
+		return apperrors.WrapInvalidInput("email is required")
+	}
+	if req.Password == "" {
+		return apperrors.WrapInvalidInput("password is required")
+	}
+	return nil
+}
+
+// ValidatePassword validates a new staff password
+func ValidatePassword(password string) error {
+	if len(password) < minPasswordLength {
+		return apperrors.WrapInvalidInput("password must be at least 8 characters")
+	}
+	if len(password) > maxPasswordLength {
+		return apperrors.WrapInvalidInput("password must be at most 72 bytes")
+	}
+	return nil
+}
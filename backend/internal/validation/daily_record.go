@@ -0,0 +1,97 @@
+package validation
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var careLogTypes = map[string]bool{
+	"food":      true,
+	"excretion": true,
+	"medicine":  true,
+	"treatment": true,
+	"other":     true,
+}
+
+var careLogStatuses = map[string]bool{
+	model.CareLogStatusCompleted: true,
+	model.CareLogStatusPartial:   true,
+	model.CareLogStatusSkipped:   true,
+}
+
+// ValidateAddVital validates the add vital request
+func ValidateAddVital(req *model.AddVitalRequest) error {
+	if err := validateDailyRecordEntry(&req.DailyRecordEntry); err != nil {
+		return err
+	}
+	if req.Temperature == nil && req.HeartRate == nil && req.RespirationRate == nil && req.Weight == nil {
+		return apperrors.WrapInvalidInput("at least one of temperature, heart rate, respiration rate or weight is required")
+	}
+	if req.Temperature != nil && (*req.Temperature < 25 || *req.Temperature > 45) {
+		return apperrors.WrapInvalidInput("temperature must be between 25 and 45")
+	}
+	if req.HeartRate != nil && (*req.HeartRate <= 0 || *req.HeartRate > 500) {
+		return apperrors.WrapInvalidInput("heart rate must be between 1 and 500")
+	}
+	if req.RespirationRate != nil && (*req.RespirationRate <= 0 || *req.RespirationRate > 300) {
+		return apperrors.WrapInvalidInput("respiration rate must be between 1 and 300")
+	}
+	if req.Weight != nil && (*req.Weight <= 0 || *req.Weight >= 1000) {
+		return apperrors.WrapInvalidInput("weight must be greater than 0 and less than 1000")
+	}
+	return nil
+}
+
+// ValidateAddCareLog validates the add care log request
+func ValidateAddCareLog(req *model.AddCareLogRequest) error {
+	if err := validateDailyRecordEntry(&req.DailyRecordEntry); err != nil {
+		return err
+	}
+	if !careLogTypes[req.Type] {
+		return apperrors.WrapInvalidInput("type must be one of food, excretion, medicine, treatment, other")
+	}
+	if req.Status != "" && !careLogStatuses[req.Status] {
+		return apperrors.WrapInvalidInput("status must be one of completed, partial, skipped")
+	}
+	if req.CarePlanItemID != "" {
+		if _, err := uuid.Parse(req.CarePlanItemID); err != nil {
+			return apperrors.WrapInvalidInput("invalid care plan item ID format")
+		}
+	} else if req.Timing != "" {
+		return apperrors.WrapInvalidInput("timing requires care plan item ID")
+	}
+	if len(req.Value) > 100 {
+		return apperrors.WrapInvalidInput("value must be less than 100 characters")
+	}
+	return nil
+}
+
+// ValidateAddStaffNote validates the add staff note request
+func ValidateAddStaffNote(req *model.AddStaffNoteRequest) error {
+	if err := validateDailyRecordEntry(&req.DailyRecordEntry); err != nil {
+		return err
+	}
+	if strings.TrimSpace(req.Content) == "" {
+		return apperrors.WrapInvalidInput("content is required")
+	}
+	return nil
+}
+
+func validateDailyRecordEntry(entry *model.DailyRecordEntry) error {
+	if entry.RecordDate != "" {
+		if _, err := time.Parse("2006-01-02", entry.RecordDate); err != nil {
+			return apperrors.WrapInvalidInput("invalid record date format (expected YYYY-MM-DD)")
+		}
+	}
+	if entry.RecordedTime != "" {
+		if !isClock(entry.RecordedTime) {
+			return apperrors.WrapInvalidInput("invalid recorded time format (expected HH:MM)")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package validation
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var examinationStatuses = map[string]bool{
+	model.ExaminationStatusOrdered:    true,
+	model.ExaminationStatusInProgress: true,
+	model.ExaminationStatusCompleted:  true,
+}
+
+// ValidateExaminationStatus validates the status of an examination
+func ValidateExaminationStatus(status string) error {
+	if !examinationStatuses[status] {
+		return apperrors.WrapInvalidInput("invalid examination status")
+	}
+	return nil
+}
+
+// ValidateCreateExamination validates the create examination request
+func ValidateCreateExamination(req *model.CreateExaminationRequest) error {
+	if _, err := uuid.Parse(req.PetID); err != nil {
+		return apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if req.MedicalRecordID != "" {
+		if _, err := uuid.Parse(req.MedicalRecordID); err != nil {
+			return apperrors.WrapInvalidInput("invalid medical record ID format")
+		}
+	}
+	if req.DoctorID != "" {
+		if _, err := uuid.Parse(req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+	if len([]rune(req.TestType)) > 100 {
+		return apperrors.WrapInvalidInput("test type must be 100 characters or less")
+	}
+	if len([]rune(req.Machine)) > 100 {
+		return apperrors.WrapInvalidInput("machine must be 100 characters or less")
+	}
+	return validateAnalytes(req.Analytes)
+}
+
+// ValidateUpdateExamination validates the update examination request
+func ValidateUpdateExamination(req *model.UpdateExaminationRequest) error {
+	if req.DoctorID != nil && *req.DoctorID != "" {
+		if _, err := uuid.Parse(*req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+	if req.TestType != nil && len([]rune(*req.TestType)) > 100 {
+		return apperrors.WrapInvalidInput("test type must be 100 characters or less")
+	}
+	if req.Machine != nil && len([]rune(*req.Machine)) > 100 {
+		return apperrors.WrapInvalidInput("machine must be 100 characters or less")
+	}
+	if req.Status != nil && *req.Status != model.ExaminationStatusOrdered && *req.Status != model.ExaminationStatusInProgress {
+		return apperrors.WrapInvalidInput("status must be 依頼中 or 検査中 (use complete to finish an examination)")
+	}
+	if req.Analytes != nil {
+		return validateAnalytes(*req.Analytes)
+	}
+	return nil
+}
+
+// ValidateCompleteExamination validates the complete examination request
+func ValidateCompleteExamination(req *model.CompleteExaminationRequest) error {
+	if len(req.Results) == 0 {
+		return apperrors.WrapInvalidInput("at least one result is required")
+	}
+	analytes := make([]string, len(req.Results))
+	for i, result := range req.Results {
+		if result.Value == nil && strings.TrimSpace(result.TextValue) == "" {
+			return apperrors.WrapInvalidInput("result for " + result.Analyte + " needs a value or text value")
+		}
+		analytes[i] = result.Analyte
+	}
+	return validateAnalytes(analytes)
+}
+
+// ValidateExaminationTrend validates the examination trend request
+func ValidateExaminationTrend(req *model.ExaminationTrendRequest) error {
+	return validateAnalytes([]string{req.Analyte})
+}
+
+// ValidateSaveLabReferenceRange validates the save reference range request
+func ValidateSaveLabReferenceRange(req *model.SaveLabReferenceRangeRequest) error {
+	if err := validateAnalytes([]string{req.Analyte}); err != nil {
+		return err
+	}
+	if req.Low == nil && req.High == nil {
+		return apperrors.WrapInvalidInput("low or high is required")
+	}
+	if req.Low != nil && req.High != nil && *req.Low > *req.High {
+		return apperrors.WrapInvalidInput("low must not be greater than high")
+	}
+	if len([]rune(req.Unit)) > 30 {
+		return apperrors.WrapInvalidInput("unit must be 30 characters or less")
+	}
+	return nil
+}
+
+func validateAnalytes(analytes []string) error {
+	seen := map[string]bool{}
+	for _, analyte := range analytes {
+		code := model.NormalizeAnalyte(analyte)
+		if code == "" {
+			return apperrors.WrapInvalidInput("analyte is required")
+		}
+		if len(code) > 30 {
+			return apperrors.WrapInvalidInput("analyte must be 30 characters or less")
+		}
+		if seen[code] {
+			return apperrors.WrapInvalidInput("duplicate analyte: " + code)
+		}
+		seen[code] = true
+	}
+	return nil
+}
+
+var analyzerImportStatuses = map[string]bool{
+	model.AnalyzerImportStatusUnmatched: true,
+	model.AnalyzerImportStatusMatched:   true,
+	model.AnalyzerImportStatusDiscarded: true,
+}
+
+// ValidateAnalyzerImportStatus validates the status of an analyzer import
+func ValidateAnalyzerImportStatus(status string) error {
+	if !analyzerImportStatuses[status] {
+		return apperrors.WrapInvalidInput("invalid analyzer import status")
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var hospitalizationTypes = map[string]bool{
+	model.HospitalizationTypeInpatient: true,
+	model.HospitalizationTypeHotel:     true,
+}
+
+var carePlanItemTypes = map[string]bool{
+	"food":        true,
+	"medicine":    true,
+	"treatment":   true,
+	"instruction": true,
+	"item":        true,
+}
+
+// ValidateCreateHospitalization validates the create hospitalization request
+func ValidateCreateHospitalization(req *model.CreateHospitalizationRequest) error {
+	if req.PetID == "" {
+		return apperrors.WrapInvalidInput("pet ID is required")
+	}
+	if _, err := uuid.Parse(req.PetID); err != nil {
+		return apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if req.Type != "" && !hospitalizationTypes[req.Type] {
+		return apperrors.WrapInvalidInput("type must be 入院 or ホテル")
+	}
+	if req.StartDate == "" {
+		return apperrors.WrapInvalidInput("start date is required")
+	}
+	if req.EndDate == "" {
+		return apperrors.WrapInvalidInput("end date is required")
+	}
+	if req.CageID != "" {
+		if _, err := uuid.Parse(req.CageID); err != nil {
+			return apperrors.WrapInvalidInput("invalid cage ID format")
+		}
+	}
+	if err := validateCageSize(req.CageSize); err != nil {
+		return err
+	}
+	if req.Status != "" && req.Status != model.HospitalizationStatusReserved && req.Status != model.HospitalizationStatusAdmitted {
+		return apperrors.WrapInvalidInput("status must be 予約 or 入院中")
+	}
+	return nil
+}
+
+// ValidateUpdateHospitalization validates the update hospitalization request
+func ValidateUpdateHospitalization(req *model.UpdateHospitalizationRequest) error {
+	if req.CageID != nil {
+		if _, err := uuid.Parse(*req.CageID); err != nil {
+			return apperrors.WrapInvalidInput("invalid cage ID format")
+		}
+	}
+	if req.Status != nil {
+		switch *req.Status {
+		case model.HospitalizationStatusReserved, model.HospitalizationStatusAdmitted, model.HospitalizationStatusTemporaryLeave:
+		default:
+			return apperrors.WrapInvalidInput("status must be 予約, 入院中 or 一時帰宅; use discharge to discharge")
+		}
+	}
+	return nil
+}
+
+// ValidateAvailableCages validates the available cages request
+func ValidateAvailableCages(req *model.AvailableCagesRequest) error {
+	if req.StartDate == "" {
+		return apperrors.WrapInvalidInput("start date is required")
+	}
+	if req.EndDate == "" {
+		return apperrors.WrapInvalidInput("end date is required")
+	}
+	return validateCageSize(req.Size)
+}
+
+// ValidateAddCarePlanItem validates the add care plan item request
+func ValidateAddCarePlanItem(req *model.AddCarePlanItemRequest) error {
+	if !carePlanItemTypes[req.Type] {
+		return apperrors.WrapInvalidInput("type must be one of food, medicine, treatment, instruction, item")
+	}
+	if req.MasterID != "" {
+		if _, err := uuid.Parse(req.MasterID); err != nil {
+			return apperrors.WrapInvalidInput("invalid master ID format")
+		}
+	} else if req.Name == "" {
+		return apperrors.WrapInvalidInput("name is required when master ID is not specified")
+	}
+	if len(req.Name) > 100 {
+		return apperrors.WrapInvalidInput("name must be less than 100 characters")
+	}
+	if req.UnitPrice != nil && req.UnitPrice.IsNegative() {
+		return apperrors.WrapInvalidInput("unit price must not be negative")
+	}
+	if req.Timing != nil {
+		return ValidateCareSchedule(req.Timing)
+	}
+	return nil
+}
+
+// ValidateCareSchedule validates a care plan schedule
+func ValidateCareSchedule(schedule *model.CareSchedule) error {
+	modes := 0
+	if len(schedule.Times) > 0 {
+		modes++
+	}
+	if schedule.EveryHours != 0 {
+		modes++
+	}
+	if schedule.PRN {
+		modes++
+	}
+	if modes > 1 {
+		return apperrors.WrapInvalidInput("timing must specify only one of times, every_hours or prn")
+	}
+
+	seen := make(map[string]bool, len(schedule.Times))
+	for _, timing := range schedule.Times {
+		if _, ok := model.CareTimingSlots[timing]; !ok && !isClock(timing) {
+			return apperrors.WrapInvalidInput("timing times must be morning, noon, night or HH:MM")
+		}
+		if seen[timing] {
+			return apperrors.WrapInvalidInput("timing times must not contain duplicates")
+		}
+		seen[timing] = true
+	}
+	if schedule.EveryHours < 0 || schedule.EveryHours > 24 {
+		return apperrors.WrapInvalidInput("timing every_hours must be between 1 and 24")
+	}
+	if schedule.Start != "" {
+		if schedule.EveryHours == 0 {
+			return apperrors.WrapInvalidInput("timing start requires every_hours")
+		}
+		if !isClock(schedule.Start) {
+			return apperrors.WrapInvalidInput("timing start must be HH:MM")
+		}
+	}
+	return nil
+}
+
+func isClock(s string) bool {
+	_, err := time.Parse("15:04", s)
+	return err == nil
+}
+
+func validateCageSize(size string) error {
+	if size == "" {
+		return nil
+	}
+	for _, s := range model.CageSizes {
+		if s == size {
+			return nil
+		}
+	}
+	return apperrors.WrapInvalidInput("cage size must be one of S, M, L, XL")
+}
@@ -0,0 +1,95 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var inventoryCategories = map[string]bool{
+	"medicine":   true,
+	"consumable": true,
+	"food":       true,
+	"other":      true,
+}
+
+var stockMovementTypes = map[string]bool{
+	model.StockMovementReceive:  true,
+	model.StockMovementDispense: true,
+	model.StockMovementAdjust:   true,
+	model.StockMovementExpire:   true,
+}
+
+// ValidateCreateInventoryItem validates the create inventory item request
+func ValidateCreateInventoryItem(req *model.CreateInventoryItemRequest) error {
+	if req.Name == "" || len(req.Name) > 200 {
+		return apperrors.WrapInvalidInput("name must be between 1 and 200 characters")
+	}
+	if req.Category != "" && !inventoryCategories[req.Category] {
+		return apperrors.WrapInvalidInput("category must be one of medicine, consumable, food, other")
+	}
+	if req.Quantity < 0 {
+		return apperrors.WrapInvalidInput("quantity must not be negative")
+	}
+	if req.MinStockLevel < 0 {
+		return apperrors.WrapInvalidInput("min stock level must not be negative")
+	}
+	return validateLot(req.LotNumber, req.ExpiryDate)
+}
+
+// ValidateUpdateInventoryItem validates the update inventory item request
+func ValidateUpdateInventoryItem(req *model.UpdateInventoryItemRequest) error {
+	if req.Name != nil && (*req.Name == "" || len(*req.Name) > 200) {
+		return apperrors.WrapInvalidInput("name must be between 1 and 200 characters")
+	}
+	if req.Category != nil && *req.Category != "" && !inventoryCategories[*req.Category] {
+		return apperrors.WrapInvalidInput("category must be one of medicine, consumable, food, other")
+	}
+	if req.MinStockLevel != nil && *req.MinStockLevel < 0 {
+		return apperrors.WrapInvalidInput("min stock level must not be negative")
+	}
+	return nil
+}
+
+// ValidateCreateStockMovement validates the create stock movement request
+func ValidateCreateStockMovement(req *model.CreateStockMovementRequest) error {
+	if !stockMovementTypes[req.Type] {
+		return apperrors.WrapInvalidInput("type must be one of receive, dispense, adjust, expire")
+	}
+	if req.Quantity == 0 {
+		return apperrors.WrapInvalidInput("quantity must not be zero")
+	}
+	if req.Type != model.StockMovementAdjust && req.Quantity < 0 {
+		return apperrors.WrapInvalidInput("quantity must be positive")
+	}
+	if req.Type == model.StockMovementAdjust && req.Reason == "" {
+		return apperrors.WrapInvalidInput("reason is required for adjustment")
+	}
+	if req.Type == model.StockMovementReceive {
+		if req.LotID != "" {
+			return apperrors.WrapInvalidInput("lot ID cannot be specified for receive (use lot number)")
+		}
+		return validateLot(req.LotNumber, req.ExpiryDate)
+	}
+	if req.LotID != "" {
+		if _, err := uuid.Parse(req.LotID); err != nil {
+			return apperrors.WrapInvalidInput("invalid lot ID format")
+		}
+	}
+	return nil
+}
+
+func validateLot(lotNumber, expiryDate string) error {
+	if len(lotNumber) > 50 {
+		return apperrors.WrapInvalidInput("lot number must be less than 50 characters")
+	}
+	if expiryDate != "" {
+		if _, err := time.Parse("2006-01-02", expiryDate); err != nil {
+			return apperrors.WrapInvalidInput("invalid expiry date format (expected YYYY-MM-DD)")
+		}
+	}
+	return nil
+}
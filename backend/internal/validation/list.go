@@ -0,0 +1,17 @@
+package validation
+
+import (
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ValidateListOptions validates the common sort/cursor/limit parameters of list requests
+func ValidateListOptions(opts model.ListOptions) error {
+	if opts.Limit < 0 || opts.Limit > model.MaxListLimit {
+		return apperrors.WrapInvalidInput("limit must be between 1 and 100")
+	}
+	if len(opts.Sort) > 200 {
+		return apperrors.WrapInvalidInput("sort must be less than 200 characters")
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package validation
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/animal-ekarte/backend/internal/decimal"
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ValidateVaccineProtocol validates the vaccine protocol of a master item
+func ValidateVaccineProtocol(p *model.VaccineProtocol) error {
+	if p == nil {
+		return nil
+	}
+	for _, species := range p.Species {
+		if strings.TrimSpace(species) == "" {
+			return apperrors.WrapInvalidInput("species must not be empty")
+		}
+	}
+	prev := 0
+	for _, weeks := range p.SeriesWeeks {
+		if weeks <= prev || weeks > 52 {
+			return apperrors.WrapInvalidInput("series weeks must be ascending ages between 1 and 52 weeks")
+		}
+		prev = weeks
+	}
+	if p.InitialDoses < 0 || p.InitialDoses > 5 {
+		return apperrors.WrapInvalidInput("initial doses must be between 0 and 5")
+	}
+	if p.SeriesIntervalWeeks < 0 || p.SeriesIntervalWeeks > 26 {
+		return apperrors.WrapInvalidInput("series interval must be between 0 and 26 weeks")
+	}
+	if p.BoosterMonths < 0 || p.BoosterMonths > 120 {
+		return apperrors.WrapInvalidInput("booster interval must be between 0 and 120 months")
+	}
+	return nil
+}
+
+// ValidateCreateMasterItem validates the create master item request
+func ValidateCreateMasterItem(req *model.CreateMasterItemRequest) error {
+	if err := validateMasterCode(req.Code); err != nil {
+		return err
+	}
+	if err := validateMasterName(req.Name); err != nil {
+		return err
+	}
+	if err := ValidateMasterCategory(req.Category); err != nil {
+		return err
+	}
+	if err := ValidateMasterPrice(req.Price, req.TaxRate); err != nil {
+		return err
+	}
+	if req.InventoryID != "" {
+		if _, err := uuid.Parse(req.InventoryID); err != nil {
+			return apperrors.WrapInvalidInput("invalid inventory ID format")
+		}
+	}
+	if err := validateDefaultQuantity(req.DefaultQuantity); err != nil {
+		return err
+	}
+	return validateDurationMinutes(req.DurationMinutes)
+}
+
+// ValidateUpdateMasterItem validates the update master item request
+func ValidateUpdateMasterItem(req *model.UpdateMasterItemRequest) error {
+	if req.Code != nil {
+		if err := validateMasterCode(*req.Code); err != nil {
+			return err
+		}
+	}
+	if req.Name != nil {
+		if err := validateMasterName(*req.Name); err != nil {
+			return err
+		}
+	}
+	if req.Category != nil {
+		if err := ValidateMasterCategory(*req.Category); err != nil {
+			return err
+		}
+	}
+	if err := ValidateMasterPrice(req.Price, req.TaxRate); err != nil {
+		return err
+	}
+	if req.Status != nil {
+		if err := ValidateMasterStatus(*req.Status); err != nil {
+			return err
+		}
+	}
+	if req.InventoryID != nil && *req.InventoryID != "" {
+		if _, err := uuid.Parse(*req.InventoryID); err != nil {
+			return apperrors.WrapInvalidInput("invalid inventory ID format")
+		}
+	}
+	if err := validateDefaultQuantity(req.DefaultQuantity); err != nil {
+		return err
+	}
+	return validateDurationMinutes(req.DurationMinutes)
+}
+
+// ValidateCreateMasterItemPrice validates the create master item price request
+func ValidateCreateMasterItemPrice(req *model.CreateMasterItemPriceRequest) error {
+	if req.Price == nil {
+		return apperrors.WrapInvalidInput("price is required")
+	}
+	if err := ValidateMasterPrice(req.Price, req.TaxRate); err != nil {
+		return err
+	}
+	if _, err := time.Parse("2006-01-02", req.EffectiveFrom); err != nil {
+		return apperrors.WrapInvalidInput("effective from must be in YYYY-MM-DD format")
+	}
+	return nil
+}
+
+// ValidateMasterCategory validates the category of a master item
+func ValidateMasterCategory(category string) error {
+	if !slices.Contains(model.MasterCategories, category) {
+		return apperrors.WrapInvalidInput("category must be one of " + strings.Join(model.MasterCategories, ", "))
+	}
+	return nil
+}
+
+// ValidateMasterStatus validates the status of a master item
+func ValidateMasterStatus(status string) error {
+	if status != model.MasterStatusActive && status != model.MasterStatusInactive {
+		return apperrors.WrapInvalidInput("status must be active or inactive")
+	}
+	return nil
+}
+
+// ValidateMasterPrice validates the price and tax rate of a master item
+func ValidateMasterPrice(price, taxRate *decimal.Decimal) error {
+	if price != nil && price.IsNegative() {
+		return apperrors.WrapInvalidInput("price must not be negative")
+	}
+	if taxRate != nil && !isTaxRate(*taxRate) {
+		return apperrors.WrapInvalidInput("tax rate must be 0.10 or 0.08")
+	}
+	return nil
+}
+
+func validateMasterCode(code string) error {
+	if strings.TrimSpace(code) == "" {
+		return apperrors.WrapInvalidInput("code is required")
+	}
+	if len(code) > 20 {
+		return apperrors.WrapInvalidInput("code must be 20 characters or less")
+	}
+	return nil
+}
+
+func validateMasterName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return apperrors.WrapInvalidInput("name is required")
+	}
+	if len([]rune(name)) > 200 {
+		return apperrors.WrapInvalidInput("name must be 200 characters or less")
+	}
+	return nil
+}
+
+func validateDefaultQuantity(quantity *int) error {
+	if quantity != nil && *quantity < 1 {
+		return apperrors.WrapInvalidInput("default quantity must be at least 1")
+	}
+	return nil
+}
+
+func validateDurationMinutes(minutes *int) error {
+	if minutes != nil && (*minutes < 0 || *minutes > 24*60) {
+		return apperrors.WrapInvalidInput("duration minutes must be between 0 and 1440")
+	}
+	return nil
+}
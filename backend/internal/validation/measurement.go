@@ -0,0 +1,45 @@
+package validation
+
+import (
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ValidateRecordPetMeasurement validates the record pet measurement request
+func ValidateRecordPetMeasurement(req *model.RecordPetMeasurementRequest) error {
+	if req.Weight == nil && req.BodyConditionScore == nil && req.Temperature == nil && req.HeartRate == nil && req.RespirationRate == nil {
+		return apperrors.WrapInvalidInput("at least one of weight, body condition score, temperature, heart rate or respiration rate is required")
+	}
+	if req.Weight != nil && (*req.Weight <= 0 || *req.Weight >= 1000) {
+		return apperrors.WrapInvalidInput("weight must be greater than 0 and less than 1000")
+	}
+	if req.BodyConditionScore != nil && (*req.BodyConditionScore < 1 || *req.BodyConditionScore > 9) {
+		return apperrors.WrapInvalidInput("body condition score must be between 1 and 9")
+	}
+	if req.Temperature != nil && (*req.Temperature < 25 || *req.Temperature > 45) {
+		return apperrors.WrapInvalidInput("temperature must be between 25 and 45")
+	}
+	if req.HeartRate != nil && (*req.HeartRate <= 0 || *req.HeartRate > 500) {
+		return apperrors.WrapInvalidInput("heart rate must be between 1 and 500")
+	}
+	if req.RespirationRate != nil && (*req.RespirationRate <= 0 || *req.RespirationRate > 300) {
+		return apperrors.WrapInvalidInput("respiration rate must be between 1 and 300")
+	}
+	if len(req.Notes) > 1000 {
+		return apperrors.WrapInvalidInput("notes must be less than 1000 characters")
+	}
+	return nil
+}
+
+// ValidateListPetMeasurements validates the list pet measurements request
+func ValidateListPetMeasurements(req *model.ListPetMeasurementsRequest) error {
+	if req.Type != "" {
+		if _, ok := model.MeasurementUnits[req.Type]; !ok {
+			return apperrors.WrapInvalidInput("type must be one of weight, body_condition_score, temperature, heart_rate, respiration_rate")
+		}
+	}
+	if req.AlertPercent < 0 || req.AlertPercent > 100 {
+		return apperrors.WrapInvalidInput("alert_percent must be between 0 and 100")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
 	"github.com/animal-ekarte/backend/internal/model"
 )
 
@@ -205,3 +206,56 @@ func ValidateUpdateMedicalRecord(req *model.UpdateMedicalRecordRequest) error {
 
 	return nil
 }
+
+// ValidateAmendMedicalRecord 確定済カルテ修正リクエストのバリデーション
+func ValidateAmendMedicalRecord(req *model.AmendMedicalRecordRequest) error {
+	if req == nil {
+		return apperrors.WrapInvalidInput("request is nil")
+	}
+
+	var errors []string
+
+	// Reasonの検証
+	if strings.TrimSpace(req.Reason) == "" {
+		errors = append(errors, "reason is required")
+	} else if len(req.Reason) > 1000 {
+		errors = append(errors, "reason must be less than 1000 characters")
+	}
+
+	limits := []struct {
+		name  string
+		value *string
+		max   int
+	}{
+		{"chief_complaint", req.ChiefComplaint, 1000},
+		{"subjective", req.Subjective, 2000},
+		{"objective", req.Objective, 2000},
+		{"assessment", req.Assessment, 2000},
+		{"plan", req.Plan, 2000},
+		{"surgery_notes", req.SurgeryNotes, 2000},
+		{"diagnosis", req.Diagnosis, 1000},
+		{"treatment", req.Treatment, 2000},
+		{"prescription", req.Prescription, 2000},
+		{"notes", req.Notes, 2000},
+	}
+
+	changed := false
+	for _, l := range limits {
+		if l.value == nil {
+			continue
+		}
+		changed = true
+		if len(*l.value) > l.max {
+			errors = append(errors, fmt.Sprintf("%s must be less than %d characters", l.name, l.max))
+		}
+	}
+	if !changed {
+		errors = append(errors, "at least one field must be amended")
+	}
+
+	if len(errors) > 0 {
+		return apperrors.WrapInvalidInput(fmt.Sprintf("validation failed: %s", strings.Join(errors, "; ")))
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var reservationStatuses = map[string]bool{
+	model.ReservationStatusPending:        true,
+	model.ReservationStatusConfirmed:      true,
+	model.ReservationStatusCheckedIn:      true,
+	model.ReservationStatusInConsultation: true,
+	model.ReservationStatusAccounting:     true,
+	model.ReservationStatusCompleted:      true,
+	model.ReservationStatusCanceled:       true,
+}
+
+// ValidateCreateReservation validates the create reservation request
+func ValidateCreateReservation(req *model.CreateReservationRequest) error {
+	if req.PetID == "" {
+		return apperrors.WrapInvalidInput("pet ID is required")
+	}
+	if _, err := uuid.Parse(req.PetID); err != nil {
+		return apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+
+	if req.OwnerID == "" {
+		return apperrors.WrapInvalidInput("owner ID is required")
+	}
+	if _, err := uuid.Parse(req.OwnerID); err != nil {
+		return apperrors.WrapInvalidInput("invalid owner ID format")
+	}
+
+	if req.DoctorID != "" {
+		if _, err := uuid.Parse(req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+
+	if req.IsDesignated && req.DoctorID == "" {
+		return apperrors.WrapInvalidInput("doctor ID is required for a designated reservation")
+	}
+
+	if req.StartTime == "" {
+		return apperrors.WrapInvalidInput("start time is required")
+	}
+	if req.EndTime == "" {
+		return apperrors.WrapInvalidInput("end time is required")
+	}
+
+	if req.VisitType != "" && req.VisitType != "first" && req.VisitType != "revisit" {
+		return apperrors.WrapInvalidInput("visit type must be 'first' or 'revisit'")
+	}
+
+	if len(req.ServiceType) > 30 {
+		return apperrors.WrapInvalidInput("service type must be less than 30 characters")
+	}
+
+	if req.Status != "" && !reservationStatuses[req.Status] {
+		return apperrors.WrapInvalidInput("invalid reservation status")
+	}
+
+	return nil
+}
+
+// ValidateUpdateReservation validates the update reservation request
+func ValidateUpdateReservation(req *model.UpdateReservationRequest) error {
+	if req.PetID != nil {
+		if _, err := uuid.Parse(*req.PetID); err != nil {
+			return apperrors.WrapInvalidInput("invalid pet ID format")
+		}
+	}
+
+	if req.OwnerID != nil {
+		if _, err := uuid.Parse(*req.OwnerID); err != nil {
+			return apperrors.WrapInvalidInput("invalid owner ID format")
+		}
+	}
+
+	if req.DoctorID != nil && *req.DoctorID != "" {
+		if _, err := uuid.Parse(*req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+
+	if req.VisitType != nil && *req.VisitType != "" && *req.VisitType != "first" && *req.VisitType != "revisit" {
+		return apperrors.WrapInvalidInput("visit type must be 'first' or 'revisit'")
+	}
+
+	if req.ServiceType != nil && len(*req.ServiceType) > 30 {
+		return apperrors.WrapInvalidInput("service type must be less than 30 characters")
+	}
+
+	if req.Status != nil && !reservationStatuses[*req.Status] {
+		return apperrors.WrapInvalidInput("invalid reservation status")
+	}
+
+	return nil
+}
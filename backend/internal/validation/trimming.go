@@ -0,0 +1,89 @@
+package validation
+
+import (
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+var trimmingStatuses = map[string]bool{
+	model.TrimmingStatusBooked:     true,
+	model.TrimmingStatusInProgress: true,
+	model.TrimmingStatusCompleted:  true,
+	model.TrimmingStatusCanceled:   true,
+}
+
+// ValidateTrimmingStatus validates the status of a trimming
+func ValidateTrimmingStatus(status string) error {
+	if !trimmingStatuses[status] {
+		return apperrors.WrapInvalidInput("invalid trimming status")
+	}
+	return nil
+}
+
+// ValidateCreateTrimming validates the create trimming request
+func ValidateCreateTrimming(req *model.CreateTrimmingRequest) error {
+	if _, err := uuid.Parse(req.PetID); err != nil {
+		return apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if req.StaffID != "" {
+		if _, err := uuid.Parse(req.StaffID); err != nil {
+			return apperrors.WrapInvalidInput("invalid staff ID format")
+		}
+	}
+	if req.AppointmentDate == "" {
+		return apperrors.WrapInvalidInput("appointment date is required")
+	}
+	if _, err := uuid.Parse(req.CourseID); err != nil {
+		return apperrors.WrapInvalidInput("invalid course ID format")
+	}
+	return validateTrimmingOptionIDs(req.OptionIDs)
+}
+
+// ValidateUpdateTrimming validates the update trimming request
+func ValidateUpdateTrimming(req *model.UpdateTrimmingRequest) error {
+	if req.StaffID != nil && *req.StaffID != "" {
+		if _, err := uuid.Parse(*req.StaffID); err != nil {
+			return apperrors.WrapInvalidInput("invalid staff ID format")
+		}
+	}
+	if req.CourseID != nil {
+		if _, err := uuid.Parse(*req.CourseID); err != nil {
+			return apperrors.WrapInvalidInput("invalid course ID format")
+		}
+	}
+	if req.OptionIDs != nil {
+		return validateTrimmingOptionIDs(*req.OptionIDs)
+	}
+	return nil
+}
+
+// ValidateCompleteTrimming validates the complete trimming request
+func ValidateCompleteTrimming(req *model.CompleteTrimmingRequest) error {
+	if req.AccountingID == "" {
+		return nil
+	}
+	if req.CreateAccounting {
+		return apperrors.WrapInvalidInput("create_accounting and accounting_id cannot be used together")
+	}
+	if _, err := uuid.Parse(req.AccountingID); err != nil {
+		return apperrors.WrapInvalidInput("invalid accounting ID format")
+	}
+	return nil
+}
+
+func validateTrimmingOptionIDs(ids []string) error {
+	seen := map[uuid.UUID]bool{}
+	for _, id := range ids {
+		uid, err := uuid.Parse(id)
+		if err != nil {
+			return apperrors.WrapInvalidInput("invalid option ID format")
+		}
+		if seen[uid] {
+			return apperrors.WrapInvalidInput("duplicate option ID: " + id)
+		}
+		seen[uid] = true
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package validation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	apperrors "github.com/animal-ekarte/backend/internal/errors"
+	"github.com/animal-ekarte/backend/internal/model"
+)
+
+// ValidateCreateVaccination validates the create vaccination request
+func ValidateCreateVaccination(req *model.CreateVaccinationRequest) error {
+	if _, err := uuid.Parse(req.PetID); err != nil {
+		return apperrors.WrapInvalidInput("invalid pet ID format")
+	}
+	if req.DoctorID != "" {
+		if _, err := uuid.Parse(req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+	if req.VaccineMasterID != "" {
+		if _, err := uuid.Parse(req.VaccineMasterID); err != nil {
+			return apperrors.WrapInvalidInput("invalid vaccine master ID format")
+		}
+	} else if req.VaccineName == "" {
+		return apperrors.WrapInvalidInput("vaccine name is required when vaccine master ID is not specified")
+	}
+	if len(req.VaccineName) > 100 {
+		return apperrors.WrapInvalidInput("vaccine name must be less than 100 characters")
+	}
+	if len(req.LotNumber) > 50 {
+		return apperrors.WrapInvalidInput("lot number must be less than 50 characters")
+	}
+	if req.LotID != "" {
+		if req.VaccineMasterID == "" {
+			return apperrors.WrapInvalidInput("vaccine master ID is required when lot ID is specified")
+		}
+		if _, err := uuid.Parse(req.LotID); err != nil {
+			return apperrors.WrapInvalidInput("invalid lot ID format")
+		}
+	}
+	return validateVaccinationDates(req.VaccinationDate, req.NextDate)
+}
+
+// ValidateUpdateVaccination validates the update vaccination request
+func ValidateUpdateVaccination(req *model.UpdateVaccinationRequest) error {
+	if req.DoctorID != nil && *req.DoctorID != "" {
+		if _, err := uuid.Parse(*req.DoctorID); err != nil {
+			return apperrors.WrapInvalidInput("invalid doctor ID format")
+		}
+	}
+	if req.VaccineName != nil && (*req.VaccineName == "" || len(*req.VaccineName) > 100) {
+		return apperrors.WrapInvalidInput("vaccine name must be between 1 and 100 characters")
+	}
+	if req.LotNumber != nil && len(*req.LotNumber) > 50 {
+		return apperrors.WrapInvalidInput("lot number must be less than 50 characters")
+	}
+	if req.VaccinationDate != nil {
+		if _, err := time.Parse("2006-01-02", *req.VaccinationDate); err != nil {
+			return apperrors.WrapInvalidInput("invalid vaccination date format (expected YYYY-MM-DD)")
+		}
+	}
+	if req.NextDate != nil && *req.NextDate != "" {
+		if _, err := time.Parse("2006-01-02", *req.NextDate); err != nil {
+			return apperrors.WrapInvalidInput("invalid next date format (expected YYYY-MM-DD)")
+		}
+	}
+	return nil
+}
+
+func validateVaccinationDates(vaccinationDate, nextDate string) error {
+	vaccinated, err := time.Parse("2006-01-02", vaccinationDate)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid vaccination date format (expected YYYY-MM-DD)")
+	}
+	if nextDate == "" {
+		return nil
+	}
+	next, err := time.Parse("2006-01-02", nextDate)
+	if err != nil {
+		return apperrors.WrapInvalidInput("invalid next date format (expected YYYY-MM-DD)")
+	}
+	if !next.After(vaccinated) {
+		return apperrors.WrapInvalidInput("next date must be after vaccination date")
+	}
+	return nil
+}
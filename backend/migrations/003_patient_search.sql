@@ -0,0 +1,16 @@
+-- 患者検索（飼い主・ペットの横断検索）
+-- search_text / phone_digits はアプリケーションが表記ゆれをそろえて保存する
+-- （既存の行はAPIの起動時に補完される）
+
+-- 部分一致検索用のトライグラム拡張
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- 検索用カラム追加（モデルと同期）
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS phone_digits VARCHAR(20) NOT NULL DEFAULT '';
+ALTER TABLE pets ADD COLUMN IF NOT EXISTS search_text TEXT NOT NULL DEFAULT '';
+
+-- 部分一致（LIKE '%...%'）用のインデックス
+CREATE INDEX IF NOT EXISTS idx_owners_search_text ON owners USING gin (search_text gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_owners_phone_digits ON owners USING gin (phone_digits gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_pets_search_text ON pets USING gin (search_text gin_trgm_ops);
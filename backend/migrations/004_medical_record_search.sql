@@ -0,0 +1,9 @@
+-- カルテ全文検索（SOAP・診断・処方）
+-- search_bigrams はアプリケーションが表記ゆれをそろえた2文字単位の語（バイグラム）で作る
+-- （既存の行はAPIの起動時に補完される）
+
+-- 検索用カラム追加（モデルと同期）
+ALTER TABLE medical_records ADD COLUMN IF NOT EXISTS search_bigrams TSVECTOR;
+
+-- 全文検索（search_bigrams @@ tsquery）用のインデックス
+CREATE INDEX IF NOT EXISTS idx_mr_search_bigrams ON medical_records USING gin (search_bigrams);
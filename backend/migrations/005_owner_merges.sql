@@ -0,0 +1,22 @@
+-- 飼い主の統合記録
+-- 統合元の飼い主は削除されるため、統合時点の登録内容と移した件数を残す
+
+CREATE TABLE IF NOT EXISTS owner_merges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    target_owner_id UUID NOT NULL,
+    source_owner_id UUID NOT NULL,
+    source_owner_number INTEGER NOT NULL,
+    source_name VARCHAR(100) NOT NULL,
+    source_name_kana VARCHAR(100),
+    source_phone VARCHAR(20),
+    source_email VARCHAR(255),
+    source_address TEXT,
+    source_notes TEXT,
+    moved_rows JSONB,
+    reason TEXT,
+    merged_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_owner_merge_target ON owner_merges(target_owner_id);
+CREATE INDEX IF NOT EXISTS idx_owner_merge_source ON owner_merges(source_owner_id);
@@ -0,0 +1,22 @@
+-- ペットの飼い主の履歴
+-- 譲渡のたびに前の飼い主の期間を終了し、新しい飼い主の期間を追加する（ended_atがNULLの行が現在の飼い主）
+
+CREATE TABLE IF NOT EXISTS pet_ownerships (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    pet_id UUID NOT NULL,
+    owner_id UUID NOT NULL,
+    started_at DATE NOT NULL,
+    ended_at DATE,
+    reason TEXT,
+    transferred_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_pet_ownership_pet ON pet_ownerships(pet_id);
+CREATE INDEX IF NOT EXISTS idx_pet_ownership_owner ON pet_ownerships(owner_id);
+
+-- 既存のペットは登録日からの現在の飼い主の期間とする
+INSERT INTO pet_ownerships (pet_id, owner_id, started_at)
+SELECT p.id, p.owner_id, CAST(p.created_at AS DATE) FROM pets p
+WHERE NOT EXISTS (SELECT 1 FROM pet_ownerships o WHERE o.pet_id = p.id);
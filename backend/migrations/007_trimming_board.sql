@@ -0,0 +1,31 @@
+-- トリミングボード
+-- コース・オプションはマスタの名称・価格・所要時間を写して持ち、トリマーごとの空き時間を所要時間から計算する
+-- master_items / trimmings / accountings はAPIの起動時（AutoMigrate）に作られるため、
+-- テーブルがまだない初回起動時は何もしない（カラム・インデックスはAutoMigrateが作る）
+
+DO $$
+BEGIN
+    IF to_regclass('public.master_items') IS NOT NULL THEN
+        ALTER TABLE master_items ADD COLUMN IF NOT EXISTS duration_minutes INTEGER;
+    END IF;
+
+    IF to_regclass('public.trimmings') IS NOT NULL THEN
+        ALTER TABLE trimmings ADD COLUMN IF NOT EXISTS duration_minutes INTEGER NOT NULL DEFAULT 0;
+        ALTER TABLE trimmings ADD COLUMN IF NOT EXISTS course_id UUID;
+        ALTER TABLE trimmings ADD COLUMN IF NOT EXISTS course_price DECIMAL(10,2);
+        ALTER TABLE trimmings ADD COLUMN IF NOT EXISTS started_at TIMESTAMP WITH TIME ZONE;
+        ALTER TABLE trimmings ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+        CREATE INDEX IF NOT EXISTS idx_trimming_staff_time ON trimmings(staff_id, appointment_date);
+
+        -- オプションはマスタを写した要素の配列とする（それ以外の形式は読めないため空にする）
+        UPDATE trimmings SET options = '[]'
+        WHERE options IS NULL
+           OR json_typeof(options) <> 'array'
+           OR EXISTS (SELECT 1 FROM json_array_elements(options) e WHERE json_typeof(e) <> 'object');
+    END IF;
+
+    IF to_regclass('public.accountings') IS NOT NULL THEN
+        ALTER TABLE accountings ADD COLUMN IF NOT EXISTS trimming_id UUID;
+    END IF;
+END $$;
@@ -0,0 +1,35 @@
+-- 検査結果
+-- 検査項目ごとの結果を動物種別の基準範囲で判定し、項目ごとの推移を検査日順に引けるようにする
+
+-- 基準範囲（species が空なら動物種を問わない既定の範囲）
+CREATE TABLE IF NOT EXISTS lab_reference_ranges (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    analyte VARCHAR(30) NOT NULL,
+    species VARCHAR(50) NOT NULL DEFAULT '',
+    name VARCHAR(100),
+    unit VARCHAR(30),
+    low DOUBLE PRECISION,
+    high DOUBLE PRECISION,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_lab_reference_range ON lab_reference_ranges(analyte, species);
+
+-- examinations はAPIの起動時（AutoMigrate）に作られるため、テーブルがまだない初回起動時は何もしない
+-- （カラム・インデックスはAutoMigrateが作る）
+DO $$
+BEGIN
+    IF to_regclass('public.examinations') IS NOT NULL THEN
+        ALTER TABLE examinations ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP WITH TIME ZONE;
+
+        CREATE INDEX IF NOT EXISTS idx_examination_pet_date ON examinations(pet_id, examination_date);
+        CREATE INDEX IF NOT EXISTS idx_examination_medical_record ON examinations(medical_record_id);
+
+        -- 検査項目は項目コードを持つ要素の配列とする（それ以外の形式は読めないため空にする）
+        UPDATE examinations SET items = '[]'
+        WHERE items IS NULL
+           OR json_typeof(items) <> 'array'
+           OR EXISTS (SELECT 1 FROM json_array_elements(items) e WHERE json_typeof(e) <> 'object');
+    END IF;
+END $$;
@@ -0,0 +1,45 @@
+-- 検査装置の結果取り込み
+-- 検査に検体番号を連番で割り当て、装置から受信した結果は照合の成否とともに記録する
+
+-- 検体番号のシーケンス作成（100001から開始）
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_sequences WHERE schemaname = 'public' AND sequencename = 'examination_accession_no_seq') THEN
+        CREATE SEQUENCE examination_accession_no_seq START WITH 100001;
+    END IF;
+END $$;
+
+-- examinations はAPIの起動時（AutoMigrate）に作られるため、テーブルがまだない初回起動時は何もしない
+-- （テーブル・インデックスはAutoMigrateが作り、列の既定値はAPIの起動時にシーケンスへそろえる）
+DO $$
+BEGIN
+    IF to_regclass('public.examinations') IS NOT NULL THEN
+        ALTER TABLE examinations ADD COLUMN IF NOT EXISTS accession_no INTEGER;
+        UPDATE examinations SET accession_no = nextval('examination_accession_no_seq') WHERE accession_no IS NULL;
+        ALTER TABLE examinations ALTER COLUMN accession_no SET NOT NULL;
+        ALTER TABLE examinations ALTER COLUMN accession_no SET DEFAULT nextval('examination_accession_no_seq');
+        CREATE UNIQUE INDEX IF NOT EXISTS idx_examination_accession_no ON examinations(accession_no);
+
+        -- 受信した結果（未照合は手動で検査へ割り当てるか破棄する）
+        CREATE TABLE IF NOT EXISTS analyzer_imports (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            format VARCHAR(10) NOT NULL,
+            source VARCHAR(255),
+            machine VARCHAR(100),
+            accession_no VARCHAR(50),
+            pet_number VARCHAR(50),
+            observed_at TIMESTAMP WITH TIME ZONE,
+            results JSON,
+            raw_message TEXT,
+            status VARCHAR(10) NOT NULL,
+            reason TEXT,
+            examination_id UUID REFERENCES examinations(id),
+            resolved_by UUID,
+            resolved_at TIMESTAMP WITH TIME ZONE,
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE INDEX IF NOT EXISTS idx_analyzer_import_status ON analyzer_imports(status);
+    END IF;
+END $$;
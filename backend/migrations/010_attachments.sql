@@ -0,0 +1,37 @@
+-- カルテ・検査の添付ファイル
+-- ファイル本体は添付ファイルの保存先（ローカルまたはS3互換ストレージ）に置き、保存先のキーとDICOMの属性を記録する
+
+-- examinations はAPIの起動時（AutoMigrate）に作られるため、テーブルがまだない初回起動時は何もしない
+-- （テーブル・インデックスはAutoMigrateが作る）
+DO $$
+BEGIN
+    IF to_regclass('public.examinations') IS NOT NULL THEN
+        CREATE TABLE IF NOT EXISTS attachments (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            pet_id UUID NOT NULL REFERENCES pets(id),
+            medical_record_id UUID REFERENCES medical_records(id),
+            examination_id UUID REFERENCES examinations(id),
+            file_name VARCHAR(255) NOT NULL,
+            content_type VARCHAR(100) NOT NULL,
+            size BIGINT NOT NULL,
+            checksum VARCHAR(64),
+            storage_key VARCHAR(255) NOT NULL,
+            has_thumbnail BOOLEAN NOT NULL DEFAULT FALSE,
+            description TEXT,
+            modality VARCHAR(16),
+            study_date DATE,
+            dicom_patient_id VARCHAR(64),
+            dicom_patient_name VARCHAR(255),
+            study_description VARCHAR(255),
+            body_part VARCHAR(64),
+            study_instance_uid VARCHAR(64),
+            uploaded_by UUID,
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+            updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE INDEX IF NOT EXISTS idx_attachment_pet ON attachments(pet_id);
+        CREATE INDEX IF NOT EXISTS idx_attachment_medical_record ON attachments(medical_record_id);
+        CREATE INDEX IF NOT EXISTS idx_attachment_examination ON attachments(examination_id);
+    END IF;
+END $$;
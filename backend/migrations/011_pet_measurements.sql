@@ -0,0 +1,26 @@
+-- ペットの体重・バイタルの測定値（ペットごとの時系列）
+-- ペットの登録・更新時の体重、入院中のバイタル記録、受付での測定から記録する
+
+-- vitals はAPIの起動時（AutoMigrate）に作られるため、テーブルがまだない初回起動時は何もしない
+-- （テーブル・インデックスはAutoMigrateが作る）
+DO $$
+BEGIN
+    IF to_regclass('public.vitals') IS NOT NULL THEN
+        CREATE TABLE IF NOT EXISTS pet_measurements (
+            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+            pet_id UUID NOT NULL REFERENCES pets(id),
+            type VARCHAR(30) NOT NULL,
+            value DECIMAL(6,2) NOT NULL,
+            unit VARCHAR(10),
+            measured_at TIMESTAMP WITH TIME ZONE NOT NULL,
+            source VARCHAR(10) NOT NULL,
+            vital_id UUID REFERENCES vitals(id),
+            staff_id UUID,
+            notes TEXT,
+            created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+        );
+
+        CREATE INDEX IF NOT EXISTS idx_pet_measurement_series ON pet_measurements(pet_id, type, measured_at);
+        CREATE INDEX IF NOT EXISTS idx_pet_measurement_vital ON pet_measurements(vital_id);
+    END IF;
+END $$;
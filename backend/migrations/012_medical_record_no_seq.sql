@@ -0,0 +1,4 @@
+-- カルテ番号の採番
+-- 同じ秒に受付したカルテの番号が重複しないよう、シーケンスから MR + 8桁の連番を採番する
+-- （既存のタイムスタンプによる番号 MR + 10桁とは桁数が異なるため重複しない）
+CREATE SEQUENCE IF NOT EXISTS medical_record_no_seq;
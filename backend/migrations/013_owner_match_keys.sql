@@ -0,0 +1,14 @@
+-- 飼い主の重複候補の照合用カラム
+-- 氏名・フリガナ・メールアドレス・住所の表記ゆれをそろえた値をアプリケーションが保存する
+-- （既存の行はAPIの起動時に補完される）。等値結合で組を作れるよう、電話番号も含めてB-treeインデックスを張る。
+
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS name_key VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS name_kana_key VARCHAR(100) NOT NULL DEFAULT '';
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS email_key VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE owners ADD COLUMN IF NOT EXISTS address_key TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_owners_name_key ON owners(name_key);
+CREATE INDEX IF NOT EXISTS idx_owners_name_kana_key ON owners(name_kana_key);
+CREATE INDEX IF NOT EXISTS idx_owners_phone_key ON owners(phone_digits);
+CREATE INDEX IF NOT EXISTS idx_owners_email_key ON owners(email_key);
+CREATE INDEX IF NOT EXISTS idx_owners_address_key ON owners(address_key);
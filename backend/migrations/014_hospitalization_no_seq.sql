@@ -0,0 +1,5 @@
+-- 入院番号の採番
+-- 同じ秒に受け付けた入院の番号が重複しないよう、シーケンスから HP + 8桁の連番を採番する
+-- （既存のタイムスタンプによる番号 HP + 10桁とは桁数が異なるため重複しない）
+-- 一意インデックスは重複した既存の番号を採番し直してから、APIの起動時に作成する
+CREATE SEQUENCE IF NOT EXISTS hospitalization_no_seq;